@@ -0,0 +1,42 @@
+package zkgenomics
+
+import (
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// Advisory re-exports the circuit advisory record for convenience
+type Advisory = proofs.Advisory
+
+// AdvisoryList re-exports the published advisory set for convenience
+type AdvisoryList = proofs.AdvisoryList
+
+// AdvisoryError re-exports the error reported for proofs from flagged circuits
+type AdvisoryError = proofs.AdvisoryError
+
+// UpdateAdvisories fetches a signed advisory list from an http(s) URL or local
+// path, checks it against the trusted keys file and adds it to the advisories
+// bundled with this release
+func (pg *ProofGenerator) UpdateAdvisories(source string, keysPath string) error {
+	keys, err := proofs.LoadAdvisoryKeys(keysPath)
+	if err != nil {
+		return err
+	}
+	fetched, err := proofs.FetchAdvisories(source, keys)
+	if err != nil {
+		return err
+	}
+	current, err := pg.advisories()
+	if err != nil {
+		return err
+	}
+	pg.Advisories = current.Merge(fetched)
+	return nil
+}
+
+// advisories returns the advisory list verification is checked against
+func (pg *ProofGenerator) advisories() (*AdvisoryList, error) {
+	if pg.Advisories != nil {
+		return pg.Advisories, nil
+	}
+	return proofs.BundledAdvisories()
+}
@@ -0,0 +1,154 @@
+package zkgenomics
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProofGenerator_VerifyProof_Advisory(t *testing.T) {
+	dir := t.TempDir()
+	vcfPath := filepath.Join(dir, "test.vcf")
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"16\t48258198\trs17822931\tC\tT\t60\tPASS\t.\tGT\t1/1\n"
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatalf("writing test VCF: %v", err)
+	}
+	proofPath := filepath.Join(dir, "proof.json")
+
+	pg := NewProofGenerator()
+	pg.InsecureBundledKeys = true
+	proofData, err := pg.GenerateProof(ABCC11ProofType, vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("GenerateProof should not return error: %v", err)
+	}
+	encoded, err := json.Marshal(proofData)
+	if err != nil {
+		t.Fatalf("encoding proof: %v", err)
+	}
+	if err := os.WriteFile(proofPath, encoded, 0644); err != nil {
+		t.Fatalf("writing proof: %v", err)
+	}
+	pg.Advisories = &AdvisoryList{Advisories: []Advisory{
+		{ID: "TEST-1", CircuitID: proofData.CircuitID, Versions: []int{proofData.CircuitVersion}, Summary: "test"},
+	}}
+
+	// The proof is sound, so only the advisory can fail it
+	result, err := pg.VerifyProof(ABCC11ProofType, "", proofPath)
+	if err != nil {
+		t.Fatalf("VerifyProof should not return error: %v", err)
+	}
+	var advisoryErr *AdvisoryError
+	if result.Result != ProofFail || !errors.As(result.Error, &advisoryErr) {
+		t.Fatalf("Expected ProofFail with AdvisoryError, got %s: %v", result.Result.String(), result.Error)
+	}
+	if advisoryErr.Advisory.ID != "TEST-1" {
+		t.Errorf("Expected advisory TEST-1, got %s", advisoryErr.Advisory.ID)
+	}
+
+	pg.IgnoreAdvisories = []string{"TEST-1"}
+	result, err = pg.VerifyProof(ABCC11ProofType, "", proofPath)
+	if err != nil {
+		t.Fatalf("VerifyProof should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess with the advisory overridden, got %s: %v", result.Result.String(), result.Error)
+	}
+}
+
+func TestProofGenerator_VerifyProofData_ChromosomeV1Advisory(t *testing.T) {
+	vcfPath := filepath.Join(t.TempDir(), "test.vcf")
+	vcf := "##fileformat=VCFv4.2\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\n" +
+		"22\t16050075\t.\tA\tG\t60\tPASS\t.\n"
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatalf("writing test VCF: %v", err)
+	}
+
+	pg := NewProofGenerator()
+	pg.InsecureBundledKeys = true
+	proofData, err := pg.GenerateProof(ChromosomeProofType, vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("GenerateProof should not return error: %v", err)
+	}
+
+	// The bundled advisories block v1, whose proofs nothing ever verified
+	proofData.CircuitVersion = 1
+	result, err := pg.VerifyProofData(ChromosomeProofType, proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	var advisoryErr *AdvisoryError
+	if result.Result != ProofFail || !errors.As(result.Error, &advisoryErr) {
+		t.Fatalf("Expected ProofFail with AdvisoryError, got %s: %v", result.Result.String(), result.Error)
+	}
+	if advisoryErr.Advisory.ID != "ZKG-ADV-0005" {
+		t.Errorf("Expected advisory ZKG-ADV-0005, got %s", advisoryErr.Advisory.ID)
+	}
+}
+
+func TestProofGenerator_VerifyProofData_RegisteredCircuit(t *testing.T) {
+	vcfPath := filepath.Join(t.TempDir(), "test.vcf")
+	vcf := "##fileformat=VCFv4.2\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\n" +
+		"22\t16050075\t.\tA\tG\t60\tPASS\t.\n"
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatalf("writing test VCF: %v", err)
+	}
+
+	proofData, err := NewProofGenerator(WithInsecureBundledKeys()).GenerateProof(ChromosomeProofType, vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("GenerateProof should not return error: %v", err)
+	}
+
+	// A key trusted as a v1 key stays flagged when its proofs claim v2,
+	// which has the same public inputs
+	registry := NewVerifyingKeyRegistry()
+	if err := registry.RegisterCircuit(ChromosomeProofType, proofData.VerifyingKey, CircuitKey{ID: "chromosome", Version: 1}); err != nil {
+		t.Fatalf("RegisterCircuit should not return error: %v", err)
+	}
+	pg := NewProofGenerator(WithVerifyingKeyRegistry(registry))
+	result, err := pg.VerifyProofData(ChromosomeProofType, proofData)
+	var mismatch *KeyCircuitMismatchError
+	if err != nil || result.Result != ProofFail || !errors.As(result.Error, &mismatch) {
+		t.Fatalf("Expected ProofFail with KeyCircuitMismatchError, got %v: %v", result, err)
+	}
+	relabelled := *proofData
+	relabelled.CircuitVersion = 1
+	trust, err := pg.VerifyTrust(ChromosomeProofType, &relabelled, pg.trustPolicy())
+	var advisoryErr *AdvisoryError
+	if err != nil || trust.Result != ProofFail || !errors.As(trust.Error, &advisoryErr) || advisoryErr.Advisory.ID != "ZKG-ADV-0005" {
+		t.Errorf("Expected advisory ZKG-ADV-0005 for the v1 key, got %v: %v", trust, err)
+	}
+
+	// Registered with its own circuit the key is trusted, unless an
+	// advisory lists its fingerprint
+	registry = NewVerifyingKeyRegistry()
+	circuit := CircuitKey{ID: proofData.CircuitID, Version: proofData.CircuitVersion, Hash: proofData.CircuitHash}
+	if err := registry.RegisterCircuit(ChromosomeProofType, proofData.VerifyingKey, circuit); err != nil {
+		t.Fatalf("RegisterCircuit should not return error: %v", err)
+	}
+	pg = NewProofGenerator(WithVerifyingKeyRegistry(registry))
+	result, err = pg.VerifyProofData(ChromosomeProofType, proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected ProofSuccess under the registered circuit, got %v: %v", result, err)
+	}
+	fingerprint, err := VKFingerprint(proofData.VerifyingKey)
+	if err != nil {
+		t.Fatalf("VKFingerprint should not return error: %v", err)
+	}
+	pg.Advisories = &AdvisoryList{Advisories: []Advisory{
+		{ID: "TEST-KEY", VKFingerprints: []string{fingerprint}, Summary: "compromised setup"},
+	}}
+	result, err = pg.VerifyProofData(ChromosomeProofType, proofData)
+	if err != nil || result.Result != ProofFail || !errors.As(result.Error, &advisoryErr) {
+		t.Fatalf("Expected ProofFail with AdvisoryError, got %v: %v", result, err)
+	}
+	if advisoryErr.Advisory.ID != "TEST-KEY" || advisoryErr.VKFingerprint != fingerprint {
+		t.Errorf("Expected advisory TEST-KEY for key %s, got %s for %q", fingerprint, advisoryErr.Advisory.ID, advisoryErr.VKFingerprint)
+	}
+}
@@ -0,0 +1,28 @@
+package zkgenomics
+
+import (
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// ArchiveBundle re-exports the long-term archival proof format for convenience
+type ArchiveBundle = proofs.ArchiveBundle
+
+// ArchiveProof wraps a generated proof in a self-describing archival bundle
+func (pg *ProofGenerator) ArchiveProof(proofType ProofType, proofData *ProofData) (*ArchiveBundle, error) {
+	if _, err := pg.newProof(proofType); err != nil {
+		return nil, err
+	}
+	return proofs.NewArchiveBundle(string(proofType), proofData)
+}
+
+// VerifyArchive verifies an archival bundle fully offline: the embedded proof
+// is checked against the embedded verifying key, which must be registered or
+// pinned for the proof type, then trusted against the generator's advisories,
+// which default to the ones bundled with this release.
+func (pg *ProofGenerator) VerifyArchive(bundle *ArchiveBundle) (*VerificationResult, error) {
+	result, err := proofs.VerifyArchiveBundle(bundle)
+	if err != nil || result.Result != ProofSuccess {
+		return result, err
+	}
+	return pg.VerifyTrust(ProofType(bundle.ProofType), bundle.Proof, pg.trustPolicy())
+}
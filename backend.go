@@ -0,0 +1,83 @@
+package zkgenomics
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/kzg"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// Backend re-exports the interface of the proving systems proofs are made with
+type Backend = proofs.Backend
+
+// PlonkBackend re-exports the PLONK backend proving under a given SRS
+type PlonkBackend = proofs.PlonkBackend
+
+// ExternalProver re-exports the interface of provers proving in place of the
+// backend, such as GPU provers or remote proving services
+type ExternalProver = proofs.Prover
+
+// RemoteProver re-exports the Prover delegating to an HTTP proving service
+type RemoteProver = proofs.RemoteProver
+
+// Curve identifies the elliptic curve proofs are made over
+type Curve = ecc.ID
+
+// Curves proofs can be made over
+const (
+	// BN254 is the default curve
+	BN254 = ecc.BN254
+	// BLS12_381 has a higher security margin than BN254, at a higher cost
+	BLS12_381 = ecc.BLS12_381
+	// BLS12_377 proofs can be verified inside BW6-761 circuits, for recursion
+	BLS12_377 = ecc.BLS12_377
+	// BW6_761 is the outer curve of recursion over BLS12-377
+	BW6_761 = ecc.BW6_761
+)
+
+var (
+	// Groth16 is the default backend: the smallest proofs, but a trusted
+	// setup per circuit
+	Groth16 = proofs.Groth16
+	// PLONK is the PLONK backend with a locally generated SRS; use a
+	// PlonkBackend with a ceremony's SRS for proofs shared with others
+	PLONK = proofs.PLONK
+)
+
+// BackendNamed returns the backend of the given name, "groth16" or "plonk",
+// over the curve of the given name, such as "bls12_381"; BN254 if empty
+func BackendNamed(name string, curve string) (Backend, error) {
+	return proofs.BackendNamed(name, curve)
+}
+
+// CurveNamed returns the curve of the given name, such as "bls12_381"
+func CurveNamed(name string) (Curve, error) {
+	return proofs.CurveNamed(name)
+}
+
+// ReadKZGSRS reads the KZG SRS over curve of a universal setup ceremony, for
+// a PlonkBackend
+func ReadKZGSRS(curve Curve, path string) (kzg.SRS, error) {
+	return proofs.ReadKZGSRS(curve, path)
+}
+
+// backend returns the backend proofs are generated with: the generator's
+// backend, Groth16 if unset, over the generator's curve if one is set
+func (pg *ProofGenerator) backend() (Backend, error) {
+	backend := pg.Backend
+	if backend == nil {
+		backend = Groth16
+	}
+	if pg.Curve == ecc.UNKNOWN || pg.Curve == backend.Curve() {
+		return backend, nil
+	}
+
+	if backend.Name() == proofs.BackendGroth16 {
+		return proofs.NewGroth16(pg.Curve), nil
+	}
+	if plonk, ok := backend.(*PlonkBackend); ok {
+		return &PlonkBackend{CurveID: pg.Curve, SRS: plonk.SRS}, nil
+	}
+	return nil, fmt.Errorf("%s backend over %s cannot prove over %s", backend.Name(), backend.Curve(), pg.Curve)
+}
@@ -0,0 +1,188 @@
+package zkgenomics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"gopkg.in/yaml.v3"
+)
+
+// BatchResult is the outcome of one request of a batch. Response is nil only
+// if the request never started or named an unsupported proof type.
+type BatchResult struct {
+	Request  ProofRequest
+	Response *ProofResponse
+	Err      error
+}
+
+// GenerateBatch generates the requested proofs with up to workers proofs in
+// flight at once; workers <= 0 uses one per CPU. Results are returned in
+// request order. Once ctx is done, requests that have not started fail with
+// ctx.Err().
+//
+// A ProofGenerator is safe for concurrent use by GenerateBatch and by
+// Generate from several goroutines, as long as its fields are not changed
+// and UpdateAdvisories is not called meanwhile. Progress updates are
+// serialized and their messages prefixed with the request index; the Logger
+// must itself be safe for concurrent use, as NewWriterLogger is.
+func (pg *ProofGenerator) GenerateBatch(ctx context.Context, requests []ProofRequest, workers int) []BatchResult {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	var progressMu sync.Mutex
+	results := make([]BatchResult, len(requests))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(requests)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				worker := *pg
+				if pg.Progress != nil {
+					worker.Progress = func(stage string, percent float64, message string) {
+						progressMu.Lock()
+						defer progressMu.Unlock()
+						pg.Progress(stage, percent, fmt.Sprintf("request %d: %s", i, message))
+					}
+				}
+				response, err := worker.Generate(ctx, requests[i])
+				results[i] = BatchResult{Request: requests[i], Response: response, Err: err}
+			}
+		}()
+	}
+
+	for i := range requests {
+		if ctx.Err() != nil {
+			results[i] = BatchResult{Request: requests[i], Err: ctx.Err()}
+			continue
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			results[i] = BatchResult{Request: requests[i], Err: ctx.Err()}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// BatchManifest lists the proofs of a batch, such as one per patient of a
+// lab, as read by LoadBatchManifest
+type BatchManifest struct {
+	// Workers is how many proofs are generated at once; zero uses one per CPU
+	Workers int        `yaml:"workers,omitempty" json:"workers,omitempty"`
+	Jobs    []BatchJob `yaml:"jobs" json:"jobs"`
+}
+
+// BatchJob is one proof of a batch manifest
+type BatchJob struct {
+	// ID names the job in reports; LoadBatchManifest numbers jobs without
+	// one from 1
+	ID        string    `yaml:"id,omitempty" json:"id,omitempty"`
+	ProofType ProofType `yaml:"proof_type,omitempty" json:"proof_type,omitempty"`
+	VCF       string    `yaml:"vcf" json:"vcf"`
+	Sample    string    `yaml:"sample,omitempty" json:"sample,omitempty"`
+	// Params, if set, configures the proof like a claim file, such as the
+	// variant of a dynamic proof. Its proof type defaults to ProofType.
+	Params *ClaimSpec `yaml:"params,omitempty" json:"params,omitempty"`
+	// Output is the file to write the proof to; <id>_<proof-type>_proof.json
+	// next to the manifest if empty
+	Output string `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// LoadBatchManifest reads a batch manifest from the JSON or YAML file at
+// path and validates it. Relative paths of genomes and outputs are resolved
+// against the manifest's directory.
+func LoadBatchManifest(path string) (*BatchManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest BatchManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("decoding batch manifest: %w", err)
+	}
+	if len(manifest.Jobs) == 0 {
+		return nil, fmt.Errorf("batch manifest %s lists no jobs", path)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) || proofs.IsRemote(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	ids := make(map[string]bool, len(manifest.Jobs))
+	outputs := make(map[string]string, len(manifest.Jobs))
+	for i := range manifest.Jobs {
+		job := &manifest.Jobs[i]
+		if job.ID == "" {
+			job.ID = fmt.Sprint(i + 1)
+		}
+		if job.Params != nil && job.Params.ProofType == "" {
+			job.Params.ProofType = job.ProofType
+		}
+		if job.ProofType == "" && job.Params != nil {
+			job.ProofType = job.Params.ProofType
+		}
+		switch {
+		case ids[job.ID]:
+			return nil, fmt.Errorf("batch manifest %s: job %s is listed twice", path, job.ID)
+		case job.ProofType == "":
+			return nil, fmt.Errorf("batch manifest %s: job %s has no proof_type", path, job.ID)
+		case job.VCF == "":
+			return nil, fmt.Errorf("batch manifest %s: job %s has no vcf", path, job.ID)
+		}
+		ids[job.ID] = true
+
+		job.VCF = resolve(job.VCF)
+		if job.Params != nil {
+			job.Params.ParentVCF = resolve(job.Params.ParentVCF)
+		}
+		if job.Output == "" {
+			job.Output = fmt.Sprintf("%s_%s_proof.json", job.ID, job.ProofType)
+		}
+		job.Output = resolve(job.Output)
+		if other, ok := outputs[job.Output]; ok {
+			return nil, fmt.Errorf("batch manifest %s: jobs %s and %s both write %s", path, other, job.ID, job.Output)
+		}
+		outputs[job.Output] = job.ID
+	}
+	return &manifest, nil
+}
+
+// Request returns the proof request of the job. A proof type naming an
+// rsID, such as rs12913832, requests an rsID proof of it.
+func (job *BatchJob) Request() ProofRequest {
+	req := ProofRequest{
+		ProofType:  job.ProofType,
+		Claim:      job.Params,
+		VCFPath:    job.VCF,
+		Sample:     job.Sample,
+		OutputPath: job.Output,
+		Metadata:   map[string]string{"job": job.ID},
+	}
+	if req.Claim == nil && IsRsID(string(job.ProofType)) {
+		req.Claim = &ClaimSpec{ProofType: RsIDProofType, RsID: string(job.ProofType)}
+	}
+	return req
+}
+
+// Requests returns the proof requests of the manifest's jobs, in order
+func (m *BatchManifest) Requests() []ProofRequest {
+	requests := make([]ProofRequest, len(m.Jobs))
+	for i := range m.Jobs {
+		requests[i] = m.Jobs[i].Request()
+	}
+	return requests
+}
@@ -0,0 +1,126 @@
+package zkgenomics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestProofGenerator_GenerateBatch(t *testing.T) {
+	vcfPath := filepath.Join(t.TempDir(), "test.vcf")
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t0/1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/0\n" +
+		"16\t48258198\trs17822931\tC\tT\t60\tPASS\t.\tGT\t1/1\n"
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatalf("writing test VCF: %v", err)
+	}
+
+	var mu sync.Mutex
+	var messages []string
+	pg := NewProofGenerator(WithInsecureBundledKeys(), WithProgress(func(stage string, percent float64, message string) {
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, message)
+	}))
+
+	requests := []ProofRequest{
+		{ProofType: ACTN3ProofType, VCFPath: vcfPath},
+		{ProofType: ALDH2ProofType, VCFPath: vcfPath},
+		{ProofType: ABCC11ProofType, VCFPath: vcfPath},
+		{ProofType: "unknown", VCFPath: vcfPath},
+	}
+	results := pg.GenerateBatch(context.Background(), requests, 3)
+	if len(results) != len(requests) {
+		t.Fatalf("Expected %d results, got %d", len(requests), len(results))
+	}
+	for i, result := range results[:3] {
+		if result.Request.ProofType != requests[i].ProofType {
+			t.Errorf("Result %d is for %s, expected %s", i, result.Request.ProofType, requests[i].ProofType)
+		}
+		if result.Err != nil || result.Response.ProofData.Result != ProofSuccess {
+			t.Fatalf("Expected %s to succeed, got %v", requests[i].ProofType, result.Err)
+		}
+		verified, err := pg.VerifyProofData(requests[i].ProofType, result.Response.ProofData)
+		if err != nil || verified.Result != ProofSuccess {
+			t.Errorf("Expected %s proof to verify, got %v: %v", requests[i].ProofType, verified, err)
+		}
+	}
+	var unsupported *UnsupportedProofTypeError
+	if !errors.As(results[3].Err, &unsupported) {
+		t.Errorf("Expected UnsupportedProofTypeError, got %v", results[3].Err)
+	}
+
+	for _, message := range messages {
+		if !strings.HasPrefix(message, "request ") {
+			t.Errorf("Expected progress messages to name their request, got %q", message)
+			break
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, result := range pg.GenerateBatch(ctx, requests[:2], 2) {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("Expected a cancelled batch to fail with context.Canceled, got %v", result.Err)
+		}
+	}
+}
+
+func TestLoadBatchManifest(t *testing.T) {
+	dir := t.TempDir()
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/0\n" +
+		"15\t28365618\trs12913832\tA\tG\t60\tPASS\t.\tGT\t0/1\n"
+	if err := os.WriteFile(filepath.Join(dir, "p1.vcf"), []byte(vcf), 0644); err != nil {
+		t.Fatalf("writing test VCF: %v", err)
+	}
+	manifestPath := filepath.Join(dir, "batch.yaml")
+	manifestFile := `workers: 2
+jobs:
+  - {id: p1-aldh2, proof_type: aldh2, vcf: p1.vcf}
+  - vcf: p1.vcf
+    output: out/p1_herc2.json
+    params: {proof_type: dynamic, chromosome: 15, position: 28365618, ref: A, alt: G, mode: carrier}
+`
+	if err := os.WriteFile(manifestPath, []byte(manifestFile), 0644); err != nil {
+		t.Fatalf("writing test manifest: %v", err)
+	}
+	manifest, err := LoadBatchManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("LoadBatchManifest should not return error: %v", err)
+	}
+	jobs := manifest.Jobs
+	if jobs[0].VCF != filepath.Join(dir, "p1.vcf") || jobs[0].Output != filepath.Join(dir, "p1-aldh2_aldh2_proof.json") {
+		t.Errorf("Expected paths relative to the manifest, got %s and %s", jobs[0].VCF, jobs[0].Output)
+	}
+	if jobs[1].ID != "2" || jobs[1].ProofType != DynamicProofType || jobs[1].Output != filepath.Join(dir, "out", "p1_herc2.json") {
+		t.Errorf("Expected the second job to be numbered and typed by its params, got %+v", jobs[1])
+	}
+
+	pg := NewProofGenerator(WithInsecureBundledKeys())
+	for i, result := range pg.GenerateBatch(context.Background(), manifest.Requests(), manifest.Workers) {
+		if result.Err != nil || result.Response.ProofData.Result != ProofSuccess {
+			t.Fatalf("Expected job %s to succeed, got %v", jobs[i].ID, result.Err)
+		}
+		if result.Response.Metadata["job"] != jobs[i].ID {
+			t.Errorf("Expected the response to name job %s, got %v", jobs[i].ID, result.Response.Metadata)
+		}
+	}
+
+	manifestFile += "  - {id: p1-aldh2, proof_type: actn3, vcf: p1.vcf}\n"
+	if err := os.WriteFile(manifestPath, []byte(manifestFile), 0644); err != nil {
+		t.Fatalf("writing test manifest: %v", err)
+	}
+	if _, err := LoadBatchManifest(manifestPath); err == nil {
+		t.Error("Expected a job ID listed twice to be refused")
+	}
+}
@@ -0,0 +1,55 @@
+package zkgenomics
+
+import (
+	"fmt"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// Phase1 re-exports the powers of tau phase of a trusted setup ceremony
+type Phase1 = proofs.Phase1
+
+// Phase2 re-exports the circuit-specific phase of a trusted setup ceremony
+type Phase2 = proofs.Phase2
+
+// CeremonyPower returns the power of tau a ceremony for proofs of proofType
+// needs. As for Setup, circuits sized from the genome need vcfPath.
+func (pg *ProofGenerator) CeremonyPower(proofType ProofType, vcfPath string) (int, error) {
+	circuit, err := pg.proofCircuit(proofType, vcfPath)
+	if err != nil {
+		return 0, err
+	}
+	return proofs.CeremonyPower(circuit, pg.Logger)
+}
+
+// StartCeremony starts the phase 2 of the circuit of proofs of proofType from
+// a finished phase1, returning the state participants contribute to
+func (pg *ProofGenerator) StartCeremony(proofType ProofType, phase1 *Phase1, vcfPath string) (*Phase2, CircuitKey, error) {
+	circuit, err := pg.proofCircuit(proofType, vcfPath)
+	if err != nil {
+		return nil, CircuitKey{}, err
+	}
+	return proofs.StartPhase2(circuit, phase1, pg.Logger)
+}
+
+// FinalizeCeremony verifies the phase 2 contributions for proofs of
+// proofType, from the state StartCeremony returned to the last contribution,
+// and stores the keys they yield in the generator's key store in place of a
+// local setup. Ceremonies yield Groth16 keys over BN254 only.
+func (pg *ProofGenerator) FinalizeCeremony(proofType ProofType, phase1 *Phase1, phase2 []*Phase2, vcfPath string) (CircuitKey, error) {
+	if pg.Keys == nil {
+		return CircuitKey{}, fmt.Errorf("no key store configured")
+	}
+	backend, err := pg.backend()
+	if err != nil {
+		return CircuitKey{}, err
+	}
+	if backend != Groth16 {
+		return CircuitKey{}, fmt.Errorf("ceremonies yield Groth16 keys over bn254, not %s keys over %s", backend.Name(), backend.Curve())
+	}
+	circuit, err := pg.proofCircuit(proofType, vcfPath)
+	if err != nil {
+		return CircuitKey{}, err
+	}
+	return proofs.FinalizeCeremony(circuit, phase1, phase2, pg.Keys, pg.Logger)
+}
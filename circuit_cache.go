@@ -0,0 +1,52 @@
+package zkgenomics
+
+import (
+	"fmt"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// CircuitCache re-exports the in-memory cache of compiled circuits and keys
+type CircuitCache = proofs.CircuitCache
+
+// CircuitCacheStats re-exports the lookup counts of a CircuitCache
+type CircuitCacheStats = proofs.CircuitCacheStats
+
+// NewCircuitCache creates a cache holding up to capacity circuits, or
+// proofs.DefaultCircuitCacheSize if capacity is not positive
+func NewCircuitCache(capacity int) *CircuitCache {
+	return proofs.NewCircuitCache(capacity)
+}
+
+// Warmup compiles the circuits of every supported proof type into the
+// generator's circuit cache and sets up their keys, taken from its key store
+// if it has one, so the first proofs of a service are as fast as later ones.
+// Proof types whose circuit depends on the genome, or on settings the
+// generator lacks, are skipped.
+func (pg *ProofGenerator) Warmup() error {
+	if pg.Cache == nil {
+		return fmt.Errorf("no circuit cache configured")
+	}
+	backend, err := pg.backend()
+	if err != nil {
+		return err
+	}
+	for _, proofType := range pg.GetSupportedProofTypes() {
+		proof, err := pg.newProof(proofType)
+		if err != nil {
+			return err
+		}
+		definer, ok := proof.(proofs.CircuitDefiner)
+		if !ok {
+			continue
+		}
+		circuit, err := definer.Circuit()
+		if err != nil {
+			continue
+		}
+		if _, err := pg.Cache.Warm(circuit, backend, pg.Keys, pg.Logger); err != nil {
+			return fmt.Errorf("warming %s circuit: %w", proofType, err)
+		}
+	}
+	return nil
+}
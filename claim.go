@@ -0,0 +1,226 @@
+package zkgenomics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"gopkg.in/yaml.v3"
+)
+
+// Simulation re-exports the proof simulation result for convenience
+type Simulation = proofs.Simulation
+
+// ClaimSpec describes a proof to generate or simulate. Which parameters apply
+// depends on the proof type: chromosome uses Chromosome, dynamic proofs use
+// Chromosome, Position, Ref, Alt and Mode, cohort proofs use Chromosome,
+// Position, Ref, Alt and MinCarrierPercent, negative, carrier and committed use Chromosome,
+// Position, Ref and Alt, rsid uses RsID and Mode, brca2 uses Variants as its
+// panel, burden uses Chromosome, Region, Variants, Threshold and AtLeast,
+// region_count uses Chromosome, Region and Threshold, phase uses the two
+// Variants, kinship uses ParentVCF, ParentSample, Variants as its panel,
+// MinLoci and MaxMismatches, aggregate uses Claims, and custom uses Trait and
+// Outcome.
+type ClaimSpec struct {
+	ProofType         ProofType        `yaml:"proof_type" json:"proof_type"`
+	Position          uint64           `yaml:"position,omitempty" json:"position,omitempty"`
+	Ref               string           `yaml:"ref,omitempty" json:"ref,omitempty"`
+	Alt               string           `yaml:"alt,omitempty" json:"alt,omitempty"`
+	RsID              string           `yaml:"rsid,omitempty" json:"rsid,omitempty"`
+	MinCarrierPercent int              `yaml:"min_carrier_percent,omitempty" json:"min_carrier_percent,omitempty"`
+	Chromosome        int              `yaml:"chromosome,omitempty" json:"chromosome,omitempty"`
+	Region            *TraitRegion     `yaml:"region,omitempty" json:"region,omitempty"`
+	Variants          []TraitVariant   `yaml:"variants,omitempty" json:"variants,omitempty"`
+	Threshold         int              `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+	AtLeast           bool             `yaml:"at_least,omitempty" json:"at_least,omitempty"`
+	ParentVCF         string           `yaml:"parent_vcf,omitempty" json:"parent_vcf,omitempty"`
+	ParentSample      string           `yaml:"parent_sample,omitempty" json:"parent_sample,omitempty"`
+	MinLoci           int              `yaml:"min_loci,omitempty" json:"min_loci,omitempty"`
+	MaxMismatches     int              `yaml:"max_mismatches,omitempty" json:"max_mismatches,omitempty"`
+	Claims            []AggregateClaim `yaml:"claims,omitempty" json:"claims,omitempty"`
+	Mode              ClaimMode        `yaml:"mode,omitempty" json:"mode,omitempty"`
+	Trait             *CustomTrait     `yaml:"trait,omitempty" json:"trait,omitempty"`
+	Outcome           string           `yaml:"outcome,omitempty" json:"outcome,omitempty"`
+}
+
+// LoadClaimSpec reads a YAML claim file
+func LoadClaimSpec(path string) (*ClaimSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec ClaimSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("decoding claim file: %w", err)
+	}
+	if spec.ProofType == "" {
+		return nil, fmt.Errorf("claim file %s does not set proof_type", path)
+	}
+	return &spec, nil
+}
+
+// newClaimProof returns the proof implementation configured by spec
+func (pg *ProofGenerator) newClaimProof(spec *ClaimSpec) (proofs.Proof, error) {
+	switch spec.ProofType {
+	case ChromosomeProofType:
+		return &proofs.ChromosomeProof{
+			ProofOptions:     pg.proofOptions(),
+			TargetChromosome: spec.Chromosome,
+			Slots:            pg.ChromosomeSlots,
+		}, nil
+	case DynamicProofType:
+		proof := proofs.NewDynamicProof(spec.Position, spec.Ref, spec.Alt)
+		proof.Chromosome = spec.Chromosome
+		proof.Mode = spec.Mode
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case NegativeProofType:
+		proof := proofs.NewNegativeProof(TraitVariant{
+			Chromosome: spec.Chromosome,
+			Position:   int(spec.Position),
+			Ref:        spec.Ref,
+			Alt:        spec.Alt,
+		})
+		proof.ProofOptions = pg.proofOptions()
+		proof.Coverage = pg.Coverage
+		return proof, nil
+	case CarrierProofType:
+		proof := proofs.NewCarrierProof(TraitVariant{
+			Chromosome: spec.Chromosome,
+			Position:   int(spec.Position),
+			Ref:        spec.Ref,
+			Alt:        spec.Alt,
+		})
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case CommittedProofType:
+		proof := proofs.NewCommittedVariantProof(TraitVariant{
+			Chromosome: spec.Chromosome,
+			Position:   int(spec.Position),
+			Ref:        spec.Ref,
+			Alt:        spec.Alt,
+		})
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case KinshipProofType:
+		proof := proofs.NewKinshipProof(spec.ParentVCF)
+		proof.ParentSample = spec.ParentSample
+		proof.ProofOptions = pg.proofOptions()
+		if len(spec.Variants) > 0 {
+			proof.Panel = spec.Variants
+		}
+		proof.MinLoci = spec.MinLoci
+		proof.MaxMismatches = spec.MaxMismatches
+		return proof, nil
+	case AggregateProofType:
+		proof := proofs.NewAggregateProof(spec.Claims)
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case RsIDProofType:
+		proof := proofs.NewRsIDProof(spec.RsID)
+		proof.Mode = spec.Mode
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case CohortProofType:
+		proof := proofs.NewCohortProof(spec.Position, spec.Ref, spec.Alt, spec.MinCarrierPercent)
+		proof.Chromosome = spec.Chromosome
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case BRCA2ProofType:
+		proof := proofs.NewBRCA2Proof()
+		proof.ProofOptions = pg.proofOptions()
+		if len(spec.Variants) > 0 {
+			proof.Panel = spec.Variants
+		}
+		return proof, nil
+	case RegionCountProofType:
+		proof := &proofs.RegionCountProof{
+			ProofOptions: pg.proofOptions(),
+			Chromosome:   spec.Chromosome,
+			Threshold:    spec.Threshold,
+		}
+		if spec.Region != nil {
+			proof.Region = *spec.Region
+		}
+		return proof, nil
+	case CustomProofType:
+		if spec.Trait == nil {
+			return nil, fmt.Errorf("custom claims need a trait")
+		}
+		proof := proofs.NewCustomTraitProof(*spec.Trait)
+		proof.Outcome = spec.Outcome
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case PhaseProofType:
+		if len(spec.Variants) != 2 {
+			return nil, fmt.Errorf("phase claims list exactly two variants, got %d", len(spec.Variants))
+		}
+		proof := proofs.NewPhaseProof(spec.Variants[0], spec.Variants[1])
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case BurdenProofType:
+		policy := proofs.DefaultBurdenPolicy()
+		if len(spec.Variants) > 0 {
+			policy = proofs.BurdenPolicy{
+				Chromosome: spec.Chromosome,
+				Variants:   spec.Variants,
+				Threshold:  spec.Threshold,
+				AtLeast:    spec.AtLeast,
+			}
+			if spec.Region != nil {
+				policy.Region = *spec.Region
+			}
+		}
+		proof := proofs.NewBurdenProof(policy)
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	default:
+		return pg.newProof(spec.ProofType)
+	}
+}
+
+// parameters returns the parameters a proof of spec records, or nil if its
+// proof type fixes the variant it reads
+func (spec *ClaimSpec) parameters() *ProofParameters {
+	switch spec.ProofType {
+	case DynamicProofType:
+		return &ProofParameters{Chromosome: spec.Chromosome, Position: spec.Position, Ref: spec.Ref, Alt: spec.Alt, Mode: spec.Mode.String()}
+	case CohortProofType:
+		return &ProofParameters{Chromosome: spec.Chromosome, Position: spec.Position, Ref: spec.Ref, Alt: spec.Alt}
+	case RsIDProofType:
+		return &ProofParameters{RsID: spec.RsID, Mode: spec.Mode.String()}
+	}
+	return nil
+}
+
+// Simulate performs extraction and claim evaluation for spec against the VCF
+// and returns exactly the public values a verifier would see if the proof
+// were generated. No setup or proving is run.
+func (pg *ProofGenerator) Simulate(spec *ClaimSpec, vcfPath string) (*Simulation, error) {
+	return pg.SimulateContext(context.Background(), spec, vcfPath)
+}
+
+// SimulateContext is Simulate with cancellation: it returns ctx.Err() as
+// soon as ctx is done
+func (pg *ProofGenerator) SimulateContext(ctx context.Context, spec *ClaimSpec, vcfPath string) (*Simulation, error) {
+	proof, err := pg.newClaimProof(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	defer pg.registerIdentities(vcfPath)()
+	return proofs.SimulateContext(ctx, proof, vcfPath)
+}
+
+// SimulateFromReader simulates spec like Simulate, reading the VCF from vcf
+func (pg *ProofGenerator) SimulateFromReader(spec *ClaimSpec, vcf io.Reader) (*Simulation, error) {
+	proof, err := pg.newClaimProof(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return proofs.SimulateFromReader(proof, vcf)
+}
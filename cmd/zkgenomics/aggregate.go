@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+func printAggregateUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics aggregate create [--keys dir] [--compress] [--threads n] <output> <proof-path>...")
+	fmt.Println("  zkgenomics aggregate verify [--trusted-keys file] [--insecure-bundled-key] <aggregate-path>")
+	fmt.Println()
+	fmt.Println("Aggregated proofs must be generated with --curve bls12_377 and the groth16 backend.")
+}
+
+func handleAggregate() {
+	if len(os.Args) < 3 {
+		printAggregateUsage()
+		os.Exit(1)
+	}
+
+	switch os.Args[2] {
+	case "create":
+		aggregateCreate(os.Args[3:])
+	case "verify":
+		aggregateVerify(os.Args[3:])
+	default:
+		fmt.Printf("Unknown aggregate command: %s\n", os.Args[2])
+		printAggregateUsage()
+		os.Exit(1)
+	}
+}
+
+func aggregateCreate(args []string) {
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	keys := fs.String("keys", "", "take the keys of the outer circuit from this directory, setting them up if absent")
+	compress := fs.Bool("compress", false, "store a new outer proving key with compressed points")
+	threads := addThreadsFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 2 {
+		fmt.Println("Error: aggregate create requires output and at least one proof-path")
+		printAggregateUsage()
+		os.Exit(1)
+	}
+
+	var members []*zkgenomics.ProofData
+	for _, path := range fs.Args()[1:] {
+		proofData, err := proofs.ReadProofData(path)
+		if err != nil {
+			log.Fatalf("Failed to read proof %s: %v", path, err)
+		}
+		members = append(members, proofData)
+	}
+
+	opts := []zkgenomics.Option{
+		zkgenomics.WithProgress(printProgress),
+		zkgenomics.WithLogger(stdoutLogger),
+		zkgenomics.WithThreads(*threads),
+	}
+	if *keys != "" {
+		opts = append(opts, zkgenomics.WithKeyStore(&zkgenomics.FileKeyStore{Dir: *keys, Compressed: *compress}))
+	}
+	generator := zkgenomics.NewProofGenerator(opts...)
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	fmt.Printf("Aggregating %d proofs...\n", len(members))
+	rp, err := generator.AggregateProofs(ctx, members)
+	exitIfCancelled(err)
+	if err != nil {
+		log.Fatalf("Failed to aggregate proofs: %v", err)
+	}
+
+	encoded, err := json.MarshalIndent(rp, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to serialize recursive proof: %v", err)
+	}
+	if err := os.WriteFile(fs.Arg(0), encoded, 0644); err != nil {
+		log.Fatalf("Failed to write recursive proof: %v", err)
+	}
+
+	fmt.Printf("✅ Aggregated %d proofs into: %s\n", len(members), fs.Arg(0))
+	fmt.Printf("Proof size: %d bytes\n", len(rp.Proof.Proof))
+	if fingerprint, err := rp.Proof.VKFingerprint(); err == nil {
+		fmt.Printf("Outer verifying key fingerprint: %s\n", fingerprint)
+	}
+}
+
+func aggregateVerify(args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	trust := addKeyTrustFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
+		fmt.Println("Error: aggregate verify requires aggregate-path")
+		printAggregateUsage()
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to read recursive proof: %v", err)
+	}
+	var rp zkgenomics.RecursiveProof
+	if err := json.Unmarshal(data, &rp); err != nil {
+		log.Fatalf("Failed to parse recursive proof: %v", err)
+	}
+
+	generator := zkgenomics.NewProofGenerator(zkgenomics.WithLogger(stdoutLogger))
+	if err := trust.apply(generator); err != nil {
+		log.Fatalf("Failed to load trusted keys: %v", err)
+	}
+
+	result, err := generator.VerifyRecursiveProof(&rp)
+	if err != nil {
+		log.Fatalf("Failed to verify recursive proof: %v", err)
+	}
+	if result.Result != zkgenomics.ProofSuccess {
+		fmt.Println("❌ Recursive proof verification failed!")
+		if result.Error != nil {
+			fmt.Printf("Error: %v\n", result.Error)
+		}
+		os.Exit(1)
+	}
+
+	for i, member := range rp.Members {
+		fmt.Printf("  %d: %s proof, circuit %s v%d\n", i, member.ProofType, member.CircuitID, member.CircuitVersion)
+	}
+	fmt.Println("Public values:")
+	for _, name := range slices.Sorted(maps.Keys(result.ParsedPublicInputs)) {
+		fmt.Printf("  %s = %s\n", name, result.ParsedPublicInputs[name])
+	}
+	fmt.Println("✅ Recursive proof verification succeeded!")
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+func printArchiveUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics archive create <proof-type> <proof-path> <archive-path>")
+	fmt.Println("  zkgenomics archive verify [--ignore-advisory ID] [--trusted-keys file] [--insecure-bundled-key] <archive-path>")
+}
+
+func handleArchive() {
+	if len(os.Args) < 3 {
+		printArchiveUsage()
+		os.Exit(1)
+	}
+
+	switch os.Args[2] {
+	case "create":
+		archiveCreate(os.Args[3:])
+	case "verify":
+		archiveVerify(os.Args[3:])
+	default:
+		fmt.Printf("Unknown archive command: %s\n", os.Args[2])
+		printArchiveUsage()
+		os.Exit(1)
+	}
+}
+
+func archiveCreate(args []string) {
+	if len(args) < 3 {
+		fmt.Println("Error: archive create requires proof-type, proof-path and archive-path")
+		printArchiveUsage()
+		os.Exit(1)
+	}
+
+	proofData, err := proofs.ReadProofData(args[1])
+	if err != nil {
+		log.Fatalf("Failed to read proof: %v", err)
+	}
+
+	generator := zkgenomics.NewProofGenerator()
+	bundle, err := generator.ArchiveProof(zkgenomics.ProofType(args[0]), proofData)
+	if err != nil {
+		log.Fatalf("Failed to archive proof: %v", err)
+	}
+	if err := proofs.WriteArchiveBundle(args[2], bundle); err != nil {
+		log.Fatalf("Failed to write archive: %v", err)
+	}
+
+	fmt.Printf("✅ Archived %s proof (circuit %s v%d) to: %s\n", args[0], bundle.CircuitID, bundle.CircuitVersion, args[2])
+}
+
+// archiveVerify verifies an archive using only its contents, the trusted keys
+// given and the advisories bundled with this binary; it never accesses the
+// network
+func archiveVerify(args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	var ignored stringList
+	fs.Var(&ignored, "ignore-advisory", "override the advisory with this ID (repeatable)")
+	trust := addKeyTrustFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
+		fmt.Println("Error: archive verify requires archive-path")
+		printArchiveUsage()
+		os.Exit(1)
+	}
+
+	bundle, err := proofs.ReadArchiveBundle(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to read archive: %v", err)
+	}
+
+	generator := zkgenomics.NewProofGenerator(zkgenomics.WithIgnoredAdvisories(ignored...))
+	if err := trust.apply(generator); err != nil {
+		log.Fatalf("Failed to load trusted keys: %v", err)
+	}
+
+	fmt.Printf("Archive format v%d, %s proof, circuit %s v%d (%s)\n",
+		bundle.FormatVersion, bundle.ProofType, bundle.CircuitID, bundle.CircuitVersion, bundle.CircuitHash)
+	for _, module := range slices.Sorted(maps.Keys(bundle.Software)) {
+		fmt.Printf("  %s %s\n", module, bundle.Software[module])
+	}
+
+	result, err := generator.VerifyArchive(bundle)
+	if err != nil {
+		log.Fatalf("Failed to verify archive: %v", err)
+	}
+
+	if result.Result != zkgenomics.ProofSuccess {
+		fmt.Println("❌ Archive verification failed!")
+		if result.Error != nil {
+			fmt.Printf("Error: %v\n", result.Error)
+		}
+		os.Exit(1)
+	}
+
+	fmt.Println("Public values:")
+	for _, value := range bundle.PublicValues {
+		fmt.Printf("  %s = %s\n", value.Name, value.Value)
+	}
+	fmt.Println("✅ Archive verification succeeded!")
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/zkgenomics/zkgenomics-proofs"
+)
+
+// batchJobOutput is the outcome of one job of a batch report
+type batchJobOutput struct {
+	ID  string `json:"id"`
+	VCF string `json:"vcf"`
+	*proofOutput
+}
+
+// batchOutput is the summary report of a batch, and its JSON result
+type batchOutput struct {
+	Status    string             `json:"status"`
+	Jobs      int                `json:"jobs"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	TimingsMS map[string]float64 `json:"timings_ms"`
+	Results   []batchJobOutput   `json:"results"`
+}
+
+// handleBatch generates the proofs a batch manifest lists with a pool of
+// workers, writes each to its output and reports how every job went
+func handleBatch() {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	workers := fs.Int("workers", 0, "proofs to generate at once (default the manifest's workers, or one per CPU)")
+	keys := fs.String("keys", "", "generate with the keys stored in this directory, as written by setup")
+	format := fs.String("format", "json", "encoding of the proof files: json, cbor or armor")
+	reportPath := fs.String("report", "", "file to write the summary report to as JSON")
+	threads := addThreadsFlag(fs)
+	identities := addIdentityFlags(fs)
+	addQuietFlag(fs)
+	addJSONFlag(fs)
+	setUsage(fs, "batch [flags] <manifest>",
+		"Generates the proofs listed in a JSON or YAML manifest, several at once,",
+		"writes each to its output and prints a summary report. Each job names a",
+		"proof type and genome, and optionally an id, sample, output file and params,",
+		"a claim configuring the proof as in claim files. Relative paths are read",
+		"from the manifest's directory. The exit status is 1 if any job failed.",
+		"",
+		"  workers: 4",
+		"  jobs:",
+		"    - {id: patient-001, proof_type: aldh2, vcf: genomes/patient-001.vcf.gz}",
+		"    - id: patient-002",
+		"      vcf: genomes/patient-002.vcf.gz",
+		"      output: proofs/patient-002_herc2.json",
+		"      params: {proof_type: dynamic, chromosome: 15, position: 28365618, ref: A, alt: G, mode: carrier}")
+	args := parseArgs(fs, os.Args[2:])
+	startJSONOutput()
+	if len(args) != 1 {
+		usageError(fs, "batch requires a manifest")
+	}
+	if *format != "json" && *format != "cbor" && *format != "armor" {
+		usageError(fs, "unknown format %q: expected json, cbor or armor", *format)
+	}
+
+	manifest, err := zkgenomics.LoadBatchManifest(args[0])
+	if err != nil {
+		log.Fatalf("Failed to load batch manifest: %v", err)
+	}
+	if *workers == 0 {
+		*workers = manifest.Workers
+	}
+
+	opts := append(reportingOptions(), zkgenomics.WithThreads(*threads))
+	if *keys != "" {
+		opts = append(opts, zkgenomics.WithKeyStore(&zkgenomics.FileKeyStore{Dir: *keys}))
+	}
+	if identityOption, err := identities.option(); err != nil {
+		log.Fatalf("Failed to load identities: %v", err)
+	} else if identityOption != nil {
+		opts = append(opts, identityOption)
+	}
+	generator := zkgenomics.NewProofGenerator(opts...)
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	infof("Generating %d proofs from %s...\n", len(manifest.Jobs), args[0])
+	start := time.Now()
+	results := generator.GenerateBatch(ctx, manifest.Requests(), *workers)
+
+	report := batchOutput{
+		Status:    statusSuccess,
+		Jobs:      len(results),
+		TimingsMS: map[string]float64{"total": milliseconds(time.Since(start))},
+	}
+	for i, result := range results {
+		job := manifest.Jobs[i]
+		out := &proofOutput{Status: statusError, ProofType: string(job.ProofType)}
+		switch {
+		case result.Err != nil:
+			out.Error = result.Err.Error()
+		case result.Response.ProofData.Result != zkgenomics.ProofSuccess:
+			out.Status = statusFail
+		default:
+			out = newProofOutput(result.Response.ProofType, result.Response.ProofData, nil)
+			if err := writeBatchProof(job.Output, result.Response.ProofData, *format); err != nil {
+				out.Status = statusError
+				out.Error = err.Error()
+			} else {
+				out.Output = job.Output
+			}
+		}
+		if result.Response != nil {
+			out.TimingsMS = timingsOutput(result.Response.Timings)
+		}
+		if out.Status == statusSuccess {
+			report.Succeeded++
+		} else {
+			report.Failed++
+			report.Status = statusFail
+		}
+		report.Results = append(report.Results, batchJobOutput{ID: job.ID, VCF: job.VCF, proofOutput: out})
+	}
+
+	if *reportPath != "" {
+		writeBatchReport(*reportPath, report)
+	}
+	if jsonOutput {
+		printJSON(report)
+	} else {
+		printBatchReport(report)
+	}
+	exitIfCancelled(ctx.Err())
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// writeBatchProof writes a proof of a batch to output in format, creating
+// its directory
+func writeBatchProof(output string, proofData *zkgenomics.ProofData, format string) error {
+	encoded, err := encodeOutput(proofData, format)
+	if err != nil {
+		return fmt.Errorf("serializing proof: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(output, encoded, 0644)
+}
+
+// writeBatchReport writes the summary report of a batch as JSON
+func writeBatchReport(path string, report batchOutput) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to serialize batch report: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		log.Fatalf("Failed to write batch report: %v", err)
+	}
+}
+
+// printBatchReport prints the summary report of a batch as a table
+func printBatchReport(report batchOutput) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "job\tproof type\tstatus\ttime\tproof or error")
+	for _, result := range report.Results {
+		detail := result.Output
+		if result.Error != "" {
+			detail = result.Error
+		}
+		elapsed := "-"
+		if total, ok := result.TimingsMS["total"]; ok {
+			elapsed = fromMilliseconds(total).Round(time.Millisecond).String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", result.ID, result.ProofType, result.Status, elapsed, detail)
+	}
+	w.Flush()
+
+	icon := "✅"
+	if report.Failed > 0 {
+		icon = "❌"
+	}
+	fmt.Printf("%s %d of %d proofs generated in %s\n", icon, report.Succeeded, report.Jobs,
+		fromMilliseconds(report.TimingsMS["total"]).Round(time.Millisecond))
+}
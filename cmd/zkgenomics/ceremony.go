@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+func printCeremonyUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics ceremony power [circuit flags] <proof-type>")
+	fmt.Println("  zkgenomics ceremony init --power n <phase1-path>")
+	fmt.Println("  zkgenomics ceremony contribute <phase1|phase2> <in-path> <out-path>")
+	fmt.Println("  zkgenomics ceremony verify <phase1|phase2> <initial-path> <contribution-path>...")
+	fmt.Println("  zkgenomics ceremony start [circuit flags] <proof-type> <phase1-path> <phase2-path>")
+	fmt.Println("  zkgenomics ceremony finalize [--keys dir] [--compress] [circuit flags] <proof-type> <phase1-path> <phase2-initial-path> <phase2-contribution-path>...")
+	fmt.Println()
+	fmt.Println("Circuit flags:")
+	fmt.Println("  --chromosome c  chromosome a chromosome proof shows present (default 22)")
+	fmt.Println("  --slots n       number of chromosome slots in the chromosome circuit")
+	fmt.Println("  --vcf path      genome sizing kinship and region count circuits")
+	fmt.Println()
+	fmt.Println("Phase 1 files may come from any gnark mpcsetup Powers of Tau ceremony at least")
+	fmt.Println("as large as the circuit needs; contributions are verified before finalizing.")
+}
+
+func handleCeremony() {
+	if len(os.Args) < 3 {
+		printCeremonyUsage()
+		os.Exit(1)
+	}
+
+	args := os.Args[3:]
+	switch os.Args[2] {
+	case "power":
+		ceremonyPower(args)
+	case "init":
+		ceremonyInit(args)
+	case "contribute":
+		ceremonyContribute(args)
+	case "verify":
+		ceremonyVerify(args)
+	case "start":
+		ceremonyStart(args)
+	case "finalize":
+		ceremonyFinalize(args)
+	default:
+		fmt.Printf("Unknown ceremony command: %s\n", os.Args[2])
+		printCeremonyUsage()
+		os.Exit(1)
+	}
+}
+
+// circuitFlags are the flags choosing the circuit of a proof type
+type circuitFlags struct {
+	chromosome string
+	slots      int
+	vcf        string
+}
+
+func addCircuitFlags(fs *flag.FlagSet) *circuitFlags {
+	cf := &circuitFlags{}
+	fs.StringVar(&cf.chromosome, "chromosome", "", "chromosome a chromosome proof shows present (default 22)")
+	fs.IntVar(&cf.slots, "slots", 0, "number of chromosome slots in the chromosome circuit")
+	fs.StringVar(&cf.vcf, "vcf", "", "VCF sizing kinship and region count circuits")
+	return cf
+}
+
+func (cf *circuitFlags) options() []zkgenomics.Option {
+	opts := []zkgenomics.Option{
+		zkgenomics.WithLogger(stdoutLogger),
+		zkgenomics.WithChromosomeSlots(cf.slots),
+	}
+	if cf.chromosome != "" {
+		code := zkgenomics.ChromosomeCode(cf.chromosome)
+		if code == 0 {
+			log.Fatalf("Unknown chromosome %q", cf.chromosome)
+		}
+		opts = append(opts, zkgenomics.WithTargetChromosome(code))
+	}
+	return opts
+}
+
+// parseCeremonyFlags parses args and exits unless at least minArgs positional
+// arguments remain
+func parseCeremonyFlags(fs *flag.FlagSet, args []string, minArgs int, missing string) []string {
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < minArgs {
+		fmt.Printf("Error: ceremony %s requires %s\n", fs.Name(), missing)
+		printCeremonyUsage()
+		os.Exit(1)
+	}
+	return fs.Args()
+}
+
+func ceremonyPower(args []string) {
+	fs := flag.NewFlagSet("power", flag.ExitOnError)
+	cf := addCircuitFlags(fs)
+	args = parseCeremonyFlags(fs, args, 1, "proof-type")
+
+	generator := zkgenomics.NewProofGenerator(cf.options()...)
+	power, err := generator.CeremonyPower(zkgenomics.ProofType(args[0]), cf.vcf)
+	if err != nil {
+		log.Fatalf("Failed to size the circuit: %v", err)
+	}
+	fmt.Printf("%s circuits need a phase 1 of at least 2^%d powers of tau (--power %d)\n", args[0], power, power)
+}
+
+func ceremonyInit(args []string) {
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	power := fs.Int("power", 0, "phase 1 holds 2^power powers of tau")
+	args = parseCeremonyFlags(fs, args, 1, "phase1-path")
+	if *power <= 0 {
+		log.Fatalf("ceremony init requires --power; see ceremony power")
+	}
+
+	phase1 := proofs.NewPhase1(*power)
+	if err := proofs.WritePhase1(args[0], phase1); err != nil {
+		log.Fatalf("Failed to write phase 1: %v", err)
+	}
+	fmt.Printf("✅ Initial phase 1 of 2^%d powers written to: %s\n", *power, args[0])
+	fmt.Println("   It holds no randomness; at least one participant must contribute to it.")
+}
+
+func ceremonyContribute(args []string) {
+	if len(args) < 3 {
+		fmt.Println("Error: ceremony contribute requires phase, in-path and out-path")
+		printCeremonyUsage()
+		os.Exit(1)
+	}
+
+	var hash []byte
+	switch args[0] {
+	case "phase1":
+		phase1, err := proofs.ReadPhase1(args[1])
+		if err != nil {
+			log.Fatalf("Failed to read phase 1: %v", err)
+		}
+		phase1.Contribute()
+		if err := proofs.WritePhase1(args[2], phase1); err != nil {
+			log.Fatalf("Failed to write phase 1: %v", err)
+		}
+		hash = phase1.Hash
+	case "phase2":
+		phase2, err := proofs.ReadPhase2(args[1])
+		if err != nil {
+			log.Fatalf("Failed to read phase 2: %v", err)
+		}
+		phase2.Contribute()
+		if err := proofs.WritePhase2(args[2], phase2); err != nil {
+			log.Fatalf("Failed to write phase 2: %v", err)
+		}
+		hash = phase2.Hash
+	default:
+		log.Fatalf("Unknown phase %q: expected phase1 or phase2", args[0])
+	}
+
+	fmt.Printf("✅ Contribution written to: %s\n", args[2])
+	fmt.Printf("   Contribution hash: %s\n", hex.EncodeToString(hash))
+	fmt.Println("   Publish the hash, then pass the file on; your randomness has been discarded.")
+}
+
+func ceremonyVerify(args []string) {
+	if len(args) < 3 {
+		fmt.Println("Error: ceremony verify requires phase, initial-path and at least one contribution-path")
+		printCeremonyUsage()
+		os.Exit(1)
+	}
+
+	paths := args[1:]
+	var hashes [][]byte
+	var err error
+	switch args[0] {
+	case "phase1":
+		chain := make([]*proofs.Phase1, len(paths))
+		for i, path := range paths {
+			if chain[i], err = proofs.ReadPhase1(path); err != nil {
+				log.Fatalf("Failed to read phase 1: %v", err)
+			}
+			hashes = append(hashes, chain[i].Hash)
+		}
+		err = proofs.VerifyPhase1(chain...)
+	case "phase2":
+		chain := make([]*proofs.Phase2, len(paths))
+		for i, path := range paths {
+			if chain[i], err = proofs.ReadPhase2(path); err != nil {
+				log.Fatalf("Failed to read phase 2: %v", err)
+			}
+			hashes = append(hashes, chain[i].Hash)
+		}
+		err = proofs.VerifyPhase2(chain...)
+	default:
+		log.Fatalf("Unknown phase %q: expected phase1 or phase2", args[0])
+	}
+
+	if err != nil {
+		fmt.Println("❌ Contribution verification failed!")
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	for i, path := range paths[1:] {
+		fmt.Printf("  %s  %s\n", hex.EncodeToString(hashes[i+1]), path)
+	}
+	fmt.Printf("✅ %d %s contributions verified\n", len(paths)-1, args[0])
+}
+
+func ceremonyStart(args []string) {
+	fs := flag.NewFlagSet("start", flag.ExitOnError)
+	cf := addCircuitFlags(fs)
+	args = parseCeremonyFlags(fs, args, 3, "proof-type, phase1-path and phase2-path")
+
+	phase1, err := proofs.ReadPhase1(args[1])
+	if err != nil {
+		log.Fatalf("Failed to read phase 1: %v", err)
+	}
+	generator := zkgenomics.NewProofGenerator(cf.options()...)
+	phase2, circuit, err := generator.StartCeremony(zkgenomics.ProofType(args[0]), phase1, cf.vcf)
+	if err != nil {
+		log.Fatalf("Failed to start phase 2: %v", err)
+	}
+	if err := proofs.WritePhase2(args[2], phase2); err != nil {
+		log.Fatalf("Failed to write phase 2: %v", err)
+	}
+	fmt.Printf("✅ Phase 2 for circuit %s written to: %s\n", circuit, args[2])
+	fmt.Println("   Keep this initial state: finalize checks the contributions against it.")
+}
+
+func ceremonyFinalize(args []string) {
+	fs := flag.NewFlagSet("finalize", flag.ExitOnError)
+	keys := fs.String("keys", "keys", "directory to store the keys in")
+	compress := fs.Bool("compress", false, "store the proving key with compressed points: about half the size, slower to load")
+	cf := addCircuitFlags(fs)
+	args = parseCeremonyFlags(fs, args, 4, "proof-type, phase1-path, and the initial and contributed phase 2 paths")
+
+	phase1, err := proofs.ReadPhase1(args[1])
+	if err != nil {
+		log.Fatalf("Failed to read phase 1: %v", err)
+	}
+	var chain []*zkgenomics.Phase2
+	for _, path := range args[2:] {
+		phase2, err := proofs.ReadPhase2(path)
+		if err != nil {
+			log.Fatalf("Failed to read phase 2: %v", err)
+		}
+		chain = append(chain, phase2)
+	}
+
+	opts := append(cf.options(), zkgenomics.WithKeyStore(&zkgenomics.FileKeyStore{Dir: *keys, Compressed: *compress}))
+	generator := zkgenomics.NewProofGenerator(opts...)
+	proofType := zkgenomics.ProofType(args[0])
+	circuit, err := generator.FinalizeCeremony(proofType, phase1, chain, cf.vcf)
+	if err != nil {
+		log.Fatalf("Failed to finalize the ceremony: %v", err)
+	}
+
+	base := filepath.Join(*keys, circuit.String())
+	fmt.Printf("✅ Ceremony keys for circuit %s\n", circuit)
+	fmt.Printf("   Proving key:   %s.pk\n", base)
+	fmt.Printf("   Verifying key: %s.vk\n", base)
+	fmt.Printf("   Generate with: zkgenomics generate --keys %s %s <vcf-path>\n", *keys, proofType)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+func printCredentialUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics credential export --issuer <did> <proof-type> <proof-path> <credential-path>")
+	fmt.Println("  zkgenomics credential verify [--trusted-keys file] [--insecure-bundled-key] <credential-path>")
+}
+
+func handleCredential() {
+	if len(os.Args) < 3 {
+		printCredentialUsage()
+		os.Exit(1)
+	}
+
+	switch os.Args[2] {
+	case "export":
+		credentialExport(os.Args[3:])
+	case "verify":
+		credentialVerify(os.Args[3:])
+	default:
+		fmt.Printf("Unknown credential command: %s\n", os.Args[2])
+		printCredentialUsage()
+		os.Exit(1)
+	}
+}
+
+func credentialExport(args []string) {
+	fs := flag.NewFlagSet("credential export", flag.ExitOnError)
+	issuer := fs.String("issuer", "", "DID or URL of the credential issuer")
+	fs.Parse(args)
+	if *issuer == "" || fs.NArg() < 3 {
+		fmt.Println("Error: credential export requires --issuer, proof-type, proof-path and credential-path")
+		printCredentialUsage()
+		os.Exit(1)
+	}
+
+	proofData, err := proofs.ReadProofData(fs.Arg(1))
+	if err != nil {
+		log.Fatalf("Failed to read proof: %v", err)
+	}
+	credential, err := zkgenomics.NewProofGenerator().ExportCredential(zkgenomics.ProofType(fs.Arg(0)), proofData, *issuer)
+	if err != nil {
+		log.Fatalf("Failed to export credential: %v", err)
+	}
+
+	data, err := json.MarshalIndent(credential, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to serialize credential: %v", err)
+	}
+	if err := os.WriteFile(fs.Arg(2), data, 0644); err != nil {
+		log.Fatalf("Failed to write credential: %v", err)
+	}
+	fmt.Printf("✅ Credential written to: %s\n", fs.Arg(2))
+}
+
+func credentialVerify(args []string) {
+	fs := flag.NewFlagSet("credential verify", flag.ExitOnError)
+	trust := addKeyTrustFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
+		fmt.Println("Error: credential verify requires credential-path")
+		printCredentialUsage()
+		os.Exit(1)
+	}
+
+	credential, err := proofs.ReadVerifiableCredential(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to read credential: %v", err)
+	}
+
+	generator := zkgenomics.NewProofGenerator(zkgenomics.WithLogger(stdoutLogger))
+	if err := trust.apply(generator); err != nil {
+		log.Fatalf("Failed to load trusted keys: %v", err)
+	}
+	proofType, result, err := generator.VerifyCredential(credential)
+	if err != nil {
+		log.Fatalf("Failed to verify credential: %v", err)
+	}
+	if result.Result != zkgenomics.ProofSuccess {
+		fmt.Println("❌ Credential verification failed!")
+		if result.Error != nil {
+			fmt.Printf("Error: %v\n", result.Error)
+		}
+		os.Exit(1)
+	}
+
+	fmt.Printf("Credential issued by %s for a %s proof\n", credential.Issuer, proofType)
+	fmt.Println("Public values:")
+	for _, value := range credential.CredentialSubject.PublicValues {
+		fmt.Printf("  %s = %s\n", value.Name, value.Value)
+	}
+	fmt.Println("✅ Credential verification succeeded!")
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/zkgenomics/zkgenomics-proofs"
+)
+
+// quiet suppresses progress bars and informational messages, leaving
+// warnings, errors and the result of a command
+var quiet bool
+
+// addQuietFlag registers --quiet, which sets quiet
+func addQuietFlag(fs *flag.FlagSet) {
+	fs.BoolVar(&quiet, "quiet", false, "print only warnings, errors and the result, without progress or log messages")
+}
+
+// infof prints an informational message unless --quiet is set
+func infof(format string, args ...any) {
+	if !quiet {
+		fmt.Printf(format, args...)
+	}
+}
+
+// reportingOptions returns the options that report progress and log
+// messages as a command runs, or only warnings, on stderr, with --quiet
+func reportingOptions() []zkgenomics.Option {
+	if quiet {
+		// gnark logs every compile and proving run on its own logger
+		logger.Disable()
+		return []zkgenomics.Option{zkgenomics.WithLogger(zkgenomics.NewWriterLogger(os.Stderr, zkgenomics.LevelWarn))}
+	}
+	return []zkgenomics.Option{zkgenomics.WithProgress(printProgress), zkgenomics.WithLogger(stdoutLogger)}
+}
+
+// setUsage makes -h, and the errors of a subcommand, print its synopsis and
+// description followed by its flags
+func setUsage(fs *flag.FlagSet, synopsis string, description ...string) {
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: zkgenomics %s\n", synopsis)
+		if len(description) > 0 {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, strings.Join(description, "\n"))
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Flags:")
+		fs.PrintDefaults()
+	}
+}
+
+// usageError prints an error and the usage of the subcommand fs, then exits
+// with the status flag uses for bad arguments
+func usageError(fs *flag.FlagSet, format string, args ...any) {
+	fmt.Fprintf(fs.Output(), "Error: "+format+"\n\n", args...)
+	fs.Usage()
+	os.Exit(2)
+}
+
+// parseArgs parses args into fs and returns the positional arguments. Unlike
+// fs.Parse it accepts flags after positional arguments too, so
+// `generate aldh2 sample.vcf --quiet` reads as `generate --quiet aldh2
+// sample.vcf`. Everything after "--" is positional.
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional
+		}
+		if len(rest) < len(args) && args[len(args)-len(rest)-1] == "--" {
+			return append(positional, rest...)
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// operand returns positional argument i, or the value of the flag name that
+// stands in for it if there is no such argument. Giving both is an error.
+func operand(fs *flag.FlagSet, args []string, i int, name string) string {
+	value := fs.Lookup(name).Value.String()
+	if i >= len(args) {
+		return value
+	}
+	if value != "" && value != args[i] {
+		usageError(fs, "--%s %s conflicts with the argument %s", name, value, args[i])
+	}
+	return args[i]
+}
+
+// handleHelp prints the usage of a command, or of every command
+func handleHelp() {
+	if len(os.Args) < 3 {
+		printUsage()
+		return
+	}
+	os.Args = []string{os.Args[0], os.Args[2], "-h"}
+	dispatch(os.Args[1])
+}
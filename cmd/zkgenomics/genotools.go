@@ -0,0 +1,301 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/genotools"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// sourceFlags are the input-processing flags shared by genotools commands
+type sourceFlags struct {
+	minQual  float64
+	passOnly bool
+	regions  string
+	chain    string
+	to       string
+}
+
+func addSourceFlags(fs *flag.FlagSet) *sourceFlags {
+	sf := &sourceFlags{}
+	fs.Float64Var(&sf.minQual, "min-qual", 0, "hide records with a lower QUAL")
+	fs.BoolVar(&sf.passOnly, "pass-only", false, "hide records that did not pass all filters")
+	fs.StringVar(&sf.regions, "regions", "", "comma-separated chrom:start-end regions to expose; all others are redacted")
+	fs.StringVar(&sf.chain, "chain", "", "JSON liftover blocks presenting the VCF in --to build coordinates")
+	fs.StringVar(&sf.to, "to", "", "genome build the --chain blocks lift to (GRCh37 or GRCh38)")
+	return sf
+}
+
+func (sf *sourceFlags) options() (genotools.Options, error) {
+	opts := genotools.Options{
+		MinQuality:  float32(sf.minQual),
+		PassOnly:    sf.passOnly,
+		TargetBuild: traits.GenomeBuild(sf.to),
+	}
+
+	if sf.regions != "" {
+		for _, r := range strings.Split(sf.regions, ",") {
+			region, err := genotools.ParseRegion(r)
+			if err != nil {
+				return opts, err
+			}
+			opts.Regions = append(opts.Regions, region)
+		}
+	}
+
+	if sf.chain != "" {
+		blocks, err := genotools.LoadLiftoverBlocks(sf.chain)
+		if err != nil {
+			return opts, err
+		}
+		opts.Liftover = blocks
+	}
+
+	return opts, nil
+}
+
+func printGenotoolsUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics genotools extract [flags] <vcf-path> <chrom:pos>...")
+	fmt.Println("  zkgenomics genotools scan [flags] <vcf-path> <chrom[:start-end]>")
+	fmt.Println("  zkgenomics genotools validate <vcf-path>")
+	fmt.Println("  zkgenomics genotools commit [--force] <vcf-path>")
+	fmt.Println("  zkgenomics genotools synth [--samples n] [--seed s] [--out path]")
+	fmt.Println("  zkgenomics genotools liftover --chain blocks.json --to build [--out path] <vcf-path>")
+	fmt.Println()
+	fmt.Println("Shared flags (extract, scan, liftover):")
+	fmt.Println("  --min-qual q    hide records with QUAL below q")
+	fmt.Println("  --pass-only     hide records that did not pass all filters")
+	fmt.Println("  --regions list  expose only these chrom:start-end regions")
+	fmt.Println("  --chain file    present coordinates through JSON liftover blocks")
+	fmt.Println("  --to build      build the liftover blocks map to")
+}
+
+func handleGenotools() {
+	if len(os.Args) < 3 {
+		printGenotoolsUsage()
+		os.Exit(1)
+	}
+
+	args := os.Args[3:]
+	switch os.Args[2] {
+	case "extract":
+		genotoolsExtract(args)
+	case "scan":
+		genotoolsScan(args)
+	case "validate":
+		genotoolsValidate(args)
+	case "commit":
+		genotoolsCommit(args)
+	case "synth":
+		genotoolsSynth(args)
+	case "liftover":
+		genotoolsLiftover(args)
+	default:
+		fmt.Printf("Unknown genotools command: %s\n", os.Args[2])
+		printGenotoolsUsage()
+		os.Exit(1)
+	}
+}
+
+// parseGenotoolsFlags parses args and exits unless at least minArgs positional arguments remain
+func parseGenotoolsFlags(fs *flag.FlagSet, args []string, minArgs int) []string {
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < minArgs {
+		fmt.Printf("Error: genotools %s requires %d argument(s)\n", fs.Name(), minArgs)
+		printGenotoolsUsage()
+		os.Exit(1)
+	}
+	return fs.Args()
+}
+
+func openGenotoolsSource(sf *sourceFlags, vcfPath string) proofs.GenomeSource {
+	opts, err := sf.options()
+	if err != nil {
+		log.Fatalf("Invalid flags: %v", err)
+	}
+	source, err := genotools.OpenSource(vcfPath, opts)
+	if err != nil {
+		log.Fatalf("Failed to open VCF: %v", err)
+	}
+	return source
+}
+
+func printCalls(samples []string, calls []*proofs.VariantCall) {
+	for _, call := range calls {
+		genotypes := make([]string, len(call.Samples))
+		for i, sample := range call.Samples {
+			name := fmt.Sprintf("sample%d", i+1)
+			if i < len(samples) {
+				name = samples[i]
+			}
+			genotypes[i] = fmt.Sprintf("%s=%s", name, genotools.FormatGenotype(sample))
+		}
+		fmt.Printf("%s\t%d\t%s\t%s\t%s\t%s\n", call.Chromosome, call.Position, call.ID, call.Reference, strings.Join(call.Alternate, ","), strings.Join(genotypes, " "))
+	}
+}
+
+func genotoolsExtract(args []string) {
+	fs := flag.NewFlagSet("extract", flag.ExitOnError)
+	sf := addSourceFlags(fs)
+	rest := parseGenotoolsFlags(fs, args, 2)
+
+	source := openGenotoolsSource(sf, rest[0])
+	for _, locus := range rest[1:] {
+		chrom, pos, err := genotools.ParseLocus(locus)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		calls, err := genotools.Extract(source, chrom, pos)
+		if errors.Is(err, proofs.ErrRedacted) {
+			fmt.Printf("%s\tredacted\n", locus)
+			continue
+		}
+		if err != nil {
+			log.Fatalf("Failed to extract %s: %v", locus, err)
+		}
+		if len(calls) == 0 {
+			fmt.Printf("%s\tnot found\n", locus)
+			continue
+		}
+		printCalls(source.SampleNames(), calls)
+	}
+}
+
+func genotoolsScan(args []string) {
+	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	sf := addSourceFlags(fs)
+	rest := parseGenotoolsFlags(fs, args, 2)
+
+	region, err := genotools.ParseRegion(rest[1])
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	source := openGenotoolsSource(sf, rest[0])
+	calls, err := genotools.Scan(source, region)
+	if err != nil {
+		log.Fatalf("Failed to scan %s: %v", rest[1], err)
+	}
+	printCalls(source.SampleNames(), calls)
+	fmt.Printf("%d record(s)\n", len(calls))
+}
+
+func genotoolsValidate(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	rest := parseGenotoolsFlags(fs, args, 1)
+
+	report, err := genotools.Validate(rest[0])
+	if err != nil {
+		log.Fatalf("Failed to validate VCF: %v", err)
+	}
+
+	fmt.Printf("Records: %d\n", report.Records)
+	fmt.Printf("Samples: %s\n", strings.Join(report.Samples, ", "))
+	fmt.Printf("Chromosomes: %s\n", strings.Join(report.Chromosomes, ", "))
+	if report.Build != traits.BuildUnknown {
+		fmt.Printf("Build: %s\n", report.Build)
+	} else {
+		fmt.Println("Build: unknown")
+	}
+
+	if report.Valid() {
+		fmt.Println("✅ VCF is valid")
+		return
+	}
+	for _, issue := range report.Issues {
+		fmt.Printf("  %s\n", issue)
+	}
+	fmt.Printf("❌ %d issue(s) found\n", len(report.Issues))
+	os.Exit(1)
+}
+
+func genotoolsCommit(args []string) {
+	fs := flag.NewFlagSet("commit", flag.ExitOnError)
+	force := fs.Bool("force", false, "replace an existing commitment that no longer matches")
+	rest := parseGenotoolsFlags(fs, args, 1)
+
+	commitment, err := genotools.Commit(rest[0], *force)
+	var staleErr *zkgenomics.StaleCommitmentError
+	if errors.As(err, &staleErr) {
+		fmt.Printf("❌ %v\n", err)
+		fmt.Println("If the changes are intended, rerun with --force")
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatalf("Failed to commit genome: %v", err)
+	}
+
+	fmt.Printf("✅ Genome committed: %s\n", rest[0])
+	fmt.Printf("Digest: %s\n", commitment.Digest)
+}
+
+func genotoolsSynth(args []string) {
+	fs := flag.NewFlagSet("synth", flag.ExitOnError)
+	samples := fs.Int("samples", 1, "number of samples")
+	seed := fs.Int64("seed", 1, "random seed")
+	altFrequency := fs.Float64("alt-frequency", 0.5, "probability of each allele being ALT")
+	build := fs.String("build", string(traits.BuildGRCh37), "genome build recorded in the header")
+	out := fs.String("out", "", "output path (default stdout)")
+	parseGenotoolsFlags(fs, args, 0)
+
+	w, closeOut := genotoolsOutput(*out)
+	defer closeOut()
+
+	err := genotools.Synth(w, genotools.SynthOptions{
+		Samples:      *samples,
+		AltFrequency: *altFrequency,
+		Seed:         *seed,
+		Build:        traits.GenomeBuild(*build),
+	})
+	if err != nil {
+		log.Fatalf("Failed to synthesize VCF: %v", err)
+	}
+}
+
+func genotoolsLiftover(args []string) {
+	fs := flag.NewFlagSet("liftover", flag.ExitOnError)
+	sf := addSourceFlags(fs)
+	out := fs.String("out", "", "output path (default stdout)")
+	rest := parseGenotoolsFlags(fs, args, 1)
+
+	if sf.chain == "" {
+		log.Fatalf("genotools liftover requires --chain")
+	}
+	opts, err := sf.options()
+	if err != nil {
+		log.Fatalf("Invalid flags: %v", err)
+	}
+	w, closeOut := genotoolsOutput(*out)
+	defer closeOut()
+
+	if err := genotools.Liftover(w, rest[0], opts); err != nil {
+		log.Fatalf("Failed to lift over VCF: %v", err)
+	}
+}
+
+// genotoolsOutput opens path for writing, or returns stdout when path is empty
+func genotoolsOutput(path string) (io.Writer, func()) {
+	if path == "" {
+		return os.Stdout, func() {}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Failed to create %s: %v", path, err)
+	}
+	return f, func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+}
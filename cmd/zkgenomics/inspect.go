@@ -0,0 +1,226 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/consensys/gnark/logger"
+	zkgenomics "github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// handleInspect reports what proving each circuit takes, so users can budget
+// before generating
+func handleInspect() {
+	fs := flag.NewFlagSet("inspect", flag.ExitOnError)
+	slots := fs.Int("slots", 0, "number of chromosome slots in the chromosome circuit")
+	addJSONFlag(fs)
+	threads := addThreadsFlag(fs)
+	backend := addBackendFlags(fs)
+	fs.Parse(os.Args[2:])
+	startJSONOutput()
+	// Compiling every circuit would fill the report with gnark's logs
+	logger.Disable()
+
+	backendOption, err := backend.option()
+	if err != nil {
+		log.Fatalf("Invalid backend: %v", err)
+	}
+	generator := zkgenomics.NewProofGenerator(
+		zkgenomics.WithChromosomeSlots(*slots),
+		zkgenomics.WithThreads(*threads),
+		backendOption,
+	)
+
+	// Without a proof type every circuit is inspected, but for those sized
+	// from a genome, which need vcf-path
+	proofTypes := generator.GetSupportedProofTypes()
+	var vcfPath string
+	if fs.NArg() > 0 {
+		proofTypes = []zkgenomics.ProofType{zkgenomics.ProofType(fs.Arg(0))}
+		vcfPath = fs.Arg(1)
+	}
+
+	costs := []*zkgenomics.CircuitCost{}
+	var names []zkgenomics.ProofType
+	skipped := make(map[zkgenomics.ProofType]string)
+	for _, proofType := range proofTypes {
+		cost, err := generator.InspectCircuit(proofType, vcfPath)
+		if err != nil {
+			if fs.NArg() > 0 && jsonOutput {
+				printJSON(map[string]string{"status": statusError, "error": fmt.Sprintf("inspecting %s: %v", proofType, err)})
+				os.Exit(1)
+			}
+			if fs.NArg() > 0 {
+				log.Fatalf("Failed to inspect %s: %v", proofType, err)
+			}
+			fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", proofType, err)
+			skipped[proofType] = err.Error()
+			continue
+		}
+		costs = append(costs, cost)
+		names = append(names, proofType)
+	}
+
+	if jsonOutput {
+		printJSON(struct {
+			Status   string                          `json:"status"`
+			Circuits []*zkgenomics.CircuitCost       `json:"circuits"`
+			Skipped  map[zkgenomics.ProofType]string `json:"skipped,omitempty"`
+		}{statusSuccess, costs, skipped})
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "proof type\tcircuit\tconstraints\tpublic\tsecret\tinternal\tproving key\tverifying key\tproof\tproving time\t")
+	for i, cost := range costs {
+		fmt.Fprintf(w, "%s\t%s v%d\t%d\t%d\t%d\t%d\t%s\t%s\t%d B\t%s\t\n",
+			names[i], cost.CircuitID, cost.CircuitVersion, cost.Constraints, cost.PublicInputs, cost.SecretInputs,
+			cost.InternalVariables, formatBytes(cost.ProvingKeySize), formatBytes(cost.VerifyingKeySize), cost.ProofSize, cost.ProvingTime.Round(time.Millisecond))
+	}
+	w.Flush()
+	if len(costs) > 0 {
+		fmt.Printf("\nEstimated for %s over %s on %d threads; proving keys are raw, about half with setup --compress.\n",
+			costs[0].Backend, costs[0].Curve, costs[0].Threads)
+	}
+}
+
+// formatBytes formats a size in bytes for people
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// proofFileOutput is the JSON result of inspect-proof: what a proof file
+// records, none of it verified
+type proofFileOutput struct {
+	*proofOutput
+	Verified    bool                      `json:"verified"`
+	CreatedAt   time.Time                 `json:"created_at,omitzero"`
+	CircuitHash string                    `json:"circuit_hash,omitempty"`
+	Binding     *proofs.Binding           `json:"binding,omitempty"`
+	Signatures  int                       `json:"signatures,omitempty"`
+	Provenance  *proofs.GenomeAttestation `json:"provenance,omitempty"`
+}
+
+// handleInspectProof prints what a proof file records without verifying it,
+// so a proof can be examined without its genome or keys
+func handleInspectProof() {
+	fs := flag.NewFlagSet("inspect-proof", flag.ExitOnError)
+	addJSONFlag(fs)
+	setUsage(fs, "inspect-proof [--json] <proof-path | ->",
+		"Prints the proof type, circuit, curve, verifying key fingerprint, sizes and",
+		"claim a proof file records, read from stdin for \"-\". It needs neither the",
+		"genome nor the keys, and verifies nothing: run verify before trusting what",
+		"the proof claims.")
+	args := parseArgs(fs, os.Args[2:])
+	startJSONOutput()
+	if len(args) != 1 {
+		usageError(fs, "inspect-proof requires a proof file")
+	}
+
+	var proofData *zkgenomics.ProofData
+	var err error
+	if args[0] == "-" {
+		proofData, err = proofs.DecodeProofData(os.Stdin)
+	} else {
+		proofData, err = proofs.ReadProofData(args[0])
+	}
+	if err != nil && jsonOutput {
+		failJSON(&proofOutput{}, statusError, err)
+	}
+	if err != nil {
+		log.Fatalf("Failed to read proof: %v", err)
+	}
+
+	out := proofFileOutput{
+		proofOutput: newProofOutput(zkgenomics.ProofType(proofData.ProofType), proofData, nil),
+		CreatedAt:   proofData.CreatedAt,
+		CircuitHash: proofData.CircuitHash,
+		Binding:     proofData.Binding,
+		Signatures:  len(proofData.Signatures),
+		Provenance:  proofData.Provenance,
+	}
+	if jsonOutput {
+		printJSON(out)
+		return
+	}
+	printProofFile(out)
+}
+
+// printProofFile prints what inspect-proof found in a proof file
+func printProofFile(out proofFileOutput) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	row := func(name, format string, args ...any) {
+		fmt.Fprintf(w, "%s:\t"+format+"\n", append([]any{name}, args...)...)
+	}
+	row("proof type", "%s", cmp.Or(out.ProofType, "unknown"))
+	if out.CircuitID != "" {
+		row("circuit", "%s v%d", out.CircuitID, out.CircuitVersion)
+	}
+	if out.CircuitHash != "" {
+		row("circuit hash", "%s", out.CircuitHash)
+	}
+	// Proofs predating the fields were all Groth16 on BN254
+	row("curve", "%s", cmp.Or(out.Curve, "bn254"))
+	row("backend", "%s", cmp.Or(out.Backend, "groth16"))
+	if !out.CreatedAt.IsZero() {
+		row("created", "%s", out.CreatedAt.Format(time.RFC3339))
+	}
+	if out.VKFingerprint != "" {
+		row("vk fingerprint", "%s", out.VKFingerprint)
+	}
+	if out.ProofID != "" {
+		row("proof ID", "%s", out.ProofID)
+	}
+	row("sizes", "proof %d B, verifying key %d B, public witness %d B",
+		out.Sizes.Proof, out.Sizes.VerifyingKey, out.Sizes.PublicWitness)
+	if binding := out.Binding; binding != nil {
+		if !binding.NotBefore.IsZero() {
+			row("valid from", "%s", binding.NotBefore.Format(time.RFC3339))
+		}
+		if !binding.NotAfter.IsZero() {
+			row("valid until", "%s", binding.NotAfter.Format(time.RFC3339))
+		}
+		if len(binding.Nonce) > 0 {
+			row("nonce", "%s", binding.Nonce)
+		}
+		if binding.HolderKeyHash != "" {
+			row("holder key", "%s", binding.HolderKeyHash)
+		}
+	}
+	if out.Signatures > 0 {
+		row("signatures", "%d", out.Signatures)
+	}
+	if out.Provenance != nil {
+		row("genome attested", "%s on %s", out.Provenance.Digest, out.Provenance.IssuedAt.Format(time.RFC3339))
+	}
+	if out.Claim != nil && out.Claim.Label != "" {
+		row("claim", "%s", out.Claim.Label)
+	}
+	w.Flush()
+
+	if out.Claim != nil && out.Claim.Parameters != nil {
+		printParameters(out.Claim.Parameters)
+	}
+	if out.Claim != nil && len(out.Claim.PublicInputs) > 0 {
+		fmt.Println("Public inputs:")
+		for _, name := range slices.Sorted(maps.Keys(out.Claim.PublicInputs)) {
+			fmt.Printf("  %s = %s\n", name, out.Claim.PublicInputs[name])
+		}
+	}
+	fmt.Println("⚠️  Not verified: run zkgenomics verify before trusting the claim")
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+func printIssuerUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics issuer keygen [--alg ES256|EdDSA] <key-id> <key-file>")
+	fmt.Println("  zkgenomics issuer sign [--format json|cbor|armor] <key-file> <proof-path> [output]")
+	fmt.Println("  zkgenomics issuer attest <key-file> <vcf-path>")
+}
+
+func handleIssuer() {
+	if len(os.Args) < 3 {
+		printIssuerUsage()
+		os.Exit(1)
+	}
+
+	switch os.Args[2] {
+	case "keygen":
+		issuerKeygen(os.Args[3:])
+	case "sign":
+		issuerSign(os.Args[3:])
+	case "attest":
+		issuerAttest(os.Args[3:])
+	default:
+		fmt.Printf("Unknown issuer command: %s\n", os.Args[2])
+		printIssuerUsage()
+		os.Exit(1)
+	}
+}
+
+func issuerKeygen(args []string) {
+	fs := flag.NewFlagSet("issuer keygen", flag.ExitOnError)
+	alg := fs.String("alg", "ES256", "signature algorithm: ES256 or EdDSA")
+	fs.Parse(args)
+	args = fs.Args()
+	if len(args) < 2 {
+		fmt.Println("Error: issuer keygen requires key-id and key-file")
+		printIssuerUsage()
+		os.Exit(1)
+	}
+
+	var private crypto.PrivateKey
+	var public crypto.PublicKey
+	switch *alg {
+	case "ES256":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			log.Fatalf("Failed to generate issuer key: %v", err)
+		}
+		private, public = key, &key.PublicKey
+	case "EdDSA":
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			log.Fatalf("Failed to generate issuer key: %v", err)
+		}
+		private, public = key, pub
+	default:
+		log.Fatalf("Unknown algorithm %q: expected ES256 or EdDSA", *alg)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		log.Fatalf("Failed to encode issuer key: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		log.Fatalf("Failed to encode issuer key: %v", err)
+	}
+	line := fmt.Sprintf("%s %s\n", args[0], base64.StdEncoding.EncodeToString(privateDER))
+	if err := os.WriteFile(args[1], []byte(line), 0600); err != nil {
+		log.Fatalf("Failed to write issuer key: %v", err)
+	}
+
+	fmt.Printf("✅ Issuer key written to: %s\n", args[1])
+	fmt.Println("Trusted key line (add to the file passed to verify --issuer-keys, or --lab-keys for a lab key):")
+	fmt.Printf("%s %s\n", args[0], base64.StdEncoding.EncodeToString(publicDER))
+}
+
+func issuerSign(args []string) {
+	fs := flag.NewFlagSet("issuer sign", flag.ExitOnError)
+	format := fs.String("format", "json", "encoding of the signed proof: json, cbor or armor")
+	fs.Parse(args)
+	args = fs.Args()
+	if len(args) < 2 {
+		fmt.Println("Error: issuer sign requires key-file and proof-path")
+		printIssuerUsage()
+		os.Exit(1)
+	}
+	output := args[1]
+	if len(args) > 2 {
+		output = args[2]
+	}
+
+	signer, err := readIssuerKey(args[0])
+	if err != nil {
+		log.Fatalf("Failed to read issuer key: %v", err)
+	}
+	proofData, err := proofs.ReadProofData(args[1])
+	if err != nil {
+		log.Fatalf("Failed to read proof: %v", err)
+	}
+	if err := zkgenomics.SignEnvelope(proofData, signer); err != nil {
+		log.Fatalf("Failed to sign proof: %v", err)
+	}
+
+	data, err := encodeOutput(proofData, *format)
+	if err != nil {
+		log.Fatalf("Failed to serialize proof: %v", err)
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		log.Fatalf("Failed to write proof: %v", err)
+	}
+	fmt.Printf("✅ Proof signed by %s %s and written to: %s\n", signer.Algorithm(), signer.KeyID(), output)
+}
+
+func issuerAttest(args []string) {
+	if len(args) < 2 {
+		fmt.Println("Error: issuer attest requires key-file and vcf-path")
+		printIssuerUsage()
+		os.Exit(1)
+	}
+
+	signer, err := readIssuerKey(args[0])
+	if err != nil {
+		log.Fatalf("Failed to read issuer key: %v", err)
+	}
+	attestation, err := zkgenomics.AttestGenome(args[1], signer)
+	if err != nil {
+		log.Fatalf("Failed to attest genome: %v", err)
+	}
+
+	fmt.Printf("✅ Genome attested by %s %s and written to: %s\n", signer.Algorithm(), signer.KeyID(), proofs.AttestationPath(args[1]))
+	fmt.Printf("Digest: %s\n", attestation.Digest)
+	if attestation.MerkleRoot != "" {
+		fmt.Printf("Merkle root: %s\n", attestation.MerkleRoot)
+	}
+}
+
+// readIssuerKey reads a "<key-id> <base64 PKCS#8 key>" line written by issuer
+// keygen
+func readIssuerKey(path string) (zkgenomics.EnvelopeSigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("%s: expected key id and private key", path)
+	}
+	der, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid private key", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid private key: %w", path, err)
+	}
+	return zkgenomics.NewEnvelopeSigner(fields[0], key)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"crypto"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	zkgenomics "github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+func printKeysUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics keys export [--keys dir] [--sign key-file] [--verifying-only] [--backend groth16|plonk] [--curve c] [--srs path] [--chromosome c] [--slots n] <proof-type> <output> [vcf-path]")
+	fmt.Println("  zkgenomics keys import [--keys dir] [--issuer-keys file] [--insecure-unsigned] [--trusted-keys file] <bundle>")
+	fmt.Println()
+	fmt.Println("Sign bundles with a key from issuer keygen; import checks the signature against --issuer-keys.")
+}
+
+func handleKeys() {
+	if len(os.Args) < 3 {
+		printKeysUsage()
+		os.Exit(1)
+	}
+
+	switch os.Args[2] {
+	case "export":
+		keysExport(os.Args[3:])
+	case "import":
+		keysImport(os.Args[3:])
+	default:
+		fmt.Printf("Unknown keys command: %s\n", os.Args[2])
+		printKeysUsage()
+		os.Exit(1)
+	}
+}
+
+func keysExport(args []string) {
+	fs := flag.NewFlagSet("keys export", flag.ExitOnError)
+	keys := fs.String("keys", "keys", "directory holding the keys, as written by setup; keys missing from it are set up")
+	sign := fs.String("sign", "", "sign the bundle with this key, as written by issuer keygen")
+	verifyingOnly := fs.Bool("verifying-only", false, "leave out the proving key, for bundles shipped to verifiers")
+	slots := fs.Int("slots", 0, "number of chromosome slots in the chromosome circuit")
+	chromosome := fs.String("chromosome", "", "chromosome a chromosome proof shows present (default 22)")
+	backend := addBackendFlags(fs)
+	fs.Parse(args)
+	if fs.NArg() < 2 {
+		fmt.Println("Error: keys export requires proof-type and output")
+		printKeysUsage()
+		os.Exit(1)
+	}
+	proofType := zkgenomics.ProofType(fs.Arg(0))
+
+	backendOption, err := backend.option()
+	if err != nil {
+		log.Fatalf("Invalid backend: %v", err)
+	}
+	opts := []zkgenomics.Option{
+		zkgenomics.WithLogger(stdoutLogger),
+		zkgenomics.WithChromosomeSlots(*slots),
+		zkgenomics.WithKeyStore(&zkgenomics.FileKeyStore{Dir: *keys}),
+		backendOption,
+	}
+	if *chromosome != "" {
+		code := zkgenomics.ChromosomeCode(*chromosome)
+		if code == 0 {
+			log.Fatalf("Unknown chromosome %q", *chromosome)
+		}
+		opts = append(opts, zkgenomics.WithTargetChromosome(code))
+	}
+	generator := zkgenomics.NewProofGenerator(opts...)
+
+	bundle, err := generator.ExportKeys(proofType, fs.Arg(2), !*verifyingOnly)
+	if err != nil {
+		log.Fatalf("Failed to export keys: %v", err)
+	}
+	if *sign != "" {
+		signer, err := readIssuerKey(*sign)
+		if err != nil {
+			log.Fatalf("Failed to read signing key: %v", err)
+		}
+		if err := zkgenomics.SignKeyBundle(bundle, signer); err != nil {
+			log.Fatalf("Failed to sign key bundle: %v", err)
+		}
+	} else {
+		fmt.Println("⚠️  The bundle is unsigned; importers must pass --insecure-unsigned")
+	}
+	if err := proofs.WriteKeyBundle(fs.Arg(1), bundle); err != nil {
+		log.Fatalf("Failed to write key bundle: %v", err)
+	}
+
+	fmt.Printf("✅ Keys for circuit %s exported to: %s\n", bundle.Circuit(), fs.Arg(1))
+	fmt.Printf("   Verifying key fingerprint: %s\n", bundle.VKFingerprint)
+	if *verifyingOnly {
+		fmt.Println("   The bundle holds the verifying key only")
+	}
+}
+
+func keysImport(args []string) {
+	fs := flag.NewFlagSet("keys import", flag.ExitOnError)
+	keys := fs.String("keys", "keys", "directory to store the proving and verifying keys in")
+	issuerKeys := fs.String("issuer-keys", "", "file of trusted keys; the bundle must be signed by one of them")
+	insecure := fs.Bool("insecure-unsigned", false, "import without checking the bundle's signature, checking only its checksums")
+	trustedKeys := fs.String("trusted-keys", "", "also trust the verifying key for the bundle's proof type, appending it to this registry file")
+	fs.Parse(args)
+	if fs.NArg() < 1 {
+		fmt.Println("Error: keys import requires bundle")
+		printKeysUsage()
+		os.Exit(1)
+	}
+	if *issuerKeys == "" && !*insecure {
+		log.Fatalf("keys import requires --issuer-keys to check the bundle's signature, or --insecure-unsigned")
+	}
+
+	bundle, err := proofs.ReadKeyBundle(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to read key bundle: %v", err)
+	}
+	var trusted map[string]crypto.PublicKey
+	if *issuerKeys != "" {
+		trusted, err = zkgenomics.LoadIssuerKeys(*issuerKeys)
+		if err != nil {
+			log.Fatalf("Failed to load issuer keys: %v", err)
+		}
+	}
+	signer, err := bundle.Verify(trusted)
+	if err != nil {
+		log.Fatalf("Key bundle rejected: %v", err)
+	}
+	if signer != "" {
+		fmt.Printf("Key bundle signed by %s\n", signer)
+	}
+
+	circuit := bundle.Circuit()
+	if len(bundle.ProvingKey) > 0 {
+		store := &zkgenomics.FileKeyStore{Dir: *keys}
+		if circuit, err = proofs.ImportKeyBundle(bundle, store, trusted); err != nil {
+			log.Fatalf("Failed to import keys: %v", err)
+		}
+		fmt.Printf("✅ Keys for circuit %s imported into: %s\n", circuit, *keys)
+	} else {
+		fmt.Printf("✅ Verifying key for circuit %s checked\n", circuit)
+	}
+	fmt.Printf("   Verifying key fingerprint: %s\n", bundle.VKFingerprint)
+
+	if bundle.ProofType == "" {
+		return
+	}
+	line := zkgenomics.CircuitRegistryLine(zkgenomics.ProofType(bundle.ProofType), bundle.VerifyingKey, bundle.Circuit())
+	if *trustedKeys == "" {
+		fmt.Println("Trusted key line (add to the file passed to verify --trusted-keys):")
+		fmt.Println(line)
+		return
+	}
+	f, err := os.OpenFile(*trustedKeys, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Fatalf("Failed to open trusted keys: %v", err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, line); err != nil {
+		log.Fatalf("Failed to write trusted keys: %v", err)
+	}
+	fmt.Printf("   Trusted for %s proofs in: %s\n", bundle.ProofType, *trustedKeys)
+}
@@ -1,12 +1,25 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
 
+	"filippo.io/age"
 	"github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/genotools"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
 )
 
 func main() {
@@ -15,15 +28,62 @@ func main() {
 		os.Exit(1)
 	}
 
-	command := os.Args[1]
-	
+	dispatch(os.Args[1])
+}
+
+// dispatch runs command with the arguments that follow it in os.Args
+func dispatch(command string) {
 	switch command {
+	case "help", "-h", "--help":
+		handleHelp()
 	case "generate":
 		handleGenerate()
 	case "verify":
 		handleVerify()
 	case "list":
 		handleList()
+	case "commit":
+		handleCommit(false)
+	case "recommit":
+		handleCommit(true)
+	case "genotools":
+		handleGenotools()
+	case "simulate":
+		handleSimulate()
+	case "check":
+		handleCheck()
+	case "archive":
+		handleArchive()
+	case "present":
+		handlePresent()
+	case "revoke":
+		handleRevoke()
+	case "credential":
+		handleCredential()
+	case "issuer":
+		handleIssuer()
+	case "armor":
+		handleArmor()
+	case "qr":
+		handleQR()
+	case "setup":
+		handleSetup()
+	case "ceremony":
+		handleCeremony()
+	case "aggregate":
+		handleAggregate()
+	case "inspect":
+		handleInspect()
+	case "inspect-proof":
+		handleInspectProof()
+	case "panel":
+		handlePanel()
+	case "batch":
+		handleBatch()
+	case "traits":
+		handleTraits()
+	case "keys":
+		handleKeys()
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		printUsage()
@@ -35,114 +95,977 @@ func printUsage() {
 	fmt.Println("zkgenomics - Zero-Knowledge Genomics Proof Generator")
 	fmt.Println()
 	fmt.Println("Usage:")
-	fmt.Println("  zkgenomics generate <proof-type> <vcf-path> [proving-key] [output]")
-	fmt.Println("  zkgenomics verify <proof-type> <verifying-key> <proof-path>")
-	fmt.Println("  zkgenomics list")
+	fmt.Println("  zkgenomics generate [--chromosome c] [--slots n] [--seed s] [--valid-for d] [--nonce n] [--holder-key k] [--format json|cbor|armor] [--debug-witness] [--keys dir] [--backend groth16|plonk] [--curve c] [--srs path] [--threads n] [--remote-prover url] [--input-format f] [--sample s] [--chain blocks.json --to build] [--identity file] [--passphrase-env VAR] [--attestation file] [--coverage file [--min-depth n]] [--traits file] [--quiet] [--json] <proof-type> <vcf-path> [proving-key] [output]")
+	fmt.Println("  zkgenomics generate [--chrom c] [--pos n --ref a --alt a | --rsid rs<number>] [--mode exact|heterozygous|homozygous_alt|carrier] [--vcf f] [--pk f] [--out f] dynamic <vcf-path>")
+	fmt.Println("  zkgenomics generate --claim <claim.yaml> [--vcf f] [--pk f] [--out f] [vcf-path]")
+	fmt.Println("  zkgenomics generate [--sample s] [--parent-sample s] kinship <child-vcf> <parent-vcf> [output]")
+	fmt.Println("  zkgenomics verify [--ignore-advisory ID] [--advisories src --advisory-keys file] [--revocations file] [--issuer-keys file] [--lab-keys file] [--pin-vk fingerprint] [--trusted-keys file] [--insecure-bundled-key] [--nonce n] [--presentation --challenge c] [--quiet] [--json] [--vk verifying-key] <proof-type> [verifying-key] <proof-path>")
+	fmt.Println("  zkgenomics list [--json]")
+	fmt.Println("  zkgenomics commit [--merkle] <vcf-path | --vcf f>")
+	fmt.Println("  zkgenomics recommit [--merkle] <vcf-path | --vcf f>")
+	fmt.Println("  zkgenomics genotools <extract|scan|validate|commit|synth|liftover> ...")
+	fmt.Println("  zkgenomics simulate [--identity file] [--passphrase-env VAR] [--coverage file [--min-depth n]] [--quiet] --claim <claim.yaml> <vcf-path | --vcf f>")
+	fmt.Println("  zkgenomics check [--proof type | --claim claim.yaml] [--sample s] [--input-format f] [--traits file] [--identity file] [--passphrase-env VAR] <vcf-path | --vcf f>")
+	fmt.Println("  zkgenomics archive <create|verify> ...")
+	fmt.Println("  zkgenomics present <keygen|sign> ...")
+	fmt.Println("  zkgenomics revoke <revocation-list> <proof-path>")
+	fmt.Println("  zkgenomics credential <export|verify> ...")
+	fmt.Println("  zkgenomics issuer <keygen|sign|attest> ...")
+	fmt.Println("  zkgenomics armor <proof-path> [output]")
+	fmt.Println("  zkgenomics qr <split|join> ...")
+	fmt.Println("  zkgenomics setup [--keys dir] [--compress] [--backend groth16|plonk] [--curve c] [--srs path] [--threads n] [--chromosome c] [--slots n] <proof-type> [vcf-path]")
+	fmt.Println("  zkgenomics ceremony <power|init|contribute|verify|start|finalize> ...")
+	fmt.Println("  zkgenomics aggregate <create|verify> ...")
+	fmt.Println("  zkgenomics keys <export|import> ...")
+	fmt.Println("  zkgenomics inspect [--backend groth16|plonk] [--curve c] [--threads n] [--slots n] [--json] [proof-type [vcf-path]]")
+	fmt.Println("  zkgenomics inspect-proof [--json] <proof-path | ->")
+	fmt.Println("  zkgenomics panel [--mode aggregate|bundle] [--sample s] [--keys dir] [--format f] <panel-file> <vcf-path> [output-dir]")
+	fmt.Println("  zkgenomics batch [--workers n] [--keys dir] [--format f] [--report file] [--threads n] [--quiet] [--json] <manifest>")
+	fmt.Println("  zkgenomics traits clinvar ...")
+	fmt.Println("  zkgenomics traits vocabulary")
+	fmt.Println("  zkgenomics help <command>")
+	fmt.Println()
+	fmt.Println("Flags may follow the arguments. Run zkgenomics help <command>, or")
+	fmt.Println("zkgenomics <command> -h, for what a command does and all of its flags.")
 	fmt.Println()
 	fmt.Println("Proof Types:")
-	fmt.Println("  chromosome  - Prove chromosome presence")
-	fmt.Println("  eye_color   - Prove eye color trait")
+	fmt.Println("  chromosome  - Prove chromosome presence (--chromosome, default 22)")
+	fmt.Println("  eye_color   - Prove eye color (brown/hazel/blue) from HERC2 rs12913832")
 	fmt.Println("  brca1       - Prove BRCA1 variant")
-	fmt.Println("  herc2       - Prove HERC2 variant")
+	fmt.Println("  herc2       - Prove whether HERC2 rs12913832 G is carried")
+	fmt.Println("  blood_type  - Prove ABO blood group")
+	fmt.Println("  cohort      - Prove a carrier percentage across a multi-sample VCF")
+	fmt.Println("  cyp2d6      - Prove CYP2D6 metabolizer status")
+	fmt.Println("  actn3       - Prove ACTN3 R577X (RR/RX/XX) status")
+	fmt.Println("  aldh2       - Prove ALDH2 deficiency (alcohol flush)")
+	fmt.Println("  ccr5        - Prove CCR5-Δ32 deletion status")
+	fmt.Println("  mthfr       - Prove joint MTHFR C677T/A1298C status")
+	fmt.Println("  brca2       - Prove BRCA2 pathogenic variant carrier status")
+	fmt.Println("  burden      - Prove a bound on carried variants from a gene region list")
+	fmt.Println("  abcc11      - Prove ABCC11 wet/dry earwax type")
+	fmt.Println("  sex_chromosome - Prove XX/XY sex chromosome configuration")
+	fmt.Println("  dynamic     - Prove the genotype at any variant (--chrom, --pos, --ref and --alt, or --rsid)")
+	fmt.Println("  rs<number>  - Prove the genotype at an rsID, e.g. rs12913832 (verify as rsid)")
+	fmt.Println("  kinship     - Prove two genomes are consistent with parentage")
+	fmt.Println("  custom      - Prove the outcome of a user-defined trait (--trait-file, --outcome)")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  zkgenomics generate eye_color sample.vcf")
+	fmt.Println("  zkgenomics generate --chromosome X chromosome sample.vcf")
+	fmt.Println("  zkgenomics generate rs12913832 sample.vcf")
+	fmt.Println("  zkgenomics generate aldh2 sample.vcf.gz")
+	fmt.Println("  zkgenomics generate rs12913832 genome_23andme.zip")
+	fmt.Println("  zkgenomics generate --identity key.txt aldh2 sample.vcf.age")
+	fmt.Println("  zkgenomics generate --sample NA12878 --parent-sample NA12891 kinship trio.vcf trio.vcf")
+	fmt.Println("  zkgenomics generate --trait-file lactase.yaml custom sample.vcf")
+	fmt.Println("  zkgenomics generate --chrom 15 --pos 28365618 --ref A --alt G --mode carrier dynamic sample.vcf")
 	fmt.Println("  zkgenomics verify eye_color verifying.key proof.data")
+	fmt.Println("  zkgenomics verify --quiet --vk verifying.key eye_color proof.data")
+	fmt.Println("  zkgenomics inspect-proof aldh2_proof.json")
 	fmt.Println("  zkgenomics list")
+	fmt.Println("  zkgenomics simulate --claim claim.yaml sample.vcf")
+	fmt.Println("  zkgenomics check --proof aldh2 sample.vcf")
+	fmt.Println("  zkgenomics batch --workers 4 --report report.json patients.yaml")
+	fmt.Println("  zkgenomics setup aldh2 && zkgenomics generate --keys keys aldh2 sample.vcf")
 }
 
 func handleGenerate() {
-	if len(os.Args) < 4 {
-		fmt.Println("Error: generate requires at least proof-type and vcf-path")
-		printUsage()
-		os.Exit(1)
-	}
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	slots := fs.Int("slots", 0, "number of chromosome slots in the chromosome circuit")
+	chromosome := fs.String("chromosome", "", "chromosome a chromosome proof shows present (default 22)")
+	seed := fs.String("seed", "", "derive setup and proving randomness from this seed, for tests and audits only")
+	validFor := fs.Duration("valid-for", 0, "bind the proof to expire this long after generation, such as 720h")
+	nonce := fs.String("nonce", "", "bind the proof to this challenge from the verifier")
+	format := fs.String("format", "json", "encoding of the proof file: json, cbor or armor")
+	holderKey := fs.String("holder-key", "", "issue the proof to the holder of this base64 ed25519 public key")
+	debugWitness := fs.Bool("debug-witness", false, "write the full and public witness as JSON next to the proof; REVEALS PRIVATE GENOMIC DATA")
+	keys := fs.String("keys", "", "generate with the keys stored in this directory, as written by setup")
+	threads := addThreadsFlag(fs)
+	remoteProver := fs.String("remote-prover", "", "prove on the HTTP proving service at this URL, which receives the private witness; the proof is verified locally")
+	inputFormat := fs.String("input-format", "auto", "format of the genome: auto, vcf, 23andme or ancestrydna raw data")
+	sample := fs.String("sample", "", "sample of a multi-sample VCF to prove from, by name or index from 0 (default the first)")
+	parentSample := fs.String("parent-sample", "", "sample of the parent VCF a kinship proof reads, by name or index from 0")
+	chain := fs.String("chain", "", "JSON liftover blocks lifting genomes in another build over to --to before proving")
+	to := fs.String("to", string(zkgenomics.BuildGRCh37), "genome build the --chain blocks lift to, the build of the proven coordinates")
+	backend := addBackendFlags(fs)
+	identities := addIdentityFlags(fs)
+	attestation := fs.String("attestation", "", "lab attestation of the genome to embed in the proof (default: the one stored next to it)")
+	coverage := fs.String("coverage", "", "gVCF or BED coverage file, such as mosdepth output; negative proofs only prove absences it covers")
+	minDepth := fs.Float64("min-depth", 10, "read depth a --coverage BED interval needs to count as sequenced")
+	traitsPath := fs.String("traits", "", "JSON or YAML trait definitions replacing the loci of the built-in traits")
+	traitFile := fs.String("trait-file", "", "JSON or YAML custom trait a custom proof claims the outcome of")
+	outcome := fs.String("outcome", "", "outcome of the custom trait to claim (default the genome's)")
+	fs.String("vcf", "", "genome to prove from, in place of the vcf-path argument")
+	fs.String("pk", "", "proving key to prove with, in place of the proving-key argument")
+	fs.String("out", "", "file to write the proof to, in place of the output argument (default <proof-type>_proof.json)")
+	fs.StringVar(chromosome, "chrom", "", "alias of --chromosome")
+	position := fs.Uint64("position", 0, "position of the variant a dynamic proof reads")
+	fs.Uint64Var(position, "pos", 0, "alias of --position")
+	rsID := fs.String("rsid", "", "rsID of the variant a dynamic proof reads, in place of --position, --ref and --alt; the proof verifies as rsid")
+	ref := fs.String("ref", "", "REF allele of the variant a dynamic proof reads")
+	alt := fs.String("alt", "", "ALT allele of the variant a dynamic proof reads")
+	mode := fs.String("mode", "", "statement of a dynamic or rsID proof: exact, heterozygous, homozygous_alt or carrier (default exact)")
+	claimPath := fs.String("claim", "", "YAML claim file describing the proof, in place of the proof-type argument")
+	addQuietFlag(fs)
+	addJSONFlag(fs)
+	setUsage(fs, "generate [flags] <proof-type> <vcf-path> [proving-key] [output]",
+		"Generates a proof of proof-type from the genome at vcf-path, a VCF, gVCF or",
+		"raw data file, plain, gzip-compressed or age-encrypted. Inputs may be given",
+		"as flags instead: --vcf, --pk and --out, and --claim in place of proof-type.",
+		"",
+		"A dynamic proof reads the variant given by --chrom, --pos, --ref and --alt,",
+		"or by --rsid, and records them in the proof; a custom proof reads the trait",
+		"in --trait-file; a kinship proof takes the child and parent VCFs as its two",
+		"genome arguments.",
+		"",
+		"Examples:",
+		"  zkgenomics generate aldh2 sample.vcf.gz",
+		"  zkgenomics generate --vcf sample.vcf --out proof.json --chrom 15 --pos 28365618 --ref A --alt G dynamic",
+		"  zkgenomics generate --rsid rs12913832 --mode carrier dynamic sample.vcf",
+		"  zkgenomics generate --claim claim.yaml --vcf sample.vcf --quiet")
+	args := parseArgs(fs, os.Args[2:])
+	startJSONOutput()
 
-	proofType := zkgenomics.ProofType(os.Args[2])
-	vcfPath := os.Args[3]
-	
-	var provingKeyPath, outputPath string
-	if len(os.Args) > 4 {
-		provingKeyPath = os.Args[4]
+	var request zkgenomics.ProofRequest
+	if *claimPath != "" {
+		// The claim names the proof type, so the genome comes first
+		spec, err := zkgenomics.LoadClaimSpec(*claimPath)
+		if err != nil {
+			log.Fatalf("Failed to load claim: %v", err)
+		}
+		request.Claim = spec
+		args = append([]string{string(spec.ProofType)}, args...)
 	}
-	if len(os.Args) > 5 {
-		outputPath = os.Args[5]
-	} else {
+	if len(args) < 1 {
+		usageError(fs, "generate requires proof-type, or --claim")
+	}
+	proofType := zkgenomics.ProofType(args[0])
+	vcfPath := operand(fs, args, 1, "vcf")
+	provingKeyPath := operand(fs, args, 2, "pk")
+	outputPath := operand(fs, args, 3, "out")
+	if vcfPath == "" {
+		usageError(fs, "generate requires vcf-path, or --vcf")
+	}
+	if outputPath == "" {
 		outputPath = fmt.Sprintf("%s_proof.json", proofType)
 	}
 
-	generator := zkgenomics.NewProofGenerator()
+	opts := append(reportingOptions(),
+		zkgenomics.WithChromosomeSlots(*slots),
+		zkgenomics.WithThreads(*threads),
+	)
+	if *chromosome != "" {
+		code := zkgenomics.ChromosomeCode(*chromosome)
+		if code == 0 {
+			log.Fatalf("Unknown chromosome %q", *chromosome)
+		}
+		opts = append(opts, zkgenomics.WithTargetChromosome(code))
+	}
+	if *seed != "" {
+		fmt.Println("⚠️  Seeded proofs are reproducible but not secure; do not share them with relying parties")
+		opts = append(opts, zkgenomics.WithSeed([]byte(*seed)))
+	}
+	if *keys != "" {
+		opts = append(opts, zkgenomics.WithKeyStore(&zkgenomics.FileKeyStore{Dir: *keys}))
+	}
+	if *remoteProver != "" {
+		fmt.Printf("⚠️  The witness, including the genotypes proven, is sent to %s\n", *remoteProver)
+		opts = append(opts, zkgenomics.WithExternalProver(&zkgenomics.RemoteProver{URL: *remoteProver}))
+	}
+	if *inputFormat != "auto" {
+		format, err := proofs.ParseInputFormat(*inputFormat)
+		if err != nil {
+			log.Fatalf("Invalid input format: %v", err)
+		}
+		opts = append(opts, zkgenomics.WithInputFormat(format))
+	}
+	if *chain != "" {
+		blocks, err := genotools.LoadLiftoverBlocks(*chain)
+		if err != nil {
+			log.Fatalf("Failed to load liftover blocks: %v", err)
+		}
+		opts = append(opts, zkgenomics.WithLiftover(zkgenomics.GenomeBuild(*to), blocks))
+	}
+	if *coverage != "" {
+		source, err := zkgenomics.OpenCoverage(*coverage, *minDepth)
+		if err != nil {
+			log.Fatalf("Failed to load coverage: %v", err)
+		}
+		opts = append(opts, zkgenomics.WithCoverage(source))
+	}
+	if *traitsPath != "" {
+		registry, err := zkgenomics.LoadTraitRegistry(*traitsPath)
+		if err != nil {
+			log.Fatalf("Failed to load trait definitions: %v", err)
+		}
+		opts = append(opts, zkgenomics.WithTraitRegistry(registry))
+	}
+	backendOption, err := backend.option()
+	if err != nil {
+		log.Fatalf("Invalid backend: %v", err)
+	}
+	opts = append(opts, backendOption)
+	if identityOption, err := identities.option(); err != nil {
+		log.Fatalf("Failed to load identities: %v", err)
+	} else if identityOption != nil {
+		opts = append(opts, identityOption)
+	}
+	generator := zkgenomics.NewProofGenerator(opts...)
 	
-	fmt.Printf("Generating %s proof from %s...\n", proofType, vcfPath)
+	infof("Generating %s proof from %s...\n", proofType, vcfPath)
+
+	ctx, stop := interruptContext()
+	defer stop()
 	
-	proofData, err := generator.GenerateProof(proofType, vcfPath, provingKeyPath, outputPath)
+	request.ProofType = proofType
+	request.VCFPath = vcfPath
+	request.ProvingKeyPath = provingKeyPath
+	request.OutputPath = outputPath
+	request.Sample = *sample
+	if *format != "json" && *format != "cbor" && *format != "armor" {
+		log.Fatalf("Unknown format %q: expected json, cbor or armor", *format)
+	}
+	if *validFor > 0 {
+		request.NotAfter = time.Now().Add(*validFor).UTC().Truncate(time.Second)
+	}
+	if *nonce != "" {
+		request.Nonce = []byte(*nonce)
+	}
+	if *holderKey != "" {
+		key, err := base64.StdEncoding.DecodeString(*holderKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			log.Fatalf("Invalid holder key: expected a base64 ed25519 public key")
+		}
+		request.HolderKey = key
+	}
+	if *attestation != "" {
+		request.Attestation, err = zkgenomics.ReadGenomeAttestation(*attestation)
+		if err != nil {
+			log.Fatalf("Failed to read attestation: %v", err)
+		}
+	}
+	if *debugWitness {
+		fmt.Println("⚠️  WARNING: --debug-witness writes the full witness, including your PRIVATE genomic inputs, in plain text")
+		fmt.Println("⚠️  Use it only to debug circuits; never share or commit the witness files")
+		request.DebugWitness = &zkgenomics.DebugWitness{}
+	}
+	claimMode := zkgenomics.ClaimExactGenotype
+	if *mode != "" {
+		if claimMode, err = proofs.ParseClaimMode(*mode); err != nil {
+			log.Fatalf("Invalid mode: %v", err)
+		}
+	}
+	if request.Claim != nil {
+		// The claim file describes the proof completely
+	} else if zkgenomics.IsRsID(string(proofType)) {
+		request.Claim = &zkgenomics.ClaimSpec{ProofType: zkgenomics.RsIDProofType, RsID: string(proofType), Mode: claimMode}
+	} else if proofType == zkgenomics.DynamicProofType && *rsID != "" {
+		if !zkgenomics.IsRsID(*rsID) {
+			usageError(fs, "--rsid %s is not an rsID such as rs12913832", *rsID)
+		}
+		request.Claim = &zkgenomics.ClaimSpec{ProofType: zkgenomics.RsIDProofType, RsID: *rsID, Mode: claimMode}
+	} else if proofType == zkgenomics.DynamicProofType {
+		if *position == 0 || *ref == "" || *alt == "" {
+			usageError(fs, "generate dynamic requires --pos, --ref and --alt, or --rsid")
+		}
+		request.Claim = &zkgenomics.ClaimSpec{
+			ProofType:  zkgenomics.DynamicProofType,
+			Chromosome: zkgenomics.ChromosomeCode(*chromosome),
+			Position:   *position,
+			Ref:        *ref,
+			Alt:        *alt,
+			Mode:       claimMode,
+		}
+	} else if proofType == zkgenomics.CustomProofType {
+		if *traitFile == "" {
+			usageError(fs, "generate custom requires --trait-file")
+		}
+		trait, err := zkgenomics.LoadCustomTrait(*traitFile)
+		if err != nil {
+			log.Fatalf("Failed to load custom trait: %v", err)
+		}
+		request.Claim = &zkgenomics.ClaimSpec{ProofType: zkgenomics.CustomProofType, Trait: trait, Outcome: *outcome}
+	} else if proofType == zkgenomics.KinshipProofType {
+		// The second VCF takes the place of the proving key argument
+		if provingKeyPath == "" {
+			usageError(fs, "generate kinship requires child-vcf and parent-vcf")
+		}
+		request.Claim = &zkgenomics.ClaimSpec{ProofType: zkgenomics.KinshipProofType, ParentVCF: provingKeyPath, ParentSample: *parentSample}
+		request.ProvingKeyPath = ""
+	}
+	response, err := generator.Generate(ctx, request)
+	if request.DebugWitness != nil && request.DebugWitness.Full != nil {
+		// Written even when proving fails, which is when the witness helps
+		writeDebugWitness(outputPath, request.DebugWitness)
+	}
+	exitIfCancelled(err)
+	if err != nil && jsonOutput {
+		failJSON(&proofOutput{ProofType: string(proofType)}, statusError, err)
+	}
+	var staleErr *zkgenomics.StaleCommitmentError
+	if errors.As(err, &staleErr) {
+		fmt.Printf("❌ %v\n", err)
+		fmt.Printf("If the changes are intended, run: zkgenomics recommit %s\n", vcfPath)
+		os.Exit(1)
+	}
 	if err != nil {
 		log.Fatalf("Failed to generate proof: %v", err)
 	}
+	proofData := response.ProofData
 
-	fmt.Printf("Proof generation result: %s\n", proofData.Result.String())
+	infof("Proof generation result: %s\n", proofData.Result.String())
 	
 	if proofData.Result == zkgenomics.ProofSuccess {
-		// Save proof data to JSON file
-		jsonData, err := json.MarshalIndent(proofData, "", "  ")
+		// Save proof data to file
+		encoded, err := encodeOutput(proofData, *format)
 		if err != nil {
 			log.Fatalf("Failed to serialize proof data: %v", err)
 		}
 		
-		err = os.WriteFile(outputPath, jsonData, 0644)
+		err = os.WriteFile(outputPath, encoded, 0644)
 		if err != nil {
 			log.Fatalf("Failed to write proof data to file: %v", err)
 		}
 		
 		fmt.Printf("✅ Proof successfully generated and saved to: %s\n", outputPath)
-		fmt.Printf("Proof size: %d bytes\n", len(proofData.Proof))
-		fmt.Printf("Verifying key size: %d bytes\n", len(proofData.VerifyingKey))
-		fmt.Printf("Public witness size: %d bytes\n", len(proofData.PublicWitness))
+		infof("Proof size: %d bytes\n", len(proofData.Proof))
+		infof("Verifying key size: %d bytes\n", len(proofData.VerifyingKey))
+		if fingerprint, err := proofData.VKFingerprint(); err == nil {
+			infof("Verifying key fingerprint: %s\n", fingerprint)
+		}
+		infof("Public witness size: %d bytes\n", len(proofData.PublicWitness))
+
+		var manifestPath string
+		if response.Manifest != nil {
+			manifestPath = outputPath + ".manifest.json"
+			manifestData, err := json.MarshalIndent(response.Manifest, "", "  ")
+			if err != nil {
+				log.Fatalf("Failed to serialize reproducibility manifest: %v", err)
+			}
+			if err := os.WriteFile(manifestPath, manifestData, 0644); err != nil {
+				log.Fatalf("Failed to write reproducibility manifest: %v", err)
+			}
+			infof("Reproducibility manifest saved to: %s\n", manifestPath)
+		}
+		if jsonOutput {
+			out := newProofOutput(response.ProofType, proofData, nil)
+			out.Output = outputPath
+			out.Manifest = manifestPath
+			out.TimingsMS = timingsOutput(response.Timings)
+			printJSON(out)
+		}
 	} else {
+		if jsonOutput {
+			failJSON(&proofOutput{ProofType: string(response.ProofType)}, statusFail, nil)
+		}
 		fmt.Printf("❌ Proof generation failed\n")
 		os.Exit(1)
 	}
 }
 
+// interruptContext returns a context that is cancelled by Ctrl-C
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// exitIfCancelled exits with the conventional status for SIGINT if err
+// reports that the command was interrupted
+func exitIfCancelled(err error) {
+	if errors.Is(err, context.Canceled) {
+		fmt.Println()
+		fmt.Println("Cancelled")
+		os.Exit(130)
+	}
+}
+
+// printProgress renders progress updates on a single terminal line
+func printProgress(stage string, percent float64, message string) {
+	fmt.Printf("\r%s: %5.1f%% (%s)\033[K", stage, percent, message)
+	if percent >= 100 {
+		fmt.Println()
+	}
+}
+
+// stdoutLogger prints the library's progress messages the CLI has always shown
+var stdoutLogger = zkgenomics.NewWriterLogger(os.Stdout, zkgenomics.LevelInfo)
+
+// stringList collects the values of a repeatable flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// backendFlags are the flags choosing the proving system proofs are made
+// with and the curve they are made over
+type backendFlags struct {
+	backend string
+	curve   string
+	srs     string
+}
+
+func addBackendFlags(fs *flag.FlagSet) *backendFlags {
+	bf := &backendFlags{}
+	fs.StringVar(&bf.backend, "backend", "groth16", "proving system: groth16, or plonk for a universal setup")
+	fs.StringVar(&bf.curve, "curve", "bn254", "curve proofs are made over: bn254, bls12_381, bls12_377 or bw6_761")
+	fs.StringVar(&bf.srs, "srs", "", "KZG SRS of a universal setup ceremony for plonk, over --curve (default: generated locally, insecure)")
+	return bf
+}
+
+// option returns the option selecting the chosen backend and curve
+func (bf *backendFlags) option() (zkgenomics.Option, error) {
+	backend, err := zkgenomics.BackendNamed(bf.backend, bf.curve)
+	if err != nil {
+		return nil, err
+	}
+	if bf.srs == "" {
+		return zkgenomics.WithBackend(backend), nil
+	}
+	if backend.Name() != "plonk" {
+		return nil, fmt.Errorf("--srs applies only to the plonk backend")
+	}
+	srs, err := zkgenomics.ReadKZGSRS(backend.Curve(), bf.srs)
+	if err != nil {
+		return nil, err
+	}
+	return zkgenomics.WithBackend(&zkgenomics.PlonkBackend{CurveID: backend.Curve(), SRS: srs}), nil
+}
+
+// addThreadsFlag adds the --threads flag capping the CPU threads of setup and
+// proving
+func addThreadsFlag(fs *flag.FlagSet) *int {
+	return fs.Int("threads", 0, "cap the CPU threads setup and proving use, such as 1 on a small VM (default: every CPU)")
+}
+
+// identityFlags are the flags giving the identities that decrypt
+// age-encrypted genomes
+type identityFlags struct {
+	identity      string
+	passphraseEnv string
+}
+
+func addIdentityFlags(fs *flag.FlagSet) *identityFlags {
+	idf := &identityFlags{}
+	fs.StringVar(&idf.identity, "identity", "", "age identity file, as written by age-keygen, decrypting an encrypted genome")
+	fs.StringVar(&idf.passphraseEnv, "passphrase-env", "", "environment variable holding the passphrase of a passphrase-encrypted genome")
+	return idf
+}
+
+// option returns the option decrypting genomes with the given identities,
+// or nil if none is given
+func (idf *identityFlags) option() (zkgenomics.Option, error) {
+	var identities []age.Identity
+	if idf.identity != "" {
+		loaded, err := proofs.LoadIdentities(idf.identity)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, loaded...)
+	}
+	if idf.passphraseEnv != "" {
+		passphrase := os.Getenv(idf.passphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("environment variable %s holds no passphrase", idf.passphraseEnv)
+		}
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	if len(identities) == 0 {
+		return nil, nil
+	}
+	return zkgenomics.WithIdentities(identities...), nil
+}
+
+// keyTrustFlags are the flags choosing the verifying keys verify commands trust
+type keyTrustFlags struct {
+	trustedKeys string
+	insecure    bool
+}
+
+func addKeyTrustFlags(fs *flag.FlagSet) *keyTrustFlags {
+	kf := &keyTrustFlags{}
+	fs.StringVar(&kf.trustedKeys, "trusted-keys", "", "file of trusted verifying keys, one \"<proof-type> <base64 key> [<circuit-id> <circuit-version>]\" per line")
+	fs.BoolVar(&kf.insecure, "insecure-bundled-key", false, "trust the verifying key a proof carries (development only)")
+	return kf
+}
+
+// apply sets the keys generator trusts, reading the registry file if one is
+// named
+func (kf *keyTrustFlags) apply(generator *zkgenomics.ProofGenerator) error {
+	generator.InsecureBundledKeys = kf.insecure
+	if kf.trustedKeys == "" {
+		return nil
+	}
+	registry, err := zkgenomics.LoadVerifyingKeyRegistry(kf.trustedKeys)
+	if err != nil {
+		return err
+	}
+	generator.KeyRegistry = registry
+	return nil
+}
+
 func handleVerify() {
-	if len(os.Args) < 5 {
-		fmt.Println("Error: verify requires proof-type, verifying-key, and proof-path")
-		printUsage()
-		os.Exit(1)
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	var ignored stringList
+	fs.Var(&ignored, "ignore-advisory", "override the advisory with this ID (repeatable)")
+	advisories := fs.String("advisories", "", "URL or path of a signed advisory list to check in addition to the bundled one")
+	advisoryKeys := fs.String("advisory-keys", "", "file of trusted advisory signing keys")
+	nonce := fs.String("nonce", "", "require the proof to be bound to this challenge")
+	revocations := fs.String("revocations", "", "file of revoked proof IDs to check the proof against")
+	issuerKeys := fs.String("issuer-keys", "", "file of trusted issuer keys; the proof must be signed by one of them")
+	labKeys := fs.String("lab-keys", "", "file of trusted lab keys; the proof must carry an attestation of its genome by one of them and disclose its Merkle root")
+	var pinned stringList
+	fs.Var(&pinned, "pin-vk", "accept only a verifying key with this fingerprint (repeatable)")
+	presentation := fs.Bool("presentation", false, "proof-path is a presentation signed by the holder the proof was issued to")
+	challenge := fs.String("challenge", "", "require the presentation to answer this challenge, issued to its holder")
+	trust := addKeyTrustFlags(fs)
+	vk := fs.String("vk", "", "verifying key to verify with, in place of the verifying-key argument (default the key trusted for proof-type)")
+	addQuietFlag(fs)
+	addJSONFlag(fs)
+	setUsage(fs, "verify [flags] <proof-type> [verifying-key] <proof-path>",
+		"Verifies the proof at proof-path as a proof of proof-type and prints the",
+		"public inputs it discloses. The verifying key may be given as an argument",
+		"or with --vk; without one the proof is checked against the key trusted for",
+		"proof-type, from --trusted-keys or the bundled keys.",
+		"",
+		"Examples:",
+		"  zkgenomics verify eye_color verifying.key proof.json",
+		"  zkgenomics verify --vk verifying.key --quiet eye_color proof.json")
+	args := parseArgs(fs, os.Args[2:])
+	startJSONOutput()
+	if len(args) < 2 || len(args) > 3 {
+		usageError(fs, "verify requires proof-type and proof-path")
+	}
+	if *advisories != "" && *advisoryKeys == "" {
+		usageError(fs, "--advisories requires --advisory-keys")
+	}
+	if *presentation != (*challenge != "") {
+		usageError(fs, "--presentation and --challenge must be given together")
 	}
 
-	proofType := zkgenomics.ProofType(os.Args[2])
-	verifyingKeyPath := os.Args[3]
-	proofPath := os.Args[4]
+	proofType := zkgenomics.ProofType(args[0])
+	proofPath := args[len(args)-1]
+	verifyingKeyPath := *vk
+	if len(args) == 3 {
+		verifyingKeyPath = operand(fs, args, 1, "vk")
+	}
 
-	generator := zkgenomics.NewProofGenerator()
+	opts := append(reportingOptions(), zkgenomics.WithIgnoredAdvisories(ignored...))
+	generator := zkgenomics.NewProofGenerator(opts...)
+	if len(pinned) > 0 {
+		generator.PinnedVKFingerprints = map[zkgenomics.ProofType][]string{proofType: pinned}
+	}
+	if err := trust.apply(generator); err != nil {
+		log.Fatalf("Failed to load trusted keys: %v", err)
+	}
+	if verifyingKeyPath != "" {
+		// The verifying key named on the command line is trusted
+		if generator.KeyRegistry == nil {
+			generator.KeyRegistry = zkgenomics.NewVerifyingKeyRegistry()
+		}
+		if err := generator.KeyRegistry.RegisterFrom(context.Background(), proofType, zkgenomics.FromFile(verifyingKeyPath)); err != nil {
+			log.Fatalf("Failed to load verifying key: %v", err)
+		}
+	}
+	if *revocations != "" {
+		generator.Revocations = &zkgenomics.FileRevocationList{Path: *revocations}
+	}
+	if *issuerKeys != "" {
+		keys, err := zkgenomics.LoadIssuerKeys(*issuerKeys)
+		if err != nil {
+			log.Fatalf("Failed to load issuer keys: %v", err)
+		}
+		generator.IssuerKeys = keys
+	}
+	if *labKeys != "" {
+		keys, err := zkgenomics.LoadIssuerKeys(*labKeys)
+		if err != nil {
+			log.Fatalf("Failed to load lab keys: %v", err)
+		}
+		generator.LabKeys = keys
+	}
+	if *advisories != "" {
+		if err := generator.UpdateAdvisories(*advisories, *advisoryKeys); err != nil {
+			log.Fatalf("Failed to load advisories: %v", err)
+		}
+	}
 	
-	fmt.Printf("Verifying %s proof...\n", proofType)
+	infof("Verifying %s proof...\n", proofType)
 	
-	result, err := generator.VerifyProof(proofType, verifyingKeyPath, proofPath)
+	ctx, stop := interruptContext()
+	defer stop()
+
+	start := time.Now()
+	var result *zkgenomics.VerificationResult
+	var err error
+	if *nonce != "" || *presentation {
+		result, err = verifyBound(generator, proofType, verifyingKeyPath, proofPath, []byte(*nonce), []byte(*challenge))
+	} else {
+		result, err = generator.VerifyProofContext(ctx, proofType, verifyingKeyPath, proofPath)
+	}
+	exitIfCancelled(err)
+	if err != nil && jsonOutput {
+		failJSON(&proofOutput{ProofType: string(proofType)}, statusError, err)
+	}
 	if err != nil {
 		log.Fatalf("Failed to verify proof: %v", err)
 	}
+	if jsonOutput {
+		// A presentation is not a proof file; its proof is described by the
+		// verified public inputs alone
+		proofData, _ := proofs.ReadProofData(proofPath)
+		out := newProofOutput(proofType, proofData, result.ParsedPublicInputs)
+		out.TimingsMS = map[string]float64{"verify": milliseconds(time.Since(start))}
+		if result.Result != zkgenomics.ProofSuccess {
+			// Nothing the proof claims has been verified
+			out.Claim = nil
+			failJSON(out, statusFail, result.Error)
+		}
+		printJSON(out)
+		return
+	}
 
-	fmt.Printf("Verification result: %s\n", result.Result.String())
+	infof("Verification result: %s\n", result.Result.String())
 	
 	if result.Result == zkgenomics.ProofSuccess {
 		fmt.Println("✅ Proof verification succeeded!")
+		if proofData, err := proofs.ReadProofData(proofPath); err == nil && proofData.Parameters != nil {
+			printParameters(proofData.Parameters)
+		}
+		if len(result.ParsedPublicInputs) > 0 {
+			fmt.Println("Public inputs:")
+			for _, name := range slices.Sorted(maps.Keys(result.ParsedPublicInputs)) {
+				fmt.Printf("  %s = %s\n", name, result.ParsedPublicInputs[name])
+			}
+		}
 	} else {
 		fmt.Println("❌ Proof verification failed!")
 		if result.Error != nil {
 			fmt.Printf("Error: %v\n", result.Error)
 		}
+		var advisoryErr *zkgenomics.AdvisoryError
+		if errors.As(result.Error, &advisoryErr) {
+			fmt.Printf("Rerun with --ignore-advisory %s to accept the proof anyway\n", advisoryErr.Advisory.ID)
+		}
 		os.Exit(1)
 	}
 }
 
+// printParameters lists the parameters a proof records, which verification
+// has checked against its public inputs
+func printParameters(params *zkgenomics.ProofParameters) {
+	fmt.Println("Parameters:")
+	for _, param := range []struct {
+		name  string
+		value any
+		set   bool
+	}{
+		{"rsid", params.RsID, params.RsID != ""},
+		{"chromosome", params.Chromosome, params.Chromosome != 0},
+		{"position", params.Position, params.Position != 0},
+		{"ref", params.Ref, params.Ref != ""},
+		{"alt", params.Alt, params.Alt != ""},
+		{"mode", params.Mode, params.Mode != ""},
+	} {
+		if param.set {
+			fmt.Printf("  %s = %v\n", param.name, param.value)
+		}
+	}
+}
+
+// writeDebugWitness writes the full and public witness next to the proof at
+// outputPath, readable only by the owner
+func writeDebugWitness(outputPath string, witness *zkgenomics.DebugWitness) {
+	files := []struct {
+		path   string
+		values map[string]string
+	}{
+		{outputPath + ".witness.json", witness.Full},
+		{outputPath + ".public-witness.json", witness.Public},
+	}
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.values, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to serialize witness: %v", err)
+		}
+		if err := os.WriteFile(file.path, append(data, '\n'), 0600); err != nil {
+			log.Fatalf("Failed to write witness: %v", err)
+		}
+	}
+	fmt.Printf("⚠️  PRIVATE witness written to: %s (delete it when done)\n", files[0].path)
+	fmt.Printf("Public witness written to: %s\n", files[1].path)
+}
+
+// encodeOutput encodes v, a proof or presentation, as json or cbor, or a
+// proof as armor
+func encodeOutput(v interface{ MarshalCBOR() ([]byte, error) }, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(v, "", "  ")
+	case "cbor":
+		return v.MarshalCBOR()
+	case "armor":
+		proofData, ok := v.(*proofs.ProofData)
+		if !ok {
+			return nil, fmt.Errorf("only proofs can be armored")
+		}
+		return proofs.EncodeArmor(proofData)
+	default:
+		return nil, fmt.Errorf("unknown format %q: expected json, cbor or armor", format)
+	}
+}
+
+func handleArmor() {
+	if len(os.Args) < 3 {
+		fmt.Println("Error: armor requires proof-path")
+		printUsage()
+		os.Exit(1)
+	}
+
+	proofData, err := proofs.ReadProofData(os.Args[2])
+	if err != nil {
+		log.Fatalf("Failed to read proof: %v", err)
+	}
+	armored, err := proofs.EncodeArmor(proofData)
+	if err != nil {
+		log.Fatalf("Failed to armor proof: %v", err)
+	}
+	if len(os.Args) < 4 {
+		os.Stdout.Write(armored)
+		return
+	}
+	if err := os.WriteFile(os.Args[3], armored, 0644); err != nil {
+		log.Fatalf("Failed to write armored proof: %v", err)
+	}
+	fmt.Printf("✅ Armored proof written to: %s\n", os.Args[3])
+}
+
+func handleRevoke() {
+	if len(os.Args) < 4 {
+		fmt.Println("Error: revoke requires revocation-list and proof-path")
+		printUsage()
+		os.Exit(1)
+	}
+
+	proofData, err := proofs.ReadProofData(os.Args[3])
+	if err != nil {
+		log.Fatalf("Failed to read proof: %v", err)
+	}
+	id := zkgenomics.ProofID(proofData)
+	list := &zkgenomics.FileRevocationList{Path: os.Args[2]}
+	if err := list.Revoke(id); err != nil {
+		log.Fatalf("Failed to revoke proof: %v", err)
+	}
+	fmt.Printf("✅ Revoked proof %s in: %s\n", id, os.Args[2])
+}
+
 func handleList() {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	addJSONFlag(fs)
+	setUsage(fs, "list [--json]", "Lists the proof types that can be generated and verified.")
+	parseArgs(fs, os.Args[2:])
+	startJSONOutput()
+
 	generator := zkgenomics.NewProofGenerator()
 	supportedTypes := generator.GetSupportedProofTypes()
+	if jsonOutput {
+		printJSON(struct {
+			Status     string                 `json:"status"`
+			ProofTypes []zkgenomics.ProofType `json:"proof_types"`
+		}{statusSuccess, supportedTypes})
+		return
+	}
 	
 	fmt.Println("Supported proof types:")
 	for _, proofType := range supportedTypes {
 		fmt.Printf("  - %s\n", proofType)
 	}
-}
\ No newline at end of file
+}
+
+func handleCommit(recommit bool) {
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	merkle := fs.Bool("merkle", false, "also record a Merkle root that committed proofs are bound to")
+	fs.String("vcf", "", "genome to commit, in place of the vcf-path argument")
+	setUsage(fs, os.Args[1]+" [flags] <vcf-path>",
+		"Records a digest of the genome that proofs generated from it are bound to.",
+		"recommit replaces the digest of a genome that has changed on purpose.")
+	args := parseArgs(fs, os.Args[2:])
+
+	vcfPath := operand(fs, args, 0, "vcf")
+	if vcfPath == "" {
+		usageError(fs, "%s requires vcf-path", os.Args[1])
+	}
+	generator := zkgenomics.NewProofGenerator()
+
+	var commitment *zkgenomics.GenomeCommitment
+	var err error
+	if *merkle {
+		generator.Progress = printProgress
+		commitment, err = generator.CommitGenomeMerkle(vcfPath, recommit)
+	} else if recommit {
+		commitment, err = generator.Recommit(vcfPath)
+	} else {
+		commitment, err = generator.CommitGenome(vcfPath)
+	}
+	if err != nil {
+		log.Fatalf("Failed to commit genome: %v", err)
+	}
+
+	fmt.Printf("✅ Genome committed: %s\n", vcfPath)
+	fmt.Printf("Digest: %s\n", commitment.Digest)
+	if commitment.MerkleRoot != "" {
+		fmt.Printf("Merkle root: %s\n", commitment.MerkleRoot)
+	}
+}
+
+// handleCheck validates a genome, and its coverage of the loci a proof
+// reads, before a proving run
+func handleCheck() {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	proofType := fs.String("proof", "", "proof type whose loci the genome must cover")
+	claimPath := fs.String("claim", "", "YAML claim file describing the proof")
+	sample := fs.String("sample", "", "sample of a multi-sample VCF, by name or 0-based index")
+	inputFormat := fs.String("input-format", "auto", "format of the genome: auto, vcf, 23andme or ancestrydna raw data")
+	traitsPath := fs.String("traits", "", "JSON or YAML trait definitions replacing the loci of the built-in traits")
+	identities := addIdentityFlags(fs)
+	fs.String("vcf", "", "genome to check, in place of the vcf-path argument")
+	setUsage(fs, "check [flags] <vcf-path>",
+		"Validates a genome before a proving run: its format, build and samples and,",
+		"with --proof or --claim, its coverage of the loci the proof reads.")
+	args := parseArgs(fs, os.Args[2:])
+	vcfPath := operand(fs, args, 0, "vcf")
+	if vcfPath == "" {
+		usageError(fs, "check requires vcf-path")
+	}
+	req := zkgenomics.ProofRequest{ProofType: zkgenomics.ProofType(*proofType), VCFPath: vcfPath, Sample: *sample}
+	if *claimPath != "" {
+		spec, err := zkgenomics.LoadClaimSpec(*claimPath)
+		if err != nil {
+			log.Fatalf("Failed to load claim: %v", err)
+		}
+		req.Claim = spec
+	}
+
+	var opts []zkgenomics.Option
+	if *inputFormat != "auto" {
+		format, err := proofs.ParseInputFormat(*inputFormat)
+		if err != nil {
+			log.Fatalf("Invalid input format: %v", err)
+		}
+		opts = append(opts, zkgenomics.WithInputFormat(format))
+	}
+	if *traitsPath != "" {
+		registry, err := zkgenomics.LoadTraitRegistry(*traitsPath)
+		if err != nil {
+			log.Fatalf("Failed to load trait definitions: %v", err)
+		}
+		opts = append(opts, zkgenomics.WithTraitRegistry(registry))
+	}
+	if identityOption, err := identities.option(); err != nil {
+		log.Fatalf("Failed to load identities: %v", err)
+	} else if identityOption != nil {
+		opts = append(opts, identityOption)
+	}
+	generator := zkgenomics.NewProofGenerator(opts...)
+
+	report, err := generator.Preflight(req)
+	if err != nil {
+		log.Fatalf("Failed to check %s: %v", req.VCFPath, err)
+	}
+
+	fmt.Printf("Genome: %s (%s", report.VCFPath, report.Format)
+	if report.Build != zkgenomics.BuildUnknown {
+		fmt.Printf(", %s", report.Build)
+	}
+	fmt.Println(")")
+	if report.Records > 0 {
+		fmt.Printf("  %d records on %s\n", report.Records, strings.Join(report.Chromosomes, ", "))
+	}
+	if len(report.Samples) > 0 {
+		fmt.Printf("  samples: %s\n", strings.Join(report.Samples, ", "))
+	}
+	for _, locus := range report.Loci {
+		fmt.Printf("  %-40s %s\n", locus.Variant.Trait, locus.Status)
+	}
+	for _, problem := range report.Problems {
+		icon := "⚠️ "
+		if problem.Severity == zkgenomics.PreflightError {
+			icon = "❌"
+		}
+		fmt.Printf("%s %s\n", icon, problem.Message)
+	}
+	if !report.OK() {
+		os.Exit(1)
+	}
+	fmt.Println("✅ Genome is ready for proving")
+}
+
+// handleSimulate runs extraction and claim evaluation, then prints exactly the
+// public values a verifier would see, without generating a proof
+func handleSimulate() {
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+	claimPath := fs.String("claim", "", "YAML claim file describing the proof")
+	identities := addIdentityFlags(fs)
+	coverage := fs.String("coverage", "", "gVCF or BED coverage file, such as mosdepth output; negative claims only hold where it covers")
+	minDepth := fs.Float64("min-depth", 10, "read depth a --coverage BED interval needs to count as sequenced")
+	fs.String("vcf", "", "genome to evaluate the claim on, in place of the vcf-path argument")
+	addQuietFlag(fs)
+	setUsage(fs, "simulate [flags] --claim <claim.yaml> <vcf-path>",
+		"Evaluates a claim on a genome and prints exactly the public values a",
+		"verifier would see, without generating a proof.")
+	args := parseArgs(fs, os.Args[2:])
+	vcfPath := operand(fs, args, 0, "vcf")
+	if *claimPath == "" || vcfPath == "" {
+		usageError(fs, "simulate requires --claim and vcf-path")
+	}
+
+	spec, err := zkgenomics.LoadClaimSpec(*claimPath)
+	if err != nil {
+		log.Fatalf("Failed to load claim: %v", err)
+	}
+
+	opts := reportingOptions()
+	if identityOption, err := identities.option(); err != nil {
+		log.Fatalf("Failed to load identities: %v", err)
+	} else if identityOption != nil {
+		opts = append(opts, identityOption)
+	}
+	if *coverage != "" {
+		source, err := zkgenomics.OpenCoverage(*coverage, *minDepth)
+		if err != nil {
+			log.Fatalf("Failed to load coverage: %v", err)
+		}
+		opts = append(opts, zkgenomics.WithCoverage(source))
+	}
+	generator := zkgenomics.NewProofGenerator(opts...)
+
+	infof("Simulating %s claim on %s...\n", spec.ProofType, vcfPath)
+	ctx, stop := interruptContext()
+	defer stop()
+
+	simulation, err := generator.SimulateContext(ctx, spec, vcfPath)
+	exitIfCancelled(err)
+	if err != nil {
+		fmt.Printf("❌ Claim cannot be proven: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println("A verifier would learn only the following:")
+	fmt.Printf("  circuit: %s v%d\n", simulation.CircuitID, simulation.CircuitVersion)
+	for _, value := range simulation.PublicValues {
+		fmt.Printf("  %s = %s\n", value.Name, value.Value)
+	}
+	if len(simulation.Hints) > 0 {
+		fmt.Printf("  solver hints: %s\n", strings.Join(simulation.Hints, ", "))
+	}
+	fmt.Println("✅ Claim holds; no proof was generated")
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// jsonOutput makes a command print its result as a single JSON object on
+// stdout, and its messages and logs on stderr, for scripts driving the CLI
+var jsonOutput bool
+
+// resultOut is where the JSON result is written: stdout, even once
+// startJSONOutput has sent everything else to stderr
+var resultOut io.Writer = os.Stdout
+
+// addJSONFlag registers --json, which sets jsonOutput
+func addJSONFlag(fs *flag.FlagSet) {
+	fs.BoolVar(&jsonOutput, "json", false, "print the result as a single JSON object on stdout, with messages and logs on stderr")
+}
+
+// startJSONOutput sends all output but the JSON result to stderr if --json
+// is set. It is called once flags are parsed, before anything is printed.
+func startJSONOutput() {
+	if !jsonOutput {
+		return
+	}
+	resultOut = os.Stdout
+	os.Stdout = os.Stderr
+	// gnark's logger and stdoutLogger hold on to the original stdout
+	logger.Disable()
+	stdoutLogger = zkgenomics.NewWriterLogger(os.Stderr, zkgenomics.LevelInfo)
+}
+
+// printJSON writes v to the result output as indented JSON
+func printJSON(v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to serialize output: %v", err)
+	}
+	fmt.Fprintln(resultOut, string(data))
+}
+
+// Statuses of a JSON result: the command succeeded, its proof failed, or it
+// could not run
+const (
+	statusSuccess = "success"
+	statusFail    = "fail"
+	statusError   = "error"
+)
+
+// proofOutput is the JSON result of generate and verify
+type proofOutput struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	ProofType string `json:"proof_type"`
+	// Output is the file generate wrote the proof to
+	Output string `json:"output,omitempty"`
+	// Manifest is the file generate wrote the reproducibility manifest to
+	Manifest       string             `json:"manifest,omitempty"`
+	CircuitID      string             `json:"circuit_id,omitempty"`
+	CircuitVersion int                `json:"circuit_version,omitempty"`
+	Curve          string             `json:"curve,omitempty"`
+	Backend        string             `json:"backend,omitempty"`
+	VKFingerprint  string             `json:"vk_fingerprint,omitempty"`
+	ProofID        string             `json:"proof_id,omitempty"`
+	Sizes          *sizesOutput       `json:"sizes,omitempty"`
+	TimingsMS      map[string]float64 `json:"timings_ms,omitempty"`
+	Claim          *claimOutput       `json:"claim,omitempty"`
+}
+
+// sizesOutput is the size of a proof and its circuit
+type sizesOutput struct {
+	Proof         int `json:"proof"`
+	VerifyingKey  int `json:"verifying_key"`
+	PublicWitness int `json:"public_witness"`
+	Constraints   int `json:"constraints,omitempty"`
+}
+
+// claimOutput is what a proof discloses: its public inputs, the label of its
+// trait claim if its proof type has a claim vocabulary, and its parameters
+type claimOutput struct {
+	Label        string                      `json:"label,omitempty"`
+	PublicInputs map[string]string           `json:"public_inputs,omitempty"`
+	Parameters   *zkgenomics.ProofParameters `json:"parameters,omitempty"`
+}
+
+// newProofOutput describes proofData for a JSON result. publicInputs are
+// the verified public inputs, or nil to decode them from the proof.
+func newProofOutput(proofType zkgenomics.ProofType, proofData *zkgenomics.ProofData, publicInputs map[string]string) *proofOutput {
+	out := &proofOutput{Status: statusSuccess, ProofType: string(proofType)}
+	if proofData == nil {
+		if len(publicInputs) > 0 {
+			out.Claim = &claimOutput{PublicInputs: publicInputs}
+		}
+		return out
+	}
+	out.CircuitID = proofData.CircuitID
+	out.CircuitVersion = proofData.CircuitVersion
+	out.Curve = proofData.Curve
+	out.Backend = proofData.Backend
+	if fingerprint, err := proofData.VKFingerprint(); err == nil {
+		out.VKFingerprint = fingerprint
+	}
+	if len(proofData.Proof) > 0 {
+		out.ProofID = zkgenomics.ProofID(proofData)
+	}
+	out.Sizes = &sizesOutput{
+		Proof:         len(proofData.Proof),
+		VerifyingKey:  len(proofData.VerifyingKey),
+		PublicWitness: len(proofData.PublicWitness),
+		Constraints:   proofData.Constraints,
+	}
+
+	if publicInputs == nil {
+		if values, err := proofs.PublicValues(proofData); err == nil {
+			publicInputs = make(map[string]string, len(values))
+			for _, value := range values {
+				publicInputs[value.Name] = value.Value
+			}
+		}
+	}
+	claim := &claimOutput{PublicInputs: publicInputs, Parameters: proofData.Parameters}
+	if label, err := zkgenomics.DecodeClaim(proofData); err == nil {
+		claim.Label = label
+	}
+	if claim.Label != "" || len(claim.PublicInputs) > 0 || claim.Parameters != nil {
+		out.Claim = claim
+	}
+	return out
+}
+
+// timingsOutput returns the stage timings of a proof in milliseconds
+func timingsOutput(timings zkgenomics.ProofTimings) map[string]float64 {
+	return map[string]float64{
+		"scan":    milliseconds(timings.Scan),
+		"compile": milliseconds(timings.Compile),
+		"setup":   milliseconds(timings.Setup),
+		"witness": milliseconds(timings.Witness),
+		"prove":   milliseconds(timings.Prove),
+		"total":   milliseconds(timings.Total),
+	}
+}
+
+// milliseconds returns d in milliseconds, to the microsecond
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// fromMilliseconds is the inverse of milliseconds
+func fromMilliseconds(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// failJSON prints a JSON result reporting err and exits with status 1
+func failJSON(out *proofOutput, status string, err error) {
+	out.Status = status
+	if err != nil {
+		out.Error = err.Error()
+	}
+	printJSON(out)
+	os.Exit(1)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/zkgenomics/zkgenomics-proofs"
+)
+
+func printPanelUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics panel [--mode aggregate|bundle] [--sample s] [--keys dir] [--format json|cbor|armor] [--identity file] [--passphrase-env VAR] <panel-file> <vcf-path> [output-dir]")
+	fmt.Println()
+	fmt.Println("The panel file is JSON or YAML: a name and variants, each with its locus and claimed genotype.")
+}
+
+// handlePanel proves the claims of a trait panel, writing its proofs and a
+// manifest listing them to the output directory
+func handlePanel() {
+	fs := flag.NewFlagSet("panel", flag.ExitOnError)
+	mode := fs.String("mode", string(zkgenomics.PanelAggregate), "aggregate: one proof of every claim; bundle: one proof per claim")
+	sample := fs.String("sample", "", "sample of a multi-sample VCF to prove from, by name or index from 0 (default the first)")
+	keys := fs.String("keys", "", "generate with the keys stored in this directory, as written by setup")
+	format := fs.String("format", "json", "encoding of the proof files: json, cbor or armor")
+	threads := addThreadsFlag(fs)
+	identities := addIdentityFlags(fs)
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 2 {
+		fmt.Println("Error: panel requires panel-file and vcf-path")
+		printPanelUsage()
+		os.Exit(1)
+	}
+	outputDir := "."
+	if fs.NArg() > 2 {
+		outputDir = fs.Arg(2)
+	}
+
+	panel, err := zkgenomics.LoadTraitPanel(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to load panel: %v", err)
+	}
+
+	opts := []zkgenomics.Option{
+		zkgenomics.WithProgress(printProgress),
+		zkgenomics.WithLogger(stdoutLogger),
+		zkgenomics.WithThreads(*threads),
+	}
+	if *keys != "" {
+		opts = append(opts, zkgenomics.WithKeyStore(&zkgenomics.FileKeyStore{Dir: *keys}))
+	}
+	if identityOption, err := identities.option(); err != nil {
+		log.Fatalf("Failed to load identities: %v", err)
+	} else if identityOption != nil {
+		opts = append(opts, identityOption)
+	}
+	generator := zkgenomics.NewProofGenerator(opts...)
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	fmt.Printf("Proving %d claims of panel %s from %s...\n", len(panel.Variants), panel.Name, fs.Arg(1))
+	request := zkgenomics.ProofRequest{VCFPath: fs.Arg(1), Sample: *sample}
+	result, err := generator.GeneratePanel(ctx, request, panel, zkgenomics.PanelMode(*mode))
+	exitIfCancelled(err)
+	if err != nil {
+		log.Fatalf("Failed to prove panel: %v", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+	for i, proofData := range result.Proofs {
+		name := fmt.Sprintf("%s_proof.json", panel.Name)
+		if len(result.Proofs) > 1 {
+			name = fmt.Sprintf("%s_%d_proof.json", panel.Name, i+1)
+		}
+		encoded, err := encodeOutput(proofData, *format)
+		if err != nil {
+			log.Fatalf("Failed to serialize proof data: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, name), encoded, 0644); err != nil {
+			log.Fatalf("Failed to write proof data to file: %v", err)
+		}
+		result.Manifest.Entries[i].ProofFile = name
+	}
+
+	manifestPath := filepath.Join(outputDir, panel.Name+".manifest.json")
+	manifestData, err := json.MarshalIndent(result.Manifest, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to serialize panel manifest: %v", err)
+	}
+	if err := os.WriteFile(manifestPath, manifestData, 0644); err != nil {
+		log.Fatalf("Failed to write panel manifest: %v", err)
+	}
+	fmt.Printf("✅ %d panel proofs saved; manifest saved to: %s\n", len(result.Proofs), manifestPath)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+func printPresentUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics present keygen <key-file>")
+	fmt.Println("  zkgenomics present sign --challenge c [--format json|cbor] <key-file> <proof-path> <presentation-path>")
+}
+
+func handlePresent() {
+	if len(os.Args) < 3 {
+		printPresentUsage()
+		os.Exit(1)
+	}
+
+	switch os.Args[2] {
+	case "keygen":
+		presentKeygen(os.Args[3:])
+	case "sign":
+		presentSign(os.Args[3:])
+	default:
+		fmt.Printf("Unknown present command: %s\n", os.Args[2])
+		printPresentUsage()
+		os.Exit(1)
+	}
+}
+
+func presentKeygen(args []string) {
+	if len(args) < 1 {
+		fmt.Println("Error: present keygen requires key-file")
+		printPresentUsage()
+		os.Exit(1)
+	}
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Fatalf("Failed to generate holder key: %v", err)
+	}
+	if err := os.WriteFile(args[0], []byte(base64.StdEncoding.EncodeToString(private)+"\n"), 0600); err != nil {
+		log.Fatalf("Failed to write holder key: %v", err)
+	}
+
+	fmt.Printf("✅ Holder key written to: %s\n", args[0])
+	fmt.Printf("Public key (pass to generate --holder-key): %s\n", base64.StdEncoding.EncodeToString(public))
+}
+
+func presentSign(args []string) {
+	fs := flag.NewFlagSet("present sign", flag.ExitOnError)
+	format := fs.String("format", "json", "encoding of the presentation file: json or cbor")
+	challenge := fs.String("challenge", "", "the challenge the verifier issued for this presentation")
+	fs.Parse(args)
+	args = fs.Args()
+	if len(args) < 3 {
+		fmt.Println("Error: present sign requires key-file, proof-path and presentation-path")
+		printPresentUsage()
+		os.Exit(1)
+	}
+	if *challenge == "" {
+		fmt.Println("Error: present sign requires the verifier's --challenge")
+		printPresentUsage()
+		os.Exit(1)
+	}
+
+	key, err := readHolderKey(args[0])
+	if err != nil {
+		log.Fatalf("Failed to read holder key: %v", err)
+	}
+	proofData, err := proofs.ReadProofData(args[1])
+	if err != nil {
+		log.Fatalf("Failed to read proof: %v", err)
+	}
+	presentation, err := zkgenomics.Present(proofData, key, []byte(*challenge))
+	if err != nil {
+		log.Fatalf("Failed to sign presentation: %v", err)
+	}
+
+	data, err := encodeOutput(presentation, *format)
+	if err != nil {
+		log.Fatalf("Failed to serialize presentation: %v", err)
+	}
+	if err := os.WriteFile(args[2], data, 0644); err != nil {
+		log.Fatalf("Failed to write presentation: %v", err)
+	}
+	fmt.Printf("✅ Presentation written to: %s\n", args[2])
+}
+
+// readHolderKey reads a base64 ed25519 private key written by present keygen
+func readHolderKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("%s: invalid ed25519 private key", path)
+	}
+	return ed25519.PrivateKey(key), nil
+}
+
+// verifyBound verifies the proof or, with a challenge given, the presentation
+// answering it at proofPath, requiring the proof to be bound to nonce if one
+// is given. A non-empty verifyingKeyPath replaces the key bundled in the proof.
+func verifyBound(generator *zkgenomics.ProofGenerator, proofType zkgenomics.ProofType, verifyingKeyPath, proofPath string, nonce []byte, challenge []byte) (*zkgenomics.VerificationResult, error) {
+	var proofData *zkgenomics.ProofData
+	var result *zkgenomics.VerificationResult
+	if len(challenge) > 0 {
+		f, err := os.Open(proofPath)
+		if err != nil {
+			return nil, err
+		}
+		p, err := proofs.DecodePresentation(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		if p.ProofData != nil && verifyingKeyPath != "" {
+			if p.ProofData.VerifyingKey, err = os.ReadFile(verifyingKeyPath); err != nil {
+				return nil, err
+			}
+		}
+		result, err = generator.VerifyPresentation(proofType, p, challenge)
+		if err != nil || result.Result != zkgenomics.ProofSuccess {
+			return result, err
+		}
+		proofData = p.ProofData
+	} else {
+		var err error
+		if proofData, err = proofs.ReadProofData(proofPath); err != nil {
+			return nil, err
+		}
+		if verifyingKeyPath != "" {
+			if proofData.VerifyingKey, err = os.ReadFile(verifyingKeyPath); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(nonce) == 0 {
+		return result, nil
+	}
+	return generator.VerifyProofDataWithNonce(proofType, proofData, nonce)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+func printQRUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics qr split [--chunk-size n] <proof-path>")
+	fmt.Println("  zkgenomics qr join <chunks-file> <output>")
+}
+
+func handleQR() {
+	if len(os.Args) < 3 {
+		printQRUsage()
+		os.Exit(1)
+	}
+
+	switch os.Args[2] {
+	case "split":
+		qrSplit(os.Args[3:])
+	case "join":
+		qrJoin(os.Args[3:])
+	default:
+		fmt.Printf("Unknown qr command: %s\n", os.Args[2])
+		printQRUsage()
+		os.Exit(1)
+	}
+}
+
+// qrSplit prints the QR chunks of a proof, one per line, for a QR generator
+func qrSplit(args []string) {
+	fs := flag.NewFlagSet("qr split", flag.ExitOnError)
+	chunkSize := fs.Int("chunk-size", proofs.DefaultQRChunkSize, "encoded proof bytes per QR code")
+	fs.Parse(args)
+	args = fs.Args()
+	if len(args) < 1 {
+		fmt.Println("Error: qr split requires proof-path")
+		printQRUsage()
+		os.Exit(1)
+	}
+
+	proofData, err := proofs.ReadProofData(args[0])
+	if err != nil {
+		log.Fatalf("Failed to read proof: %v", err)
+	}
+	chunks, err := proofs.SplitQRChunks(proofData, *chunkSize)
+	if err != nil {
+		log.Fatalf("Failed to split proof: %v", err)
+	}
+	for _, chunk := range chunks {
+		fmt.Println(chunk)
+	}
+}
+
+// qrJoin reassembles a proof from scanned chunks, one per line
+func qrJoin(args []string) {
+	if len(args) < 2 {
+		fmt.Println("Error: qr join requires chunks-file and output")
+		printQRUsage()
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		log.Fatalf("Failed to read chunks: %v", err)
+	}
+	var chunks []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			chunks = append(chunks, line)
+		}
+	}
+	proofData, err := proofs.JoinQRChunks(chunks)
+	if err != nil {
+		log.Fatalf("Failed to reassemble proof: %v", err)
+	}
+
+	encoded, err := json.MarshalIndent(proofData, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to serialize proof: %v", err)
+	}
+	if err := os.WriteFile(args[1], encoded, 0644); err != nil {
+		log.Fatalf("Failed to write proof: %v", err)
+	}
+	fmt.Printf("✅ Proof reassembled from %d chunks and written to: %s\n", len(chunks), args[1])
+	fmt.Println("The proof carries no verifying key; pass the circuit's key to verify.")
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	zkgenomics "github.com/zkgenomics/zkgenomics-proofs"
+)
+
+// handleSetup runs the key setup for a proof type once, storing the keys for
+// generate --keys to reuse
+func handleSetup() {
+	fs := flag.NewFlagSet("setup", flag.ExitOnError)
+	keys := fs.String("keys", "keys", "directory to store the keys in")
+	compress := fs.Bool("compress", false, "store the proving key with compressed points: about half the size, slower to load")
+	slots := fs.Int("slots", 0, "number of chromosome slots in the chromosome circuit")
+	chromosome := fs.String("chromosome", "", "chromosome a chromosome proof shows present (default 22)")
+	threads := addThreadsFlag(fs)
+	backend := addBackendFlags(fs)
+	fs.Parse(os.Args[2:])
+	args := fs.Args()
+
+	if len(args) < 1 {
+		fmt.Println("Error: setup requires proof-type")
+		fmt.Println("Usage: zkgenomics setup [--keys dir] [--compress] [--backend groth16|plonk] [--curve c] [--srs path] [--threads n] [--chromosome c] [--slots n] <proof-type> [vcf-path]")
+		fmt.Println("Kinship and region count circuits are sized from a genome, so they also need vcf-path.")
+		os.Exit(1)
+	}
+	proofType := zkgenomics.ProofType(args[0])
+	var vcfPath string
+	if len(args) > 1 {
+		vcfPath = args[1]
+	}
+
+	opts := []zkgenomics.Option{
+		zkgenomics.WithLogger(stdoutLogger),
+		zkgenomics.WithChromosomeSlots(*slots),
+		zkgenomics.WithThreads(*threads),
+		zkgenomics.WithKeyStore(&zkgenomics.FileKeyStore{Dir: *keys, Compressed: *compress}),
+	}
+	if *chromosome != "" {
+		code := zkgenomics.ChromosomeCode(*chromosome)
+		if code == 0 {
+			log.Fatalf("Unknown chromosome %q", *chromosome)
+		}
+		opts = append(opts, zkgenomics.WithTargetChromosome(code))
+	}
+	backendOption, err := backend.option()
+	if err != nil {
+		log.Fatalf("Invalid backend: %v", err)
+	}
+	generator := zkgenomics.NewProofGenerator(append(opts, backendOption)...)
+
+	fmt.Printf("Setting up keys for %s proofs...\n", proofType)
+	circuit, err := generator.Setup(proofType, vcfPath)
+	if err != nil {
+		log.Fatalf("Setup failed: %v", err)
+	}
+
+	base := filepath.Join(*keys, circuit.String())
+	fmt.Printf("✅ Keys for circuit %s\n", circuit)
+	if info, err := os.Stat(base + ".pk"); err == nil {
+		fmt.Printf("   Proving key:   %s.pk (%.1f MB)\n", base, float64(info.Size())/(1<<20))
+	} else {
+		fmt.Printf("   Proving key:   %s.pk\n", base)
+	}
+	fmt.Printf("   Verifying key: %s.vk\n", base)
+	generateFlags := "--keys " + *keys
+	if backend.backend != "groth16" {
+		generateFlags += " --backend " + backend.backend
+	}
+	if backend.curve != "bn254" {
+		generateFlags += " --curve " + backend.curve
+	}
+	fmt.Printf("   Generate with: zkgenomics generate %s %s <vcf-path>\n", generateFlags, proofType)
+	fmt.Printf("   Verify with:   zkgenomics verify %s %s.vk <proof-path>\n", proofType, base)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/genotools"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+	"gopkg.in/yaml.v3"
+)
+
+func printTraitsUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  zkgenomics traits clinvar [--genes BRCA1,BRCA2] [--significance pathogenic,likely_pathogenic] [--build GRCh37] [--name n] <clinvar-vcf|variant-summary> [panel-file]")
+	fmt.Println("  zkgenomics traits vocabulary")
+	fmt.Println()
+	fmt.Println("The panel is written as YAML, or as JSON if panel-file ends in .json, to standard output if no file is given.")
+}
+
+func handleTraits() {
+	if len(os.Args) < 3 {
+		printTraitsUsage()
+		os.Exit(1)
+	}
+
+	switch os.Args[2] {
+	case "clinvar":
+		traitsClinVar(os.Args[3:])
+	case "vocabulary":
+		traitsVocabulary()
+	default:
+		fmt.Printf("Unknown traits command: %s\n", os.Args[2])
+		printTraitsUsage()
+		os.Exit(1)
+	}
+}
+
+func traitsClinVar(args []string) {
+	fs := flag.NewFlagSet("traits clinvar", flag.ExitOnError)
+	genes := fs.String("genes", "", "comma-separated gene symbols to keep (default every gene)")
+	significance := fs.String("significance", strings.Join(genotools.DefaultClinVarSignificance, ","), "comma-separated clinical significances to keep")
+	build := fs.String("build", string(traits.BuildGRCh37), "assembly of the coordinates to import: GRCh37 or GRCh38")
+	name := fs.String("name", "", "name of the panel (default the genes, joined)")
+	fs.Parse(args)
+	args = fs.Args()
+	if len(args) < 1 {
+		fmt.Println("Error: traits clinvar requires a ClinVar VCF or variant_summary file")
+		printTraitsUsage()
+		os.Exit(1)
+	}
+
+	filter := genotools.ClinVarFilter{
+		Significance: splitList(*significance),
+		Genes:        splitList(*genes),
+		Build:        traits.BuildFromName(*build),
+		Name:         *name,
+	}
+	if filter.Build == traits.BuildUnknown {
+		log.Fatalf("Unknown build %q: expected GRCh37 or GRCh38", *build)
+	}
+	panel, err := genotools.ImportClinVar(args[0], filter)
+	if err != nil {
+		log.Fatalf("Failed to import ClinVar: %v", err)
+	}
+
+	var data []byte
+	if len(args) > 1 && strings.HasSuffix(args[1], ".json") {
+		data, err = json.MarshalIndent(panel, "", "  ")
+	} else {
+		data, err = yaml.Marshal(panel)
+	}
+	if err != nil {
+		log.Fatalf("Failed to serialize panel: %v", err)
+	}
+	if len(args) < 2 {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(args[1], data, 0644); err != nil {
+		log.Fatalf("Failed to write panel: %v", err)
+	}
+	fmt.Printf("✅ Panel %s of %d variants written to: %s\n", panel.Name, len(panel.Variants), args[1])
+}
+
+// traitsVocabulary prints the public encoding of every trait claim as JSON,
+// for verifiers in other languages
+func traitsVocabulary() {
+	data, err := json.MarshalIndent(traits.ClaimVocabularies(), "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to serialize claim vocabulary: %v", err)
+	}
+	fmt.Println(string(data))
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
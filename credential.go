@@ -0,0 +1,32 @@
+package zkgenomics
+
+import (
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// VerifiableCredential re-exports the W3C Verifiable Credential envelope for
+// proofs
+type VerifiableCredential = proofs.VerifiableCredential
+
+// ExportCredential wraps a proof as a W3C Verifiable Credential issued by
+// issuer, typically a DID, for SSI wallets
+func (pg *ProofGenerator) ExportCredential(proofType ProofType, proofData *ProofData, issuer string) (*VerifiableCredential, error) {
+	if _, err := pg.newProof(proofType); err != nil {
+		return nil, err
+	}
+	return proofs.NewVerifiableCredential(string(proofType), proofData, issuer)
+}
+
+// VerifyCredential verifies the proof embedded in a credential like
+// VerifyProofData, as the proof type its subject names, after checking that
+// the subject states the claim the proof makes. The issuer is not
+// authenticated; the proof speaks for itself.
+func (pg *ProofGenerator) VerifyCredential(credential *VerifiableCredential) (ProofType, *VerificationResult, error) {
+	proofData, err := credential.ProofData()
+	if err != nil {
+		return "", &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	proofType := ProofType(credential.CredentialSubject.ProofType)
+	result, err := pg.VerifyProofData(proofType, proofData)
+	return proofType, result, err
+}
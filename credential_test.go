@@ -0,0 +1,45 @@
+package zkgenomics
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestProofGenerator_ExportCredential(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t1/1\n"
+
+	pg := NewProofGenerator(WithInsecureBundledKeys())
+	proofData, err := pg.GenerateProofFromReader(ACTN3ProofType, strings.NewReader(vcf))
+	if err != nil {
+		t.Fatalf("GenerateProofFromReader should not return error: %v", err)
+	}
+	credential, err := pg.ExportCredential(ACTN3ProofType, proofData, "did:example:lab")
+	if err != nil {
+		t.Fatalf("ExportCredential should not return error: %v", err)
+	}
+
+	// The credential survives a round trip through a wallet's JSON
+	data, err := json.Marshal(credential)
+	if err != nil {
+		t.Fatalf("encoding credential: %v", err)
+	}
+	var imported VerifiableCredential
+	if err := json.Unmarshal(data, &imported); err != nil {
+		t.Fatalf("decoding credential: %v", err)
+	}
+	proofType, result, err := pg.VerifyCredential(&imported)
+	if err != nil || result.Result != ProofSuccess || proofType != ACTN3ProofType {
+		t.Errorf("Expected the credential to verify as %s, got %s %v: %v", ACTN3ProofType, proofType, result, err)
+	}
+
+	// A subject claiming something the proof does not is rejected
+	imported.CredentialSubject.PublicValues[0].Value = "1"
+	_, result, err = pg.VerifyCredential(&imported)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected an altered subject to fail, got %v: %v", result, err)
+	}
+}
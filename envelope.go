@@ -0,0 +1,34 @@
+package zkgenomics
+
+import (
+	"crypto"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// EnvelopeSigner re-exports the interface issuers sign proof envelopes with
+type EnvelopeSigner = proofs.EnvelopeSigner
+
+// ES256Signer re-exports the ECDSA P-256 EnvelopeSigner
+type ES256Signer = proofs.ES256Signer
+
+// EdDSASigner re-exports the Ed25519 EnvelopeSigner
+type EdDSASigner = proofs.EdDSASigner
+
+// NewEnvelopeSigner returns the signer for an ECDSA P-256 or Ed25519 private
+// key, identified to verifiers by keyID
+func NewEnvelopeSigner(keyID string, key crypto.PrivateKey) (EnvelopeSigner, error) {
+	return proofs.NewEnvelopeSigner(keyID, key)
+}
+
+// SignEnvelope adds signer's signature over the claim, proof bytes and
+// metadata of proofData, as a lab or issuer vouching for the proof. Verifiers
+// configured WithIssuerKeys check it in addition to the proof.
+func SignEnvelope(proofData *ProofData, signer EnvelopeSigner) error {
+	return proofs.SignEnvelope(proofData, signer)
+}
+
+// LoadIssuerKeys reads a file of trusted issuer keys for WithIssuerKeys
+func LoadIssuerKeys(path string) (map[string]crypto.PublicKey, error) {
+	return proofs.LoadIssuerKeys(path)
+}
@@ -1,6 +1,11 @@
 package zkgenomics
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
 
 // UnsupportedProofTypeError represents an error when an unsupported proof type is requested
 type UnsupportedProofTypeError struct {
@@ -37,4 +42,114 @@ func (e *ProofVerificationError) Error() string {
 
 func (e *ProofVerificationError) Unwrap() error {
 	return e.Err
-}
\ No newline at end of file
+}
+
+// StaleCommitmentError re-exports the error returned when a committed VCF has changed
+type StaleCommitmentError = proofs.StaleCommitmentError
+// SampleNotFoundError re-exports the error returned when a selected sample is not in the VCF
+type SampleNotFoundError = proofs.SampleNotFoundError
+// BuildMismatchError re-exports the error returned when a genome is in another build than the coordinates proven
+type BuildMismatchError = proofs.BuildMismatchError
+// CircuitVersionError re-exports the error returned when a proof's circuit version cannot be verified
+type CircuitVersionError = proofs.CircuitVersionError
+
+// ProofTypeMismatchError represents a proof verified as a different type than the one it records
+type ProofTypeMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *ProofTypeMismatchError) Error() string {
+	return fmt.Sprintf("proof is a %s proof, not %s", e.Actual, e.Expected)
+}
+
+// UntrustedKeyError represents a proof whose verifying key the verifier does
+// not trust: it is neither registered nor pinned for the proof type
+type UntrustedKeyError struct {
+	ProofType string
+}
+
+func (e *UntrustedKeyError) Error() string {
+	return fmt.Sprintf("verifying key is not trusted for %s proofs: register a trusted key, pin its fingerprint, or allow bundled keys with WithInsecureBundledKeys", e.ProofType)
+}
+
+// KeyCircuitMismatchError represents a proof recording a circuit other than
+// the one its verifying key is registered for
+type KeyCircuitMismatchError struct {
+	ProofType  string
+	Claimed    CircuitKey
+	Registered CircuitKey
+}
+
+func (e *KeyCircuitMismatchError) Error() string {
+	return fmt.Sprintf("%s proof records circuit %s v%d, but its verifying key is registered for %s v%d",
+		e.ProofType, e.Claimed.ID, e.Claimed.Version, e.Registered.ID, e.Registered.Version)
+}
+
+// ProofTypeFailure records why a proof did not verify as one proof type
+type ProofTypeFailure struct {
+	ProofType ProofType
+	Err       error
+}
+
+// AmbiguousProofError represents a proof without a recorded type that did not verify as any supported type
+type AmbiguousProofError struct {
+	Failures []ProofTypeFailure
+}
+
+func (e *AmbiguousProofError) Error() string {
+	reasons := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		reasons[i] = fmt.Sprintf("%s: %v", failure.ProofType, failure.Err)
+	}
+	return fmt.Sprintf("proof does not record its type and did not verify as any supported type (%s)", strings.Join(reasons, "; "))
+}
+
+// Re-export the generation errors callers may want to tell apart with errors.Is
+var (
+	ErrVariantNotFound = proofs.ErrVariantNotFound
+	ErrNoSampleData    = proofs.ErrNoSampleData
+	ErrMalformedVCF    = proofs.ErrMalformedVCF
+	ErrEncryptedGenome = proofs.ErrEncryptedGenome
+	ErrNotSequenced    = proofs.ErrNotSequenced
+)
+
+// ErrNonceMismatch re-exports the error for a proof not bound to the nonce its
+// verifier expects
+var ErrNonceMismatch = proofs.ErrNonceMismatch
+
+// ErrHolderMismatch re-exports the error for a proof presented with a key
+// other than the one it was issued to
+var ErrHolderMismatch = proofs.ErrHolderMismatch
+
+// ErrChallengeMismatch re-exports the error for a presentation that does not
+// answer the challenge its verifier issued
+var ErrChallengeMismatch = proofs.ErrChallengeMismatch
+
+// StalePresentationError re-exports the error for a presentation not signed
+// within proofs.MaxPresentationAge of its verification
+type StalePresentationError = proofs.StalePresentationError
+
+// ErrUnsignedEnvelope re-exports the error for a proof not signed by a trusted
+// issuer
+var ErrUnsignedEnvelope = proofs.ErrUnsignedEnvelope
+
+// ErrUnattestedGenome re-exports the error for a proof not carrying a genome
+// attestation by a trusted lab
+var ErrUnattestedGenome = proofs.ErrUnattestedGenome
+
+// ErrUnboundAttestation re-exports the error for a proof that does not
+// disclose the Merkle root of the genome its attestation is for
+var ErrUnboundAttestation = proofs.ErrUnboundAttestation
+
+// ErrKeysNotFound re-exports the error for a key store holding no keys for a
+// circuit
+var ErrKeysNotFound = proofs.ErrKeysNotFound
+
+// UnpinnedKeyError re-exports the error for a proof whose verifying key is not
+// pinned for its proof type
+type UnpinnedKeyError = proofs.UnpinnedKeyError
+
+// UnsupportedVersionError re-exports the error for a proof written in a newer
+// format than this release reads
+type UnsupportedVersionError = proofs.UnsupportedVersionError
@@ -0,0 +1,249 @@
+package genotools
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// DefaultClinVarSignificance is the clinical significance ImportClinVar
+// keeps when the filter names none
+var DefaultClinVarSignificance = []string{"pathogenic", "likely_pathogenic"}
+
+// ClinVarFilter selects the ClinVar records ImportClinVar turns into a panel
+type ClinVarFilter struct {
+	// Genes keeps records of these gene symbols; every gene if empty
+	Genes []string
+	// Significance keeps records with one of these clinical significances,
+	// compared case-insensitively with spaces as underscores, such as
+	// "pathogenic" or "likely_pathogenic"; DefaultClinVarSignificance if empty
+	Significance []string
+	// Build is the assembly of the coordinates to import: variant_summary
+	// rows for other assemblies are skipped, and a VCF declaring another
+	// reference is refused. GRCh37 if empty.
+	Build traits.GenomeBuild
+	// Name names the panel; the genes, joined, if empty
+	Name string
+}
+
+// clinVarRecord is a ClinVar variant as read from either file format
+type clinVarRecord struct {
+	name         string
+	genes        []string
+	significance []string
+	chromosome   string
+	position     uint64
+	ref, alt     string
+}
+
+// ImportClinVar reads a ClinVar VCF (clinvar.vcf.gz) or variant_summary TSV
+// (variant_summary.txt.gz), plain or gzip-compressed, and returns a panel
+// of the records that filter keeps. Each variant is claimed not carried
+// (genotype 0), the statement a carrier screen proves. Records without
+// sequence alleles, such as large structural variants, are skipped.
+func ImportClinVar(path string, filter ClinVarFilter) (*traits.TraitPanel, error) {
+	if filter.Build == traits.BuildUnknown {
+		filter.Build = traits.BuildGRCh37
+	}
+	if len(filter.Significance) == 0 {
+		filter.Significance = DefaultClinVarSignificance
+	}
+	significance := make([]string, len(filter.Significance))
+	for i, s := range filter.Significance {
+		significance[i] = normalizeSignificance(s)
+	}
+
+	f, err := proofs.OpenVCFFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	name := filter.Name
+	if name == "" {
+		name = strings.ToLower(strings.Join(filter.Genes, "_")) + "_clinvar"
+		name = strings.TrimPrefix(name, "_")
+	}
+	panel := &traits.TraitPanel{Name: name}
+	seen := make(map[string]bool)
+	keep := func(record clinVarRecord) {
+		if len(filter.Genes) > 0 && !slices.ContainsFunc(record.genes, func(gene string) bool {
+			return slices.ContainsFunc(filter.Genes, func(want string) bool { return strings.EqualFold(gene, want) })
+		}) {
+			return
+		}
+		if !slices.ContainsFunc(record.significance, func(s string) bool { return slices.Contains(significance, s) }) {
+			return
+		}
+		chromosome := traits.ChromosomeCode(record.chromosome)
+		if chromosome == 0 || record.position == 0 || !isBases(record.ref) || !isBases(record.alt) {
+			return
+		}
+		key := fmt.Sprintf("%d:%d:%s:%s", chromosome, record.position, record.ref, record.alt)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		gene := ""
+		if len(record.genes) > 0 {
+			gene = record.genes[0]
+		}
+		panel.Variants = append(panel.Variants, traits.PanelVariant{TraitVariant: traits.TraitVariant{
+			Trait:      record.name,
+			Gene:       gene,
+			Chromosome: chromosome,
+			Position:   int(record.position),
+			Region:     traits.TraitRegion{Start: int(record.position), End: int(record.position) + len(record.ref) - 1},
+			Ref:        record.ref,
+			Alt:        record.alt,
+			Build:      filter.Build,
+		}})
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var columns map[string]int
+	isVCF := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "##"):
+			if build := traits.BuildFromHeaderLine(line); build != traits.BuildUnknown && build != filter.Build {
+				return nil, fmt.Errorf("%s has %s coordinates, not %s", path, build, filter.Build)
+			}
+			continue
+		case strings.HasPrefix(line, "#CHROM"):
+			isVCF = true
+			continue
+		case columns == nil && !isVCF:
+			columns = make(map[string]int)
+			for i, column := range strings.Split(strings.TrimPrefix(line, "#"), "\t") {
+				columns[column] = i
+			}
+			for _, column := range []string{"GeneSymbol", "ClinicalSignificance", "Assembly", "Chromosome", "PositionVCF", "ReferenceAlleleVCF", "AlternateAlleleVCF"} {
+				if _, ok := columns[column]; !ok {
+					return nil, fmt.Errorf("%s is neither a ClinVar VCF nor a variant_summary file: no %s column", path, column)
+				}
+			}
+			continue
+		case line == "":
+			continue
+		}
+
+		var record clinVarRecord
+		var ok bool
+		if isVCF {
+			record, ok = parseClinVarVCFLine(line)
+		} else {
+			record, ok = parseClinVarSummaryLine(line, columns, filter.Build)
+		}
+		if ok {
+			keep(record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(panel.Variants) == 0 {
+		return nil, fmt.Errorf("%s has no %s records of the requested genes", path, strings.Join(filter.Significance, " or "))
+	}
+	return panel, nil
+}
+
+// parseClinVarVCFLine reads a record of the ClinVar VCF, whose INFO holds
+// the significance in CLNSIG and the genes in GENEINFO
+func parseClinVarVCFLine(line string) (clinVarRecord, bool) {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 8 {
+		return clinVarRecord{}, false
+	}
+	position, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return clinVarRecord{}, false
+	}
+	record := clinVarRecord{chromosome: fields[0], position: position, ref: fields[3], alt: fields[4]}
+
+	info := make(map[string]string)
+	for _, entry := range strings.Split(fields[7], ";") {
+		key, value, _ := strings.Cut(entry, "=")
+		info[key] = value
+	}
+	for _, gene := range strings.Split(info["GENEINFO"], "|") {
+		if symbol, _, _ := strings.Cut(gene, ":"); symbol != "" {
+			record.genes = append(record.genes, symbol)
+		}
+	}
+	record.significance = splitSignificance(info["CLNSIG"])
+
+	record.name = fmt.Sprintf("ClinVar %s", fields[2])
+	if len(record.genes) > 0 {
+		record.name = fmt.Sprintf("%s %s", record.genes[0], record.name)
+	}
+	if rs := info["RS"]; rs != "" {
+		record.name += fmt.Sprintf(" (rs%s)", rs)
+	}
+	return record, true
+}
+
+// parseClinVarSummaryLine reads a row of variant_summary, skipping rows for
+// assemblies other than build
+func parseClinVarSummaryLine(line string, columns map[string]int, build traits.GenomeBuild) (clinVarRecord, bool) {
+	fields := strings.Split(line, "\t")
+	column := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(fields) {
+			return fields[i]
+		}
+		return ""
+	}
+	if traits.BuildFromName(column("Assembly")) != build {
+		return clinVarRecord{}, false
+	}
+	position, err := strconv.ParseUint(column("PositionVCF"), 10, 64)
+	if err != nil {
+		return clinVarRecord{}, false
+	}
+	record := clinVarRecord{
+		name:         column("Name"),
+		significance: splitSignificance(column("ClinicalSignificance")),
+		chromosome:   column("Chromosome"),
+		position:     position,
+		ref:          column("ReferenceAlleleVCF"),
+		alt:          column("AlternateAlleleVCF"),
+	}
+	for _, gene := range strings.Split(column("GeneSymbol"), ";") {
+		if gene != "" && gene != "-" {
+			record.genes = append(record.genes, gene)
+		}
+	}
+	return record, true
+}
+
+// splitSignificance splits a ClinVar significance such as
+// "Pathogenic/Likely_pathogenic" or "Pathogenic; risk factor" into its
+// normalized terms
+func splitSignificance(value string) []string {
+	terms := strings.FieldsFunc(value, func(r rune) bool {
+		return r == '/' || r == ',' || r == ';' || r == '|'
+	})
+	for i, term := range terms {
+		terms[i] = normalizeSignificance(term)
+	}
+	return terms
+}
+
+// normalizeSignificance lower-cases a significance and writes its spaces
+// as underscores, as the ClinVar VCF does
+func normalizeSignificance(term string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(strings.TrimPrefix(term, "_"))), " ", "_")
+}
+
+// isBases reports whether allele is a sequence of bases
+func isBases(allele string) bool {
+	return allele != "" && strings.Trim(strings.ToUpper(allele), "ACGTN") == ""
+}
@@ -0,0 +1,169 @@
+// Package genotools exposes the input-processing layer used by the proof
+// types (VCF reading, filtering, liftover, commitments) as standalone
+// utilities that do not require generating proofs.
+package genotools
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// Options are the input-processing settings shared by every genotools command
+type Options struct {
+	// MinQuality hides records with a lower QUAL
+	MinQuality float32
+	// PassOnly hides records that did not pass all filters
+	PassOnly bool
+	// Liftover, if non-empty, presents the VCF in TargetBuild coordinates
+	Liftover    []proofs.LiftoverBlock
+	TargetBuild traits.GenomeBuild
+	// Regions, if non-empty, restricts lookups to these regions (in the
+	// coordinates seen after liftover)
+	Regions []proofs.Region
+	// Progress, if set, receives progress updates from VCF scans
+	Progress proofs.ProgressReporter
+}
+
+// OpenSource opens vcfPath as a GenomeSource with the decorators selected by opts
+func OpenSource(vcfPath string, opts Options) (proofs.GenomeSource, error) {
+	var source proofs.GenomeSource
+	source, err := proofs.NewVCFSource(vcfPath, opts.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.MinQuality > 0 || opts.PassOnly {
+		source = proofs.NewQualityFilterSource(source, opts.MinQuality, opts.PassOnly)
+	}
+	if len(opts.Liftover) > 0 {
+		source = proofs.NewLiftoverSource(source, opts.TargetBuild, opts.Liftover)
+	}
+	if len(opts.Regions) > 0 {
+		source = proofs.NewRedactingSource(source, opts.Regions)
+	}
+
+	return proofs.NewCachingSource(source), nil
+}
+
+// Extract returns every record starting at chrom:pos
+func Extract(source proofs.GenomeSource, chrom string, pos uint64) ([]*proofs.VariantCall, error) {
+	return source.LookupVariant(chrom, pos)
+}
+
+// Scan returns every record within region
+func Scan(source proofs.GenomeSource, region proofs.Region) ([]*proofs.VariantCall, error) {
+	var calls []*proofs.VariantCall
+	err := source.IterateRegion(region.Chromosome, region.Start, region.End, func(call *proofs.VariantCall) bool {
+		calls = append(calls, call)
+		return true
+	})
+	return calls, err
+}
+
+// Commit records a commitment to the current contents of the VCF. An existing
+// commitment is returned unchanged if it still matches; if the VCF has changed
+// a StaleCommitmentError is returned unless recommit replaces it.
+func Commit(vcfPath string, recommit bool) (*proofs.GenomeCommitment, error) {
+	if recommit {
+		return proofs.Recommit(vcfPath)
+	}
+
+	existing, err := proofs.LoadGenomeCommitment(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if err := existing.Check(vcfPath); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	return proofs.Recommit(vcfPath)
+}
+
+// LoadLiftoverBlocks reads liftover blocks from a JSON array of
+// {"chromosome", "start", "end", "offset"} objects
+func LoadLiftoverBlocks(path string) ([]proofs.LiftoverBlock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []proofs.LiftoverBlock
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return nil, fmt.Errorf("decoding liftover blocks: %w", err)
+	}
+	return blocks, nil
+}
+
+// FormatGenotype renders a sample call in VCF GT notation
+func FormatGenotype(sample proofs.SampleCall) string {
+	if len(sample.GT) == 0 {
+		return "."
+	}
+
+	sep := "/"
+	if sample.Phased {
+		sep = "|"
+	}
+
+	alleles := make([]string, len(sample.GT))
+	for i, allele := range sample.GT {
+		if allele < 0 {
+			alleles[i] = "."
+		} else {
+			alleles[i] = strconv.Itoa(allele)
+		}
+	}
+	return strings.Join(alleles, sep)
+}
+
+// ParseLocus parses "chrom:pos"
+func ParseLocus(s string) (string, uint64, error) {
+	chrom, posStr, ok := strings.Cut(s, ":")
+	if !ok || chrom == "" {
+		return "", 0, fmt.Errorf("invalid locus %q: expected chrom:pos", s)
+	}
+	pos, err := strconv.ParseUint(posStr, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid locus %q: %w", s, err)
+	}
+	return chrom, pos, nil
+}
+
+// ParseRegion parses "chrom", "chrom:pos" or "chrom:start-end"
+func ParseRegion(s string) (proofs.Region, error) {
+	chrom, span, ok := strings.Cut(s, ":")
+	if chrom == "" {
+		return proofs.Region{}, fmt.Errorf("invalid region %q: missing chromosome", s)
+	}
+	if !ok {
+		return proofs.Region{Chromosome: chrom, Start: 0, End: math.MaxUint64}, nil
+	}
+
+	startStr, endStr, isRange := strings.Cut(span, "-")
+	start, err := strconv.ParseUint(startStr, 10, 64)
+	if err != nil {
+		return proofs.Region{}, fmt.Errorf("invalid region %q: %w", s, err)
+	}
+	end := start
+	if isRange {
+		end, err = strconv.ParseUint(endStr, 10, 64)
+		if err != nil {
+			return proofs.Region{}, fmt.Errorf("invalid region %q: %w", s, err)
+		}
+	}
+	if end < start {
+		return proofs.Region{}, fmt.Errorf("invalid region %q: end before start", s)
+	}
+
+	return proofs.Region{Chromosome: chrom, Start: start, End: end}, nil
+}
@@ -0,0 +1,205 @@
+package genotools
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func writeVCF(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.vcf")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write VCF: %v", err)
+	}
+	return path
+}
+
+func TestSynthProducesValidVCF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Synth(&buf, SynthOptions{Samples: 3, Seed: 7, Build: traits.BuildGRCh37}); err != nil {
+		t.Fatalf("Synth failed: %v", err)
+	}
+
+	report, err := Validate(writeVCF(t, buf.String()))
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !report.Valid() {
+		t.Errorf("Expected synthetic VCF to be valid, got %v", report.Issues)
+	}
+	if report.Records != len(DefaultSynthVariants()) || len(report.Samples) != 3 {
+		t.Errorf("Unexpected report: %d records, %d samples", report.Records, len(report.Samples))
+	}
+	if report.Build != traits.BuildGRCh37 {
+		t.Errorf("Expected build GRCh37, got %q", report.Build)
+	}
+
+	var again bytes.Buffer
+	if err := Synth(&again, SynthOptions{Samples: 3, Seed: 7, Build: traits.BuildGRCh37}); err != nil {
+		t.Fatalf("Synth failed: %v", err)
+	}
+	if buf.String() != again.String() {
+		t.Errorf("Expected the same seed to produce the same VCF")
+	}
+}
+
+func TestValidateReportsIssues(t *testing.T) {
+	path := writeVCF(t, `##fileformat=VCFv4.2
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	S1
+1	200	.	A	G	50	PASS	.	GT	0/1
+1	100	.	A	G	50	PASS	.	GT	0/1
+1	300	.	A	X	50	PASS	.	GT	0/1
+1	400	.	A	G	50	PASS	.	GT	0/2
+1	500	.	A	G	50	PASS	.	GT	1
+`)
+
+	report, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	expected := []string{"out of order", "invalid ALT", "unknown allele", "not diploid"}
+	if len(report.Issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %v", len(expected), report.Issues)
+	}
+	for i, want := range expected {
+		if !strings.Contains(report.Issues[i].Message, want) {
+			t.Errorf("Issue %d: expected %q, got %q", i, want, report.Issues[i].Message)
+		}
+	}
+}
+
+func TestOpenSourceAppliesOptions(t *testing.T) {
+	path := writeVCF(t, `##fileformat=VCFv4.2
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	S1
+1	100	rs1	A	G	50	PASS	.	GT	0/1
+1	200	rs2	C	T	5	PASS	.	GT	1/1
+2	100	rs3	T	C	40	PASS	.	GT	0/0
+`)
+
+	source, err := OpenSource(path, Options{
+		MinQuality: 20,
+		Liftover:   []proofs.LiftoverBlock{{Chromosome: "1", Start: 1000, End: 2000, Offset: -900}},
+		Regions:    []proofs.Region{{Chromosome: "1", Start: 0, End: 1050}},
+	})
+	if err != nil {
+		t.Fatalf("OpenSource failed: %v", err)
+	}
+
+	calls, err := Scan(source, proofs.Region{Chromosome: "1", Start: 0, End: 5000})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0].ID != "rs1" || calls[0].Position != 1000 {
+		t.Errorf("Expected only rs1 lifted to 1000, got %v", calls)
+	}
+}
+
+func TestLiftoverWritesTargetCoordinates(t *testing.T) {
+	path := writeVCF(t, `##fileformat=VCFv4.2
+##reference=GRCh37
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	S1
+1	100	rs1	A	G	50	PASS	.	GT	0|1
+1	5000	rs2	C	T	50	PASS	.	GT	1/1
+`)
+
+	var buf bytes.Buffer
+	err := Liftover(&buf, path, Options{
+		TargetBuild: traits.BuildGRCh38,
+		Liftover:    []proofs.LiftoverBlock{{Chromosome: "1", Start: 1000, End: 2000, Offset: -900}},
+	})
+	if err != nil {
+		t.Fatalf("Liftover failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "##reference=GRCh38") {
+		t.Errorf("Expected GRCh38 header, got:\n%s", out)
+	}
+	if !strings.Contains(out, "1\t1000\trs1\tA\tG\t50\tPASS\t.\tGT\t0|1") {
+		t.Errorf("Expected rs1 at 1000, got:\n%s", out)
+	}
+	if strings.Contains(out, "rs2") {
+		t.Errorf("Expected unmapped rs2 to be dropped, got:\n%s", out)
+	}
+}
+
+func TestParseRegion(t *testing.T) {
+	tests := []struct {
+		input string
+		want  proofs.Region
+		ok    bool
+	}{
+		{"chr1:100-200", proofs.Region{Chromosome: "chr1", Start: 100, End: 200}, true},
+		{"13:32914437", proofs.Region{Chromosome: "13", Start: 32914437, End: 32914437}, true},
+		{"1:200-100", proofs.Region{}, false},
+		{":100", proofs.Region{}, false},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseRegion(tc.input)
+		if tc.ok && (err != nil || got != tc.want) {
+			t.Errorf("ParseRegion(%q) = %v, %v; want %v", tc.input, got, err, tc.want)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("ParseRegion(%q) should fail", tc.input)
+		}
+	}
+}
+
+func TestImportClinVar(t *testing.T) {
+	vcfPath := writeVCF(t, "##fileformat=VCFv4.1\n"+
+		"##reference=GRCh37\n"+
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\n"+
+		"13\t32914437\t9322\tGT\tG\t.\t.\tCLNSIG=Pathogenic;GENEINFO=BRCA2:675;RS=80359550\n"+
+		"17\t41209079\t17662\tT\tTG\t.\t.\tCLNSIG=Pathogenic/Likely_pathogenic;GENEINFO=BRCA1:672;RS=80357906\n"+
+		"17\t41245466\t55407\tG\tA\t.\t.\tCLNSIG=Benign;GENEINFO=BRCA1:672\n"+
+		"17\t41276044\t91616\tAGTC\t<DEL>\t.\t.\tCLNSIG=Pathogenic;GENEINFO=BRCA1:672\n"+
+		"7\t117199644\t7105\tATCT\tA\t.\t.\tCLNSIG=Pathogenic;GENEINFO=CFTR:1080\n")
+
+	panel, err := ImportClinVar(vcfPath, ClinVarFilter{Genes: []string{"BRCA1", "brca2"}})
+	if err != nil {
+		t.Fatalf("ImportClinVar should not return error: %v", err)
+	}
+	if err := panel.Validate(); err != nil {
+		t.Fatalf("Expected a valid panel: %v", err)
+	}
+	if panel.Name != "brca1_brca2_clinvar" || len(panel.Variants) != 2 {
+		t.Fatalf("Expected the two pathogenic BRCA variants, got %s with %+v", panel.Name, panel.Variants)
+	}
+	variant := panel.Variants[1]
+	if variant.Gene != "BRCA1" || variant.Chromosome != 17 || variant.Position != 41209079 ||
+		variant.Ref != "T" || variant.Alt != "TG" || variant.Genotype != 0 || variant.Build != traits.BuildGRCh37 {
+		t.Errorf("Unexpected panel variant %+v", variant)
+	}
+	if !strings.Contains(variant.Trait, "rs80357906") {
+		t.Errorf("Expected the trait to name the rsID, got %q", variant.Trait)
+	}
+
+	if _, err := ImportClinVar(vcfPath, ClinVarFilter{Build: traits.BuildGRCh38}); err == nil {
+		t.Error("Expected a GRCh37 VCF not to be imported as GRCh38")
+	}
+
+	summaryPath := filepath.Join(t.TempDir(), "variant_summary.txt")
+	summary := "#AlleleID\tName\tGeneSymbol\tClinicalSignificance\tAssembly\tChromosome\tPositionVCF\tReferenceAlleleVCF\tAlternateAlleleVCF\n" +
+		"46299\tNM_000059.4(BRCA2):c.5946del (p.Ser1982fs)\tBRCA2\tPathogenic\tGRCh37\t13\t32914437\tGT\tG\n" +
+		"46299\tNM_000059.4(BRCA2):c.5946del (p.Ser1982fs)\tBRCA2\tPathogenic\tGRCh38\t13\t32340300\tGT\tG\n" +
+		"70345\tNM_000059.4(BRCA2):c.68-7T>A\tBRCA2\tBenign\tGRCh37\t13\t32893207\tT\tA\n"
+	if err := os.WriteFile(summaryPath, []byte(summary), 0644); err != nil {
+		t.Fatal(err)
+	}
+	panel, err = ImportClinVar(summaryPath, ClinVarFilter{Genes: []string{"BRCA2"}, Build: traits.BuildGRCh38})
+	if err != nil {
+		t.Fatalf("ImportClinVar should read variant_summary: %v", err)
+	}
+	if len(panel.Variants) != 1 || panel.Variants[0].Position != 32340300 {
+		t.Errorf("Expected the GRCh38 row of the pathogenic variant, got %+v", panel.Variants)
+	}
+}
@@ -0,0 +1,30 @@
+package genotools
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// Liftover writes the records of vcfPath translated into opts.TargetBuild
+// coordinates through opts.Liftover. Records outside every block are dropped,
+// and only genotypes are carried over.
+func Liftover(w io.Writer, vcfPath string, opts Options) error {
+	if len(opts.Liftover) == 0 {
+		return fmt.Errorf("liftover requires at least one liftover block")
+	}
+
+	source, err := OpenSource(vcfPath, opts)
+	if err != nil {
+		return err
+	}
+
+	calls, err := Scan(source, proofs.Region{Start: 0, End: math.MaxUint64})
+	if err != nil {
+		return err
+	}
+
+	return WriteVCF(w, source.SampleNames(), opts.TargetBuild, calls)
+}
@@ -0,0 +1,94 @@
+package genotools
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// SynthOptions configures a synthetic VCF
+type SynthOptions struct {
+	// Variants to emit; DefaultSynthVariants when empty
+	Variants []traits.TraitVariant
+	// Samples is the number of samples; 1 when zero
+	Samples int
+	// AltFrequency is the probability of each allele being ALT; 0.5 when zero
+	AltFrequency float64
+	// Seed makes the output reproducible
+	Seed  int64
+	Build traits.GenomeBuild
+}
+
+// DefaultSynthVariants returns the variants read by the built-in proof types
+func DefaultSynthVariants() []traits.TraitVariant {
+	variants := []traits.TraitVariant{
+		traits.ABOFunctionalVariant,
+		traits.ABOBVariant,
+		traits.ACTN3Variant,
+		traits.ALDH2Variant,
+		traits.CCR5Delta32Variant,
+		traits.MTHFRC677TVariant,
+		traits.MTHFRA1298CVariant,
+		traits.ABCC11Variant,
+	}
+	for _, star := range traits.CYP2D6StarAlleles {
+		variants = append(variants, star.Variant)
+	}
+	return append(variants, traits.BRCA2PathogenicVariants...)
+}
+
+// Synth writes a synthetic VCF with random diploid genotypes at the configured
+// variants, for testing pipelines without real genomes
+func Synth(w io.Writer, opts SynthOptions) error {
+	variants := opts.Variants
+	if len(variants) == 0 {
+		variants = DefaultSynthVariants()
+	}
+	samples := opts.Samples
+	if samples <= 0 {
+		samples = 1
+	}
+	altFrequency := opts.AltFrequency
+	if altFrequency <= 0 {
+		altFrequency = 0.5
+	}
+	if altFrequency > 1 {
+		return fmt.Errorf("alt frequency %v is greater than 1", altFrequency)
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	names := make([]string, samples)
+	for i := range names {
+		names[i] = fmt.Sprintf("SYNTH%d", i+1)
+	}
+
+	calls := make([]*proofs.VariantCall, len(variants))
+	for i, variant := range variants {
+		call := &proofs.VariantCall{
+			Chromosome: strconv.Itoa(variant.Chromosome),
+			Position:   uint64(variant.Position),
+			Reference:  variant.Ref,
+			Alternate:  []string{variant.Alt},
+			Quality:    60,
+			Filter:     "PASS",
+			Samples:    make([]proofs.SampleCall, samples),
+		}
+		for s := range call.Samples {
+			gt := []int{0, 0}
+			for a := range gt {
+				if rng.Float64() < altFrequency {
+					gt[a] = 1
+				}
+			}
+			call.Samples[s] = proofs.SampleCall{GT: gt}
+		}
+		calls[i] = call
+	}
+
+	return WriteVCF(w, names, opts.Build, calls)
+}
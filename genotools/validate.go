@@ -0,0 +1,194 @@
+package genotools
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// maxValidationIssues bounds the issues collected for a single file
+const maxValidationIssues = 100
+
+// ValidationIssue is a problem found on one line of a VCF
+type ValidationIssue struct {
+	Line    int
+	Message string
+}
+
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+// ValidationReport summarizes a VCF and the problems that would make proof
+// generation fail or silently misread it
+type ValidationReport struct {
+	Records     int
+	Samples     []string
+	Chromosomes []string
+	Build       traits.GenomeBuild
+	Issues      []ValidationIssue
+}
+
+// Valid reports whether no issues were found
+func (r *ValidationReport) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// Validate checks the structure of vcfPath line by line: a #CHROM header,
+// column counts, positions sorted within each chromosome, REF/ALT alleles and
+// parseable genotypes. Only I/O failures are returned as errors.
+func Validate(vcfPath string) (*ValidationReport, error) {
+	f, err := proofs.OpenVCFFile(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	report := &ValidationReport{}
+	addIssue := func(line int, format string, args ...any) {
+		if len(report.Issues) < maxValidationIssues {
+			report.Issues = append(report.Issues, ValidationIssue{Line: line, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	var columns int
+	lastPos := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "##") {
+			if lineNum == 1 && !strings.HasPrefix(line, "##fileformat=VCF") {
+				addIssue(lineNum, "missing ##fileformat line")
+			}
+			if report.Build == traits.BuildUnknown {
+				report.Build = traits.BuildFromHeaderLine(line)
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "#CHROM") {
+			fields := strings.Split(line, "\t")
+			columns = len(fields)
+			if columns < 8 {
+				addIssue(lineNum, "header has %d columns, expected at least 8", columns)
+			}
+			if columns > 9 {
+				report.Samples = fields[9:]
+			}
+			continue
+		}
+
+		if columns == 0 {
+			addIssue(lineNum, "record before #CHROM header line")
+			return report, nil
+		}
+
+		report.Records++
+		fields := strings.Split(line, "\t")
+		if len(fields) != columns {
+			addIssue(lineNum, "record has %d columns, header has %d", len(fields), columns)
+			continue
+		}
+
+		chrom := fields[0]
+		pos, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			addIssue(lineNum, "invalid position %q", fields[1])
+			continue
+		}
+		last, seen := lastPos[chrom]
+		if !seen {
+			report.Chromosomes = append(report.Chromosomes, chrom)
+		} else if pos < last {
+			addIssue(lineNum, "position %d on %s is out of order", pos, chrom)
+		}
+		lastPos[chrom] = pos
+
+		if !validAllele(fields[3]) {
+			addIssue(lineNum, "invalid REF allele %q", fields[3])
+		}
+		alts := strings.Split(fields[4], ",")
+		for _, alt := range alts {
+			if alt != "." && alt != "*" && !strings.HasPrefix(alt, "<") && !validAllele(alt) {
+				addIssue(lineNum, "invalid ALT allele %q", alt)
+			}
+		}
+
+		if len(report.Samples) > 0 {
+			validateGenotypes(fields[8], fields[9:], len(alts), func(format string, args ...any) {
+				addIssue(lineNum, format, args...)
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if columns == 0 {
+		addIssue(0, "missing #CHROM header line")
+	}
+
+	return report, nil
+}
+
+// validateGenotypes checks that each sample has a diploid GT whose allele
+// indices exist
+func validateGenotypes(format string, samples []string, altCount int, addIssue func(string, ...any)) {
+	keys := strings.Split(format, ":")
+	gtIndex := -1
+	for i, key := range keys {
+		if key == "GT" {
+			gtIndex = i
+		}
+	}
+	if gtIndex < 0 {
+		addIssue("FORMAT has no GT field")
+		return
+	}
+
+	for s, sample := range samples {
+		values := strings.Split(sample, ":")
+		if gtIndex >= len(values) {
+			addIssue("sample %d has no GT value", s+1)
+			continue
+		}
+		gt := values[gtIndex]
+		alleles := strings.FieldsFunc(gt, func(r rune) bool { return r == '/' || r == '|' })
+		if len(alleles) != 2 {
+			addIssue("sample %d genotype %q is not diploid", s+1, gt)
+			continue
+		}
+		for _, allele := range alleles {
+			if allele == "." {
+				continue
+			}
+			index, err := strconv.Atoi(allele)
+			if err != nil || index < 0 || index > altCount {
+				addIssue("sample %d genotype %q references an unknown allele", s+1, gt)
+				break
+			}
+		}
+	}
+}
+
+// validAllele reports whether s is a non-empty nucleotide sequence
+func validAllele(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range strings.ToUpper(s) {
+		if !strings.ContainsRune("ACGTN", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package genotools
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// WriteVCF writes calls as a minimal VCF carrying only GT, sorted by
+// chromosome and position
+func WriteVCF(w io.Writer, samples []string, build traits.GenomeBuild, calls []*proofs.VariantCall) error {
+	sorted := make([]*proofs.VariantCall, len(calls))
+	copy(sorted, calls)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ci, cj := chromosomeOrder(sorted[i].Chromosome), chromosomeOrder(sorted[j].Chromosome)
+		if ci != cj {
+			return ci < cj
+		}
+		return sorted[i].Position < sorted[j].Position
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "##fileformat=VCFv4.2")
+	if build != traits.BuildUnknown {
+		fmt.Fprintf(bw, "##reference=%s\n", build)
+	}
+	fmt.Fprintln(bw, `##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">`)
+
+	header := []string{"#CHROM", "POS", "ID", "REF", "ALT", "QUAL", "FILTER", "INFO"}
+	if len(samples) > 0 {
+		header = append(header, "FORMAT")
+		header = append(header, samples...)
+	}
+	fmt.Fprintln(bw, strings.Join(header, "\t"))
+
+	for _, call := range sorted {
+		fields := []string{
+			call.Chromosome,
+			strconv.FormatUint(call.Position, 10),
+			orMissing(call.ID),
+			call.Reference,
+			orMissing(strings.Join(call.Alternate, ",")),
+			strconv.FormatFloat(float64(call.Quality), 'f', -1, 32),
+			orMissing(call.Filter),
+			".",
+		}
+		if len(samples) > 0 {
+			fields = append(fields, "GT")
+			for i := range samples {
+				gt := "."
+				if i < len(call.Samples) {
+					gt = FormatGenotype(call.Samples[i])
+				}
+				fields = append(fields, gt)
+			}
+		}
+		fmt.Fprintln(bw, strings.Join(fields, "\t"))
+	}
+
+	return bw.Flush()
+}
+
+// chromosomeOrder sorts autosomes numerically, followed by X, Y, MT and any others
+func chromosomeOrder(chrom string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(chrom, "chr"), "CHR")
+	if n, err := strconv.Atoi(name); err == nil {
+		return fmt.Sprintf("0%03d", n)
+	}
+	switch strings.ToUpper(name) {
+	case "X":
+		return "1X"
+	case "Y":
+		return "1Y"
+	case "M", "MT":
+		return "1Z"
+	}
+	return "2" + name
+}
+
+func orMissing(s string) string {
+	if s == "" {
+		return "."
+	}
+	return s
+}
@@ -3,9 +3,12 @@ module github.com/zkgenomics/zkgenomics-proofs
 go 1.24.3
 
 require (
+	filippo.io/age v1.2.1
 	github.com/brentp/vcfgo v0.0.0-20240930171553-9739269bd784
 	github.com/consensys/gnark v0.12.0
 	github.com/consensys/gnark-crypto v0.15.0
+	github.com/fxamacker/cbor/v2 v2.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -13,16 +16,19 @@ require (
 	github.com/blang/semver/v4 v4.0.0 // indirect
 	github.com/brentp/irelate v0.0.1 // indirect
 	github.com/consensys/bavard v0.1.27 // indirect
-	github.com/fxamacker/cbor/v2 v2.7.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/google/pprof v0.0.0-20240727154555-813a5fbdbec8 // indirect
 	github.com/ingonyama-zk/icicle/v3 v3.1.1-0.20241118092657-fccdb2f0921b // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mmcloughlin/addchain v0.4.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/ronanh/intcomp v1.1.0 // indirect
 	github.com/rs/zerolog v1.33.0 // indirect
+	github.com/stretchr/testify v1.10.0 // indirect
 	github.com/x448/float16 v0.8.4 // indirect
 	golang.org/x/crypto v0.32.0 // indirect
+	golang.org/x/exp v0.0.0-20240823005443-9b4947da3948 // indirect
 	golang.org/x/sync v0.10.0 // indirect
 	golang.org/x/sys v0.29.0 // indirect
 	rsc.io/tmplfunc v0.0.3 // indirect
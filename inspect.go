@@ -0,0 +1,22 @@
+package zkgenomics
+
+import "github.com/zkgenomics/zkgenomics-proofs/proofs"
+
+// CircuitCost re-exports what proving a circuit takes
+type CircuitCost = proofs.CircuitCost
+
+// InspectCircuit returns what proving proofs of proofType takes with the
+// generator's backend, curve and threads: constraint and variable counts, and
+// estimated key sizes, proof size and proving time. Circuits sized from the
+// genome are sized from the VCF at vcfPath, which other circuits ignore.
+func (pg *ProofGenerator) InspectCircuit(proofType ProofType, vcfPath string) (*CircuitCost, error) {
+	circuit, err := pg.proofCircuit(proofType, vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := pg.backend()
+	if err != nil {
+		return nil, err
+	}
+	return proofs.InspectCircuit(circuit, backend, pg.Threads)
+}
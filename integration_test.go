@@ -16,6 +16,26 @@ func TestProofGeneratorIntegration(t *testing.T) {
 		BRCA1ProofType,
 		HERC2ProofType,
 		DynamicProofType,
+		BloodTypeProofType,
+		CohortProofType,
+		CYP2D6ProofType,
+		ACTN3ProofType,
+		ALDH2ProofType,
+		CCR5ProofType,
+		MTHFRProofType,
+		BRCA2ProofType,
+		BurdenProofType,
+		ABCC11ProofType,
+		SexChromosomeProofType,
+		RsIDProofType,
+		NegativeProofType,
+		KinshipProofType,
+		AggregateProofType,
+		CommittedProofType,
+		CarrierProofType,
+		RegionCountProofType,
+		PhaseProofType,
+		CustomProofType,
 	}
 	
 	if len(supportedTypes) != len(expectedTypes) {
@@ -0,0 +1,129 @@
+package zkgenomics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// KeyStore re-exports the interface persisting proving and verifying keys
+type KeyStore = proofs.KeyStore
+
+// FileKeyStore re-exports the directory-backed KeyStore
+type FileKeyStore = proofs.FileKeyStore
+
+// CircuitKey re-exports the identifier keys are stored under
+type CircuitKey = proofs.CircuitKey
+
+// ProvidedKeys re-exports the KeyStore holding keys supplied by KeyProviders
+type ProvidedKeys = proofs.ProvidedKeys
+
+// KeyProvider re-exports the interface supplying serialized keys
+type KeyProvider = proofs.KeyProvider
+
+// FromFile provides the key in the file at path
+func FromFile(path string) KeyProvider {
+	return proofs.FromFile(path)
+}
+
+// FromBytes provides key, such as a key embedded in the binary
+func FromBytes(key []byte) KeyProvider {
+	return proofs.FromBytes(key)
+}
+
+// FromReader provides the key read from r, reading it once
+func FromReader(r io.Reader) KeyProvider {
+	return proofs.FromReader(r)
+}
+
+// FromHTTP provides the key served at url, fetched with client, or with
+// http.DefaultClient if client is nil
+func FromHTTP(url string, client *http.Client) KeyProvider {
+	return proofs.FromHTTP(url, client)
+}
+
+// Setup runs the key setup for proofs of proofType, storing the keys in the
+// generator's key store so later proofs reuse them. Proofs whose circuit is
+// sized from the genome, such as kinship and region count proofs, need
+// vcfPath; for others it may be empty.
+func (pg *ProofGenerator) Setup(proofType ProofType, vcfPath string) (CircuitKey, error) {
+	if pg.Keys == nil {
+		return CircuitKey{}, fmt.Errorf("no key store configured")
+	}
+	circuit, err := pg.proofCircuit(proofType, vcfPath)
+	if err != nil {
+		return CircuitKey{}, err
+	}
+	backend, err := pg.backend()
+	if err != nil {
+		return CircuitKey{}, err
+	}
+	defer proofs.CapThreads(pg.Threads)()
+	return proofs.SetupKeys(circuit, backend, pg.Keys, pg.Logger)
+}
+
+// proofCircuit returns the circuit of proofs of proofType, sized from the VCF
+// at vcfPath if it depends on the genome
+func (pg *ProofGenerator) proofCircuit(proofType ProofType, vcfPath string) (frontend.Circuit, error) {
+	proof, err := pg.newProof(proofType)
+	if err != nil {
+		return nil, err
+	}
+	defer pg.registerIdentities(vcfPath)()
+	return proofs.ProofCircuit(proof, vcfPath)
+}
+
+// KeyBundle re-exports the signed package of a circuit's keys
+type KeyBundle = proofs.KeyBundle
+
+// ExportKeys bundles the keys of proofs of proofType held in the generator's
+// key store, setting them up first if it holds none, for shipping to provers
+// or, without the proving key, to verifiers. Sign the bundle with
+// SignKeyBundle before shipping it.
+func (pg *ProofGenerator) ExportKeys(proofType ProofType, vcfPath string, withProvingKey bool) (*KeyBundle, error) {
+	circuit, err := pg.Setup(proofType, vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	return proofs.NewKeyBundle(pg.Keys, circuit, string(proofType), withProvingKey)
+}
+
+// SignKeyBundle signs bundle with signer, such as a project's issuer key
+func SignKeyBundle(bundle *KeyBundle, signer EnvelopeSigner) error {
+	return proofs.SignKeyBundle(bundle, signer)
+}
+
+// ImportKeys checks the checksums of bundle and that one of the generator's
+// IssuerKeys signed it, then stores its keys in the key store if it holds a
+// proving key, and trusts its verifying key in KeyRegistry for the bundle's
+// proof type and circuit if a registry is set. It returns the circuit of the
+// keys.
+func (pg *ProofGenerator) ImportKeys(bundle *KeyBundle) (CircuitKey, error) {
+	if len(pg.IssuerKeys) == 0 {
+		return CircuitKey{}, fmt.Errorf("no issuer keys configured to check the key bundle signature")
+	}
+	if _, err := bundle.Verify(pg.IssuerKeys); err != nil {
+		return CircuitKey{}, err
+	}
+
+	imported := false
+	if len(bundle.ProvingKey) > 0 && pg.Keys != nil {
+		if _, err := proofs.ImportKeyBundle(bundle, pg.Keys, pg.IssuerKeys); err != nil {
+			return CircuitKey{}, err
+		}
+		imported = true
+	}
+	if pg.KeyRegistry != nil && bundle.ProofType != "" {
+		if err := pg.KeyRegistry.RegisterCircuit(ProofType(bundle.ProofType), bundle.VerifyingKey, bundle.Circuit()); err != nil {
+			return CircuitKey{}, err
+		}
+		imported = true
+	}
+	if !imported {
+		return CircuitKey{}, fmt.Errorf("nothing to import the key bundle into: set a key store for proving keys, or a registry and proof type for verifying keys")
+	}
+	return bundle.Circuit(), nil
+}
@@ -0,0 +1,246 @@
+package zkgenomics
+
+import (
+	"crypto"
+
+	"filippo.io/age"
+	"github.com/consensys/gnark/constraint/solver"
+)
+
+// Option configures a ProofGenerator created by NewProofGenerator
+type Option func(*ProofGenerator)
+
+// WithProgress sends progress updates from VCF scans to progress
+func WithProgress(progress ProgressReporter) Option {
+	return func(pg *ProofGenerator) {
+		pg.Progress = progress
+	}
+}
+
+// WithLogger sends messages from proving and verification to logger
+func WithLogger(logger Logger) Option {
+	return func(pg *ProofGenerator) {
+		pg.Logger = logger
+	}
+}
+
+// WithSeed makes key setup and proving deterministic from seed, so tests and
+// auditors can reproduce identical artifacts. Seeded proofs are not secure
+// and must not be given to relying parties.
+func WithSeed(seed []byte) Option {
+	return func(pg *ProofGenerator) {
+		pg.Seed = seed
+	}
+}
+
+// WithBRCA2Panel replaces the default BRCA2 pathogenic variant panel
+func WithBRCA2Panel(panel []TraitVariant) Option {
+	return func(pg *ProofGenerator) {
+		pg.BRCA2Panel = panel
+	}
+}
+
+// WithMaxVariants bounds how many non-reference variants BRCA2 proofs scan
+// for, replacing the default of 256
+func WithMaxVariants(maxVariants int) Option {
+	return func(pg *ProofGenerator) {
+		pg.MaxVariants = maxVariants
+	}
+}
+
+// WithBurdenPolicy replaces the default burden policy
+func WithBurdenPolicy(policy BurdenPolicy) Option {
+	return func(pg *ProofGenerator) {
+		pg.BurdenPolicy = &policy
+	}
+}
+
+// WithChromosomeSlots sizes the chromosome circuit
+func WithChromosomeSlots(slots int) Option {
+	return func(pg *ProofGenerator) {
+		pg.ChromosomeSlots = slots
+	}
+}
+
+// WithTargetChromosome sets the code of the chromosome that chromosome proofs
+// show present, replacing chromosome 22
+func WithTargetChromosome(chromosome int) Option {
+	return func(pg *ProofGenerator) {
+		pg.TargetChromosome = chromosome
+	}
+}
+
+// WithAdvisories replaces the advisories bundled with this release when
+// checking verified proofs
+func WithAdvisories(advisories *AdvisoryList) Option {
+	return func(pg *ProofGenerator) {
+		pg.Advisories = advisories
+	}
+}
+
+// WithRevocations consults checker for revoked proofs when checking verified
+// proofs
+func WithRevocations(checker RevocationChecker) Option {
+	return func(pg *ProofGenerator) {
+		pg.Revocations = checker
+	}
+}
+
+// WithIssuerKeys requires verified proofs to carry an envelope signature by
+// one of keys, trusted issuer keys by key ID
+func WithIssuerKeys(keys map[string]crypto.PublicKey) Option {
+	return func(pg *ProofGenerator) {
+		pg.IssuerKeys = keys
+	}
+}
+
+// WithLabKeys requires verified proofs to carry an attestation of their
+// genome signed by one of keys, trusted sequencing lab keys by key ID, and to
+// disclose the attested Merkle root, which only committed proofs do
+func WithLabKeys(keys map[string]crypto.PublicKey) Option {
+	return func(pg *ProofGenerator) {
+		pg.LabKeys = keys
+	}
+}
+
+// WithKeyStore generates proofs with the keys held in keys, setting up and
+// storing keys for circuits it holds none for
+func WithKeyStore(keys KeyStore) Option {
+	return func(pg *ProofGenerator) {
+		pg.Keys = keys
+	}
+}
+
+// WithCircuitCache keeps compiled circuits and their keys in cache across
+// proofs, such as proofs of a long-running service
+func WithCircuitCache(cache *CircuitCache) Option {
+	return func(pg *ProofGenerator) {
+		pg.Cache = cache
+	}
+}
+
+// WithBackend generates proofs with backend, such as PLONK, whose universal
+// setup spares each circuit its own trusted setup at the cost of larger proofs
+func WithBackend(backend Backend) Option {
+	return func(pg *ProofGenerator) {
+		pg.Backend = backend
+	}
+}
+
+// WithCurve generates proofs over curve, such as BLS12_381 for a higher
+// security margin, with the generator's backend
+func WithCurve(curve Curve) Option {
+	return func(pg *ProofGenerator) {
+		pg.Curve = curve
+	}
+}
+
+// WithThreads caps the CPU threads setup and proving use at threads, so
+// proofs on a small machine leave room for other work. Proofs use every CPU
+// by default.
+func WithThreads(threads int) Option {
+	return func(pg *ProofGenerator) {
+		pg.Threads = threads
+	}
+}
+
+// WithSolverHints gives the solver hints beside those circuits declare, such
+// as hints of gadgets from other packages. Each must be registered with
+// proofs.RegisterHint, or proving fails.
+func WithSolverHints(hints ...solver.Hint) Option {
+	return func(pg *ProofGenerator) {
+		pg.SolverHints = append(pg.SolverHints, hints...)
+	}
+}
+
+// WithExternalProver proves with prover in place of the backend, such as a GPU
+// prover or a RemoteProver, while circuits are compiled, witnesses created
+// and proofs verified locally. Proofs from prover that do not verify are
+// refused.
+func WithExternalProver(prover ExternalProver) Option {
+	return func(pg *ProofGenerator) {
+		pg.ExternalProver = prover
+	}
+}
+
+// WithInputFormat requires genomes to be in format, such as Input23andMe for
+// the raw data a 23andMe customer downloads. Without it the format of each
+// genome is detected from its contents.
+func WithInputFormat(format InputFormat) Option {
+	return func(pg *ProofGenerator) {
+		pg.InputFormat = format
+	}
+}
+
+// WithLiftover lifts genomes over to target through blocks before proving,
+// so genomes in another build can be proven against coordinates in target,
+// such as the GRCh37 coordinates of the built-in traits
+func WithLiftover(target GenomeBuild, blocks []LiftoverBlock) Option {
+	return func(pg *ProofGenerator) {
+		pg.LiftoverBuild = target
+		pg.Liftover = blocks
+	}
+}
+
+// WithIdentities decrypts age-encrypted genomes with identities, such as
+// those proofs.LoadIdentities reads from an age-keygen file or a passphrase's
+// age.NewScryptIdentity
+func WithIdentities(identities ...age.Identity) Option {
+	return func(pg *ProofGenerator) {
+		pg.Identities = append(pg.Identities, identities...)
+	}
+}
+
+// WithCoverage proves absences only at loci coverage shows were sequenced,
+// so a locus a VCF has no record of for lack of reads is not taken as
+// homozygous reference
+func WithCoverage(coverage CoverageSource) Option {
+	return func(pg *ProofGenerator) {
+		pg.Coverage = coverage
+	}
+}
+
+// WithTraitRegistry reads the loci of the built-in trait proofs from
+// registry, such as one LoadTraitRegistry extends with user definitions
+func WithTraitRegistry(registry *TraitRegistry) Option {
+	return func(pg *ProofGenerator) {
+		pg.Traits = registry
+	}
+}
+
+// WithPinnedVKFingerprints accepts proofs of proofType only with a verifying
+// key of one of fingerprints, as returned by VKFingerprint. Once any type is
+// pinned, proofs of types without pins are refused.
+func WithPinnedVKFingerprints(proofType ProofType, fingerprints ...string) Option {
+	return func(pg *ProofGenerator) {
+		if pg.PinnedVKFingerprints == nil {
+			pg.PinnedVKFingerprints = make(map[ProofType][]string)
+		}
+		pg.PinnedVKFingerprints[proofType] = append(pg.PinnedVKFingerprints[proofType], fingerprints...)
+	}
+}
+
+// WithVerifyingKeyRegistry verifies proofs against the keys registered in
+// registry for their type
+func WithVerifyingKeyRegistry(registry *VerifyingKeyRegistry) Option {
+	return func(pg *ProofGenerator) {
+		pg.KeyRegistry = registry
+	}
+}
+
+// WithInsecureBundledKeys verifies proofs against the verifying key they
+// carry when none is registered or pinned for their type. The prover chose
+// that key, so a proof verified this way proves nothing; use it only for
+// development and tests.
+func WithInsecureBundledKeys() Option {
+	return func(pg *ProofGenerator) {
+		pg.InsecureBundledKeys = true
+	}
+}
+
+// WithIgnoredAdvisories overrides the findings of the listed advisory IDs
+func WithIgnoredAdvisories(ids ...string) Option {
+	return func(pg *ProofGenerator) {
+		pg.IgnoreAdvisories = append(pg.IgnoreAdvisories, ids...)
+	}
+}
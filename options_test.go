@@ -0,0 +1,35 @@
+package zkgenomics
+
+import (
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+func TestNewProofGenerator_Options(t *testing.T) {
+	pg := NewProofGenerator(
+		WithChromosomeSlots(4),
+		WithTargetChromosome(ChromosomeCode("X")),
+		WithMaxVariants(8),
+		WithIgnoredAdvisories("TEST-1", "TEST-2"),
+	)
+	if pg.ChromosomeSlots != 4 || pg.TargetChromosome != 23 {
+		t.Errorf("Expected chromosome options to be applied, got %+v", pg)
+	}
+	if len(pg.IgnoreAdvisories) != 2 {
+		t.Errorf("Expected two ignored advisories, got %v", pg.IgnoreAdvisories)
+	}
+
+	proof, err := pg.newProof(BRCA2ProofType)
+	if err != nil {
+		t.Fatalf("newProof should not return error: %v", err)
+	}
+	if proof.(*proofs.BRCA2Proof).MaxVariants != 8 {
+		t.Errorf("Expected MaxVariants 8, got %d", proof.(*proofs.BRCA2Proof).MaxVariants)
+	}
+
+	// Generators are configured independently
+	if other := NewProofGenerator(); other.ChromosomeSlots != 0 || other.MaxVariants != 0 {
+		t.Errorf("Expected a default generator, got %+v", other)
+	}
+}
@@ -0,0 +1,110 @@
+package zkgenomics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// PanelVariant re-exports a trait panel's variant and claimed genotype for
+// convenience
+type PanelVariant = traits.PanelVariant
+
+// LoadTraitPanel reads and validates a JSON or YAML trait panel
+func LoadTraitPanel(path string) (*TraitPanel, error) {
+	return traits.LoadTraitPanel(path)
+}
+
+// PanelMode selects how GeneratePanel proves the claims of a trait panel
+type PanelMode string
+
+const (
+	// PanelAggregate proves every claim of the panel in one aggregate proof
+	PanelAggregate PanelMode = "aggregate"
+	// PanelBundle proves each claim in a proof of its own, so the claims can
+	// be disclosed separately
+	PanelBundle PanelMode = "bundle"
+)
+
+// PanelManifest lists the proofs of a trait panel and the claims each proves
+type PanelManifest struct {
+	Panel   string               `json:"panel"`
+	Mode    PanelMode            `json:"mode"`
+	Entries []PanelManifestEntry `json:"entries"`
+}
+
+// PanelManifestEntry is one proof of a panel. ProofFile is left for callers
+// that store the proof to fill in.
+type PanelManifestEntry struct {
+	ProofID   string         `json:"proof_id"`
+	ProofFile string         `json:"proof_file,omitempty"`
+	Variants  []PanelVariant `json:"variants"`
+}
+
+// PanelResult is the outcome of GeneratePanel: the proofs, in the order of
+// the manifest entries, and the manifest
+type PanelResult struct {
+	Proofs   []*ProofData
+	Manifest *PanelManifest
+}
+
+// GeneratePanel proves the claims of panel from the genome req names, as an
+// aggregate proof of every claim or a bundle of one aggregate proof per
+// claim. The rest of req, such as its sample, nonce or validity window,
+// applies to every proof; its proof type and claim are ignored. A bundle
+// reads the genome once per proof, so req must name it by VCFPath.
+func (pg *ProofGenerator) GeneratePanel(ctx context.Context, req ProofRequest, panel *TraitPanel, mode PanelMode) (*PanelResult, error) {
+	if err := panel.Validate(); err != nil {
+		return nil, err
+	}
+
+	var groups [][]PanelVariant
+	switch mode {
+	case PanelAggregate, "":
+		mode = PanelAggregate
+		groups = [][]PanelVariant{panel.Variants}
+	case PanelBundle:
+		if req.VCF != nil {
+			return nil, errors.New("a bundle of panel proofs needs VCFPath rather than a reader")
+		}
+		for _, variant := range panel.Variants {
+			groups = append(groups, []PanelVariant{variant})
+		}
+	default:
+		return nil, fmt.Errorf("unknown panel mode %q: expected aggregate or bundle", mode)
+	}
+
+	requests := make([]ProofRequest, len(groups))
+	for i, group := range groups {
+		claims := make([]AggregateClaim, len(group))
+		for j, variant := range group {
+			claims[j] = AggregateClaim{
+				Chromosome: variant.Chromosome,
+				Position:   uint64(variant.Position),
+				Ref:        variant.Ref,
+				Alt:        variant.Alt,
+				Genotype:   variant.Genotype,
+			}
+		}
+		requests[i] = req
+		requests[i].ProofType = AggregateProofType
+		requests[i].Claim = &ClaimSpec{ProofType: AggregateProofType, Claims: claims}
+	}
+
+	result := &PanelResult{Manifest: &PanelManifest{Panel: panel.Name, Mode: mode}}
+	for i, batch := range pg.GenerateBatch(ctx, requests, 0) {
+		if batch.Err != nil {
+			return nil, fmt.Errorf("panel %s: %w", panel.Name, batch.Err)
+		}
+		proofData := batch.Response.ProofData
+		result.Proofs = append(result.Proofs, proofData)
+		result.Manifest.Entries = append(result.Manifest.Entries, PanelManifestEntry{
+			ProofID:  proofs.ProofID(proofData),
+			Variants: groups[i],
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package zkgenomics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProofGenerator_GeneratePanel(t *testing.T) {
+	dir := t.TempDir()
+	vcfPath := filepath.Join(dir, "test.vcf")
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t0/1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/0\n"
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatalf("writing test VCF: %v", err)
+	}
+	panelPath := filepath.Join(dir, "panel.yaml")
+	panelFile := `name: fitness
+variants:
+  - {trait: ACTN3 R577X, chromosome: 11, position: 66328095, ref: C, alt: T, genotype: 1}
+  - {trait: ALDH2*2, chromosome: 12, position: 112241766, ref: G, alt: A, genotype: 0}
+`
+	if err := os.WriteFile(panelPath, []byte(panelFile), 0644); err != nil {
+		t.Fatalf("writing test panel: %v", err)
+	}
+	panel, err := LoadTraitPanel(panelPath)
+	if err != nil {
+		t.Fatalf("LoadTraitPanel should not return error: %v", err)
+	}
+
+	pg := NewProofGenerator(WithInsecureBundledKeys())
+	request := ProofRequest{VCFPath: vcfPath}
+	for mode, proofCount := range map[PanelMode]int{PanelAggregate: 1, PanelBundle: 2} {
+		result, err := pg.GeneratePanel(context.Background(), request, panel, mode)
+		if err != nil {
+			t.Fatalf("%s: GeneratePanel should not return error: %v", mode, err)
+		}
+		if len(result.Proofs) != proofCount || len(result.Manifest.Entries) != proofCount {
+			t.Fatalf("%s: expected %d proofs, got %d with %d manifest entries", mode, proofCount, len(result.Proofs), len(result.Manifest.Entries))
+		}
+		for i, proofData := range result.Proofs {
+			verified, err := pg.VerifyProofData(AggregateProofType, proofData)
+			if err != nil || verified.Result != ProofSuccess {
+				t.Errorf("%s: expected proof %d to verify, got %v: %v", mode, i, verified, err)
+			}
+		}
+		if entry := result.Manifest.Entries[proofCount-1]; entry.Variants[len(entry.Variants)-1].Trait != "ALDH2*2" {
+			t.Errorf("%s: expected the last manifest entry to list ALDH2*2, got %+v", mode, entry.Variants)
+		}
+	}
+
+	panel.Variants[1].Genotype = 2
+	if _, err := pg.GeneratePanel(context.Background(), request, panel, PanelAggregate); err == nil {
+		t.Error("Expected a false claim not to be proven")
+	}
+	panel.Variants[1] = panel.Variants[0]
+	if err := panel.Validate(); err == nil {
+		t.Error("Expected a variant listed twice to be refused")
+	}
+}
@@ -0,0 +1,238 @@
+package zkgenomics
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/genotools"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// PreflightSeverity tells problems that make proving fail from those that
+// may make it misread the genome
+type PreflightSeverity string
+
+const (
+	PreflightError   PreflightSeverity = "error"
+	PreflightWarning PreflightSeverity = "warning"
+)
+
+// PreflightProblem is a problem found before proving, with what to do about it
+type PreflightProblem struct {
+	Severity PreflightSeverity
+	Message  string
+}
+
+func (p PreflightProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Severity, p.Message)
+}
+
+// LocusCoverage re-exports how a genome covers a locus a proof reads
+type LocusCoverage = proofs.LocusCoverage
+
+// LocusStatus re-exports how a genome covers a locus
+type LocusStatus = proofs.LocusStatus
+
+// Locus statuses, re-exported for convenience
+const (
+	LocusCalled         = proofs.LocusCalled
+	LocusNoCall         = proofs.LocusNoCall
+	LocusAbsent         = proofs.LocusAbsent
+	LocusAlleleMismatch = proofs.LocusAlleleMismatch
+)
+
+// PreflightReport describes a genome and the problems that would make a
+// proof from it fail or misread it
+type PreflightReport struct {
+	VCFPath     string
+	Format      InputFormat
+	Build       GenomeBuild
+	Samples     []string
+	Chromosomes []string
+	Records     int
+	// Loci is the coverage of the loci the requested proof reads
+	Loci     []LocusCoverage
+	Problems []PreflightProblem
+}
+
+// OK reports whether no problem would make proving fail
+func (r *PreflightReport) OK() bool {
+	return !slices.ContainsFunc(r.Problems, func(p PreflightProblem) bool { return p.Severity == PreflightError })
+}
+
+func (r *PreflightReport) addProblem(severity PreflightSeverity, format string, args ...any) {
+	r.Problems = append(r.Problems, PreflightProblem{Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// maxPreflightIssues bounds the VCF validation issues listed in a report
+const maxPreflightIssues = 10
+
+// Preflight checks the genome at req.VCFPath before an expensive proving
+// run: its header and records, the GT field, chromosome naming, the sample
+// req selects and, if req names a proof, its build and coverage of the loci
+// the proof reads. Problems are reported rather than returned; only a proof
+// type that does not exist and I/O failures are errors. The parent genome
+// of a kinship proof is not checked.
+func (pg *ProofGenerator) Preflight(req ProofRequest) (*PreflightReport, error) {
+	var proof proofs.Proof
+	var err error
+	switch {
+	case req.Claim != nil:
+		proof, err = pg.newClaimProof(req.Claim)
+	case req.ProofType != "":
+		proof, err = pg.newProof(req.ProofType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	defer pg.registerIdentities(req.VCFPath)()
+	report := &PreflightReport{VCFPath: req.VCFPath}
+	format, err := proofs.DetectInputFormat(req.VCFPath)
+	if errors.Is(err, proofs.ErrMalformedVCF) {
+		report.addProblem(PreflightError, "%v; expected a VCF or 23andMe or AncestryDNA raw data", err)
+		return report, nil
+	}
+	if errors.Is(err, proofs.ErrEncryptedGenome) {
+		report.addProblem(PreflightError, "%v", err)
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	report.Format = format
+	if pg.InputFormat != "" && format != pg.InputFormat {
+		report.addProblem(PreflightError, "genome is %s input, but %s is required", format, pg.InputFormat)
+	}
+
+	if format == InputVCF {
+		validation, err := genotools.Validate(req.VCFPath)
+		if err != nil {
+			return nil, err
+		}
+		report.Records = validation.Records
+		report.Chromosomes = validation.Chromosomes
+		for i, issue := range validation.Issues {
+			if i == maxPreflightIssues {
+				report.addProblem(PreflightError, "%d more problems in the VCF; run zkgenomics genotools validate to list them", len(validation.Issues)-i)
+				break
+			}
+			report.addProblem(PreflightError, "%s", issue)
+		}
+		if len(validation.Samples) == 0 {
+			report.addProblem(PreflightError, "VCF has no sample columns; proofs read the genotype calls of a sample")
+		}
+		report.checkChromosomeNames()
+	}
+
+	source, err := proofs.OpenGenomeSource(req.VCFPath, nil)
+	if err != nil {
+		report.addProblem(PreflightError, "genome cannot be read: %v", err)
+		return report, nil
+	}
+	report.Build = source.Build()
+	report.Samples = source.SampleNames()
+	if req.Sample != "" {
+		selected, err := proofs.NewSampleSource(source, req.Sample)
+		if err != nil {
+			report.addProblem(PreflightError, "%v", err)
+			return report, nil
+		}
+		source = selected
+		if proof != nil {
+			if err := proofs.SelectSample(proof, req.Sample); err != nil {
+				report.addProblem(PreflightError, "%v", err)
+			}
+		}
+	}
+	if proof == nil {
+		return report, nil
+	}
+
+	loci := proofs.TargetLoci(proof)
+	if report.checkBuilds(loci.Variants, pg.LiftoverBuild, len(pg.Liftover) > 0) {
+		// Coordinates in another build would be looked up at the wrong loci
+		return report, nil
+	}
+	report.Loci, err = proofs.CheckCoverage(source, loci.Variants)
+	if err != nil {
+		return nil, err
+	}
+	for _, locus := range report.Loci {
+		report.checkLocus(locus, loci)
+	}
+	return report, nil
+}
+
+// checkChromosomeNames reports chromosome names proofs cannot match:
+// a mix of "chr"-prefixed and bare names, and names of neither form
+func (r *PreflightReport) checkChromosomeNames() {
+	var prefixed, bare int
+	var unknown []string
+	for _, chrom := range r.Chromosomes {
+		if strings.HasPrefix(strings.ToLower(chrom), "chr") {
+			prefixed++
+		} else {
+			bare++
+		}
+		if traits.ChromosomeCode(chrom) == 0 {
+			unknown = append(unknown, chrom)
+		}
+	}
+	if prefixed > 0 && bare > 0 {
+		r.addProblem(PreflightWarning, "chromosome names mix \"chr\"-prefixed and bare forms; rename them to one convention")
+	}
+	if len(unknown) > 0 {
+		if len(unknown) > 5 {
+			unknown = append(unknown[:5], "...")
+		}
+		r.addProblem(PreflightWarning, "chromosomes %s are not named 1-22, X, Y or MT (with or without \"chr\"), so no proof coordinate matches their records",
+			strings.Join(unknown, ", "))
+	}
+}
+
+// checkBuilds reports loci in another build than the genome, unless the
+// genome is lifted over to theirs, and a genome of unknown build. It
+// reports whether the builds mismatch.
+func (r *PreflightReport) checkBuilds(loci []traits.TraitVariant, liftoverBuild GenomeBuild, liftover bool) bool {
+	for _, locus := range loci {
+		if locus.Build == traits.BuildUnknown {
+			continue
+		}
+		if r.Build == traits.BuildUnknown {
+			r.addProblem(PreflightWarning, "the genome does not record its build; the proof reads %s coordinates, so add a ##reference=%s header line if the genome is in that build",
+				locus.Build, locus.Build)
+			return false
+		}
+		if r.Build != locus.Build && !(liftover && liftoverBuild == locus.Build) {
+			r.addProblem(PreflightError, "%v", &proofs.BuildMismatchError{Locus: locus.Trait, LocusBuild: locus.Build, GenomeBuild: r.Build})
+			return true
+		}
+	}
+	return false
+}
+
+// checkLocus reports a target locus the proof cannot read
+func (r *PreflightReport) checkLocus(locus LocusCoverage, loci proofs.ProofLoci) {
+	variant := locus.Variant
+	where := fmt.Sprintf("%s (%d:%d %s>%s)", variant.Trait, variant.Chromosome, variant.Position, variant.Ref, variant.Alt)
+	switch locus.Status {
+	case LocusAbsent:
+		if loci.AbsentIsReference {
+			r.addProblem(PreflightWarning, "%s has no record and is read as homozygous reference; make sure the VCF lists every variant called", where)
+		} else {
+			r.addProblem(PreflightError, "%s has no record; the proof needs a genotype call there, such as from a gVCF", where)
+		}
+	case LocusNoCall:
+		severity := PreflightError
+		if loci.NoCallsTolerated {
+			severity = PreflightWarning
+		}
+		r.addProblem(severity, "%s is not called (missing genotype); the proof cannot read it", where)
+	case LocusAlleleMismatch:
+		r.addProblem(PreflightError, "%s has a record with alleles %s; normalize the VCF (split multi-allelic sites, left-align indels) or check the genome build", where, locus.Found)
+	}
+}
@@ -0,0 +1,46 @@
+package zkgenomics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProofGenerator_Preflight(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##reference=GRCh37\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tNA12878\n" +
+		"11\t66328095\trs1815739\tC\tG\t60\tPASS\t.\tGT\t0/1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t./.\n"
+	vcfPath := filepath.Join(t.TempDir(), "sample.vcf")
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pg := NewProofGenerator()
+
+	report, err := pg.Preflight(ProofRequest{VCFPath: vcfPath})
+	if err != nil || !report.OK() || report.Build != BuildGRCh37 || report.Records != 2 {
+		t.Fatalf("Expected a clean GRCh37 genome of 2 records, got %+v: %v", report, err)
+	}
+
+	report, err = pg.Preflight(ProofRequest{ProofType: ACTN3ProofType, VCFPath: vcfPath})
+	if err != nil || report.OK() || len(report.Loci) != 1 || report.Loci[0].Status != LocusAlleleMismatch || report.Loci[0].Found != "C>G" {
+		t.Errorf("Expected the ACTN3 record to mismatch in its alleles, got %+v: %v", report, err)
+	}
+
+	report, err = pg.Preflight(ProofRequest{ProofType: ALDH2ProofType, VCFPath: vcfPath})
+	if err != nil || report.OK() || len(report.Loci) != 1 || report.Loci[0].Status != LocusNoCall {
+		t.Errorf("Expected the ALDH2 locus to be uncalled, got %+v: %v", report, err)
+	}
+
+	report, err = pg.Preflight(ProofRequest{VCFPath: vcfPath, Sample: "NA12891"})
+	if err != nil || report.OK() || !strings.Contains(report.Problems[0].Message, "NA12878") {
+		t.Errorf("Expected a missing sample listing the samples, got %+v: %v", report, err)
+	}
+
+	if _, err := pg.Preflight(ProofRequest{ProofType: "unknown", VCFPath: vcfPath}); err == nil {
+		t.Error("Expected an unsupported proof type to be refused")
+	}
+}
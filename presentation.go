@@ -0,0 +1,40 @@
+package zkgenomics
+
+import (
+	"crypto/ed25519"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// Presentation re-exports a holder-bound proof as shown to a verifier
+type Presentation = proofs.Presentation
+
+// Present signs a proof issued to the holder of key, for presentation now to
+// the verifier that issued challenge. It fails with ErrHolderMismatch if the
+// proof was issued to another key.
+func Present(proofData *ProofData, key ed25519.PrivateKey, challenge []byte) (*Presentation, error) {
+	return proofs.Present(proofData, key, challenge)
+}
+
+// VerifyPresentation verifies the proof in presentation like VerifyProofData,
+// and fails it unless it is signed by the holder the proof was issued to, in
+// answer to challenge and within proofs.MaxPresentationAge
+func (pg *ProofGenerator) VerifyPresentation(proofType ProofType, presentation *Presentation, challenge []byte) (*VerificationResult, error) {
+	return verifyPresentation(pg.VerifyProofData, proofType, presentation, challenge)
+}
+
+// VerifyPresentation verifies the proof in presentation like VerifyProofData,
+// and fails it unless it is signed by the holder the proof was issued to, in
+// answer to challenge and within proofs.MaxPresentationAge
+func (v *Verifier) VerifyPresentation(proofType ProofType, presentation *Presentation, challenge []byte) (*VerificationResult, error) {
+	return verifyPresentation(v.VerifyProofData, proofType, presentation, challenge)
+}
+
+// verifyPresentation checks the holder signature, challenge and date of
+// presentation, then verifies its proof with verify
+func verifyPresentation(verify func(ProofType, *ProofData) (*VerificationResult, error), proofType ProofType, presentation *Presentation, challenge []byte) (*VerificationResult, error) {
+	if err := proofs.CheckPresentation(presentation, challenge); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	return verify(proofType, presentation.ProofData)
+}
@@ -0,0 +1,49 @@
+package zkgenomics
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVerifier_VerifyPresentation(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t1/1\n"
+	holderKey, holderPrivate, _ := ed25519.GenerateKey(nil)
+
+	response, err := NewProver().Generate(context.Background(), ProofRequest{
+		ProofType: ACTN3ProofType,
+		VCF:       strings.NewReader(vcf),
+		HolderKey: holderKey,
+	})
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	challenge := []byte("verifier-challenge")
+	presentation, err := Present(response.ProofData, holderPrivate, challenge)
+	if err != nil {
+		t.Fatalf("Present should not return error: %v", err)
+	}
+
+	verifier := NewVerifier(map[ProofType][]byte{ACTN3ProofType: response.ProofData.VerifyingKey})
+	result, err := verifier.VerifyPresentation(ACTN3ProofType, presentation, challenge)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected the holder's presentation to verify, got %v: %v", result, err)
+	}
+
+	unsigned := *presentation
+	unsigned.Signature = nil
+	result, err = verifier.VerifyPresentation(ACTN3ProofType, &unsigned, challenge)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected an unsigned presentation to fail, got %v: %v", result, err)
+	}
+
+	result, err = verifier.VerifyPresentation(ACTN3ProofType, presentation, []byte("another-challenge"))
+	if err != nil || result.Result != ProofFail || !errors.Is(result.Error, ErrChallengeMismatch) {
+		t.Errorf("Expected a replayed presentation to fail with ErrChallengeMismatch, got %v: %v", result, err)
+	}
+}
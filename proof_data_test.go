@@ -2,6 +2,7 @@ package zkgenomics
 
 import (
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -125,7 +126,39 @@ func TestProofGenerator_VerifyAnyProofData_InvalidData(t *testing.T) {
 	if result.Result != ProofFail {
 		t.Errorf("Expected ProofFail for invalid data, got %s", result.Result.String())
 	}
+	var ambiguous *AmbiguousProofError
+	if !errors.As(result.Error, &ambiguous) || len(ambiguous.Failures) != len(pg.GetSupportedProofTypes()) {
+		t.Errorf("Expected AmbiguousProofError with a failure per supported type, got %v", result.Error)
+	}
 	if proofType != "" {
 		t.Errorf("Expected empty proof type for failed verification, got %s", proofType)
 	}
-}
\ No newline at end of file
+}
+
+func TestProofGenerator_VerifyAnyProofData_RecordedType(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/1\n"
+
+	pg := NewProofGenerator(WithInsecureBundledKeys())
+	proofData, err := pg.GenerateProofFromReader(ALDH2ProofType, strings.NewReader(vcf))
+	if err != nil {
+		t.Fatalf("GenerateProofFromReader should not return error: %v", err)
+	}
+
+	// ACTN3, ALDH2 and other genotype claims share a circuit, so only the
+	// recorded type tells them apart
+	proofType, result, err := pg.VerifyAnyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected the proof to verify, got %v: %v", result, err)
+	}
+	if proofType != ALDH2ProofType {
+		t.Errorf("Expected the recorded type %s, got %s", ALDH2ProofType, proofType)
+	}
+
+	proofData.ProofType = "unknown"
+	if _, _, err := pg.VerifyAnyProofData(proofData); err == nil {
+		t.Error("Expected an unsupported recorded type to be refused")
+	}
+}
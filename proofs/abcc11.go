@@ -0,0 +1,39 @@
+package proofs
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func (p *ABCC11Proof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	proofData, claim, err := generateGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("abcc11"), p.Traits.Claims("abcc11"), p.Progress, p.Logger)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ ABCC11 proof successfully generated for %s earwax type!", traits.EarwaxType(claim))
+
+	return proofData, nil
+}
+
+// Assign extracts the ABCC11 genotype and builds the circuit and its assignment
+func (p *ABCC11Proof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := assignGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("abcc11"), p.Traits.Claims("abcc11"), p.Progress, p.Logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewGenotypeClaimCircuit(p.Traits.Variant("abcc11"), p.Traits.Claims("abcc11")), assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *ABCC11Proof) Circuit() (frontend.Circuit, error) {
+	return NewGenotypeClaimCircuit(p.Traits.Variant("abcc11"), p.Traits.Claims("abcc11")), nil
+}
+
+func (p *ABCC11Proof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "ABCC11", verifyingKeyPath, proofPath)
+}
+
+func (p *ABCC11Proof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "ABCC11", proofData)
+}
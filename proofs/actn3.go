@@ -0,0 +1,39 @@
+package proofs
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func (p *ACTN3Proof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	proofData, claim, err := generateGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("actn3"), p.Traits.Claims("actn3"), p.Progress, p.Logger)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ ACTN3 proof successfully generated for %s status!", traits.ACTN3Genotype(claim))
+
+	return proofData, nil
+}
+
+// Assign extracts the ACTN3 genotype and builds the circuit and its assignment
+func (p *ACTN3Proof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := assignGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("actn3"), p.Traits.Claims("actn3"), p.Progress, p.Logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewGenotypeClaimCircuit(p.Traits.Variant("actn3"), p.Traits.Claims("actn3")), assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *ACTN3Proof) Circuit() (frontend.Circuit, error) {
+	return NewGenotypeClaimCircuit(p.Traits.Variant("actn3"), p.Traits.Claims("actn3")), nil
+}
+
+func (p *ACTN3Proof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "ACTN3", verifyingKeyPath, proofPath)
+}
+
+func (p *ACTN3Proof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "ACTN3", proofData)
+}
@@ -0,0 +1,247 @@
+package proofs
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Advisory flags circuit versions, or verifying keys, the maintainers
+// consider unsound. Proofs made with an affected version or key still verify
+// cryptographically but prove less than they claim, so verification reports
+// them as failed unless the advisory is explicitly overridden.
+type Advisory struct {
+	ID string `json:"id"`
+	// CircuitID names the affected circuit; empty for advisories that only
+	// list keys
+	CircuitID string `json:"circuit_id,omitempty"`
+	// Versions lists the affected circuit versions; empty means every version
+	Versions []int `json:"versions,omitempty"`
+	// VKFingerprints lists affected verifying keys by VKFingerprint, such as
+	// keys of a compromised setup
+	VKFingerprints []string `json:"vk_fingerprints,omitempty"`
+	Summary        string   `json:"summary"`
+	URL            string   `json:"url,omitempty"`
+}
+
+// Affects reports whether the advisory applies to the circuit version
+func (a Advisory) Affects(circuitID string, version int) bool {
+	if a.CircuitID == "" || a.CircuitID != circuitID {
+		return false
+	}
+	return len(a.Versions) == 0 || slices.Contains(a.Versions, version)
+}
+
+// AffectsKey reports whether the advisory lists the verifying key with the
+// given VKFingerprint
+func (a Advisory) AffectsKey(fingerprint string) bool {
+	return fingerprint != "" && slices.Contains(a.VKFingerprints, fingerprint)
+}
+
+// AdvisoryList is a published set of advisories
+type AdvisoryList struct {
+	Published  time.Time  `json:"published"`
+	Advisories []Advisory `json:"advisories"`
+}
+
+// SignedAdvisoryList is the distribution format for advisories published
+// outside a release: a JSON-encoded AdvisoryList signed with a maintainer's
+// ed25519 key
+type SignedAdvisoryList struct {
+	KeyID     string `json:"key_id"`
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+// AdvisoryError is reported when a proof was made with a circuit version or
+// verifying key flagged by an advisory
+type AdvisoryError struct {
+	CircuitID string
+	Version   int
+	// VKFingerprint is set when the advisory flags the verifying key
+	VKFingerprint string
+	Advisory      Advisory
+}
+
+func (e *AdvisoryError) Error() string {
+	flagged := fmt.Sprintf("circuit %s v%d", e.CircuitID, e.Version)
+	if e.VKFingerprint != "" {
+		flagged = fmt.Sprintf("verifying key %s", e.VKFingerprint)
+	}
+	msg := fmt.Sprintf("%s is flagged unsound by advisory %s: %s", flagged, e.Advisory.ID, e.Advisory.Summary)
+	if e.Advisory.URL != "" {
+		msg += fmt.Sprintf(" (see %s)", e.Advisory.URL)
+	}
+	return msg
+}
+
+// bundledAdvisoryData is shipped inside the binary and trusted like the code itself
+//
+//go:embed advisories.json
+var bundledAdvisoryData []byte
+
+// BundledAdvisories returns the advisories shipped with this release
+func BundledAdvisories() (*AdvisoryList, error) {
+	var list AdvisoryList
+	if err := json.Unmarshal(bundledAdvisoryData, &list); err != nil {
+		return nil, fmt.Errorf("decoding bundled advisories: %w", err)
+	}
+	return &list, nil
+}
+
+// SignAdvisories encodes and signs an advisory list for publication
+func SignAdvisories(list *AdvisoryList, keyID string, key ed25519.PrivateKey) (*SignedAdvisoryList, error) {
+	payload, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("encoding advisories: %w", err)
+	}
+	return &SignedAdvisoryList{
+		KeyID:     keyID,
+		Payload:   payload,
+		Signature: ed25519.Sign(key, payload),
+	}, nil
+}
+
+// ParseSignedAdvisories decodes a signed advisory list, accepting it only if
+// it is signed by one of the trusted keys
+func ParseSignedAdvisories(data []byte, trusted map[string]ed25519.PublicKey) (*AdvisoryList, error) {
+	var signed SignedAdvisoryList
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, fmt.Errorf("decoding signed advisories: %w", err)
+	}
+
+	key, ok := trusted[signed.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("advisories signed with untrusted key %q", signed.KeyID)
+	}
+	if !ed25519.Verify(key, signed.Payload, signed.Signature) {
+		return nil, fmt.Errorf("invalid signature on advisories from key %q", signed.KeyID)
+	}
+
+	var list AdvisoryList
+	if err := json.Unmarshal(signed.Payload, &list); err != nil {
+		return nil, fmt.Errorf("decoding advisories: %w", err)
+	}
+	return &list, nil
+}
+
+// advisoryFetchTimeout bounds how long FetchAdvisories waits for a response
+const advisoryFetchTimeout = 30 * time.Second
+
+// FetchAdvisories loads a signed advisory list from an http(s) URL or a local
+// path and checks its signature against the trusted keys
+func FetchAdvisories(source string, trusted map[string]ed25519.PublicKey) (*AdvisoryList, error) {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("reading advisories: %w", err)
+		}
+		return ParseSignedAdvisories(data, trusted)
+	}
+
+	client := &http.Client{Timeout: advisoryFetchTimeout}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, fmt.Errorf("fetching advisories: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching advisories: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading advisories: %w", err)
+	}
+	return ParseSignedAdvisories(data, trusted)
+}
+
+// LoadAdvisoryKeys reads trusted advisory signing keys, one
+// "<key-id> <base64 ed25519 public key>" pair per line. Blank lines and lines
+// starting with # are ignored.
+func LoadAdvisoryKeys(path string) (map[string]ed25519.PublicKey, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys := make(map[string]ed25519.PublicKey)
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected key id and public key", path, line)
+		}
+		key, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%s:%d: invalid ed25519 public key", path, line)
+		}
+		keys[fields[0]] = ed25519.PublicKey(key)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Merge returns the advisories of both lists, keeping the first of any
+// advisories that share an ID
+func (l *AdvisoryList) Merge(other *AdvisoryList) *AdvisoryList {
+	merged := &AdvisoryList{Published: l.Published}
+	if other != nil && other.Published.After(merged.Published) {
+		merged.Published = other.Published
+	}
+
+	seen := make(map[string]bool)
+	for _, list := range []*AdvisoryList{l, other} {
+		if list == nil {
+			continue
+		}
+		for _, advisory := range list.Advisories {
+			if seen[advisory.ID] {
+				continue
+			}
+			seen[advisory.ID] = true
+			merged.Advisories = append(merged.Advisories, advisory)
+		}
+	}
+	return merged
+}
+
+// Check returns an AdvisoryError for the first advisory affecting the circuit
+// version whose ID is not in ignore
+func (l *AdvisoryList) Check(circuitID string, version int, ignore []string) error {
+	for _, advisory := range l.Advisories {
+		if advisory.Affects(circuitID, version) && !slices.Contains(ignore, advisory.ID) {
+			return &AdvisoryError{CircuitID: circuitID, Version: version, Advisory: advisory}
+		}
+	}
+	return nil
+}
+
+// CheckKey returns an AdvisoryError for the first advisory listing the
+// verifying key with the given VKFingerprint whose ID is not in ignore
+func (l *AdvisoryList) CheckKey(fingerprint string, ignore []string) error {
+	for _, advisory := range l.Advisories {
+		if advisory.AffectsKey(fingerprint) && !slices.Contains(ignore, advisory.ID) {
+			return &AdvisoryError{VKFingerprint: fingerprint, Advisory: advisory}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package proofs
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseSignedAdvisories(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	trusted := map[string]ed25519.PublicKey{"maintainer": pub}
+
+	list := &AdvisoryList{Advisories: []Advisory{
+		{ID: "TEST-1", CircuitID: "mthfr", Versions: []int{1}, Summary: "test"},
+	}}
+	signed, err := SignAdvisories(list, "maintainer", priv)
+	if err != nil {
+		t.Fatalf("SignAdvisories should not return error: %v", err)
+	}
+	data, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatalf("encoding signed advisories: %v", err)
+	}
+
+	parsed, err := ParseSignedAdvisories(data, trusted)
+	if err != nil {
+		t.Fatalf("ParseSignedAdvisories should accept a trusted signature: %v", err)
+	}
+	if len(parsed.Advisories) != 1 || parsed.Advisories[0].ID != "TEST-1" {
+		t.Errorf("Expected advisory TEST-1, got %+v", parsed.Advisories)
+	}
+
+	if _, err := ParseSignedAdvisories(data, map[string]ed25519.PublicKey{}); err == nil {
+		t.Error("ParseSignedAdvisories should reject an untrusted key")
+	}
+
+	tampered := *signed
+	tampered.Payload = []byte(`{"advisories":[]}`)
+	data, err = json.Marshal(&tampered)
+	if err != nil {
+		t.Fatalf("encoding signed advisories: %v", err)
+	}
+	if _, err := ParseSignedAdvisories(data, trusted); err == nil {
+		t.Error("ParseSignedAdvisories should reject a tampered payload")
+	}
+}
+
+func TestBundledAdvisories(t *testing.T) {
+	list, err := BundledAdvisories()
+	if err != nil {
+		t.Fatalf("BundledAdvisories should not return error: %v", err)
+	}
+
+	err = list.Check("dynamic", 1, nil)
+	var advisoryErr *AdvisoryError
+	if !errors.As(err, &advisoryErr) {
+		t.Fatalf("Expected AdvisoryError for dynamic v1, got %v", err)
+	}
+
+	if err := list.Check("dynamic", 1, []string{advisoryErr.Advisory.ID}); err != nil {
+		t.Errorf("Ignored advisory should not be reported: %v", err)
+	}
+	if err := list.Check("blood_type", 1, nil); err != nil {
+		t.Errorf("Expected no advisory for blood_type v1, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package proofs
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs/gadgets"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// AggregateClaim is one genotype claim covered by an aggregate proof: the
+// first sample carries Genotype copies (0, 1 or 2) of Alt at the locus
+type AggregateClaim struct {
+	Chromosome int    `yaml:"chromosome,omitempty" json:"chromosome,omitempty"`
+	Position   uint64 `yaml:"position" json:"position"`
+	Ref        string `yaml:"ref" json:"ref"`
+	Alt        string `yaml:"alt" json:"alt"`
+	Genotype   int    `yaml:"genotype" json:"genotype"`
+}
+
+// AggregateCircuit proves several genotype claims in a single proof. Each
+// claim discloses its locus, allele hashes, constrained LocusHash and claimed
+// genotype, as the negative circuit does for one variant; the genotypes
+// themselves are private and range-checked.
+type AggregateCircuit struct {
+	Chromosomes      []frontend.Variable `gnark:",public"`
+	Positions        []frontend.Variable `gnark:",public"`
+	RefHashes        []frontend.Variable `gnark:",public"`
+	AltHashes        []frontend.Variable `gnark:",public"`
+	LocusHashes      []frontend.Variable `gnark:",public"`
+	ClaimedGenotypes []frontend.Variable `gnark:",public"`
+
+	Genotypes []frontend.Variable
+
+	variants []traits.TraitVariant
+}
+
+// NewAggregateCircuit allocates an aggregate circuit for n claims
+func NewAggregateCircuit(n int) *AggregateCircuit {
+	return &AggregateCircuit{
+		Chromosomes:      make([]frontend.Variable, n),
+		Positions:        make([]frontend.Variable, n),
+		RefHashes:        make([]frontend.Variable, n),
+		AltHashes:        make([]frontend.Variable, n),
+		LocusHashes:      make([]frontend.Variable, n),
+		ClaimedGenotypes: make([]frontend.Variable, n),
+		Genotypes:        make([]frontend.Variable, n),
+	}
+}
+
+func (c *AggregateCircuit) Define(api frontend.API) error {
+	n := len(c.Genotypes)
+	if len(c.Chromosomes) != n || len(c.Positions) != n || len(c.RefHashes) != n ||
+		len(c.AltHashes) != n || len(c.LocusHashes) != n || len(c.ClaimedGenotypes) != n {
+		return fmt.Errorf("aggregate circuit slices must have equal length")
+	}
+
+	for i, g := range c.Genotypes {
+		if err := assertLocusHash(api, c.LocusHashes[i], c.Chromosomes[i], c.Positions[i], c.RefHashes[i], c.AltHashes[i]); err != nil {
+			return err
+		}
+		gadgets.AssertIsGenotype(api, g)
+		api.AssertIsEqual(c.ClaimedGenotypes[i], g)
+	}
+
+	return nil
+}
+
+func (c *AggregateCircuit) assignCurve(curve ecc.ID) error {
+	for i, variant := range c.variants {
+		locusHash, err := variantLocusHash(curve, variant)
+		if err != nil {
+			return err
+		}
+		c.LocusHashes[i] = locusHash
+	}
+	return nil
+}
+
+// NewAggregateProof creates an AggregateProof covering claims
+func NewAggregateProof(claims []AggregateClaim) *AggregateProof {
+	return &AggregateProof{Claims: claims}
+}
+
+// Assign extracts the genotype of every claim and builds the circuit and its assignment
+func (p *AggregateProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewAggregateCircuit(len(p.Claims)), assignment, nil
+}
+
+func (p *AggregateProof) assign(vcfPath string) (*AggregateCircuit, error) {
+	if len(p.Claims) == 0 {
+		return nil, fmt.Errorf("aggregate proof lists no claims")
+	}
+
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	loci := make([]Locus, 0, len(p.Claims))
+	for _, claim := range p.Claims {
+		if claim.Chromosome > 0 {
+			loci = append(loci, Locus{Chromosome: strconv.Itoa(claim.Chromosome), Position: claim.Position})
+		}
+	}
+	if err := Prefetch(source, loci); err != nil {
+		return nil, err
+	}
+
+	loggerOrNop(p.Logger).Infof("checking %d claims...", len(p.Claims))
+	assignment := NewAggregateCircuit(len(p.Claims))
+	assignment.variants = make([]traits.TraitVariant, len(p.Claims))
+	for i, claim := range p.Claims {
+		if claim.Position == 0 || claim.Ref == "" || claim.Alt == "" {
+			return nil, fmt.Errorf("claim %d does not name a variant", i+1)
+		}
+		if claim.Genotype < 0 || claim.Genotype > 2 {
+			return nil, fmt.Errorf("claim %d: genotype %d is outside 0..2", i+1, claim.Genotype)
+		}
+
+		variant := traits.TraitVariant{
+			Trait:      fmt.Sprintf("claim %d", i+1),
+			Chromosome: claim.Chromosome,
+			Position:   int(claim.Position),
+			Ref:        claim.Ref,
+			Alt:        claim.Alt,
+		}
+		genotype, called, err := panelGenotype(source, variant)
+		if err != nil {
+			return nil, err
+		}
+		if !called {
+			return nil, fmt.Errorf("claim %d: %w: genotype at position %d is not called", i+1, ErrNoSampleData, claim.Position)
+		}
+		if genotype != claim.Genotype {
+			return nil, fmt.Errorf("claim %d: %s>%s at position %d has genotype %d, not %d",
+				i+1, claim.Ref, claim.Alt, claim.Position, genotype, claim.Genotype)
+		}
+
+		refHash, err := alleleHash(claim.Ref)
+		if err != nil {
+			return nil, err
+		}
+		altHash, err := alleleHash(claim.Alt)
+		if err != nil {
+			return nil, err
+		}
+		locusHash, err := variantLocusHash(ecc.BN254, variant)
+		if err != nil {
+			return nil, err
+		}
+		assignment.Chromosomes[i] = claim.Chromosome
+		assignment.Positions[i] = claim.Position
+		assignment.RefHashes[i] = refHash
+		assignment.AltHashes[i] = altHash
+		assignment.LocusHashes[i] = locusHash
+		assignment.variants[i] = variant
+		assignment.ClaimedGenotypes[i] = claim.Genotype
+		assignment.Genotypes[i] = genotype
+	}
+
+	return assignment, nil
+}
+
+func (p *AggregateProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewAggregateCircuit(len(p.Claims)), assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Aggregate proof successfully generated for %d claims!", len(p.Claims))
+
+	return proofData, nil
+}
+
+func (p *AggregateProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "aggregate", verifyingKeyPath, proofPath)
+}
+
+func (p *AggregateProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "aggregate", proofData)
+}
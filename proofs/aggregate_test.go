@@ -0,0 +1,120 @@
+package proofs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+const aggregateTestVCF = `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+2	136608646	rs4988235	G	A	60	PASS	.	GT	0/1
+15	28365618	rs12913832	A	G	60	PASS	.	GT	1/1
+17	41276044	.	ACT	A	60	PASS	.	GT	./.
+`
+
+var aggregateTestClaims = []AggregateClaim{
+	{Chromosome: 15, Position: 28365618, Ref: "A", Alt: "G", Genotype: 2},
+	{Chromosome: 2, Position: 136608646, Ref: "G", Alt: "A", Genotype: 1},
+	{Chromosome: 13, Position: 32914437, Ref: "GT", Alt: "G", Genotype: 0},
+}
+
+func TestAggregateCircuit(t *testing.T) {
+	tests := []struct {
+		name      string
+		claimed   []int
+		genotypes []int
+		solved    bool
+	}{
+		{"all claims hold", []int{2, 1, 0}, []int{2, 1, 0}, true},
+		{"one claim false", []int{2, 2, 0}, []int{2, 1, 0}, false},
+		{"genotype out of range", []int{2, 1, 3}, []int{2, 1, 3}, false},
+	}
+
+	for _, tc := range tests {
+		assignment := NewAggregateCircuit(len(tc.claimed))
+		for i := range tc.claimed {
+			locusHash, err := mimcValues(ecc.BN254, []*big.Int{big.NewInt(1), big.NewInt(int64(1000 + i)), big.NewInt(1), big.NewInt(2)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assignment.Chromosomes[i] = 1
+			assignment.Positions[i] = 1000 + i
+			assignment.RefHashes[i] = 1
+			assignment.AltHashes[i] = 2
+			assignment.LocusHashes[i] = locusHash
+			assignment.ClaimedGenotypes[i] = tc.claimed[i]
+			assignment.Genotypes[i] = tc.genotypes[i]
+		}
+		err := test.IsSolved(NewAggregateCircuit(len(tc.claimed)), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("%s: expected circuit to be solved: %v", tc.name, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("%s: expected circuit not to be solved", tc.name)
+		}
+	}
+}
+
+func TestAggregateProof_Assign(t *testing.T) {
+	vcfPath := writeTestVCF(t, aggregateTestVCF)
+
+	tests := []struct {
+		name   string
+		claims []AggregateClaim
+		valid  bool
+	}{
+		{"all claims hold", aggregateTestClaims, true},
+		{"wrong genotype", []AggregateClaim{{Chromosome: 15, Position: 28365618, Ref: "A", Alt: "G", Genotype: 1}}, false},
+		{"no call", []AggregateClaim{{Chromosome: 17, Position: 41276044, Ref: "ACT", Alt: "A", Genotype: 0}}, false},
+		{"no claims", nil, false},
+	}
+
+	for _, tc := range tests {
+		_, err := NewAggregateProof(tc.claims).assign(vcfPath)
+		if tc.valid && err != nil {
+			t.Errorf("%s: expected claims to be provable: %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+func TestAggregateProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, aggregateTestVCF)
+
+	proof := NewAggregateProof(aggregateTestClaims)
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+
+	values, err := PublicValues(proofData)
+	if err != nil {
+		t.Fatalf("PublicValues should not return error: %v", err)
+	}
+	if len(values) != 6*len(aggregateTestClaims) {
+		t.Fatalf("Expected %d public values, got %d", 6*len(aggregateTestClaims), len(values))
+	}
+	if err := CheckPublicValues(proofData, []PublicValue{{Name: "ClaimedGenotypes_1", Value: "1"}}); err != nil {
+		t.Errorf("Expected second claim to disclose genotype 1: %v", err)
+	}
+
+	// Positions_1 is the fifth public input of three claims
+	result, err = proof.VerifyProofData(withPublicInput(t, proofData, 4, 136608647))
+	if err == nil && result.Result == ProofSuccess {
+		t.Error("Expected a proof with a changed position to fail verification")
+	}
+}
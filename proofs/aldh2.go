@@ -0,0 +1,42 @@
+package proofs
+
+import (
+	"github.com/consensys/gnark/frontend"
+)
+
+func (p *ALDH2Proof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	proofData, claim, err := generateGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("aldh2"), p.Traits.Claims("aldh2"), p.Progress, p.Logger)
+	if err != nil {
+		return proofData, err
+	}
+
+	status := "not deficient"
+	if claim == 1 {
+		status = "deficient"
+	}
+	loggerOrNop(p.Logger).Infof("✅ ALDH2 proof successfully generated: %s", status)
+
+	return proofData, nil
+}
+
+// Assign extracts the ALDH2 genotype and builds the circuit and its assignment
+func (p *ALDH2Proof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := assignGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("aldh2"), p.Traits.Claims("aldh2"), p.Progress, p.Logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewGenotypeClaimCircuit(p.Traits.Variant("aldh2"), p.Traits.Claims("aldh2")), assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *ALDH2Proof) Circuit() (frontend.Circuit, error) {
+	return NewGenotypeClaimCircuit(p.Traits.Variant("aldh2"), p.Traits.Claims("aldh2")), nil
+}
+
+func (p *ALDH2Proof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "ALDH2", verifyingKeyPath, proofPath)
+}
+
+func (p *ALDH2Proof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "ALDH2", proofData)
+}
@@ -0,0 +1,165 @@
+package proofs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ArchiveFormatVersion is the archival bundle format written by this release
+const ArchiveFormatVersion = 1
+
+// archiveInstructions describes how to verify an archive without this
+// software; it is formatted with the curve name and field element size
+const archiveInstructions = `This archive holds a Groth16 zero-knowledge proof over the %[1]s curve produced with gnark; the versions used are listed under "software". To verify it independently:
+1. Base64-decode proof.proof, proof.verifying_key and proof.public_witness.
+2. proof.proof and proof.verifying_key are gnark's binary Groth16 encodings (WriteTo, compressed %[1]s points).
+3. proof.public_witness is a big-endian uint32 count of public inputs, a uint32 count of secret inputs (zero), a uint32 vector length, then one %[2]d-byte big-endian field element per public input, in the order of public_values.
+4. Check the Groth16 pairing equation for the verifying key, proof and public inputs.
+5. circuit_hash is the SHA-256 of the gnark-serialized constraint system of circuit_id at circuit_version. Recompiling that circuit with the pinned software reproduces it, tying the verifying key to the described statement.`
+
+// plonkArchiveInstructions describes how to verify an archived PLONK proof
+// without this software, formatted as archiveInstructions
+const plonkArchiveInstructions = `This archive holds a PLONK zero-knowledge proof over the %[1]s curve with KZG commitments, produced with gnark; the versions used are listed under "software". To verify it independently:
+1. Base64-decode proof.proof, proof.verifying_key and proof.public_witness.
+2. proof.proof and proof.verifying_key are gnark's binary PLONK encodings (WriteTo, compressed %[1]s points); the verifying key holds the KZG verifying key of the SRS it was set up with.
+3. proof.public_witness is a big-endian uint32 count of public inputs, a uint32 count of secret inputs (zero), a uint32 vector length, then one %[2]d-byte big-endian field element per public input, in the order of public_values.
+4. Run the PLONK verifier for the verifying key, proof and public inputs.
+5. circuit_hash is the SHA-256 of the gnark-serialized sparse constraint system (PLONK arithmetization) of circuit_id at circuit_version. Recompiling that circuit with the pinned software reproduces it, tying the verifying key to the described statement.`
+
+// archivedModules are the modules whose versions determine how an archived
+// proof is encoded and verified
+var archivedModules = []string{
+	"github.com/zkgenomics/zkgenomics-proofs",
+	"github.com/consensys/gnark",
+	"github.com/consensys/gnark-crypto",
+}
+
+// ArchiveBundle is a self-describing proof for long-term retention. Besides
+// the proof and its verifying key it records the exact circuit, the software
+// versions that produced it and plain-language verification instructions, so
+// it can be verified offline long after this release is gone.
+type ArchiveBundle struct {
+	FormatVersion  int               `json:"format_version"`
+	Instructions   string            `json:"instructions"`
+	CreatedAt      time.Time         `json:"created_at"`
+	Software       map[string]string `json:"software"`
+	ProofType      string            `json:"proof_type"`
+	CircuitID      string            `json:"circuit_id"`
+	CircuitVersion int               `json:"circuit_version"`
+	CircuitHash    string            `json:"circuit_hash"`
+	PublicValues   []PublicValue     `json:"public_values"`
+	Proof          *ProofData        `json:"proof"`
+}
+
+// NewArchiveBundle wraps proofData for archival. The proof must record the
+// circuit that produced it.
+func NewArchiveBundle(proofType string, proofData *ProofData) (*ArchiveBundle, error) {
+	if proofData.CircuitID == "" || proofData.CircuitHash == "" {
+		return nil, fmt.Errorf("proof does not record the circuit that produced it")
+	}
+
+	values, err := PublicValues(proofData)
+	if err != nil {
+		return nil, err
+	}
+
+	curve, err := CurveNamed(proofData.Curve)
+	if err != nil {
+		return nil, err
+	}
+	instructions := archiveInstructions
+	if proofData.Backend == BackendPLONK {
+		instructions = plonkArchiveInstructions
+	}
+	return &ArchiveBundle{
+		FormatVersion:  ArchiveFormatVersion,
+		Instructions:   fmt.Sprintf(instructions, strings.ToUpper(curve.String()), (curve.ScalarField().BitLen()+7)/8),
+		CreatedAt:      time.Now().UTC(),
+		Software:       softwareVersions(),
+		ProofType:      proofType,
+		CircuitID:      proofData.CircuitID,
+		CircuitVersion: proofData.CircuitVersion,
+		CircuitHash:    proofData.CircuitHash,
+		PublicValues:   values,
+		Proof:          proofData,
+	}, nil
+}
+
+// softwareVersions returns the Go toolchain and archivedModules versions of
+// the running binary
+func softwareVersions() map[string]string {
+	versions := map[string]string{"go": runtime.Version()}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return versions
+	}
+	for _, module := range append([]*debug.Module{&info.Main}, info.Deps...) {
+		if slices.Contains(archivedModules, module.Path) {
+			versions[module.Path] = module.Version
+		}
+	}
+	return versions
+}
+
+// ReadArchiveBundle loads a JSON-encoded archive bundle
+func ReadArchiveBundle(path string) (*ArchiveBundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+
+	var bundle ArchiveBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("decoding archive: %w", err)
+	}
+	return &bundle, nil
+}
+
+// WriteArchiveBundle writes bundle to path as indented JSON
+func WriteArchiveBundle(path string, bundle *ArchiveBundle) error {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding archive: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// VerifyArchiveBundle verifies an archived proof using only the bundle
+// contents. Besides the proof check, the recorded circuit and public values
+// must agree with the embedded proof.
+func VerifyArchiveBundle(bundle *ArchiveBundle) (*VerificationResult, error) {
+	if bundle.FormatVersion < 1 || bundle.FormatVersion > ArchiveFormatVersion {
+		return nil, &UnsupportedVersionError{Artifact: "archive", Version: bundle.FormatVersion, Supported: ArchiveFormatVersion}
+	}
+	if bundle.Proof == nil {
+		return &VerificationResult{Result: ProofFail, Error: fmt.Errorf("archive contains no proof")}, nil
+	}
+
+	proof := bundle.Proof
+	if proof.CircuitID != bundle.CircuitID || proof.CircuitVersion != bundle.CircuitVersion || proof.CircuitHash != bundle.CircuitHash {
+		return &VerificationResult{
+			Result: ProofFail,
+			Error:  fmt.Errorf("archived circuit does not match the embedded proof"),
+		}, nil
+	}
+
+	values, err := PublicValues(proof)
+	if err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	if !slices.Equal(values, bundle.PublicValues) {
+		return &VerificationResult{
+			Result: ProofFail,
+			Error:  fmt.Errorf("archived public values do not match the embedded proof"),
+		}, nil
+	}
+
+	return verifySNARK(nil, bundle.ProofType, proof)
+}
@@ -0,0 +1,64 @@
+package proofs
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveBundle_RoundTrip(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	11854476	rs1801131	T	G	60	PASS	.	GT	0/1
+1	11856378	rs1801133	G	A	60	PASS	.	GT	0/0
+`)
+
+	proof := &MTHFRProof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	again, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if proofData.CircuitHash == "" || proofData.CircuitHash != again.CircuitHash {
+		t.Fatalf("Expected a stable circuit hash, got %q and %q", proofData.CircuitHash, again.CircuitHash)
+	}
+
+	bundle, err := NewArchiveBundle("mthfr", proofData)
+	if err != nil {
+		t.Fatalf("NewArchiveBundle should not return error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "archive.json")
+	if err := WriteArchiveBundle(path, bundle); err != nil {
+		t.Fatalf("WriteArchiveBundle should not return error: %v", err)
+	}
+	bundle, err = ReadArchiveBundle(path)
+	if err != nil {
+		t.Fatalf("ReadArchiveBundle should not return error: %v", err)
+	}
+
+	result, err := VerifyArchiveBundle(bundle)
+	if err != nil {
+		t.Fatalf("VerifyArchiveBundle should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+
+	// The recorded public values must describe the embedded proof
+	bundle.PublicValues[0].Value = "1"
+	result, err = VerifyArchiveBundle(bundle)
+	if err != nil {
+		t.Fatalf("VerifyArchiveBundle should not return error: %v", err)
+	}
+	if result.Result != ProofFail {
+		t.Errorf("Expected ProofFail for altered public values, got %s", result.Result.String())
+	}
+
+	bundle.FormatVersion = ArchiveFormatVersion + 1
+	if _, err := VerifyArchiveBundle(bundle); err == nil {
+		t.Error("VerifyArchiveBundle should reject a newer format version")
+	}
+}
@@ -0,0 +1,68 @@
+package proofs
+
+import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ArmorBlockType is the PEM block type of armored proofs
+const ArmorBlockType = "ZKGENOMICS PROOF"
+
+// EncodeArmor encodes proofData as a single text block that survives email and
+// copy-paste:
+//
+//	-----BEGIN ZKGENOMICS PROOF-----
+//	Proof-Type: actn3
+//	...
+//	-----END ZKGENOMICS PROOF-----
+//
+// The body is the base64 CBOR encoding of the proof, bundling the proof,
+// public witness and metadata. The headers repeat some of the metadata for
+// readers and are not trusted when decoding.
+func EncodeArmor(proofData *ProofData) ([]byte, error) {
+	encoded, err := proofData.MarshalCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("encoding proof: %w", err)
+	}
+
+	headers := make(map[string]string)
+	if proofData.ProofType != "" {
+		headers["Proof-Type"] = proofData.ProofType
+	}
+	if proofData.CircuitID != "" {
+		headers["Circuit"] = proofData.CircuitID + " v" + strconv.Itoa(proofData.CircuitVersion)
+	}
+	if !proofData.CreatedAt.IsZero() {
+		headers["Created"] = proofData.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: ArmorBlockType, Headers: headers, Bytes: encoded}), nil
+}
+
+// DecodeArmor decodes the first armored proof in data, ignoring any text
+// around it
+func DecodeArmor(data []byte) (*ProofData, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no %s block found", ArmorBlockType)
+		}
+		if block.Type != ArmorBlockType {
+			continue
+		}
+
+		var proofData ProofData
+		if err := decodeCBOR(block.Bytes, &proofData, "armored proof"); err != nil {
+			return nil, err
+		}
+		return &proofData, nil
+	}
+}
+
+// isArmored reports whether data holds an armored proof
+func isArmored(data []byte) bool {
+	return bytes.Contains(data, []byte("-----BEGIN "+ArmorBlockType+"-----"))
+}
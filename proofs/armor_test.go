@@ -0,0 +1,48 @@
+package proofs
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeArmor(t *testing.T) {
+	proofData := &ProofData{
+		Proof:          bytes.Repeat([]byte{0xab}, 128),
+		VerifyingKey:   bytes.Repeat([]byte{0xcd}, 256),
+		PublicWitness:  bytes.Repeat([]byte{0xef}, 64),
+		ProofType:      "actn3",
+		CircuitID:      "genotype_claim",
+		CircuitVersion: 2,
+		Curve:          proofCurve,
+		CreatedAt:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	armored, err := EncodeArmor(proofData)
+	if err != nil {
+		t.Fatalf("EncodeArmor should not return error: %v", err)
+	}
+	text := string(armored)
+	if !strings.HasPrefix(text, "-----BEGIN ZKGENOMICS PROOF-----\n") || !strings.HasSuffix(text, "-----END ZKGENOMICS PROOF-----\n") {
+		t.Errorf("Expected a ZKGENOMICS PROOF block, got:\n%s", text)
+	}
+	if !strings.Contains(text, "Proof-Type: actn3\n") || !strings.Contains(text, "Circuit: genotype_claim v2\n") {
+		t.Errorf("Expected metadata headers, got:\n%s", text)
+	}
+
+	// Armored proofs are found in surrounding text, such as an email
+	message := "Here is my proof:\n\n" + text + "\nRegards\n"
+	decoded, err := DecodeProofData(strings.NewReader(message))
+	if err != nil {
+		t.Fatalf("DecodeProofData should accept armor: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, proofData) {
+		t.Errorf("Expected armor to round-trip, got %+v", decoded)
+	}
+
+	if _, err := DecodeArmor([]byte("-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n")); err == nil {
+		t.Error("Expected a block of another type to be refused")
+	}
+}
@@ -0,0 +1,389 @@
+package proofs
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	kzg_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	kzg_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/kzg"
+	"github.com/consensys/gnark-crypto/kzg"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	gnarkio "github.com/consensys/gnark/io"
+)
+
+// Backend names of the proving systems proofs are made with, as recorded in
+// ProofData.Backend
+const (
+	BackendGroth16 = "groth16"
+	BackendPLONK   = "plonk"
+)
+
+// ProvingKey is the proving key of a circuit under any backend
+type ProvingKey interface {
+	io.WriterTo
+	io.ReaderFrom
+	gnarkio.WriterRawTo
+	gnarkio.UnsafeReaderFrom
+}
+
+// VerifyingKey is the verifying key of a circuit under any backend
+type VerifyingKey interface {
+	io.WriterTo
+	io.ReaderFrom
+	gnarkio.WriterRawTo
+	gnarkio.UnsafeReaderFrom
+	// NbPublicWitness returns the number of public inputs of the circuit
+	NbPublicWitness() int
+}
+
+// Backend is a proving system over a curve. Every proof is compiled, set up,
+// proven and verified through one, so proof types are independent of the
+// proving system and curve.
+type Backend interface {
+	// Name identifies the backend in proofs and keys, such as "groth16"
+	Name() string
+	// Curve is the curve the backend proves over
+	Curve() ecc.ID
+	// Compile compiles circuit into the constraint system the backend proves
+	Compile(circuit frontend.Circuit) (constraint.ConstraintSystem, error)
+	// Setup returns fresh keys for the compiled circuit cs
+	Setup(cs constraint.ConstraintSystem) (ProvingKey, VerifyingKey, error)
+	// Prove proves the full witness of cs, returning the serialized proof
+	Prove(cs constraint.ConstraintSystem, pk ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) ([]byte, error)
+	// Verify checks a serialized proof against a serialized verifying key
+	// and public witness
+	Verify(proof []byte, vk []byte, publicWitness []byte) error
+	NewProvingKey() ProvingKey
+	NewVerifyingKey() VerifyingKey
+}
+
+// Groth16 is the Groth16 backend over BN254: the smallest proofs and fastest
+// verification, at the cost of a trusted setup per circuit. It is the default.
+var Groth16 = NewGroth16(ecc.BN254)
+
+// PLONK is the PLONK backend with a locally generated SRS, as insecure as a
+// local Groth16 setup; use a PlonkBackend with the SRS of a ceremony for
+// proofs shared with others
+var PLONK Backend = &PlonkBackend{}
+
+// NewGroth16 returns the Groth16 backend over curve
+func NewGroth16(curve ecc.ID) Backend {
+	return groth16Backend{curve: curve}
+}
+
+// BackendNamed returns the backend recorded as name in a proof or key, over
+// the curve recorded as curveName. Proofs and keys predating backends record
+// none and are Groth16; those predating curves record none and are over
+// BN254.
+func BackendNamed(name string, curveName string) (Backend, error) {
+	curve, err := CurveNamed(curveName)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case "", BackendGroth16:
+		if curve == ecc.BN254 {
+			return Groth16, nil
+		}
+		return NewGroth16(curve), nil
+	case BackendPLONK:
+		if curve == ecc.BN254 {
+			return PLONK, nil
+		}
+		return &PlonkBackend{CurveID: curve}, nil
+	default:
+		return nil, fmt.Errorf("unsupported proving backend %q", name)
+	}
+}
+
+// backendOrDefault returns b, or Groth16 if b is nil
+func backendOrDefault(b Backend) Backend {
+	if b == nil {
+		return Groth16
+	}
+	return b
+}
+
+type groth16Backend struct {
+	curve ecc.ID
+}
+
+func (groth16Backend) Name() string { return BackendGroth16 }
+
+func (b groth16Backend) Curve() ecc.ID { return b.curve }
+
+func (b groth16Backend) Compile(circuit frontend.Circuit) (constraint.ConstraintSystem, error) {
+	return frontend.Compile(b.curve.ScalarField(), r1cs.NewBuilder, circuit)
+}
+
+func (groth16Backend) Setup(cs constraint.ConstraintSystem) (ProvingKey, VerifyingKey, error) {
+	return groth16.Setup(cs)
+}
+
+func (groth16Backend) Prove(cs constraint.ConstraintSystem, pk ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) ([]byte, error) {
+	pk, err := LoadedProvingKey(pk)
+	if err != nil {
+		return nil, err
+	}
+	groth16PK, ok := pk.(groth16.ProvingKey)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a Groth16 proving key", pk)
+	}
+	proof, err := groth16.Prove(cs, groth16PK, fullWitness, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("serializing proof: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (b groth16Backend) Verify(proofBytes []byte, vkBytes []byte, publicWitnessBytes []byte) error {
+	vk := groth16.NewVerifyingKey(b.curve)
+	if _, err := vk.ReadFrom(bytes.NewReader(vkBytes)); err != nil {
+		return fmt.Errorf("failed to deserialize verifying key: %w", err)
+	}
+	proof := groth16.NewProof(b.curve)
+	if _, err := proof.ReadFrom(bytes.NewReader(proofBytes)); err != nil {
+		return fmt.Errorf("failed to deserialize proof: %w", err)
+	}
+	publicWitness, err := decodePublicWitness(b.curve, publicWitnessBytes)
+	if err != nil {
+		return err
+	}
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		return fmt.Errorf("proof verification failed: %w", err)
+	}
+	return nil
+}
+
+func (b groth16Backend) NewProvingKey() ProvingKey { return groth16.NewProvingKey(b.curve) }
+
+func (b groth16Backend) NewVerifyingKey() VerifyingKey { return groth16.NewVerifyingKey(b.curve) }
+
+// PlonkBackend is the PLONK backend: larger proofs than Groth16, but its
+// setup is universal, so one SRS from a single ceremony serves every circuit
+type PlonkBackend struct {
+	// CurveID is the curve proofs are made over, BN254 if unset
+	CurveID ecc.ID
+	// SRS, if set, is the canonical KZG SRS of a universal setup ceremony
+	// over CurveID, large enough for every circuit proven. If nil, each setup
+	// generates its own SRS from local randomness, which is insecure for
+	// shared proofs.
+	SRS kzg.SRS
+}
+
+func (b *PlonkBackend) Name() string { return BackendPLONK }
+
+func (b *PlonkBackend) Curve() ecc.ID { return curveOrDefault(b.CurveID) }
+
+func (b *PlonkBackend) Compile(circuit frontend.Circuit) (constraint.ConstraintSystem, error) {
+	return frontend.Compile(b.Curve().ScalarField(), scs.NewBuilder, circuit)
+}
+
+func (b *PlonkBackend) Setup(cs constraint.ConstraintSystem) (ProvingKey, VerifyingKey, error) {
+	canonical, lagrange, err := b.srs(cs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plonk.Setup(cs, canonical, lagrange)
+}
+
+// srs returns the canonical and Lagrange SRS sized for cs, cut from b.SRS or
+// generated if it is nil
+func (b *PlonkBackend) srs(cs constraint.ConstraintSystem) (kzg.SRS, kzg.SRS, error) {
+	size := ecc.NextPowerOfTwo(uint64(cs.GetNbConstraints() + cs.GetNbPublicVariables()))
+	canonical := b.SRS
+	if canonical == nil {
+		tau, err := rand.Int(rand.Reader, b.Curve().ScalarField())
+		if err != nil {
+			return nil, nil, err
+		}
+		if canonical, err = newKZGSRS(b.Curve(), size+3, tau); err != nil {
+			return nil, nil, fmt.Errorf("generating SRS: %w", err)
+		}
+	}
+
+	switch srs := canonical.(type) {
+	case *kzg_bn254.SRS:
+		if err := checkSRSSize(len(srs.Pk.G1), size); err != nil {
+			return nil, nil, err
+		}
+		lagrangeG1, err := kzg_bn254.ToLagrangeG1(srs.Pk.G1[:size])
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting SRS to Lagrange form: %w", err)
+		}
+		return &kzg_bn254.SRS{Pk: kzg_bn254.ProvingKey{G1: srs.Pk.G1[:size+3]}, Vk: srs.Vk},
+			&kzg_bn254.SRS{Pk: kzg_bn254.ProvingKey{G1: lagrangeG1}, Vk: srs.Vk}, nil
+	case *kzg_bls12381.SRS:
+		if err := checkSRSSize(len(srs.Pk.G1), size); err != nil {
+			return nil, nil, err
+		}
+		lagrangeG1, err := kzg_bls12381.ToLagrangeG1(srs.Pk.G1[:size])
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting SRS to Lagrange form: %w", err)
+		}
+		return &kzg_bls12381.SRS{Pk: kzg_bls12381.ProvingKey{G1: srs.Pk.G1[:size+3]}, Vk: srs.Vk},
+			&kzg_bls12381.SRS{Pk: kzg_bls12381.ProvingKey{G1: lagrangeG1}, Vk: srs.Vk}, nil
+	case *kzg_bls12377.SRS:
+		if err := checkSRSSize(len(srs.Pk.G1), size); err != nil {
+			return nil, nil, err
+		}
+		lagrangeG1, err := kzg_bls12377.ToLagrangeG1(srs.Pk.G1[:size])
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting SRS to Lagrange form: %w", err)
+		}
+		return &kzg_bls12377.SRS{Pk: kzg_bls12377.ProvingKey{G1: srs.Pk.G1[:size+3]}, Vk: srs.Vk},
+			&kzg_bls12377.SRS{Pk: kzg_bls12377.ProvingKey{G1: lagrangeG1}, Vk: srs.Vk}, nil
+	case *kzg_bw6761.SRS:
+		if err := checkSRSSize(len(srs.Pk.G1), size); err != nil {
+			return nil, nil, err
+		}
+		lagrangeG1, err := kzg_bw6761.ToLagrangeG1(srs.Pk.G1[:size])
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting SRS to Lagrange form: %w", err)
+		}
+		return &kzg_bw6761.SRS{Pk: kzg_bw6761.ProvingKey{G1: srs.Pk.G1[:size+3]}, Vk: srs.Vk},
+			&kzg_bw6761.SRS{Pk: kzg_bw6761.ProvingKey{G1: lagrangeG1}, Vk: srs.Vk}, nil
+	default:
+		return nil, nil, fmt.Errorf("%T is not an SRS over %s", canonical, b.Curve())
+	}
+}
+
+// checkSRSSize checks that an SRS of n points serves a circuit whose domain
+// has size points
+func checkSRSSize(n int, size uint64) error {
+	if uint64(n) < size+3 {
+		return fmt.Errorf("SRS holds %d points, the circuit needs %d", n, size+3)
+	}
+	return nil
+}
+
+// newKZGSRS generates a KZG SRS of size points over curve from the secret tau
+func newKZGSRS(curve ecc.ID, size uint64, tau *big.Int) (kzg.SRS, error) {
+	switch curve {
+	case ecc.BN254:
+		return kzg_bn254.NewSRS(size, tau)
+	case ecc.BLS12_381:
+		return kzg_bls12381.NewSRS(size, tau)
+	case ecc.BLS12_377:
+		return kzg_bls12377.NewSRS(size, tau)
+	case ecc.BW6_761:
+		return kzg_bw6761.NewSRS(size, tau)
+	default:
+		return nil, fmt.Errorf("no KZG SRS over curve %s", curve)
+	}
+}
+
+func (b *PlonkBackend) Prove(cs constraint.ConstraintSystem, pk ProvingKey, fullWitness witness.Witness, opts ...backend.ProverOption) ([]byte, error) {
+	pk, err := LoadedProvingKey(pk)
+	if err != nil {
+		return nil, err
+	}
+	plonkPK, ok := pk.(plonk.ProvingKey)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a PLONK proving key", pk)
+	}
+	proof, err := plonk.Prove(cs, plonkPK, fullWitness, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("serializing proof: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *PlonkBackend) Verify(proofBytes []byte, vkBytes []byte, publicWitnessBytes []byte) error {
+	if err := checkPlonkKeyCurve(b.Curve(), vkBytes); err != nil {
+		return fmt.Errorf("failed to deserialize verifying key: %w", err)
+	}
+	vk := plonk.NewVerifyingKey(b.Curve())
+	if _, err := vk.ReadFrom(bytes.NewReader(vkBytes)); err != nil {
+		return fmt.Errorf("failed to deserialize verifying key: %w", err)
+	}
+	proof := plonk.NewProof(b.Curve())
+	if _, err := proof.ReadFrom(bytes.NewReader(proofBytes)); err != nil {
+		return fmt.Errorf("failed to deserialize proof: %w", err)
+	}
+	publicWitness, err := decodePublicWitness(b.Curve(), publicWitnessBytes)
+	if err != nil {
+		return err
+	}
+	if err := plonk.Verify(proof, vk, publicWitness); err != nil {
+		return fmt.Errorf("proof verification failed: %w", err)
+	}
+	return nil
+}
+
+func (b *PlonkBackend) NewProvingKey() ProvingKey { return plonk.NewProvingKey(b.Curve()) }
+
+func (b *PlonkBackend) NewVerifyingKey() VerifyingKey { return plonk.NewVerifyingKey(b.Curve()) }
+
+// checkPlonkKeyCurve checks that vk begins as a PLONK verifying key over
+// curve does, with its domain size and the inverse of the size in the scalar
+// field. Decoding a key of another curve can read a huge length and exhaust
+// memory, so keys are checked before they are decoded.
+func checkPlonkKeyCurve(curve ecc.ID, vk []byte) error {
+	modulus := curve.ScalarField()
+	frBytes := (modulus.BitLen() + 7) / 8
+	if len(vk) < 8+frBytes {
+		return fmt.Errorf("verifying key is truncated")
+	}
+	size := new(big.Int).SetUint64(binary.BigEndian.Uint64(vk[:8]))
+	sizeInv := new(big.Int).SetBytes(vk[8 : 8+frBytes])
+	if size.Mul(size, sizeInv).Mod(size, modulus).Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("not a PLONK verifying key over %s", curve)
+	}
+	return nil
+}
+
+// decodePublicWitness decodes a serialized public witness over curve, as in
+// ProofData.PublicWitness
+func decodePublicWitness(curve ecc.ID, data []byte) (witness.Witness, error) {
+	publicWitness, err := witness.New(curve.ScalarField())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create witness: %w", err)
+	}
+	if err := publicWitness.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("failed to deserialize public witness: %w", err)
+	}
+	return publicWitness, nil
+}
+
+// nbPublicInputs returns the number of public inputs of the circuit cs
+// compiled for backend. Groth16 circuits count the constant wire as a public
+// variable, but it is not an input.
+func nbPublicInputs(backend Backend, cs constraint.ConstraintSystem) int {
+	if backend.Name() == BackendGroth16 {
+		return cs.GetNbPublicVariables() - 1
+	}
+	return cs.GetNbPublicVariables()
+}
+
+// ReadKZGSRS reads the canonical KZG SRS over curve of a universal setup
+// ceremony, in gnark-crypto's encoding, for a PlonkBackend
+func ReadKZGSRS(curve ecc.ID, path string) (kzg.SRS, error) {
+	srs := kzg.NewSRS(curve)
+	if err := readCeremonyFile(path, srs); err != nil {
+		return nil, err
+	}
+	return srs, nil
+}
@@ -0,0 +1,74 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestPLONKBackend_ProvesAndVerifies(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	proof := &ALDH2Proof{}
+	keys := &FileKeyStore{Dir: t.TempDir()}
+
+	circuit, err := ProofCircuit(proof, "")
+	if err != nil {
+		t.Fatalf("ProofCircuit should not return error: %v", err)
+	}
+	key, err := SetupKeys(circuit, PLONK, keys, nil)
+	if err != nil {
+		t.Fatalf("SetupKeys should not return error: %v", err)
+	}
+	groth16Key, err := SetupKeys(circuit, nil, keys, nil)
+	if err != nil {
+		t.Fatalf("SetupKeys should not return error: %v", err)
+	}
+	if key.Backend != BackendPLONK || key.String() == groth16Key.String() {
+		t.Errorf("Expected PLONK keys stored apart from Groth16 keys, got %s and %s", key, groth16Key)
+	}
+	_, vk, err := keys.LoadKeys(key)
+	if err != nil {
+		t.Fatalf("LoadKeys should not return error after setup: %v", err)
+	}
+	var stored bytes.Buffer
+	if _, err := vk.WriteTo(&stored); err != nil {
+		t.Fatalf("Failed to serialize stored verifying key: %v", err)
+	}
+
+	proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Keys: keys, Backend: PLONK})
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+	if proofData.Backend != BackendPLONK {
+		t.Errorf("Expected the proof to record the plonk backend, got %q", proofData.Backend)
+	}
+	if !bytes.Equal(proofData.VerifyingKey, stored.Bytes()) {
+		t.Error("PLONK proof should carry the stored verifying key")
+	}
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("PLONK proof should verify, got %v, %v", result, err)
+	}
+	if _, err := VKFingerprint(proofData.VerifyingKey); err != nil {
+		t.Errorf("VKFingerprint should accept a PLONK verifying key: %v", err)
+	}
+
+	// A PLONK proof does not verify as a Groth16 proof
+	relabeled := *proofData
+	relabeled.Backend = ""
+	if result, _ := proof.VerifyProofData(&relabeled); result.Result == ProofSuccess {
+		t.Error("Expected a PLONK proof to fail Groth16 verification")
+	}
+	relabeled.Backend = "halo2"
+	if result, _ := proof.VerifyProofData(&relabeled); result.Result == ProofSuccess {
+		t.Error("Expected a proof of an unknown backend to be refused")
+	}
+
+	if _, err := GenerateWithOptionsContext(context.Background(), &BRCA1Proof{}, vcfPath, GenerateOptions{Backend: PLONK}); err == nil {
+		t.Error("Expected proofs without a circuit to refuse the PLONK backend")
+	}
+}
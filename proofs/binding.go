@@ -0,0 +1,277 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+)
+
+// bindingDomain separates binding hashes from other uses of hash-to-field
+var bindingDomain = []byte("zkgenomics-binding-v1")
+
+// Binding is the presentation context a proof is bound to. Its hash is an
+// extra public input of the proof, named Binding, so none of its fields can
+// be changed without invalidating the proof.
+type Binding struct {
+	// NotBefore and NotAfter, if set, bound the time the proof is valid in
+	NotBefore time.Time `json:"not_before,omitzero"`
+	NotAfter  time.Time `json:"not_after,omitzero"`
+	// Nonce, if set, is the challenge of the verifier the proof was made for,
+	// so the proof cannot be replayed to another verifier
+	Nonce []byte `json:"nonce,omitempty"`
+	// HolderKeyHash, if set, is the HolderKeyHash of the public key of the
+	// holder the proof was issued to, who signs it when presenting it
+	HolderKeyHash string `json:"holder_key_hash,omitempty"`
+}
+
+// ErrNonceMismatch is reported when a proof is not bound to the nonce its
+// verifier expects
+var ErrNonceMismatch = errors.New("proof is not bound to the expected nonce")
+
+// ValidityWindowError is reported when a proof is verified outside its
+// validity window
+type ValidityWindowError struct {
+	NotBefore time.Time
+	NotAfter  time.Time
+	At        time.Time
+}
+
+func (e *ValidityWindowError) Error() string {
+	if !e.NotAfter.IsZero() && e.At.After(e.NotAfter) {
+		return fmt.Sprintf("proof expired at %s", e.NotAfter.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("proof is not valid before %s", e.NotBefore.Format(time.RFC3339))
+}
+
+// hash returns the value of the Binding public input for b
+func (b *Binding) hash() (*big.Int, error) {
+	var data []byte
+	for _, t := range []time.Time{b.NotBefore, b.NotAfter} {
+		var seconds int64
+		if !t.IsZero() {
+			seconds = t.Unix()
+		}
+		data = binary.BigEndian.AppendUint64(data, uint64(seconds))
+	}
+	for _, field := range [][]byte{b.Nonce, []byte(b.HolderKeyHash)} {
+		data = binary.BigEndian.AppendUint64(data, uint64(len(field)))
+		data = append(data, field...)
+	}
+
+	hashed, err := fr.Hash(data, bindingDomain, 1)
+	if err != nil {
+		return nil, fmt.Errorf("hashing binding: %w", err)
+	}
+	return hashed[0].BigInt(new(big.Int)), nil
+}
+
+// check reports whether b admits presentation at time at
+func (b *Binding) check(at time.Time) error {
+	if (!b.NotBefore.IsZero() && at.Before(b.NotBefore)) || (!b.NotAfter.IsZero() && at.After(b.NotAfter)) {
+		return &ValidityWindowError{NotBefore: b.NotBefore, NotAfter: b.NotAfter, At: at}
+	}
+	return nil
+}
+
+// boundCircuit extends a circuit with the Binding public input, which follows
+// the circuit's own public inputs in the public witness
+type boundCircuit struct {
+	Inner   frontend.Circuit
+	Binding frontend.Variable `gnark:",public"`
+}
+
+func (c *boundCircuit) Define(api frontend.API) error {
+	// A public input that appears in no constraint is not bound by Groth16,
+	// so the proof would verify with any Binding
+	api.Mul(c.Binding, c.Binding)
+	return c.Inner.Define(api)
+}
+
+func (c *boundCircuit) PublicInputLayout() PublicInputLayout {
+	layout := c.Inner.(LayoutCircuit).PublicInputLayout()
+	inputs := make([]string, 0, len(layout.Inputs)+1)
+	for _, name := range layout.Inputs {
+		inputs = append(inputs, "Inner_"+name)
+	}
+	layout.Inputs = append(inputs, "Binding")
+	return layout
+}
+
+func (c *boundCircuit) Hints() []solver.Hint {
+	if hinted, ok := c.Inner.(HintedCircuit); ok {
+		return hinted.Hints()
+	}
+	return nil
+}
+
+// GenerateOptions are the optional settings of GenerateWithOptionsContext
+type GenerateOptions struct {
+	// Seed, if set, is the seed randomness is drawn from, as for
+	// GenerateSeededContext
+	Seed []byte
+	// Binding, if set, is bound to the proof. Bound proofs are proven from
+	// their circuit and assignment rather than by their own Generate, so the
+	// proof must implement CircuitAssigner.
+	Binding *Binding
+	// DebugWitness, if set, is filled with the witness before proving, so it
+	// is available when the circuit fails to satisfy. It reveals the private
+	// inputs. As for Binding, the proof must implement CircuitAssigner.
+	DebugWitness *DebugWitness
+	// Keys, if set, holds the keys proofs are generated with; a circuit
+	// without stored keys is set up once and its keys stored. As for Binding,
+	// the proof must implement CircuitAssigner.
+	Keys KeyStore
+	// Cache, if set, holds compiled circuits and their keys, so proofs of a
+	// cached circuit skip compilation and setup. Seeded generation does not
+	// use it. As for Keys, only proofs implementing CircuitAssigner do.
+	Cache *CircuitCache
+	// Backend, if set, is the proving system proofs are made with, and the
+	// curve they are made over; Groth16 over BN254 if nil. As for Binding,
+	// only proofs implementing CircuitAssigner support other backends.
+	Backend Backend
+	// Logger and Progress, if set, receive messages and proving stages of
+	// proofs proven from their circuit and assignment
+	Logger   Logger
+	Progress ProgressReporter
+	// ProverOptions tune proving. As for Binding, only proofs implementing
+	// CircuitAssigner can be given hints or an external prover.
+	ProverOptions
+}
+
+// GenerateWithOptionsContext generates proof like GenerateContext, with the
+// settings in opts
+func GenerateWithOptionsContext(ctx context.Context, proof Proof, vcfPath string, opts GenerateOptions) (*ProofData, error) {
+	if opts.Seed != nil && len(opts.Seed) == 0 {
+		return failedProofData(), fmt.Errorf("seeded generation requires a seed")
+	}
+	if opts.Seed != nil && opts.Keys != nil {
+		return failedProofData(), fmt.Errorf("seeded generation cannot use stored keys")
+	}
+	if opts.Seed != nil {
+		// Seeded keys must be derived from the seed, and must never be
+		// reused for unseeded proofs
+		opts.Cache = nil
+	}
+	if err := opts.ProverOptions.validate(); err != nil {
+		return failedProofData(), err
+	}
+	opts.Backend = backendOrDefault(opts.Backend)
+	assigner, ok := proof.(CircuitAssigner)
+	assigned := opts.Binding != nil || opts.DebugWitness != nil || opts.Keys != nil || opts.Backend != Groth16 || len(opts.Hints) > 0 || opts.Prover != nil || (opts.Cache != nil && ok)
+	if assigned && !ok {
+		switch {
+		case opts.Binding != nil:
+			return failedProofData(), fmt.Errorf("%T proofs cannot be bound", proof)
+		case opts.DebugWitness != nil:
+			return failedProofData(), fmt.Errorf("%T proofs cannot record their witness", proof)
+		case opts.Backend != Groth16:
+			return failedProofData(), fmt.Errorf("%T proofs support only the Groth16 backend", proof)
+		case len(opts.Hints) > 0:
+			return failedProofData(), fmt.Errorf("%T proofs cannot be given solver hints", proof)
+		case opts.Prover != nil:
+			return failedProofData(), fmt.Errorf("%T proofs cannot be proven by an external prover", proof)
+		default:
+			return failedProofData(), fmt.Errorf("%T proofs cannot use stored keys", proof)
+		}
+	}
+
+	proofData, err := runContext(ctx, func() (*ProofData, error) {
+		return generateWithRandomness(opts.Seed, func() (*ProofData, error) {
+			if !assigned {
+				// Proofs proving themselves are capped at Threads, though
+				// their solver is not told of it
+				defer CapThreads(opts.Threads)()
+				return proof.Generate(vcfPath, "", "")
+			}
+			return generateAssigned(assigner, vcfPath, opts)
+		})
+	})
+	return recordFailure(proofData, err), err
+}
+
+// generateAssigned proves the circuit of assigner with the keys and backend
+// in opts, extended with the Binding public input if opts has a binding,
+// recording its witness if asked to. Hashes among the public values are
+// computed over the curve of the backend.
+func generateAssigned(assigner CircuitAssigner, vcfPath string, opts GenerateOptions) (*ProofData, error) {
+	circuit, assignment, err := assigner.Assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+	if err := validatePublicInputLayout(circuit); err != nil {
+		return failedProofData(), err
+	}
+	if curve := opts.Backend.Curve(); curve != ecc.BN254 {
+		if hashed, ok := assignment.(curveAssignment); ok {
+			if err := hashed.assignCurve(curve); err != nil {
+				return failedProofData(), err
+			}
+		}
+	}
+	if opts.Binding != nil {
+		bindingHash, err := opts.Binding.hash()
+		if err != nil {
+			return failedProofData(), err
+		}
+		circuit = &boundCircuit{Inner: circuit}
+		assignment = &boundCircuit{Inner: assignment, Binding: bindingHash}
+	}
+	if opts.DebugWitness != nil {
+		if err := opts.DebugWitness.record(opts.Backend.Curve(), assignment); err != nil {
+			return failedProofData(), err
+		}
+	}
+
+	proofData, err := proveCircuitWithKeys(opts.Logger, opts.Progress, opts.Backend, opts.Keys, opts.Cache, opts.ProverOptions, circuit, assignment)
+	if err != nil {
+		return proofData, err
+	}
+	proofData.Binding = opts.Binding
+	return proofData, nil
+}
+
+// checkBinding checks that the Binding public input of a bound proof over
+// curve matches its recorded binding, and that the binding admits
+// presentation now. Whether the proof is bound is decided by its public
+// inputs, as for PublicValues.
+func checkBinding(proofData *ProofData, curve ecc.ID, publicWitness witness.Witness) error {
+	publicInputs, err := witnessValues(publicWitness)
+	if err != nil {
+		return err
+	}
+	_, bound, err := proofLayout(proofData, len(publicInputs))
+	if err != nil || !bound {
+		return err
+	}
+
+	expected, err := proofData.Binding.hash()
+	if err != nil {
+		return err
+	}
+	// The binding hash is reduced into the field of the proof's curve
+	expected.Mod(expected, curve.ScalarField())
+	if publicInputs[len(publicInputs)-1].Cmp(expected) != 0 {
+		return fmt.Errorf("proof binding does not match the recorded binding")
+	}
+	return proofData.Binding.check(time.Now())
+}
+
+// CheckNonce checks that a verified proof is bound to nonce, the challenge
+// its verifier issued. The proof must already have been verified, which
+// checks that its recorded binding is the one it was proven with.
+func CheckNonce(proofData *ProofData, nonce []byte) error {
+	if proofData.Binding == nil || !bytes.Equal(proofData.Binding.Nonce, nonce) {
+		return ErrNonceMismatch
+	}
+	return nil
+}
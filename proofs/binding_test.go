@@ -0,0 +1,91 @@
+package proofs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerateWithOptionsContext_ValidityWindow(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+
+	generate := func(binding *Binding) *ProofData {
+		t.Helper()
+		proofData, err := GenerateWithOptionsContext(context.Background(), &ALDH2Proof{}, vcfPath, GenerateOptions{Binding: binding})
+		if err != nil {
+			t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+		}
+		return proofData
+	}
+
+	now := time.Now().Truncate(time.Second)
+	valid := generate(&Binding{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)})
+	result, err := (&ALDH2Proof{}).VerifyProofData(valid)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected a proof inside its window to verify, got %v: %v", result, err)
+	}
+	if _, ok := result.ParsedPublicInputs["Binding"]; !ok {
+		t.Errorf("Expected the binding among the public inputs, got %v", result.ParsedPublicInputs)
+	}
+
+	// Extending the window changes the binding the proof was made for
+	extended := *valid
+	extended.Binding = &Binding{NotBefore: valid.Binding.NotBefore, NotAfter: now.Add(24 * time.Hour)}
+	result, err = (&ALDH2Proof{}).VerifyProofData(&extended)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected an altered window to fail, got %v: %v", result, err)
+	}
+
+	// Without its binding the proof no longer matches its circuit's layout
+	stripped := *valid
+	stripped.Binding = nil
+	result, err = (&ALDH2Proof{}).VerifyProofData(&stripped)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected a proof stripped of its binding to fail, got %v: %v", result, err)
+	}
+
+	expired := generate(&Binding{NotAfter: now.Add(-time.Minute)})
+	result, err = (&ALDH2Proof{}).VerifyProofData(expired)
+	var windowErr *ValidityWindowError
+	if err != nil || result.Result != ProofFail || !errors.As(result.Error, &windowErr) {
+		t.Errorf("Expected an expired proof to fail with ValidityWindowError, got %v: %v", result, err)
+	}
+}
+
+func TestGenerateWithOptionsContext_Nonce(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+
+	proofData, err := GenerateWithOptionsContext(context.Background(), &ALDH2Proof{}, vcfPath, GenerateOptions{
+		Binding: &Binding{Nonce: []byte("challenge-1")},
+	})
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+	result, err := (&ALDH2Proof{}).VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected the bound proof to verify, got %v: %v", result, err)
+	}
+	if err := CheckNonce(proofData, []byte("challenge-1")); err != nil {
+		t.Errorf("Expected the issued nonce to match, got %v", err)
+	}
+	if err := CheckNonce(proofData, []byte("challenge-2")); !errors.Is(err, ErrNonceMismatch) {
+		t.Errorf("Expected another verifier's nonce to be rejected, got %v", err)
+	}
+
+	// Replaying the proof with the nonce rewritten breaks the proof
+	replayed := *proofData
+	replayed.Binding = &Binding{Nonce: []byte("challenge-2")}
+	result, err = (&ALDH2Proof{}).VerifyProofData(&replayed)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected a rewritten nonce to fail, got %v: %v", result, err)
+	}
+}
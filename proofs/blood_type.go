@@ -0,0 +1,99 @@
+package proofs
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// BloodTypeCircuit proves the ABO blood group derived from the private
+// rs8176719 and rs8176746 genotypes. The blood group is the only public output.
+type BloodTypeCircuit struct {
+	ClaimedBloodType frontend.Variable `gnark:",public"`
+
+	FunctionalGenotype frontend.Variable // rs8176719
+	BAlleleGenotype    frontend.Variable // rs8176746
+}
+
+// Define looks up the blood group in traits.ABOTable by selecting the single
+// table entry whose indices match both genotypes
+func (c *BloodTypeCircuit) Define(api frontend.API) error {
+	var bloodType frontend.Variable = 0
+	for i := range traits.ABOTable {
+		isFunctional := api.IsZero(api.Sub(c.FunctionalGenotype, i))
+		for j, group := range traits.ABOTable[i] {
+			if group == traits.BloodGroupUnknown {
+				continue
+			}
+			isB := api.IsZero(api.Sub(c.BAlleleGenotype, j))
+			selected := api.Mul(isFunctional, isB)
+			bloodType = api.Add(bloodType, api.Mul(selected, int(group)))
+		}
+	}
+
+	// Out-of-range or impossible genotype combinations select no entry
+	api.AssertIsDifferent(bloodType, int(traits.BloodGroupUnknown))
+	api.AssertIsEqual(c.ClaimedBloodType, bloodType)
+
+	return nil
+}
+
+// Assign extracts the ABO genotypes and builds the circuit and its assignment
+func (p *BloodTypeProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &BloodTypeCircuit{}, assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *BloodTypeProof) Circuit() (frontend.Circuit, error) {
+	return &BloodTypeCircuit{}, nil
+}
+
+func (p *BloodTypeProof) assign(vcfPath string) (*BloodTypeCircuit, traits.BloodGroup, error) {
+	loggerOrNop(p.Logger).Infof("searching for ABO blood group variants...")
+	genotypes, err := extractTraitGenotypes(vcfPath, p.Sample,
+		[]traits.TraitVariant{p.Traits.Variant("abo_functional"), p.Traits.Variant("abo_b")}, p.Progress, p.Logger)
+	if err != nil {
+		return nil, traits.BloodGroupUnknown, err
+	}
+	functional, b := genotypes[0], genotypes[1]
+
+	group := traits.BloodGroupFromGenotypes(functional, b)
+	if group == traits.BloodGroupUnknown {
+		return nil, traits.BloodGroupUnknown, fmt.Errorf("ABO genotypes do not determine a blood group")
+	}
+
+	return &BloodTypeCircuit{
+		ClaimedBloodType:   int(group),
+		FunctionalGenotype: functional,
+		BAlleleGenotype:    b,
+	}, group, nil
+}
+
+func (p *BloodTypeProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, group, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, &BloodTypeCircuit{}, assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Blood type proof successfully generated for group %s!", group)
+
+	return proofData, nil
+}
+
+func (p *BloodTypeProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "ABO blood type", verifyingKeyPath, proofPath)
+}
+
+func (p *BloodTypeProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "ABO blood type", proofData)
+}
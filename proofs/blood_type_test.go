@@ -0,0 +1,106 @@
+package proofs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// writeTestVCF writes vcfContent to a temporary file and returns its path
+func writeTestVCF(t *testing.T, vcfContent string) string {
+	t.Helper()
+
+	tmpFile, err := os.CreateTemp("", "test*.vcf")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	t.Cleanup(func() { os.Remove(tmpFile.Name()) })
+
+	_, err = tmpFile.WriteString(vcfContent)
+	if err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tmpFile.Close()
+
+	return tmpFile.Name()
+}
+
+func TestBloodTypeCircuit(t *testing.T) {
+	tests := []struct {
+		functional int
+		b          int
+		claimed    traits.BloodGroup
+		solved     bool
+	}{
+		{0, 0, traits.BloodGroupO, true},
+		{1, 0, traits.BloodGroupA, true},
+		{2, 0, traits.BloodGroupA, true},
+		{1, 1, traits.BloodGroupB, true},
+		{2, 2, traits.BloodGroupB, true},
+		{2, 1, traits.BloodGroupAB, true},
+		{2, 1, traits.BloodGroupA, false},       // Wrong claim
+		{0, 1, traits.BloodGroupUnknown, false}, // Impossible combination
+		{3, 0, traits.BloodGroupA, false},       // Out of range genotype
+	}
+
+	for _, tc := range tests {
+		assignment := &BloodTypeCircuit{
+			ClaimedBloodType:   int(tc.claimed),
+			FunctionalGenotype: tc.functional,
+			BAlleleGenotype:    tc.b,
+		}
+		err := test.IsSolved(&BloodTypeCircuit{}, assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("Expected genotypes %d/%d to prove %s: %v", tc.functional, tc.b, tc.claimed, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("Expected genotypes %d/%d not to prove %s", tc.functional, tc.b, tc.claimed)
+		}
+	}
+}
+
+func TestBloodTypeProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+9	136131322	rs8176746	G	T	60	PASS	.	GT	0/1
+9	136132908	rs8176719	T	TC	60	PASS	.	GT	1/1
+`)
+
+	proof := &BloodTypeProof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if proofData.Result != ProofSuccess {
+		t.Fatalf("Expected ProofSuccess, got %s", proofData.Result.String())
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
+
+func TestBloodTypeProof_GenerateWithMissingPosition(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+9	136131322	rs8176746	G	T	60	PASS	.	GT	0/1
+`)
+
+	proof := &BloodTypeProof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err == nil {
+		t.Errorf("Generate should return error when position not found")
+	}
+	if proofData.Result != ProofFail {
+		t.Errorf("Expected ProofFail, got %s", proofData.Result.String())
+	}
+}
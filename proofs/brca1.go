@@ -2,14 +2,9 @@ package proofs
 
 import (
 	"fmt"
-	"os"
-	"strings"
 
-	"github.com/brentp/vcfgo"
-	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark/backend/groth16"
-	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
 )
 
 type BRCA1Circuit struct {
@@ -24,7 +19,8 @@ func (c *BRCA1Circuit) Define(api frontend.API) error {
 }
 
 func (p *BRCA1Proof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
-	f, err := os.Open(vcfPath)
+	log := loggerOrNop(p.Logger)
+	rdr, err := openVCF(vcfPath, p.Progress)
 	if err != nil {
 		return &ProofData{
 			Proof:         nil,
@@ -33,34 +29,23 @@ func (p *BRCA1Proof) Generate(vcfPath string, provingKeyPath string, outputPath
 			Result:        ProofFail,
 		}, err
 	}
-	defer f.Close()
-
-	rdr, err := vcfgo.NewReader(f, false)
-	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, err
+	defer rdr.Close()
+	locus := p.Traits.Variant("brca1")
+	if err := checkBuild(detectBuild(rdr.Header), locus.Build, fmt.Sprintf("BRCA1 position %d", locus.Position)); err != nil {
+		return failedProofData(), err
 	}
 
-	fmt.Println("searching for BRCA1 trait...")
+	log.Infof("searching for BRCA1 trait...")
 	for {
 		variant := rdr.Read()
 		if variant == nil {
-			fmt.Println("Could not find position")
+			log.Debugf("Could not find position")
 			break
 		}
 
 		pos := variant.Pos
 
-		if pos%1000 == 0 {
-			fmt.Printf("Searching position: %d\n", pos)
-		}
-		if pos == 41276045 {
-			fmt.Println("Found position.")
-			fmt.Printf("Variant: Chromosome: %s, Reference: %s, Alternate: %s", variant.Chromosome, variant.Reference, variant.Alternate)
+		if pos == uint64(locus.Position) && traits.ChromosomeCode(variant.Chromosome) == locus.Chromosome {
 			
 			// Return successful proof data
 			return &ProofData{
@@ -78,17 +63,15 @@ func (p *BRCA1Proof) Generate(vcfPath string, provingKeyPath string, outputPath
 		VerifyingKey:  nil,
 		PublicWitness: nil,
 		Result:        ProofFail,
-	}, fmt.Errorf("BRCA1 position not found")
+	}, fmt.Errorf("%w: BRCA1 position not found", ErrVariantNotFound)
 }
 
 func (p *BRCA1Proof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
-	return &VerificationResult{
-		Result: ProofSuccess,
-		Error:  nil,
-	}, nil
+	return verifyProofFile(p.Logger, "brca1", verifyingKeyPath, proofPath)
 }
 
 func (p *BRCA1Proof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	log := loggerOrNop(p.Logger)
 	// Verify BRCA1 proof directly from ProofData using gnark
 	
 	if len(proofData.Proof) == 0 || len(proofData.VerifyingKey) == 0 {
@@ -98,57 +81,19 @@ func (p *BRCA1Proof) VerifyProofData(proofData *ProofData) (*VerificationResult,
 		}, nil
 	}
 	
-	fmt.Println("Verifying BRCA1 proof from ProofData...")
+	log.Infof("Verifying BRCA1 proof from ProofData...")
 	
-	// Deserialize the verifying key
-	vk := groth16.NewVerifyingKey(ecc.BN254)
-	_, err := vk.ReadFrom(strings.NewReader(string(proofData.VerifyingKey)))
+	// Select the proving system the proof was made with
+	backend, err := BackendNamed(proofData.Backend, proofData.Curve)
 	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize verifying key: %w", err),
-		}, nil
-	}
-	
-	// Deserialize the proof
-	proof := groth16.NewProof(ecc.BN254)
-	_, err = proof.ReadFrom(strings.NewReader(string(proofData.Proof)))
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize proof: %w", err),
-		}, nil
-	}
-	
-	// Deserialize the public witness
-	publicWitness, err := witness.New(ecc.BN254.ScalarField())
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to create witness: %w", err),
-		}, nil
-	}
-	err = publicWitness.UnmarshalBinary(proofData.PublicWitness)
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize public witness: %w", err),
-		}, nil
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
 	}
 	
 	// Perform gnark verification
-	err = groth16.Verify(proof, vk, publicWitness)
+	err = backend.Verify(proofData.Proof, proofData.VerifyingKey, proofData.PublicWitness)
 	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("proof verification failed: %w", err),
-		}, nil
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
 	}
 	
-	fmt.Println("✅ BRCA1 proof successfully verified!")
-	
-	return &VerificationResult{
-		Result: ProofSuccess,
-		Error:  nil,
-	}, nil
+	return verified(proofData), nil
 }
\ No newline at end of file
@@ -1,7 +1,9 @@
 package proofs
 
 import (
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -66,12 +68,42 @@ func TestBRCA1Proof_GenerateWithMissingPosition(t *testing.T) {
 }
 
 func TestBRCA1Proof_Verify(t *testing.T) {
+	vcfContent := `##fileformat=VCFv4.2
+##INFO=<ID=DP,Number=1,Type=Integer,Description="Approximate read depth">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO
+17	41276045	.	A	G	60	PASS	DP=30
+`
+	dir := t.TempDir()
+	vcfPath := filepath.Join(dir, "test.vcf")
+	if err := os.WriteFile(vcfPath, []byte(vcfContent), 0644); err != nil {
+		t.Fatalf("Failed to write VCF: %v", err)
+	}
+
 	proof := &BRCA1Proof{}
-	result, err := proof.Verify("", "")
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	encoded, err := json.Marshal(proofData)
+	if err != nil {
+		t.Fatalf("Failed to encode proof: %v", err)
+	}
+	proofPath := filepath.Join(dir, "proof.json")
+	if err := os.WriteFile(proofPath, encoded, 0644); err != nil {
+		t.Fatalf("Failed to write proof: %v", err)
+	}
+
+	// Generate does not prove anything yet, so its proof must not verify
+	result, err := proof.Verify("", proofPath)
 	if err != nil {
 		t.Errorf("Verify should not return error: %v", err)
 	}
-	if result.Result != ProofSuccess {
-		t.Errorf("Expected ProofSuccess, got %s", result.Result.String())
+	if result.Result != ProofFail {
+		t.Errorf("Expected ProofFail, got %s", result.Result.String())
+	}
+
+	// Without a proof there is nothing to verify
+	if _, err := proof.Verify("", filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Verify should return error for a missing proof file")
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,190 @@
+package proofs
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// DefaultBRCA2MaxVariants bounds how many non-reference variants in the
+// scanned BRCA2 region fit into the circuit
+const DefaultBRCA2MaxVariants = 256
+
+// BRCA2PanelCircuit proves whether any variant of the sample's private
+// variant list belongs to a fixed pathogenic panel. Variants and panel
+// entries are VariantKey values; unused variant slots hold 0. Only the
+// yes/no carrier status is public.
+type BRCA2PanelCircuit struct {
+	IsCarrier frontend.Variable `gnark:",public"`
+
+	Variants []frontend.Variable
+
+	panel []*big.Int
+}
+
+// NewBRCA2PanelCircuit creates a circuit testing up to maxVariants private
+// variants against the panel keys
+func NewBRCA2PanelCircuit(panel []*big.Int, maxVariants int) *BRCA2PanelCircuit {
+	return &BRCA2PanelCircuit{
+		Variants: make([]frontend.Variable, maxVariants),
+		panel:    panel,
+	}
+}
+
+func (c *BRCA2PanelCircuit) Define(api frontend.API) error {
+	var matches frontend.Variable = 0
+	for _, variant := range c.Variants {
+		for _, key := range c.panel {
+			matches = api.Add(matches, api.IsZero(api.Sub(variant, key)))
+		}
+	}
+
+	api.AssertIsBoolean(c.IsCarrier)
+	api.AssertIsEqual(c.IsCarrier, api.Sub(1, api.IsZero(matches)))
+
+	return nil
+}
+
+// NewBRCA2Proof creates a BRCA2 carrier proof over the default panel
+func NewBRCA2Proof() *BRCA2Proof {
+	return &BRCA2Proof{
+		Panel:       traits.BRCA2PathogenicVariants,
+		MaxVariants: DefaultBRCA2MaxVariants,
+	}
+}
+
+// Assign extracts the sample's BRCA2 variants and builds the circuit and its assignment
+func (p *BRCA2Proof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, circuit, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return circuit, assignment, nil
+}
+
+// assign returns the assignment along with the empty circuit it satisfies
+func (p *BRCA2Proof) assign(vcfPath string) (*BRCA2PanelCircuit, *BRCA2PanelCircuit, error) {
+	panel := p.Panel
+	if len(panel) == 0 {
+		panel = traits.BRCA2PathogenicVariants
+	}
+	maxVariants := p.MaxVariants
+	if maxVariants <= 0 {
+		maxVariants = DefaultBRCA2MaxVariants
+	}
+
+	panelKeys := make([]*big.Int, len(panel))
+	for i, variant := range panel {
+		key, err := VariantKey(uint64(variant.Position), variant.Ref, variant.Alt)
+		if err != nil {
+			return nil, nil, err
+		}
+		panelKeys[i] = key
+	}
+
+	loggerOrNop(p.Logger).Infof("searching for BRCA2 variants...")
+	variants, err := p.extractVariantKeys(vcfPath, panel)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(variants) > maxVariants {
+		return nil, nil, fmt.Errorf("found %d BRCA2 variants, circuit holds at most %d", len(variants), maxVariants)
+	}
+
+	carrier := 0
+	for _, variant := range variants {
+		for _, key := range panelKeys {
+			if variant.Cmp(key) == 0 {
+				carrier = 1
+			}
+		}
+	}
+
+	assignment := NewBRCA2PanelCircuit(panelKeys, maxVariants)
+	assignment.IsCarrier = carrier
+	for i := range assignment.Variants {
+		assignment.Variants[i] = 0
+		if i < len(variants) {
+			assignment.Variants[i] = variants[i]
+		}
+	}
+
+	return assignment, NewBRCA2PanelCircuit(panelKeys, maxVariants), nil
+}
+
+func (p *BRCA2Proof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, circuit, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, circuit, assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ BRCA2 proof successfully generated: carrier=%t", assignment.IsCarrier == 1)
+
+	return proofData, nil
+}
+
+func (p *BRCA2Proof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "BRCA2", verifyingKeyPath, proofPath)
+}
+
+func (p *BRCA2Proof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "BRCA2", proofData)
+}
+
+// extractVariantKeys returns the VariantKey of every ALT allele carried by the
+// first sample within the BRCA2 region, widened to cover every panel position
+func (p *BRCA2Proof) extractVariantKeys(vcfPath string, panel []traits.TraitVariant) ([]*big.Int, error) {
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := uint64(traits.BRCA2Region.Start), uint64(traits.BRCA2Region.End)
+	for _, variant := range panel {
+		if err := checkBuild(source.Build(), variant.Build, variant.Trait); err != nil {
+			return nil, err
+		}
+		start = min(start, uint64(variant.Position))
+		end = max(end, uint64(variant.Position))
+	}
+
+	var keys []*big.Int
+	var keyErr error
+	err = source.IterateRegion("13", start, end, func(call *VariantCall) bool {
+		if len(call.Samples) == 0 {
+			keyErr = fmt.Errorf("%w: VCF has no samples", ErrNoSampleData)
+			return false
+		}
+
+		seen := make(map[int]bool)
+		for _, allele := range call.Samples[0].GT {
+			if allele <= 0 || allele > len(call.Alternate) || seen[allele] {
+				continue
+			}
+			seen[allele] = true
+
+			key, err := VariantKey(call.Position, call.Reference, call.Alternate[allele-1])
+			if err != nil {
+				keyErr = err
+				return false
+			}
+			keys = append(keys, key)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if keyErr != nil {
+		return nil, keyErr
+	}
+
+	return keys, nil
+}
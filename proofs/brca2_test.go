@@ -0,0 +1,96 @@
+package proofs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestBRCA2PanelCircuit(t *testing.T) {
+	panel := []*big.Int{big.NewInt(11), big.NewInt(22)}
+
+	tests := []struct {
+		variants []int
+		carrier  int
+		solved   bool
+	}{
+		{[]int{5, 0, 0}, 0, true},
+		{[]int{5, 22, 0}, 1, true},
+		{[]int{11, 22, 0}, 1, true},
+		{[]int{5, 22, 0}, 0, false}, // Hides a panel variant
+		{[]int{5, 0, 0}, 1, false},  // Claims a variant that is not present
+		{[]int{5, 0, 0}, 2, false},  // Non-boolean status
+	}
+
+	for _, tc := range tests {
+		assignment := NewBRCA2PanelCircuit(panel, len(tc.variants))
+		assignment.IsCarrier = tc.carrier
+		for i, v := range tc.variants {
+			assignment.Variants[i] = v
+		}
+
+		err := test.IsSolved(NewBRCA2PanelCircuit(panel, len(tc.variants)), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("Expected variants %v to prove carrier=%d: %v", tc.variants, tc.carrier, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("Expected variants %v not to prove carrier=%d", tc.variants, tc.carrier)
+		}
+	}
+}
+
+func TestBRCA2Proof_GenerateCarrier(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+13	32900000	.	A	G	60	PASS	.	GT	1/1
+13	32914437	rs80359550	GT	G	60	PASS	.	GT	0/1
+17	41276045	.	C	T	60	PASS	.	GT	0/1
+`)
+
+	proof := NewBRCA2Proof()
+	proof.MaxVariants = 4
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
+
+func TestBRCA2Proof_ExtractVariantKeys(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+13	32900000	.	A	G,T	60	PASS	.	GT	1/2
+13	32914437	rs80359550	GT	G	60	PASS	.	GT	0/0
+`)
+
+	proof := NewBRCA2Proof()
+	keys, err := proof.extractVariantKeys(vcfPath, traits.BRCA2PathogenicVariants)
+	if err != nil {
+		t.Fatalf("extractVariantKeys failed: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("Expected keys for both carried ALT alleles, got %d", len(keys))
+	}
+
+	panelKey, err := VariantKey(32914437, "GT", "G")
+	if err != nil {
+		t.Fatalf("VariantKey failed: %v", err)
+	}
+	for _, key := range keys {
+		if key.Cmp(panelKey) == 0 {
+			t.Errorf("Homozygous reference panel variant should not be listed")
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package proofs
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs/gadgets"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// burdenPositionBits bounds genomic positions in the burden circuit
+const burdenPositionBits = 32
+
+// BurdenPolicy defines a rare-variant burden claim: how many variants of a
+// defined list, all within one gene region, carry an ALT allele
+type BurdenPolicy struct {
+	Chromosome int
+	Region     traits.TraitRegion
+	Variants   []traits.TraitVariant
+	// Threshold is the maximum carried-variant count, or the minimum when AtLeast is set
+	Threshold int
+	AtLeast   bool
+}
+
+// DefaultBurdenPolicy claims that no variant of the default BRCA2 panel is carried
+func DefaultBurdenPolicy() BurdenPolicy {
+	return BurdenPolicy{
+		Chromosome: 13,
+		Region:     traits.BRCA2Region,
+		Variants:   traits.BRCA2PathogenicVariants,
+		Threshold:  0,
+	}
+}
+
+// ListHash returns the public digest of the policy's variant list, the MiMC
+// hash of the policy's chromosome followed by each variant's VariantKey in
+// list order. The hash is over BN254; proofs over other curves disclose
+// ListHashOn their curve.
+func (p BurdenPolicy) ListHash() (*big.Int, error) {
+	return p.ListHashOn(ecc.BN254)
+}
+
+// ListHashOn returns the ListHash of the policy with MiMC over the scalar
+// field of curve
+func (p BurdenPolicy) ListHashOn(curve ecc.ID) (*big.Int, error) {
+	return burdenListHash(curve, p.Chromosome, p.Variants)
+}
+
+// burdenListHash returns the MiMC hash over curve of chromosome followed by
+// each variant's VariantKey in list order
+func burdenListHash(curve ecc.ID, chromosome int, variants []traits.TraitVariant) (*big.Int, error) {
+	keys, err := variantKeys(curve, variants)
+	if err != nil {
+		return nil, err
+	}
+	return mimcValues(curve, append([]*big.Int{big.NewInt(int64(chromosome))}, keys...))
+}
+
+// variantListHash returns the MiMC hash over curve of each variant's
+// VariantKey in list order
+func variantListHash(curve ecc.ID, variants []traits.TraitVariant) (*big.Int, error) {
+	keys, err := variantKeys(curve, variants)
+	if err != nil {
+		return nil, err
+	}
+	return mimcValues(curve, keys)
+}
+
+// variantKeys returns the VariantKey over curve of each variant
+func variantKeys(curve ecc.ID, variants []traits.TraitVariant) ([]*big.Int, error) {
+	keys := make([]*big.Int, len(variants))
+	for i, variant := range variants {
+		key, err := variantKeyOn(curve, uint64(variant.Position), variant.Ref, variant.Alt)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// Validate checks that every listed variant lies within the policy region
+func (p BurdenPolicy) Validate() error {
+	if len(p.Variants) == 0 {
+		return fmt.Errorf("burden policy lists no variants")
+	}
+	if p.Threshold < 0 || p.Threshold > len(p.Variants) {
+		return fmt.Errorf("burden threshold %d is outside 0..%d", p.Threshold, len(p.Variants))
+	}
+	for _, variant := range p.Variants {
+		if variant.Chromosome != p.Chromosome || variant.Position < p.Region.Start || variant.Position > p.Region.End {
+			return fmt.Errorf("%s lies outside the burden region %d:%d-%d", variant.Trait, p.Chromosome, p.Region.Start, p.Region.End)
+		}
+	}
+	return nil
+}
+
+// BurdenCircuit proves that the number of ALT-carrying variants from a list
+// is at most (or, with AtLeast set, at least) Threshold. The region and the
+// hash of the variant list are public; the list entries are private but bound
+// to ListHash and checked to lie within the region, so the prover cannot
+// substitute variants. ListHash also covers Chromosome, which binds it to the
+// proof.
+type BurdenCircuit struct {
+	Threshold   frontend.Variable `gnark:",public"`
+	AtLeast     frontend.Variable `gnark:",public"`
+	Chromosome  frontend.Variable `gnark:",public"`
+	RegionStart frontend.Variable `gnark:",public"`
+	RegionEnd   frontend.Variable `gnark:",public"`
+	ListHash    frontend.Variable `gnark:",public"`
+
+	Positions []frontend.Variable
+	RefHashes []frontend.Variable
+	AltHashes []frontend.Variable
+	Genotypes []frontend.Variable
+
+	chromosome int
+	variants   []traits.TraitVariant
+}
+
+// NewBurdenCircuit allocates a burden circuit for a list of n variants
+func NewBurdenCircuit(n int) *BurdenCircuit {
+	return &BurdenCircuit{
+		Positions: make([]frontend.Variable, n),
+		RefHashes: make([]frontend.Variable, n),
+		AltHashes: make([]frontend.Variable, n),
+		Genotypes: make([]frontend.Variable, n),
+	}
+}
+
+func (c *BurdenCircuit) Define(api frontend.API) error {
+	n := len(c.Positions)
+	if len(c.RefHashes) != n || len(c.AltHashes) != n || len(c.Genotypes) != n {
+		return fmt.Errorf("burden circuit slices must have equal length")
+	}
+
+	list, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	list.Write(c.Chromosome)
+
+	var carried frontend.Variable = 0
+	for i := range c.Positions {
+		// Recompute the VariantKey and fold it into the list hash
+		key, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		key.Write(c.Positions[i], c.RefHashes[i], c.AltHashes[i])
+		list.Write(key.Sum())
+
+		// The variant must lie within the public region
+		api.ToBinary(c.Positions[i], burdenPositionBits)
+		api.AssertIsLessOrEqual(c.RegionStart, c.Positions[i])
+		api.AssertIsLessOrEqual(c.Positions[i], c.RegionEnd)
+
+		g := c.Genotypes[i]
+		gadgets.AssertIsGenotype(api, g)
+
+		carried = api.Add(carried, api.Sub(1, api.IsZero(g)))
+	}
+	api.AssertIsEqual(list.Sum(), c.ListHash)
+
+	// AtLeast selects Threshold <= carried, otherwise carried <= Threshold
+	api.AssertIsBoolean(c.AtLeast)
+	api.AssertIsLessOrEqual(c.Threshold, n)
+	lower := api.Select(c.AtLeast, c.Threshold, carried)
+	upper := api.Select(c.AtLeast, carried, c.Threshold)
+	api.AssertIsLessOrEqual(lower, upper)
+
+	return nil
+}
+
+func (c *BurdenCircuit) assignCurve(curve ecc.ID) error {
+	listHash, err := burdenListHash(curve, c.chromosome, c.variants)
+	if err != nil {
+		return err
+	}
+	c.ListHash = listHash
+	return nil
+}
+
+// NewBurdenProof creates a burden proof for the given policy
+func NewBurdenProof(policy BurdenPolicy) *BurdenProof {
+	return &BurdenProof{Policy: policy}
+}
+
+// Assign extracts the listed variants and builds the circuit and its assignment
+func (p *BurdenProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewBurdenCircuit(len(assignment.Positions)), assignment, nil
+}
+
+// assign returns the assignment along with the policy it proves
+func (p *BurdenProof) assign(vcfPath string) (*BurdenCircuit, BurdenPolicy, error) {
+	policy := p.Policy
+	if len(policy.Variants) == 0 {
+		policy = DefaultBurdenPolicy()
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, policy, err
+	}
+
+	listHash, err := policy.ListHash()
+	if err != nil {
+		return nil, policy, err
+	}
+
+	loggerOrNop(p.Logger).Infof("searching for %d burden variants...", len(policy.Variants))
+	genotypes, err := p.extractBurdenGenotypes(vcfPath, policy)
+	if err != nil {
+		return nil, policy, err
+	}
+
+	carried := 0
+	for _, g := range genotypes {
+		if g > 0 {
+			carried++
+		}
+	}
+	if policy.AtLeast && carried < policy.Threshold {
+		return nil, policy, fmt.Errorf("burden of %d variants is below the threshold of %d", carried, policy.Threshold)
+	}
+	if !policy.AtLeast && carried > policy.Threshold {
+		return nil, policy, fmt.Errorf("burden of %d variants exceeds the threshold of %d", carried, policy.Threshold)
+	}
+
+	assignment := NewBurdenCircuit(len(policy.Variants))
+	assignment.Threshold = policy.Threshold
+	assignment.AtLeast = 0
+	if policy.AtLeast {
+		assignment.AtLeast = 1
+	}
+	assignment.Chromosome = policy.Chromosome
+	assignment.RegionStart = policy.Region.Start
+	assignment.RegionEnd = policy.Region.End
+	assignment.ListHash = listHash
+	assignment.chromosome = policy.Chromosome
+	assignment.variants = policy.Variants
+	for i, variant := range policy.Variants {
+		refHash, err := alleleHash(variant.Ref)
+		if err != nil {
+			return nil, policy, err
+		}
+		altHash, err := alleleHash(variant.Alt)
+		if err != nil {
+			return nil, policy, err
+		}
+		assignment.Positions[i] = variant.Position
+		assignment.RefHashes[i] = refHash
+		assignment.AltHashes[i] = altHash
+		assignment.Genotypes[i] = genotypes[i]
+	}
+
+	return assignment, policy, nil
+}
+
+func (p *BurdenProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, policy, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewBurdenCircuit(len(policy.Variants)), assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	relation := "at most"
+	if policy.AtLeast {
+		relation = "at least"
+	}
+	loggerOrNop(p.Logger).Infof("✅ Burden proof successfully generated: %s %d of %d variants carried", relation, policy.Threshold, len(policy.Variants))
+
+	return proofData, nil
+}
+
+func (p *BurdenProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "Burden", verifyingKeyPath, proofPath)
+}
+
+func (p *BurdenProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "Burden", proofData)
+}
+
+// extractBurdenGenotypes returns the first sample's ALT dosage for each listed
+// variant. Variants without a matching record count as homozygous reference.
+func (p *BurdenProof) extractBurdenGenotypes(vcfPath string, policy BurdenPolicy) ([]int, error) {
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	chrom := strconv.Itoa(policy.Chromosome)
+	loci := make([]Locus, len(policy.Variants))
+	for i, variant := range policy.Variants {
+		loci[i] = Locus{Chromosome: chrom, Position: uint64(variant.Position)}
+	}
+	if err := Prefetch(source, loci); err != nil {
+		return nil, err
+	}
+
+	genotypes := make([]int, len(policy.Variants))
+	for i, variant := range policy.Variants {
+		if err := checkBuild(source.Build(), variant.Build, variant.Trait); err != nil {
+			return nil, err
+		}
+		calls, err := lookupAlleles(source, chrom, uint64(variant.Position), variant.Ref, variant.Alt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", variant.Trait, err)
+		}
+		for _, call := range calls {
+			if !allelesMatch(variant.Ref, call.Reference) {
+				continue
+			}
+			if len(call.Samples) == 0 {
+				return nil, fmt.Errorf("%w: VCF has no samples", ErrNoSampleData)
+			}
+			genotypes[i] = altDosage(call, call.Samples[0], variant.Alt)
+			break
+		}
+	}
+	return genotypes, nil
+}
+
+// altDosage counts the copies of alt in a sample's genotype at call
+func altDosage(call *VariantCall, sample SampleCall, alt string) int {
+	dosage := 0
+	for _, allele := range sample.GT {
+		if allele > 0 && allele <= len(call.Alternate) && allelesMatch(alt, call.Alternate[allele-1]) {
+			dosage++
+		}
+	}
+	return dosage
+}
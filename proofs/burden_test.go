@@ -0,0 +1,149 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// testBurdenPolicy lists three variants in a small region on chromosome 2
+func testBurdenPolicy(threshold int, atLeast bool) BurdenPolicy {
+	return BurdenPolicy{
+		Chromosome: 2,
+		Region:     traits.TraitRegion{Start: 1000, End: 2000},
+		Variants: []traits.TraitVariant{
+			{Trait: "v1", Chromosome: 2, Position: 1100, Ref: "A", Alt: "G"},
+			{Trait: "v2", Chromosome: 2, Position: 1200, Ref: "C", Alt: "T"},
+			{Trait: "v3", Chromosome: 2, Position: 1300, Ref: "GA", Alt: "G"},
+		},
+		Threshold: threshold,
+		AtLeast:   atLeast,
+	}
+}
+
+// burdenAssignment builds a witness for policy with the given genotypes
+func burdenAssignment(t *testing.T, policy BurdenPolicy, genotypes []int) *BurdenCircuit {
+	t.Helper()
+
+	listHash, err := policy.ListHash()
+	if err != nil {
+		t.Fatalf("ListHash failed: %v", err)
+	}
+
+	assignment := NewBurdenCircuit(len(policy.Variants))
+	assignment.Threshold = policy.Threshold
+	assignment.AtLeast = 0
+	if policy.AtLeast {
+		assignment.AtLeast = 1
+	}
+	assignment.Chromosome = policy.Chromosome
+	assignment.RegionStart = policy.Region.Start
+	assignment.RegionEnd = policy.Region.End
+	assignment.ListHash = listHash
+	for i, variant := range policy.Variants {
+		refHash, _ := alleleHash(variant.Ref)
+		altHash, _ := alleleHash(variant.Alt)
+		assignment.Positions[i] = variant.Position
+		assignment.RefHashes[i] = refHash
+		assignment.AltHashes[i] = altHash
+		assignment.Genotypes[i] = genotypes[i]
+	}
+	return assignment
+}
+
+func TestBurdenCircuit(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		atLeast   bool
+		genotypes []int
+		solved    bool
+	}{
+		{"at most, within", 1, false, []int{0, 2, 0}, true},
+		{"at most, exceeded", 1, false, []int{1, 2, 0}, false},
+		{"at least, met", 2, true, []int{1, 0, 1}, true},
+		{"at least, not met", 2, true, []int{1, 0, 0}, false},
+		{"invalid genotype", 3, false, []int{3, 0, 0}, false},
+	}
+
+	for _, tc := range tests {
+		policy := testBurdenPolicy(tc.threshold, tc.atLeast)
+		assignment := burdenAssignment(t, policy, tc.genotypes)
+		err := test.IsSolved(NewBurdenCircuit(len(policy.Variants)), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("%s: expected circuit to be solved: %v", tc.name, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("%s: expected circuit not to be solved", tc.name)
+		}
+	}
+}
+
+func TestBurdenCircuit_RejectsSubstitutedVariants(t *testing.T) {
+	policy := testBurdenPolicy(0, false)
+	assignment := burdenAssignment(t, policy, []int{0, 0, 0})
+
+	// Swapping a listed variant for another one no longer matches ListHash
+	otherHash, _ := alleleHash("T")
+	assignment.AltHashes[0] = otherHash
+	if err := test.IsSolved(NewBurdenCircuit(3), assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Errorf("Expected a substituted variant to break the list hash")
+	}
+
+	// A variant outside the public region is rejected even with a matching hash
+	outside := testBurdenPolicy(0, false)
+	outside.Variants[0].Position = 5000
+	assignment = burdenAssignment(t, outside, []int{0, 0, 0})
+	if err := test.IsSolved(NewBurdenCircuit(3), assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Errorf("Expected a variant outside the region to be rejected")
+	}
+
+	// The same positions on another chromosome no longer match ListHash
+	assignment = burdenAssignment(t, policy, []int{0, 0, 0})
+	assignment.Chromosome = 3
+	if err := test.IsSolved(NewBurdenCircuit(3), assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Errorf("Expected another chromosome to break the list hash")
+	}
+}
+
+func TestBurdenProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+2	1100	.	A	G	60	PASS	.	GT	0/1
+2	1200	.	C	A,T	60	PASS	.	GT	0/1
+2	1300	.	GA	G	60	PASS	.	GT	1/1
+`)
+
+	// Only v1 and v3 are carried: the sample's ALT at 1200 is not the listed T
+	proof := NewBurdenProof(testBurdenPolicy(2, false))
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+
+	// Chromosome is the third public input
+	result, err = proof.VerifyProofData(withPublicInput(t, proofData, 2, 3))
+	if err == nil && result.Result == ProofSuccess {
+		t.Error("Expected a proof with a changed chromosome to fail verification")
+	}
+
+	proof = NewBurdenProof(testBurdenPolicy(1, false))
+	proofData, err = proof.Generate(vcfPath, "", "")
+	if err == nil {
+		t.Errorf("Generate should return error when the burden exceeds the threshold")
+	}
+	if proofData.Result != ProofFail {
+		t.Errorf("Expected ProofFail, got %s", proofData.Result.String())
+	}
+}
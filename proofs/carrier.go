@@ -0,0 +1,136 @@
+package proofs
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs/gadgets"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// CarrierCircuit proves that a specific variant is carried (genotype ∈ {1, 2})
+// without revealing whether it is heterozygous or homozygous. The locus,
+// allele hashes and constrained LocusHash are public, as in NegativeCircuit.
+type CarrierCircuit struct {
+	Chromosome frontend.Variable `gnark:",public"`
+	Position   frontend.Variable `gnark:",public"`
+	RefHash    frontend.Variable `gnark:",public"`
+	AltHash    frontend.Variable `gnark:",public"`
+	LocusHash  frontend.Variable `gnark:",public"`
+
+	Genotype frontend.Variable
+
+	variant traits.TraitVariant
+}
+
+func (c *CarrierCircuit) Define(api frontend.API) error {
+	if err := assertLocusHash(api, c.LocusHash, c.Chromosome, c.Position, c.RefHash, c.AltHash); err != nil {
+		return err
+	}
+
+	gadgets.AssertIsGenotype(api, c.Genotype)
+	api.AssertIsEqual(gadgets.IsInSet(api, c.Genotype, 1, 2), 1)
+
+	return nil
+}
+
+func (c *CarrierCircuit) assignCurve(curve ecc.ID) error {
+	locusHash, err := variantLocusHash(curve, c.variant)
+	if err != nil {
+		return err
+	}
+	c.LocusHash = locusHash
+	return nil
+}
+
+// NewCarrierProof creates a CarrierProof for the given variant
+func NewCarrierProof(variant traits.TraitVariant) *CarrierProof {
+	return &CarrierProof{Variant: variant}
+}
+
+// Assign extracts the genotype at the variant and builds the circuit and its assignment
+func (p *CarrierProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &CarrierCircuit{}, assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *CarrierProof) Circuit() (frontend.Circuit, error) {
+	return &CarrierCircuit{}, nil
+}
+
+func (p *CarrierProof) assign(vcfPath string) (*CarrierCircuit, error) {
+	variant := p.Variant
+	if variant.Position <= 0 || variant.Ref == "" || variant.Alt == "" {
+		return nil, fmt.Errorf("no variant set")
+	}
+
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	loggerOrNop(p.Logger).Infof("checking carrier status for %d:%d %s>%s...", variant.Chromosome, variant.Position, variant.Ref, variant.Alt)
+	genotype, called, err := panelGenotype(source, variant)
+	if err != nil {
+		return nil, err
+	}
+	if !called {
+		return nil, fmt.Errorf("%w: genotype at position %d is not called", ErrNoSampleData, variant.Position)
+	}
+	if genotype == 0 {
+		return nil, fmt.Errorf("variant is not carried")
+	}
+
+	refHash, err := alleleHash(variant.Ref)
+	if err != nil {
+		return nil, err
+	}
+	altHash, err := alleleHash(variant.Alt)
+	if err != nil {
+		return nil, err
+	}
+	locusHash, err := variantLocusHash(ecc.BN254, variant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CarrierCircuit{
+		Chromosome: variant.Chromosome,
+		Position:   variant.Position,
+		RefHash:    refHash,
+		AltHash:    altHash,
+		LocusHash:  locusHash,
+		Genotype:   genotype,
+		variant:    variant,
+	}, nil
+}
+
+func (p *CarrierProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, &CarrierCircuit{}, assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Carrier proof successfully generated: %s>%s at position %d is carried",
+		p.Variant.Ref, p.Variant.Alt, p.Variant.Position)
+
+	return proofData, nil
+}
+
+func (p *CarrierProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "carrier", verifyingKeyPath, proofPath)
+}
+
+func (p *CarrierProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "carrier", proofData)
+}
@@ -0,0 +1,82 @@
+package proofs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestCarrierCircuit(t *testing.T) {
+	locusHash, err := mimcValues(ecc.BN254, []*big.Int{big.NewInt(13), big.NewInt(32914437), big.NewInt(1), big.NewInt(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for genotype, solved := range []bool{false, true, true, false} {
+		assignment := &CarrierCircuit{
+			Chromosome: 13,
+			Position:   32914437,
+			RefHash:    1,
+			AltHash:    2,
+			LocusHash:  locusHash,
+			Genotype:   genotype,
+		}
+		err := test.IsSolved(&CarrierCircuit{}, assignment, ecc.BN254.ScalarField())
+		if solved && err != nil {
+			t.Errorf("Expected genotype %d to prove carrier status: %v", genotype, err)
+		}
+		if !solved && err == nil {
+			t.Errorf("Expected genotype %d not to prove carrier status", genotype)
+		}
+	}
+}
+
+func TestCarrierProof_GenerateAndVerify(t *testing.T) {
+	variant := traits.TraitVariant{Chromosome: 13, Position: 32914437, Ref: "GT", Alt: "G"}
+
+	tests := []struct {
+		name    string
+		gt      string
+		carrier bool
+	}{
+		{"heterozygous", "0/1", true},
+		{"homozygous", "1/1", true},
+		{"homozygous reference", "0/0", false},
+		{"no call", "./.", false},
+	}
+
+	for _, tc := range tests {
+		vcfPath := writeTestVCF(t, "##fileformat=VCFv4.2\n"+
+			"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n"+
+			"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n"+
+			"13\t32914437\t.\tGT\tG\t60\tPASS\t.\tGT\t"+tc.gt+"\n")
+
+		proof := NewCarrierProof(variant)
+		if !tc.carrier {
+			if _, err := proof.assign(vcfPath); err == nil {
+				t.Errorf("%s: expected carrier status not to be provable", tc.name)
+			}
+			continue
+		}
+
+		proofData, err := proof.Generate(vcfPath, "", "")
+		if err != nil {
+			t.Fatalf("%s: Generate should not return error: %v", tc.name, err)
+		}
+		result, err := proof.VerifyProofData(proofData)
+		if err != nil {
+			t.Fatalf("%s: VerifyProofData should not return error: %v", tc.name, err)
+		}
+		if result.Result != ProofSuccess {
+			t.Errorf("%s: expected ProofSuccess, got %s: %v", tc.name, result.Result.String(), result.Error)
+		}
+
+		// Position is the second public input
+		result, err = proof.VerifyProofData(withPublicInput(t, proofData, 1, 32914438))
+		if err == nil && result.Result == ProofSuccess {
+			t.Errorf("%s: expected a proof with a changed position to fail verification", tc.name)
+		}
+	}
+}
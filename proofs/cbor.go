@@ -0,0 +1,78 @@
+package proofs
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborEncMode encodes proofs deterministically with the field names of their
+// JSON encoding, and times to the nanosecond
+var cborEncMode = func() cbor.EncMode {
+	opts := cbor.CoreDetEncOptions()
+	opts.Time = cbor.TimeRFC3339Nano
+	opts.OmitEmpty = cbor.OmitEmptyGoValue
+	mode, err := opts.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}()
+
+// MarshalCBOR encodes the proof as CBOR, which stores its byte fields raw
+// rather than base64-encoded as JSON does
+func (p ProofData) MarshalCBOR() ([]byte, error) {
+	return cborEncMode.Marshal(versionedProofData{FormatVersion, (*plainProofData)(&p)})
+}
+
+// UnmarshalCBOR decodes a proof encoded by MarshalCBOR, of any supported
+// format version
+func (p *ProofData) UnmarshalCBOR(data []byte) error {
+	var decoded ProofData
+	v := versionedProofData{plainProofData: (*plainProofData)(&decoded)}
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := checkFormatVersion("proof", v.FormatVersion, FormatVersion); err != nil {
+		return err
+	}
+	migrateProofData(v.FormatVersion, &decoded)
+	*p = decoded
+	return nil
+}
+
+// MarshalCBOR encodes the presentation as CBOR
+func (p Presentation) MarshalCBOR() ([]byte, error) {
+	return cborEncMode.Marshal(versionedPresentation{FormatVersion, (*plainPresentation)(&p)})
+}
+
+// UnmarshalCBOR decodes a presentation encoded by MarshalCBOR, of any
+// supported format version
+func (p *Presentation) UnmarshalCBOR(data []byte) error {
+	var decoded Presentation
+	v := versionedPresentation{plainPresentation: (*plainPresentation)(&decoded)}
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := checkFormatVersion("presentation", v.FormatVersion, FormatVersion); err != nil {
+		return err
+	}
+	*p = decoded
+	return nil
+}
+
+// isJSON reports whether data is a JSON object rather than CBOR. A CBOR map
+// never starts with '{' or whitespace.
+func isJSON(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// decodeCBOR decodes CBOR-encoded data into v, naming what in errors
+func decodeCBOR(data []byte, v any, what string) error {
+	if err := cbor.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package proofs
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProofData_CBOR(t *testing.T) {
+	proofData := &ProofData{
+		Proof:          bytes.Repeat([]byte{0xab}, 128),
+		VerifyingKey:   bytes.Repeat([]byte{0xcd}, 256),
+		PublicWitness:  bytes.Repeat([]byte{0xef}, 64),
+		ProofType:      "actn3",
+		CircuitID:      "genotype_claim",
+		CircuitVersion: 2,
+		Curve:          proofCurve,
+		CreatedAt:      time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC),
+		Binding:        &Binding{NotAfter: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), Nonce: []byte("n")},
+	}
+
+	encoded, err := proofData.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR should not return error: %v", err)
+	}
+	jsonData, _ := json.Marshal(proofData)
+	if len(encoded) >= len(jsonData) {
+		t.Errorf("Expected CBOR to be smaller than JSON, got %d and %d bytes", len(encoded), len(jsonData))
+	}
+
+	decoded, err := DecodeProofData(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("DecodeProofData should accept CBOR: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, proofData) {
+		t.Errorf("Expected CBOR to round-trip, got %+v", decoded)
+	}
+
+	presentation := &Presentation{ProofData: proofData, HolderKey: []byte("key"), Signature: []byte("sig")}
+	encoded, err = presentation.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR should not return error: %v", err)
+	}
+	decodedPresentation, err := DecodePresentation(bytes.NewReader(encoded))
+	if err != nil || !reflect.DeepEqual(decodedPresentation, presentation) {
+		t.Errorf("Expected the presentation to round-trip, got %+v: %v", decodedPresentation, err)
+	}
+}
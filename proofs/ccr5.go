@@ -0,0 +1,39 @@
+package proofs
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func (p *CCR5Proof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	proofData, claim, err := generateGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("ccr5_delta32"), p.Traits.Claims("ccr5_delta32"), p.Progress, p.Logger)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ CCR5-Δ32 proof successfully generated: deletion %s", traits.DeletionStatus(claim))
+
+	return proofData, nil
+}
+
+// Assign extracts the CCR5 genotype and builds the circuit and its assignment
+func (p *CCR5Proof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := assignGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("ccr5_delta32"), p.Traits.Claims("ccr5_delta32"), p.Progress, p.Logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewGenotypeClaimCircuit(p.Traits.Variant("ccr5_delta32"), p.Traits.Claims("ccr5_delta32")), assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *CCR5Proof) Circuit() (frontend.Circuit, error) {
+	return NewGenotypeClaimCircuit(p.Traits.Variant("ccr5_delta32"), p.Traits.Claims("ccr5_delta32")), nil
+}
+
+func (p *CCR5Proof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "CCR5-Δ32", verifyingKeyPath, proofPath)
+}
+
+func (p *CCR5Proof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "CCR5-Δ32", proofData)
+}
@@ -0,0 +1,47 @@
+package proofs
+
+import (
+	"testing"
+)
+
+func TestCCR5Proof_GenerateWithIndelAndSNPAtSamePosition(t *testing.T) {
+	// A SNP record shares the deletion's anchor position and comes first, and
+	// the deletion is written with soft-masked bases
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+3	46414943	.	T	C	60	PASS	.	GT	0/0
+3	46414943	rs333	TACAGTCAGTATCAATTCTGGAAGAATTTCCAG	t	60	PASS	.	GT	0/1
+`)
+
+	proof := &CCR5Proof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
+
+func TestCCR5Proof_GenerateWithAlleleMismatch(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+3	46414943	.	T	C	60	PASS	.	GT	0/1
+`)
+
+	proof := &CCR5Proof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err == nil {
+		t.Errorf("Generate should return error when only a SNP is present at the position")
+	}
+	if proofData.Result != ProofFail {
+		t.Errorf("Expected ProofFail, got %s", proofData.Result.String())
+	}
+}
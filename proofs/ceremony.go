@@ -0,0 +1,232 @@
+package proofs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"slices"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/consensys/gnark/constraint"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Phase1 is the circuit-independent first phase of a trusted setup ceremony,
+// the powers of tau, in the encoding of gnark's mpcsetup package. Any number
+// of participants contribute to it in turn; the setup is secure if one of
+// them discards their randomness.
+type Phase1 = mpcsetup.Phase1
+
+// Phase2 is the circuit-specific second phase of a trusted setup ceremony,
+// started from a finished Phase1 and contributed to like it
+type Phase2 = mpcsetup.Phase2
+
+// NewPhase1 returns the initial state of a phase 1 holding 2^power powers of
+// tau. It holds no randomness, so a ceremony needs at least one contribution.
+func NewPhase1(power int) *Phase1 {
+	phase1 := mpcsetup.InitPhase1(power)
+	return &phase1
+}
+
+// Phase1Power returns the power of tau of phase1: it holds 2^power powers
+func Phase1Power(phase1 *Phase1) int {
+	return bits.Len(uint(len(phase1.Parameters.G2.Tau))) - 1
+}
+
+// CeremonyPower returns the power of tau a ceremony for circuit needs. A
+// larger phase 1, such as one from a public Powers of Tau ceremony, is cut
+// down to it.
+func CeremonyPower(circuit frontend.Circuit, logger Logger) (int, error) {
+	r1cs, _, err := compileCeremonyCircuit(loggerOrNop(logger), circuit)
+	if err != nil {
+		return 0, err
+	}
+	return circuitPower(r1cs), nil
+}
+
+// VerifyPhase1 checks each phase 1 contribution in chain against the one
+// before it. The first is the state the ceremony started from.
+func VerifyPhase1(chain ...*Phase1) error {
+	if len(chain) < 2 {
+		return errors.New("verifying a ceremony requires its initial state and at least one contribution")
+	}
+	if err := mpcsetup.VerifyPhase1(chain[0], chain[1], chain[2:]...); err != nil {
+		return fmt.Errorf("invalid phase 1 contribution: %w", err)
+	}
+	return nil
+}
+
+// VerifyPhase2 checks each phase 2 contribution in chain against the one
+// before it. The first is the state StartPhase2 returned.
+func VerifyPhase2(chain ...*Phase2) error {
+	if len(chain) < 2 {
+		return errors.New("verifying a ceremony requires its initial state and at least one contribution")
+	}
+	if err := mpcsetup.VerifyPhase2(chain[0], chain[1], chain[2:]...); err != nil {
+		return fmt.Errorf("invalid phase 2 contribution: %w", err)
+	}
+	return nil
+}
+
+// StartPhase2 starts the phase 2 of circuit from phase1, which must have
+// been contributed to. It returns the initial state participants contribute
+// to and the circuit the keys will be stored under. Messages go to logger,
+// which may be nil.
+func StartPhase2(circuit frontend.Circuit, phase1 *Phase1, logger Logger) (*Phase2, CircuitKey, error) {
+	r1cs, key, err := compileCeremonyCircuit(loggerOrNop(logger), circuit)
+	if err != nil {
+		return nil, CircuitKey{}, err
+	}
+	srs1, err := phase1ForCircuit(phase1, r1cs)
+	if err != nil {
+		return nil, CircuitKey{}, err
+	}
+	phase2, _ := mpcsetup.InitPhase2(r1cs, srs1)
+	return &phase2, key, nil
+}
+
+// FinalizeCeremony derives the Groth16 keys of circuit from the ceremony's
+// final phase1 and the chain of phase 2 states, from the one StartPhase2
+// returned to the last contribution, and stores them in keys. The chain is
+// verified, and its first state must be the one StartPhase2 derives from
+// circuit and phase1. It returns the circuit the keys are stored under.
+// Messages go to logger, which may be nil.
+func FinalizeCeremony(circuit frontend.Circuit, phase1 *Phase1, phase2 []*Phase2, keys KeyStore, logger Logger) (CircuitKey, error) {
+	log := loggerOrNop(logger)
+	if keys == nil {
+		return CircuitKey{}, errors.New("no key store configured")
+	}
+	r1cs, key, err := compileCeremonyCircuit(log, circuit)
+	if err != nil {
+		return CircuitKey{}, err
+	}
+	srs1, err := phase1ForCircuit(phase1, r1cs)
+	if err != nil {
+		return CircuitKey{}, err
+	}
+	if err := VerifyPhase2(phase2...); err != nil {
+		return CircuitKey{}, err
+	}
+
+	log.Infof("Deriving keys for circuit %s from %d phase 2 contributions...", key, len(phase2)-1)
+	initial, evals := mpcsetup.InitPhase2(r1cs, srs1)
+	if !samePhase2Parameters(&initial, phase2[0]) {
+		return CircuitKey{}, fmt.Errorf("phase 2 was not started from this phase 1 for circuit %s", key)
+	}
+	pk, vk := mpcsetup.ExtractKeys(srs1, phase2[len(phase2)-1], &evals, r1cs.GetNbConstraints())
+	// The constant wire is a public variable but not a public input
+	if vk.NbPublicWitness() != r1cs.GetNbPublicVariables()-1 {
+		return CircuitKey{}, fmt.Errorf("ceremony keys take %d public inputs, the circuit has %d", vk.NbPublicWitness(), r1cs.GetNbPublicVariables()-1)
+	}
+	if err := keys.StoreKeys(key, &pk, &vk); err != nil {
+		return CircuitKey{}, fmt.Errorf("storing keys for circuit %s: %w", key, err)
+	}
+	log.Infof("Stored keys for circuit %s", key)
+	return key, nil
+}
+
+// compileCeremonyCircuit compiles circuit for a ceremony. Ceremony keys carry
+// no commitment keys, so circuits using commitments are refused.
+func compileCeremonyCircuit(log Logger, circuit frontend.Circuit) (*cs_bn254.R1CS, CircuitKey, error) {
+	if err := validatePublicInputLayout(circuit); err != nil {
+		return nil, CircuitKey{}, err
+	}
+	cs, key, err := compileConstraintSystem(log, nil, Groth16, circuit)
+	if err != nil {
+		return nil, CircuitKey{}, err
+	}
+	if commitments, ok := cs.GetCommitments().(constraint.Groth16Commitments); ok && len(commitments) > 0 {
+		return nil, CircuitKey{}, fmt.Errorf("circuit %s uses commitments, which ceremony keys do not support", key)
+	}
+	return cs.(*cs_bn254.R1CS), key, nil
+}
+
+// circuitPower returns the power of tau of the Groth16 domain of r1cs
+func circuitPower(r1cs *cs_bn254.R1CS) int {
+	domain := fft.NewDomain(uint64(r1cs.GetNbConstraints()))
+	return bits.Len64(domain.Cardinality) - 1
+}
+
+// phase1ForCircuit returns phase1 cut down to the powers of tau r1cs needs.
+// Powers of tau are prefixes of larger ones, so a larger ceremony serves any
+// smaller circuit; a cut-down phase 1 no longer verifies against its
+// contributions, so verify it before.
+func phase1ForCircuit(phase1 *Phase1, r1cs *cs_bn254.R1CS) (*Phase1, error) {
+	tau := phase1.PublicKeys.Tau
+	if tau.SG.Equal(&tau.SXG) {
+		return nil, errors.New("phase 1 has no contributions; its keys would be insecure")
+	}
+	power, have := circuitPower(r1cs), Phase1Power(phase1)
+	if have < power {
+		return nil, fmt.Errorf("phase 1 holds 2^%d powers of tau, the circuit needs 2^%d", have, power)
+	}
+	if have == power {
+		return phase1, nil
+	}
+	n := 1 << power
+	cut := *phase1
+	cut.Parameters.G1.Tau = phase1.Parameters.G1.Tau[:2*n-1]
+	cut.Parameters.G1.AlphaTau = phase1.Parameters.G1.AlphaTau[:n]
+	cut.Parameters.G1.BetaTau = phase1.Parameters.G1.BetaTau[:n]
+	cut.Parameters.G2.Tau = phase1.Parameters.G2.Tau[:n]
+	return &cut, nil
+}
+
+// samePhase2Parameters reports whether a and b hold the same parameters,
+// ignoring the public keys of their contributions
+func samePhase2Parameters(a, b *Phase2) bool {
+	equal := func(p, q curve.G1Affine) bool { return p.Equal(&q) }
+	return a.Parameters.G1.Delta.Equal(&b.Parameters.G1.Delta) &&
+		a.Parameters.G2.Delta.Equal(&b.Parameters.G2.Delta) &&
+		slices.EqualFunc(a.Parameters.G1.L, b.Parameters.G1.L, equal) &&
+		slices.EqualFunc(a.Parameters.G1.Z, b.Parameters.G1.Z, equal)
+}
+
+// ReadPhase1 reads a phase 1 state written by WritePhase1 or by gnark's
+// mpcsetup package
+func ReadPhase1(path string) (*Phase1, error) {
+	phase1 := new(Phase1)
+	if err := readCeremonyFile(path, phase1); err != nil {
+		return nil, err
+	}
+	return phase1, nil
+}
+
+// WritePhase1 writes phase1 to path
+func WritePhase1(path string, phase1 *Phase1) error {
+	return writeKeyFile(path, phase1.WriteTo)
+}
+
+// ReadPhase2 reads a phase 2 state written by WritePhase2 or by gnark's
+// mpcsetup package
+func ReadPhase2(path string) (*Phase2, error) {
+	phase2 := new(Phase2)
+	if err := readCeremonyFile(path, phase2); err != nil {
+		return nil, err
+	}
+	return phase2, nil
+}
+
+// WritePhase2 writes phase2 to path
+func WritePhase2(path string, phase2 *Phase2) error {
+	return writeKeyFile(path, phase2.WriteTo)
+}
+
+// readCeremonyFile decodes the ceremony state at path into state
+func readCeremonyFile(path string, state io.ReaderFrom) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := state.ReadFrom(bufio.NewReader(f)); err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package proofs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestCeremony_KeysProve(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	proof := &ALDH2Proof{}
+	circuit, err := ProofCircuit(proof, "")
+	if err != nil {
+		t.Fatalf("ProofCircuit should not return error: %v", err)
+	}
+	power, err := CeremonyPower(circuit, nil)
+	if err != nil {
+		t.Fatalf("CeremonyPower should not return error: %v", err)
+	}
+
+	// A larger phase 1 is cut down to the circuit
+	initial := NewPhase1(power + 1)
+	if _, _, err := StartPhase2(circuit, initial, nil); err == nil {
+		t.Error("Expected a phase 1 without contributions to be refused")
+	}
+	dir := t.TempDir()
+	if err := WritePhase1(filepath.Join(dir, "phase1"), initial); err != nil {
+		t.Fatalf("WritePhase1 should not return error: %v", err)
+	}
+	phase1, err := ReadPhase1(filepath.Join(dir, "phase1"))
+	if err != nil {
+		t.Fatalf("ReadPhase1 should not return error: %v", err)
+	}
+	phase1.Contribute()
+	if err := VerifyPhase1(initial, phase1); err != nil {
+		t.Fatalf("VerifyPhase1 should accept a contribution: %v", err)
+	}
+	if err := VerifyPhase1(phase1, initial); err == nil {
+		t.Error("Expected VerifyPhase1 to reject contributions out of order")
+	}
+
+	start, key, err := StartPhase2(circuit, phase1, nil)
+	if err != nil {
+		t.Fatalf("StartPhase2 should not return error: %v", err)
+	}
+	chain := []*Phase2{start}
+	for i := 0; i < 2; i++ {
+		path := filepath.Join(dir, "phase2")
+		if err := WritePhase2(path, chain[len(chain)-1]); err != nil {
+			t.Fatalf("WritePhase2 should not return error: %v", err)
+		}
+		next, err := ReadPhase2(path)
+		if err != nil {
+			t.Fatalf("ReadPhase2 should not return error: %v", err)
+		}
+		next.Contribute()
+		chain = append(chain, next)
+	}
+	if err := VerifyPhase2(chain...); err != nil {
+		t.Fatalf("VerifyPhase2 should accept the contributions: %v", err)
+	}
+
+	keys := &FileKeyStore{Dir: t.TempDir()}
+	if _, err := FinalizeCeremony(circuit, phase1, chain[1:], keys, nil); err == nil {
+		t.Error("Expected a phase 2 chain not started from phase 1 to be refused")
+	}
+	finalized, err := FinalizeCeremony(circuit, phase1, chain, keys, nil)
+	if err != nil {
+		t.Fatalf("FinalizeCeremony should not return error: %v", err)
+	}
+	if finalized != key {
+		t.Errorf("Expected keys stored under %s, got %s", key, finalized)
+	}
+
+	proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Keys: keys})
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Proof made with ceremony keys should verify, got %v, %v", result, err)
+	}
+}
@@ -2,16 +2,11 @@ package proofs
 
 import (
 	"fmt"
-	"os"
-	"strconv"
-	"strings"
+	"math"
+	"slices"
 
-	"github.com/brentp/vcfgo"
-	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark/backend/groth16"
-	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
 )
 
 // bytesWriter implements io.Writer for writing to a byte slice
@@ -24,6 +19,15 @@ func (w *bytesWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// DefaultTargetChromosome is the chromosome proven present when
+// ChromosomeProof.TargetChromosome is unset
+const DefaultTargetChromosome = 22
+
+// DefaultChromosomeSlots is the chromosome circuit size used when
+// ChromosomeProof.Slots is unset: one slot per chromosome code, so every
+// chromosome of any genome fits
+const DefaultChromosomeSlots = traits.ChromosomeMT
+
 // ChromosomeCircuit defines a minimal circuit that proves
 // a specific chromosome exists in the genome without revealing
 // other genomic information
@@ -31,305 +35,140 @@ type ChromosomeCircuit struct {
 	// Public input - the chromosome number we want to prove exists
 	TargetChromosome frontend.Variable `gnark:",public"`
 
-	// Private inputs - chromosome data from the VCF file
-	// We'll keep a fixed number for simplicity
-	Chromosome1 frontend.Variable
-	Chromosome2 frontend.Variable
-	Chromosome3 frontend.Variable
-	Chromosome4 frontend.Variable
-	Chromosome5 frontend.Variable
+	// Private inputs - the distinct chromosome codes found in the VCF,
+	// padded with zeros
+	Chromosomes []frontend.Variable
 }
 
-var circuit ChromosomeCircuit
+// NewChromosomeCircuit returns a chromosome circuit with n private slots
+func NewChromosomeCircuit(n int) *ChromosomeCircuit {
+	return &ChromosomeCircuit{Chromosomes: make([]frontend.Variable, n)}
+}
 
 // Define declares the circuit constraints
 func (circuit *ChromosomeCircuit) Define(api frontend.API) error {
 	// We want to prove that TargetChromosome exists in our dataset
 	// without revealing which position it was found at
 
-	// Check if chromosomes match the target by computing their differences
-	diff1 := api.Sub(circuit.Chromosome1, circuit.TargetChromosome)
-	diff2 := api.Sub(circuit.Chromosome2, circuit.TargetChromosome)
-	diff3 := api.Sub(circuit.Chromosome3, circuit.TargetChromosome)
-	diff4 := api.Sub(circuit.Chromosome4, circuit.TargetChromosome)
-	diff5 := api.Sub(circuit.Chromosome5, circuit.TargetChromosome)
+	// Padding slots are zero, so a zero target would match them
+	api.AssertIsDifferent(circuit.TargetChromosome, 0)
 
 	// If all diffs are non-zero, their product will be non-zero
-	product := api.Mul(diff1, diff2, diff3, diff4, diff5)
+	var product frontend.Variable = 1
+	for _, chrom := range circuit.Chromosomes {
+		product = api.Mul(product, api.Sub(chrom, circuit.TargetChromosome))
+	}
 	api.AssertIsEqual(product, 0)
 
 	return nil
 }
 
-func extractChromosomeNumbers(vcfPath string, maxCount int) ([]int, error) {
-	f, err := os.Open(vcfPath)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-
-	rdr, err := vcfgo.NewReader(f, false)
+// distinctChromosomes returns the distinct chromosome codes of the records in
+// source, in order of first appearance. Unplaced contigs are skipped.
+func distinctChromosomes(source GenomeSource) ([]int, error) {
+	var chromosomes []int
+	err := source.IterateRegion("", 0, math.MaxUint64, func(call *VariantCall) bool {
+		code := traits.ChromosomeCode(call.Chromosome)
+		if code != 0 && !slices.Contains(chromosomes, code) {
+			chromosomes = append(chromosomes, code)
+		}
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	chromosomes := make([]int, 0, maxCount)
-	count := 0
-
-	for {
-		variant := rdr.Read()
-		if variant == nil {
-			break
-		}
-
-		chrStr := variant.Chromosome
-		chrStr = strings.TrimPrefix(chrStr, "chr")
-
-		chrNum, err := strconv.Atoi(chrStr)
-		if err == nil {
-			chromosomes = append(chromosomes, chrNum)
-			count++
-		}
-
-		if count >= maxCount {
-			break
-		}
-	}
-
 	return chromosomes, nil
 }
 
-func (p ChromosomeProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
-	fmt.Println("Reading VCF file...")
-	chromosomes, err := extractChromosomeNumbers(vcfPath, 10)
-	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("error reading VCF: %w", err)
-	}
-
-	if len(chromosomes) == 0 {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("no valid chromosome entries found in the VCF file")
-	}
-
-	fmt.Printf("Found %d chromosome entries: %v\n", len(chromosomes), chromosomes)
-
-	// For demonstration, let's prove chromosome 22 exists in our data
-	targetChromosome := 22
-
-	fmt.Println("Compiling circuit...")
-	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
-	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("circuit compilation error: %w", err)
+// slots returns the configured circuit size
+func (p *ChromosomeProof) slots() int {
+	if p.Slots > 0 {
+		return p.Slots
 	}
+	return DefaultChromosomeSlots
+}
 
-	// Setup proving system in memory (no file writing)
-	fmt.Println("Setting up proving system...")
-	pk, vk, err := groth16.Setup(cs)
+// Assign scans the genome and builds the circuit and its assignment
+func (p *ChromosomeProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, err := p.assign(vcfPath)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("setup error: %w", err)
+		return nil, nil, err
 	}
+	return NewChromosomeCircuit(p.slots()), assignment, nil
+}
 
-	fmt.Println("Creating witness...")
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *ChromosomeProof) Circuit() (frontend.Circuit, error) {
+	return NewChromosomeCircuit(p.slots()), nil
+}
 
-	// Pad chromosomes to 5 items (our fixed circuit size)
-	paddedChromosomes := make([]int, 5)
-	for i := 0; i < 5; i++ {
-		if i < len(chromosomes) {
-			paddedChromosomes[i] = chromosomes[i]
-		} else {
-			paddedChromosomes[i] = 0 // Default value for padding
-		}
+func (p *ChromosomeProof) assign(vcfPath string) (*ChromosomeCircuit, error) {
+	log := loggerOrNop(p.Logger)
+	targetChromosome := p.TargetChromosome
+	if targetChromosome == 0 {
+		targetChromosome = DefaultTargetChromosome
 	}
-
-	witness := &ChromosomeCircuit{
-		TargetChromosome: targetChromosome,
-		Chromosome1:      paddedChromosomes[0],
-		Chromosome2:      paddedChromosomes[1],
-		Chromosome3:      paddedChromosomes[2],
-		Chromosome4:      paddedChromosomes[3],
-		Chromosome5:      paddedChromosomes[4],
+	if targetChromosome < 1 || targetChromosome > traits.ChromosomeMT {
+		return nil, fmt.Errorf("invalid target chromosome %d", targetChromosome)
 	}
 
-	w, err := frontend.NewWitness(witness, ecc.BN254.ScalarField())
+	log.Infof("Reading VCF file...")
+	source, err := sourceOrVCF(p.Source, vcfPath, "", p.Progress)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("witness creation error: %w", err)
+		return nil, fmt.Errorf("error reading VCF: %w", err)
 	}
-
-	publicWitness, err := w.Public()
+	chromosomes, err := distinctChromosomes(source)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("public witness error: %w", err)
+		return nil, fmt.Errorf("error reading VCF: %w", err)
+	}
+	if len(chromosomes) == 0 {
+		return nil, fmt.Errorf("no valid chromosome entries found in the VCF file")
+	}
+	if !slices.Contains(chromosomes, targetChromosome) {
+		return nil, fmt.Errorf("chromosome %d not found in the VCF file", targetChromosome)
 	}
 
-	fmt.Println("Generating proof...")
-	proof, err := groth16.Prove(cs, pk, w)
-	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("proving error: %w", err)
+	// Only membership is proven, so when the genome has more chromosomes
+	// than slots it is enough to keep the target
+	n := p.slots()
+	if len(chromosomes) > n {
+		chromosomes = []int{targetChromosome}
 	}
 
-	// Serialize proof data to bytes (no file writing)
-	var proofBytes []byte
-	{
-		proofBuf := make([]byte, 0)
-		proofWriter := &bytesWriter{data: &proofBuf}
-		_, err = proof.WriteTo(proofWriter)
-		if err != nil {
-			return &ProofData{
-				Proof:         nil,
-				VerifyingKey:  nil,
-				PublicWitness: nil,
-				Result:        ProofFail,
-			}, fmt.Errorf("serializing proof: %w", err)
+	assignment := NewChromosomeCircuit(n)
+	assignment.TargetChromosome = targetChromosome
+	for i := range assignment.Chromosomes {
+		assignment.Chromosomes[i] = 0 // Default value for padding
+		if i < len(chromosomes) {
+			assignment.Chromosomes[i] = chromosomes[i]
 		}
-		proofBytes = proofBuf
 	}
+	return assignment, nil
+}
 
-	// Serialize verifying key to bytes
-	var vkBytes []byte
-	{
-		vkBuf := make([]byte, 0)
-		vkWriter := &bytesWriter{data: &vkBuf}
-		_, err = vk.WriteTo(vkWriter)
-		if err != nil {
-			return &ProofData{
-				Proof:         nil,
-				VerifyingKey:  nil,
-				PublicWitness: nil,
-				Result:        ProofFail,
-			}, fmt.Errorf("serializing verifying key: %w", err)
-		}
-		vkBytes = vkBuf
+func (p *ChromosomeProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	log := loggerOrNop(p.Logger)
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
 	}
 
-	// Serialize public witness to bytes
-	publicWitnessData, err := publicWitness.MarshalBinary()
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewChromosomeCircuit(p.slots()), assignment)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("serializing public witness: %w", err)
+		return proofData, err
 	}
 
-	fmt.Println("✅ Proof successfully generated!")
-	fmt.Printf("We have proven knowledge of chromosome %d's presence in the genomic data\n", targetChromosome)
-	fmt.Println("without revealing which entries contain this chromosome or any other genomic information.")
+	log.Infof("✅ Proof successfully generated!")
+	log.Infof("We have proven knowledge of chromosome %v's presence in the genomic data", assignment.TargetChromosome)
+	log.Infof("without revealing which entries contain this chromosome or any other genomic information.")
 
-	return &ProofData{
-		Proof:         proofBytes,
-		VerifyingKey:  vkBytes,
-		PublicWitness: publicWitnessData,
-		Result:        ProofSuccess,
-	}, nil
+	return proofData, nil
 }
 
-func (*ChromosomeProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
-	// For chromosome proof, we now expect ProofData to be provided directly
-	// This is a simplified implementation that always returns success for demonstration
-	fmt.Println("Verifying chromosome proof...")
-	fmt.Println("✅ Chromosome proof successfully verified!")
-	
-	return &VerificationResult{
-		Result: ProofSuccess,
-		Error:  nil,
-	}, nil
+func (p *ChromosomeProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "chromosome", verifyingKeyPath, proofPath)
 }
 
-func (*ChromosomeProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
-	// Verify chromosome proof directly from ProofData using gnark
-	
-	if len(proofData.Proof) == 0 || len(proofData.VerifyingKey) == 0 {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("invalid proof data: missing proof or verifying key"),
-		}, nil
-	}
-	
-	fmt.Println("Verifying chromosome proof from ProofData...")
-	
-	// Deserialize the verifying key
-	vk := groth16.NewVerifyingKey(ecc.BN254)
-	_, err := vk.ReadFrom(strings.NewReader(string(proofData.VerifyingKey)))
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize verifying key: %w", err),
-		}, nil
-	}
-	
-	// Deserialize the proof
-	proof := groth16.NewProof(ecc.BN254)
-	_, err = proof.ReadFrom(strings.NewReader(string(proofData.Proof)))
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize proof: %w", err),
-		}, nil
-	}
-	
-	// Deserialize the public witness
-	publicWitness, err := witness.New(ecc.BN254.ScalarField())
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to create witness: %w", err),
-		}, nil
-	}
-	err = publicWitness.UnmarshalBinary(proofData.PublicWitness)
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize public witness: %w", err),
-		}, nil
-	}
-	
-	// Perform gnark verification
-	err = groth16.Verify(proof, vk, publicWitness)
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("proof verification failed: %w", err),
-		}, nil
-	}
-	
-	fmt.Println("✅ Chromosome proof successfully verified!")
-	
-	return &VerificationResult{
-		Result: ProofSuccess,
-		Error:  nil,
-	}, nil
+func (p *ChromosomeProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "chromosome", proofData)
 }
\ No newline at end of file
@@ -0,0 +1,122 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+const chromosomeTestVCF = `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+chr1	1000	.	A	G	60	PASS	.	GT	0/1
+chr1	2000	.	C	T	60	PASS	.	GT	0/1
+chr7	3000	.	G	A	60	PASS	.	GT	1/1
+chrUn_gl000220	100	.	G	A	60	PASS	.	GT	0/1
+chr22	4000	.	T	C	60	PASS	.	GT	0/1
+chrX	5000	.	A	C	60	PASS	.	GT	1/1
+`
+
+func TestChromosomeCircuit(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      int
+		chromosomes []int
+		solved      bool
+	}{
+		{"present", 22, []int{1, 7, 22}, true},
+		{"absent", 22, []int{1, 7, 0}, false},
+		{"zero target matches padding", 0, []int{1, 7, 0}, false},
+	}
+
+	for _, tc := range tests {
+		assignment := NewChromosomeCircuit(len(tc.chromosomes))
+		assignment.TargetChromosome = tc.target
+		for i, chrom := range tc.chromosomes {
+			assignment.Chromosomes[i] = chrom
+		}
+		err := test.IsSolved(NewChromosomeCircuit(len(tc.chromosomes)), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("%s: expected circuit to be solved: %v", tc.name, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("%s: expected circuit not to be solved", tc.name)
+		}
+	}
+}
+
+func TestChromosomeProof_Assign(t *testing.T) {
+	vcfPath := writeTestVCF(t, chromosomeTestVCF)
+
+	proof := &ChromosomeProof{}
+	assignment, err := proof.assign(vcfPath)
+	if err != nil {
+		t.Fatalf("assign should not return error: %v", err)
+	}
+	if len(assignment.Chromosomes) != DefaultChromosomeSlots {
+		t.Errorf("Expected %d slots, got %d", DefaultChromosomeSlots, len(assignment.Chromosomes))
+	}
+	for i, want := range []int{1, 7, 22, 23, 0} {
+		if assignment.Chromosomes[i] != want {
+			t.Errorf("Slot %d: expected %d, got %v", i, want, assignment.Chromosomes[i])
+		}
+	}
+
+	// More chromosomes than slots still proves the target
+	proof.Slots = 2
+	assignment, err = proof.assign(vcfPath)
+	if err != nil {
+		t.Fatalf("assign should not return error: %v", err)
+	}
+	err = test.IsSolved(NewChromosomeCircuit(2), assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Errorf("Expected two-slot circuit to be solved: %v", err)
+	}
+}
+
+func TestChromosomeProof_TargetChromosome(t *testing.T) {
+	vcfPath := writeTestVCF(t, chromosomeTestVCF)
+
+	proof := &ChromosomeProof{TargetChromosome: 23, Slots: 4}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if err := CheckPublicValues(proofData, []PublicValue{{Name: "TargetChromosome", Value: "23"}}); err != nil {
+		t.Errorf("Expected the proof to disclose chromosome X: %v", err)
+	}
+
+	proof.TargetChromosome = 2
+	if _, err := proof.assign(vcfPath); err == nil {
+		t.Error("Expected an absent chromosome to be refused")
+	}
+}
+
+func TestChromosomeProof_SexAndMitochondrial(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+X	5000	.	A	C	60	PASS	.	GT	1/1
+Y	2800000	.	G	T	60	PASS	.	GT	1
+MT	16519	.	T	C	60	PASS	.	GT	1
+chrM	73	.	A	G	60	PASS	.	GT	1
+`)
+
+	proof := &ChromosomeProof{Slots: 4}
+	for _, target := range []int{23, 24, 25} {
+		proof.TargetChromosome = target
+		assignment, err := proof.assign(vcfPath)
+		if err != nil {
+			t.Fatalf("chromosome %d: assign should not return error: %v", target, err)
+		}
+		for i, want := range []int{23, 24, 25, 0} {
+			if assignment.Chromosomes[i] != want {
+				t.Errorf("Slot %d: expected %d, got %v", i, want, assignment.Chromosomes[i])
+			}
+		}
+		if err := test.IsSolved(NewChromosomeCircuit(4), assignment, ecc.BN254.ScalarField()); err != nil {
+			t.Errorf("chromosome %d: expected circuit to be solved: %v", target, err)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package proofs
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+)
+
+// DefaultCircuitCacheSize is the number of circuits a CircuitCache created
+// without a capacity holds
+const DefaultCircuitCacheSize = 16
+
+// CircuitCacheStats counts the lookups of a CircuitCache
+type CircuitCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Capacity  int
+}
+
+// CircuitCache keeps compiled circuits and their keys in memory, so repeated
+// proofs of a circuit in a long-running process skip compilation and setup.
+// Circuits are keyed by circuit ID, configuration and backend; the least recently used
+// circuit is evicted when the cache is full. A CircuitCache is safe for
+// concurrent use.
+type CircuitCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	// order holds the cached circuits, most recently used first
+	order *list.List
+	stats CircuitCacheStats
+}
+
+// cacheEntry is a circuit held in a CircuitCache
+type cacheEntry struct {
+	fingerprint string
+	compiled    *compiledCircuit
+}
+
+// compiledCircuit is a compiled circuit with its keys
+type compiledCircuit struct {
+	key CircuitKey
+	cs  constraint.ConstraintSystem
+	pk  ProvingKey
+	vk  VerifyingKey
+	// keys is the key store the keys were taken from, nil for a fresh setup
+	keys KeyStore
+}
+
+// NewCircuitCache creates a cache holding up to capacity circuits, or
+// DefaultCircuitCacheSize if capacity is not positive. Proving keys are large,
+// so size the cache to the circuits the process proves most.
+func NewCircuitCache(capacity int) *CircuitCache {
+	if capacity <= 0 {
+		capacity = DefaultCircuitCacheSize
+	}
+	return &CircuitCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Stats returns the lookups counted so far and the circuits held
+func (c *CircuitCache) Stats() CircuitCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	stats.Capacity = c.capacity
+	return stats
+}
+
+// Circuits returns the circuits held, most recently used first
+func (c *CircuitCache) Circuits() []CircuitKey {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]CircuitKey, 0, c.order.Len())
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(*cacheEntry).compiled.key)
+	}
+	return keys
+}
+
+// Warm compiles circuit for backend, Groth16 if nil, and sets up its keys,
+// taken from keys if it is set, unless the cache already holds it. Messages go
+// to logger, which may be nil.
+func (c *CircuitCache) Warm(circuit frontend.Circuit, backend Backend, keys KeyStore, logger Logger) (CircuitKey, error) {
+	if err := validatePublicInputLayout(circuit); err != nil {
+		return CircuitKey{}, err
+	}
+	compiled, err := compileCircuit(loggerOrNop(logger), nil, backendOrDefault(backend), keys, c, circuit)
+	if err != nil {
+		return CircuitKey{}, err
+	}
+	return compiled.key, nil
+}
+
+func (c *CircuitCache) get(fingerprint string) *compiledCircuit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[fingerprint]
+	if !ok {
+		c.stats.Misses++
+		return nil
+	}
+	c.stats.Hits++
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).compiled
+}
+
+func (c *CircuitCache) put(fingerprint string, compiled *compiledCircuit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[fingerprint]; ok {
+		e.Value.(*cacheEntry).compiled = compiled
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[fingerprint] = c.order.PushFront(&cacheEntry{fingerprint: fingerprint, compiled: compiled})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).fingerprint)
+		c.stats.Evictions++
+	}
+}
+
+// compileCircuit compiles circuit for backend and sets up its keys. A circuit
+// held in cache is reused, unless it was set up from another key store than
+// keys; otherwise the keys are taken from keys, or from a fresh setup if it is
+// nil.
+func compileCircuit(log Logger, progress ProgressReporter, backend Backend, keys KeyStore, cache *CircuitCache, circuit frontend.Circuit) (*compiledCircuit, error) {
+	var fingerprint string
+	if cache != nil {
+		fingerprint = backend.Name() + ":" + backend.Curve().String() + ":" + circuitFingerprint(circuit)
+		if cached := cache.get(fingerprint); cached != nil {
+			if keys == nil || sameKeyStore(cached.keys, keys) {
+				log.Infof("Using cached circuit %s", cached.key)
+				return cached, nil
+			}
+			pk, vk, err := circuitKeys(log, progress, backend, keys, cached.cs, cached.key)
+			if err != nil {
+				return nil, err
+			}
+			compiled := &compiledCircuit{key: cached.key, cs: cached.cs, pk: pk, vk: vk, keys: keys}
+			cache.put(fingerprint, compiled)
+			return compiled, nil
+		}
+	}
+
+	cs, key, err := compileConstraintSystem(log, progress, backend, circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, vk, err := circuitKeys(log, progress, backend, keys, cs, key)
+	if err != nil {
+		return nil, err
+	}
+	compiled := &compiledCircuit{key: key, cs: cs, pk: pk, vk: vk, keys: keys}
+	if cache != nil {
+		cache.put(fingerprint, compiled)
+	}
+	return compiled, nil
+}
+
+// compileConstraintSystem compiles circuit for backend, which must declare its
+// public input layout, returning the constraint system and the key
+// identifying it
+func compileConstraintSystem(log Logger, progress ProgressReporter, backend Backend, circuit frontend.Circuit) (constraint.ConstraintSystem, CircuitKey, error) {
+	log.Infof("Compiling circuit...")
+	done := startStage(progress, "compile", "compiling circuit")
+	cs, err := backend.Compile(circuit)
+	done()
+	if err != nil {
+		return nil, CircuitKey{}, fmt.Errorf("circuit compilation error: %w", err)
+	}
+
+	circuitHash, err := constraintSystemHash(cs)
+	if err != nil {
+		return nil, CircuitKey{}, err
+	}
+	layout := circuit.(LayoutCircuit).PublicInputLayout()
+	key := CircuitKey{ID: layout.CircuitID, Version: layout.Version, Hash: circuitHash}
+	if backend.Name() != BackendGroth16 {
+		key.Backend = backend.Name()
+	}
+	if curve := backend.Curve(); curve != ecc.BN254 {
+		key.Curve = curve.String()
+	}
+	return cs, key, nil
+}
+
+// sameKeyStore reports whether a and b are the same key store
+func sameKeyStore(a, b KeyStore) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// circuitFingerprint identifies the configuration of circuit without
+// compiling it: its circuit ID and version, and every field of the circuit
+// value, unexported configuration included. Circuits that compile to
+// different constraint systems have different fingerprints.
+func circuitFingerprint(circuit frontend.Circuit) string {
+	h := sha256.New()
+	if layoutCircuit, ok := circuit.(LayoutCircuit); ok {
+		layout := layoutCircuit.PublicInputLayout()
+		fmt.Fprintf(h, "%s-v%d;", layout.CircuitID, layout.Version)
+	}
+	writeFingerprint(h, reflect.ValueOf(circuit))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeFingerprint writes the type and contents of v to w, following pointers
+// and interfaces
+func writeFingerprint(w io.Writer, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		io.WriteString(w, "nil;")
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			io.WriteString(w, "nil;")
+			return
+		}
+		fmt.Fprintf(w, "%s:", v.Elem().Type())
+		writeFingerprint(w, v.Elem())
+	case reflect.Struct:
+		fmt.Fprintf(w, "%s{", v.Type())
+		for i := 0; i < v.NumField(); i++ {
+			writeFingerprint(w, v.Field(i))
+		}
+		io.WriteString(w, "}")
+	case reflect.Slice, reflect.Array:
+		fmt.Fprintf(w, "[%d:", v.Len())
+		for i := 0; i < v.Len(); i++ {
+			writeFingerprint(w, v.Index(i))
+		}
+		io.WriteString(w, "]")
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		fmt.Fprintf(w, "map%d{", len(keys))
+		for _, key := range keys {
+			writeFingerprint(w, key)
+			writeFingerprint(w, v.MapIndex(key))
+		}
+		io.WriteString(w, "}")
+	default:
+		fmt.Fprintf(w, "%q;", fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,62 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestCircuitCache_SkipsCompileAndSetup(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	cache := NewCircuitCache(1)
+	proof := &ALDH2Proof{}
+
+	var verifyingKeys [][]byte
+	for i := 0; i < 2; i++ {
+		proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Cache: cache})
+		if err != nil {
+			t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+		}
+		result, err := proof.VerifyProofData(proofData)
+		if err != nil || result.Result != ProofSuccess {
+			t.Errorf("Proof %d generated from the cache should verify, got %v, %v", i, result, err)
+		}
+		verifyingKeys = append(verifyingKeys, proofData.VerifyingKey)
+	}
+	if !bytes.Equal(verifyingKeys[0], verifyingKeys[1]) {
+		t.Error("Expected proofs of a cached circuit to share its verifying key")
+	}
+	stats := cache.Stats()
+	if stats.Misses != 1 || stats.Hits != 1 || stats.Entries != 1 {
+		t.Errorf("Expected one miss then one hit, got %+v", stats)
+	}
+
+	// Seeded generation neither reads nor fills the cache
+	if _, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Seed: []byte("seed"), Cache: cache}); err != nil {
+		t.Fatalf("Seeded generation should not return error: %v", err)
+	}
+	if after := cache.Stats(); after != stats {
+		t.Errorf("Expected seeded generation to bypass the cache, got %+v", after)
+	}
+
+	// Another variant shares the circuit ID but not the circuit, and evicts it
+	actn3, err := (&ACTN3Proof{}).Circuit()
+	if err != nil {
+		t.Fatalf("Circuit should not return error: %v", err)
+	}
+	key, err := cache.Warm(actn3, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Warm should not return error: %v", err)
+	}
+	stats = cache.Stats()
+	if stats.Misses != 2 || stats.Evictions != 1 {
+		t.Errorf("Expected the ACTN3 circuit to miss and evict the ALDH2 circuit, got %+v", stats)
+	}
+	if circuits := cache.Circuits(); len(circuits) != 1 || circuits[0] != key {
+		t.Errorf("Expected the cache to hold only %s, got %v", key, circuits)
+	}
+}
@@ -0,0 +1,69 @@
+package proofs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClaimMode selects the public statement a dynamic proof makes about the
+// genotype. Only ClaimExactGenotype discloses the genotype itself.
+type ClaimMode int
+
+const (
+	// ClaimExactGenotype discloses the ALT dosage (0, 1 or 2)
+	ClaimExactGenotype ClaimMode = iota
+	// ClaimHeterozygous states that exactly one ALT allele is carried
+	ClaimHeterozygous
+	// ClaimHomozygousAlt states that two ALT alleles are carried
+	ClaimHomozygousAlt
+	// ClaimCarrier states that at least one ALT allele is carried, without
+	// revealing which of the two
+	ClaimCarrier
+)
+
+var claimModeNames = []string{"exact", "heterozygous", "homozygous_alt", "carrier"}
+
+func (m ClaimMode) String() string {
+	if m < 0 || int(m) >= len(claimModeNames) {
+		return fmt.Sprintf("ClaimMode(%d)", int(m))
+	}
+	return claimModeNames[m]
+}
+
+// ParseClaimMode parses a claim mode name as returned by ClaimMode.String
+func ParseClaimMode(name string) (ClaimMode, error) {
+	for i, modeName := range claimModeNames {
+		if strings.EqualFold(name, modeName) {
+			return ClaimMode(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown claim mode %q (expected one of %s)", name, strings.Join(claimModeNames, ", "))
+}
+
+// Holds reports whether the statement holds for an ALT dosage
+func (m ClaimMode) Holds(genotype int) bool {
+	switch m {
+	case ClaimExactGenotype:
+		return genotype >= 0 && genotype <= 2
+	case ClaimHeterozygous:
+		return genotype == 1
+	case ClaimHomozygousAlt:
+		return genotype == 2
+	case ClaimCarrier:
+		return genotype == 1 || genotype == 2
+	}
+	return false
+}
+
+func (m ClaimMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *ClaimMode) UnmarshalText(text []byte) error {
+	mode, err := ParseClaimMode(string(text))
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
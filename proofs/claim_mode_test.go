@@ -0,0 +1,84 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+func TestDynamicCircuit_ClaimModes(t *testing.T) {
+	tests := []struct {
+		mode     ClaimMode
+		claimed  int
+		genotype int
+		solved   bool
+	}{
+		{ClaimExactGenotype, 1, 1, true},
+		{ClaimExactGenotype, 2, 1, false},
+		{ClaimHeterozygous, 0, 1, true},
+		{ClaimHeterozygous, 0, 2, false},
+		{ClaimHomozygousAlt, 0, 2, true},
+		{ClaimHomozygousAlt, 0, 1, false},
+		{ClaimCarrier, 0, 1, true},
+		{ClaimCarrier, 0, 2, true},
+		{ClaimCarrier, 0, 0, false},
+		// Non-exact claims must not disclose the genotype
+		{ClaimCarrier, 2, 2, false},
+		{ClaimMode(4), 0, 1, false},
+	}
+
+	locus := testLocus(t, 15, 28365618, "A", "G")
+	for _, tc := range tests {
+		assignment := &DynamicCircuit{
+			ClaimedGenotype: tc.claimed,
+			ClaimMode:       int(tc.mode),
+			ActualGenotype:  tc.genotype,
+		}
+		locus.assignTo(assignment)
+		setTestAlleles(assignment, tc.genotype)
+		err := test.IsSolved(&DynamicCircuit{}, assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("%s claim %d with genotype %d: expected circuit to be solved: %v", tc.mode, tc.claimed, tc.genotype, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("%s claim %d with genotype %d: expected circuit not to be solved", tc.mode, tc.claimed, tc.genotype)
+		}
+	}
+}
+
+func TestDynamicProof_CarrierMode(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+15	28365618	rs12913832	A	G	60	PASS	.	GT	1/1
+`)
+
+	proof := NewDynamicProof(28365618, "A", "G")
+	proof.Mode = ClaimCarrier
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	expected := []PublicValue{{Name: "ClaimedGenotype", Value: "0"}, {Name: "ClaimMode", Value: "3"}}
+	if err := CheckPublicValues(proofData, expected); err != nil {
+		t.Errorf("Expected a carrier claim hiding the genotype: %v", err)
+	}
+
+	proof.Mode = ClaimHeterozygous
+	if _, err := proof.assign(vcfPath, proof.Position, proof.Reference, proof.Alternate); err == nil {
+		t.Error("Expected a heterozygous claim to be refused for a homozygous genotype")
+	}
+}
+
+func TestParseClaimMode(t *testing.T) {
+	for _, mode := range []ClaimMode{ClaimExactGenotype, ClaimHeterozygous, ClaimHomozygousAlt, ClaimCarrier} {
+		parsed, err := ParseClaimMode(mode.String())
+		if err != nil || parsed != mode {
+			t.Errorf("Expected %s to round-trip, got %v (%v)", mode, parsed, err)
+		}
+	}
+	if _, err := ParseClaimMode("dominant"); err == nil {
+		t.Error("Expected an unknown mode to be rejected")
+	}
+}
@@ -0,0 +1,250 @@
+package proofs
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs/gadgets"
+)
+
+// CohortCircuit proves that at least MinCarrierPercent of a cohort carries the
+// ALT allele at the locus of LocusHash. Each genome is represented only by its
+// member's commitment to their genotype at that locus, and the commitments by
+// CommitmentsHash, so no individual genotype is disclosed and a verifier holding
+// the commitments the members published can check which genomes were counted.
+type CohortCircuit struct {
+	MinCarrierPercent frontend.Variable `gnark:",public"`
+	LocusHash         frontend.Variable `gnark:",public"`
+	CommitmentsHash   frontend.Variable `gnark:",public"`
+
+	Commitments []frontend.Variable
+	Genotypes   []frontend.Variable
+	Salts       []frontend.Variable
+}
+
+// NewCohortCircuit allocates a cohort circuit sized for n genomes
+func NewCohortCircuit(n int) *CohortCircuit {
+	return &CohortCircuit{
+		Commitments: make([]frontend.Variable, n),
+		Genotypes:   make([]frontend.Variable, n),
+		Salts:       make([]frontend.Variable, n),
+	}
+}
+
+func (c *CohortCircuit) Define(api frontend.API) error {
+	if len(c.Genotypes) != len(c.Commitments) || len(c.Salts) != len(c.Commitments) {
+		return fmt.Errorf("cohort circuit slices must have equal length")
+	}
+
+	var carriers frontend.Variable = 0
+	for i := range c.Genotypes {
+		// Open the member's commitment to their genotype at this locus
+		h, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		h.Write(c.LocusHash, c.Genotypes[i], c.Salts[i])
+		api.AssertIsEqual(h.Sum(), c.Commitments[i])
+
+		g := c.Genotypes[i]
+		gadgets.AssertIsGenotype(api, g)
+
+		carriers = api.Add(carriers, api.Sub(1, api.IsZero(g)))
+	}
+
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.Commitments...)
+	api.AssertIsEqual(h.Sum(), c.CommitmentsHash)
+
+	// carriers / n >= percent / 100, rearranged to avoid division
+	api.AssertIsLessOrEqual(c.MinCarrierPercent, 100)
+	api.AssertIsLessOrEqual(api.Mul(c.MinCarrierPercent, len(c.Genotypes)), api.Mul(carriers, 100))
+
+	return nil
+}
+
+// CommitCohortGenotype returns MiMC(locusHash, genotype, salt), the commitment
+// a cohort member publishes to their genotype at the locus of locusHash and
+// a CohortProof over the cohort opens
+func CommitCohortGenotype(locusHash *big.Int, genotype int, salt *big.Int) (*big.Int, error) {
+	return mimcValues(ecc.BN254, []*big.Int{locusHash, big.NewInt(int64(genotype)), salt})
+}
+
+// CohortCommitmentsHash returns the MiMC hash of the members' commitments in
+// sample order, the CommitmentsHash a cohort proof over them discloses
+func CohortCommitmentsHash(commitments []*big.Int) (*big.Int, error) {
+	return mimcValues(ecc.BN254, commitments)
+}
+
+// assignCurve refuses curves other than BN254, the curve genotype
+// commitments are made over
+func (c *CohortCircuit) assignCurve(curve ecc.ID) error {
+	return fmt.Errorf("genotype commitments are over bn254, not %s", curve)
+}
+
+// NewCohortProof creates a CohortProof claiming that at least minCarrierPercent
+// of the samples in a multi-sample VCF carry alt at the given position
+func NewCohortProof(position uint64, reference string, alternate string, minCarrierPercent int) *CohortProof {
+	return &CohortProof{
+		Position:          position,
+		Reference:         reference,
+		Alternate:         alternate,
+		MinCarrierPercent: minCarrierPercent,
+	}
+}
+
+// Assign extracts the cohort genotypes and builds the circuit and its
+// assignment. When Salts is empty, fresh salts are drawn and stored on p with
+// the commitments they make.
+func (p *CohortProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewCohortCircuit(len(assignment.Genotypes)), assignment, nil
+}
+
+func (p *CohortProof) assign(vcfPath string) (*CohortCircuit, error) {
+	if p.MinCarrierPercent < 0 || p.MinCarrierPercent > 100 {
+		return nil, fmt.Errorf("carrier percentage must be between 0 and 100, got %d", p.MinCarrierPercent)
+	}
+
+	genotypes, err := p.extractCohortGenotypes(vcfPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract cohort genotypes: %w", err)
+	}
+
+	locusHash, err := LocusHash(p.Chromosome, p.Position, p.Reference, p.Alternate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.Salts) == 0 {
+		if len(p.Commitments) > 0 {
+			return nil, fmt.Errorf("opening the members' commitments requires their salts")
+		}
+		p.Salts = make([]*big.Int, len(genotypes))
+		for i := range p.Salts {
+			if p.Salts[i], err = randomSalt(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(p.Salts) != len(genotypes) {
+		return nil, fmt.Errorf("expected %d salts, got %d", len(genotypes), len(p.Salts))
+	}
+	if len(p.Commitments) > 0 && len(p.Commitments) != len(genotypes) {
+		return nil, fmt.Errorf("expected %d commitments, got %d", len(genotypes), len(p.Commitments))
+	}
+
+	carriers := 0
+	for _, g := range genotypes {
+		if g > 0 {
+			carriers++
+		}
+	}
+	if carriers*100 < p.MinCarrierPercent*len(genotypes) {
+		return nil, fmt.Errorf("cohort does not meet the claimed carrier percentage of %d%%", p.MinCarrierPercent)
+	}
+
+	assignment := NewCohortCircuit(len(genotypes))
+	assignment.MinCarrierPercent = p.MinCarrierPercent
+	assignment.LocusHash = locusHash
+	commitments := make([]*big.Int, len(genotypes))
+	for i, g := range genotypes {
+		commitments[i], err = CommitCohortGenotype(locusHash, g, p.Salts[i])
+		if err != nil {
+			return nil, err
+		}
+		if len(p.Commitments) > 0 && commitments[i].Cmp(p.Commitments[i]) != 0 {
+			return nil, fmt.Errorf("sample %d does not open its commitment at %d %s>%s", i, p.Position, p.Reference, p.Alternate)
+		}
+		assignment.Commitments[i] = commitments[i]
+		assignment.Genotypes[i] = g
+		assignment.Salts[i] = p.Salts[i]
+	}
+	p.Commitments = commitments
+	if assignment.CommitmentsHash, err = CohortCommitmentsHash(commitments); err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+// Generate proves the cohort claim over every sample in the VCF, opening
+// Commitments with Salts. When Salts is empty, fresh salts are drawn and
+// stored on p with the commitments they make.
+func (p *CohortProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	loggerOrNop(p.Logger).Infof("Generating cohort proof over %d genomes...", len(assignment.Genotypes))
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewCohortCircuit(len(assignment.Genotypes)), assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Cohort proof successfully generated: at least %d%% carry the alternate allele", p.MinCarrierPercent)
+
+	return proofData, nil
+}
+
+func (p *CohortProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "Cohort", verifyingKeyPath, proofPath)
+}
+
+func (p *CohortProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "Cohort", proofData)
+}
+
+// extractCohortGenotypes returns the genotype of every sample at p.Position,
+// on p.Chromosome if it is set
+func (p *CohortProof) extractCohortGenotypes(vcfPath string) ([]int, error) {
+	source, err := sourceOrVCF(p.Source, vcfPath, "", p.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	chrom := ""
+	if p.Chromosome > 0 {
+		chrom = strconv.Itoa(p.Chromosome)
+	}
+	calls, err := lookupAlleles(source, chrom, p.Position, p.Reference, p.Alternate)
+	if err != nil {
+		return nil, err
+	}
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("%w: position %d not found in VCF file", ErrVariantNotFound, p.Position)
+	}
+	call := calls[0]
+
+	if !allelesMatch(p.Reference, call.Reference) {
+		return nil, fmt.Errorf("reference mismatch: expected %s, found %s", p.Reference, call.Reference)
+	}
+	altIndex := alternateIndex(call, p.Alternate)
+	if altIndex == 0 {
+		return nil, fmt.Errorf("alternate mismatch: expected %s, found %v", p.Alternate, call.Alternate)
+	}
+	if len(call.Samples) == 0 {
+		return nil, fmt.Errorf("%w: VCF has no samples", ErrNoSampleData)
+	}
+
+	dp := NewDynamicProof(p.Position, p.Reference, p.Alternate)
+	genotypes := make([]int, len(call.Samples))
+	for i, sample := range call.Samples {
+		genotypes[i], err = dp.parseGenotypeFromInts(sample.GT, altIndex)
+		if err != nil {
+			return nil, fmt.Errorf("sample %d: %w", i, err)
+		}
+	}
+	return genotypes, nil
+}
@@ -0,0 +1,194 @@
+package proofs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+const cohortVCF = `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	S1	S2	S3	S4
+2	136608646	rs4988235	G	A	60	PASS	.	GT	0/1	0/0	1/1	0/0
+`
+
+// cohortAssignment assigns a cohort circuit opening commitments to genotypes
+// at the locus of locusHash
+func cohortAssignment(t *testing.T, locusHash *big.Int, genotypes []int, percent int) *CohortCircuit {
+	t.Helper()
+	assignment := NewCohortCircuit(len(genotypes))
+	assignment.MinCarrierPercent = percent
+	assignment.LocusHash = locusHash
+	commitments := make([]*big.Int, len(genotypes))
+	for i, g := range genotypes {
+		salt := big.NewInt(int64(1000 + i))
+		commitment, err := CommitCohortGenotype(locusHash, g, salt)
+		if err != nil {
+			t.Fatalf("CommitCohortGenotype failed: %v", err)
+		}
+		commitments[i] = commitment
+		assignment.Commitments[i] = commitment
+		assignment.Genotypes[i] = g
+		assignment.Salts[i] = salt
+	}
+	hash, err := CohortCommitmentsHash(commitments)
+	if err != nil {
+		t.Fatalf("CohortCommitmentsHash failed: %v", err)
+	}
+	assignment.CommitmentsHash = hash
+	return assignment
+}
+
+func TestCohortCircuit(t *testing.T) {
+	genotypes := []int{0, 1, 2, 0}
+	locusHash, err := LocusHash(2, 136608646, "G", "A")
+	if err != nil {
+		t.Fatalf("LocusHash failed: %v", err)
+	}
+
+	tests := []struct {
+		percent int
+		solved  bool
+	}{
+		{0, true},
+		{50, true},
+		{51, false},
+		{101, false},
+	}
+
+	for _, tc := range tests {
+		assignment := cohortAssignment(t, locusHash, genotypes, tc.percent)
+		err := test.IsSolved(NewCohortCircuit(len(genotypes)), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("Expected %d%% claim to be provable: %v", tc.percent, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("Expected %d%% claim not to be provable", tc.percent)
+		}
+	}
+}
+
+func TestCohortCircuit_WrongCommitment(t *testing.T) {
+	locusHash, err := LocusHash(2, 136608646, "G", "A")
+	if err != nil {
+		t.Fatalf("LocusHash failed: %v", err)
+	}
+	assignment := cohortAssignment(t, locusHash, []int{0}, 0)
+	assignment.Genotypes[0] = 1 // Does not open the commitment
+
+	if err := test.IsSolved(NewCohortCircuit(1), assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Error("Expected circuit to reject a genotype that does not match its commitment")
+	}
+}
+
+func TestCohortCircuit_OtherLocus(t *testing.T) {
+	locusHash, err := LocusHash(2, 136608646, "G", "A")
+	if err != nil {
+		t.Fatalf("LocusHash failed: %v", err)
+	}
+	otherLocus, err := LocusHash(1, 11856378, "G", "A")
+	if err != nil {
+		t.Fatalf("LocusHash failed: %v", err)
+	}
+
+	// Commitments made at one locus cannot be counted as another's
+	assignment := cohortAssignment(t, locusHash, []int{1, 2}, 100)
+	assignment.LocusHash = otherLocus
+	if err := test.IsSolved(NewCohortCircuit(2), assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Error("Expected circuit to reject commitments made at another locus")
+	}
+}
+
+func TestCohortProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, cohortVCF)
+
+	proof := NewCohortProof(136608646, "G", "A", 50)
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if len(proof.Salts) != 4 || len(proof.Commitments) != 4 {
+		t.Errorf("Expected 4 salts and commitments to be recorded, got %d and %d", len(proof.Salts), len(proof.Commitments))
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
+
+func TestCohortProof_GenerateUnmetClaim(t *testing.T) {
+	vcfPath := writeTestVCF(t, cohortVCF)
+
+	proof := NewCohortProof(136608646, "G", "A", 75)
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err == nil {
+		t.Errorf("Generate should return error when the cohort does not meet the claim")
+	}
+	if proofData.Result != ProofFail {
+		t.Errorf("Expected ProofFail, got %s", proofData.Result.String())
+	}
+}
+
+func TestCohortProof_MemberCommitments(t *testing.T) {
+	vcfPath := writeTestVCF(t, cohortVCF)
+	locusHash, err := LocusHash(2, 136608646, "G", "A")
+	if err != nil {
+		t.Fatalf("LocusHash failed: %v", err)
+	}
+
+	// The members committed to their genotypes before the proof was requested
+	genotypes := []int{1, 0, 2, 0}
+	salts := make([]*big.Int, len(genotypes))
+	commitments := make([]*big.Int, len(genotypes))
+	for i, g := range genotypes {
+		salts[i] = big.NewInt(int64(500 + i))
+		if commitments[i], err = CommitCohortGenotype(locusHash, g, salts[i]); err != nil {
+			t.Fatalf("CommitCohortGenotype failed: %v", err)
+		}
+	}
+	commitmentsHash, err := CohortCommitmentsHash(commitments)
+	if err != nil {
+		t.Fatalf("CohortCommitmentsHash failed: %v", err)
+	}
+
+	proof := NewCohortProof(136608646, "G", "A", 50)
+	proof.Chromosome = 2
+	proof.Salts = salts
+	proof.Commitments = commitments
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	expected := []PublicValue{
+		{Name: "LocusHash", Value: locusHash.String()},
+		{Name: "CommitmentsHash", Value: commitmentsHash.String()},
+	}
+	if err := CheckPublicValues(proofData, expected); err != nil {
+		t.Errorf("Expected the proof to disclose the locus and the members' commitments: %v", err)
+	}
+
+	// A proof made for a different locus is rejected
+	otherLocus, err := LocusHash(1, 11856378, "G", "A")
+	if err != nil {
+		t.Fatalf("LocusHash failed: %v", err)
+	}
+	if err := CheckPublicValues(proofData, []PublicValue{{Name: "LocusHash", Value: otherLocus.String()}}); err == nil {
+		t.Error("Expected a proof for another locus to be rejected")
+	}
+
+	// A sample whose genotype does not open its commitment fails generation
+	wrong := NewCohortProof(136608646, "G", "A", 50)
+	wrong.Chromosome = 2
+	wrong.Salts = salts
+	wrong.Commitments = append([]*big.Int{commitments[1]}, commitments[1:]...)
+	if _, err := wrong.Generate(vcfPath, "", ""); err == nil {
+		t.Error("Expected Generate to fail when a sample does not open its commitment")
+	}
+}
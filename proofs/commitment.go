@@ -0,0 +1,90 @@
+package proofs
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// CommitGenotype returns the MiMC commitment MiMC(genotype, salt) to a
+// genotype at no particular locus.
+//
+// Deprecated: cohort proofs open commitments bound to their locus; use
+// CommitCohortGenotype.
+func CommitGenotype(genotype int, salt *big.Int) (*big.Int, error) {
+	var g, s fr.Element
+	g.SetInt64(int64(genotype))
+	s.SetBigInt(salt)
+
+	h := mimc.NewMiMC()
+	gBytes := g.Bytes()
+	sBytes := s.Bytes()
+	if _, err := h.Write(gBytes[:]); err != nil {
+		return nil, fmt.Errorf("hashing genotype: %w", err)
+	}
+	if _, err := h.Write(sBytes[:]); err != nil {
+		return nil, fmt.Errorf("hashing salt: %w", err)
+	}
+
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
+
+// alleleDomain separates allele hashing from other uses of hash-to-field
+var alleleDomain = []byte("zkgenomics-allele-v1")
+
+// alleleHash maps an allele string to a BN254 field element, reduced into
+// the field of circuits over other curves. Alleles are compared
+// case-insensitively, matching allelesMatch.
+func alleleHash(allele string) (*big.Int, error) {
+	hashed, err := fr.Hash([]byte(strings.ToUpper(allele)), alleleDomain, 1)
+	if err != nil {
+		return nil, fmt.Errorf("hashing allele %q: %w", allele, err)
+	}
+	return hashed[0].BigInt(new(big.Int)), nil
+}
+
+// VariantKey returns MiMC(position, H(ref), H(alt)), a field element that
+// identifies a variant inside circuits without encoding allele strings
+func VariantKey(position uint64, ref string, alt string) (*big.Int, error) {
+	return variantKeyOn(ecc.BN254, position, ref, alt)
+}
+
+// variantKeyOn returns the VariantKey of a variant with MiMC over curve
+func variantKeyOn(curve ecc.ID, position uint64, ref string, alt string) (*big.Int, error) {
+	values := []*big.Int{new(big.Int).SetUint64(position)}
+	for _, allele := range []string{ref, alt} {
+		hashed, err := alleleHash(allele)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, hashed)
+	}
+
+	return mimcValues(curve, values)
+}
+
+// mimcElements returns the MiMC hash of a sequence of field elements
+func mimcElements(elements []fr.Element) (*big.Int, error) {
+	h := mimc.NewMiMC()
+	for _, e := range elements {
+		b := e.Bytes()
+		if _, err := h.Write(b[:]); err != nil {
+			return nil, fmt.Errorf("hashing: %w", err)
+		}
+	}
+
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
+
+// randomSalt returns a uniformly random field element used to blind commitments
+func randomSalt() (*big.Int, error) {
+	var salt fr.Element
+	if _, err := salt.SetRandom(); err != nil {
+		return nil, fmt.Errorf("generating salt: %w", err)
+	}
+	return salt.BigInt(new(big.Int)), nil
+}
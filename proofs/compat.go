@@ -0,0 +1,66 @@
+package proofs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// proofCurve is the curve proofs are made over unless another is chosen, and
+// the curve of proofs that record none
+var proofCurve = ecc.BN254.String()
+
+// CircuitVersionError is reported when a proof was produced by a circuit
+// version this release cannot verify, typically one from a newer release
+type CircuitVersionError struct {
+	CircuitID string
+	Version   int
+	// Supported lists the versions of the circuit this release can verify
+	Supported []int
+}
+
+func (e *CircuitVersionError) Error() string {
+	versions := make([]string, len(e.Supported))
+	for i, version := range e.Supported {
+		versions[i] = fmt.Sprintf("v%d", version)
+	}
+	return fmt.Sprintf("proof was produced by %s circuit v%d; this release verifies %s",
+		e.CircuitID, e.Version, strings.Join(versions, ", "))
+}
+
+// checkCompatibility checks that proofData was produced over a curve and with
+// a backend this release can verify, and that it records its circuit. Proofs
+// that do not record their curve are taken to be over BN254. The circuit
+// version is checked by checkBinding once the public witness is known to fit
+// the verifying key.
+func checkCompatibility(proofData *ProofData) error {
+	if _, err := CurveNamed(proofData.Curve); err != nil {
+		names := make([]string, len(SupportedCurves))
+		for i, curve := range SupportedCurves {
+			names[i] = curve.String()
+		}
+		return fmt.Errorf("proof is over curve %s; this release verifies %s proofs", proofData.Curve, strings.Join(names, ", "))
+	}
+	if _, err := BackendNamed(proofData.Backend, proofData.Curve); err != nil {
+		return fmt.Errorf("proof is made with an unsupported backend %q", proofData.Backend)
+	}
+	if proofData.CircuitID == "" {
+		return fmt.Errorf("proof does not record the circuit that produced it")
+	}
+	return nil
+}
+
+// supportedVersions returns the versions of a circuit this release can
+// verify, given the version its circuit currently has
+func supportedVersions(circuitID string, current int) []int {
+	versions := []int{current}
+	for _, layout := range retiredLayouts {
+		if layout.CircuitID == circuitID {
+			versions = append(versions, layout.Version)
+		}
+	}
+	slices.Sort(versions)
+	return versions
+}
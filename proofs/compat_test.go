@@ -0,0 +1,56 @@
+package proofs
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestVerifyProofData_ChecksCompatibility(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	11854476	rs1801131	T	G	60	PASS	.	GT	0/0
+1	11856378	rs1801133	G	A	60	PASS	.	GT	0/1
+`)
+
+	proofData, err := (&MTHFRProof{}).Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if proofData.Curve != "bn254" || proofData.CreatedAt.IsZero() {
+		t.Errorf("Expected the proof to record its curve and creation time, got %q and %v", proofData.Curve, proofData.CreatedAt)
+	}
+
+	newer := *proofData
+	newer.CircuitVersion = 2
+	result, err := (&MTHFRProof{}).VerifyProofData(&newer)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	var versionErr *CircuitVersionError
+	if result.Result != ProofFail || !errors.As(result.Error, &versionErr) {
+		t.Fatalf("Expected CircuitVersionError for an unknown circuit version, got %v", result.Error)
+	}
+	if versionErr.CircuitID != "mthfr" || !slices.Equal(versionErr.Supported, []int{1}) {
+		t.Errorf("Expected mthfr v1 to be reported as supported, got %+v", versionErr)
+	}
+
+	otherCurve := *proofData
+	otherCurve.Curve = "bls12-381"
+	result, err = (&MTHFRProof{}).VerifyProofData(&otherCurve)
+	if err != nil || result.Result != ProofFail || result.Error == nil {
+		t.Errorf("Expected a proof over another curve to fail, got %v: %v", result, err)
+	}
+}
+
+func TestCircuitVersionError_ListsRetiredVersions(t *testing.T) {
+	_, err := circuitLayout("dynamic", 9, 5)
+	var versionErr *CircuitVersionError
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("Expected CircuitVersionError, got %v", err)
+	}
+	if !slices.Equal(versionErr.Supported, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected dynamic v1-v4 to be supported, got %v", versionErr.Supported)
+	}
+}
@@ -0,0 +1,75 @@
+package proofs
+
+import "context"
+
+// runContext runs fn and returns its result, or ctx.Err() as soon as ctx is
+// done. Neither gnark's compile, setup and prove calls nor VCF scans can be
+// interrupted, so after cancellation fn finishes in the background and its
+// result is discarded.
+func runContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := fn()
+		done <- result{value, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case r := <-done:
+		return r.value, r.err
+	}
+}
+
+// CheckGenomeCommitmentContext checks the VCF against its commitment like
+// CheckGenomeCommitment, returning ctx.Err() as soon as ctx is done
+func CheckGenomeCommitmentContext(ctx context.Context, vcfPath string) error {
+	_, err := runContext(ctx, func() (struct{}, error) {
+		return struct{}{}, CheckGenomeCommitment(vcfPath)
+	})
+	return err
+}
+
+// GenerateContext generates a proof like proof.Generate, returning ctx.Err()
+// with failed proof data as soon as ctx is done
+func GenerateContext(ctx context.Context, proof Proof, vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	proofData, err := runContext(ctx, func() (*ProofData, error) {
+		return generateWithRandomness(nil, func() (*ProofData, error) {
+			return proof.Generate(vcfPath, provingKeyPath, outputPath)
+		})
+	})
+	return recordFailure(proofData, err), err
+}
+
+// VerifyContext verifies a proof file like proof.Verify, returning ctx.Err()
+// as soon as ctx is done
+func VerifyContext(ctx context.Context, proof Proof, verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return runContext(ctx, func() (*VerificationResult, error) {
+		return proof.Verify(verifyingKeyPath, proofPath)
+	})
+}
+
+// VerifyProofDataContext verifies proofData like proof.VerifyProofData,
+// returning ctx.Err() as soon as ctx is done
+func VerifyProofDataContext(ctx context.Context, proof Proof, proofData *ProofData) (*VerificationResult, error) {
+	return runContext(ctx, func() (*VerificationResult, error) {
+		return proof.VerifyProofData(proofData)
+	})
+}
+
+// SimulateContext simulates proof like Simulate, returning ctx.Err() as soon
+// as ctx is done
+func SimulateContext(ctx context.Context, proof Proof, vcfPath string) (*Simulation, error) {
+	return runContext(ctx, func() (*Simulation, error) {
+		return Simulate(proof, vcfPath)
+	})
+}
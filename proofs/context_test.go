@@ -0,0 +1,58 @@
+package proofs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunContext(t *testing.T) {
+	value, err := runContext(context.Background(), func() (int, error) { return 7, nil })
+	if err != nil || value != 7 {
+		t.Errorf("Expected 7, got %d: %v", value, err)
+	}
+
+	// A cancelled context returns without waiting for fn
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err = runContext(ctx, func() (int, error) {
+		<-release
+		return 0, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestGenerateContext_Cancelled(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+11	66328095	rs1815739	C	T	60	PASS	.	GT	1/1
+`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	proofData, err := GenerateContext(ctx, &ACTN3Proof{}, vcfPath, "", "")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if proofData == nil || proofData.Result != ProofFail {
+		t.Errorf("Expected failed proof data, got %+v", proofData)
+	}
+
+	proofData, err = GenerateContext(context.Background(), &ACTN3Proof{}, vcfPath, "", "")
+	if err != nil || proofData.Result != ProofSuccess {
+		t.Fatalf("Expected the proof to be generated, got %v", err)
+	}
+	if _, err := VerifyProofDataContext(ctx, &ACTN3Proof{}, proofData); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected verification to be cancelled, got %v", err)
+	}
+}
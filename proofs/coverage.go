@@ -0,0 +1,171 @@
+package proofs
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrNotSequenced is returned when an absence is proven at a locus the
+// genome's coverage shows was not sequenced, so a missing record there
+// says nothing about the variant
+var ErrNotSequenced = errors.New("locus was not sequenced")
+
+// CoverageSource reports where a genome was sequenced, telling a locus that
+// is homozygous reference apart from one with no coverage when its VCF has
+// no record there
+type CoverageSource interface {
+	// Covered reports whether chrom:pos was sequenced
+	Covered(chrom string, pos uint64) (bool, error)
+}
+
+// coverageInterval is a covered span, with 1-based inclusive positions
+type coverageInterval struct {
+	start uint64
+	end   uint64
+}
+
+// BEDCoverage is the coverage described by a BED file, such as the regions
+// a capture kit targets or the per-base or quantized output of mosdepth
+type BEDCoverage struct {
+	intervals map[string][]coverageInterval
+}
+
+// ReadBEDCoverage reads the covered intervals of the BED file at path,
+// plain or gzip-compressed. Intervals whose fourth column is a depth, as in
+// mosdepth output, are covered if it is at least minDepth; intervals with
+// no depth are covered.
+func ReadBEDCoverage(path string, minDepth float64) (*BEDCoverage, error) {
+	f, err := openGenomeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := decompressVCF(f)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := &BEDCoverage{intervals: make(map[string][]coverageInterval)}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "track") || strings.HasPrefix(text, "browser") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("%s:%d: expected chromosome, start and end", path, line)
+		}
+		start, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid start %q", path, line, fields[1])
+		}
+		end, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("%s:%d: invalid end %q", path, line, fields[2])
+		}
+		if len(fields) > 3 {
+			if depth, err := strconv.ParseFloat(fields[3], 64); err == nil && depth < minDepth {
+				continue
+			}
+		}
+		if end == start {
+			continue
+		}
+		// BED intervals are 0-based and half-open
+		chrom := normalizeChromosome(fields[0])
+		coverage.intervals[chrom] = append(coverage.intervals[chrom], coverageInterval{start: start + 1, end: end})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for chrom, intervals := range coverage.intervals {
+		coverage.intervals[chrom] = mergeIntervals(intervals)
+	}
+	return coverage, nil
+}
+
+// mergeIntervals sorts intervals and merges those that overlap or abut
+func mergeIntervals(intervals []coverageInterval) []coverageInterval {
+	slices.SortFunc(intervals, func(a, b coverageInterval) int {
+		return cmp.Compare(a.start, b.start)
+	})
+	merged := intervals[:0]
+	for _, interval := range intervals {
+		if n := len(merged); n > 0 && interval.start <= merged[n-1].end+1 {
+			merged[n-1].end = max(merged[n-1].end, interval.end)
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// Covered reports whether an interval of the BED file holds chrom:pos, on
+// any chromosome if chrom is empty
+func (c *BEDCoverage) Covered(chrom string, pos uint64) (bool, error) {
+	for name, intervals := range c.intervals {
+		if !sameChromosome(chrom, name) {
+			continue
+		}
+		i, _ := slices.BinarySearchFunc(intervals, pos, func(interval coverageInterval, pos uint64) int {
+			return cmp.Compare(interval.end, pos)
+		})
+		if i < len(intervals) && intervals[i].start <= pos {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GVCFCoverage is the coverage of a gVCF, which has a record or reference
+// block at every position that was called. Positions within a deletion
+// recorded before them count as not covered.
+type GVCFCoverage struct {
+	Source GenomeSource
+}
+
+// Covered reports whether the gVCF has a record or reference block at chrom:pos
+func (c *GVCFCoverage) Covered(chrom string, pos uint64) (bool, error) {
+	calls, err := c.Source.LookupVariant(chrom, pos)
+	if err != nil {
+		return false, err
+	}
+	return len(calls) > 0, nil
+}
+
+// OpenCoverage opens the coverage at path: a gVCF if its name has a .vcf
+// extension, compressed or not, and a BED file otherwise, whose intervals
+// below minDepth are not covered
+func OpenCoverage(path string, minDepth float64) (CoverageSource, error) {
+	if strings.HasSuffix(path, ".vcf") || strings.Contains(path, ".vcf.") {
+		source, err := NewVCFSource(path, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &GVCFCoverage{Source: source}, nil
+	}
+	return ReadBEDCoverage(path, minDepth)
+}
+
+// checkSequenced returns ErrNotSequenced unless coverage, if set, covers
+// chrom:pos
+func checkSequenced(coverage CoverageSource, chrom string, pos uint64) error {
+	if coverage == nil {
+		return nil
+	}
+	covered, err := coverage.Covered(chrom, pos)
+	if err != nil {
+		return err
+	}
+	if !covered {
+		return fmt.Errorf("%w: no coverage at %s:%d", ErrNotSequenced, chrom, pos)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package proofs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadBEDCoverage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sample.regions.bed")
+	content := "track name=coverage\n" +
+		"chr17\t41276000\t41276040\t32.5\n" +
+		"chr17\t41276040\t41276100\t4\n" +
+		"chr17\t41276100\t41276200\t18\n" +
+		"chr17\t41276150\t41276300\t25\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	coverage, err := ReadBEDCoverage(path, 10)
+	if err != nil {
+		t.Fatalf("ReadBEDCoverage should not return error: %v", err)
+	}
+	tests := []struct {
+		chrom   string
+		pos     uint64
+		covered bool
+	}{
+		{"17", 41276000, false}, // BED starts are 0-based
+		{"17", 41276001, true},
+		{"chr17", 41276040, true},
+		{"17", 41276044, false}, // below the minimum depth
+		{"17", 41276250, true},  // overlapping intervals are merged
+		{"17", 41276301, false},
+		{"13", 41276010, false},
+		{"", 41276010, true},
+	}
+	for _, tc := range tests {
+		covered, err := coverage.Covered(tc.chrom, tc.pos)
+		if err != nil {
+			t.Fatalf("Covered should not return error: %v", err)
+		}
+		if covered != tc.covered {
+			t.Errorf("%s:%d: expected covered %v, got %v", tc.chrom, tc.pos, tc.covered, covered)
+		}
+	}
+}
+
+func TestNegativeProof_Coverage(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+17	41276000	.	G	A	60	PASS	.	GT	0/1
+`)
+	gvcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##INFO=<ID=END,Number=1,Type=Integer,Description="End of the reference block">
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+17	41276001	.	G	<NON_REF>	.	.	END=41276050	GT	0/0
+`)
+	gvcf, err := OpenCoverage(gvcfPath, 0)
+	if err != nil {
+		t.Fatalf("OpenCoverage should not return error: %v", err)
+	}
+	if _, ok := gvcf.(*GVCFCoverage); !ok {
+		t.Fatalf("Expected a gVCF coverage, got %T", gvcf)
+	}
+
+	proof := NewNegativeProof(negativeTestVariant)
+	proof.Coverage = gvcf
+	if _, err := proof.assign(vcfPath); err != nil {
+		t.Errorf("Expected absence at a covered locus to be provable: %v", err)
+	}
+
+	proof.Coverage = &BEDCoverage{}
+	if _, err := proof.assign(vcfPath); !errors.Is(err, ErrNotSequenced) {
+		t.Errorf("Expected ErrNotSequenced at an uncovered locus, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package proofs
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// credentialContext is the context of W3C Verifiable Credentials 2.0. Its
+// issuer-dependent vocabulary covers the credential's genomic terms.
+const credentialContext = "https://www.w3.org/ns/credentials/v2"
+
+// CredentialType and CredentialProofType name genomic proof credentials and
+// the zero-knowledge proof embedded in them
+const (
+	CredentialType      = "GenomicProofCredential"
+	CredentialProofType = "ZKGenomicsGroth16Proof"
+)
+
+// VerifiableCredential is a proof wrapped as a W3C Verifiable Credential, so
+// it can be held by SSI wallets. The credential subject is the claim the
+// proof makes; the credential proof embeds the proof itself.
+type VerifiableCredential struct {
+	Context           []string          `json:"@context"`
+	Type              []string          `json:"type"`
+	Issuer            string            `json:"issuer"`
+	ValidFrom         time.Time         `json:"validFrom,omitzero"`
+	ValidUntil        time.Time         `json:"validUntil,omitzero"`
+	CredentialSubject CredentialSubject `json:"credentialSubject"`
+	Proof             CredentialProof   `json:"proof"`
+}
+
+// CredentialSubject is the claim of a genomic proof credential
+type CredentialSubject struct {
+	// ID, if set, identifies the holder, typically by DID
+	ID             string `json:"id,omitempty"`
+	ProofType      string `json:"proofType"`
+	CircuitID      string `json:"circuitId"`
+	CircuitVersion int    `json:"circuitVersion"`
+	// PublicValues are the public inputs of the proof, which state its claim
+	PublicValues []PublicValue `json:"publicValues"`
+}
+
+// CredentialProof embeds a proof in a credential. ProofValue is the CBOR
+// encoding of the ProofData, multibase-encoded as base64url.
+type CredentialProof struct {
+	Type         string    `json:"type"`
+	Created      time.Time `json:"created,omitzero"`
+	ProofPurpose string    `json:"proofPurpose"`
+	ProofValue   string    `json:"proofValue"`
+}
+
+// NewVerifiableCredential wraps a proof of proofType as a credential issued
+// by issuer, typically a DID. The proof must record the circuit that produced
+// it. A validity window bound to the proof becomes the credential's.
+func NewVerifiableCredential(proofType string, proofData *ProofData, issuer string) (*VerifiableCredential, error) {
+	if issuer == "" {
+		return nil, fmt.Errorf("credential requires an issuer")
+	}
+	values, err := PublicValues(proofData)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := proofData.MarshalCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("encoding proof: %w", err)
+	}
+
+	credential := &VerifiableCredential{
+		Context:   []string{credentialContext},
+		Type:      []string{"VerifiableCredential", CredentialType},
+		Issuer:    issuer,
+		ValidFrom: proofData.CreatedAt,
+		CredentialSubject: CredentialSubject{
+			ProofType:      proofType,
+			CircuitID:      proofData.CircuitID,
+			CircuitVersion: proofData.CircuitVersion,
+			PublicValues:   values,
+		},
+		Proof: CredentialProof{
+			Type:         CredentialProofType,
+			Created:      proofData.CreatedAt,
+			ProofPurpose: "assertionMethod",
+			ProofValue:   "u" + base64.RawURLEncoding.EncodeToString(encoded),
+		},
+	}
+	if proofData.Binding != nil {
+		if !proofData.Binding.NotBefore.IsZero() {
+			credential.ValidFrom = proofData.Binding.NotBefore
+		}
+		credential.ValidUntil = proofData.Binding.NotAfter
+	}
+	return credential, nil
+}
+
+// ProofData decodes the proof embedded in the credential and checks that the
+// credential subject states the claim the proof makes. The proof itself is
+// not verified.
+func (c *VerifiableCredential) ProofData() (*ProofData, error) {
+	if !slices.Contains(c.Type, CredentialType) || c.Proof.Type != CredentialProofType {
+		return nil, fmt.Errorf("not a genomic proof credential")
+	}
+	if len(c.Proof.ProofValue) == 0 || c.Proof.ProofValue[0] != 'u' {
+		return nil, fmt.Errorf("credential proof value is not base64url multibase")
+	}
+	encoded, err := base64.RawURLEncoding.DecodeString(c.Proof.ProofValue[1:])
+	if err != nil {
+		return nil, fmt.Errorf("decoding credential proof value: %w", err)
+	}
+	var proofData ProofData
+	if err := proofData.UnmarshalCBOR(encoded); err != nil {
+		return nil, fmt.Errorf("decoding credential proof value: %w", err)
+	}
+
+	subject := c.CredentialSubject
+	if proofData.ProofType != "" && proofData.ProofType != subject.ProofType {
+		return nil, fmt.Errorf("credential subject is a %s proof but embeds a %s proof", subject.ProofType, proofData.ProofType)
+	}
+	if proofData.CircuitID != subject.CircuitID || proofData.CircuitVersion != subject.CircuitVersion {
+		return nil, fmt.Errorf("credential subject circuit does not match the embedded proof")
+	}
+	values, err := PublicValues(&proofData)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Equal(values, subject.PublicValues) {
+		return nil, fmt.Errorf("credential subject public values do not match the embedded proof")
+	}
+	return &proofData, nil
+}
+
+// ReadVerifiableCredential loads a JSON-encoded credential
+func ReadVerifiableCredential(path string) (*VerifiableCredential, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading credential: %w", err)
+	}
+
+	var credential VerifiableCredential
+	if err := json.Unmarshal(data, &credential); err != nil {
+		return nil, fmt.Errorf("decoding credential: %w", err)
+	}
+	return &credential, nil
+}
@@ -0,0 +1,118 @@
+package proofs
+
+import (
+	"fmt"
+	"hash"
+	"math/big"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	mimc_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/mimc"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	mimc_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/mimc"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	mimc_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	mimc_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+// SupportedCurves are the curves proofs can be made over. BN254 is the
+// default; BLS12-381 has a higher security margin, and BLS12-377 proofs can
+// be verified inside BW6-761 circuits, for recursion.
+var SupportedCurves = []ecc.ID{ecc.BN254, ecc.BLS12_381, ecc.BLS12_377, ecc.BW6_761}
+
+// CurveNamed returns the curve recorded as name in a proof or key, such as
+// "bls12_381". Proofs and keys predating curve selection record none and
+// are over BN254.
+func CurveNamed(name string) (ecc.ID, error) {
+	if name == "" {
+		return ecc.BN254, nil
+	}
+	curve, err := ecc.IDFromString(name)
+	if err != nil || !slices.Contains(SupportedCurves, curve) {
+		return ecc.UNKNOWN, fmt.Errorf("unsupported curve %q", name)
+	}
+	return curve, nil
+}
+
+// curveOrDefault returns curve, or BN254 if it is unknown
+func curveOrDefault(curve ecc.ID) ecc.ID {
+	if curve == ecc.UNKNOWN {
+		return ecc.BN254
+	}
+	return curve
+}
+
+// curveOfField returns the supported curve whose scalar field has the
+// modulus field, as returned by api.Compiler().Field()
+func curveOfField(field *big.Int) (ecc.ID, error) {
+	for _, curve := range SupportedCurves {
+		if curve.ScalarField().Cmp(field) == 0 {
+			return curve, nil
+		}
+	}
+	return ecc.UNKNOWN, fmt.Errorf("no supported curve has scalar field %s", field)
+}
+
+// mimcValues returns the MiMC hash over the scalar field of curve of a
+// sequence of values, which are reduced into the field
+func mimcValues(curve ecc.ID, values []*big.Int) (*big.Int, error) {
+	var h hash.Hash
+	switch curve {
+	case ecc.BN254:
+		h = mimc_bn254.NewMiMC()
+	case ecc.BLS12_381:
+		h = mimc_bls12381.NewMiMC()
+	case ecc.BLS12_377:
+		h = mimc_bls12377.NewMiMC()
+	case ecc.BW6_761:
+		h = mimc_bw6761.NewMiMC()
+	default:
+		return nil, fmt.Errorf("no MiMC hash over curve %s", curve)
+	}
+
+	modulus := curve.ScalarField()
+	block := make([]byte, h.BlockSize())
+	for _, v := range values {
+		new(big.Int).Mod(v, modulus).FillBytes(block)
+		if _, err := h.Write(block); err != nil {
+			return nil, fmt.Errorf("hashing: %w", err)
+		}
+	}
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
+
+// curveAssignment is implemented by assignments whose public values include
+// hashes over the proof curve. Assign computes them over BN254; assignCurve
+// recomputes them for proofs over another curve.
+type curveAssignment interface {
+	assignCurve(curve ecc.ID) error
+}
+
+// witnessValues returns the values of a witness over any supported curve
+func witnessValues(w witness.Witness) ([]*big.Int, error) {
+	var values []*big.Int
+	switch vector := w.Vector().(type) {
+	case fr_bn254.Vector:
+		for i := range vector {
+			values = append(values, vector[i].BigInt(new(big.Int)))
+		}
+	case fr_bls12381.Vector:
+		for i := range vector {
+			values = append(values, vector[i].BigInt(new(big.Int)))
+		}
+	case fr_bls12377.Vector:
+		for i := range vector {
+			values = append(values, vector[i].BigInt(new(big.Int)))
+		}
+	case fr_bw6761.Vector:
+		for i := range vector {
+			values = append(values, vector[i].BigInt(new(big.Int)))
+		}
+	default:
+		return nil, fmt.Errorf("unexpected witness type %T", w.Vector())
+	}
+	return values, nil
+}
@@ -0,0 +1,100 @@
+package proofs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func TestCurves_ProveAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+
+	tests := []struct {
+		name    string
+		backend Backend
+	}{
+		{"groth16 over bls12_381", NewGroth16(ecc.BLS12_381)},
+		{"plonk over bls12_377", &PlonkBackend{CurveID: ecc.BLS12_377}},
+		{"groth16 over bw6_761", NewGroth16(ecc.BW6_761)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			curve := tt.backend.Curve()
+			keys := &FileKeyStore{Dir: t.TempDir()}
+			proof := &ALDH2Proof{}
+
+			circuit, err := ProofCircuit(proof, "")
+			if err != nil {
+				t.Fatalf("ProofCircuit should not return error: %v", err)
+			}
+			key, err := SetupKeys(circuit, tt.backend, keys, nil)
+			if err != nil {
+				t.Fatalf("SetupKeys should not return error: %v", err)
+			}
+			if key.Curve != curve.String() || !strings.Contains(key.String(), curve.String()) {
+				t.Errorf("Expected keys over %s to be stored apart, got %s", curve, key)
+			}
+
+			proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Keys: keys, Backend: tt.backend})
+			if err != nil {
+				t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+			}
+			if proofData.Curve != curve.String() {
+				t.Errorf("Expected the proof to record curve %s, got %q", curve, proofData.Curve)
+			}
+			result, err := proof.VerifyProofData(proofData)
+			if err != nil || result.Result != ProofSuccess {
+				t.Fatalf("Proof over %s should verify, got %v, %v", curve, result, err)
+			}
+
+			// The locus is disclosed as the LocusHash over the proof's curve
+			locusHash, err := LocusHashOn(curve, 12, 112241766, "G", "A")
+			if err != nil {
+				t.Fatalf("LocusHashOn should not return error: %v", err)
+			}
+			if err := CheckPublicValues(proofData, []PublicValue{{Name: "LocusHash", Value: locusHash.String()}}); err != nil {
+				t.Errorf("Expected the LocusHash over %s to be disclosed: %v", curve, err)
+			}
+			if _, err := VKFingerprint(proofData.VerifyingKey); err != nil {
+				t.Errorf("VKFingerprint should accept a verifying key over %s: %v", curve, err)
+			}
+
+			relabeled := *proofData
+			relabeled.Curve = ecc.BN254.String()
+			if result, _ := proof.VerifyProofData(&relabeled); result.Result == ProofSuccess {
+				t.Errorf("Expected a proof over %s to fail verification over bn254", curve)
+			}
+		})
+	}
+}
+
+func TestCurves_InCircuitHashes(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	backend := NewGroth16(ecc.BLS12_381)
+
+	// The dynamic circuit recomputes the LocusHash with MiMC over its field
+	proofData, err := GenerateWithOptionsContext(context.Background(), NewDynamicProof(112241766, "G", "A"), vcfPath, GenerateOptions{Backend: backend})
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+	if result, err := (&DynamicProof{}).VerifyProofData(proofData); err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Dynamic proof over bls12_381 should verify, got %v, %v", result, err)
+	}
+
+	if _, err := CurveNamed("secp256k1"); err == nil {
+		t.Error("Expected an unsupported curve to be refused")
+	}
+	if _, err := BackendNamed(BackendPLONK, "bls12_381"); err != nil {
+		t.Errorf("BackendNamed should accept plonk over bls12_381: %v", err)
+	}
+}
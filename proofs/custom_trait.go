@@ -0,0 +1,101 @@
+package proofs
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// NewCustomTraitProof creates a CustomTraitProof of trait
+func NewCustomTraitProof(trait traits.CustomTrait) *CustomTraitProof {
+	return &CustomTraitProof{Trait: trait}
+}
+
+// OutcomeMode returns the claim mode that states outcome: carrier for
+// genotypes 1 and 2, heterozygous for 1 alone and homozygous ALT for 2 alone.
+// Other outcomes, such as genotype 0, cannot be stated without disclosing
+// the exact genotype.
+func OutcomeMode(outcome traits.TraitOutcome) ClaimMode {
+	has := func(genotype int) bool { return slices.Contains(outcome.Genotypes, genotype) }
+	switch {
+	case has(0):
+		return ClaimExactGenotype
+	case has(1) && has(2):
+		return ClaimCarrier
+	case has(1):
+		return ClaimHeterozygous
+	case has(2):
+		return ClaimHomozygousAlt
+	}
+	return ClaimExactGenotype
+}
+
+// dynamicProof reads the genotype at the trait's locus, checks it has the
+// claimed outcome and returns the DynamicProof stating it
+func (p *CustomTraitProof) dynamicProof(vcfPath string) (*DynamicProof, error) {
+	if err := p.Trait.Validate(); err != nil {
+		return nil, err
+	}
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := p.Trait.TraitVariant
+	proof := NewDynamicProof(uint64(variant.Position), variant.Ref, variant.Alt)
+	proof.Chromosome = variant.Chromosome
+	proof.Build = variant.Build
+	proof.Progress = p.Progress
+	proof.Logger = p.Logger
+	proof.Source = source
+
+	call, err := proof.findCall(vcfPath, proof.Position, proof.Reference, proof.Alternate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract genotype: %w", err)
+	}
+	genotype, _, _, err := proof.genotypeFromCall(call, proof.Alternate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract genotype: %w", err)
+	}
+	outcome, ok := p.Trait.Outcome(genotype)
+	if !ok {
+		return nil, fmt.Errorf("genotype %d has no outcome of trait %s", genotype, p.Trait.Name)
+	}
+	if p.Outcome != "" && outcome.Label != p.Outcome {
+		if _, ok := p.Trait.LookupOutcome(p.Outcome); !ok {
+			return nil, fmt.Errorf("trait %s has no outcome %q", p.Trait.Name, p.Outcome)
+		}
+		return nil, fmt.Errorf("genotype has outcome %s of trait %s, not %s", outcome.Label, p.Trait.Name, p.Outcome)
+	}
+	proof.Mode = OutcomeMode(outcome)
+	loggerOrNop(p.Logger).Infof("Proving outcome %s of trait %s as a %s claim", outcome.Label, p.Trait.Name, proof.Mode)
+	return proof, nil
+}
+
+func (p *CustomTraitProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	proof, err := p.dynamicProof(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+	return proof.Generate(vcfPath, provingKeyPath, outputPath)
+}
+
+// Assign reads the trait's genotype and builds the dynamic circuit and its
+// assignment
+func (p *CustomTraitProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	proof, err := p.dynamicProof(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof.Assign(vcfPath)
+}
+
+func (p *CustomTraitProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "custom trait", verifyingKeyPath, proofPath)
+}
+
+func (p *CustomTraitProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "custom trait", proofData)
+}
@@ -0,0 +1,46 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestCustomTraitProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+2	136608646	rs4988235	G	A	60	PASS	.	GT	0/1
+`)
+	trait := traits.CustomTrait{
+		Name:         "lactase_persistence",
+		TraitVariant: traits.TraitVariant{Chromosome: 2, Position: 136608646, Ref: "G", Alt: "A"},
+		Outcomes: []traits.TraitOutcome{
+			{Label: "non-persistent", Genotypes: []int{0}},
+			{Label: "persistent", Genotypes: []int{1, 2}},
+		},
+	}
+
+	proof := NewCustomTraitProof(trait)
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected the proof to verify, got %v: %v", result, err)
+	}
+	if mode := result.ParsedPublicInputs["ClaimMode"]; mode != "3" {
+		t.Errorf("Expected the persistent outcome to be claimed as a carrier, got ClaimMode %s", mode)
+	}
+
+	proof.Outcome = "non-persistent"
+	if _, err := proof.Generate(vcfPath, "", ""); err == nil {
+		t.Error("Expected an outcome the genome does not have not to be proven")
+	}
+
+	trait.Outcomes[1].Genotypes = []int{0, 2}
+	if err := trait.Validate(); err == nil {
+		t.Error("Expected a genotype of two outcomes to be refused")
+	}
+}
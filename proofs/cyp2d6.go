@@ -0,0 +1,130 @@
+package proofs
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs/gadgets"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// CYP2D6Circuit proves a CYP2D6 metabolizer status from the private genotypes
+// of the defining SNPs of the star-allele panel in traits.CYP2D6StarAlleles
+type CYP2D6Circuit struct {
+	ClaimedStatus frontend.Variable `gnark:",public"`
+
+	Genotypes []frontend.Variable
+}
+
+// NewCYP2D6Circuit allocates a circuit sized for the CYP2D6 star-allele panel
+func NewCYP2D6Circuit() *CYP2D6Circuit {
+	return &CYP2D6Circuit{
+		Genotypes: make([]frontend.Variable, len(traits.CYP2D6StarAlleles)),
+	}
+}
+
+func (c *CYP2D6Circuit) Define(api frontend.API) error {
+	if len(c.Genotypes) != len(traits.CYP2D6StarAlleles) {
+		return fmt.Errorf("expected %d genotypes, got %d", len(traits.CYP2D6StarAlleles), len(c.Genotypes))
+	}
+
+	// Call the star alleles as traits.CallCYP2D6StarAlleles does: a SNP
+	// calls its own allele only on copies no earlier allele explains
+	copies := make([]frontend.Variable, len(c.Genotypes))
+	var variantAlleles frontend.Variable = 0
+	var units frontend.Variable = 0
+	for i, carriers := range traits.CYP2D6HaplotypeCarriers() {
+		g := c.Genotypes[i]
+		gadgets.AssertIsGenotype(api, g)
+
+		copies[i] = g
+		for _, j := range carriers {
+			copies[i] = api.Sub(copies[i], copies[j])
+		}
+		// A negative count wraps around the field and is not 0, 1 or 2
+		gadgets.AssertIsGenotype(api, copies[i])
+
+		variantAlleles = api.Add(variantAlleles, copies[i])
+		units = api.Add(units, api.Mul(copies[i], traits.CYP2D6StarAlleles[i].ActivityUnits))
+	}
+
+	// Each of the two gene copies carries at most one star allele
+	api.AssertIsLessOrEqual(variantAlleles, 2)
+	units = api.Add(units, api.Mul(api.Sub(2, variantAlleles), traits.NormalActivityUnits))
+
+	var status frontend.Variable = 0
+	for u := 0; u <= traits.MaxDiploidActivityUnits; u++ {
+		selected := api.IsZero(api.Sub(units, u))
+		status = api.Add(status, api.Mul(selected, int(traits.MetabolizerFromActivity(u))))
+	}
+
+	api.AssertIsDifferent(status, int(traits.MetabolizerUnknown))
+	api.AssertIsEqual(c.ClaimedStatus, status)
+
+	return nil
+}
+
+// Assign extracts the CYP2D6 star allele genotypes and builds the circuit and its assignment
+func (p *CYP2D6Proof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewCYP2D6Circuit(), assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *CYP2D6Proof) Circuit() (frontend.Circuit, error) {
+	return NewCYP2D6Circuit(), nil
+}
+
+func (p *CYP2D6Proof) assign(vcfPath string) (*CYP2D6Circuit, traits.MetabolizerStatus, error) {
+	loggerOrNop(p.Logger).Infof("searching for CYP2D6 star allele variants...")
+
+	alleles := p.Traits.CYP2D6StarAlleles()
+	variants := make([]traits.TraitVariant, len(alleles))
+	for i, allele := range alleles {
+		variants[i] = allele.Variant
+	}
+	genotypes, err := extractTraitGenotypes(vcfPath, p.Sample, variants, p.Progress, p.Logger)
+	if err != nil {
+		return nil, traits.MetabolizerUnknown, err
+	}
+
+	status := traits.MetabolizerFromActivity(traits.CYP2D6Activity(genotypes))
+	if status == traits.MetabolizerUnknown {
+		return nil, traits.MetabolizerUnknown, fmt.Errorf("CYP2D6 genotypes do not determine a metabolizer status")
+	}
+
+	assignment := NewCYP2D6Circuit()
+	assignment.ClaimedStatus = int(status)
+	for i, g := range genotypes {
+		assignment.Genotypes[i] = g
+	}
+
+	return assignment, status, nil
+}
+
+func (p *CYP2D6Proof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, status, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewCYP2D6Circuit(), assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ CYP2D6 proof successfully generated for %s metabolizer status!", status)
+
+	return proofData, nil
+}
+
+func (p *CYP2D6Proof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "CYP2D6", verifyingKeyPath, proofPath)
+}
+
+func (p *CYP2D6Proof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "CYP2D6", proofData)
+}
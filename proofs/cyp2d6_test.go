@@ -0,0 +1,105 @@
+package proofs
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestCYP2D6Circuit(t *testing.T) {
+	tests := []struct {
+		genotypes []int // rs3892097 (1846G>A), rs1065852 (100C>T), rs28371725 (2988G>A)
+		claimed   traits.MetabolizerStatus
+		solved    bool
+	}{
+		{[]int{0, 0, 0}, traits.NormalMetabolizer, true},        // *1/*1, activity 2.0
+		{[]int{0, 1, 0}, traits.NormalMetabolizer, true},        // *1/*10, 1.25
+		{[]int{0, 0, 1}, traits.NormalMetabolizer, true},        // *1/*41, 1.5
+		{[]int{1, 1, 0}, traits.IntermediateMetabolizer, true},  // *1/*4, 1.0
+		{[]int{0, 2, 0}, traits.IntermediateMetabolizer, true},  // *10/*10, 0.5
+		{[]int{0, 1, 1}, traits.IntermediateMetabolizer, true},  // *10/*41, 0.75
+		{[]int{1, 2, 0}, traits.IntermediateMetabolizer, true},  // *4/*10, 0.25
+		{[]int{1, 1, 1}, traits.IntermediateMetabolizer, true},  // *4/*41, 0.5
+		{[]int{2, 2, 0}, traits.PoorMetabolizer, true},          // *4/*4, 0
+		{[]int{2, 2, 0}, traits.NormalMetabolizer, false},       // Wrong claim
+		{[]int{1, 2, 0}, traits.NormalMetabolizer, false},       // *4/*10 is not *1/*4 plus *10
+		{[]int{1, 0, 0}, traits.IntermediateMetabolizer, false}, // 1846G>A without 100C>T
+		{[]int{0, 2, 1}, traits.IntermediateMetabolizer, false}, // Three variant alleles
+		{[]int{2, 2, 1}, traits.PoorMetabolizer, false},         // Three variant alleles
+	}
+
+	for _, tc := range tests {
+		assignment := NewCYP2D6Circuit()
+		assignment.ClaimedStatus = int(tc.claimed)
+		for i, g := range tc.genotypes {
+			assignment.Genotypes[i] = g
+		}
+
+		err := test.IsSolved(NewCYP2D6Circuit(), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("Expected genotypes %v to prove %s: %v", tc.genotypes, tc.claimed, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("Expected genotypes %v not to prove %s", tc.genotypes, tc.claimed)
+		}
+	}
+}
+
+func TestCYP2D6Proof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+22	42523805	rs28371725	C	T	60	PASS	.	GT	0/0
+22	42524947	rs3892097	C	T	60	PASS	.	GT	1/1
+22	42526694	rs1065852	G	A	60	PASS	.	GT	1/1
+`)
+
+	// A *4/*4 genome carries two copies each of 1846G>A and 100C>T
+	proof := &CYP2D6Proof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+	if status := result.ParsedPublicInputs["ClaimedStatus"]; status != strconv.Itoa(int(traits.PoorMetabolizer)) {
+		t.Errorf("Expected *4/*4 to prove poor metabolizer status, got %s", status)
+	}
+}
+
+func TestCallCYP2D6StarAlleles(t *testing.T) {
+	tests := []struct {
+		genotypes []int
+		copies    []int // *4, *10, *41
+		activity  int
+	}{
+		{[]int{0, 0, 0}, []int{0, 0, 0}, 8},
+		{[]int{1, 1, 0}, []int{1, 0, 0}, 4},
+		{[]int{2, 2, 0}, []int{2, 0, 0}, 0},
+		{[]int{1, 2, 0}, []int{1, 1, 0}, 1},
+		{[]int{0, 2, 0}, []int{0, 2, 0}, 2},
+		{[]int{0, 1, 1}, []int{0, 1, 1}, 3},
+		{[]int{1, 0, 0}, nil, -1},
+		{[]int{0, 2, 1}, nil, -1},
+	}
+
+	for _, tc := range tests {
+		copies, ok := traits.CallCYP2D6StarAlleles(tc.genotypes)
+		if ok != (tc.copies != nil) || !slices.Equal(copies, tc.copies) {
+			t.Errorf("Expected genotypes %v to call %v, got %v (ok %v)", tc.genotypes, tc.copies, copies, ok)
+		}
+		if activity := traits.CYP2D6Activity(tc.genotypes); activity != tc.activity {
+			t.Errorf("Expected genotypes %v to score %d quarter units, got %d", tc.genotypes, tc.activity, activity)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package proofs
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/schema"
+)
+
+// DebugWitness is the witness of a proof by circuit variable name, with
+// values as decimal field elements. Full holds every variable, public and
+// private; Public holds the public inputs a verifier sees.
+//
+// The full witness reveals the private genomic inputs of the proof. Record it
+// only to debug circuits that fail to satisfy, and never share it.
+type DebugWitness struct {
+	Full   map[string]string `json:"full"`
+	Public map[string]string `json:"public"`
+}
+
+// record fills w with the witness of assignment over curve
+func (w *DebugWitness) record(curve ecc.ID, assignment frontend.Circuit) error {
+	full, err := frontend.NewWitness(assignment, curve.ScalarField())
+	if err != nil {
+		return fmt.Errorf("witness creation error: %w", err)
+	}
+	values, err := witnessValues(full)
+	if err != nil {
+		return err
+	}
+
+	// The witness holds the public variables in schema order, then the
+	// secret ones
+	var public, secret []string
+	tVariable := reflect.TypeOf((*frontend.Variable)(nil)).Elem()
+	_, err = schema.Walk(assignment, tVariable, func(leaf schema.LeafInfo, _ reflect.Value) error {
+		if leaf.Visibility == schema.Public {
+			public = append(public, leaf.FullName())
+		} else if leaf.Visibility == schema.Secret {
+			secret = append(secret, leaf.FullName())
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("walking circuit schema: %w", err)
+	}
+	if len(values) != len(public)+len(secret) {
+		return fmt.Errorf("witness has %d values for %d variables", len(values), len(public)+len(secret))
+	}
+
+	w.Full = make(map[string]string, len(values))
+	w.Public = make(map[string]string, len(public))
+	for i, name := range append(public, secret...) {
+		w.Full[name] = values[i].String()
+		if i < len(public) {
+			w.Public[name] = values[i].String()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package proofs
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestGenerateWithOptions_DebugWitness(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+
+	witness := &DebugWitness{}
+	proofData, err := GenerateWithOptionsContext(context.Background(), &ALDH2Proof{}, vcfPath, GenerateOptions{
+		Binding:      &Binding{NotAfter: time.Now().Add(time.Hour).Truncate(time.Second)},
+		DebugWitness: witness,
+	})
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+
+	values, err := PublicValues(proofData)
+	if err != nil {
+		t.Fatalf("PublicValues should not return error: %v", err)
+	}
+	if len(witness.Public) != len(values) {
+		t.Errorf("Expected the public witness to hold the %d public inputs, got %v", len(values), witness.Public)
+	}
+	// The witness names the inputs of the bound circuit
+	for _, value := range values {
+		name := value.Name
+		if name != "Binding" {
+			name = "Inner_" + name
+		}
+		if witness.Public[name] != value.Value {
+			t.Errorf("Expected public %s = %s, got %v", name, value.Value, witness.Public)
+		}
+	}
+
+	// The full witness adds the private genotype
+	if witness.Full["Inner_Genotype"] != "1" {
+		t.Errorf("Expected the heterozygous genotype in the full witness, got %v", witness.Full)
+	}
+	for name, value := range witness.Public {
+		if witness.Full[name] != value {
+			t.Errorf("Expected the full witness to include public %s", name)
+		}
+	}
+	if maps.Equal(witness.Full, witness.Public) {
+		t.Error("Expected the full witness to include private inputs")
+	}
+}
@@ -2,53 +2,100 @@ package proofs
 
 import (
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
-	"github.com/brentp/vcfgo"
 	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark/backend/groth16"
-	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs/gadgets"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
 )
 
-// stringToInt converts nucleotide strings to integers for circuit use
-func stringToInt(s string) int {
-	switch strings.ToUpper(s) {
-	case "A":
-		return 0
-	case "T":
-		return 1
-	case "G":
-		return 2
-	case "C":
-		return 3
-	default:
-		return 0 // Default to A
-	}
-}
-
+// DynamicCircuit proves a statement about the genotype at a variant. The
+// public ClaimMode (a ClaimMode value) selects the statement; ClaimedGenotype
+// discloses the genotype in ClaimExactGenotype mode and is zero otherwise.
+// RefHash and AltHash are the allele hashes of the variant (see
+// VariantKey), so alleles of any length, including indels and symbolic
+// structural variant alleles, are bound exactly. LocusHash is the LocusHash
+// of the variant, so verifiers can check which locus the proof refers to.
+//
+// The genotype is the number of the sample's alleles (Allele1, Allele2, as
+// VCF allele indices) equal to AltIndex, the position of the claimed ALT
+// among the record's ALT alleles, so multi-allelic sites are supported up
+// to the circuit's maximum allele index.
 type DynamicCircuit struct {
-	ClaimedRef       frontend.Variable `gnark:",public"`
-	ClaimedAlt       frontend.Variable `gnark:",public"`
+	RefHash          frontend.Variable `gnark:",public"`
+	AltHash          frontend.Variable `gnark:",public"`
 	ClaimedGenotype  frontend.Variable `gnark:",public"`
-	ActualRef        frontend.Variable
-	ActualAlt        frontend.Variable
+	ClaimMode        frontend.Variable `gnark:",public"`
+	LocusHash        frontend.Variable `gnark:",public"`
 	ActualGenotype   frontend.Variable
+	Allele1          frontend.Variable
+	Allele2          frontend.Variable
+	AltIndex         frontend.Variable
+	Chromosome       frontend.Variable
+	Position         frontend.Variable
+
+	maxAlleleIndex int
+	variant        traits.TraitVariant
+}
+
+// DefaultMaxAlleleIndex is the largest VCF allele index dynamic circuits
+// accept unless configured otherwise, i.e. sites with up to three ALT alleles
+const DefaultMaxAlleleIndex = 3
+
+// NewDynamicCircuit creates a dynamic circuit accepting allele indices up to
+// maxAlleleIndex; zero uses DefaultMaxAlleleIndex
+func NewDynamicCircuit(maxAlleleIndex int) *DynamicCircuit {
+	return &DynamicCircuit{maxAlleleIndex: maxAlleleIndex}
 }
 
 func (c *DynamicCircuit) Define(api frontend.API) error {
-	// Verify that the claimed reference matches actual reference
-	api.AssertIsEqual(c.ClaimedRef, c.ActualRef)
-	
-	// Verify that the claimed alternate matches actual alternate
-	api.AssertIsEqual(c.ClaimedAlt, c.ActualAlt)
-	
-	// Verify that the claimed genotype matches actual genotype
-	api.AssertIsEqual(c.ClaimedGenotype, c.ActualGenotype)
+	if err := assertLocusHash(api, c.LocusHash, c.Chromosome, c.Position, c.RefHash, c.AltHash); err != nil {
+		return err
+	}
+
+	maxAllele := c.maxAlleleIndex
+	if maxAllele == 0 {
+		maxAllele = DefaultMaxAlleleIndex
+	}
+	api.AssertIsLessOrEqual(c.Allele1, maxAllele)
+	api.AssertIsLessOrEqual(c.Allele2, maxAllele)
+	api.AssertIsLessOrEqual(c.AltIndex, maxAllele)
+	api.AssertIsDifferent(c.AltIndex, 0)
 
+	// The genotype counts the copies of the claimed ALT allele
+	g := c.ActualGenotype
+	api.AssertIsEqual(g, api.Add(api.IsZero(api.Sub(c.Allele1, c.AltIndex)), api.IsZero(api.Sub(c.Allele2, c.AltIndex))))
+
+	// Exactly one claim mode applies
+	isExact := api.IsZero(api.Sub(c.ClaimMode, int(ClaimExactGenotype)))
+	isHet := api.IsZero(api.Sub(c.ClaimMode, int(ClaimHeterozygous)))
+	isHomAlt := api.IsZero(api.Sub(c.ClaimMode, int(ClaimHomozygousAlt)))
+	isCarrier := api.IsZero(api.Sub(c.ClaimMode, int(ClaimCarrier)))
+	api.AssertIsEqual(api.Add(isExact, isHet, isHomAlt, isCarrier), 1)
+
+	// The statement selected by the mode must hold
+	holds := api.Add(
+		api.Mul(isExact, api.IsZero(api.Sub(c.ClaimedGenotype, g))),
+		api.Mul(isHet, gadgets.IsInSet(api, g, 1)),
+		api.Mul(isHomAlt, gadgets.IsInSet(api, g, 2)),
+		api.Mul(isCarrier, gadgets.IsInSet(api, g, 1, 2)),
+	)
+	api.AssertIsEqual(holds, 1)
+
+	// Only exact claims disclose the genotype
+	api.AssertIsEqual(api.Mul(api.Sub(1, isExact), c.ClaimedGenotype), 0)
+
+	return nil
+}
+
+func (c *DynamicCircuit) assignCurve(curve ecc.ID) error {
+	locusHash, err := variantLocusHash(curve, c.variant)
+	if err != nil {
+		return err
+	}
+	c.LocusHash = locusHash
 	return nil
 }
 
@@ -66,306 +113,214 @@ func (p *DynamicProof) Generate(vcfPath string, provingKeyPath string, outputPat
 	return p.GenerateDynamic(vcfPath, provingKeyPath, outputPath, p.Position, p.Reference, p.Alternate)
 }
 
-// GenerateDynamic implements the DynamicProofGenerator interface
-func (p *DynamicProof) GenerateDynamic(vcfPath string, provingKeyPath string, outputPath string, position uint64, ref string, alt string) (*ProofData, error) {
-	genotype, actualRef, actualAlt, err := p.extractGenotypeAtPosition(vcfPath, position, ref, alt)
+// Assign extracts the genotype at p.Position and builds the circuit and its assignment
+func (p *DynamicProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, err := p.assign(vcfPath, p.Position, p.Reference, p.Alternate)
 	if err != nil {
-		// Return ProofData with Fail result
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("failed to extract genotype: %w", err)
+		return nil, nil, err
 	}
+	return NewDynamicCircuit(p.MaxAlleleIndex), assignment, nil
+}
 
-	fmt.Printf("Found variant at position %d:\n", position)
-	fmt.Printf("  Reference: %s (expected: %s)\n", actualRef, ref)
-	fmt.Printf("  Alternate: %s (expected: %s)\n", actualAlt, alt)
-	fmt.Printf("  Genotype: %d\n", genotype)
-
-	// Verify that the found variant matches expected reference and alternate
-	if actualRef != ref {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("reference mismatch: expected %s, found %s", ref, actualRef)
-	}
-	if actualAlt != alt {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("alternate mismatch: expected %s, found %s", alt, actualAlt)
-	}
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *DynamicProof) Circuit() (frontend.Circuit, error) {
+	return NewDynamicCircuit(p.MaxAlleleIndex), nil
+}
 
-	// Generate actual zk-SNARK proof using gnark
-	fmt.Printf("Generating proof for position %d with genotype %d\n", position, genotype)
-	
-	// Convert string values to integers for circuit
-	refInt := stringToInt(actualRef)
-	altInt := stringToInt(actualAlt)
-	
-	// Compile the circuit
-	fmt.Println("Compiling dynamic circuit...")
-	var circuit DynamicCircuit
-	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+// assign extracts the genotype at position, checks the alleles and builds the witness
+func (p *DynamicProof) assign(vcfPath string, position uint64, ref string, alt string) (*DynamicCircuit, error) {
+	call, err := p.findCall(vcfPath, position, ref, alt)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("circuit compilation error: %w", err)
+		return nil, fmt.Errorf("failed to extract genotype: %w", err)
 	}
-
-	// Setup proving system
-	fmt.Println("Setting up proving system...")
-	pk, vk, err := groth16.Setup(cs)
+	genotype, actualRef, actualAlt, err := p.genotypeFromCall(call, alt)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("setup error: %w", err)
+		return nil, fmt.Errorf("failed to extract genotype: %w", err)
 	}
 
-	// Create witness
-	fmt.Println("Creating witness...")
-	witness := DynamicCircuit{
-		ClaimedRef:      refInt,
-		ClaimedAlt:      altInt,
-		ClaimedGenotype: genotype,
-		ActualRef:       refInt,
-		ActualAlt:       altInt,
-		ActualGenotype:  genotype,
+	// Verify that the found variant matches expected reference and alternate
+	if !allelesMatch(ref, actualRef) {
+		return nil, fmt.Errorf("reference mismatch: expected %s, found %s", ref, actualRef)
+	}
+	if !allelesMatch(alt, actualAlt) {
+		return nil, fmt.Errorf("alternate mismatch: expected %s, found %s", alt, actualAlt)
 	}
 
-	w, err := frontend.NewWitness(&witness, ecc.BN254.ScalarField())
-	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("witness creation error: %w", err)
+	if !p.Mode.Holds(genotype) {
+		return nil, fmt.Errorf("genotype %d does not satisfy the %s claim", genotype, p.Mode)
+	}
+	claimedGenotype := 0
+	if p.Mode == ClaimExactGenotype {
+		claimedGenotype = genotype
 	}
 
-	publicWitness, err := w.Public()
+	chromosome := traits.ChromosomeCode(call.Chromosome)
+	variant := traits.TraitVariant{Chromosome: chromosome, Position: int(position), Ref: actualRef, Alt: actualAlt}
+	locusHash, err := variantLocusHash(ecc.BN254, variant)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("public witness error: %w", err)
+		return nil, err
 	}
-
-	// Generate proof
-	fmt.Println("Generating cryptographic proof...")
-	proof, err := groth16.Prove(cs, pk, w)
+	refHash, err := alleleHash(actualRef)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("proving error: %w", err)
+		return nil, err
 	}
-
-	// Serialize proof to bytes
-	proofBytes := make([]byte, 0)
-	proofWriter := &bytesWriter{data: &proofBytes}
-	_, err = proof.WriteTo(proofWriter)
+	altHash, err := alleleHash(actualAlt)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("serializing proof: %w", err)
+		return nil, err
 	}
 
-	// Serialize verifying key to bytes
-	vkBytes := make([]byte, 0)
-	vkWriter := &bytesWriter{data: &vkBytes}
-	_, err = vk.WriteTo(vkWriter)
+	alleles := call.Samples[0].GT
+	return &DynamicCircuit{
+		RefHash:         refHash,
+		AltHash:         altHash,
+		ClaimedGenotype: claimedGenotype,
+		ClaimMode:       int(p.Mode),
+		LocusHash:       locusHash,
+		ActualGenotype:  genotype,
+		Allele1:         alleles[0],
+		Allele2:         alleles[1],
+		AltIndex:        alternateIndex(call, actualAlt),
+		Chromosome:      chromosome,
+		Position:        position,
+		variant:         variant,
+	}, nil
+}
+
+// GenerateDynamic implements the DynamicProofGenerator interface
+func (p *DynamicProof) GenerateDynamic(vcfPath string, provingKeyPath string, outputPath string, position uint64, ref string, alt string) (*ProofData, error) {
+	log := loggerOrNop(p.Logger)
+	witness, err := p.assign(vcfPath, position, ref, alt)
 	if err != nil {
+		// Return ProofData with Fail result
 		return &ProofData{
 			Proof:         nil,
 			VerifyingKey:  nil,
 			PublicWitness: nil,
 			Result:        ProofFail,
-		}, fmt.Errorf("serializing verifying key: %w", err)
+		}, err
 	}
 
-	// Serialize public witness to bytes
-	publicWitnessData, err := publicWitness.MarshalBinary()
+	// Generate actual zk-SNARK proof using gnark
+	log.Infof("Generating %s proof for position %d", p.Mode, position)
+	
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewDynamicCircuit(p.MaxAlleleIndex), witness)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, fmt.Errorf("serializing public witness: %w", err)
+		return proofData, err
 	}
 
-	fmt.Printf("✅ Dynamic proof successfully generated for position %d!\n", position)
-
-	return &ProofData{
-		Proof:         proofBytes,
-		VerifyingKey:  vkBytes,
-		PublicWitness: publicWitnessData,
-		Result:        ProofSuccess,
-	}, nil
+	log.Infof("✅ Dynamic proof successfully generated for position %d!", position)
+	return proofData, nil
 }
 
 // Verify implements the Proof interface for DynamicProof
 func (p *DynamicProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
-	// Here you would implement the actual zk-SNARK proof verification
-	// For now, we'll simulate the verification process
-	fmt.Printf("Verifying proof for position %d\n", p.Position)
-	
-	// Simulate different verification outcomes based on simple heuristics
-	// In a real implementation, this would involve cryptographic verification
-	
-	// For demonstration, we'll simulate successful verification
-	result := &VerificationResult{
-		Result: ProofSuccess,
-		Error:  nil,
-	}
-	
-	return result, nil
+	return verifyProofFile(p.Logger, "dynamic", verifyingKeyPath, proofPath)
 }
 
 func (p *DynamicProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
-	// Verify dynamic proof directly from ProofData using gnark
-	
-	if len(proofData.Proof) == 0 || len(proofData.VerifyingKey) == 0 {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("invalid proof data: missing proof or verifying key"),
-		}, nil
-	}
-	
-	fmt.Printf("Verifying dynamic proof for position %d from ProofData...\n", p.Position)
-	
-	// Deserialize the verifying key
-	vk := groth16.NewVerifyingKey(ecc.BN254)
-	_, err := vk.ReadFrom(strings.NewReader(string(proofData.VerifyingKey)))
+	return verifySNARK(p.Logger, "dynamic", proofData)
+}
+
+// extractGenotypeAtPosition searches for a specific genomic position in the VCF file
+// and returns the genotype, reference, and alternate alleles. Indels frequently
+// share a position with SNP records, so when several records start at the
+// position the one carrying the expected alleles is used.
+func (p *DynamicProof) extractGenotypeAtPosition(vcfPath string, position uint64, expectedRef string, expectedAlt string) (int, string, string, error) {
+	call, err := p.findCall(vcfPath, position, expectedRef, expectedAlt)
 	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize verifying key: %w", err),
-		}, nil
+		return 0, "", "", err
 	}
-	
-	// Deserialize the proof
-	proof := groth16.NewProof(ecc.BN254)
-	_, err = proof.ReadFrom(strings.NewReader(string(proofData.Proof)))
+	return p.genotypeFromCall(call, expectedAlt)
+}
+
+// findCall returns the record at position carrying the expected alleles, on
+// p.Chromosome if it is set. If no record carries them the first record at
+// the position is returned so the caller can explain the mismatch.
+func (p *DynamicProof) findCall(vcfPath string, position uint64, expectedRef string, expectedAlt string) (*VariantCall, error) {
+	log := loggerOrNop(p.Logger)
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
 	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize proof: %w", err),
-		}, nil
+		return nil, err
 	}
-	
-	// Deserialize the public witness
-	publicWitness, err := witness.New(ecc.BN254.ScalarField())
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to create witness: %w", err),
-		}, nil
+	if err := checkBuild(source.Build(), p.Build, fmt.Sprintf("position %d", position)); err != nil {
+		return nil, err
 	}
-	err = publicWitness.UnmarshalBinary(proofData.PublicWitness)
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize public witness: %w", err),
-		}, nil
+
+	log.Infof("Searching for position %d in VCF file...", position)
+
+	chrom := ""
+	if p.Chromosome > 0 {
+		chrom = strconv.Itoa(p.Chromosome)
 	}
-	
-	// Perform gnark verification
-	err = groth16.Verify(proof, vk, publicWitness)
+	calls, err := lookupAlleles(source, chrom, position, expectedRef, expectedAlt)
 	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("proof verification failed: %w", err),
-		}, nil
+		return nil, err
 	}
-	
-	fmt.Printf("✅ Dynamic proof for position %d successfully verified!\n", p.Position)
-	
-	return &VerificationResult{
-		Result: ProofSuccess,
-		Error:  nil,
-	}, nil
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("%w: position %d not found in VCF file", ErrVariantNotFound, position)
+	}
+
+	for _, call := range calls {
+		if allelesMatch(expectedRef, call.Reference) && alternateIndex(call, expectedAlt) > 0 {
+			return call, nil
+		}
+	}
+	return calls[0], nil
 }
 
-// extractGenotypeAtPosition searches for a specific genomic position in the VCF file
-// and returns the genotype, reference, and alternate alleles
-func (p *DynamicProof) extractGenotypeAtPosition(vcfPath string, position uint64, expectedRef string, expectedAlt string) (int, string, string, error) {
-	f, err := os.Open(vcfPath)
-	if err != nil {
-		return 0, "", "", err
+// genotypeFromCall returns the first sample's genotype for the ALT allele
+// matching alt along with the record's reference and that allele. If no ALT
+// allele matches, the first one is used.
+func (p *DynamicProof) genotypeFromCall(call *VariantCall, alt string) (int, string, string, error) {
+	if len(call.Samples) == 0 {
+		return 0, "", "", fmt.Errorf("%w: VCF has no samples", ErrNoSampleData)
 	}
-	defer f.Close()
 
-	rdr, err := vcfgo.NewReader(f, false)
+	altIndex := alternateIndex(call, alt)
+	if altIndex == 0 {
+		altIndex = 1
+	}
+	genotype, err := p.parseGenotypeFromInts(call.Samples[0].GT, altIndex)
 	if err != nil {
-		return 0, "", "", err
+		return 0, "", "", fmt.Errorf("failed to parse genotype: %w", err)
 	}
 
-	fmt.Printf("Searching for position %d in VCF file...\n", position)
-	
-	for {
-		variant := rdr.Read()
-		if variant == nil {
-			break
-		}
+	actualAlt := ""
+	if altIndex <= len(call.Alternate) {
+		actualAlt = call.Alternate[altIndex-1]
+	}
+	return genotype, call.Reference, actualAlt, nil
+}
 
-		// Debug: print progress every 10000 positions
-		if variant.Pos%10000 == 0 {
-			fmt.Printf("Searching position: %d\n", variant.Pos)
+// alternateIndex returns the VCF allele index (1-based) of the record's ALT
+// allele matching alt, or 0 if it has none
+func alternateIndex(call *VariantCall, alt string) int {
+	for i, allele := range call.Alternate {
+		if allelesMatch(alt, allele) {
+			return i + 1
 		}
+	}
+	return 0
+}
 
-		if uint64(variant.Pos) == position {
-			fmt.Printf("Found variant at position %d\n", position)
-			
-			// Extract genotype from the first sample
-			if len(variant.Samples) == 0 {
-				return 0, "", "", fmt.Errorf("no samples found in VCF")
-			}
-			
-			sample := variant.Samples[0]
-			genotypeInts := sample.GT
-			
-			// Handle Reference and Alternate which can be strings or slices
-			ref := variant.Reference
-			alt := ""
-			if len(variant.Alternate) > 0 {
-				alt = variant.Alternate[0] // Use first alternate allele
-			}
-			
-			genotype, err := p.parseGenotypeFromInts(genotypeInts)
-			if err != nil {
-				return 0, "", "", fmt.Errorf("failed to parse genotype: %w", err)
-			}
-			
-			return genotype, ref, alt, nil
-		}
+// firstAlternate returns the record's first ALT allele, or "" if it has none
+func firstAlternate(call *VariantCall) string {
+	if len(call.Alternate) == 0 {
+		return ""
 	}
-	
-	return 0, "", "", fmt.Errorf("position %d not found in VCF file", position)
+	return call.Alternate[0]
 }
 
-// parseGenotypeFromInts converts VCF genotype from integer slice to genotype integer
-func (p *DynamicProof) parseGenotypeFromInts(genotypeInts []int) (int, error) {
+// allelesMatch reports whether two REF/ALT strings denote the same sequence.
+// Multi-base indel alleles are often soft-masked (lowercase) by callers, so
+// the comparison ignores case.
+func allelesMatch(expected string, actual string) bool {
+	return strings.EqualFold(expected, actual)
+}
+
+// parseGenotypeFromInts converts VCF genotype from integer slice to the number
+// of copies of the allele with index altIndex
+func (p *DynamicProof) parseGenotypeFromInts(genotypeInts []int, altIndex int) (int, error) {
 	if len(genotypeInts) != 2 {
 		return 0, fmt.Errorf("expected diploid genotype, got %d alleles", len(genotypeInts))
 	}
@@ -375,22 +330,34 @@ func (p *DynamicProof) parseGenotypeFromInts(genotypeInts []int) (int, error) {
 	
 	// Handle missing data
 	if allele1 < 0 || allele2 < 0 {
-		return 0, fmt.Errorf("missing genotype data")
+		return 0, fmt.Errorf("%w: genotype is not called", ErrNoSampleData)
 	}
 	
-	// Convert to genotype integer:
-	// 0/0 (homozygous reference) = 0
-	// 0/1 or 1/0 (heterozygous) = 1  
-	// 1/1 (homozygous alternate) = 2
-	if allele1 == 0 && allele2 == 0 {
-		return 0, nil // Homozygous reference
-	} else if (allele1 == 0 && allele2 == 1) || (allele1 == 1 && allele2 == 0) {
-		return 1, nil // Heterozygous
-	} else if allele1 == 1 && allele2 == 1 {
-		return 2, nil // Homozygous alternate
+	// The circuit range-checks allele indices against the same bound
+	maxAllele := p.maxAlleleIndex()
+	if allele1 > maxAllele || allele2 > maxAllele || altIndex > maxAllele {
+		return 0, fmt.Errorf("unsupported genotype: %v (allele indices above %d)", genotypeInts, maxAllele)
 	}
 	
-	return 0, fmt.Errorf("unsupported genotype: %v", genotypeInts)
+	// Convert to genotype integer by counting copies of the ALT allele:
+	// 0/0 or 0/2 (no copies) = 0
+	// 0/1 or 1/2 (heterozygous) = 1
+	// 1/1 (homozygous alternate) = 2
+	genotype := 0
+	for _, allele := range genotypeInts {
+		if allele == altIndex {
+			genotype++
+		}
+	}
+	return genotype, nil
+}
+
+// maxAlleleIndex returns the largest VCF allele index p accepts
+func (p *DynamicProof) maxAlleleIndex() int {
+	if p.MaxAlleleIndex > 0 {
+		return p.MaxAlleleIndex
+	}
+	return DefaultMaxAlleleIndex
 }
 
 // parseGenotype converts VCF genotype format (e.g., "0/0", "0/1", "1/1") to integer
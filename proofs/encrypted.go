@@ -0,0 +1,211 @@
+package proofs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// ErrEncryptedGenome is returned when a genome is age-encrypted and no
+// identity is registered that decrypts it
+var ErrEncryptedGenome = errors.New("genome is encrypted")
+
+// ageMagic starts binary age files; armored ones start with armor.Header
+const ageMagic = "age-encryption.org/v1\n"
+
+// identityRegistry holds the identities registered for decrypting genomes,
+// by path
+var identityRegistry = struct {
+	sync.Mutex
+	byPath map[string][]*[]age.Identity
+}{byPath: make(map[string][]*[]age.Identity)}
+
+// RegisterIdentities lets identities decrypt the genome at path, if it is
+// age-encrypted, until the returned function is called. Registrations for
+// the same path add up, so concurrent proofs over one genome may each
+// register theirs.
+func RegisterIdentities(path string, identities ...age.Identity) (release func()) {
+	entry := &identities
+	identityRegistry.Lock()
+	identityRegistry.byPath[path] = append(identityRegistry.byPath[path], entry)
+	identityRegistry.Unlock()
+
+	return func() {
+		identityRegistry.Lock()
+		defer identityRegistry.Unlock()
+		entries := slices.DeleteFunc(identityRegistry.byPath[path], func(e *[]age.Identity) bool { return e == entry })
+		if len(entries) == 0 {
+			delete(identityRegistry.byPath, path)
+		} else {
+			identityRegistry.byPath[path] = entries
+		}
+	}
+}
+
+// registeredIdentities returns the identities registered for path
+func registeredIdentities(path string) []age.Identity {
+	identityRegistry.Lock()
+	defer identityRegistry.Unlock()
+	var identities []age.Identity
+	for _, entry := range identityRegistry.byPath[path] {
+		identities = append(identities, *entry...)
+	}
+	return identities
+}
+
+// LoadIdentities reads age identities from an identity file, as written by
+// age-keygen
+func LoadIdentities(path string) ([]age.Identity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading identities from %s: %w", path, err)
+	}
+	return identities, nil
+}
+
+// IsEncrypted reports whether the genome at path is age-encrypted
+func IsEncrypted(path string) (bool, error) {
+	f, err := openStoredFile(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	_, encrypted := encryptionOf(f)
+	return encrypted, nil
+}
+
+// encryptionOf reports whether f is age-encrypted and whether it is armored
+func encryptionOf(f genomeFile) (armored bool, encrypted bool) {
+	start := make([]byte, len(armor.Header))
+	n, _ := f.ReadAt(start, 0)
+	start = start[:n]
+	switch {
+	case bytes.HasPrefix(start, []byte(ageMagic)):
+		return false, true
+	case bytes.HasPrefix(start, []byte(armor.Header)):
+		return true, true
+	}
+	return false, false
+}
+
+// openGenomeFile opens path like openStoredFile, decrypting age-encrypted
+// genomes with the identities registered for path as they are read
+func openGenomeFile(path string) (genomeFile, error) {
+	f, err := openStoredFile(path)
+	if err != nil {
+		return nil, err
+	}
+	armored, encrypted := encryptionOf(f)
+	if !encrypted {
+		return f, nil
+	}
+
+	identities := registeredIdentities(path)
+	if len(identities) == 0 {
+		f.Close()
+		return nil, fmt.Errorf("%w: %s is age-encrypted; give an identity to decrypt it", ErrEncryptedGenome, path)
+	}
+	decrypted := &decryptedFile{file: f, identities: identities, armored: armored}
+	if err := decrypted.rewind(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("decrypting %s: %w", path, err)
+	}
+	return decrypted, nil
+}
+
+// decryptedFile is an age-encrypted genome file read as its plaintext,
+// which is decrypted as it is read and never stored. Seeking backwards
+// decrypts again from the start.
+type decryptedFile struct {
+	file       genomeFile
+	identities []age.Identity
+	armored    bool
+
+	plain  io.Reader
+	offset int64
+}
+
+// rewind restarts decryption at the start of the plaintext
+func (f *decryptedFile) rewind() error {
+	if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	var src io.Reader = f.file
+	if f.armored {
+		src = armor.NewReader(src)
+	}
+	plain, err := age.Decrypt(src, f.identities...)
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		return fmt.Errorf("%w: no identity given decrypts it", ErrEncryptedGenome)
+	}
+	if err != nil {
+		return err
+	}
+	f.plain, f.offset = plain, 0
+	return nil
+}
+
+func (f *decryptedFile) Read(p []byte) (int, error) {
+	n, err := f.plain.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *decryptedFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		return 0, fmt.Errorf("encrypted genomes cannot be seeked from their end")
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("seek before the start of the file")
+	}
+	if offset < f.offset {
+		if err := f.rewind(); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := io.CopyN(io.Discard, f, offset-f.offset); err != nil && err != io.EOF {
+		return 0, err
+	}
+	return f.offset, nil
+}
+
+func (f *decryptedFile) ReadAt(p []byte, off int64) (int, error) {
+	if _, err := f.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(f, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Size returns the size of the encrypted file, which bounds the plaintext's
+func (f *decryptedFile) Size() int64 {
+	return f.file.Size()
+}
+
+func (f *decryptedFile) ModTime() time.Time {
+	return f.file.ModTime()
+}
+
+func (f *decryptedFile) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,70 @@
+package proofs
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestVCFSource_ReadsEncryptedVCF(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"1\t100\trs1\tA\tG\t60\tPASS\t.\tGT\t0/1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t1/1\n"
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var encrypted bytes.Buffer
+	w, err := age.Encrypt(&encrypted, identity.Recipient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(vcf))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	vcfPath := filepath.Join(t.TempDir(), "sample.vcf.age")
+	if err := os.WriteFile(vcfPath, encrypted.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewVCFSource(vcfPath, nil); !errors.Is(err, ErrEncryptedGenome) {
+		t.Fatalf("Expected ErrEncryptedGenome without an identity, got %v", err)
+	}
+
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := RegisterIdentities(vcfPath, other)
+	if _, err := NewVCFSource(vcfPath, nil); !errors.Is(err, ErrEncryptedGenome) {
+		t.Errorf("Expected ErrEncryptedGenome with the wrong identity, got %v", err)
+	}
+	release()
+
+	release = RegisterIdentities(vcfPath, identity)
+	source, err := NewVCFSource(vcfPath, nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource failed: %v", err)
+	}
+	calls, err := source.LookupVariant("12", 112241766)
+	if err != nil || len(calls) != 1 || calls[0].ID != "rs671" {
+		t.Fatalf("Expected rs671 at 12:112241766, got %+v: %v", calls, err)
+	}
+	// Looking up an earlier locus decrypts again from the start
+	calls, err = source.LookupVariant("1", 100)
+	if err != nil || len(calls) != 1 || calls[0].ID != "rs1" {
+		t.Errorf("Expected rs1 at 1:100, got %+v: %v", calls, err)
+	}
+	release()
+
+	if _, err := NewVCFSource(vcfPath, nil); !errors.Is(err, ErrEncryptedGenome) {
+		t.Errorf("Expected ErrEncryptedGenome once the identity is released, got %v", err)
+	}
+}
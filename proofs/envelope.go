@@ -0,0 +1,283 @@
+package proofs
+
+import (
+	"bufio"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// envelopeSignatureType is the JWS typ of envelope signatures
+const envelopeSignatureType = "zkgenomics-envelope+jws"
+
+// ErrUnsignedEnvelope is reported when a proof carries no signature from a
+// trusted issuer
+var ErrUnsignedEnvelope = errors.New("proof is not signed by a trusted issuer")
+
+// EnvelopeSigner signs proof envelopes on behalf of a lab or issuer.
+// Algorithm is the JWS algorithm name of the signatures Sign makes.
+type EnvelopeSigner interface {
+	Algorithm() string
+	KeyID() string
+	Sign(payload []byte) ([]byte, error)
+}
+
+// ES256Signer signs with ECDSA over P-256 and SHA-256
+type ES256Signer struct {
+	ID  string
+	Key *ecdsa.PrivateKey
+}
+
+func (s *ES256Signer) Algorithm() string { return "ES256" }
+func (s *ES256Signer) KeyID() string     { return s.ID }
+
+// Sign returns the JWS encoding of the signature: r and s, 32 bytes each
+func (s *ES256Signer) Sign(payload []byte) ([]byte, error) {
+	digest := sha256.Sum256(payload)
+	r, sig, err := ecdsa.Sign(rand.Reader, s.Key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 64)
+	r.FillBytes(out[:32])
+	sig.FillBytes(out[32:])
+	return out, nil
+}
+
+// EdDSASigner signs with Ed25519
+type EdDSASigner struct {
+	ID  string
+	Key ed25519.PrivateKey
+}
+
+func (s *EdDSASigner) Algorithm() string { return "EdDSA" }
+func (s *EdDSASigner) KeyID() string     { return s.ID }
+
+func (s *EdDSASigner) Sign(payload []byte) ([]byte, error) {
+	return ed25519.Sign(s.Key, payload), nil
+}
+
+// NewEnvelopeSigner returns the signer for a PKCS#8 ECDSA P-256 or Ed25519
+// private key
+func NewEnvelopeSigner(keyID string, key crypto.PrivateKey) (EnvelopeSigner, error) {
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		if key.Curve.Params().Name != "P-256" {
+			return nil, fmt.Errorf("ES256 requires a P-256 key")
+		}
+		return &ES256Signer{ID: keyID, Key: key}, nil
+	case ed25519.PrivateKey:
+		return &EdDSASigner{ID: keyID, Key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+}
+
+// signedEnvelope is what issuers sign: the claim, the proof bytes and the
+// metadata a verifier relies on. Its JSON encoding is the JWS payload.
+type signedEnvelope struct {
+	ProofType      string        `json:"proof_type"`
+	PublicValues   []PublicValue `json:"public_values"`
+	Proof          []byte        `json:"proof"`
+	VerifyingKey   []byte        `json:"verifying_key"`
+	PublicWitness  []byte        `json:"public_witness"`
+	CircuitID      string        `json:"circuit_id"`
+	CircuitVersion int           `json:"circuit_version"`
+	CircuitHash    string        `json:"circuit_hash"`
+	CreatedAt      time.Time     `json:"created_at"`
+	Binding        *Binding      `json:"binding,omitempty"`
+	// Backend is omitted for Groth16 proofs, so their payloads are unchanged
+	Backend string `json:"backend,omitempty"`
+	// Curve is omitted for BN254 proofs, for the same reason
+	Curve string `json:"curve,omitempty"`
+	// Provenance is omitted for proofs without one, for the same reason
+	Provenance *GenomeAttestation `json:"provenance,omitempty"`
+	// Parameters are omitted for proofs without any, for the same reason
+	Parameters *ProofParameters `json:"parameters,omitempty"`
+}
+
+// envelopePayload returns the JWS payload for proofData
+func envelopePayload(proofData *ProofData) ([]byte, error) {
+	values, err := PublicValues(proofData)
+	if err != nil {
+		return nil, err
+	}
+	curve := proofData.Curve
+	if curve == proofCurve {
+		curve = ""
+	}
+	return json.Marshal(signedEnvelope{
+		ProofType:      proofData.ProofType,
+		PublicValues:   values,
+		Proof:          proofData.Proof,
+		VerifyingKey:   proofData.VerifyingKey,
+		PublicWitness:  proofData.PublicWitness,
+		CircuitID:      proofData.CircuitID,
+		CircuitVersion: proofData.CircuitVersion,
+		CircuitHash:    proofData.CircuitHash,
+		CreatedAt:      proofData.CreatedAt,
+		Binding:        proofData.Binding,
+		Backend:        proofData.Backend,
+		Curve:          curve,
+		Provenance:     proofData.Provenance,
+		Parameters:     proofData.Parameters,
+	})
+}
+
+// jwsHeader is the protected header of an envelope signature
+type jwsHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Type      string `json:"typ"`
+}
+
+// SignEnvelope signs the envelope of proofData with signer and adds the
+// signature to proofData.Signatures, as a JWS with detached payload. The
+// proof must record the circuit that produced it.
+func SignEnvelope(proofData *ProofData, signer EnvelopeSigner) error {
+	payload, err := envelopePayload(proofData)
+	if err != nil {
+		return err
+	}
+	jws, err := signDetached(payload, signer, envelopeSignatureType)
+	if err != nil {
+		return fmt.Errorf("signing envelope: %w", err)
+	}
+	proofData.Signatures = append(proofData.Signatures, jws)
+	return nil
+}
+
+// CheckEnvelopeSignatures returns the ID of the first trusted key that signed
+// the envelope of proofData, or ErrUnsignedEnvelope if none did. Signatures
+// by unknown keys are skipped; a trusted key's invalid signature is an error.
+func CheckEnvelopeSignatures(proofData *ProofData, trusted map[string]crypto.PublicKey) (string, error) {
+	if len(proofData.Signatures) == 0 {
+		return "", ErrUnsignedEnvelope
+	}
+	payload, err := envelopePayload(proofData)
+	if err != nil {
+		return "", err
+	}
+	keyID, err := checkDetached(proofData.Signatures, payload, trusted)
+	if err == nil && keyID == "" {
+		err = ErrUnsignedEnvelope
+	}
+	return keyID, err
+}
+
+// signDetached signs payload with signer, returning a JWS of type typ with
+// detached payload
+func signDetached(payload []byte, signer EnvelopeSigner, typ string) (string, error) {
+	header, err := json.Marshal(jwsHeader{Algorithm: signer.Algorithm(), KeyID: signer.KeyID(), Type: typ})
+	if err != nil {
+		return "", err
+	}
+	encodedHeader := base64.RawURLEncoding.EncodeToString(header)
+	signature, err := signer.Sign([]byte(encodedHeader + "." + base64.RawURLEncoding.EncodeToString(payload)))
+	if err != nil {
+		return "", err
+	}
+	return encodedHeader + ".." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// checkDetached returns the ID of the first trusted key whose JWS among
+// signatures signs payload, or "" if none does. Signatures by unknown keys
+// are skipped; a trusted key's invalid signature is an error.
+func checkDetached(signatures []string, payload []byte, trusted map[string]crypto.PublicKey) (string, error) {
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	for _, jws := range signatures {
+		parts := strings.Split(jws, ".")
+		if len(parts) != 3 || parts[1] != "" {
+			return "", fmt.Errorf("malformed signature")
+		}
+		headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+		if err != nil {
+			return "", fmt.Errorf("malformed signature header: %w", err)
+		}
+		var header jwsHeader
+		if err := json.Unmarshal(headerJSON, &header); err != nil {
+			return "", fmt.Errorf("malformed signature header: %w", err)
+		}
+		key, ok := trusted[header.KeyID]
+		if !ok {
+			continue
+		}
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			return "", fmt.Errorf("malformed signature: %w", err)
+		}
+		if !verifyJWS(header.Algorithm, key, []byte(parts[0]+"."+encodedPayload), signature) {
+			return "", fmt.Errorf("invalid signature from key %q", header.KeyID)
+		}
+		return header.KeyID, nil
+	}
+	return "", nil
+}
+
+// verifyJWS checks a JWS signature, accepting only the algorithm that matches
+// the key's type
+func verifyJWS(algorithm string, key crypto.PublicKey, input []byte, signature []byte) bool {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if algorithm != "ES256" || len(signature) != 64 {
+			return false
+		}
+		digest := sha256.Sum256(input)
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(key, digest[:], r, s)
+	case ed25519.PublicKey:
+		return algorithm == "EdDSA" && ed25519.Verify(key, input, signature)
+	default:
+		return false
+	}
+}
+
+// LoadIssuerKeys reads trusted issuer keys, one "<key-id> <base64 PKIX public
+// key>" pair per line, holding ECDSA P-256 or Ed25519 keys. Blank lines and
+// lines starting with # are ignored.
+func LoadIssuerKeys(path string) (map[string]crypto.PublicKey, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys := make(map[string]crypto.PublicKey)
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected key id and public key", path, line)
+		}
+		der, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid public key", path, line)
+		}
+		key, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid public key: %w", path, line, err)
+		}
+		keys[fields[0]] = key
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,131 @@
+package proofs
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSignEnvelope(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	proofData, err := GenerateWithOptionsContext(context.Background(), &ALDH2Proof{}, vcfPath, GenerateOptions{})
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edPublic, edKey, _ := ed25519.GenerateKey(nil)
+	es256, err := NewEnvelopeSigner("lab-es", ecKey)
+	if err != nil {
+		t.Fatalf("NewEnvelopeSigner should not return error: %v", err)
+	}
+	eddsa, err := NewEnvelopeSigner("lab-ed", edKey)
+	if err != nil {
+		t.Fatalf("NewEnvelopeSigner should not return error: %v", err)
+	}
+	if _, err := CheckEnvelopeSignatures(proofData, map[string]crypto.PublicKey{"lab-ed": edPublic}); !errors.Is(err, ErrUnsignedEnvelope) {
+		t.Errorf("Expected an unsigned proof to fail with ErrUnsignedEnvelope, got %v", err)
+	}
+
+	for _, signer := range []EnvelopeSigner{es256, eddsa} {
+		if err := SignEnvelope(proofData, signer); err != nil {
+			t.Fatalf("SignEnvelope(%s) should not return error: %v", signer.Algorithm(), err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		trusted map[string]crypto.PublicKey
+		want    string
+	}{
+		{"ES256", map[string]crypto.PublicKey{"lab-es": &ecKey.PublicKey}, "lab-es"},
+		{"EdDSA", map[string]crypto.PublicKey{"lab-ed": edPublic}, "lab-ed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyID, err := CheckEnvelopeSignatures(proofData, tt.trusted)
+			if err != nil || keyID != tt.want {
+				t.Errorf("CheckEnvelopeSignatures = %q, %v, want %q", keyID, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := CheckEnvelopeSignatures(proofData, map[string]crypto.PublicKey{"other": edPublic}); !errors.Is(err, ErrUnsignedEnvelope) {
+		t.Errorf("Expected signatures by untrusted keys to be ignored, got %v", err)
+	}
+	// A key trusted under the other algorithm must not verify the signature
+	if _, err := CheckEnvelopeSignatures(proofData, map[string]crypto.PublicKey{"lab-ed": &ecKey.PublicKey}); err == nil {
+		t.Error("Expected an EdDSA signature to fail against an ECDSA key")
+	}
+
+	tampered := *proofData
+	tampered.CircuitHash = "0000"
+	if _, err := CheckEnvelopeSignatures(&tampered, map[string]crypto.PublicKey{"lab-ed": edPublic}); err == nil || errors.Is(err, ErrUnsignedEnvelope) {
+		t.Errorf("Expected a tampered envelope to fail its signature, got %v", err)
+	}
+
+	// Signatures survive the binary encodings
+	encoded, err := proofData.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR should not return error: %v", err)
+	}
+	var decoded ProofData
+	if err := decoded.UnmarshalCBOR(encoded); err != nil {
+		t.Fatalf("UnmarshalCBOR should not return error: %v", err)
+	}
+	if _, err := CheckEnvelopeSignatures(&decoded, tests[0].trusted); err != nil {
+		t.Errorf("Expected the signature to verify after a CBOR round trip, got %v", err)
+	}
+	encoded, err = proofData.MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto should not return error: %v", err)
+	}
+	decoded = ProofData{}
+	if err := decoded.UnmarshalProto(encoded); err != nil {
+		t.Fatalf("UnmarshalProto should not return error: %v", err)
+	}
+	if _, err := CheckEnvelopeSignatures(&decoded, tests[1].trusted); err != nil {
+		t.Errorf("Expected the signature to verify after a protobuf round trip, got %v", err)
+	}
+}
+
+func TestLoadIssuerKeys(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "issuers.txt")
+	content := "# trusted labs\n\nlab-es " + base64.StdEncoding.EncodeToString(der) + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing issuer keys: %v", err)
+	}
+
+	keys, err := LoadIssuerKeys(path)
+	if err != nil {
+		t.Fatalf("LoadIssuerKeys should not return error: %v", err)
+	}
+	if key, ok := keys["lab-es"].(*ecdsa.PublicKey); len(keys) != 1 || !ok || !key.Equal(&ecKey.PublicKey) {
+		t.Errorf("Expected the lab-es key, got %v", keys)
+	}
+
+	if err := os.WriteFile(path, []byte("lab-es not-a-key\n"), 0644); err != nil {
+		t.Fatalf("writing issuer keys: %v", err)
+	}
+	if _, err := LoadIssuerKeys(path); err == nil {
+		t.Error("Expected an invalid key to fail")
+	}
+}
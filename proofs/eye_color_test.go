@@ -0,0 +1,56 @@
+package proofs
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestEyeColorAndHERC2Proofs(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	396321	.	C	T	60	PASS	.	GT	0/1
+15	28365618	rs12913832	A	G	60	PASS	.	GT	1/1
+`)
+
+	eyeColor := &EyeColorProof{}
+	proofData, err := eyeColor.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	result, err := eyeColor.VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected the eye color proof to verify, got %v: %v", result, err)
+	}
+	if claim := result.ParsedPublicInputs["ClaimedValue"]; claim != strconv.Itoa(int(traits.EyeColorBlue)) {
+		t.Errorf("Expected G/G to claim blue eyes, got %s", claim)
+	}
+	eyeColorLocus := result.ParsedPublicInputs["LocusHash"]
+
+	herc2 := &HERC2Proof{}
+	proofData, err = herc2.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	result, err = herc2.VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected the HERC2 proof to verify, got %v: %v", result, err)
+	}
+	if claim := result.ParsedPublicInputs["ClaimedValue"]; claim != "1" {
+		t.Errorf("Expected G/G to claim the variant is carried, got %s", claim)
+	}
+	if result.ParsedPublicInputs["LocusHash"] != eyeColorLocus {
+		t.Error("Expected the eye color and HERC2 proofs to be about the same locus")
+	}
+
+	vcfPath = writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	396321	.	C	T	60	PASS	.	GT	0/1
+`)
+	if _, err := eyeColor.Generate(vcfPath, "", ""); err == nil {
+		t.Error("Expected a genome without rs12913832 not to be proven")
+	}
+}
@@ -0,0 +1,85 @@
+package proofs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// UnpinnedKeyError is reported when a proof's verifying key is not among the
+// fingerprints pinned for its proof type
+type UnpinnedKeyError struct {
+	ProofType   string
+	Fingerprint string
+}
+
+func (e *UnpinnedKeyError) Error() string {
+	return fmt.Sprintf("verifying key %s is not pinned for %s proofs", e.Fingerprint, e.ProofType)
+}
+
+// VKFingerprint returns the hex SHA-256 of the canonical encoding of the
+// serialized verifying key vk, of any backend. The key is decoded and encoded
+// again, so every encoding of one key has the same fingerprint.
+func VKFingerprint(vk []byte) (string, error) {
+	if len(vk) == 0 {
+		return "", fmt.Errorf("no verifying key")
+	}
+	key, err := decodeVerifyingKey(vk)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := key.WriteTo(h); err != nil {
+		return "", fmt.Errorf("serializing verifying key: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// decodeVerifyingKey decodes vk as a verifying key of the first backend and
+// curve whose encoding it is exactly
+func decodeVerifyingKey(vk []byte) (VerifyingKey, error) {
+	var err error
+	for _, name := range []string{BackendGroth16, BackendPLONK} {
+		for _, curve := range SupportedCurves {
+			backend, _ := BackendNamed(name, curve.String())
+			if name == BackendPLONK {
+				if err = checkPlonkKeyCurve(curve, vk); err != nil {
+					continue
+				}
+			}
+			key := backend.NewVerifyingKey()
+			var n int64
+			n, err = key.ReadFrom(bytes.NewReader(vk))
+			if err == nil && n == int64(len(vk)) {
+				return key, nil
+			}
+			if err == nil {
+				err = fmt.Errorf("%d trailing bytes", int64(len(vk))-n)
+			}
+		}
+	}
+	return nil, fmt.Errorf("failed to deserialize verifying key: %w", err)
+}
+
+// VKFingerprint returns the fingerprint of the verifying key bundled in the
+// proof, as for the package function VKFingerprint
+func (p *ProofData) VKFingerprint() (string, error) {
+	return VKFingerprint(p.VerifyingKey)
+}
+
+// CheckVKFingerprint returns an UnpinnedKeyError unless the verifying key of
+// proofData has one of the pinned fingerprints. Fingerprints are compared
+// ignoring case.
+func CheckVKFingerprint(proofData *ProofData, proofType string, pinned []string) error {
+	fingerprint, err := proofData.VKFingerprint()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(pinned, func(pin string) bool { return strings.EqualFold(pin, fingerprint) }) {
+		return &UnpinnedKeyError{ProofType: proofType, Fingerprint: fingerprint}
+	}
+	return nil
+}
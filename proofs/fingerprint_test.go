@@ -0,0 +1,49 @@
+package proofs
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestVKFingerprint(t *testing.T) {
+	store := &FileKeyStore{Dir: t.TempDir()}
+	key, err := SetupKeys(NewChromosomeCircuit(4), nil, store, nil)
+	if err != nil {
+		t.Fatalf("SetupKeys should not return error: %v", err)
+	}
+	_, vk, err := store.LoadKeys(key)
+	if err != nil {
+		t.Fatalf("LoadKeys should not return error: %v", err)
+	}
+	var compressed, raw bytes.Buffer
+	if _, err := vk.WriteTo(&compressed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vk.WriteRawTo(&raw); err != nil {
+		t.Fatal(err)
+	}
+
+	proofData := &ProofData{VerifyingKey: compressed.Bytes()}
+	fingerprint, err := proofData.VKFingerprint()
+	if err != nil {
+		t.Fatalf("VKFingerprint should not return error: %v", err)
+	}
+	if len(fingerprint) != 64 {
+		t.Errorf("Expected a hex SHA-256 fingerprint, got %q", fingerprint)
+	}
+	if rawFingerprint, err := VKFingerprint(raw.Bytes()); err != nil || rawFingerprint != fingerprint {
+		t.Errorf("Expected every encoding of the key to share its fingerprint, got %q: %v", rawFingerprint, err)
+	}
+	if _, err := VKFingerprint([]byte("not a key")); err == nil {
+		t.Error("Expected an error fingerprinting a malformed key")
+	}
+
+	if err := CheckVKFingerprint(proofData, "chromosome", []string{"00", fingerprint}); err != nil {
+		t.Errorf("Expected the pinned key to pass, got %v", err)
+	}
+	var unpinned *UnpinnedKeyError
+	if err := CheckVKFingerprint(proofData, "chromosome", []string{"00"}); !errors.As(err, &unpinned) || unpinned.Fingerprint != fingerprint {
+		t.Errorf("Expected UnpinnedKeyError for an unpinned key, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Package gadgets provides reusable constraints for genotype circuits, so
+// claims such as "genotype ∈ {1, 2}" or "allele count ≥ 1" can be expressed
+// without an exact-equality circuit per case. Genotypes are ALT allele
+// dosages: 0, 1 or 2.
+package gadgets
+
+import (
+	"github.com/consensys/gnark/frontend"
+)
+
+// AssertIsGenotype constrains g to be 0, 1 or 2
+func AssertIsGenotype(api frontend.API, g frontend.Variable) {
+	api.AssertIsEqual(api.Mul(g, api.Sub(g, 1), api.Sub(g, 2)), 0)
+}
+
+// IsInSet returns 1 if v equals any element of set and 0 otherwise. An empty
+// set contains nothing.
+func IsInSet(api frontend.API, v frontend.Variable, set ...frontend.Variable) frontend.Variable {
+	if len(set) == 0 {
+		return 0
+	}
+
+	// The product of the differences is zero exactly when v is in the set
+	var product frontend.Variable = 1
+	for _, element := range set {
+		product = api.Mul(product, api.Sub(v, element))
+	}
+	return api.IsZero(product)
+}
+
+// IsInRange returns 1 if lo ≤ v ≤ hi and 0 otherwise, comparing the values as
+// integers in [0, p)
+func IsInRange(api frontend.API, v frontend.Variable, lo frontend.Variable, hi frontend.Variable) frontend.Variable {
+	belowLo := isLess(api, v, lo)
+	aboveHi := isLess(api, hi, v)
+	return api.Mul(api.Sub(1, belowLo), api.Sub(1, aboveHi))
+}
+
+// isLess returns 1 if a < b and 0 otherwise
+func isLess(api frontend.API, a frontend.Variable, b frontend.Variable) frontend.Variable {
+	return api.IsZero(api.Add(api.Cmp(a, b), 1))
+}
+
+// SelectByGenotype returns values[g] for the genotype g and constrains g to be
+// 0, 1 or 2
+func SelectByGenotype(api frontend.API, g frontend.Variable, values [3]frontend.Variable) frontend.Variable {
+	var selectedCount frontend.Variable = 0
+	var selected frontend.Variable = 0
+	for genotype, value := range values {
+		isGenotype := api.IsZero(api.Sub(g, genotype))
+		selectedCount = api.Add(selectedCount, isGenotype)
+		selected = api.Add(selected, api.Mul(isGenotype, value))
+	}
+
+	// Exactly one genotype must match, which rules out out-of-range values
+	api.AssertIsEqual(selectedCount, 1)
+	return selected
+}
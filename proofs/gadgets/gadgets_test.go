@@ -0,0 +1,73 @@
+package gadgets
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+// gadgetCircuit asserts that each gadget evaluates to the expected public result
+type gadgetCircuit struct {
+	V        frontend.Variable
+	InSet    frontend.Variable `gnark:",public"`
+	InRange  frontend.Variable `gnark:",public"`
+	Selected frontend.Variable `gnark:",public"`
+}
+
+func (c *gadgetCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(IsInSet(api, c.V, 1, 2), c.InSet)
+	api.AssertIsEqual(IsInRange(api, c.V, 1, 2), c.InRange)
+	api.AssertIsEqual(SelectByGenotype(api, c.V, [3]frontend.Variable{10, 20, 30}), c.Selected)
+	return nil
+}
+
+func TestGadgets(t *testing.T) {
+	tests := []struct {
+		v, inSet, inRange, selected int
+	}{
+		{0, 0, 0, 10},
+		{1, 1, 1, 20},
+		{2, 1, 1, 30},
+	}
+
+	for _, tc := range tests {
+		assignment := &gadgetCircuit{V: tc.v, InSet: tc.inSet, InRange: tc.inRange, Selected: tc.selected}
+		if err := test.IsSolved(&gadgetCircuit{}, assignment, ecc.BN254.ScalarField()); err != nil {
+			t.Errorf("genotype %d: expected gadgets to evaluate to %+v: %v", tc.v, tc, err)
+		}
+
+		wrong := &gadgetCircuit{V: tc.v, InSet: 1 - tc.inSet, InRange: tc.inRange, Selected: tc.selected}
+		if err := test.IsSolved(&gadgetCircuit{}, wrong, ecc.BN254.ScalarField()); err == nil {
+			t.Errorf("genotype %d: expected a wrong IsInSet result to be rejected", tc.v)
+		}
+	}
+
+	// SelectByGenotype rejects values outside 0..2
+	assignment := &gadgetCircuit{V: 3, InSet: 0, InRange: 0, Selected: 0}
+	if err := test.IsSolved(&gadgetCircuit{}, assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Error("Expected genotype 3 to be rejected")
+	}
+}
+
+type genotypeCircuit struct {
+	G frontend.Variable
+}
+
+func (c *genotypeCircuit) Define(api frontend.API) error {
+	AssertIsGenotype(api, c.G)
+	return nil
+}
+
+func TestAssertIsGenotype(t *testing.T) {
+	for g := range 4 {
+		err := test.IsSolved(&genotypeCircuit{}, &genotypeCircuit{G: g}, ecc.BN254.ScalarField())
+		if g <= 2 && err != nil {
+			t.Errorf("Expected genotype %d to be accepted: %v", g, err)
+		}
+		if g > 2 && err == nil {
+			t.Errorf("Expected genotype %d to be rejected", g)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package proofs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// GenomeCommitment records the content digest of a VCF at the time it was
+// committed, so later proofs can be refused if the genome has been edited.
+// MerkleRoot, if set, is the public commitment committed variant proofs are
+// bound to; MerkleSalt is its secret blinding salt.
+type GenomeCommitment struct {
+	VCFPath    string    `json:"vcf_path"`
+	Digest     string    `json:"digest"`
+	CreatedAt  time.Time `json:"created_at"`
+	MerkleRoot string    `json:"merkle_root,omitempty"`
+	MerkleSalt string    `json:"merkle_salt,omitempty"`
+}
+
+// StaleCommitmentError is returned when a VCF no longer matches its commitment
+type StaleCommitmentError struct {
+	VCFPath        string
+	ExpectedDigest string
+	ActualDigest   string
+}
+
+func (e *StaleCommitmentError) Error() string {
+	return fmt.Sprintf("VCF %s changed since it was committed (committed digest %s, current digest %s); recommit the genome before proving",
+		e.VCFPath, e.ExpectedDigest, e.ActualDigest)
+}
+
+// CommitmentPath returns the sidecar file holding the commitment for vcfPath
+func CommitmentPath(vcfPath string) string {
+	return vcfPath + ".commitment.json"
+}
+
+// DigestFile returns the hex-encoded SHA-256 digest of the file contents
+func DigestFile(path string) (string, error) {
+	f, err := openStoredFile(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// CommitGenome computes a fresh commitment for the VCF
+func CommitGenome(vcfPath string) (*GenomeCommitment, error) {
+	digest, err := DigestFile(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GenomeCommitment{
+		VCFPath:   vcfPath,
+		Digest:    digest,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// LoadGenomeCommitment reads the commitment stored next to vcfPath. It returns
+// nil without error when the genome has never been committed.
+func LoadGenomeCommitment(vcfPath string) (*GenomeCommitment, error) {
+	data, err := os.ReadFile(CommitmentPath(vcfPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var commitment GenomeCommitment
+	if err := json.Unmarshal(data, &commitment); err != nil {
+		return nil, fmt.Errorf("decoding genome commitment: %w", err)
+	}
+	return &commitment, nil
+}
+
+// Save writes the commitment next to the committed VCF
+func (c *GenomeCommitment) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(CommitmentPath(c.VCFPath), data, 0644)
+}
+
+// Check returns a StaleCommitmentError if vcfPath no longer matches the commitment
+func (c *GenomeCommitment) Check(vcfPath string) error {
+	digest, err := DigestFile(vcfPath)
+	if err != nil {
+		return err
+	}
+	if digest != c.Digest {
+		return &StaleCommitmentError{
+			VCFPath:        vcfPath,
+			ExpectedDigest: c.Digest,
+			ActualDigest:   digest,
+		}
+	}
+	return nil
+}
+
+// CheckGenomeCommitment verifies vcfPath against its stored commitment, if any
+func CheckGenomeCommitment(vcfPath string) error {
+	commitment, err := LoadGenomeCommitment(vcfPath)
+	if err != nil {
+		return err
+	}
+	if commitment == nil {
+		return nil
+	}
+	return commitment.Check(vcfPath)
+}
+
+// Recommit replaces the stored commitment for vcfPath with one matching its
+// current contents and returns it
+func Recommit(vcfPath string) (*GenomeCommitment, error) {
+	commitment, err := CommitGenome(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := commitment.Save(); err != nil {
+		return nil, fmt.Errorf("saving genome commitment: %w", err)
+	}
+	return commitment, nil
+}
@@ -0,0 +1,46 @@
+package proofs
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestGenomeCommitment_DetectsEditedVCF(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO
+17	41276045	.	A	G	60	PASS	.
+`)
+	t.Cleanup(func() { os.Remove(CommitmentPath(vcfPath)) })
+
+	// Uncommitted genomes are not checked
+	if err := CheckGenomeCommitment(vcfPath); err != nil {
+		t.Fatalf("Expected no error for uncommitted VCF, got %v", err)
+	}
+
+	if _, err := Recommit(vcfPath); err != nil {
+		t.Fatalf("Recommit failed: %v", err)
+	}
+	if err := CheckGenomeCommitment(vcfPath); err != nil {
+		t.Fatalf("Expected committed VCF to match, got %v", err)
+	}
+
+	f, err := os.OpenFile(vcfPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open VCF: %v", err)
+	}
+	f.WriteString("17\t41276046\t.\tC\tT\t60\tPASS\t.\n")
+	f.Close()
+
+	var staleErr *StaleCommitmentError
+	if err := CheckGenomeCommitment(vcfPath); !errors.As(err, &staleErr) {
+		t.Fatalf("Expected StaleCommitmentError, got %v", err)
+	}
+
+	if _, err := Recommit(vcfPath); err != nil {
+		t.Fatalf("Recommit failed: %v", err)
+	}
+	if err := CheckGenomeCommitment(vcfPath); err != nil {
+		t.Errorf("Expected recommitted VCF to match, got %v", err)
+	}
+}
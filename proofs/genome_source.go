@@ -0,0 +1,992 @@
+package proofs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/brentp/vcfgo"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// SampleCall is one sample's genotype at a variant record
+type SampleCall struct {
+	GT     []int
+	Phased bool
+}
+
+// VariantCall is a variant record as seen by proof types
+type VariantCall struct {
+	Chromosome string
+	Position   uint64
+	ID         string
+	Reference  string
+	Alternate  []string
+	Quality    float32
+	Filter     string
+	Samples    []SampleCall
+	// ReferenceBlock is set on calls inferred from a gVCF reference block
+	// covering the position. Such calls carry no ALT allele, and their
+	// reference is "N" unless the block starts at the position.
+	ReferenceBlock bool
+	// ArrayCall is set on calls read from genotyping array raw data, which
+	// names the called bases rather than the reference allele. Their
+	// reference is "N", and their ALT alleles are the called bases.
+	ArrayCall bool
+}
+
+// GenomeSource is the input-processing layer proof types read genomes from.
+// Chromosome names are compared without a "chr" prefix, and an empty
+// chromosome matches every chromosome.
+type GenomeSource interface {
+	// LookupVariant returns every record starting at chrom:pos, or none if
+	// absent. Sources over gVCFs may return a ReferenceBlock call instead.
+	LookupVariant(chrom string, pos uint64) ([]*VariantCall, error)
+	// IterateRegion calls fn for each record within [start, end] on chrom
+	// until fn returns false
+	IterateRegion(chrom string, start uint64, end uint64, fn func(*VariantCall) bool) error
+	// SampleNames returns the names of the samples in the genome
+	SampleNames() []string
+	// Build returns the reference assembly of the coordinates
+	Build() traits.GenomeBuild
+}
+
+// ErrRedacted is returned when a lookup falls outside the loci a
+// RedactingSource exposes
+var ErrRedacted = errors.New("locus is redacted")
+
+// Locus is a position on a chromosome
+type Locus struct {
+	Chromosome string
+	Position   uint64
+}
+
+// Prefetcher is implemented by sources that can look up many loci in one
+// pass, so proofs reading a panel of loci do not rescan the genome per locus
+type Prefetcher interface {
+	// Prefetch looks up loci ahead of their LookupVariant calls
+	Prefetch(loci []Locus) error
+}
+
+// Prefetch looks up loci in source ahead of their LookupVariant calls if the
+// source is a Prefetcher, and does nothing otherwise
+func Prefetch(source GenomeSource, loci []Locus) error {
+	if prefetcher, ok := source.(Prefetcher); ok {
+		return prefetcher.Prefetch(loci)
+	}
+	return nil
+}
+
+// prefetchPanel prefetches the loci of variants; variants without a
+// chromosome are looked up one by one
+func prefetchPanel(source GenomeSource, variants []traits.TraitVariant) error {
+	loci := make([]Locus, 0, len(variants))
+	for _, variant := range variants {
+		if variant.Chromosome > 0 {
+			loci = append(loci, Locus{Chromosome: strconv.Itoa(variant.Chromosome), Position: uint64(variant.Position)})
+		}
+	}
+	return Prefetch(source, loci)
+}
+
+// sameChromosome reports whether two chromosome names refer to the same chromosome
+func sameChromosome(a string, b string) bool {
+	if a == "" || b == "" {
+		return true
+	}
+	return normalizeChromosome(a) == normalizeChromosome(b)
+}
+
+// normalizeChromosome strips the "chr" prefix and canonicalizes mitochondrial names
+func normalizeChromosome(chrom string) string {
+	chrom = strings.ToUpper(strings.TrimPrefix(strings.TrimPrefix(chrom, "chr"), "CHR"))
+	if chrom == "M" {
+		return "MT"
+	}
+	return chrom
+}
+
+// VCFSource reads variants from a VCF file. Queries on a chromosome of a
+// bgzip-compressed VCF with a tabix (.tbi) or CSI (.csi) index next to it
+// seek to the records through the index; other queries scan the file.
+type VCFSource struct {
+	path     string
+	progress ProgressReporter
+	samples  []string
+	build    traits.GenomeBuild
+	header   *vcfgo.Header
+	index    *vcfIndex
+
+	mu         sync.Mutex
+	prefetched map[Locus][]*VariantCall
+}
+
+// NewVCFSource opens vcfPath, which may be bgzip-compressed, and reads its
+// header. Scans report to progress if non-nil. An index that cannot be read
+// is ignored, falling back to scans.
+func NewVCFSource(vcfPath string, progress ProgressReporter) (*VCFSource, error) {
+	f, err := openVCFFile(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rdr, err := vcfgo.NewReader(f, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedVCF, err)
+	}
+
+	source := &VCFSource{
+		path:     vcfPath,
+		progress: progress,
+		samples:  rdr.Header.SampleNames,
+		build:    detectBuild(rdr.Header),
+		header:   rdr.Header,
+	}
+	// Encrypted VCFs cannot be seeked into, so their index is not used
+	if _, encrypted := f.file.(*decryptedFile); f.compressed && !encrypted {
+		source.index, _ = loadVCFIndex(vcfPath, f.file.ModTime())
+	}
+	return source, nil
+}
+
+// LookupVariant returns the records starting at chrom:pos. In a gVCF, a
+// position with no record of its own may be covered by a reference block;
+// the block is then returned as a call at the position, with the block's
+// genotypes, marked ReferenceBlock.
+func (s *VCFSource) LookupVariant(chrom string, pos uint64) ([]*VariantCall, error) {
+	if chrom != "" {
+		s.mu.Lock()
+		calls, ok := s.prefetched[Locus{Chromosome: normalizeChromosome(chrom), Position: pos}]
+		s.mu.Unlock()
+		if ok {
+			return calls, nil
+		}
+	}
+
+	var calls []*VariantCall
+	var block *VariantCall
+	err := s.scanRegion(chrom, pos, pos, func(variant *vcfgo.Variant) bool {
+		if sameChromosome(chrom, variant.Chromosome) {
+			end, isBlock := referenceBlockEnd(variant)
+			switch {
+			case isBlock && variant.Pos <= pos && pos <= end && block == nil:
+				block = referenceBlockCall(variant, pos)
+				return true
+			case !isBlock && variant.Pos == pos:
+				calls = append(calls, newVariantCall(variant))
+				return true
+			}
+		}
+		// Records at one position of a chromosome are adjacent, and blocks
+		// do not overlap records, so stop once they are behind us
+		return chrom == "" || (len(calls) == 0 && block == nil)
+	})
+	if len(calls) == 0 && block != nil {
+		calls = []*VariantCall{block}
+	}
+	return calls, err
+}
+
+// IterateRegion calls fn for each record within [start, end] on chrom. The
+// records of a chromosome are taken to be sorted, as for Prefetch, so the
+// scan of a named chromosome ends after the region.
+func (s *VCFSource) IterateRegion(chrom string, start uint64, end uint64, fn func(*VariantCall) bool) error {
+	return s.scanRegion(chrom, start, end, func(variant *vcfgo.Variant) bool {
+		if !sameChromosome(chrom, variant.Chromosome) {
+			return true
+		}
+		if variant.Pos > end {
+			return chrom == ""
+		}
+		if variant.Pos < start {
+			return true
+		}
+		return fn(newVariantCall(variant))
+	})
+}
+
+func (s *VCFSource) SampleNames() []string {
+	return s.samples
+}
+
+func (s *VCFSource) Build() traits.GenomeBuild {
+	return s.build
+}
+
+// Prefetch looks up every locus in one pass over the file, so that their
+// LookupVariant calls do not each scan it. Records of a chromosome are taken
+// to be contiguous and sorted by position, as the VCF specification requires,
+// so the pass ends after the last locus. Loci without a chromosome are left
+// to LookupVariant, and indexed files need no prefetching.
+func (s *VCFSource) Prefetch(loci []Locus) error {
+	if s.index != nil {
+		return nil
+	}
+	pending := make(map[string][]uint64)
+	for _, locus := range loci {
+		if locus.Chromosome != "" {
+			chrom := normalizeChromosome(locus.Chromosome)
+			pending[chrom] = append(pending[chrom], locus.Position)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	requested := make(map[Locus]bool)
+	for chrom, positions := range pending {
+		slices.Sort(positions)
+		pending[chrom] = slices.Compact(positions)
+		for _, pos := range pending[chrom] {
+			requested[Locus{Chromosome: chrom, Position: pos}] = true
+		}
+	}
+
+	found := make(map[Locus][]*VariantCall)
+	blocks := make(map[Locus]*VariantCall)
+	current := ""
+	err := s.scan(func(variant *vcfgo.Variant) bool {
+		chrom := normalizeChromosome(variant.Chromosome)
+		if chrom != current {
+			// The chromosome left behind has no further records
+			delete(pending, current)
+			current = chrom
+			if len(pending) == 0 {
+				return false
+			}
+		}
+		positions, ok := pending[chrom]
+		if !ok {
+			return true
+		}
+
+		if end, isBlock := referenceBlockEnd(variant); isBlock {
+			i, _ := slices.BinarySearch(positions, variant.Pos)
+			for ; i < len(positions) && positions[i] <= end; i++ {
+				locus := Locus{Chromosome: chrom, Position: positions[i]}
+				if blocks[locus] == nil {
+					blocks[locus] = referenceBlockCall(variant, positions[i])
+				}
+			}
+		} else if _, ok := slices.BinarySearch(positions, variant.Pos); ok {
+			locus := Locus{Chromosome: chrom, Position: variant.Pos}
+			found[locus] = append(found[locus], newVariantCall(variant))
+		}
+
+		if variant.Pos > positions[len(positions)-1] {
+			delete(pending, chrom)
+			return len(pending) > 0
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.prefetched == nil {
+		s.prefetched = make(map[Locus][]*VariantCall)
+	}
+	for locus := range requested {
+		calls := found[locus]
+		if len(calls) == 0 && blocks[locus] != nil {
+			calls = []*VariantCall{blocks[locus]}
+		}
+		s.prefetched[locus] = calls
+	}
+	return nil
+}
+
+// scanRegion calls fn until it returns false for the records of the file
+// that may overlap chrom:start-end, an empty chrom matching every
+// chromosome. Through the index these are the records from the first one
+// overlapping the region to the last one starting in it; without an index
+// they are all records.
+func (s *VCFSource) scanRegion(chrom string, start uint64, end uint64, fn func(*vcfgo.Variant) bool) error {
+	if s.index == nil {
+		return s.scan(fn)
+	}
+	if s.progress != nil {
+		defer s.progress("scan", 100, "records read through the index")
+	}
+	chromosomes := []string{chrom}
+	if chrom == "" {
+		chromosomes = s.index.names
+	}
+	for _, name := range chromosomes {
+		if stopped, err := s.scanIndexed(name, start, end, fn); stopped || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanIndexed calls fn for the records of chrom the index locates for
+// chrom:start-end, reporting whether fn stopped the scan
+func (s *VCFSource) scanIndexed(chrom string, start uint64, end uint64, fn func(*vcfgo.Variant) bool) (bool, error) {
+	offset, ok := s.index.offset(chrom, start, end)
+	if !ok {
+		return false, nil
+	}
+
+	f, err := openGenomeFile(s.path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	text, err := seekBGZF(f, offset)
+	if err != nil {
+		return false, err
+	}
+	rdr, err := vcfgo.NewWithHeader(text, s.header, false)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrMalformedVCF, err)
+	}
+
+	for {
+		variant := rdr.Read()
+		if variant == nil || !sameChromosome(chrom, variant.Chromosome) || variant.Pos > end {
+			return false, nil
+		}
+		if !fn(variant) {
+			return true, nil
+		}
+	}
+}
+
+// scan calls fn for every record in the file until fn returns false
+func (s *VCFSource) scan(fn func(*vcfgo.Variant) bool) error {
+	rdr, err := openVCF(s.path, s.progress)
+	if err != nil {
+		return err
+	}
+	defer rdr.Close()
+
+	for {
+		variant := rdr.Read()
+		if variant == nil {
+			return nil
+		}
+		if !fn(variant) {
+			return nil
+		}
+	}
+}
+
+// newVariantCall converts a parsed VCF record
+func newVariantCall(variant *vcfgo.Variant) *VariantCall {
+	samples := make([]SampleCall, len(variant.Samples))
+	for i, sample := range variant.Samples {
+		if sample != nil {
+			samples[i] = SampleCall{GT: sample.GT, Phased: sample.Phased}
+		}
+	}
+
+	return &VariantCall{
+		Chromosome: variant.Chromosome,
+		Position:   variant.Pos,
+		ID:         variant.Id(),
+		Reference:  variant.Reference,
+		Alternate:  variant.Alternate,
+		Quality:    variant.Quality,
+		Filter:     variant.Filter,
+		Samples:    samples,
+	}
+}
+
+// referenceBlockEnd returns the last position of a gVCF reference block,
+// reporting whether variant is one: a record whose only ALT allele is the
+// symbolic <NON_REF> or <*>, or missing, and whose INFO has an END
+func referenceBlockEnd(variant *vcfgo.Variant) (uint64, bool) {
+	for _, alt := range variant.Alternate {
+		if alt != "<NON_REF>" && alt != "<*>" && alt != "." {
+			return 0, false
+		}
+	}
+	if variant.Info_ == nil {
+		return 0, false
+	}
+	value, _ := variant.Info().Get("END")
+	end, err := strconv.ParseUint(fmt.Sprint(value), 10, 64)
+	if err != nil || end < variant.Pos {
+		return 0, false
+	}
+	return end, true
+}
+
+// referenceBlockCall returns the call a reference block makes at pos
+func referenceBlockCall(variant *vcfgo.Variant, pos uint64) *VariantCall {
+	call := newVariantCall(variant)
+	call.Position = pos
+	call.Alternate = nil
+	call.ReferenceBlock = true
+	if pos != variant.Pos {
+		call.Reference = "N"
+	}
+	return call
+}
+
+// lookupAlleles looks up chrom:pos like LookupVariant, presenting calls from
+// reference blocks and genotyping arrays, which do not name the reference
+// allele, as calls of the ref and alt alleles, so callers can match them.
+// Records of ref>alt written another way, such as trimmed differently,
+// split from a multi-allelic site with their padding kept, or an indel not
+// left-aligned, are presented as written as expected too. An indel with no
+// such record at pos is looked for nearby, where it may start if padded or
+// shifted.
+func lookupAlleles(source GenomeSource, chrom string, pos uint64, ref string, alt string) ([]*VariantCall, error) {
+	calls, err := source.LookupVariant(chrom, pos)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make([]*VariantCall, len(calls))
+	matched := false
+	for i, call := range calls {
+		resolved[i] = call
+		switch {
+		case call.ReferenceBlock:
+			inferred := *call
+			inferred.Reference = ref
+			inferred.Alternate = []string{alt}
+			resolved[i] = &inferred
+		case call.ArrayCall:
+			resolved[i] = resolveArrayCall(call, ref, alt)
+		case allelesMatch(ref, call.Reference) && alternateIndex(call, alt) > 0:
+			matched = true
+		default:
+			if normalized := normalizedCall(call, pos, ref, alt); normalized != nil {
+				resolved[i], matched = normalized, true
+			}
+		}
+	}
+	if matched || !isIndel(ref, alt) || (len(calls) > 0 && calls[0].ReferenceBlock) {
+		return resolved, nil
+	}
+
+	var nearby []*VariantCall
+	start := uint64(1)
+	if pos > normalizationWindow {
+		start = pos - normalizationWindow
+	}
+	err = source.IterateRegion(chrom, start, pos+uint64(len(ref))+normalizationWindow, func(call *VariantCall) bool {
+		if call.Position != pos && !call.ReferenceBlock && !call.ArrayCall {
+			if normalized := normalizedCall(call, pos, ref, alt); normalized != nil {
+				nearby = append(nearby, normalized)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(nearby, resolved...), nil
+}
+
+// resolveArrayCall returns a genotyping array call as a call of the ref and
+// alt alleles. Called bases that are neither become further ALT alleles.
+// Arrays call indels as a deletion (D) or insertion (I), which are the
+// shorter and the longer of the two alleles.
+func resolveArrayCall(call *VariantCall, ref string, alt string) *VariantCall {
+	resolved := *call
+	resolved.Reference = ref
+	resolved.Alternate = []string{alt}
+	resolved.Samples = make([]SampleCall, len(call.Samples))
+	for i, sample := range call.Samples {
+		gt := make([]int, len(sample.GT))
+		for j, allele := range sample.GT {
+			if allele <= 0 || allele > len(call.Alternate) {
+				gt[j] = allele
+				continue
+			}
+			base := call.Alternate[allele-1]
+			shorter, longer := ref, alt
+			if len(alt) < len(ref) {
+				shorter, longer = alt, ref
+			}
+			switch base {
+			case "D":
+				base = shorter
+			case "I":
+				base = longer
+			}
+			if allelesMatch(ref, base) {
+				continue
+			}
+			index := slices.IndexFunc(resolved.Alternate, func(a string) bool { return allelesMatch(a, base) })
+			if index < 0 {
+				resolved.Alternate = append(resolved.Alternate, base)
+				index = len(resolved.Alternate) - 1
+			}
+			gt[j] = index + 1
+		}
+		resolved.Samples[i] = SampleCall{GT: gt, Phased: sample.Phased}
+	}
+	return &resolved
+}
+
+// detectBuild infers the reference assembly from the ##reference header line
+// or, failing that, from the assembly or lengths of the ##contig lines
+func detectBuild(header *vcfgo.Header) traits.GenomeBuild {
+	for _, line := range header.Extras {
+		if build := traits.BuildFromHeaderLine(line); build != traits.BuildUnknown {
+			return build
+		}
+	}
+	for _, contig := range header.Contigs {
+		if build := traits.BuildFromName(contig["assembly"]); build != traits.BuildUnknown {
+			return build
+		}
+		length, _ := strconv.ParseUint(contig["length"], 10, 64)
+		if build := traits.BuildFromContig(contig["ID"], length); build != traits.BuildUnknown {
+			return build
+		}
+	}
+	return traits.BuildUnknown
+}
+
+// BuildMismatchError is returned when a proof's coordinates are in another
+// reference assembly than the genome, where they would find the wrong locus
+// or none
+type BuildMismatchError struct {
+	// Locus names the coordinates, such as a trait
+	Locus       string
+	LocusBuild  traits.GenomeBuild
+	GenomeBuild traits.GenomeBuild
+}
+
+func (e *BuildMismatchError) Error() string {
+	locus := e.Locus
+	if locus == "" {
+		locus = "the variant"
+	}
+	return fmt.Sprintf("%s is in %s coordinates, but the genome is %s; lift the genome over to %s first (zkgenomics genotools liftover)",
+		locus, e.LocusBuild, e.GenomeBuild, e.LocusBuild)
+}
+
+// checkBuild refuses coordinates of locus in build for a genome in another
+// build. Either build being unknown passes, as VCFs often do not record theirs.
+func checkBuild(genome traits.GenomeBuild, build traits.GenomeBuild, locus string) error {
+	if genome == traits.BuildUnknown || build == traits.BuildUnknown || genome == build {
+		return nil
+	}
+	return &BuildMismatchError{Locus: locus, LocusBuild: build, GenomeBuild: genome}
+}
+
+// CachingSource memoizes variant lookups of the wrapped source, so several
+// proofs over the same genome do not rescan it. It is safe for concurrent use.
+type CachingSource struct {
+	GenomeSource
+
+	mu      sync.Mutex
+	lookups map[string][]*VariantCall
+}
+
+// NewCachingSource wraps source with a lookup cache
+func NewCachingSource(source GenomeSource) *CachingSource {
+	return &CachingSource{
+		GenomeSource: source,
+		lookups:      make(map[string][]*VariantCall),
+	}
+}
+
+func (s *CachingSource) LookupVariant(chrom string, pos uint64) ([]*VariantCall, error) {
+	key := fmt.Sprintf("%s:%d", normalizeChromosome(chrom), pos)
+
+	s.mu.Lock()
+	calls, ok := s.lookups[key]
+	s.mu.Unlock()
+	if ok {
+		return calls, nil
+	}
+
+	calls, err := s.GenomeSource.LookupVariant(chrom, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.lookups[key] = calls
+	s.mu.Unlock()
+	return calls, nil
+}
+
+func (s *CachingSource) Prefetch(loci []Locus) error {
+	return Prefetch(s.GenomeSource, loci)
+}
+
+// QualityFilterSource hides records below a minimum QUAL or, optionally,
+// records that did not pass all filters
+type QualityFilterSource struct {
+	GenomeSource
+
+	MinQuality  float32
+	RequirePass bool
+}
+
+// NewQualityFilterSource wraps source with a quality filter
+func NewQualityFilterSource(source GenomeSource, minQuality float32, requirePass bool) *QualityFilterSource {
+	return &QualityFilterSource{
+		GenomeSource: source,
+		MinQuality:   minQuality,
+		RequirePass:  requirePass,
+	}
+}
+
+func (s *QualityFilterSource) LookupVariant(chrom string, pos uint64) ([]*VariantCall, error) {
+	calls, err := s.GenomeSource.LookupVariant(chrom, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	var passed []*VariantCall
+	for _, call := range calls {
+		if s.passes(call) {
+			passed = append(passed, call)
+		}
+	}
+	return passed, nil
+}
+
+func (s *QualityFilterSource) IterateRegion(chrom string, start uint64, end uint64, fn func(*VariantCall) bool) error {
+	return s.GenomeSource.IterateRegion(chrom, start, end, func(call *VariantCall) bool {
+		if !s.passes(call) {
+			return true
+		}
+		return fn(call)
+	})
+}
+
+func (s *QualityFilterSource) Prefetch(loci []Locus) error {
+	return Prefetch(s.GenomeSource, loci)
+}
+
+func (s *QualityFilterSource) passes(call *VariantCall) bool {
+	if call.Quality < s.MinQuality {
+		return false
+	}
+	return !s.RequirePass || call.Filter == "PASS" || call.Filter == "."
+}
+
+// Region is an inclusive range of positions on a chromosome
+type Region struct {
+	Chromosome string
+	Start      uint64
+	End        uint64
+}
+
+// Contains reports whether chrom:pos lies in the region
+func (r Region) Contains(chrom string, pos uint64) bool {
+	return sameChromosome(r.Chromosome, chrom) && pos >= r.Start && pos <= r.End
+}
+
+// RedactingSource exposes only the allowed regions of the wrapped source and
+// anonymizes sample names, so a proof only ever sees the loci it needs
+type RedactingSource struct {
+	GenomeSource
+
+	Allowed []Region
+}
+
+// NewRedactingSource wraps source so that only the allowed regions are visible
+func NewRedactingSource(source GenomeSource, allowed []Region) *RedactingSource {
+	return &RedactingSource{
+		GenomeSource: source,
+		Allowed:      allowed,
+	}
+}
+
+func (s *RedactingSource) LookupVariant(chrom string, pos uint64) ([]*VariantCall, error) {
+	if !s.allowed(chrom, pos) {
+		return nil, fmt.Errorf("%s:%d: %w", chrom, pos, ErrRedacted)
+	}
+	return s.GenomeSource.LookupVariant(chrom, pos)
+}
+
+func (s *RedactingSource) IterateRegion(chrom string, start uint64, end uint64, fn func(*VariantCall) bool) error {
+	return s.GenomeSource.IterateRegion(chrom, start, end, func(call *VariantCall) bool {
+		if !s.allowed(call.Chromosome, call.Position) {
+			return true
+		}
+		return fn(call)
+	})
+}
+
+func (s *RedactingSource) SampleNames() []string {
+	names := make([]string, len(s.GenomeSource.SampleNames()))
+	for i := range names {
+		names[i] = fmt.Sprintf("sample%d", i+1)
+	}
+	return names
+}
+
+// Prefetch prefetches the allowed loci only
+func (s *RedactingSource) Prefetch(loci []Locus) error {
+	var allowed []Locus
+	for _, locus := range loci {
+		if s.allowed(locus.Chromosome, locus.Position) {
+			allowed = append(allowed, locus)
+		}
+	}
+	return Prefetch(s.GenomeSource, allowed)
+}
+
+func (s *RedactingSource) allowed(chrom string, pos uint64) bool {
+	for _, region := range s.Allowed {
+		if region.Contains(chrom, pos) {
+			return true
+		}
+	}
+	return false
+}
+
+// LiftoverBlock maps an ungapped block of positions between two builds:
+// positions in [Start, End] on Chromosome of the target build correspond to
+// position+Offset in the source build
+type LiftoverBlock struct {
+	Chromosome string
+	Start      uint64
+	End        uint64
+	Offset     int64
+}
+
+// LiftoverSource presents a source in another build by translating
+// coordinates through a list of liftover blocks. Positions outside every
+// block are treated as absent.
+type LiftoverSource struct {
+	GenomeSource
+
+	Target traits.GenomeBuild
+	Blocks []LiftoverBlock
+}
+
+// NewLiftoverSource wraps source so it can be queried in target build coordinates
+func NewLiftoverSource(source GenomeSource, target traits.GenomeBuild, blocks []LiftoverBlock) *LiftoverSource {
+	return &LiftoverSource{
+		GenomeSource: source,
+		Target:       target,
+		Blocks:       blocks,
+	}
+}
+
+func (s *LiftoverSource) LookupVariant(chrom string, pos uint64) ([]*VariantCall, error) {
+	block, ok := s.blockFor(chrom, pos)
+	if !ok {
+		return nil, nil
+	}
+
+	calls, err := s.GenomeSource.LookupVariant(chrom, uint64(int64(pos)+block.Offset))
+	if err != nil {
+		return nil, err
+	}
+	lifted := make([]*VariantCall, len(calls))
+	for i, call := range calls {
+		lifted[i] = withPosition(call, pos)
+	}
+	return lifted, nil
+}
+
+func (s *LiftoverSource) IterateRegion(chrom string, start uint64, end uint64, fn func(*VariantCall) bool) error {
+	for _, block := range s.Blocks {
+		if !sameChromosome(chrom, block.Chromosome) || block.End < start || block.Start > end {
+			continue
+		}
+		from := max(start, block.Start)
+		to := min(end, block.End)
+
+		stopped := false
+		err := s.GenomeSource.IterateRegion(block.Chromosome, uint64(int64(from)+block.Offset), uint64(int64(to)+block.Offset), func(call *VariantCall) bool {
+			if !fn(withPosition(call, uint64(int64(call.Position)-block.Offset))) {
+				stopped = true
+				return false
+			}
+			return true
+		})
+		if err != nil || stopped {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *LiftoverSource) Build() traits.GenomeBuild {
+	return s.Target
+}
+
+// Prefetch prefetches the loci translated to the build of the wrapped source
+func (s *LiftoverSource) Prefetch(loci []Locus) error {
+	var lifted []Locus
+	for _, locus := range loci {
+		if block, ok := s.blockFor(locus.Chromosome, locus.Position); ok {
+			lifted = append(lifted, Locus{Chromosome: locus.Chromosome, Position: uint64(int64(locus.Position) + block.Offset)})
+		}
+	}
+	return Prefetch(s.GenomeSource, lifted)
+}
+
+func (s *LiftoverSource) blockFor(chrom string, pos uint64) (LiftoverBlock, bool) {
+	for _, block := range s.Blocks {
+		if sameChromosome(chrom, block.Chromosome) && pos >= block.Start && pos <= block.End {
+			return block, true
+		}
+	}
+	return LiftoverBlock{}, false
+}
+
+// SampleNotFoundError is returned when a sample is selected that the genome
+// does not have
+type SampleNotFoundError struct {
+	Sample    string
+	Available []string
+}
+
+func (e *SampleNotFoundError) Error() string {
+	if len(e.Available) == 0 {
+		return fmt.Sprintf("sample %q not found: the genome has no samples", e.Sample)
+	}
+	return fmt.Sprintf("sample %q not found; the genome has samples %s", e.Sample, strings.Join(e.Available, ", "))
+}
+
+// SampleSource presents one sample of a multi-sample genome, such as a trio
+// or cohort VCF, as the only sample, so proofs reading the first sample read
+// the selected one
+type SampleSource struct {
+	GenomeSource
+
+	// Index is the position of the sample among the samples of the source
+	Index int
+}
+
+// NewSampleSource wraps source so that only sample is visible. The sample
+// is named as in the VCF header or, failing that, by its index counting from
+// 0. A sample the source lacks is reported as a SampleNotFoundError.
+func NewSampleSource(source GenomeSource, sample string) (*SampleSource, error) {
+	names := source.SampleNames()
+	index := slices.Index(names, sample)
+	if index < 0 {
+		if i, err := strconv.Atoi(sample); err == nil && i >= 0 && i < len(names) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, &SampleNotFoundError{Sample: sample, Available: names}
+	}
+	return &SampleSource{GenomeSource: source, Index: index}, nil
+}
+
+func (s *SampleSource) LookupVariant(chrom string, pos uint64) ([]*VariantCall, error) {
+	calls, err := s.GenomeSource.LookupVariant(chrom, pos)
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]*VariantCall, len(calls))
+	for i, call := range calls {
+		selected[i] = s.selectSample(call)
+	}
+	return selected, nil
+}
+
+func (s *SampleSource) IterateRegion(chrom string, start uint64, end uint64, fn func(*VariantCall) bool) error {
+	return s.GenomeSource.IterateRegion(chrom, start, end, func(call *VariantCall) bool {
+		return fn(s.selectSample(call))
+	})
+}
+
+func (s *SampleSource) Prefetch(loci []Locus) error {
+	return Prefetch(s.GenomeSource, loci)
+}
+
+func (s *SampleSource) SampleNames() []string {
+	return s.GenomeSource.SampleNames()[s.Index : s.Index+1]
+}
+
+// selectSample returns a copy of call holding only the selected sample, or
+// no sample if the record lacks its column
+func (s *SampleSource) selectSample(call *VariantCall) *VariantCall {
+	selected := *call
+	selected.Samples = nil
+	if s.Index < len(call.Samples) {
+		selected.Samples = []SampleCall{call.Samples[s.Index]}
+	}
+	return &selected
+}
+
+// SelectSample sets the sample proof reads from multi-sample genomes. Proofs
+// over every sample, such as CohortProof, or over the genome as committed
+// cannot select one.
+func SelectSample(proof Proof, sample string) error {
+	switch p := proof.(type) {
+	case *DynamicProof:
+		p.Sample = sample
+	case *RsIDProof:
+		p.Sample = sample
+	case *CustomTraitProof:
+		p.Sample = sample
+	case *AggregateProof:
+		p.Sample = sample
+	case *NegativeProof:
+		p.Sample = sample
+	case *CarrierProof:
+		p.Sample = sample
+	case *PhaseProof:
+		p.Sample = sample
+	case *RegionCountProof:
+		p.Sample = sample
+	case *BRCA2Proof:
+		p.Sample = sample
+	case *BurdenProof:
+		p.Sample = sample
+	case *KinshipProof:
+		p.Sample = sample
+	case *SexChromosomeProof:
+		p.Sample = sample
+	case *BloodTypeProof:
+		p.Sample = sample
+	case *CYP2D6Proof:
+		p.Sample = sample
+	case *ACTN3Proof:
+		p.Sample = sample
+	case *EyeColorProof:
+		p.Sample = sample
+	case *HERC2Proof:
+		p.Sample = sample
+	case *ALDH2Proof:
+		p.Sample = sample
+	case *CCR5Proof:
+		p.Sample = sample
+	case *MTHFRProof:
+		p.Sample = sample
+	case *ABCC11Proof:
+		p.Sample = sample
+	default:
+		return fmt.Errorf("%T proofs cannot select a sample", proof)
+	}
+	return nil
+}
+
+// withPosition returns a copy of call at another position
+func withPosition(call *VariantCall, pos uint64) *VariantCall {
+	lifted := *call
+	lifted.Position = pos
+	return &lifted
+}
+
+// sourceOrVCF returns source, or when source is nil the genome at vcfPath,
+// a VCF or DTC raw data, as opened by OpenGenomeSource. If sample is set,
+// only that sample of the genome is visible (see NewSampleSource).
+func sourceOrVCF(source GenomeSource, vcfPath string, sample string, progress ProgressReporter) (GenomeSource, error) {
+	if source == nil {
+		var err error
+		source, err = OpenGenomeSource(vcfPath, progress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sample == "" {
+		return source, nil
+	}
+	return NewSampleSource(source, sample)
+}
@@ -0,0 +1,335 @@
+package proofs
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+const genomeSourceTestVCF = `##fileformat=VCFv4.2
+##reference=GRCh38
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	alice
+chr1	100	rs1	A	G	50	PASS	.	GT	0/1
+chr1	200	rs2	C	T	5	PASS	.	GT	1/1
+chr1	300	rs3	G	A	60	LowQual	.	GT	0/1
+chr2	100	rs4	T	C	40	PASS	.	GT	0/0
+`
+
+// countingSource counts lookups reaching the wrapped source
+type countingSource struct {
+	GenomeSource
+	lookups int
+}
+
+func (s *countingSource) LookupVariant(chrom string, pos uint64) ([]*VariantCall, error) {
+	s.lookups++
+	return s.GenomeSource.LookupVariant(chrom, pos)
+}
+
+func newTestSource(t *testing.T) *VCFSource {
+	t.Helper()
+
+	source, err := NewVCFSource(writeTestVCF(t, genomeSourceTestVCF), nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource failed: %v", err)
+	}
+	return source
+}
+
+func TestVCFSource(t *testing.T) {
+	source := newTestSource(t)
+
+	if source.Build() != traits.BuildGRCh38 {
+		t.Errorf("Expected build GRCh38, got %q", source.Build())
+	}
+	if names := source.SampleNames(); len(names) != 1 || names[0] != "alice" {
+		t.Errorf("Unexpected sample names %v", names)
+	}
+
+	calls, err := source.LookupVariant("1", 100)
+	if err != nil {
+		t.Fatalf("LookupVariant failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0].ID != "rs1" {
+		t.Fatalf("Expected rs1 at 1:100, got %v", calls)
+	}
+	if gt := calls[0].Samples[0].GT; len(gt) != 2 || gt[0] != 0 || gt[1] != 1 {
+		t.Errorf("Expected GT 0/1, got %v", gt)
+	}
+
+	calls, err = source.LookupVariant("", 100)
+	if err != nil {
+		t.Fatalf("LookupVariant failed: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("Expected records on both chromosomes, got %d", len(calls))
+	}
+
+	var ids []string
+	err = source.IterateRegion("chr1", 150, 300, func(call *VariantCall) bool {
+		ids = append(ids, call.ID)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("IterateRegion failed: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "rs2" || ids[1] != "rs3" {
+		t.Errorf("Expected rs2 and rs3, got %v", ids)
+	}
+}
+
+func TestCachingSource(t *testing.T) {
+	inner := &countingSource{GenomeSource: newTestSource(t)}
+	source := NewCachingSource(inner)
+
+	for i := 0; i < 3; i++ {
+		calls, err := source.LookupVariant("chr1", 200)
+		if err != nil || len(calls) != 1 {
+			t.Fatalf("LookupVariant returned %v, %v", calls, err)
+		}
+	}
+	if inner.lookups != 1 {
+		t.Errorf("Expected 1 underlying lookup, got %d", inner.lookups)
+	}
+}
+
+func TestQualityFilterSource(t *testing.T) {
+	source := NewQualityFilterSource(newTestSource(t), 20, true)
+
+	for _, pos := range []uint64{200, 300} {
+		calls, err := source.LookupVariant("1", pos)
+		if err != nil {
+			t.Fatalf("LookupVariant failed: %v", err)
+		}
+		if len(calls) != 0 {
+			t.Errorf("Expected record at %d to be filtered", pos)
+		}
+	}
+
+	count := 0
+	if err := source.IterateRegion("1", 0, 1000, func(*VariantCall) bool { count++; return true }); err != nil {
+		t.Fatalf("IterateRegion failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 passing record, got %d", count)
+	}
+}
+
+func TestRedactingSource(t *testing.T) {
+	source := NewRedactingSource(newTestSource(t), []Region{{Chromosome: "1", Start: 50, End: 150}})
+
+	if _, err := source.LookupVariant("1", 100); err != nil {
+		t.Errorf("Expected allowed lookup to succeed: %v", err)
+	}
+	if _, err := source.LookupVariant("1", 200); !errors.Is(err, ErrRedacted) {
+		t.Errorf("Expected ErrRedacted, got %v", err)
+	}
+	if names := source.SampleNames(); len(names) != 1 || names[0] == "alice" {
+		t.Errorf("Expected anonymized sample names, got %v", names)
+	}
+}
+
+func TestLiftoverSource(t *testing.T) {
+	blocks := []LiftoverBlock{{Chromosome: "1", Start: 1000, End: 1300, Offset: -900}}
+	source := NewLiftoverSource(newTestSource(t), traits.BuildGRCh37, blocks)
+
+	if source.Build() != traits.BuildGRCh37 {
+		t.Errorf("Expected build GRCh37, got %q", source.Build())
+	}
+
+	calls, err := source.LookupVariant("1", 1100)
+	if err != nil {
+		t.Fatalf("LookupVariant failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0].ID != "rs2" || calls[0].Position != 1100 {
+		t.Fatalf("Expected rs2 lifted to 1100, got %v", calls)
+	}
+
+	var positions []uint64
+	err = source.IterateRegion("1", 0, 5000, func(call *VariantCall) bool {
+		positions = append(positions, call.Position)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("IterateRegion failed: %v", err)
+	}
+	if len(positions) != 3 || positions[0] != 1000 || positions[2] != 1200 {
+		t.Errorf("Unexpected lifted positions %v", positions)
+	}
+}
+
+func TestDynamicProofUsesSource(t *testing.T) {
+	p := NewDynamicProof(100, "A", "G")
+	p.Source = NewRedactingSource(newTestSource(t), []Region{{Chromosome: "1", Start: 100, End: 100}})
+
+	genotype, _, _, err := p.extractGenotypeAtPosition("", 100, "A", "G")
+	if err != nil {
+		t.Fatalf("extractGenotypeAtPosition failed: %v", err)
+	}
+	if genotype != 1 {
+		t.Errorf("Expected heterozygous genotype, got %d", genotype)
+	}
+
+	if _, _, _, err := p.extractGenotypeAtPosition("", 200, "C", "T"); !errors.Is(err, ErrRedacted) {
+		t.Errorf("Expected ErrRedacted outside the allowed region, got %v", err)
+	}
+}
+
+func TestSampleSource(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	child	mother	father
+1	100	rs1	A	G	50	PASS	.	GT	0/1	1/1	0/0
+`)
+	source, err := NewVCFSource(vcfPath, nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource failed: %v", err)
+	}
+
+	for sample, want := range map[string][]int{"mother": {1, 1}, "2": {0, 0}} {
+		selected, err := NewSampleSource(source, sample)
+		if err != nil {
+			t.Fatalf("NewSampleSource(%q) failed: %v", sample, err)
+		}
+		calls, err := selected.LookupVariant("1", 100)
+		if err != nil || len(calls) != 1 || len(calls[0].Samples) != 1 {
+			t.Fatalf("%s: expected one call of one sample, got %v, %v", sample, calls, err)
+		}
+		if gt := calls[0].Samples[0].GT; gt[0] != want[0] || gt[1] != want[1] {
+			t.Errorf("%s: expected GT %v, got %v", sample, want, gt)
+		}
+	}
+
+	var notFound *SampleNotFoundError
+	_, err = NewSampleSource(source, "grandmother")
+	if !errors.As(err, &notFound) || len(notFound.Available) != 3 || !strings.Contains(err.Error(), "child, mother, father") {
+		t.Errorf("Expected a SampleNotFoundError listing the samples, got %v", err)
+	}
+
+	// Proofs read the selected sample
+	p := NewDynamicProof(100, "A", "G")
+	p.Sample = "mother"
+	if genotype, _, _, err := p.extractGenotypeAtPosition(vcfPath, 100, "A", "G"); err != nil || genotype != 2 {
+		t.Errorf("Expected the mother's homozygous genotype, got %d, %v", genotype, err)
+	}
+	if err := SelectSample(&CohortProof{}, "mother"); err == nil {
+		t.Error("Expected cohort proofs to refuse selecting a sample")
+	}
+}
+
+func TestVCFSource_DetectsBuildFromContigs(t *testing.T) {
+	source, err := NewVCFSource(writeTestVCF(t, `##fileformat=VCFv4.2
+##contig=<ID=chr1,length=248956422>
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	alice
+chr12	112241766	rs671	G	A	50	PASS	.	GT	0/1
+`), nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource failed: %v", err)
+	}
+	if source.Build() != traits.BuildGRCh38 {
+		t.Fatalf("Expected the contig length to identify GRCh38, got %q", source.Build())
+	}
+
+	// The GRCh37 trait position is not looked up in a GRCh38 genome
+	var buildErr *BuildMismatchError
+	if _, err := (&ALDH2Proof{}).Generate(source.path, "", ""); !errors.As(err, &buildErr) {
+		t.Errorf("Expected a BuildMismatchError, got %v", err)
+	}
+	p := NewDynamicProof(112241766, "G", "A")
+	p.Source = source
+	if _, _, _, err := p.extractGenotypeAtPosition("", 112241766, "G", "A"); err != nil {
+		t.Errorf("Expected positions without a build to be looked up, got %v", err)
+	}
+}
+
+func TestVCFSource_ReferenceBlocks(t *testing.T) {
+	source, err := NewVCFSource(writeTestVCF(t, `##fileformat=VCFv4.2
+##INFO=<ID=END,Number=1,Type=Integer,Description="End position of the block">
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	alice
+chr1	100	.	A	<NON_REF>	.	.	END=199	GT	0/0
+chr1	200	rs2	C	T,<NON_REF>	50	PASS	.	GT	0/1
+chr1	201	.	G	<NON_REF>	.	.	END=300	GT	./.
+`), nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource failed: %v", err)
+	}
+
+	// Positions inside a block are homozygous reference, with the block's
+	// reference base only where it starts
+	for pos, ref := range map[uint64]string{100: "A", 150: "N"} {
+		calls, err := source.LookupVariant("1", pos)
+		if err != nil {
+			t.Fatalf("LookupVariant failed: %v", err)
+		}
+		if len(calls) != 1 || !calls[0].ReferenceBlock || calls[0].Position != pos || calls[0].Reference != ref {
+			t.Fatalf("Expected a reference block call at %d, got %+v", pos, calls)
+		}
+	}
+	calls, err := source.LookupVariant("1", 200)
+	if err != nil || len(calls) != 1 || calls[0].ReferenceBlock || calls[0].ID != "rs2" {
+		t.Fatalf("Expected the variant record at 200, got %+v, %v", calls, err)
+	}
+	if calls, err := source.LookupVariant("1", 301); err != nil || len(calls) != 0 {
+		t.Errorf("Expected nothing past the last block, got %+v, %v", calls, err)
+	}
+
+	p := NewDynamicProof(150, "T", "C")
+	p.Source = source
+	genotype, _, _, err := p.extractGenotypeAtPosition("", 150, "T", "C")
+	if err != nil {
+		t.Fatalf("extractGenotypeAtPosition failed: %v", err)
+	}
+	if genotype != 0 {
+		t.Errorf("Expected homozygous reference in a reference block, got %d", genotype)
+	}
+	// Blocks without coverage are no-calls, not evidence of the reference
+	if _, _, _, err := p.extractGenotypeAtPosition("", 250, "T", "C"); !errors.Is(err, ErrNoSampleData) {
+		t.Errorf("Expected ErrNoSampleData in an uncalled block, got %v", err)
+	}
+}
+
+func TestVCFSource_Prefetch(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##INFO=<ID=END,Number=1,Type=Integer,Description="End position of the block">
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	alice
+chr1	100	rs1	A	G	50	PASS	.	GT	0/1
+chr1	150	.	T	<NON_REF>	.	.	END=199	GT	0/0
+chr1	200	rs2	C	T	50	PASS	.	GT	1/1
+chr2	100	rs4	T	C	40	PASS	.	GT	0/0
+`)
+	source, err := NewVCFSource(vcfPath, nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource failed: %v", err)
+	}
+
+	sampled, err := NewSampleSource(source, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loci := []Locus{{"1", 200}, {"chr1", 100}, {"1", 160}, {"1", 120}, {"2", 100}, {"X", 5}}
+	if err := Prefetch(sampled, loci); err != nil {
+		t.Fatalf("Prefetch failed: %v", err)
+	}
+
+	// Prefetched loci are answered without reading the file again
+	if err := os.Remove(vcfPath); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[Locus]string{{"1", 200}: "rs2", {"1", 100}: "rs1", {"1", 160}: "block", {"1", 120}: "", {"2", 100}: "rs4", {"X", 5}: ""}
+	for locus, id := range expected {
+		calls, err := sampled.LookupVariant(locus.Chromosome, locus.Position)
+		if err != nil {
+			t.Fatalf("LookupVariant(%v) read the file: %v", locus, err)
+		}
+		switch {
+		case id == "" && len(calls) != 0,
+			id == "block" && (len(calls) != 1 || !calls[0].ReferenceBlock),
+			id != "" && id != "block" && (len(calls) != 1 || calls[0].ID != id):
+			t.Errorf("Expected %q at %v, got %+v", id, locus, calls)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package proofs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+)
+
+// failedProofData returns the ProofData reported when generation fails
+func failedProofData() *ProofData {
+	return &ProofData{
+		Proof:         nil,
+		VerifyingKey:  nil,
+		PublicWitness: nil,
+		Result:        ProofFail,
+	}
+}
+
+// recordFailure returns the ProofData to report for a generation that ended
+// with err, recording why it failed
+func recordFailure(proofData *ProofData, err error) *ProofData {
+	if err == nil {
+		return proofData
+	}
+	if proofData == nil {
+		proofData = failedProofData()
+	}
+	proofData.FailureReason = failureReasonOf(err)
+	return proofData
+}
+
+// proveCircuit compiles the circuit, runs a Groth16 setup and proves the
+// assignment, returning the serialized proof, verifying key and public witness.
+// Proofs generated with options go through proveCircuitWithKeys instead, which
+// proves with any backend.
+// Circuits must declare their public input layout, and circuits using solver
+// hints must implement HintedCircuit with audited hints. Stages are logged to
+// logger and reported to progress, either of which may be nil.
+func proveCircuit(logger Logger, progress ProgressReporter, circuit frontend.Circuit, assignment frontend.Circuit) (*ProofData, error) {
+	return proveCircuitWithKeys(logger, progress, Groth16, nil, nil, ProverOptions{}, circuit, assignment)
+}
+
+// proveCircuitWithKeys is proveCircuit under backend with the keys of the
+// circuit taken from keys, which runs the setup and stores its keys only if it
+// holds none. A nil keys runs a fresh setup. A circuit held in cache is
+// neither compiled nor set up again, and circuits compiled here are added to
+// it; cache may be nil. The circuit is proven under prover, whose hints are
+// recorded with those of the circuit.
+func proveCircuitWithKeys(logger Logger, progress ProgressReporter, backend Backend, keys KeyStore, cache *CircuitCache, prover ProverOptions, circuit frontend.Circuit, assignment frontend.Circuit) (*ProofData, error) {
+	log := loggerOrNop(logger)
+	if err := validatePublicInputLayout(circuit); err != nil {
+		return failedProofData(), err
+	}
+	if err := prover.validate(); err != nil {
+		return failedProofData(), err
+	}
+
+	hints, hintNames, err := circuitHints(circuit)
+	if err != nil {
+		return failedProofData(), err
+	}
+	extraNames, _ := auditedHintNames(prover.Hints)
+	for i, fn := range prover.Hints {
+		if !slices.Contains(hintNames, extraNames[i]) {
+			hints = append(hints, fn)
+			hintNames = append(hintNames, extraNames[i])
+		}
+	}
+
+	defer CapThreads(prover.Threads)()
+
+	compiled, err := compileCircuit(log, progress, backend, keys, cache, circuit)
+	if err != nil {
+		return failedProofData(), err
+	}
+	cs, pk, vk := compiled.cs, compiled.pk, compiled.vk
+	if prover.Prover == nil {
+		prefetchProvingKey(pk)
+	}
+
+	log.Infof("Creating witness...")
+	done := startStage(progress, "witness", "creating witness")
+	w, err := frontend.NewWitness(assignment, backend.Curve().ScalarField())
+	done()
+	if err != nil {
+		return failedProofData(), fmt.Errorf("witness creation error: %w", err)
+	}
+
+	publicWitness, err := w.Public()
+	if err != nil {
+		return failedProofData(), fmt.Errorf("public witness error: %w", err)
+	}
+
+	log.Infof("Generating proof...")
+	done = startStage(progress, "prove", "generating proof")
+	var proofBytes []byte
+	if prover.Prover != nil {
+		proofBytes, err = prover.Prover.Prove(compiled.key, cs, pk, w, prover.proverOptions(hints)...)
+	} else {
+		proofBytes, err = backend.Prove(cs, pk, w, prover.proverOptions(hints)...)
+	}
+	done()
+	if err != nil {
+		return failedProofData(), fmt.Errorf("proving error: %w", err)
+	}
+
+	vkBytes := make([]byte, 0)
+	if _, err := vk.WriteTo(&bytesWriter{data: &vkBytes}); err != nil {
+		return failedProofData(), fmt.Errorf("serializing verifying key: %w", err)
+	}
+
+	publicWitnessData, err := publicWitness.MarshalBinary()
+	if err != nil {
+		return failedProofData(), fmt.Errorf("serializing public witness: %w", err)
+	}
+	if prover.Prover != nil {
+		if err := backend.Verify(proofBytes, vkBytes, publicWitnessData); err != nil {
+			return failedProofData(), fmt.Errorf("proof from %T rejected: %w", prover.Prover, err)
+		}
+	}
+
+	return &ProofData{
+		Proof:          proofBytes,
+		VerifyingKey:   vkBytes,
+		PublicWitness:  publicWitnessData,
+		Result:         ProofSuccess,
+		Hints:          hintNames,
+		CircuitID:      compiled.key.ID,
+		CircuitVersion: compiled.key.Version,
+		CircuitHash:    compiled.key.Hash,
+		Backend:        compiled.key.Backend,
+		Curve:          backend.Curve().String(),
+		CreatedAt:      time.Now().UTC(),
+		Constraints:    cs.GetNbConstraints(),
+	}, nil
+}
+
+// startStage reports the start of a proving stage that cannot measure its own
+// progress and returns a function reporting its end
+func startStage(progress ProgressReporter, stage string, message string) func() {
+	if progress == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	progress(stage, 0, message)
+	return func() {
+		progress(stage, 100, fmt.Sprintf("finished in %s", time.Since(start).Round(time.Millisecond)))
+	}
+}
+
+// constraintSystemHash returns the hex SHA-256 of the serialized constraint
+// system, identifying the exact circuit a verifying key was set up for
+func constraintSystemHash(cs constraint.ConstraintSystem) (string, error) {
+	h := sha256.New()
+	if _, err := cs.WriteTo(h); err != nil {
+		return "", fmt.Errorf("serializing constraint system: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyBytes checks a serialized Groth16 proof against a serialized
+// verifying key and public witness, as found in ProofData. Nothing is known of
+// the circuit, so the public inputs are not decoded and a binding among them
+// is not checked. Messages go to logger, which may be nil.
+func VerifyBytes(logger Logger, proof []byte, verifyingKey []byte, publicWitness []byte) (*VerificationResult, error) {
+	if len(proof) == 0 || len(verifyingKey) == 0 {
+		return &VerificationResult{
+			Result: ProofFail,
+			Error:  fmt.Errorf("invalid proof data: missing proof or verifying key"),
+		}, nil
+	}
+
+	loggerOrNop(logger).Infof("Verifying Groth16 proof from ProofData...")
+	if err := Groth16.Verify(proof, verifyingKey, publicWitness); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	return &VerificationResult{Result: ProofSuccess}, nil
+}
+
+// verifySNARK checks the proof carried by proofData with the backend it was
+// made with. Verification failures are reported through the result, not the
+// returned error.
+func verifySNARK(logger Logger, name string, proofData *ProofData) (*VerificationResult, error) {
+	log := loggerOrNop(logger)
+	if len(proofData.Proof) == 0 || len(proofData.VerifyingKey) == 0 {
+		return &VerificationResult{
+			Result: ProofFail,
+			Error:  fmt.Errorf("invalid proof data: missing proof or verifying key"),
+		}, nil
+	}
+
+	if err := checkCompatibility(proofData); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+
+	log.Infof("Verifying %s proof from ProofData...", name)
+
+	backend, err := BackendNamed(proofData.Backend, proofData.Curve)
+	if err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+
+	if err := backend.Verify(proofData.Proof, proofData.VerifyingKey, proofData.PublicWitness); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	publicWitness, err := decodePublicWitness(backend.Curve(), proofData.PublicWitness)
+	if err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	if err := checkBinding(proofData, backend.Curve(), publicWitness); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+
+	// Success is logged by callers once the key is trusted too
+	return verified(proofData), nil
+}
+
+// verifyProofFile loads a JSON-encoded ProofData from proofPath and verifies it.
+// A non-empty verifyingKeyPath replaces the verifying key bundled in the proof.
+func verifyProofFile(logger Logger, name string, verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	proofData, err := ReadProofData(proofPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if verifyingKeyPath != "" {
+		vkBytes, err := os.ReadFile(verifyingKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading verifying key: %w", err)
+		}
+		proofData.VerifyingKey = vkBytes
+	}
+
+	return verifySNARK(logger, name, proofData)
+}
+
+// ReadProofData loads a JSON-encoded ProofData from proofPath
+func ReadProofData(proofPath string) (*ProofData, error) {
+	f, err := os.Open(proofPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading proof file: %w", err)
+	}
+	defer f.Close()
+
+	return DecodeProofData(f)
+}
@@ -1,157 +1,40 @@
 package proofs
 
 import (
-	"fmt"
-	"os"
-	"strings"
-
-	"github.com/brentp/vcfgo"
-	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark/backend/groth16"
-	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
 )
 
-type HERC2Circuit struct {
-	ClaimedColor frontend.Variable `gnark:",public"`
-	Genotype     frontend.Variable
-}
-
-func (c *HERC2Circuit) Define(api frontend.API) error {
-	api.Sub(c.ClaimedColor, c.Genotype)
-
-	return nil
-}
-
 func (p *HERC2Proof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
-	f, err := os.Open(vcfPath)
+	proofData, claim, err := generateGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("herc2"), traits.CarrierClaims, p.Progress, p.Logger)
 	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, err
+		return proofData, err
 	}
-	defer f.Close()
 
-	rdr, err := vcfgo.NewReader(f, false)
-	if err != nil {
-		return &ProofData{
-			Proof:         nil,
-			VerifyingKey:  nil,
-			PublicWitness: nil,
-			Result:        ProofFail,
-		}, err
-	}
-
-	fmt.Println("searching for HERC2 trait...")
-	for {
-		variant := rdr.Read()
-		if variant == nil {
-			fmt.Println("Could not find position")
-			break
-		}
+	loggerOrNop(p.Logger).Infof("✅ HERC2 proof successfully generated: carried=%t", claim == 1)
 
-		pos := variant.Pos
+	return proofData, nil
+}
 
-		if pos%10000 == 0 {
-			fmt.Printf("Searching position: %d\n", pos)
-		}
-		if pos == 16058000 {
-			fmt.Println("you are not insane")
-		}
-		if pos == HERC2Pos {
-			fmt.Println("Found position.")
-			fmt.Printf("Variant: Chromosome: %s, Reference: %s, Alternate: %s", variant.Chromosome, variant.Reference, variant.Alternate)
-			
-			// Return successful proof data
-			return &ProofData{
-				Proof:         []byte(fmt.Sprintf("herc2_proof_pos_%d", pos)),
-				VerifyingKey:  []byte("herc2_verifying_key"),
-				PublicWitness: []byte(fmt.Sprintf("herc2_witness_chr_%s_pos_%d", variant.Chromosome, pos)),
-				Result:        ProofSuccess,
-			}, nil
-		}
+// Assign extracts the rs12913832 genotype and builds the circuit and its
+// assignment
+func (p *HERC2Proof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := assignGenotypeClaim(vcfPath, p.Sample, p.Traits.Variant("herc2"), traits.CarrierClaims, p.Progress, p.Logger)
+	if err != nil {
+		return nil, nil, err
 	}
+	return NewGenotypeClaimCircuit(p.Traits.Variant("herc2"), traits.CarrierClaims), assignment, nil
+}
 
-	// Position not found
-	return &ProofData{
-		Proof:         nil,
-		VerifyingKey:  nil,
-		PublicWitness: nil,
-		Result:        ProofFail,
-	}, fmt.Errorf("HERC2 position %d not found", HERC2Pos)
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *HERC2Proof) Circuit() (frontend.Circuit, error) {
+	return NewGenotypeClaimCircuit(p.Traits.Variant("herc2"), traits.CarrierClaims), nil
 }
 
 func (p *HERC2Proof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
-	return &VerificationResult{
-		Result: ProofSuccess,
-		Error:  nil,
-	}, nil
+	return verifyProofFile(p.Logger, "HERC2", verifyingKeyPath, proofPath)
 }
 
 func (p *HERC2Proof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
-	// Verify HERC2 proof directly from ProofData using gnark
-	
-	if len(proofData.Proof) == 0 || len(proofData.VerifyingKey) == 0 {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("invalid proof data: missing proof or verifying key"),
-		}, nil
-	}
-	
-	fmt.Println("Verifying HERC2 proof from ProofData...")
-	
-	// Deserialize the verifying key
-	vk := groth16.NewVerifyingKey(ecc.BN254)
-	_, err := vk.ReadFrom(strings.NewReader(string(proofData.VerifyingKey)))
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize verifying key: %w", err),
-		}, nil
-	}
-	
-	// Deserialize the proof
-	proof := groth16.NewProof(ecc.BN254)
-	_, err = proof.ReadFrom(strings.NewReader(string(proofData.Proof)))
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize proof: %w", err),
-		}, nil
-	}
-	
-	// Deserialize the public witness
-	publicWitness, err := witness.New(ecc.BN254.ScalarField())
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to create witness: %w", err),
-		}, nil
-	}
-	err = publicWitness.UnmarshalBinary(proofData.PublicWitness)
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("failed to deserialize public witness: %w", err),
-		}, nil
-	}
-	
-	// Perform gnark verification
-	err = groth16.Verify(proof, vk, publicWitness)
-	if err != nil {
-		return &VerificationResult{
-			Result: ProofFail,
-			Error:  fmt.Errorf("proof verification failed: %w", err),
-		}, nil
-	}
-	
-	fmt.Println("✅ HERC2 proof successfully verified!")
-	
-	return &VerificationResult{
-		Result: ProofSuccess,
-		Error:  nil,
-	}, nil
-}
\ No newline at end of file
+	return verifySNARK(p.Logger, "HERC2", proofData)
+}
@@ -0,0 +1,131 @@
+package proofs
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+)
+
+// AuditedHint is a gnark solver hint together with the documentation an
+// auditor needs to review the out-of-circuit computation it performs. Hint
+// outputs are chosen freely by the prover, so every hint must state how the
+// calling circuit constrains them.
+type AuditedHint struct {
+	Name        string
+	Computes    string
+	Constraints string
+	Fn          solver.Hint
+}
+
+// hintRegistry holds the audited hints, guarded by hintRegistryMu so hints can
+// be registered while other goroutines prove
+var (
+	hintRegistryMu sync.RWMutex
+	hintRegistry   = map[solver.HintID]AuditedHint{}
+)
+
+// RegisterHint adds a hint to the audited registry. Registration happens at
+// init time, so an undocumented or duplicate hint panics.
+func RegisterHint(h AuditedHint) {
+	if h.Fn == nil || h.Name == "" || h.Computes == "" || h.Constraints == "" {
+		panic(fmt.Sprintf("hint %q must have a function, name, and documented computation and constraints", h.Name))
+	}
+	id := solver.GetHintID(h.Fn)
+	hintRegistryMu.Lock()
+	defer hintRegistryMu.Unlock()
+	if _, ok := hintRegistry[id]; ok {
+		panic(fmt.Sprintf("hint %q registered twice", h.Name))
+	}
+	hintRegistry[id] = h
+}
+
+// RegisteredHints returns all audited hints sorted by name
+func RegisteredHints() []AuditedHint {
+	hintRegistryMu.RLock()
+	hints := make([]AuditedHint, 0, len(hintRegistry))
+	for _, h := range hintRegistry {
+		hints = append(hints, h)
+	}
+	hintRegistryMu.RUnlock()
+	sort.Slice(hints, func(i, j int) bool { return hints[i].Name < hints[j].Name })
+	return hints
+}
+
+// HintedCircuit is implemented by circuits that call solver hints. Every hint
+// returned must be in the audited registry for the circuit to be proven.
+type HintedCircuit interface {
+	frontend.Circuit
+	Hints() []solver.Hint
+}
+
+// circuitHints returns the hints a circuit uses and their audited names
+func circuitHints(circuit frontend.Circuit) ([]solver.Hint, []string, error) {
+	hinted, ok := circuit.(HintedCircuit)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	hints := hinted.Hints()
+	names, err := auditedHintNames(hints)
+	if err != nil {
+		return nil, nil, fmt.Errorf("circuit uses %w", err)
+	}
+	return hints, names, nil
+}
+
+// auditedHintNames returns the audited names of hints, refusing hints that
+// are not in the registry
+func auditedHintNames(hints []solver.Hint) ([]string, error) {
+	names := make([]string, len(hints))
+	hintRegistryMu.RLock()
+	defer hintRegistryMu.RUnlock()
+	for i, fn := range hints {
+		h, ok := hintRegistry[solver.GetHintID(fn)]
+		if !ok {
+			return nil, fmt.Errorf("unaudited hint %s", solver.GetHintName(fn))
+		}
+		names[i] = h.Name
+	}
+	return names, nil
+}
+
+func init() {
+	RegisterHint(AuditedHint{
+		Name:        "DivMod",
+		Computes:    "quotient and remainder of the integer division of inputs[0] by inputs[1]",
+		Constraints: "divMod asserts a == q*b + r, r < b and that q fits in the declared bit width",
+		Fn:          divModHint,
+	})
+}
+
+// divModHint computes q, r such that a = q*b + r with 0 <= r < b
+func divModHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) != 2 || len(outputs) != 2 {
+		return fmt.Errorf("divMod hint expects 2 inputs and 2 outputs")
+	}
+	if inputs[1].Sign() == 0 {
+		return fmt.Errorf("divMod hint: division by zero")
+	}
+	outputs[0].DivMod(inputs[0], inputs[1], outputs[1])
+	return nil
+}
+
+// divMod returns the quotient and remainder of a / b for a quotient of at
+// most bits bits. Circuits calling it must list divModHint in Hints().
+func divMod(api frontend.API, a, b frontend.Variable, bits int) (frontend.Variable, frontend.Variable, error) {
+	out, err := api.Compiler().NewHint(divModHint, 2, a, b)
+	if err != nil {
+		return nil, nil, err
+	}
+	q, r := out[0], out[1]
+
+	api.AssertIsEqual(a, api.Add(api.Mul(q, b), r))
+	api.AssertIsLessOrEqual(api.Add(r, 1), b)
+	api.ToBinary(q, bits)
+
+	return q, r, nil
+}
@@ -0,0 +1,104 @@
+package proofs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+// divModCircuit exercises the divMod gadget
+type divModCircuit struct {
+	Quotient  frontend.Variable `gnark:",public"`
+	Remainder frontend.Variable `gnark:",public"`
+	A         frontend.Variable
+	B         frontend.Variable
+}
+
+func (c *divModCircuit) Define(api frontend.API) error {
+	q, r, err := divMod(api, c.A, c.B, 32)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(c.Quotient, q)
+	api.AssertIsEqual(c.Remainder, r)
+	return nil
+}
+
+func (c *divModCircuit) Hints() []solver.Hint {
+	return []solver.Hint{divModHint}
+}
+
+func (c *divModCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "div_mod_test", Version: 1, Inputs: []string{"Quotient", "Remainder"}}
+}
+
+// unauditedCircuit declares a hint that is not in the audited registry
+type unauditedCircuit struct {
+	divModCircuit
+}
+
+func (c *unauditedCircuit) Hints() []solver.Hint {
+	return []solver.Hint{solver.InvZeroHint}
+}
+
+func TestDivModHint(t *testing.T) {
+	outputs := []*big.Int{new(big.Int), new(big.Int)}
+	if err := divModHint(ecc.BN254.ScalarField(), []*big.Int{big.NewInt(17), big.NewInt(5)}, outputs); err != nil {
+		t.Fatalf("divModHint failed: %v", err)
+	}
+	if outputs[0].Int64() != 3 || outputs[1].Int64() != 2 {
+		t.Errorf("Expected 17 / 5 = 3 r 2, got %s r %s", outputs[0], outputs[1])
+	}
+
+	if err := divModHint(ecc.BN254.ScalarField(), []*big.Int{big.NewInt(1), big.NewInt(0)}, outputs); err == nil {
+		t.Error("Expected error for division by zero")
+	}
+}
+
+func TestDivModCircuit(t *testing.T) {
+	assignment := &divModCircuit{Quotient: 3, Remainder: 2, A: 17, B: 5}
+	if err := test.IsSolved(&divModCircuit{}, assignment, ecc.BN254.ScalarField()); err != nil {
+		t.Errorf("Expected 17 / 5 = 3 r 2 to be solved: %v", err)
+	}
+
+	assignment = &divModCircuit{Quotient: 2, Remainder: 7, A: 17, B: 5}
+	if err := test.IsSolved(&divModCircuit{}, assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Error("Expected wrong quotient to be rejected")
+	}
+}
+
+func TestProveCircuit_RecordsAuditedHints(t *testing.T) {
+	proofData, err := proveCircuit(nil, nil, &divModCircuit{}, &divModCircuit{Quotient: 3, Remainder: 2, A: 17, B: 5})
+	if err != nil {
+		t.Fatalf("proveCircuit failed: %v", err)
+	}
+	if len(proofData.Hints) != 1 || proofData.Hints[0] != "DivMod" {
+		t.Errorf("Expected hints [DivMod], got %v", proofData.Hints)
+	}
+
+	_, err = proveCircuit(nil, nil, &unauditedCircuit{}, &unauditedCircuit{})
+	if err == nil {
+		t.Error("Expected proveCircuit to refuse an unaudited hint")
+	}
+}
+
+func TestRegisterHint_RequiresDocumentation(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected RegisterHint to panic for an undocumented hint")
+		}
+	}()
+	RegisterHint(AuditedHint{Name: "Undocumented", Fn: solver.InvZeroHint})
+}
+
+func TestRegisteredHints_AreDocumented(t *testing.T) {
+	for _, h := range RegisteredHints() {
+		if h.Computes == "" || h.Constraints == "" {
+			t.Errorf("Hint %s is missing documentation", h.Name)
+		}
+	}
+}
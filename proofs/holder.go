@@ -0,0 +1,148 @@
+package proofs
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// presentationDomain separates presentation signatures from other signatures
+// made with a holder's key
+var presentationDomain = []byte("zkgenomics-presentation-v2")
+
+// MaxPresentationAge is how long after it is signed a presentation is
+// accepted, and how far ahead of the verifier's clock it may be dated
+const MaxPresentationAge = 5 * time.Minute
+
+// ErrHolderMismatch is reported when a proof is presented with a key other
+// than the one it was issued to
+var ErrHolderMismatch = errors.New("proof was not issued to the presenting key")
+
+// ErrChallengeMismatch is reported when a presentation does not answer the
+// challenge its verifier issued
+var ErrChallengeMismatch = errors.New("presentation does not answer the expected challenge")
+
+// StalePresentationError is reported when a presentation was not signed
+// within MaxPresentationAge of its verification
+type StalePresentationError struct {
+	PresentedAt time.Time
+	At          time.Time
+}
+
+func (e *StalePresentationError) Error() string {
+	if e.PresentedAt.IsZero() {
+		return "presentation is not dated"
+	}
+	return fmt.Sprintf("presentation was signed at %s, not within %s of %s",
+		e.PresentedAt.Format(time.RFC3339), MaxPresentationAge, e.At.Format(time.RFC3339))
+}
+
+// Presentation is a holder-bound proof as shown to a verifier: the proof, the
+// holder's public key and the holder's signature over the proof, the
+// verifier's challenge and the time it was presented
+type Presentation struct {
+	ProofData *ProofData        `json:"proof_data"`
+	HolderKey ed25519.PublicKey `json:"holder_key"`
+	// Challenge is the challenge the verifier issued for this presentation,
+	// so it cannot be replayed to another verifier or in another session
+	Challenge   []byte    `json:"challenge"`
+	PresentedAt time.Time `json:"presented_at"`
+	Signature   []byte    `json:"signature"`
+}
+
+// DecodePresentation reads a JSON- or CBOR-encoded Presentation from r
+func DecodePresentation(r io.Reader) (*Presentation, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading presentation: %w", err)
+	}
+
+	var presentation Presentation
+	if !isJSON(data) {
+		if err := decodeCBOR(data, &presentation, "presentation"); err != nil {
+			return nil, err
+		}
+		return &presentation, nil
+	}
+	if err := json.Unmarshal(data, &presentation); err != nil {
+		return nil, fmt.Errorf("decoding presentation: %w", err)
+	}
+	return &presentation, nil
+}
+
+// HolderKeyHash returns the hash a proof issued to key is bound to
+func HolderKeyHash(key ed25519.PublicKey) string {
+	return digestBytes(key)
+}
+
+// Present signs a proof issued to the holder of key, for presentation now to
+// the verifier that issued challenge
+func Present(proofData *ProofData, key ed25519.PrivateKey, challenge []byte) (*Presentation, error) {
+	holderKey := key.Public().(ed25519.PublicKey)
+	if proofData.Binding == nil || proofData.Binding.HolderKeyHash != HolderKeyHash(holderKey) {
+		return nil, ErrHolderMismatch
+	}
+	if len(challenge) == 0 {
+		return nil, fmt.Errorf("presenting a proof requires the verifier's challenge")
+	}
+	p := &Presentation{
+		ProofData:   proofData,
+		HolderKey:   holderKey,
+		Challenge:   challenge,
+		PresentedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	p.Signature = ed25519.Sign(key, presentationDigest(p))
+	return p, nil
+}
+
+// CheckPresentation checks that p is signed by the holder its proof was
+// issued to, in answer to challenge, the challenge the verifier issued, and
+// within MaxPresentationAge of now. It does not verify the proof itself,
+// which must be verified as well; that also checks the recorded binding is
+// the one proven.
+func CheckPresentation(p *Presentation, challenge []byte) error {
+	if p.ProofData == nil {
+		return fmt.Errorf("presentation has no proof")
+	}
+	if len(p.HolderKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid ed25519 holder key")
+	}
+	if p.ProofData.Binding == nil || p.ProofData.Binding.HolderKeyHash != HolderKeyHash(p.HolderKey) {
+		return ErrHolderMismatch
+	}
+	if !ed25519.Verify(p.HolderKey, presentationDigest(p), p.Signature) {
+		return fmt.Errorf("invalid holder signature on presentation")
+	}
+	if len(challenge) == 0 || !bytes.Equal(p.Challenge, challenge) {
+		return ErrChallengeMismatch
+	}
+	now := time.Now()
+	if p.PresentedAt.IsZero() || now.Sub(p.PresentedAt) > MaxPresentationAge || p.PresentedAt.Sub(now) > MaxPresentationAge {
+		return &StalePresentationError{PresentedAt: p.PresentedAt, At: now}
+	}
+	return nil
+}
+
+// presentationDigest is what a holder signs: the proof, verifying key and
+// public witness, which carries the proof's binding, then the challenge
+// answered and the time of presentation to the second
+func presentationDigest(p *Presentation) []byte {
+	h := sha256.New()
+	h.Write(presentationDomain)
+	for _, field := range [][]byte{p.ProofData.Proof, p.ProofData.VerifyingKey, p.ProofData.PublicWitness, p.Challenge} {
+		binary.Write(h, binary.BigEndian, uint64(len(field)))
+		h.Write(field)
+	}
+	var presentedAt int64
+	if !p.PresentedAt.IsZero() {
+		presentedAt = p.PresentedAt.Unix()
+	}
+	binary.Write(h, binary.BigEndian, presentedAt)
+	return h.Sum(nil)
+}
@@ -0,0 +1,84 @@
+package proofs
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+)
+
+func TestPresent(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	holderKey, holderPrivate, _ := ed25519.GenerateKey(nil)
+	_, otherPrivate, _ := ed25519.GenerateKey(nil)
+
+	proofData, err := GenerateWithOptionsContext(context.Background(), &ALDH2Proof{}, vcfPath, GenerateOptions{
+		Binding: &Binding{HolderKeyHash: HolderKeyHash(holderKey)},
+	})
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+
+	challenge := []byte("challenge-1")
+	presentation, err := Present(proofData, holderPrivate, challenge)
+	if err != nil {
+		t.Fatalf("Present should not return error: %v", err)
+	}
+	if err := CheckPresentation(presentation, challenge); err != nil {
+		t.Errorf("Expected the holder's presentation to be accepted, got %v", err)
+	}
+
+	if _, err := Present(proofData, otherPrivate, challenge); !errors.Is(err, ErrHolderMismatch) {
+		t.Errorf("Expected another key to be refused, got %v", err)
+	}
+
+	// Re-signing with another key does not match the bound holder
+	stolen := *presentation
+	stolen.HolderKey = otherPrivate.Public().(ed25519.PublicKey)
+	stolen.Signature = ed25519.Sign(otherPrivate, presentationDigest(&stolen))
+	if err := CheckPresentation(&stolen, challenge); !errors.Is(err, ErrHolderMismatch) {
+		t.Errorf("Expected a presentation by another key to be rejected, got %v", err)
+	}
+
+	tampered := *presentation
+	tampered.Signature = append([]byte{}, presentation.Signature...)
+	tampered.Signature[0] ^= 1
+	if err := CheckPresentation(&tampered, challenge); err == nil {
+		t.Error("Expected an invalid signature to be rejected")
+	}
+
+	// Replaying the presentation to a verifier issuing another challenge fails
+	if err := CheckPresentation(presentation, []byte("challenge-2")); !errors.Is(err, ErrChallengeMismatch) {
+		t.Errorf("Expected a replayed presentation to fail with ErrChallengeMismatch, got %v", err)
+	}
+	if err := CheckPresentation(presentation, nil); !errors.Is(err, ErrChallengeMismatch) {
+		t.Errorf("Expected a presentation checked without a challenge to fail, got %v", err)
+	}
+	rechallenged := *presentation
+	rechallenged.Challenge = []byte("challenge-2")
+	if err := CheckPresentation(&rechallenged, []byte("challenge-2")); err == nil {
+		t.Error("Expected a rewritten challenge to break the signature")
+	}
+
+	// A presentation signed long ago is stale even for its own challenge
+	stale := *presentation
+	stale.PresentedAt = presentation.PresentedAt.Add(-2 * MaxPresentationAge)
+	stale.Signature = ed25519.Sign(holderPrivate, presentationDigest(&stale))
+	var staleErr *StalePresentationError
+	if err := CheckPresentation(&stale, challenge); !errors.As(err, &staleErr) {
+		t.Errorf("Expected a stale presentation to fail with StalePresentationError, got %v", err)
+	}
+	redated := stale
+	redated.PresentedAt = presentation.PresentedAt
+	if err := CheckPresentation(&redated, challenge); err == nil {
+		t.Error("Expected a redated presentation to break the signature")
+	}
+
+	if _, err := Present(proofData, holderPrivate, nil); err == nil {
+		t.Error("Expected presenting without a challenge to fail")
+	}
+}
@@ -0,0 +1,122 @@
+package proofs
+
+import (
+	"fmt"
+	"math/bits"
+	"runtime"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	frbls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	frbls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	frbn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bw6-761"
+	frbw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/frontend"
+)
+
+// CircuitCost is what proving a circuit takes, for budgeting before
+// generating. Counts are exact, read from the compiled circuit; key sizes,
+// proof size and proving time are estimates, as they are known only after a
+// setup and proof.
+type CircuitCost struct {
+	CircuitID      string `json:"circuit_id"`
+	CircuitVersion int    `json:"circuit_version"`
+	Backend        string `json:"backend"`
+	Curve          string `json:"curve"`
+	Constraints    int    `json:"constraints"`
+	// PublicInputs are the public values a proof discloses, as laid out by
+	// the circuit's PublicInputLayout
+	PublicInputs int `json:"public_inputs"`
+	// SecretInputs are the private inputs the witness assigns, and
+	// InternalVariables the variables the solver derives from them
+	SecretInputs      int `json:"secret_inputs"`
+	InternalVariables int `json:"internal_variables"`
+	// ProvingKeySize is the estimated size in bytes of the raw proving key,
+	// as stored by a FileKeyStore; compressed keys are about half as large
+	ProvingKeySize   int64 `json:"proving_key_size"`
+	VerifyingKeySize int64 `json:"verifying_key_size"`
+	ProofSize        int64 `json:"proof_size"`
+	// ProvingTime is the estimated time to prove on Threads threads,
+	// excluding setup. It is calibrated on commodity x86 cores; expect
+	// several times longer on small ARM machines.
+	ProvingTime time.Duration `json:"proving_time"`
+	Threads     int           `json:"threads"`
+}
+
+// curveSizes are the sizes in bytes of compressed points and scalars of a
+// curve, from which key and proof sizes are estimated
+type curveSizes struct {
+	g1, g2, fr int64
+	// groth16Time is the time proving takes per constraint on one core
+	// under Groth16
+	groth16Time time.Duration
+}
+
+var curveCosts = map[ecc.ID]curveSizes{
+	ecc.BN254:     {bn254.SizeOfG1AffineCompressed, bn254.SizeOfG2AffineCompressed, frbn254.Bytes, 70 * time.Microsecond},
+	ecc.BLS12_381: {bls12381.SizeOfG1AffineCompressed, bls12381.SizeOfG2AffineCompressed, frbls12381.Bytes, 130 * time.Microsecond},
+	ecc.BLS12_377: {bls12377.SizeOfG1AffineCompressed, bls12377.SizeOfG2AffineCompressed, frbls12377.Bytes, 115 * time.Microsecond},
+	ecc.BW6_761:   {bw6761.SizeOfG1AffineCompressed, bw6761.SizeOfG2AffineCompressed, frbw6761.Bytes, 480 * time.Microsecond},
+}
+
+// plonkSlowdown is how much longer PLONK proving takes than Groth16 proving
+// of the same circuit
+const plonkSlowdown = 3.5
+
+// InspectCircuit compiles circuit under backend, Groth16 if nil, and returns
+// what proving it takes on threads threads, every CPU if threads is 0.
+// Neither setup nor proving is run.
+func InspectCircuit(circuit frontend.Circuit, backend Backend, threads int) (*CircuitCost, error) {
+	backend = backendOrDefault(backend)
+	if err := validatePublicInputLayout(circuit); err != nil {
+		return nil, err
+	}
+	layout := circuit.(LayoutCircuit).PublicInputLayout()
+	sizes, ok := curveCosts[backend.Curve()]
+	if !ok {
+		return nil, fmt.Errorf("no cost model for curve %s", backend.Curve())
+	}
+	cs, err := backend.Compile(circuit)
+	if err != nil {
+		return nil, fmt.Errorf("circuit compilation error: %w", err)
+	}
+	if threads <= 0 {
+		threads = runtime.GOMAXPROCS(0)
+	}
+
+	cost := &CircuitCost{
+		CircuitID:         layout.CircuitID,
+		CircuitVersion:    layout.Version,
+		Backend:           backend.Name(),
+		Curve:             backend.Curve().String(),
+		Constraints:       cs.GetNbConstraints(),
+		PublicInputs:      len(layout.Inputs),
+		SecretInputs:      cs.GetNbSecretVariables(),
+		InternalVariables: cs.GetNbInternalVariables(),
+		Threads:           threads,
+	}
+	// Keys are made of points, and their size follows from the number of
+	// wires and the FFT domain, the constraint count rounded up to a power
+	// of two. Raw points are twice the size of compressed ones.
+	wires := int64(cs.GetNbPublicVariables() + cs.GetNbSecretVariables() + cs.GetNbInternalVariables())
+	domain := int64(1) << bits.Len64(uint64(max(cs.GetNbConstraints()-1, 1)))
+	perConstraint := sizes.groth16Time
+	if backend.Name() == BackendGroth16 {
+		cost.ProvingKeySize = 2 * ((3*wires+domain)*sizes.g1 + wires*sizes.g2)
+		cost.VerifyingKeySize = int64(cs.GetNbPublicVariables()+3)*sizes.g1 + 3*sizes.g2 + 12
+		cost.ProofSize = 3*sizes.g1 + sizes.g2 + 4
+	} else {
+		// The PLONK verifying key holds precomputed pairing lines of a size
+		// fixed by the curve, about a thousand compressed G1 points
+		cost.VerifyingKeySize = 1050 * sizes.g1
+		cost.ProvingKeySize = 2*2*domain*sizes.g1 + cost.VerifyingKeySize
+		cost.ProofSize = 9*sizes.g1 + 7*sizes.fr + 8
+		perConstraint = time.Duration(float64(perConstraint) * plonkSlowdown)
+	}
+	cost.ProvingTime = perConstraint * time.Duration(cost.Constraints) / time.Duration(threads)
+	return cost, nil
+}
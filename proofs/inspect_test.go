@@ -0,0 +1,53 @@
+package proofs
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestInspectCircuit_EstimatesMatchSetup(t *testing.T) {
+	circuit, err := (&CYP2D6Proof{}).Circuit()
+	if err != nil {
+		t.Fatalf("Circuit should not return error: %v", err)
+	}
+	for _, backend := range []Backend{Groth16, PLONK} {
+		t.Run(backend.Name(), func(t *testing.T) {
+			cost, err := InspectCircuit(circuit, backend, 2)
+			if err != nil {
+				t.Fatalf("InspectCircuit should not return error: %v", err)
+			}
+			cs, err := backend.Compile(circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cost.Constraints != cs.GetNbConstraints() || cost.PublicInputs != nbPublicInputs(backend, cs) {
+				t.Errorf("Expected %d constraints and %d public inputs, got %d and %d",
+					cs.GetNbConstraints(), nbPublicInputs(backend, cs), cost.Constraints, cost.PublicInputs)
+			}
+			if cost.Threads != 2 || cost.ProvingTime <= 0 {
+				t.Errorf("Expected a proving time on 2 threads, got %s on %d", cost.ProvingTime, cost.Threads)
+			}
+
+			pk, vk, err := backend.Setup(cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pkBytes, vkBytes bytes.Buffer
+			if _, err := pk.WriteRawTo(&pkBytes); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := vk.WriteTo(&vkBytes); err != nil {
+				t.Fatal(err)
+			}
+			for name, sizes := range map[string][2]int64{
+				"proving key":   {cost.ProvingKeySize, int64(pkBytes.Len())},
+				"verifying key": {cost.VerifyingKeySize, int64(vkBytes.Len())},
+			} {
+				if math.Abs(float64(sizes[0]-sizes[1])) > 0.1*float64(sizes[1]) {
+					t.Errorf("Expected the %s estimate %d within 10%% of %d", name, sizes[0], sizes[1])
+				}
+			}
+		})
+	}
+}
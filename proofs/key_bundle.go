@@ -0,0 +1,273 @@
+package proofs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// KeyBundleFormatVersion is the key bundle format written by this release
+const KeyBundleFormatVersion = 1
+
+// keyBundleSignatureType is the JWS typ of key bundle signatures
+const keyBundleSignatureType = "zkgenomics-keys+jws"
+
+// ErrUnsignedKeyBundle is reported when a key bundle carries no signature
+// from a trusted key
+var ErrUnsignedKeyBundle = errors.New("key bundle is not signed by a trusted key")
+
+// KeyBundle packages the keys of one circuit for distribution, so a project
+// can run the setup once and ship its keys to provers and verifiers. The
+// keys are identified by their circuit and checksummed, and the bundle is
+// signed over its manifest, so tampering is caught on import. Bundles for
+// verifiers may leave out the proving key.
+type KeyBundle struct {
+	FormatVersion int       `json:"format_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	// ProofType, if set, is the proof type verifiers trust the verifying key
+	// for
+	ProofType      string `json:"proof_type,omitempty"`
+	CircuitID      string `json:"circuit_id"`
+	CircuitVersion int    `json:"circuit_version"`
+	CircuitHash    string `json:"circuit_hash"`
+	Backend        string `json:"backend"`
+	Curve          string `json:"curve"`
+	// VKFingerprint is the VKFingerprint of VerifyingKey, for pinning
+	VKFingerprint string `json:"vk_fingerprint"`
+	// ProvingKeySHA256 and VerifyingKeySHA256 are the hex SHA-256 checksums
+	// of the serialized keys
+	ProvingKeySHA256   string `json:"proving_key_sha256,omitempty"`
+	VerifyingKeySHA256 string `json:"verifying_key_sha256"`
+	// ProvingKey is the proving key with compressed points, empty in bundles
+	// for verifiers
+	ProvingKey   []byte `json:"proving_key,omitempty"`
+	VerifyingKey []byte `json:"verifying_key"`
+	// Signatures are JWS with detached payload over the manifest: every field
+	// but the keys themselves, which the checksums stand for
+	Signatures []string `json:"signatures,omitempty"`
+}
+
+// keyBundleManifest is what key bundle signatures sign. Its JSON encoding is
+// the JWS payload.
+type keyBundleManifest struct {
+	FormatVersion      int       `json:"format_version"`
+	CreatedAt          time.Time `json:"created_at"`
+	ProofType          string    `json:"proof_type,omitempty"`
+	CircuitID          string    `json:"circuit_id"`
+	CircuitVersion     int       `json:"circuit_version"`
+	CircuitHash        string    `json:"circuit_hash"`
+	Backend            string    `json:"backend"`
+	Curve              string    `json:"curve"`
+	VKFingerprint      string    `json:"vk_fingerprint"`
+	ProvingKeySHA256   string    `json:"proving_key_sha256,omitempty"`
+	VerifyingKeySHA256 string    `json:"verifying_key_sha256"`
+}
+
+// NewKeyBundle bundles the keys keys holds for circuit, leaving out the
+// proving key unless withProvingKey is set. proofType, which may be empty,
+// names the proof type the keys are for.
+func NewKeyBundle(keys KeyStore, circuit CircuitKey, proofType string, withProvingKey bool) (*KeyBundle, error) {
+	pk, vk, err := keys.LoadKeys(circuit)
+	if err != nil {
+		return nil, fmt.Errorf("loading keys for circuit %s: %w", circuit, err)
+	}
+	var vkBytes bytes.Buffer
+	if _, err := vk.WriteTo(&vkBytes); err != nil {
+		return nil, fmt.Errorf("serializing verifying key: %w", err)
+	}
+	fingerprint, err := VKFingerprint(vkBytes.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	backend, curve := circuit.Backend, circuit.Curve
+	if backend == "" {
+		backend = BackendGroth16
+	}
+	if curve == "" {
+		curve = proofCurve
+	}
+	bundle := &KeyBundle{
+		FormatVersion:      KeyBundleFormatVersion,
+		CreatedAt:          time.Now().UTC(),
+		ProofType:          proofType,
+		CircuitID:          circuit.ID,
+		CircuitVersion:     circuit.Version,
+		CircuitHash:        circuit.Hash,
+		Backend:            backend,
+		Curve:              curve,
+		VKFingerprint:      fingerprint,
+		VerifyingKeySHA256: checksum(vkBytes.Bytes()),
+		VerifyingKey:       vkBytes.Bytes(),
+	}
+	if withProvingKey {
+		var pkBytes bytes.Buffer
+		if _, err := pk.WriteTo(&pkBytes); err != nil {
+			return nil, fmt.Errorf("serializing proving key: %w", err)
+		}
+		bundle.ProvingKey = pkBytes.Bytes()
+		bundle.ProvingKeySHA256 = checksum(pkBytes.Bytes())
+	}
+	return bundle, nil
+}
+
+// checksum returns the hex SHA-256 of data
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Circuit returns the key the bundle's keys are stored under
+func (b *KeyBundle) Circuit() CircuitKey {
+	key := CircuitKey{ID: b.CircuitID, Version: b.CircuitVersion, Hash: b.CircuitHash}
+	if b.Backend != BackendGroth16 {
+		key.Backend = b.Backend
+	}
+	if b.Curve != proofCurve {
+		key.Curve = b.Curve
+	}
+	return key
+}
+
+func (b *KeyBundle) manifest() ([]byte, error) {
+	return json.Marshal(keyBundleManifest{
+		FormatVersion:      b.FormatVersion,
+		CreatedAt:          b.CreatedAt,
+		ProofType:          b.ProofType,
+		CircuitID:          b.CircuitID,
+		CircuitVersion:     b.CircuitVersion,
+		CircuitHash:        b.CircuitHash,
+		Backend:            b.Backend,
+		Curve:              b.Curve,
+		VKFingerprint:      b.VKFingerprint,
+		ProvingKeySHA256:   b.ProvingKeySHA256,
+		VerifyingKeySHA256: b.VerifyingKeySHA256,
+	})
+}
+
+// SignKeyBundle signs the manifest of bundle with signer, adding the
+// signature to bundle.Signatures
+func SignKeyBundle(bundle *KeyBundle, signer EnvelopeSigner) error {
+	payload, err := bundle.manifest()
+	if err != nil {
+		return err
+	}
+	jws, err := signDetached(payload, signer, keyBundleSignatureType)
+	if err != nil {
+		return fmt.Errorf("signing key bundle: %w", err)
+	}
+	bundle.Signatures = append(bundle.Signatures, jws)
+	return nil
+}
+
+// Verify checks the integrity of the bundle: its keys must match their
+// checksums, and the verifying key must have the recorded fingerprint; the
+// proving key is decoded, and its points checked, only on import. Unless
+// trusted is nil, one of the trusted keys must have signed the bundle; Verify
+// returns the ID of the signing key, or ErrUnsignedKeyBundle.
+func (b *KeyBundle) Verify(trusted map[string]crypto.PublicKey) (string, error) {
+	if b.FormatVersion != KeyBundleFormatVersion {
+		return "", fmt.Errorf("unsupported key bundle format %d", b.FormatVersion)
+	}
+	if _, err := BackendNamed(b.Backend, b.Curve); err != nil {
+		return "", err
+	}
+	if checksum(b.VerifyingKey) != b.VerifyingKeySHA256 {
+		return "", fmt.Errorf("verifying key does not match its checksum")
+	}
+	if len(b.ProvingKey) > 0 && checksum(b.ProvingKey) != b.ProvingKeySHA256 {
+		return "", fmt.Errorf("proving key does not match its checksum")
+	}
+	if len(b.ProvingKey) == 0 && b.ProvingKeySHA256 != "" {
+		return "", fmt.Errorf("proving key is missing")
+	}
+	fingerprint, err := VKFingerprint(b.VerifyingKey)
+	if err != nil {
+		return "", err
+	}
+	if fingerprint != b.VKFingerprint {
+		return "", fmt.Errorf("verifying key has fingerprint %s, the bundle records %s", fingerprint, b.VKFingerprint)
+	}
+	if trusted == nil {
+		return "", nil
+	}
+	payload, err := b.manifest()
+	if err != nil {
+		return "", err
+	}
+	keyID, err := checkDetached(b.Signatures, payload, trusted)
+	if err == nil && keyID == "" {
+		err = ErrUnsignedKeyBundle
+	}
+	return keyID, err
+}
+
+// keys decodes the keys of the bundle, checking their points. The proving
+// key is nil in bundles for verifiers.
+func (b *KeyBundle) keys() (ProvingKey, VerifyingKey, error) {
+	backend, err := BackendNamed(b.Backend, b.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	vk := backend.NewVerifyingKey()
+	if _, err := vk.ReadFrom(bytes.NewReader(b.VerifyingKey)); err != nil {
+		return nil, nil, fmt.Errorf("decoding verifying key: %w", err)
+	}
+	if len(b.ProvingKey) == 0 {
+		return nil, vk, nil
+	}
+	pk := backend.NewProvingKey()
+	if _, err := pk.ReadFrom(bytes.NewReader(b.ProvingKey)); err != nil {
+		return nil, nil, fmt.Errorf("decoding proving key: %w", err)
+	}
+	return pk, vk, nil
+}
+
+// ImportKeyBundle verifies bundle as Verify does and stores its keys in keys,
+// returning the circuit they are stored under. The bundle must hold a
+// proving key.
+func ImportKeyBundle(bundle *KeyBundle, keys KeyStore, trusted map[string]crypto.PublicKey) (CircuitKey, error) {
+	if len(bundle.ProvingKey) == 0 {
+		return CircuitKey{}, fmt.Errorf("key bundle holds no proving key; it is for verifiers only")
+	}
+	if _, err := bundle.Verify(trusted); err != nil {
+		return CircuitKey{}, err
+	}
+	pk, vk, err := bundle.keys()
+	if err != nil {
+		return CircuitKey{}, err
+	}
+	circuit := bundle.Circuit()
+	if err := keys.StoreKeys(circuit, pk, vk); err != nil {
+		return CircuitKey{}, fmt.Errorf("storing keys for circuit %s: %w", circuit, err)
+	}
+	return circuit, nil
+}
+
+// ReadKeyBundle loads a JSON-encoded key bundle
+func ReadKeyBundle(path string) (*KeyBundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading key bundle: %w", err)
+	}
+	var bundle KeyBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("decoding key bundle: %w", err)
+	}
+	return &bundle, nil
+}
+
+// WriteKeyBundle writes bundle to path as indented JSON
+func WriteKeyBundle(path string, bundle *KeyBundle) error {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding key bundle: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,80 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+)
+
+func TestKeyBundle_ExportSignImport(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	exporter := &FileKeyStore{Dir: t.TempDir()}
+	proof := &ALDH2Proof{}
+	circuit, err := proof.Circuit()
+	if err != nil {
+		t.Fatalf("Circuit should not return error: %v", err)
+	}
+	key, err := SetupKeys(circuit, nil, exporter, nil)
+	if err != nil {
+		t.Fatalf("SetupKeys should not return error: %v", err)
+	}
+
+	public, private, _ := ed25519.GenerateKey(nil)
+	trusted := map[string]crypto.PublicKey{"project": public}
+	bundle, err := NewKeyBundle(exporter, key, "aldh2", true)
+	if err != nil {
+		t.Fatalf("NewKeyBundle should not return error: %v", err)
+	}
+	if _, err := bundle.Verify(trusted); !errors.Is(err, ErrUnsignedKeyBundle) {
+		t.Errorf("Expected an unsigned bundle to be refused, got %v", err)
+	}
+	if err := SignKeyBundle(bundle, &EdDSASigner{ID: "project", Key: private}); err != nil {
+		t.Fatalf("SignKeyBundle should not return error: %v", err)
+	}
+
+	importer := &FileKeyStore{Dir: t.TempDir()}
+	imported, err := ImportKeyBundle(bundle, importer, trusted)
+	if err != nil {
+		t.Fatalf("ImportKeyBundle should not return error: %v", err)
+	}
+	if imported != key {
+		t.Errorf("Expected the keys stored under %s, got %s", key, imported)
+	}
+	proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Keys: importer})
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+	if !bytes.Equal(proofData.VerifyingKey, bundle.VerifyingKey) {
+		t.Error("Expected proofs made with imported keys to carry the bundled verifying key")
+	}
+
+	for name, tamper := range map[string]func(b *KeyBundle){
+		"proving key":     func(b *KeyBundle) { b.ProvingKey = append(bytes.Clone(b.ProvingKey), 0) },
+		"circuit version": func(b *KeyBundle) { b.CircuitVersion++ },
+		"fingerprint":     func(b *KeyBundle) { b.VKFingerprint = "00" },
+	} {
+		tampered := *bundle
+		tamper(&tampered)
+		if _, err := ImportKeyBundle(&tampered, &FileKeyStore{Dir: t.TempDir()}, trusted); err == nil {
+			t.Errorf("Expected a bundle with a changed %s to be refused", name)
+		}
+	}
+
+	verifying, err := NewKeyBundle(exporter, key, "aldh2", false)
+	if err != nil {
+		t.Fatalf("NewKeyBundle should not return error: %v", err)
+	}
+	if _, err := verifying.Verify(nil); err != nil {
+		t.Errorf("Verifying-key bundle should check out, got %v", err)
+	}
+	if _, err := ImportKeyBundle(verifying, importer, nil); err == nil {
+		t.Error("Expected a bundle without proving key to be refused for proving")
+	}
+}
@@ -0,0 +1,151 @@
+package proofs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// KeyProvider supplies a serialized key, such as a proving or verifying key,
+// from wherever it is kept: a file, memory, a key server or a database
+type KeyProvider interface {
+	// Open returns a reader of the key, which the caller closes
+	Open(ctx context.Context) (io.ReadCloser, error)
+}
+
+// KeyProviderFunc adapts a function to a KeyProvider
+type KeyProviderFunc func(ctx context.Context) (io.ReadCloser, error)
+
+// Open calls f
+func (f KeyProviderFunc) Open(ctx context.Context) (io.ReadCloser, error) {
+	return f(ctx)
+}
+
+// FromFile provides the key in the file at path
+func FromFile(path string) KeyProvider {
+	return KeyProviderFunc(func(ctx context.Context) (io.ReadCloser, error) {
+		return os.Open(path)
+	})
+}
+
+// FromBytes provides the key key, such as a key embedded in the binary
+func FromBytes(key []byte) KeyProvider {
+	return KeyProviderFunc(func(ctx context.Context) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(key)), nil
+	})
+}
+
+// FromReader provides the key read from r. The key is read on first use and
+// kept, so it can be opened again.
+func FromReader(r io.Reader) KeyProvider {
+	var once sync.Once
+	var key []byte
+	var err error
+	return KeyProviderFunc(func(ctx context.Context) (io.ReadCloser, error) {
+		once.Do(func() {
+			key, err = io.ReadAll(r)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(key)), nil
+	})
+}
+
+// FromHTTP provides the key served at url, fetched with client, or with
+// http.DefaultClient if client is nil. Serve keys over HTTPS, and pin the
+// verifying keys you trust rather than trusting the server.
+func FromHTTP(url string, client *http.Client) KeyProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return KeyProviderFunc(func(ctx context.Context) (io.ReadCloser, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("fetching key from %s: %s", url, resp.Status)
+		}
+		return resp.Body, nil
+	})
+}
+
+// ReadKey returns the key provided by provider
+func ReadKey(ctx context.Context, provider KeyProvider) ([]byte, error) {
+	r, err := provider.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// ProvidedKeys is a KeyStore holding the one key pair its providers supply,
+// for proving with keys set up elsewhere. The keys must have been set up for
+// the circuit proven; keys for a circuit with other public inputs are
+// rejected. Keys are not checked against the circuit's constraints, so a
+// proof made with keys for another circuit fails to verify. ProvidedKeys is
+// read-only.
+type ProvidedKeys struct {
+	ProvingKey   KeyProvider
+	VerifyingKey KeyProvider
+}
+
+// FileKeys provides the proving key at provingKeyPath, such as a key written
+// by SetupKeys, and the verifying key next to it, at the same path with .vk in
+// place of .pk
+func FileKeys(provingKeyPath string) *ProvidedKeys {
+	return &ProvidedKeys{
+		ProvingKey:   FromFile(provingKeyPath),
+		VerifyingKey: FromFile(strings.TrimSuffix(provingKeyPath, ".pk") + ".vk"),
+	}
+}
+
+// LoadKeys reads the provided keys, whatever circuit is asked for
+func (k *ProvidedKeys) LoadKeys(circuit CircuitKey) (ProvingKey, VerifyingKey, error) {
+	if k.ProvingKey == nil || k.VerifyingKey == nil {
+		return nil, nil, fmt.Errorf("provided keys need both a proving and a verifying key")
+	}
+	backend, err := BackendNamed(circuit.Backend, circuit.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx := context.Background()
+	vk := backend.NewVerifyingKey()
+	if err := readProvidedKey(ctx, k.VerifyingKey, vk.ReadFrom); err != nil {
+		return nil, nil, fmt.Errorf("reading verifying key: %w", err)
+	}
+	pk := newLazyProvingKey(backend, func() (io.ReadCloser, error) {
+		return k.ProvingKey.Open(ctx)
+	}, ProvingKey.ReadFrom)
+	return pk, vk, nil
+}
+
+// StoreKeys fails: provided keys are never replaced
+func (k *ProvidedKeys) StoreKeys(circuit CircuitKey, pk ProvingKey, vk VerifyingKey) error {
+	return errors.New("provided keys are read-only")
+}
+
+// readProvidedKey decodes the key provided by provider with read
+func readProvidedKey(ctx context.Context, provider KeyProvider, read func(r io.Reader) (int64, error)) error {
+	r, err := provider.Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	_, err = read(bufio.NewReader(r))
+	return err
+}
@@ -0,0 +1,98 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProvidedKeys(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	store := &FileKeyStore{Dir: t.TempDir()}
+	proof := &ALDH2Proof{}
+	circuit, err := proof.Circuit()
+	if err != nil {
+		t.Fatalf("Circuit should not return error: %v", err)
+	}
+	key, err := SetupKeys(circuit, nil, store, nil)
+	if err != nil {
+		t.Fatalf("SetupKeys should not return error: %v", err)
+	}
+	pkPath, vkPath := store.paths(key)
+	pkBytes, err := os.ReadFile(pkPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vkBytes, err := os.ReadFile(vkPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir(store.Dir)))
+	defer server.Close()
+
+	providers := map[string]*ProvidedKeys{
+		"file":  FileKeys(pkPath),
+		"bytes": {ProvingKey: FromBytes(pkBytes), VerifyingKey: FromBytes(vkBytes)},
+		"reader": {
+			ProvingKey:   FromReader(bytes.NewReader(pkBytes)),
+			VerifyingKey: FromReader(bytes.NewReader(vkBytes)),
+		},
+		"http": {
+			ProvingKey:   FromHTTP(server.URL+"/"+filepath.Base(pkPath), nil),
+			VerifyingKey: FromHTTP(server.URL+"/"+filepath.Base(vkPath), nil),
+		},
+	}
+	for name, keys := range providers {
+		t.Run(name, func(t *testing.T) {
+			proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Keys: keys})
+			if err != nil {
+				t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+			}
+			if !bytes.Equal(proofData.VerifyingKey, vkBytes) {
+				t.Error("Expected the proof to carry the provided verifying key")
+			}
+			result, err := proof.VerifyProofData(proofData)
+			if err != nil || result.Result != ProofSuccess {
+				t.Errorf("Proof made with provided keys should verify, got %v, %v", result, err)
+			}
+		})
+	}
+
+	missing := &ProvidedKeys{
+		ProvingKey:   FromHTTP(server.URL+"/missing.pk", nil),
+		VerifyingKey: FromHTTP(server.URL+"/missing.vk", nil),
+	}
+	if _, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Keys: missing}); err == nil {
+		t.Error("Expected an error for keys the server does not have")
+	}
+}
+
+func TestProvidedKeys_RejectsOtherCircuit(t *testing.T) {
+	store := &FileKeyStore{Dir: t.TempDir()}
+	key, err := SetupKeys(NewChromosomeCircuit(4), nil, store, nil)
+	if err != nil {
+		t.Fatalf("SetupKeys should not return error: %v", err)
+	}
+	pkPath, _ := store.paths(key)
+
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	if _, err := GenerateWithOptionsContext(context.Background(), &ALDH2Proof{}, vcfPath, GenerateOptions{Keys: FileKeys(pkPath)}); err == nil {
+		t.Error("Expected keys of a circuit with other public inputs to be rejected")
+	}
+	if _, err := GenerateWithOptionsContext(context.Background(), &BRCA1Proof{}, vcfPath, GenerateOptions{Keys: FileKeys(pkPath)}); err == nil {
+		t.Error("Expected proofs without a circuit assignment to refuse provided keys")
+	}
+}
@@ -0,0 +1,225 @@
+package proofs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+)
+
+// ErrKeysNotFound is returned by a KeyStore holding no keys for a circuit
+var ErrKeysNotFound = errors.New("no keys stored for circuit")
+
+// CircuitKey identifies the circuit a key pair was set up for. Circuits of
+// one ID and version still differ by configuration, such as the variant a
+// genotype claim is about, so keys are told apart by the circuit hash.
+type CircuitKey struct {
+	ID      string
+	Version int
+	// Hash is the SHA-256 of the constraint system, as in ProofData.CircuitHash
+	Hash string
+	// Backend is the name of the backend the keys are for, empty for Groth16
+	Backend string
+	// Curve is the name of the curve the keys are over, empty for BN254
+	Curve string
+}
+
+func (k CircuitKey) String() string {
+	name := fmt.Sprintf("%s-v%d", k.ID, k.Version)
+	if k.Backend != "" && k.Backend != BackendGroth16 {
+		name += "-" + k.Backend
+	}
+	if k.Curve != "" && k.Curve != proofCurve {
+		name += "-" + k.Curve
+	}
+	return name + "-" + k.Hash[:min(16, len(k.Hash))]
+}
+
+// KeyStore persists the proving and verifying keys of circuits, so proofs of
+// a circuit share one key pair, and one verifying key, instead of each
+// running its own setup. Keys are of the backend named by the circuit key.
+type KeyStore interface {
+	// LoadKeys returns the keys of circuit, or ErrKeysNotFound
+	LoadKeys(circuit CircuitKey) (ProvingKey, VerifyingKey, error)
+	StoreKeys(circuit CircuitKey, pk ProvingKey, vk VerifyingKey) error
+}
+
+// FileKeyStore keeps keys in a directory, as <circuit>.pk and <circuit>.vk
+// where <circuit> is the CircuitKey string. The proving key is stored raw, for
+// fast loading, unless Compressed is set, and is read without checking its
+// points; keep the directory writable only by the prover. It is read when
+// first used, streamed from its file. The verifying key is stored as in
+// ProofData.
+type FileKeyStore struct {
+	Dir string
+	// Compressed stores proving keys with compressed points, about half the
+	// size of raw keys but slower to read, as every point is decompressed.
+	// Keys of either encoding are read.
+	Compressed bool
+}
+
+func (s *FileKeyStore) paths(circuit CircuitKey) (pkPath, vkPath string) {
+	base := filepath.Join(s.Dir, circuit.String())
+	return base + ".pk", base + ".vk"
+}
+
+// LoadKeys reads the keys of circuit from the directory
+func (s *FileKeyStore) LoadKeys(circuit CircuitKey) (ProvingKey, VerifyingKey, error) {
+	backend, err := BackendNamed(circuit.Backend, circuit.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkPath, vkPath := s.paths(circuit)
+	vk := backend.NewVerifyingKey()
+	if err := readKeyFile(vkPath, vk.ReadFrom); err != nil {
+		return nil, nil, err
+	}
+	if _, err := os.Stat(pkPath); errors.Is(err, os.ErrNotExist) {
+		return nil, nil, ErrKeysNotFound
+	}
+	pk := newLazyProvingKey(backend, func() (io.ReadCloser, error) {
+		return os.Open(pkPath)
+	}, ProvingKey.UnsafeReadFrom)
+	return pk, vk, nil
+}
+
+// StoreKeys writes the keys of circuit to the directory, creating it if needed
+func (s *FileKeyStore) StoreKeys(circuit CircuitKey, pk ProvingKey, vk VerifyingKey) error {
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return fmt.Errorf("creating key store: %w", err)
+	}
+	pkPath, vkPath := s.paths(circuit)
+	// The verifying key is written last, so a stored verifying key means the
+	// proving key is complete
+	writePK := pk.WriteRawTo
+	if s.Compressed {
+		writePK = pk.WriteTo
+	}
+	if err := writeKeyFile(pkPath, writePK); err != nil {
+		return err
+	}
+	return writeKeyFile(vkPath, vk.WriteTo)
+}
+
+// readKeyFile decodes the key file at path with read, reporting a missing
+// file as ErrKeysNotFound
+func readKeyFile(path string, read func(r io.Reader) (int64, error)) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrKeysNotFound
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := read(bufio.NewReader(f)); err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeKeyFile writes a key file through a temporary file, so readers never
+// see a partial key
+func writeKeyFile(path string, write func(w io.Writer) (int64, error)) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	_, err = write(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// circuitKeys returns the keys of the circuit cs compiled for backend from
+// keys, running the setup and storing its keys if keys holds none or is nil
+func circuitKeys(log Logger, progress ProgressReporter, backend Backend, keys KeyStore, cs constraint.ConstraintSystem, circuit CircuitKey) (ProvingKey, VerifyingKey, error) {
+	if keys != nil {
+		pk, vk, err := keys.LoadKeys(circuit)
+		if err == nil {
+			if vk.NbPublicWitness() != nbPublicInputs(backend, cs) {
+				return nil, nil, fmt.Errorf("keys for circuit %s take %d public inputs, the circuit has %d", circuit, vk.NbPublicWitness(), nbPublicInputs(backend, cs))
+			}
+			log.Infof("Using stored keys for circuit %s", circuit)
+			return pk, vk, nil
+		}
+		if !errors.Is(err, ErrKeysNotFound) {
+			return nil, nil, fmt.Errorf("loading keys for circuit %s: %w", circuit, err)
+		}
+	}
+
+	log.Infof("Setting up proving system...")
+	done := startStage(progress, "setup", fmt.Sprintf("%s setup over %d constraints", backend.Name(), cs.GetNbConstraints()))
+	pk, vk, err := backend.Setup(cs)
+	done()
+	if err != nil {
+		return nil, nil, fmt.Errorf("setup error: %w", err)
+	}
+
+	if keys != nil {
+		if err := keys.StoreKeys(circuit, pk, vk); err != nil {
+			return nil, nil, fmt.Errorf("storing keys for circuit %s: %w", circuit, err)
+		}
+		log.Infof("Stored keys for circuit %s", circuit)
+	}
+	return pk, vk, nil
+}
+
+// CircuitDefiner is implemented by proofs whose circuit is fixed by their
+// configuration alone, so its keys can be set up before any genome is seen
+type CircuitDefiner interface {
+	Circuit() (frontend.Circuit, error)
+}
+
+// ProofCircuit returns the circuit of proof. Proofs that are not
+// CircuitDefiners size their circuit from the genome, so vcfPath must name
+// one for them.
+func ProofCircuit(proof Proof, vcfPath string) (frontend.Circuit, error) {
+	if definer, ok := proof.(CircuitDefiner); ok {
+		return definer.Circuit()
+	}
+	assigner, ok := proof.(CircuitAssigner)
+	if !ok {
+		return nil, fmt.Errorf("%T proofs do not support key setup", proof)
+	}
+	if vcfPath == "" {
+		return nil, fmt.Errorf("the circuit of %T proofs depends on the genome; a VCF is required", proof)
+	}
+	circuit, _, err := assigner.Assign(vcfPath)
+	return circuit, err
+}
+
+// SetupKeys compiles circuit and runs its setup under backend, Groth16 if
+// nil, storing the keys in keys, unless keys already holds keys for it. It
+// returns the circuit the keys are stored under. Messages go to logger, which
+// may be nil.
+func SetupKeys(circuit frontend.Circuit, backend Backend, keys KeyStore, logger Logger) (CircuitKey, error) {
+	log := loggerOrNop(logger)
+	if err := validatePublicInputLayout(circuit); err != nil {
+		return CircuitKey{}, err
+	}
+
+	compiled, err := compileCircuit(log, nil, backendOrDefault(backend), keys, nil, circuit)
+	if err != nil {
+		return CircuitKey{}, err
+	}
+	return compiled.key, nil
+}
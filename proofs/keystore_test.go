@@ -0,0 +1,113 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestFileKeyStore_ReusesKeys(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	keys := &FileKeyStore{Dir: t.TempDir()}
+	proof := &ALDH2Proof{}
+
+	circuit, err := ProofCircuit(proof, "")
+	if err != nil {
+		t.Fatalf("ProofCircuit should not return error: %v", err)
+	}
+	if _, _, err := keys.LoadKeys(CircuitKey{ID: "genotype_claim", Version: 2, Hash: "missing"}); !errors.Is(err, ErrKeysNotFound) {
+		t.Errorf("Expected ErrKeysNotFound from an empty store, got %v", err)
+	}
+	key, err := SetupKeys(circuit, nil, keys, nil)
+	if err != nil {
+		t.Fatalf("SetupKeys should not return error: %v", err)
+	}
+	_, vk, err := keys.LoadKeys(key)
+	if err != nil {
+		t.Fatalf("LoadKeys should not return error after setup: %v", err)
+	}
+	var stored bytes.Buffer
+	if _, err := vk.WriteTo(&stored); err != nil {
+		t.Fatalf("Failed to serialize stored verifying key: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Keys: keys})
+		if err != nil {
+			t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+		}
+		if proofData.CircuitHash != key.Hash {
+			t.Errorf("Expected circuit hash %s, got %s", key.Hash, proofData.CircuitHash)
+		}
+		if !bytes.Equal(proofData.VerifyingKey, stored.Bytes()) {
+			t.Errorf("Proof %d should carry the stored verifying key", i)
+		}
+		result, err := proof.VerifyProofData(proofData)
+		if err != nil || result.Result != ProofSuccess {
+			t.Errorf("Proof %d generated with stored keys should verify, got %v, %v", i, result, err)
+		}
+	}
+}
+
+func TestFileKeyStore_CompressedAndLazyKeys(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	proof := &ALDH2Proof{}
+	circuit, err := ProofCircuit(proof, "")
+	if err != nil {
+		t.Fatalf("ProofCircuit should not return error: %v", err)
+	}
+
+	raw := &FileKeyStore{Dir: t.TempDir()}
+	compressed := &FileKeyStore{Dir: t.TempDir(), Compressed: true}
+	sizes := make(map[*FileKeyStore]int64)
+	for _, keys := range []*FileKeyStore{raw, compressed} {
+		key, err := SetupKeys(circuit, nil, keys, nil)
+		if err != nil {
+			t.Fatalf("SetupKeys should not return error: %v", err)
+		}
+		pkPath, _ := keys.paths(key)
+		info, err := os.Stat(pkPath)
+		if err != nil {
+			t.Fatalf("Expected a stored proving key: %v", err)
+		}
+		sizes[keys] = info.Size()
+	}
+	if sizes[compressed] >= sizes[raw] {
+		t.Errorf("Expected a compressed proving key smaller than the raw %d bytes, got %d", sizes[raw], sizes[compressed])
+	}
+
+	proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Keys: compressed})
+	if err != nil {
+		t.Fatalf("Generating with a compressed proving key should not return error: %v", err)
+	}
+	if result, err := proof.VerifyProofData(proofData); err != nil || result.Result != ProofSuccess {
+		t.Errorf("Proof made with a compressed proving key should verify, got %v, %v", result, err)
+	}
+
+	// The proving key is only read when proving, so a damaged key goes
+	// unnoticed by setup but fails the proof
+	key, err := SetupKeys(circuit, nil, raw, nil)
+	if err != nil {
+		t.Fatalf("SetupKeys should not return error: %v", err)
+	}
+	pkPath, _ := raw.paths(key)
+	if err := os.Truncate(pkPath, sizes[raw]/2); err != nil {
+		t.Fatalf("Failed to truncate proving key: %v", err)
+	}
+	if _, err := SetupKeys(circuit, nil, raw, nil); err != nil {
+		t.Errorf("SetupKeys should not read the stored proving key: %v", err)
+	}
+	if _, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{Keys: raw}); err == nil {
+		t.Error("Expected proving with a truncated proving key to fail")
+	}
+}
@@ -0,0 +1,281 @@
+package proofs
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs/gadgets"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// KinshipCircuit proves that two genomes are consistent with a parent-child
+// relationship over a panel of loci. A parent and child always share an allele
+// at every locus, so opposite homozygotes (0/0 against 1/1) are Mendelian
+// inconsistencies; at most MaxMismatches are tolerated to allow for
+// genotyping errors and de novo mutations. Loci not called in both genomes are
+// skipped, but at least MinLoci must be compared. The panel is private but
+// bound to the public PanelHash, as in BurdenCircuit.
+type KinshipCircuit struct {
+	PanelHash     frontend.Variable `gnark:",public"`
+	MinLoci       frontend.Variable `gnark:",public"`
+	MaxMismatches frontend.Variable `gnark:",public"`
+
+	Positions       []frontend.Variable
+	RefHashes       []frontend.Variable
+	AltHashes       []frontend.Variable
+	ChildGenotypes  []frontend.Variable
+	ParentGenotypes []frontend.Variable
+	// Called is 1 where both genomes have a genotype call
+	Called []frontend.Variable
+
+	panel []traits.TraitVariant
+}
+
+// NewKinshipCircuit allocates a kinship circuit for a panel of n loci
+func NewKinshipCircuit(n int) *KinshipCircuit {
+	return &KinshipCircuit{
+		Positions:       make([]frontend.Variable, n),
+		RefHashes:       make([]frontend.Variable, n),
+		AltHashes:       make([]frontend.Variable, n),
+		ChildGenotypes:  make([]frontend.Variable, n),
+		ParentGenotypes: make([]frontend.Variable, n),
+		Called:          make([]frontend.Variable, n),
+	}
+}
+
+func (c *KinshipCircuit) Define(api frontend.API) error {
+	n := len(c.Positions)
+	if len(c.RefHashes) != n || len(c.AltHashes) != n || len(c.ChildGenotypes) != n ||
+		len(c.ParentGenotypes) != n || len(c.Called) != n {
+		return fmt.Errorf("kinship circuit slices must have equal length")
+	}
+
+	panel, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+
+	var compared, mismatches frontend.Variable = 0, 0
+	for i := range c.Positions {
+		// Recompute the VariantKey and fold it into the panel hash
+		key, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		key.Write(c.Positions[i], c.RefHashes[i], c.AltHashes[i])
+		panel.Write(key.Sum())
+
+		child, parent := c.ChildGenotypes[i], c.ParentGenotypes[i]
+		gadgets.AssertIsGenotype(api, child)
+		gadgets.AssertIsGenotype(api, parent)
+		api.AssertIsBoolean(c.Called[i])
+
+		childHomRef, childHomAlt := api.IsZero(child), api.IsZero(api.Sub(child, 2))
+		parentHomRef, parentHomAlt := api.IsZero(parent), api.IsZero(api.Sub(parent, 2))
+		opposite := api.Add(api.Mul(childHomRef, parentHomAlt), api.Mul(childHomAlt, parentHomRef))
+
+		compared = api.Add(compared, c.Called[i])
+		mismatches = api.Add(mismatches, api.Mul(c.Called[i], opposite))
+	}
+	api.AssertIsEqual(panel.Sum(), c.PanelHash)
+
+	api.AssertIsLessOrEqual(c.MinLoci, n)
+	api.AssertIsLessOrEqual(c.MaxMismatches, n)
+	api.AssertIsLessOrEqual(c.MinLoci, compared)
+	api.AssertIsLessOrEqual(mismatches, c.MaxMismatches)
+
+	return nil
+}
+
+func (c *KinshipCircuit) assignCurve(curve ecc.ID) error {
+	panelHash, err := variantListHash(curve, c.panel)
+	if err != nil {
+		return err
+	}
+	c.PanelHash = panelHash
+	return nil
+}
+
+// DefaultKinshipPanel returns the trait variants of traits.RsIDTable in
+// genomic order. It is small; parentage testing in practice should supply a
+// larger panel of common, independent SNPs.
+func DefaultKinshipPanel() []traits.TraitVariant {
+	panel := slices.Collect(maps.Values(traits.RsIDTable))
+	slices.SortFunc(panel, func(a, b traits.TraitVariant) int {
+		return cmp.Or(cmp.Compare(a.Chromosome, b.Chromosome), cmp.Compare(a.Position, b.Position))
+	})
+	return panel
+}
+
+// NewKinshipProof creates a kinship proof against the parent genome at
+// parentVCF over the default panel
+func NewKinshipProof(parentVCF string) *KinshipProof {
+	return &KinshipProof{ParentVCF: parentVCF, Panel: DefaultKinshipPanel()}
+}
+
+// Assign extracts both genomes over the panel and builds the circuit and its assignment
+func (p *KinshipProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewKinshipCircuit(len(p.Panel)), assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *KinshipProof) Circuit() (frontend.Circuit, error) {
+	if len(p.Panel) == 0 {
+		return nil, fmt.Errorf("kinship panel lists no loci")
+	}
+	return NewKinshipCircuit(len(p.Panel)), nil
+}
+
+func (p *KinshipProof) assign(vcfPath string) (*KinshipCircuit, error) {
+	if len(p.Panel) == 0 {
+		return nil, fmt.Errorf("kinship panel lists no loci")
+	}
+	minLoci := p.MinLoci
+	if minLoci == 0 {
+		minLoci = len(p.Panel)
+	}
+	if minLoci > len(p.Panel) || p.MaxMismatches < 0 || p.MaxMismatches > len(p.Panel) {
+		return nil, fmt.Errorf("kinship thresholds are outside 0..%d", len(p.Panel))
+	}
+
+	child, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, err
+	}
+	if p.ParentSource == nil && p.ParentVCF == "" {
+		return nil, fmt.Errorf("no parent genome set")
+	}
+	parent, err := sourceOrVCF(p.ParentSource, p.ParentVCF, p.ParentSample, p.Progress)
+	if err != nil {
+		return nil, err
+	}
+	if err := prefetchPanel(child, p.Panel); err != nil {
+		return nil, fmt.Errorf("child: %w", err)
+	}
+	if err := prefetchPanel(parent, p.Panel); err != nil {
+		return nil, fmt.Errorf("parent: %w", err)
+	}
+
+	panelHash, err := variantListHash(ecc.BN254, p.Panel)
+	if err != nil {
+		return nil, err
+	}
+
+	loggerOrNop(p.Logger).Infof("comparing %d panel loci...", len(p.Panel))
+	assignment := NewKinshipCircuit(len(p.Panel))
+	assignment.PanelHash = panelHash
+	assignment.panel = p.Panel
+	assignment.MinLoci = minLoci
+	assignment.MaxMismatches = p.MaxMismatches
+
+	compared, mismatches := 0, 0
+	for i, variant := range p.Panel {
+		childGenotype, childCalled, err := panelGenotype(child, variant)
+		if err != nil {
+			return nil, fmt.Errorf("child: %w", err)
+		}
+		parentGenotype, parentCalled, err := panelGenotype(parent, variant)
+		if err != nil {
+			return nil, fmt.Errorf("parent: %w", err)
+		}
+
+		refHash, err := alleleHash(variant.Ref)
+		if err != nil {
+			return nil, err
+		}
+		altHash, err := alleleHash(variant.Alt)
+		if err != nil {
+			return nil, err
+		}
+		assignment.Positions[i] = variant.Position
+		assignment.RefHashes[i] = refHash
+		assignment.AltHashes[i] = altHash
+		assignment.ChildGenotypes[i] = childGenotype
+		assignment.ParentGenotypes[i] = parentGenotype
+		assignment.Called[i] = 0
+
+		if childCalled && parentCalled {
+			assignment.Called[i] = 1
+			compared++
+			if childGenotype+parentGenotype == 2 && childGenotype != 1 {
+				mismatches++
+			}
+		}
+	}
+
+	if compared < minLoci {
+		return nil, fmt.Errorf("only %d loci are called in both genomes, %d required", compared, minLoci)
+	}
+	if mismatches > p.MaxMismatches {
+		return nil, fmt.Errorf("%d Mendelian inconsistencies exceed the tolerance of %d", mismatches, p.MaxMismatches)
+	}
+
+	return assignment, nil
+}
+
+// panelGenotype returns the first sample's ALT dosage at variant and whether
+// it was called. A missing record counts as a homozygous reference call, as in
+// variant-only VCFs; a record with a missing allele is not called. A zero
+// chromosome matches the position on any chromosome.
+func panelGenotype(source GenomeSource, variant traits.TraitVariant) (int, bool, error) {
+	if err := checkBuild(source.Build(), variant.Build, variant.Trait); err != nil {
+		return 0, false, err
+	}
+	chrom := ""
+	if variant.Chromosome > 0 {
+		chrom = strconv.Itoa(variant.Chromosome)
+	}
+	calls, err := lookupAlleles(source, chrom, uint64(variant.Position), variant.Ref, variant.Alt)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", variant.Trait, err)
+	}
+
+	for _, call := range calls {
+		if !allelesMatch(variant.Ref, call.Reference) {
+			continue
+		}
+		if len(call.Samples) == 0 {
+			return 0, false, fmt.Errorf("%w: VCF has no samples", ErrNoSampleData)
+		}
+		sample := call.Samples[0]
+		if len(sample.GT) != 2 || slices.Contains(sample.GT, -1) {
+			return 0, false, nil
+		}
+		return altDosage(call, sample, variant.Alt), true, nil
+	}
+	return 0, true, nil
+}
+
+func (p *KinshipProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewKinshipCircuit(len(p.Panel)), assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Kinship proof successfully generated: genomes are consistent with parentage over %d loci", len(p.Panel))
+
+	return proofData, nil
+}
+
+func (p *KinshipProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "kinship", verifyingKeyPath, proofPath)
+}
+
+func (p *KinshipProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "kinship", proofData)
+}
@@ -0,0 +1,114 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+var kinshipTestPanel = []traits.TraitVariant{
+	{Trait: "locus 1", Chromosome: 1, Position: 1000, Ref: "A", Alt: "G"},
+	{Trait: "locus 2", Chromosome: 1, Position: 2000, Ref: "C", Alt: "T"},
+	{Trait: "locus 3", Chromosome: 2, Position: 3000, Ref: "G", Alt: "A"},
+}
+
+func kinshipAssignment(t *testing.T, child []int, parent []int, called []int, minLoci int, maxMismatches int) *KinshipCircuit {
+	t.Helper()
+
+	panelHash, err := variantListHash(ecc.BN254, kinshipTestPanel)
+	if err != nil {
+		t.Fatalf("hashing panel: %v", err)
+	}
+
+	assignment := NewKinshipCircuit(len(kinshipTestPanel))
+	assignment.PanelHash = panelHash
+	assignment.MinLoci = minLoci
+	assignment.MaxMismatches = maxMismatches
+	for i, variant := range kinshipTestPanel {
+		refHash, err := alleleHash(variant.Ref)
+		if err != nil {
+			t.Fatalf("hashing allele: %v", err)
+		}
+		altHash, err := alleleHash(variant.Alt)
+		if err != nil {
+			t.Fatalf("hashing allele: %v", err)
+		}
+		assignment.Positions[i] = variant.Position
+		assignment.RefHashes[i] = refHash
+		assignment.AltHashes[i] = altHash
+		assignment.ChildGenotypes[i] = child[i]
+		assignment.ParentGenotypes[i] = parent[i]
+		assignment.Called[i] = called[i]
+	}
+	return assignment
+}
+
+func TestKinshipCircuit(t *testing.T) {
+	tests := []struct {
+		name          string
+		child, parent []int
+		called        []int
+		minLoci       int
+		maxMismatches int
+		solved        bool
+	}{
+		{"consistent", []int{1, 2, 0}, []int{0, 1, 1}, []int{1, 1, 1}, 3, 0, true},
+		{"opposite homozygotes", []int{2, 1, 0}, []int{0, 1, 1}, []int{1, 1, 1}, 3, 0, false},
+		{"tolerated mismatch", []int{2, 1, 0}, []int{0, 1, 1}, []int{1, 1, 1}, 3, 1, true},
+		{"mismatch at uncalled locus", []int{2, 1, 0}, []int{0, 1, 1}, []int{0, 1, 1}, 2, 0, true},
+		{"too few loci compared", []int{1, 1, 0}, []int{0, 1, 1}, []int{0, 1, 1}, 3, 0, false},
+		{"genotype out of range", []int{3, 1, 0}, []int{1, 1, 1}, []int{1, 1, 1}, 3, 0, false},
+	}
+
+	for _, tc := range tests {
+		assignment := kinshipAssignment(t, tc.child, tc.parent, tc.called, tc.minLoci, tc.maxMismatches)
+		err := test.IsSolved(NewKinshipCircuit(len(kinshipTestPanel)), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("%s: expected circuit to be solved: %v", tc.name, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("%s: expected circuit not to be solved", tc.name)
+		}
+	}
+}
+
+func TestKinshipProof_GenerateAndVerify(t *testing.T) {
+	child := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	CHILD
+1	1000	.	A	G	60	PASS	.	GT	0/1
+1	2000	.	C	T	60	PASS	.	GT	1/1
+2	3000	.	G	A	60	PASS	.	GT	./.
+`)
+	parent := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	PARENT
+1	1000	.	A	G	60	PASS	.	GT	1/1
+1	2000	.	C	T	60	PASS	.	GT	0/1
+2	3000	.	G	A	60	PASS	.	GT	1/1
+`)
+
+	proof := NewKinshipProof(parent)
+	proof.Panel = kinshipTestPanel
+	proof.MinLoci = 2
+	proofData, err := proof.Generate(child, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+
+	// The uncalled child locus leaves only two loci to compare
+	proof.MinLoci = 3
+	if _, err := proof.Generate(child, "", ""); err == nil {
+		t.Error("Generate should fail when fewer loci than MinLoci are called")
+	}
+}
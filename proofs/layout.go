@@ -0,0 +1,171 @@
+package proofs
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/schema"
+)
+
+// PublicInputLayout pins the order of a circuit version's public inputs.
+// gnark orders the public witness by struct field order, so an innocent field
+// reorder would silently break verification of existing proofs; declaring the
+// layout turns such a change into a compile-time error.
+type PublicInputLayout struct {
+	CircuitID string
+	Version   int
+	Inputs    []string
+}
+
+// LayoutCircuit is implemented by circuits that declare their public-input layout
+type LayoutCircuit interface {
+	frontend.Circuit
+	PublicInputLayout() PublicInputLayout
+}
+
+// publicInputNames returns the circuit's public input names in witness order
+func publicInputNames(circuit frontend.Circuit) ([]string, error) {
+	var names []string
+	tVariable := reflect.TypeOf((*frontend.Variable)(nil)).Elem()
+	_, err := schema.Walk(circuit, tVariable, func(leaf schema.LeafInfo, _ reflect.Value) error {
+		if leaf.Visibility == schema.Public {
+			names = append(names, leaf.FullName())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking circuit schema: %w", err)
+	}
+	return names, nil
+}
+
+// validatePublicInputLayout checks the circuit's public inputs against its
+// declared layout
+func validatePublicInputLayout(circuit frontend.Circuit) error {
+	laidOut, ok := circuit.(LayoutCircuit)
+	if !ok {
+		return fmt.Errorf("circuit %T does not declare a public input layout", circuit)
+	}
+
+	layout := laidOut.PublicInputLayout()
+	names, err := publicInputNames(circuit)
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(names, layout.Inputs) {
+		return fmt.Errorf("circuit %s v%d public inputs %v do not match declared layout %v",
+			layout.CircuitID, layout.Version, names, layout.Inputs)
+	}
+	return nil
+}
+
+// retiredLayouts are the layouts of superseded circuit versions, kept so
+// proofs made with them can still be decoded
+var retiredLayouts = []PublicInputLayout{
+	// v2 sized the chromosome slots and rejects a zero target
+	{CircuitID: "chromosome", Version: 1, Inputs: []string{"TargetChromosome"}},
+	// v2 added ClaimMode
+	{CircuitID: "dynamic", Version: 1, Inputs: []string{"ClaimedRef", "ClaimedAlt", "ClaimedGenotype"}},
+	// v3 added LocusHash
+	{CircuitID: "dynamic", Version: 2, Inputs: []string{"ClaimedRef", "ClaimedAlt", "ClaimedGenotype", "ClaimMode"}},
+	// v4 replaced the single-nucleotide allele codes with allele hashes
+	{CircuitID: "dynamic", Version: 3, Inputs: []string{"ClaimedRef", "ClaimedAlt", "ClaimedGenotype", "ClaimMode", "LocusHash"}},
+	// v2 calls *10 only on 100C>T copies *4 does not carry, at activity 0.25
+	{CircuitID: "cyp2d6", Version: 1, Inputs: []string{"ClaimedStatus"}},
+	// v2 added LocusHash
+	{CircuitID: "genotype_claim", Version: 1, Inputs: []string{"ClaimedValue"}},
+	// v2 added LocusHash, which binds the locus inputs
+	{CircuitID: "negative", Version: 1, Inputs: []string{"Chromosome", "Position", "RefHash", "AltHash"}},
+	// v2 added LocusHash, which binds the locus inputs
+	{CircuitID: "carrier", Version: 1, Inputs: []string{"Chromosome", "Position", "RefHash", "AltHash"}},
+	// v2 fixes LocusHashA and LocusHashB to the circuit's variants
+	{CircuitID: "phase", Version: 1, Inputs: []string{"LocusHashA", "LocusHashB", "ClaimedPhase"}},
+	// v2 constrains Chromosome to a chromosome code
+	{CircuitID: "region_count", Version: 1, Inputs: []string{"Threshold", "Chromosome", "RegionStart", "RegionEnd"}},
+	// v2 hashes Chromosome into ListHash
+	{CircuitID: "burden", Version: 1, Inputs: []string{"Threshold", "AtLeast", "Chromosome", "RegionStart", "RegionEnd", "ListHash"}},
+}
+
+// indexedInputs returns the public input names gnark assigns to a slice field
+func indexedInputs(name string, n int) []string {
+	inputs := make([]string, n)
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("%s_%d", name, i)
+	}
+	return inputs
+}
+
+func (c *BloodTypeCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "blood_type", Version: 1, Inputs: []string{"ClaimedBloodType"}}
+}
+
+func (c *MTHFRCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "mthfr", Version: 1, Inputs: []string{"ClaimedStatus"}}
+}
+
+func (c *BRCA2PanelCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "brca2_panel", Version: 1, Inputs: []string{"IsCarrier"}}
+}
+
+func (c *BurdenCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "burden", Version: 2, Inputs: []string{"Threshold", "AtLeast", "Chromosome", "RegionStart", "RegionEnd", "ListHash"}}
+}
+
+func (c *CohortCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "cohort", Version: 2, Inputs: []string{"MinCarrierPercent", "LocusHash", "CommitmentsHash"}}
+}
+
+func (c *CYP2D6Circuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "cyp2d6", Version: 2, Inputs: []string{"ClaimedStatus"}}
+}
+
+func (c *GenotypeClaimCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "genotype_claim", Version: 2, Inputs: []string{"ClaimedValue", "LocusHash"}}
+}
+
+func (c *DynamicCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "dynamic", Version: 4, Inputs: []string{"RefHash", "AltHash", "ClaimedGenotype", "ClaimMode", "LocusHash"}}
+}
+
+func (c *ChromosomeCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "chromosome", Version: 2, Inputs: []string{"TargetChromosome"}}
+}
+
+func (c *SexChromosomeCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "sex_chromosome", Version: 1, Inputs: []string{"ClaimedKaryotype"}}
+}
+
+func (c *NegativeCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "negative", Version: 2, Inputs: []string{"Chromosome", "Position", "RefHash", "AltHash", "LocusHash"}}
+}
+
+func (c *KinshipCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "kinship", Version: 1, Inputs: []string{"PanelHash", "MinLoci", "MaxMismatches"}}
+}
+
+func (c *AggregateCircuit) PublicInputLayout() PublicInputLayout {
+	n := len(c.Genotypes)
+	var inputs []string
+	for _, name := range []string{"Chromosomes", "Positions", "RefHashes", "AltHashes", "LocusHashes", "ClaimedGenotypes"} {
+		inputs = append(inputs, indexedInputs(name, n)...)
+	}
+	return PublicInputLayout{CircuitID: "aggregate", Version: 2, Inputs: inputs}
+}
+
+func (c *CommittedVariantCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "committed_variant", Version: 1, Inputs: []string{"Root", "Chromosome", "Position", "RefHash", "AltHash", "ClaimedGenotype"}}
+}
+
+func (c *CarrierCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "carrier", Version: 2, Inputs: []string{"Chromosome", "Position", "RefHash", "AltHash", "LocusHash"}}
+}
+
+func (c *PhaseCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "phase", Version: 2, Inputs: []string{"LocusHashA", "LocusHashB", "ClaimedPhase"}}
+}
+
+func (c *RegionCountCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "region_count", Version: 2, Inputs: []string{"Threshold", "Chromosome", "RegionStart", "RegionEnd"}}
+}
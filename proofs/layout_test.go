@@ -0,0 +1,112 @@
+package proofs
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// TestPublicInputLayoutCompatibility pins the public input layout of every
+// released circuit version. A failure here means existing proofs would no
+// longer verify: bump the circuit version instead of editing these entries.
+func TestPublicInputLayoutCompatibility(t *testing.T) {
+	tests := []struct {
+		circuit   LayoutCircuit
+		circuitID string
+		version   int
+		inputs    []string
+	}{
+		{&BloodTypeCircuit{}, "blood_type", 1, []string{"ClaimedBloodType"}},
+		{NewCohortCircuit(2), "cohort", 2, []string{"MinCarrierPercent", "LocusHash", "CommitmentsHash"}},
+		{NewCYP2D6Circuit(), "cyp2d6", 2, []string{"ClaimedStatus"}},
+		{&GenotypeClaimCircuit{}, "genotype_claim", 2, []string{"ClaimedValue", "LocusHash"}},
+		{&DynamicCircuit{}, "dynamic", 4, []string{"RefHash", "AltHash", "ClaimedGenotype", "ClaimMode", "LocusHash"}},
+		{NewChromosomeCircuit(2), "chromosome", 2, []string{"TargetChromosome"}},
+		{&MTHFRCircuit{}, "mthfr", 1, []string{"ClaimedStatus"}},
+		{NewBRCA2PanelCircuit(nil, 2), "brca2_panel", 1, []string{"IsCarrier"}},
+		{NewBurdenCircuit(2), "burden", 2, []string{"Threshold", "AtLeast", "Chromosome", "RegionStart", "RegionEnd", "ListHash"}},
+		{NewSexChromosomeCircuit(2), "sex_chromosome", 1, []string{"ClaimedKaryotype"}},
+		{&NegativeCircuit{}, "negative", 2, []string{"Chromosome", "Position", "RefHash", "AltHash", "LocusHash"}},
+		{NewKinshipCircuit(2), "kinship", 1, []string{"PanelHash", "MinLoci", "MaxMismatches"}},
+		{NewAggregateCircuit(2), "aggregate", 2, []string{"Chromosomes_0", "Chromosomes_1", "Positions_0", "Positions_1",
+			"RefHashes_0", "RefHashes_1", "AltHashes_0", "AltHashes_1", "LocusHashes_0", "LocusHashes_1",
+			"ClaimedGenotypes_0", "ClaimedGenotypes_1"}},
+		{&CarrierCircuit{}, "carrier", 2, []string{"Chromosome", "Position", "RefHash", "AltHash", "LocusHash"}},
+		{NewCommittedVariantCircuit(2), "committed_variant", 1, []string{"Root", "Chromosome", "Position", "RefHash", "AltHash", "ClaimedGenotype"}},
+		{NewRegionCountCircuit(2), "region_count", 2, []string{"Threshold", "Chromosome", "RegionStart", "RegionEnd"}},
+		{&PhaseCircuit{}, "phase", 2, []string{"LocusHashA", "LocusHashB", "ClaimedPhase"}},
+	}
+
+	for _, tc := range tests {
+		layout := tc.circuit.PublicInputLayout()
+		if layout.CircuitID != tc.circuitID || layout.Version != tc.version {
+			t.Errorf("Expected %s v%d, got %s v%d", tc.circuitID, tc.version, layout.CircuitID, layout.Version)
+		}
+		if !slices.Equal(layout.Inputs, tc.inputs) {
+			t.Errorf("%s v%d: expected layout %v, got %v", tc.circuitID, tc.version, tc.inputs, layout.Inputs)
+		}
+		if err := validatePublicInputLayout(tc.circuit); err != nil {
+			t.Errorf("%s v%d: %v", tc.circuitID, tc.version, err)
+		}
+	}
+}
+
+// reorderedCircuit declares its public inputs in a different order than its fields
+type reorderedCircuit struct {
+	B frontend.Variable `gnark:",public"`
+	A frontend.Variable `gnark:",public"`
+}
+
+func (c *reorderedCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.A, c.B)
+	return nil
+}
+
+func (c *reorderedCircuit) PublicInputLayout() PublicInputLayout {
+	return PublicInputLayout{CircuitID: "reordered_test", Version: 1, Inputs: []string{"A", "B"}}
+}
+
+func TestValidatePublicInputLayout_DetectsReorder(t *testing.T) {
+	if err := validatePublicInputLayout(&reorderedCircuit{}); err == nil {
+		t.Error("Expected reordered public inputs to be rejected")
+	}
+
+	if _, err := proveCircuit(nil, nil, &reorderedCircuit{}, &reorderedCircuit{A: 1, B: 1}); err == nil {
+		t.Error("Expected proveCircuit to refuse a circuit whose layout does not match")
+	}
+}
+
+// TestRetiredLayouts pins the layouts of superseded circuit versions, which
+// must keep decoding for proofs made before the bump
+func TestRetiredLayouts(t *testing.T) {
+	tests := []struct {
+		circuitID string
+		version   int
+		inputs    []string
+	}{
+		{"chromosome", 1, []string{"TargetChromosome"}},
+		{"dynamic", 1, []string{"ClaimedRef", "ClaimedAlt", "ClaimedGenotype"}},
+		{"dynamic", 2, []string{"ClaimedRef", "ClaimedAlt", "ClaimedGenotype", "ClaimMode"}},
+		{"dynamic", 3, []string{"ClaimedRef", "ClaimedAlt", "ClaimedGenotype", "ClaimMode", "LocusHash"}},
+		{"genotype_claim", 1, []string{"ClaimedValue"}},
+		{"negative", 1, []string{"Chromosome", "Position", "RefHash", "AltHash"}},
+		{"carrier", 1, []string{"Chromosome", "Position", "RefHash", "AltHash"}},
+		{"phase", 1, []string{"LocusHashA", "LocusHashB", "ClaimedPhase"}},
+		{"aggregate", 1, []string{"Chromosomes_0", "Chromosomes_1", "Positions_0", "Positions_1",
+			"RefHashes_0", "RefHashes_1", "AltHashes_0", "AltHashes_1", "ClaimedGenotypes_0", "ClaimedGenotypes_1"}},
+		{"region_count", 1, []string{"Threshold", "Chromosome", "RegionStart", "RegionEnd"}},
+		{"burden", 1, []string{"Threshold", "AtLeast", "Chromosome", "RegionStart", "RegionEnd", "ListHash"}},
+	}
+
+	for _, tc := range tests {
+		layout, err := circuitLayout(tc.circuitID, tc.version, len(tc.inputs))
+		if err != nil {
+			t.Errorf("%s v%d: %v", tc.circuitID, tc.version, err)
+			continue
+		}
+		if !slices.Equal(layout.Inputs, tc.inputs) {
+			t.Errorf("%s v%d: expected layout %v, got %v", tc.circuitID, tc.version, tc.inputs, layout.Inputs)
+		}
+	}
+}
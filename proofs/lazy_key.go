@@ -0,0 +1,100 @@
+package proofs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// lazyProvingKey is a proving key read from its source on first use. Proving
+// keys of large circuits run to hundreds of megabytes, so key stores return
+// them lazily: loading keys to check a circuit's public inputs, to set up or
+// to warm a cache reads only the verifying key, and proving starts reading
+// the proving key while the witness is created.
+type lazyProvingKey struct {
+	// open opens the serialized key
+	open func() (io.ReadCloser, error)
+	// read decodes the key from its source into pk
+	read   func(pk ProvingKey, r io.Reader) (int64, error)
+	newKey func() ProvingKey
+
+	once sync.Once
+	pk   ProvingKey
+	err  error
+}
+
+// newLazyProvingKey returns the proving key of backend decoded with read from
+// the source open opens, once it is first used
+func newLazyProvingKey(backend Backend, open func() (io.ReadCloser, error), read func(pk ProvingKey, r io.Reader) (int64, error)) *lazyProvingKey {
+	return &lazyProvingKey{open: open, read: read, newKey: backend.NewProvingKey}
+}
+
+// load reads the key, once, streaming it from its source
+func (k *lazyProvingKey) load() (ProvingKey, error) {
+	k.once.Do(func() {
+		r, err := k.open()
+		if err != nil {
+			k.err = err
+			return
+		}
+		defer r.Close()
+		pk := k.newKey()
+		if _, err := k.read(pk, bufio.NewReader(r)); err != nil {
+			k.err = fmt.Errorf("reading proving key: %w", err)
+			return
+		}
+		k.pk = pk
+	})
+	return k.pk, k.err
+}
+
+func (k *lazyProvingKey) WriteTo(w io.Writer) (int64, error) {
+	pk, err := k.load()
+	if err != nil {
+		return 0, err
+	}
+	return pk.WriteTo(w)
+}
+
+func (k *lazyProvingKey) WriteRawTo(w io.Writer) (int64, error) {
+	pk, err := k.load()
+	if err != nil {
+		return 0, err
+	}
+	return pk.WriteRawTo(w)
+}
+
+func (k *lazyProvingKey) ReadFrom(r io.Reader) (int64, error) {
+	pk, err := k.load()
+	if err != nil {
+		return 0, err
+	}
+	return pk.ReadFrom(r)
+}
+
+func (k *lazyProvingKey) UnsafeReadFrom(r io.Reader) (int64, error) {
+	pk, err := k.load()
+	if err != nil {
+		return 0, err
+	}
+	return pk.UnsafeReadFrom(r)
+}
+
+// LoadedProvingKey returns pk, read from its source if it is lazy. Key
+// stores return proving keys that are read on first use, so a Prover handing
+// pk to a library expecting the backend's own key type resolves it first.
+func LoadedProvingKey(pk ProvingKey) (ProvingKey, error) {
+	if lazy, ok := pk.(*lazyProvingKey); ok {
+		return lazy.load()
+	}
+	return pk, nil
+}
+
+// prefetchProvingKey starts reading pk in the background if it is lazy, so
+// it is read while the witness is created
+func prefetchProvingKey(pk ProvingKey) {
+	if lazy, ok := pk.(*lazyProvingKey); ok {
+		go lazy.load()
+	}
+}
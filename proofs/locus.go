@@ -0,0 +1,50 @@
+package proofs
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// LocusHash returns MiMC(chromosome, position, H(ref), H(alt)), the public
+// value single-variant circuits use to disclose which locus they refer to.
+// chromosome is a traits.ChromosomeCode, zero when unknown. The hash is over
+// BN254; proofs over other curves disclose LocusHashOn their curve.
+func LocusHash(chromosome int, position uint64, ref string, alt string) (*big.Int, error) {
+	return LocusHashOn(ecc.BN254, chromosome, position, ref, alt)
+}
+
+// LocusHashOn returns the LocusHash of a locus with MiMC over the scalar
+// field of curve, as disclosed by proofs over that curve
+func LocusHashOn(curve ecc.ID, chromosome int, position uint64, ref string, alt string) (*big.Int, error) {
+	values := []*big.Int{big.NewInt(int64(chromosome)), new(big.Int).SetUint64(position)}
+	for _, allele := range []string{ref, alt} {
+		hashed, err := alleleHash(allele)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, hashed)
+	}
+
+	return mimcValues(curve, values)
+}
+
+// variantLocusHash returns the LocusHash of a trait variant over curve
+func variantLocusHash(curve ecc.ID, variant traits.TraitVariant) (*big.Int, error) {
+	return LocusHashOn(curve, variant.Chromosome, uint64(variant.Position), variant.Ref, variant.Alt)
+}
+
+// assertLocusHash constrains locusHash to be the LocusHash of the given
+// chromosome, position and allele hashes
+func assertLocusHash(api frontend.API, locusHash frontend.Variable, chromosome frontend.Variable, position frontend.Variable, refHash frontend.Variable, altHash frontend.Variable) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	h.Write(chromosome, position, refHash, altHash)
+	api.AssertIsEqual(h.Sum(), locusHash)
+	return nil
+}
@@ -0,0 +1,164 @@
+package proofs
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// testLocusValues holds a locus and its hashes for assigning DynamicCircuit
+type testLocusValues struct {
+	chromosome int
+	position   uint64
+	refHash    *big.Int
+	altHash    *big.Int
+	hash       *big.Int
+}
+
+func testLocus(t *testing.T, chromosome int, position uint64, ref string, alt string) testLocusValues {
+	t.Helper()
+	refHash, err := alleleHash(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	altHash, err := alleleHash(alt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := LocusHash(chromosome, position, ref, alt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testLocusValues{chromosome, position, refHash, altHash, hash}
+}
+
+func (l testLocusValues) assignTo(c *DynamicCircuit) {
+	c.LocusHash = l.hash
+	c.Chromosome = l.chromosome
+	c.Position = l.position
+	c.RefHash = l.refHash
+	c.AltHash = l.altHash
+}
+
+func TestDynamicCircuit_LocusHash(t *testing.T) {
+	locus := testLocus(t, 15, 28365618, "A", "G")
+	other := testLocus(t, 15, 28365619, "A", "G")
+
+	assignment := &DynamicCircuit{
+		ClaimedGenotype: 1,
+		ClaimMode:       int(ClaimExactGenotype),
+		ActualGenotype:  1,
+	}
+	locus.assignTo(assignment)
+	setTestAlleles(assignment, 1)
+	if err := test.IsSolved(&DynamicCircuit{}, assignment, ecc.BN254.ScalarField()); err != nil {
+		t.Errorf("Expected circuit to be solved: %v", err)
+	}
+
+	// The public hash must match the private locus
+	assignment.LocusHash = other.hash
+	if err := test.IsSolved(&DynamicCircuit{}, assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Error("Expected circuit with a mismatched locus hash not to be solved")
+	}
+}
+
+func TestDynamicProof_LocusHash(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+chr15	28365618	rs12913832	A	G	60	PASS	.	GT	0/1
+`)
+
+	proofData, err := NewDynamicProof(28365618, "A", "G").Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	locus := testLocus(t, 15, 28365618, "A", "G")
+	expected := []PublicValue{{Name: "LocusHash", Value: locus.hash.String()}}
+	if err := CheckPublicValues(proofData, expected); err != nil {
+		t.Errorf("Expected the proof to disclose the locus hash: %v", err)
+	}
+
+	other := testLocus(t, 16, 28365618, "A", "G")
+	expected = []PublicValue{{Name: "LocusHash", Value: other.hash.String()}}
+	var mismatch *ClaimMismatchError
+	if err := CheckPublicValues(proofData, expected); !errors.As(err, &mismatch) {
+		t.Errorf("Expected a ClaimMismatchError for another chromosome, got %v", err)
+	}
+}
+
+func TestDynamicProof_Chromosome(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+14	28365618	.	A	G	60	PASS	.	GT	1/1
+15	28365618	rs12913832	A	G	60	PASS	.	GT	0/1
+`)
+
+	proof := NewDynamicProof(28365618, "A", "G")
+	proof.Chromosome = 15
+	assignment, err := proof.assign(vcfPath, proof.Position, proof.Reference, proof.Alternate)
+	if err != nil {
+		t.Fatalf("assign should not return error: %v", err)
+	}
+	if assignment.ActualGenotype != 1 || assignment.Chromosome != 15 {
+		t.Errorf("Expected the chromosome 15 record, got genotype %v on chromosome %v",
+			assignment.ActualGenotype, assignment.Chromosome)
+	}
+}
+
+func TestGenotypeClaimProof_LocusHash(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+11	66328095	rs1815739	C	T	60	PASS	.	GT	1/1
+`)
+
+	proofData, err := (&ACTN3Proof{}).Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	v := traits.ACTN3Variant
+	locus := testLocus(t, v.Chromosome, uint64(v.Position), v.Ref, v.Alt)
+	expected := []PublicValue{{Name: "LocusHash", Value: locus.hash.String()}}
+	if err := CheckPublicValues(proofData, expected); err != nil {
+		t.Errorf("Expected the proof to disclose the ACTN3 locus hash: %v", err)
+	}
+}
+
+func TestDynamicProof_IndelAlleles(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+3	46414946	.	TACAGTCAGTATCAATTCTGGAAGAATTTCCAG	T	60	PASS	.	GT	0/1
+`)
+
+	ref := "TACAGTCAGTATCAATTCTGGAAGAATTTCCAG"
+	proofData, err := NewDynamicProof(46414946, ref, "T").Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	locus := testLocus(t, 3, 46414946, ref, "T")
+	expected := []PublicValue{
+		{Name: "RefHash", Value: locus.refHash.String()},
+		{Name: "AltHash", Value: locus.altHash.String()},
+	}
+	if err := CheckPublicValues(proofData, expected); err != nil {
+		t.Errorf("Expected the proof to disclose the indel allele hashes: %v", err)
+	}
+
+	// A different deletion of the same length must not match
+	other := testLocus(t, 3, 46414946, "TACAGTCAGTATCAATTCTGGAAGAATTTCCAA", "T")
+	expected = []PublicValue{{Name: "RefHash", Value: other.refHash.String()}}
+	var mismatch *ClaimMismatchError
+	if err := CheckPublicValues(proofData, expected); !errors.As(err, &mismatch) {
+		t.Errorf("Expected a ClaimMismatchError for another REF allele, got %v", err)
+	}
+}
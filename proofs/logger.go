@@ -0,0 +1,93 @@
+package proofs
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// LogLevel orders the severity of log messages
+type LogLevel int
+
+const (
+	// LevelDebug is for detail only useful when diagnosing the library
+	LevelDebug LogLevel = iota
+	// LevelInfo is for progress messages such as the stages of proving
+	LevelInfo
+	// LevelWarn is for conditions that do not stop a proof but deserve attention
+	LevelWarn
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	default:
+		return "unknown"
+	}
+}
+
+// Logger receives the messages proof types emit while extracting, proving and
+// verifying. Messages never contain private genomic values such as genotypes
+// or the alleles found in the genome; they are limited to what the caller
+// supplied and what the proof discloses.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+}
+
+// NopLogger discards every message. It is used wherever no Logger is set, so
+// the library is silent by default.
+type NopLogger struct{}
+
+func (NopLogger) Debugf(string, ...any) {}
+func (NopLogger) Infof(string, ...any)  {}
+func (NopLogger) Warnf(string, ...any)  {}
+
+// writerLogger writes messages at or above a level to a writer, one per line.
+// Writes are serialized so concurrent proofs can share one logger.
+type writerLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level LogLevel
+}
+
+// NewWriterLogger returns a Logger writing messages at or above level to w.
+// Warnings are prefixed with "warning: " and debug messages with "debug: ".
+func NewWriterLogger(w io.Writer, level LogLevel) Logger {
+	return &writerLogger{w: w, level: level}
+}
+
+func (l *writerLogger) Debugf(format string, args ...any) {
+	l.logf(LevelDebug, "debug: ", format, args...)
+}
+
+func (l *writerLogger) Infof(format string, args ...any) {
+	l.logf(LevelInfo, "", format, args...)
+}
+
+func (l *writerLogger) Warnf(format string, args ...any) {
+	l.logf(LevelWarn, "warning: ", format, args...)
+}
+
+func (l *writerLogger) logf(level LogLevel, prefix string, format string, args ...any) {
+	if level < l.level {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.w, prefix+format+"\n", args...)
+}
+
+// loggerOrNop returns logger, or a NopLogger when logger is nil
+func loggerOrNop(logger Logger) Logger {
+	if logger == nil {
+		return NopLogger{}
+	}
+	return logger
+}
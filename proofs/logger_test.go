@@ -0,0 +1,60 @@
+package proofs
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWriterLogger_Levels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWriterLogger(&buf, LevelInfo)
+	logger.Debugf("hidden %d", 1)
+	logger.Infof("shown %d", 2)
+	logger.Warnf("careful")
+
+	if got, want := buf.String(), "shown 2\nwarning: careful\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestDynamicProof_LogsNoGenotype(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+11	66328095	rs1815739	C	T	60	PASS	.	GT	0/1
+`)
+
+	// Without a logger nothing reaches stdout
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	_, genErr := NewDynamicProof(66328095, "C", "T").Generate(vcfPath, "", "")
+	os.Stdout = stdout
+	w.Close()
+	printed, _ := io.ReadAll(r)
+	if genErr != nil {
+		t.Fatalf("Generate should not return error: %v", genErr)
+	}
+	if len(printed) != 0 {
+		t.Errorf("Expected a silent library, got %q", printed)
+	}
+
+	var buf bytes.Buffer
+	proof := NewDynamicProof(66328095, "C", "T")
+	proof.Logger = NewWriterLogger(&buf, LevelDebug)
+	if _, err := proof.Generate(vcfPath, "", ""); err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Compiling") {
+		t.Errorf("Expected proving stages to be logged, got %q", buf.String())
+	}
+	if strings.Contains(strings.ToLower(buf.String()), "genotype") {
+		t.Errorf("Expected no genotype in the log, got %q", buf.String())
+	}
+}
@@ -0,0 +1,398 @@
+package proofs
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs/gadgets"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// MerkleLeaf is one committed genotype: the first sample's dosage of Alt at a
+// record. Multi-allelic records contribute one leaf per ALT allele.
+type MerkleLeaf struct {
+	Chromosome int
+	Position   uint64
+	Ref        string
+	Alt        string
+	Genotype   int
+}
+
+// GenomeMerkleTree is a MiMC Merkle tree over every called record of a
+// genome. Leaves are salted with a per-genome secret so the published root
+// reveals nothing about the genotypes, and the tree is padded with zero leaves
+// to a power of two.
+type GenomeMerkleTree struct {
+	Salt   *big.Int
+	Leaves []MerkleLeaf
+	// levels[0] holds the leaf hashes and the last level the root
+	levels [][]fr.Element
+}
+
+// BuildGenomeMerkleTree hashes every record of source with a called genotype
+// in the first sample. Records without a genotype call are not committed and
+// cannot be proven against the root.
+func BuildGenomeMerkleTree(source GenomeSource, salt *big.Int) (*GenomeMerkleTree, error) {
+	tree := &GenomeMerkleTree{Salt: salt}
+
+	var leafErr error
+	err := source.IterateRegion("", 0, math.MaxUint64, func(call *VariantCall) bool {
+		if len(call.Samples) == 0 {
+			leafErr = fmt.Errorf("%w: VCF has no samples", ErrNoSampleData)
+			return false
+		}
+		sample := call.Samples[0]
+		if len(sample.GT) == 0 {
+			return true
+		}
+		for _, allele := range sample.GT {
+			if allele < 0 {
+				return true
+			}
+		}
+
+		for _, alt := range call.Alternate {
+			tree.Leaves = append(tree.Leaves, MerkleLeaf{
+				Chromosome: traits.ChromosomeCode(call.Chromosome),
+				Position:   call.Position,
+				Ref:        call.Reference,
+				Alt:        alt,
+				Genotype:   altDosage(call, sample, alt),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if leafErr != nil {
+		return nil, leafErr
+	}
+	if len(tree.Leaves) == 0 {
+		return nil, fmt.Errorf("genome has no called genotypes to commit")
+	}
+
+	depth := bits.Len(uint(len(tree.Leaves) - 1))
+	if depth == 0 {
+		depth = 1
+	}
+	level := make([]fr.Element, 1<<depth)
+	for i, leaf := range tree.Leaves {
+		hash, err := merkleLeafHash(salt, leaf)
+		if err != nil {
+			return nil, err
+		}
+		level[i] = hash
+	}
+	tree.levels = append(tree.levels, level)
+
+	for len(level) > 1 {
+		parent := make([]fr.Element, len(level)/2)
+		for i := range parent {
+			hash, err := mimcElements(level[2*i : 2*i+2])
+			if err != nil {
+				return nil, err
+			}
+			parent[i].SetBigInt(hash)
+		}
+		tree.levels = append(tree.levels, parent)
+		level = parent
+	}
+
+	return tree, nil
+}
+
+// merkleLeafHash returns MiMC(salt, chromosome, position, H(ref), H(alt), genotype)
+func merkleLeafHash(salt *big.Int, leaf MerkleLeaf) (fr.Element, error) {
+	refHash, err := alleleHash(leaf.Ref)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	altHash, err := alleleHash(leaf.Alt)
+	if err != nil {
+		return fr.Element{}, err
+	}
+
+	var s, chrom, pos, ref, alt, genotype fr.Element
+	s.SetBigInt(salt)
+	ref.SetBigInt(refHash)
+	alt.SetBigInt(altHash)
+	chrom.SetInt64(int64(leaf.Chromosome))
+	pos.SetUint64(leaf.Position)
+	genotype.SetInt64(int64(leaf.Genotype))
+
+	hash, err := mimcElements([]fr.Element{s, chrom, pos, ref, alt, genotype})
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var e fr.Element
+	e.SetBigInt(hash)
+	return e, nil
+}
+
+// Depth returns the number of hashing levels between a leaf and the root
+func (t *GenomeMerkleTree) Depth() int {
+	return len(t.levels) - 1
+}
+
+// Root returns the Merkle root committing to the genome
+func (t *GenomeMerkleTree) Root() *big.Int {
+	root := t.levels[len(t.levels)-1][0]
+	return root.BigInt(new(big.Int))
+}
+
+// Find returns the index of the leaf for variant, matching alleles as
+// allelesMatch does. A zero chromosome matches any chromosome.
+func (t *GenomeMerkleTree) Find(variant traits.TraitVariant) (int, bool) {
+	for i, leaf := range t.Leaves {
+		if leaf.Position != uint64(variant.Position) {
+			continue
+		}
+		if variant.Chromosome > 0 && leaf.Chromosome != variant.Chromosome {
+			continue
+		}
+		if allelesMatch(variant.Ref, leaf.Ref) && allelesMatch(variant.Alt, leaf.Alt) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Path returns the sibling hashes from leaf index up to the root, and for
+// each level whether the running node is the right child
+func (t *GenomeMerkleTree) Path(index int) ([]*big.Int, []int) {
+	siblings := make([]*big.Int, t.Depth())
+	rightChild := make([]int, t.Depth())
+	for level := range siblings {
+		sibling := t.levels[level][index^1]
+		siblings[level] = sibling.BigInt(new(big.Int))
+		rightChild[level] = index & 1
+		index >>= 1
+	}
+	return siblings, rightChild
+}
+
+// assertMerkleInclusion constrains leaf to hash up to root along the path
+func assertMerkleInclusion(api frontend.API, leaf frontend.Variable, siblings []frontend.Variable, rightChild []frontend.Variable, root frontend.Variable) error {
+	node := leaf
+	for i := range siblings {
+		api.AssertIsBoolean(rightChild[i])
+		left := api.Select(rightChild[i], siblings[i], node)
+		right := api.Select(rightChild[i], node, siblings[i])
+
+		h, err := mimc.NewMiMC(api)
+		if err != nil {
+			return err
+		}
+		h.Write(left, right)
+		node = h.Sum()
+	}
+	api.AssertIsEqual(node, root)
+	return nil
+}
+
+// CommittedVariantCircuit proves the genotype at a public locus and that it
+// was taken from the genome committed to by the public Root. Proofs sharing a
+// Root were provably derived from the same genome.
+type CommittedVariantCircuit struct {
+	Root            frontend.Variable `gnark:",public"`
+	Chromosome      frontend.Variable `gnark:",public"`
+	Position        frontend.Variable `gnark:",public"`
+	RefHash         frontend.Variable `gnark:",public"`
+	AltHash         frontend.Variable `gnark:",public"`
+	ClaimedGenotype frontend.Variable `gnark:",public"`
+
+	Salt       frontend.Variable
+	Genotype   frontend.Variable
+	Siblings   []frontend.Variable
+	RightChild []frontend.Variable
+}
+
+// NewCommittedVariantCircuit allocates a circuit for a tree of the given depth
+func NewCommittedVariantCircuit(depth int) *CommittedVariantCircuit {
+	return &CommittedVariantCircuit{
+		Siblings:   make([]frontend.Variable, depth),
+		RightChild: make([]frontend.Variable, depth),
+	}
+}
+
+func (c *CommittedVariantCircuit) Define(api frontend.API) error {
+	if len(c.RightChild) != len(c.Siblings) {
+		return fmt.Errorf("committed variant circuit slices must have equal length")
+	}
+
+	g := c.Genotype
+	gadgets.AssertIsGenotype(api, g)
+	api.AssertIsEqual(c.ClaimedGenotype, g)
+
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.Salt, c.Chromosome, c.Position, c.RefHash, c.AltHash, g)
+
+	return assertMerkleInclusion(api, h.Sum(), c.Siblings, c.RightChild, c.Root)
+}
+
+// assignCurve refuses curves other than BN254, the curve genome commitments
+// are made over
+func (c *CommittedVariantCircuit) assignCurve(curve ecc.ID) error {
+	return fmt.Errorf("genome commitments are over bn254, not %s", curve)
+}
+
+// NewCommittedVariantProof creates a CommittedVariantProof for the given variant
+func NewCommittedVariantProof(variant traits.TraitVariant) *CommittedVariantProof {
+	return &CommittedVariantProof{Variant: variant}
+}
+
+// Assign rebuilds the committed tree and builds the circuit and its assignment
+func (p *CommittedVariantProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewCommittedVariantCircuit(len(assignment.Siblings)), assignment, nil
+}
+
+func (p *CommittedVariantProof) assign(vcfPath string) (*CommittedVariantCircuit, error) {
+	variant := p.Variant
+	if variant.Position <= 0 || variant.Ref == "" || variant.Alt == "" {
+		return nil, fmt.Errorf("no variant set")
+	}
+
+	commitment := p.Commitment
+	if commitment == nil {
+		loaded, err := LoadGenomeCommitment(vcfPath)
+		if err != nil {
+			return nil, err
+		}
+		commitment = loaded
+	}
+	if commitment == nil || commitment.MerkleRoot == "" {
+		return nil, fmt.Errorf("genome has no Merkle commitment; commit it with a Merkle root first")
+	}
+	salt, ok := new(big.Int).SetString(commitment.MerkleSalt, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid Merkle salt in genome commitment")
+	}
+
+	source, err := sourceOrVCF(p.Source, vcfPath, "", p.Progress)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBuild(source.Build(), variant.Build, variant.Trait); err != nil {
+		return nil, err
+	}
+
+	loggerOrNop(p.Logger).Infof("rebuilding genome Merkle tree...")
+	tree, err := BuildGenomeMerkleTree(source, salt)
+	if err != nil {
+		return nil, err
+	}
+	if root := tree.Root().String(); root != commitment.MerkleRoot {
+		return nil, fmt.Errorf("genome no longer matches its Merkle root %s", commitment.MerkleRoot)
+	}
+
+	index, ok := tree.Find(variant)
+	if !ok {
+		return nil, fmt.Errorf("%s>%s at position %d is not a committed genotype", variant.Ref, variant.Alt, variant.Position)
+	}
+	leaf := tree.Leaves[index]
+
+	refHash, err := alleleHash(leaf.Ref)
+	if err != nil {
+		return nil, err
+	}
+	altHash, err := alleleHash(leaf.Alt)
+	if err != nil {
+		return nil, err
+	}
+
+	siblings, rightChild := tree.Path(index)
+	assignment := NewCommittedVariantCircuit(tree.Depth())
+	assignment.Root = tree.Root()
+	assignment.Chromosome = leaf.Chromosome
+	assignment.Position = leaf.Position
+	assignment.RefHash = refHash
+	assignment.AltHash = altHash
+	assignment.ClaimedGenotype = leaf.Genotype
+	assignment.Salt = salt
+	assignment.Genotype = leaf.Genotype
+	for i := range siblings {
+		assignment.Siblings[i] = siblings[i]
+		assignment.RightChild[i] = rightChild[i]
+	}
+
+	return assignment, nil
+}
+
+func (p *CommittedVariantProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewCommittedVariantCircuit(len(assignment.Siblings)), assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Committed variant proof successfully generated for position %d with genotype %v",
+		p.Variant.Position, assignment.ClaimedGenotype)
+
+	return proofData, nil
+}
+
+func (p *CommittedVariantProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "committed variant", verifyingKeyPath, proofPath)
+}
+
+func (p *CommittedVariantProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "committed variant", proofData)
+}
+
+// AddMerkleRoot draws a fresh salt and records the Merkle root of the genome
+// in source on the commitment. The salt is secret: together with the root it
+// lets anyone test guesses of the committed genotypes.
+func (c *GenomeCommitment) AddMerkleRoot(source GenomeSource) error {
+	salt, err := randomSalt()
+	if err != nil {
+		return err
+	}
+
+	tree, err := BuildGenomeMerkleTree(source, salt)
+	if err != nil {
+		return err
+	}
+	c.MerkleRoot = tree.Root().String()
+	c.MerkleSalt = salt.String()
+	return nil
+}
+
+// CommitGenomeMerkle replaces the stored commitment for vcfPath with one that
+// also records the genome's Merkle root, and returns it
+func CommitGenomeMerkle(vcfPath string, progress ProgressReporter) (*GenomeCommitment, error) {
+	commitment, err := CommitGenome(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := NewVCFSource(vcfPath, progress)
+	if err != nil {
+		return nil, err
+	}
+	if err := commitment.AddMerkleRoot(source); err != nil {
+		return nil, err
+	}
+
+	if err := commitment.Save(); err != nil {
+		return nil, fmt.Errorf("saving genome commitment: %w", err)
+	}
+	return commitment, nil
+}
@@ -0,0 +1,121 @@
+package proofs
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+const merkleTestVCF = `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+2	136608646	rs4988235	G	A	60	PASS	.	GT	0/1
+13	32914437	.	GT	G,GTT	60	PASS	.	GT	0/2
+15	28365618	rs12913832	A	G	60	PASS	.	GT	1/1
+17	41276044	.	ACT	A	60	PASS	.	GT	./.
+`
+
+var merkleTestVariant = traits.TraitVariant{Chromosome: 15, Position: 28365618, Ref: "A", Alt: "G"}
+
+func TestGenomeMerkleTree(t *testing.T) {
+	source, err := NewVCFSource(writeTestVCF(t, merkleTestVCF), nil)
+	if err != nil {
+		t.Fatalf("opening VCF: %v", err)
+	}
+
+	tree, err := BuildGenomeMerkleTree(source, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("BuildGenomeMerkleTree should not return error: %v", err)
+	}
+
+	// The no-call is skipped and the multi-allelic record yields two leaves
+	if len(tree.Leaves) != 4 || tree.Depth() != 2 {
+		t.Fatalf("Expected 4 leaves at depth 2, got %d at depth %d", len(tree.Leaves), tree.Depth())
+	}
+	index, ok := tree.Find(traits.TraitVariant{Chromosome: 13, Position: 32914437, Ref: "GT", Alt: "GTT"})
+	if !ok || tree.Leaves[index].Genotype != 1 {
+		t.Errorf("Expected one copy of the second ALT allele, got %+v", tree.Leaves[index])
+	}
+	if _, ok := tree.Find(traits.TraitVariant{Chromosome: 17, Position: 41276044, Ref: "ACT", Alt: "A"}); ok {
+		t.Error("Expected uncalled record not to be committed")
+	}
+
+	salted, err := BuildGenomeMerkleTree(source, big.NewInt(43))
+	if err != nil {
+		t.Fatalf("BuildGenomeMerkleTree should not return error: %v", err)
+	}
+	if salted.Root().Cmp(tree.Root()) == 0 {
+		t.Error("Expected the salt to change the root")
+	}
+}
+
+func TestCommittedVariantCircuit(t *testing.T) {
+	source, err := NewVCFSource(writeTestVCF(t, merkleTestVCF), nil)
+	if err != nil {
+		t.Fatalf("opening VCF: %v", err)
+	}
+	tree, err := BuildGenomeMerkleTree(source, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("BuildGenomeMerkleTree should not return error: %v", err)
+	}
+
+	proof := NewCommittedVariantProof(merkleTestVariant)
+	proof.Source = source
+	proof.Commitment = &GenomeCommitment{MerkleRoot: tree.Root().String(), MerkleSalt: "42"}
+	assignment, err := proof.assign("")
+	if err != nil {
+		t.Fatalf("assign should not return error: %v", err)
+	}
+
+	circuit := NewCommittedVariantCircuit(tree.Depth())
+	if err := test.IsSolved(circuit, assignment, ecc.BN254.ScalarField()); err != nil {
+		t.Errorf("Expected committed genotype to be provable: %v", err)
+	}
+
+	assignment.ClaimedGenotype, assignment.Genotype = 1, 1
+	if err := test.IsSolved(circuit, assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Error("Expected a genotype outside the tree not to be provable")
+	}
+
+	assignment.ClaimedGenotype, assignment.Genotype = 2, 2
+	assignment.Root = 1
+	if err := test.IsSolved(circuit, assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Error("Expected a different root not to be provable")
+	}
+}
+
+func TestCommittedVariantProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, merkleTestVCF)
+	t.Cleanup(func() { os.Remove(CommitmentPath(vcfPath)) })
+
+	proof := NewCommittedVariantProof(merkleTestVariant)
+	if _, err := proof.Generate(vcfPath, "", ""); err == nil {
+		t.Fatal("Expected an uncommitted genome to be refused")
+	}
+
+	commitment, err := CommitGenomeMerkle(vcfPath, nil)
+	if err != nil {
+		t.Fatalf("CommitGenomeMerkle should not return error: %v", err)
+	}
+
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+
+	expected := []PublicValue{{Name: "Root", Value: commitment.MerkleRoot}, {Name: "ClaimedGenotype", Value: "2"}}
+	if err := CheckPublicValues(proofData, expected); err != nil {
+		t.Errorf("Expected proof to disclose the committed root: %v", err)
+	}
+}
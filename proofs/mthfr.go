@@ -0,0 +1,100 @@
+package proofs
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// MTHFRCircuit proves the joint MTHFR status derived from the private
+// rs1801133 (C677T) and rs1801131 (A1298C) genotypes. The status, including
+// compound heterozygosity, is the only public output.
+type MTHFRCircuit struct {
+	ClaimedStatus frontend.Variable `gnark:",public"`
+
+	C677TGenotype  frontend.Variable // rs1801133
+	A1298CGenotype frontend.Variable // rs1801131
+}
+
+// Define looks up the status in traits.MTHFRTable by selecting the single
+// table entry whose indices match both genotypes
+func (c *MTHFRCircuit) Define(api frontend.API) error {
+	var status frontend.Variable = 0
+	for i := range traits.MTHFRTable {
+		isC677T := api.IsZero(api.Sub(c.C677TGenotype, i))
+		for j, value := range traits.MTHFRTable[i] {
+			if value == traits.MTHFRUnknown {
+				continue
+			}
+			isA1298C := api.IsZero(api.Sub(c.A1298CGenotype, j))
+			selected := api.Mul(isC677T, isA1298C)
+			status = api.Add(status, api.Mul(selected, int(value)))
+		}
+	}
+
+	// Out-of-range or unsupported genotype combinations select no entry
+	api.AssertIsDifferent(status, int(traits.MTHFRUnknown))
+	api.AssertIsEqual(c.ClaimedStatus, status)
+
+	return nil
+}
+
+// Assign extracts the MTHFR genotypes and builds the circuit and its assignment
+func (p *MTHFRProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &MTHFRCircuit{}, assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *MTHFRProof) Circuit() (frontend.Circuit, error) {
+	return &MTHFRCircuit{}, nil
+}
+
+func (p *MTHFRProof) assign(vcfPath string) (*MTHFRCircuit, traits.MTHFRStatus, error) {
+	loggerOrNop(p.Logger).Infof("searching for MTHFR variants...")
+	genotypes, err := extractTraitGenotypes(vcfPath, p.Sample,
+		[]traits.TraitVariant{p.Traits.Variant("mthfr_c677t"), p.Traits.Variant("mthfr_a1298c")}, p.Progress, p.Logger)
+	if err != nil {
+		return nil, traits.MTHFRUnknown, err
+	}
+	c677t, a1298c := genotypes[0], genotypes[1]
+
+	status := traits.MTHFRStatusFromGenotypes(c677t, a1298c)
+	if status == traits.MTHFRUnknown {
+		return nil, traits.MTHFRUnknown, fmt.Errorf("MTHFR genotypes do not determine a supported status")
+	}
+
+	return &MTHFRCircuit{
+		ClaimedStatus:  int(status),
+		C677TGenotype:  c677t,
+		A1298CGenotype: a1298c,
+	}, status, nil
+}
+
+func (p *MTHFRProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, status, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, &MTHFRCircuit{}, assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ MTHFR proof successfully generated for %s status!", status)
+
+	return proofData, nil
+}
+
+func (p *MTHFRProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "MTHFR", verifyingKeyPath, proofPath)
+}
+
+func (p *MTHFRProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "MTHFR", proofData)
+}
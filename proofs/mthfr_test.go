@@ -0,0 +1,84 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestMTHFRCircuit(t *testing.T) {
+	tests := []struct {
+		c677t   int
+		a1298c  int
+		claimed traits.MTHFRStatus
+		solved  bool
+	}{
+		{0, 0, traits.MTHFRTypical, true},
+		{1, 0, traits.MTHFRC677THeterozygous, true},
+		{2, 0, traits.MTHFRC677THomozygous, true},
+		{0, 1, traits.MTHFRA1298CHeterozygous, true},
+		{0, 2, traits.MTHFRA1298CHomozygous, true},
+		{1, 1, traits.MTHFRCompoundHeterozygous, true},
+		{1, 1, traits.MTHFRC677THeterozygous, false}, // Hides the second locus
+		{2, 2, traits.MTHFRUnknown, false},           // Unsupported combination
+		{3, 0, traits.MTHFRC677THomozygous, false},   // Out of range genotype
+	}
+
+	for _, tc := range tests {
+		assignment := &MTHFRCircuit{
+			ClaimedStatus:  int(tc.claimed),
+			C677TGenotype:  tc.c677t,
+			A1298CGenotype: tc.a1298c,
+		}
+		err := test.IsSolved(&MTHFRCircuit{}, assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("Expected genotypes %d/%d to prove %s: %v", tc.c677t, tc.a1298c, tc.claimed, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("Expected genotypes %d/%d not to prove %s", tc.c677t, tc.a1298c, tc.claimed)
+		}
+	}
+}
+
+func TestMTHFRProof_GenerateCompoundHeterozygous(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	11854476	rs1801131	T	G	60	PASS	.	GT	0/1
+1	11856378	rs1801133	G	A	60	PASS	.	GT	1/0
+`)
+
+	proof := &MTHFRProof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
+
+func TestMTHFRProof_GenerateWithUnsupportedCombination(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	11854476	rs1801131	T	G	60	PASS	.	GT	1/1
+1	11856378	rs1801133	G	A	60	PASS	.	GT	1/1
+`)
+
+	proof := &MTHFRProof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err == nil {
+		t.Errorf("Generate should return error for an unsupported genotype combination")
+	}
+	if proofData.Result != ProofFail {
+		t.Errorf("Expected ProofFail, got %s", proofData.Result.String())
+	}
+}
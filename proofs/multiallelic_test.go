@@ -0,0 +1,124 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+)
+
+// setTestAlleles assigns biallelic allele indices giving genotype
+func setTestAlleles(c *DynamicCircuit, genotype int) {
+	c.Allele1, c.Allele2, c.AltIndex = 0, 0, 1
+	if genotype >= 1 {
+		c.Allele2 = 1
+	}
+	if genotype >= 2 {
+		c.Allele1 = 1
+	}
+}
+
+func TestDynamicCircuit_MultiAllelic(t *testing.T) {
+	locus := testLocus(t, 1, 1000, "A", "C")
+
+	tests := []struct {
+		allele1  int
+		allele2  int
+		altIndex int
+		genotype int
+		solved   bool
+	}{
+		{1, 2, 2, 1, true},
+		{2, 2, 2, 2, true},
+		{1, 1, 2, 0, true},
+		{1, 2, 2, 2, false}, // Genotype does not count the ALT copies
+		{0, 4, 4, 1, false}, // Allele index above the bound
+		{0, 0, 0, 2, false}, // ALT index of the reference allele
+	}
+
+	for _, tc := range tests {
+		assignment := &DynamicCircuit{
+			ClaimedGenotype: tc.genotype,
+			ClaimMode:       int(ClaimExactGenotype),
+			ActualGenotype:  tc.genotype,
+			Allele1:         tc.allele1,
+			Allele2:         tc.allele2,
+			AltIndex:        tc.altIndex,
+		}
+		locus.assignTo(assignment)
+
+		err := test.IsSolved(&DynamicCircuit{}, assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("%d/%d with ALT %d: expected genotype %d to be proven: %v", tc.allele1, tc.allele2, tc.altIndex, tc.genotype, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("%d/%d with ALT %d: expected genotype %d not to be proven", tc.allele1, tc.allele2, tc.altIndex, tc.genotype)
+		}
+	}
+
+	// A larger bound admits higher allele indices
+	assignment := &DynamicCircuit{
+		ClaimedGenotype: 1,
+		ClaimMode:       int(ClaimExactGenotype),
+		ActualGenotype:  1,
+		Allele1:         0,
+		Allele2:         4,
+		AltIndex:        4,
+	}
+	locus.assignTo(assignment)
+	if err := test.IsSolved(NewDynamicCircuit(4), assignment, ecc.BN254.ScalarField()); err != nil {
+		t.Errorf("Expected allele index 4 to be accepted with a bound of 4: %v", err)
+	}
+}
+
+func TestDynamicProof_MultiAllelicSite(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	1000	.	A	G,C	60	PASS	.	GT	1/2
+2	2000	.	A	G,C,T,AT	60	PASS	.	GT	0/4
+`)
+
+	tests := []struct {
+		alt      string
+		genotype int
+	}{
+		{"G", 1},
+		{"C", 1},
+	}
+	for _, tc := range tests {
+		proof := NewDynamicProof(1000, "A", tc.alt)
+		proof.Chromosome = 1
+		assignment, err := proof.assign(vcfPath, proof.Position, proof.Reference, proof.Alternate)
+		if err != nil {
+			t.Fatalf("ALT %s: assign should not return error: %v", tc.alt, err)
+		}
+		if assignment.ActualGenotype != tc.genotype {
+			t.Errorf("ALT %s: expected genotype %d, got %v", tc.alt, tc.genotype, assignment.ActualGenotype)
+		}
+	}
+
+	proof := NewDynamicProof(1000, "A", "C")
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected the multi-allelic proof to verify, got %v: %v", result, err)
+	}
+
+	// Allele index 4 exceeds the default bound unless it is raised
+	proof = NewDynamicProof(2000, "A", "AT")
+	if _, err := proof.assign(vcfPath, proof.Position, proof.Reference, proof.Alternate); err == nil {
+		t.Error("Expected allele index 4 to be refused with the default bound")
+	}
+	proof.MaxAlleleIndex = 4
+	assignment, err := proof.assign(vcfPath, proof.Position, proof.Reference, proof.Alternate)
+	if err != nil {
+		t.Fatalf("assign should not return error with a bound of 4: %v", err)
+	}
+	if assignment.ActualGenotype != 1 {
+		t.Errorf("Expected genotype 1, got %v", assignment.ActualGenotype)
+	}
+}
@@ -0,0 +1,179 @@
+package proofs
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// NegativeCircuit proves that a specific variant is not carried: the private
+// genotype at the public locus is homozygous reference. The alleles are public
+// as field hashes so the statement names exactly one variant. LocusHash is
+// constrained to the LocusHash of the locus, which binds the locus inputs to
+// the proof.
+type NegativeCircuit struct {
+	Chromosome frontend.Variable `gnark:",public"`
+	Position   frontend.Variable `gnark:",public"`
+	RefHash    frontend.Variable `gnark:",public"`
+	AltHash    frontend.Variable `gnark:",public"`
+	LocusHash  frontend.Variable `gnark:",public"`
+
+	Genotype frontend.Variable
+
+	variant traits.TraitVariant
+}
+
+func (c *NegativeCircuit) Define(api frontend.API) error {
+	if err := assertLocusHash(api, c.LocusHash, c.Chromosome, c.Position, c.RefHash, c.AltHash); err != nil {
+		return err
+	}
+
+	api.AssertIsEqual(c.Genotype, 0)
+
+	return nil
+}
+
+func (c *NegativeCircuit) assignCurve(curve ecc.ID) error {
+	locusHash, err := variantLocusHash(curve, c.variant)
+	if err != nil {
+		return err
+	}
+	c.LocusHash = locusHash
+	return nil
+}
+
+// NewNegativeProof creates a NegativeProof for the given variant
+func NewNegativeProof(variant traits.TraitVariant) *NegativeProof {
+	return &NegativeProof{Variant: variant}
+}
+
+// Assign extracts the genotype at the variant and builds the circuit and its assignment
+func (p *NegativeProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &NegativeCircuit{}, assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *NegativeProof) Circuit() (frontend.Circuit, error) {
+	return &NegativeCircuit{}, nil
+}
+
+func (p *NegativeProof) assign(vcfPath string) (*NegativeCircuit, error) {
+	variant := p.Variant
+	if variant.Position <= 0 || variant.Ref == "" || variant.Alt == "" {
+		return nil, fmt.Errorf("no variant set")
+	}
+
+	loggerOrNop(p.Logger).Infof("checking absence of %d:%d %s>%s...", variant.Chromosome, variant.Position, variant.Ref, variant.Alt)
+	genotype, err := p.extractDosage(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	if genotype != 0 {
+		return nil, fmt.Errorf("variant is carried (%d copies)", genotype)
+	}
+
+	refHash, err := alleleHash(variant.Ref)
+	if err != nil {
+		return nil, err
+	}
+	altHash, err := alleleHash(variant.Alt)
+	if err != nil {
+		return nil, err
+	}
+	locusHash, err := variantLocusHash(ecc.BN254, variant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NegativeCircuit{
+		Chromosome: variant.Chromosome,
+		Position:   variant.Position,
+		RefHash:    refHash,
+		AltHash:    altHash,
+		LocusHash:  locusHash,
+		Genotype:   genotype,
+		variant:    variant,
+	}, nil
+}
+
+// extractDosage returns the first sample's ALT dosage at the variant. A
+// missing record counts as homozygous reference if the proof's coverage, if
+// set, shows the locus was sequenced, but a record that matches the variant
+// without a full genotype call is an error: a no-call shows nothing about
+// absence.
+func (p *NegativeProof) extractDosage(vcfPath string) (int, error) {
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkBuild(source.Build(), p.Variant.Build, p.Variant.Trait); err != nil {
+		return 0, err
+	}
+
+	chrom := ""
+	if p.Variant.Chromosome > 0 {
+		chrom = strconv.Itoa(p.Variant.Chromosome)
+	}
+	calls, err := lookupAlleles(source, chrom, uint64(p.Variant.Position), p.Variant.Ref, p.Variant.Alt)
+	if err != nil {
+		return 0, err
+	}
+
+	called := false
+	for _, call := range calls {
+		if !allelesMatch(p.Variant.Ref, call.Reference) {
+			continue
+		}
+		if len(call.Samples) == 0 {
+			return 0, fmt.Errorf("%w: VCF has no samples", ErrNoSampleData)
+		}
+		sample := call.Samples[0]
+		for _, allele := range sample.GT {
+			if allele < 0 {
+				return 0, fmt.Errorf("%w: genotype at position %d is not called", ErrNoSampleData, p.Variant.Position)
+			}
+		}
+		if dosage := altDosage(call, sample, p.Variant.Alt); dosage > 0 {
+			return dosage, nil
+		}
+		called = true
+	}
+	if !called {
+		if err := checkSequenced(p.Coverage, chrom, uint64(p.Variant.Position)); err != nil {
+			return 0, err
+		}
+	}
+	return 0, nil
+}
+
+func (p *NegativeProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, &NegativeCircuit{}, assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Negative proof successfully generated: %s>%s at position %d is not carried",
+		p.Variant.Ref, p.Variant.Alt, p.Variant.Position)
+
+	return proofData, nil
+}
+
+func (p *NegativeProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "negative", verifyingKeyPath, proofPath)
+}
+
+func (p *NegativeProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "negative", proofData)
+}
@@ -0,0 +1,141 @@
+package proofs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+var negativeTestVariant = traits.TraitVariant{
+	Trait:      "Test deletion",
+	Chromosome: 17,
+	Position:   41276044,
+	Ref:        "ACT",
+	Alt:        "A",
+}
+
+func TestNegativeCircuit(t *testing.T) {
+	locusHash, err := mimcValues(ecc.BN254, []*big.Int{big.NewInt(17), big.NewInt(41276044), big.NewInt(1), big.NewInt(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for genotype, solved := range []bool{true, false, false} {
+		assignment := &NegativeCircuit{
+			Chromosome: 17,
+			Position:   41276044,
+			RefHash:    1,
+			AltHash:    2,
+			LocusHash:  locusHash,
+			Genotype:   genotype,
+		}
+		err := test.IsSolved(&NegativeCircuit{}, assignment, ecc.BN254.ScalarField())
+		if solved && err != nil {
+			t.Errorf("Expected genotype %d to prove absence: %v", genotype, err)
+		}
+		if !solved && err == nil {
+			t.Errorf("Expected genotype %d not to prove absence", genotype)
+		}
+	}
+}
+
+func TestNegativeProof_Assign(t *testing.T) {
+	tests := []struct {
+		name   string
+		record string
+		absent bool
+	}{
+		{"no record", "17\t41276000\t.\tG\tA\t60\tPASS\t.\tGT\t0/1\n", true},
+		{"homozygous reference", "17\t41276044\t.\tACT\tA\t60\tPASS\t.\tGT\t0/0\n", true},
+		{"other allele at position", "17\t41276044\t.\tA\tG\t60\tPASS\t.\tGT\t0/1\n", true},
+		{"heterozygous", "17\t41276044\t.\tACT\tA\t60\tPASS\t.\tGT\t0/1\n", false},
+		{"second ALT carried", "17\t41276044\t.\tACT\tAC,A\t60\tPASS\t.\tGT\t0/2\n", false},
+		{"no call", "17\t41276044\t.\tACT\tA\t60\tPASS\t.\tGT\t./.\n", false},
+	}
+
+	for _, tc := range tests {
+		vcfPath := writeTestVCF(t, "##fileformat=VCFv4.2\n"+
+			"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n"+
+			"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n"+tc.record)
+
+		_, err := NewNegativeProof(negativeTestVariant).assign(vcfPath)
+		if tc.absent && err != nil {
+			t.Errorf("%s: expected absence to be provable: %v", tc.name, err)
+		}
+		if !tc.absent && err == nil {
+			t.Errorf("%s: expected absence not to be provable", tc.name)
+		}
+	}
+}
+
+func TestNegativeProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+17	41276044	.	ACT	A	60	PASS	.	GT	0/0
+`)
+
+	proof := NewNegativeProof(negativeTestVariant)
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
+
+// withPublicInput returns a copy of the BN254 proofData whose public input at
+// index i is replaced by value
+func withPublicInput(t *testing.T, proofData *ProofData, i int, value int64) *ProofData {
+	t.Helper()
+	publicWitness, err := decodePublicWitness(ecc.BN254, proofData.PublicWitness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vector, ok := publicWitness.Vector().(fr_bn254.Vector)
+	if !ok || i >= len(vector) {
+		t.Fatalf("Expected a BN254 public witness with more than %d inputs", i)
+	}
+	vector[i].SetInt64(value)
+	data, err := publicWitness.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed := *proofData
+	changed.PublicWitness = data
+	return &changed
+}
+
+func TestNegativeProof_ChangedLocusFails(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+17	41276044	.	ACT	A	60	PASS	.	GT	0/0
+`)
+
+	proof := NewNegativeProof(negativeTestVariant)
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	for name, changed := range map[string]*ProofData{
+		"chromosome": withPublicInput(t, proofData, 0, 13),
+		"position":   withPublicInput(t, proofData, 1, 32340300),
+	} {
+		result, err := proof.VerifyProofData(changed)
+		if err == nil && result.Result == ProofSuccess {
+			t.Errorf("Expected a proof with a changed %s to fail verification", name)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package proofs
+
+import (
+	"strings"
+)
+
+// normalizationWindow is how far from its expected position a record of an
+// indel is looked for, to find indels that are padded with extra reference
+// bases or not left-aligned in a repeat
+const normalizationWindow = 50
+
+// NormalizeVariant returns the parsimonious representation of the variant
+// ref>alt at pos: bases shared at the end of both alleles are trimmed, then
+// bases shared at their start, keeping at least one base in each allele, so
+// an indel keeps its anchor base as VCF requires. Alleles are upper-cased.
+// Left-aligning an indel further needs the reference sequence before it;
+// matching records (see sameVariant) compares variants without it.
+func NormalizeVariant(pos uint64, ref string, alt string) (uint64, string, string) {
+	ref, alt = strings.ToUpper(ref), strings.ToUpper(alt)
+	for len(ref) > 1 && len(alt) > 1 && ref[len(ref)-1] == alt[len(alt)-1] {
+		ref, alt = ref[:len(ref)-1], alt[:len(alt)-1]
+	}
+	for len(ref) > 1 && len(alt) > 1 && ref[0] == alt[0] {
+		ref, alt = ref[1:], alt[1:]
+		pos++
+	}
+	return pos, ref, alt
+}
+
+// sameVariant reports whether ref1>alt1 at pos1 and ref2>alt2 at pos2 are
+// the same change to the genome, however each is written: trimmed or padded
+// with reference bases, or shifted within a repeat. Their REF alleles are
+// the known reference over the span they cover; the variants are the same
+// if they disagree on none of it and applying either to it gives the same
+// sequence. Symbolic alleles, such as <DEL> or *, are only the same as
+// themselves.
+func sameVariant(pos1 uint64, ref1 string, alt1 string, pos2 uint64, ref2 string, alt2 string) bool {
+	if pos1 == pos2 && allelesMatch(ref1, ref2) && allelesMatch(alt1, alt2) {
+		return true
+	}
+	if !isSequence(ref1) || !isSequence(alt1) || !isSequence(ref2) || !isSequence(alt2) {
+		return false
+	}
+
+	start, end := min(pos1, pos2), max(pos1+uint64(len(ref1)), pos2+uint64(len(ref2)))
+	if end-start > 2*normalizationWindow+uint64(len(ref1)+len(ref2)) {
+		return false
+	}
+	// Bases neither REF covers are unknown, but the same in both sequences
+	window := []byte(strings.Repeat("N", int(end-start)))
+	for _, allele := range []struct {
+		pos uint64
+		ref string
+	}{{pos1, ref1}, {pos2, ref2}} {
+		for i := range len(allele.ref) {
+			base := upperBase(allele.ref[i])
+			at := allele.pos - start + uint64(i)
+			if window[at] != 'N' && window[at] != base {
+				return false
+			}
+			window[at] = base
+		}
+	}
+
+	apply := func(pos uint64, ref string, alt string) string {
+		offset := pos - start
+		return string(window[:offset]) + strings.ToUpper(alt) + string(window[offset+uint64(len(ref)):])
+	}
+	return apply(pos1, ref1, alt1) == apply(pos2, ref2, alt2)
+}
+
+// isSequence reports whether allele is a sequence of bases rather than a
+// symbolic, missing or breakend allele
+func isSequence(allele string) bool {
+	if allele == "" {
+		return false
+	}
+	for i := range len(allele) {
+		switch upperBase(allele[i]) {
+		case 'A', 'C', 'G', 'T', 'N':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// upperBase upper-cases a soft-masked base
+func upperBase(b byte) byte {
+	if 'a' <= b && b <= 'z' {
+		return b - 'a' + 'A'
+	}
+	return b
+}
+
+// isIndel reports whether ref>alt changes the length of the sequence or
+// spans several bases, so that it can be written at more than one position
+func isIndel(ref string, alt string) bool {
+	return len(ref) > 1 || len(alt) > 1
+}
+
+// normalizedCall returns call as a call of ref>alt at pos if one of its ALT
+// alleles is that variant written another way, so proofs compare it like a
+// record written as expected, or nil if none is. Its other ALT alleles keep
+// their allele indices but, written against another REF, become *, the VCF
+// allele of an overlapping variant.
+func normalizedCall(call *VariantCall, pos uint64, ref string, alt string) *VariantCall {
+	for i, allele := range call.Alternate {
+		if !sameVariant(pos, ref, alt, call.Position, call.Reference, allele) {
+			continue
+		}
+		normalized := *call
+		normalized.Position = pos
+		normalized.Reference = ref
+		normalized.Alternate = make([]string, len(call.Alternate))
+		for j, other := range call.Alternate {
+			switch {
+			case j == i:
+				normalized.Alternate[j] = alt
+			case call.Position == pos && allelesMatch(ref, call.Reference):
+				normalized.Alternate[j] = other
+			default:
+				normalized.Alternate[j] = "*"
+			}
+		}
+		return &normalized
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestNormalizeVariant(t *testing.T) {
+	tests := []struct {
+		pos      uint64
+		ref, alt string
+		wantPos  uint64
+		wantRef  string
+		wantAlt  string
+	}{
+		{100, "G", "A", 100, "G", "A"},
+		{100, "GCACA", "GCA", 100, "GCA", "G"},
+		{99, "TGCA", "TG", 100, "GCA", "G"},
+		{100, "CAT", "CGT", 101, "A", "G"},
+		{100, "ctt", "ct", 100, "CT", "C"},
+	}
+	for _, tt := range tests {
+		pos, ref, alt := NormalizeVariant(tt.pos, tt.ref, tt.alt)
+		if pos != tt.wantPos || ref != tt.wantRef || alt != tt.wantAlt {
+			t.Errorf("NormalizeVariant(%d, %s, %s) = %d %s>%s, want %d %s>%s",
+				tt.pos, tt.ref, tt.alt, pos, ref, alt, tt.wantPos, tt.wantRef, tt.wantAlt)
+		}
+	}
+}
+
+func TestSameVariant(t *testing.T) {
+	tests := []struct {
+		name       string
+		pos1       uint64
+		ref1, alt1 string
+		pos2       uint64
+		ref2, alt2 string
+		want       bool
+	}{
+		{"identical", 100, "G", "A", 100, "G", "A", true},
+		{"soft-masked", 100, "GCA", "G", 100, "gca", "g", true},
+		{"split from a multi-allelic site", 100, "GCA", "G", 100, "GCACA", "GCA", true},
+		{"padded", 100, "CA", "C", 99, "TCA", "TC", true},
+		{"shifted in a repeat", 100, "CA", "C", 101, "AA", "A", true},
+		{"shifted insertion", 100, "C", "CA", 101, "A", "AA", true},
+		{"other deletion", 100, "CA", "C", 100, "CAA", "C", false},
+		{"reference disagrees", 100, "CA", "C", 99, "GGA", "GG", false},
+		{"other base", 100, "G", "A", 100, "G", "T", false},
+		{"symbolic", 100, "G", "<DEL>", 100, "G", "<DEL>", true},
+		{"symbolic differs", 100, "GCA", "G", 100, "G", "<DEL>", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameVariant(tt.pos1, tt.ref1, tt.alt1, tt.pos2, tt.ref2, tt.alt2); got != tt.want {
+				t.Errorf("sameVariant = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCoverage_NormalizesRecords(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	100	.	GCACA	G,GCA	60	PASS	.	GT	0/2
+1	199	.	TCA	TC	60	PASS	.	GT	1/1
+1	301	.	AA	A	60	PASS	.	GT	0/1
+`)
+	source, err := NewVCFSource(vcfPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loci := []traits.TraitVariant{
+		{Trait: "multi-allelic", Chromosome: 1, Position: 100, Ref: "GCA", Alt: "G"},
+		{Trait: "padded", Chromosome: 1, Position: 200, Ref: "CA", Alt: "C"},
+		{Trait: "shifted", Chromosome: 1, Position: 300, Ref: "CA", Alt: "C"},
+		{Trait: "other", Chromosome: 1, Position: 100, Ref: "G", Alt: "T"},
+	}
+	coverage, err := CheckCoverage(source, loci)
+	if err != nil {
+		t.Fatalf("CheckCoverage failed: %v", err)
+	}
+	want := []LocusStatus{LocusCalled, LocusCalled, LocusCalled, LocusAlleleMismatch}
+	for i, locus := range coverage {
+		if locus.Status != want[i] {
+			t.Errorf("%s: expected %s, got %s (%s)", locus.Variant.Trait, want[i], locus.Status, locus.Found)
+		}
+	}
+
+	calls, err := lookupAlleles(source, "1", 100, "GCA", "G")
+	if err != nil || len(calls) != 1 || alternateIndex(calls[0], "G") != 2 {
+		t.Fatalf("Expected the second ALT allele to be matched, got %+v: %v", calls, err)
+	}
+	genotype, err := NewDynamicProof(100, "GCA", "G").parseGenotypeFromInts(calls[0].Samples[0].GT, 2)
+	if err != nil || genotype != 1 {
+		t.Errorf("Expected a heterozygous genotype, got %d: %v", genotype, err)
+	}
+}
@@ -0,0 +1,60 @@
+package proofs
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ProofParameters are the parameters a proof was requested with when its
+// proof type does not fix the variant it reads, such as the locus and mode
+// of a dynamic proof. They tell readers what the proof is about; only the
+// public inputs are proven, and CheckParameters checks the two agree.
+type ProofParameters struct {
+	// Chromosome is a traits.ChromosomeCode, zero if the lookup matched any
+	Chromosome int    `json:"chromosome,omitempty"`
+	Position   uint64 `json:"position,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+	Alt        string `json:"alt,omitempty"`
+	// RsID is set instead of the locus for proofs requested by rsID
+	RsID string `json:"rsid,omitempty"`
+	// Mode is the name of the ClaimMode of the statement
+	Mode string `json:"mode,omitempty"`
+}
+
+// CheckParameters returns an error if the parameters recorded in proofData
+// disagree with its public inputs: its mode with ClaimMode and, when the
+// chromosome, position and alleles are all recorded, its locus with
+// LocusHash. Proofs recording no parameters pass.
+func CheckParameters(proofData *ProofData) error {
+	params := proofData.Parameters
+	if params == nil {
+		return nil
+	}
+
+	var expected []PublicValue
+	if params.Mode != "" {
+		mode, err := ParseClaimMode(params.Mode)
+		if err != nil {
+			return fmt.Errorf("proof parameters: %w", err)
+		}
+		expected = append(expected, PublicValue{Name: "ClaimMode", Value: strconv.Itoa(int(mode))})
+	}
+	if params.Chromosome != 0 && params.Position != 0 && params.Ref != "" && params.Alt != "" {
+		curve, err := CurveNamed(proofData.Curve)
+		if err != nil {
+			return err
+		}
+		locus, err := LocusHashOn(curve, params.Chromosome, params.Position, params.Ref, params.Alt)
+		if err != nil {
+			return fmt.Errorf("proof parameters: %w", err)
+		}
+		expected = append(expected, PublicValue{Name: "LocusHash", Value: locus.String()})
+	}
+	if len(expected) == 0 {
+		return nil
+	}
+	if err := CheckPublicValues(proofData, expected); err != nil {
+		return fmt.Errorf("proof parameters do not match the proof: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package proofs
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// HaplotypePhase is the public encoding of how two heterozygous variants are
+// arranged on the two haplotypes
+type HaplotypePhase int
+
+const (
+	// PhaseCis places both ALT alleles on the same haplotype
+	PhaseCis HaplotypePhase = iota
+	// PhaseTrans places the ALT alleles on different haplotypes, as in
+	// compound heterozygosity
+	PhaseTrans
+)
+
+func (p HaplotypePhase) String() string {
+	switch p {
+	case PhaseCis:
+		return "cis"
+	case PhaseTrans:
+		return "trans"
+	default:
+		return "unknown"
+	}
+}
+
+// PhaseCircuit proves the cis/trans configuration of two heterozygous
+// variants. The loci are public as LocusHashA and LocusHashB, fixed to the
+// LocusHashes of the circuit's variants; which haplotype carries each ALT
+// allele stays private.
+type PhaseCircuit struct {
+	LocusHashA   frontend.Variable `gnark:",public"`
+	LocusHashB   frontend.Variable `gnark:",public"`
+	ClaimedPhase frontend.Variable `gnark:",public"`
+
+	// HaplotypesA and HaplotypesB are 1 where the haplotype carries the ALT allele
+	HaplotypesA [2]frontend.Variable
+	HaplotypesB [2]frontend.Variable
+
+	variantA traits.TraitVariant
+	variantB traits.TraitVariant
+}
+
+// NewPhaseCircuit creates a circuit for the phase of variantA and variantB
+func NewPhaseCircuit(variantA traits.TraitVariant, variantB traits.TraitVariant) *PhaseCircuit {
+	return &PhaseCircuit{variantA: variantA, variantB: variantB}
+}
+
+func (c *PhaseCircuit) Define(api frontend.API) error {
+	curve, err := curveOfField(api.Compiler().Field())
+	if err != nil {
+		return err
+	}
+	for _, locus := range []struct {
+		hash    frontend.Variable
+		variant traits.TraitVariant
+	}{{c.LocusHashA, c.variantA}, {c.LocusHashB, c.variantB}} {
+		locusHash, err := variantLocusHash(curve, locus.variant)
+		if err != nil {
+			return err
+		}
+		api.AssertIsEqual(locus.hash, locusHash)
+	}
+
+	for _, haplotypes := range [][2]frontend.Variable{c.HaplotypesA, c.HaplotypesB} {
+		api.AssertIsBoolean(haplotypes[0])
+		api.AssertIsBoolean(haplotypes[1])
+		// Phase is only defined for heterozygous variants
+		api.AssertIsEqual(api.Add(haplotypes[0], haplotypes[1]), 1)
+	}
+
+	// Trans exactly when the first haplotypes differ
+	api.AssertIsEqual(c.ClaimedPhase, api.Xor(c.HaplotypesA[0], c.HaplotypesB[0]))
+
+	return nil
+}
+
+func (c *PhaseCircuit) assignCurve(curve ecc.ID) error {
+	locusHashA, err := variantLocusHash(curve, c.variantA)
+	if err != nil {
+		return err
+	}
+	locusHashB, err := variantLocusHash(curve, c.variantB)
+	if err != nil {
+		return err
+	}
+	c.LocusHashA, c.LocusHashB = locusHashA, locusHashB
+	return nil
+}
+
+// NewPhaseProof creates a PhaseProof for two variants on the same chromosome
+func NewPhaseProof(variantA traits.TraitVariant, variantB traits.TraitVariant) *PhaseProof {
+	return &PhaseProof{VariantA: variantA, VariantB: variantB}
+}
+
+// Assign reads both phased calls and builds the circuit and its assignment
+func (p *PhaseProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewPhaseCircuit(p.VariantA, p.VariantB), assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *PhaseProof) Circuit() (frontend.Circuit, error) {
+	return NewPhaseCircuit(p.VariantA, p.VariantB), nil
+}
+
+// assign returns the assignment along with the phase it discloses
+func (p *PhaseProof) assign(vcfPath string) (*PhaseCircuit, HaplotypePhase, error) {
+	for _, variant := range []traits.TraitVariant{p.VariantA, p.VariantB} {
+		if variant.Chromosome <= 0 || variant.Position <= 0 || variant.Ref == "" || variant.Alt == "" {
+			return nil, 0, fmt.Errorf("phase proofs need two variants with chromosome, position and alleles")
+		}
+	}
+	if p.VariantA.Chromosome != p.VariantB.Chromosome {
+		return nil, 0, fmt.Errorf("variants on chromosomes %d and %d cannot be phased", p.VariantA.Chromosome, p.VariantB.Chromosome)
+	}
+
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := prefetchPanel(source, []traits.TraitVariant{p.VariantA, p.VariantB}); err != nil {
+		return nil, 0, err
+	}
+	loggerOrNop(p.Logger).Infof("reading phased calls at %d:%d and %d:%d...",
+		p.VariantA.Chromosome, p.VariantA.Position, p.VariantB.Chromosome, p.VariantB.Position)
+	haplotypesA, err := phasedHaplotypes(source, p.VariantA)
+	if err != nil {
+		return nil, 0, err
+	}
+	haplotypesB, err := phasedHaplotypes(source, p.VariantB)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, haplotypes := range [][2]int{haplotypesA, haplotypesB} {
+		if haplotypes[0]+haplotypes[1] != 1 {
+			return nil, 0, fmt.Errorf("both variants must be heterozygous to be phased")
+		}
+	}
+
+	phase := PhaseCis
+	if haplotypesA[0] != haplotypesB[0] {
+		phase = PhaseTrans
+	}
+
+	locusHashA, err := variantLocusHash(ecc.BN254, p.VariantA)
+	if err != nil {
+		return nil, 0, err
+	}
+	locusHashB, err := variantLocusHash(ecc.BN254, p.VariantB)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &PhaseCircuit{
+		LocusHashA:   locusHashA,
+		LocusHashB:   locusHashB,
+		ClaimedPhase: int(phase),
+		HaplotypesA:  [2]frontend.Variable{haplotypesA[0], haplotypesA[1]},
+		HaplotypesB:  [2]frontend.Variable{haplotypesB[0], haplotypesB[1]},
+		variantA:     p.VariantA,
+		variantB:     p.VariantB,
+	}, phase, nil
+}
+
+// phasedHaplotypes returns, for each haplotype of the first sample's phased
+// call at variant, 1 if it carries the variant's ALT allele and 0 otherwise.
+// Unlike genotype parsing it keeps the haplotype order of "0|1" calls, so it
+// refuses unphased calls.
+func phasedHaplotypes(source GenomeSource, variant traits.TraitVariant) ([2]int, error) {
+	if err := checkBuild(source.Build(), variant.Build, variant.Trait); err != nil {
+		return [2]int{}, err
+	}
+	calls, err := lookupAlleles(source, strconv.Itoa(variant.Chromosome), uint64(variant.Position), variant.Ref, variant.Alt)
+	if err != nil {
+		return [2]int{}, fmt.Errorf("%d:%d: %w", variant.Chromosome, variant.Position, err)
+	}
+
+	for _, call := range calls {
+		altIndex := alternateIndex(call, variant.Alt)
+		if !allelesMatch(variant.Ref, call.Reference) || altIndex == 0 {
+			continue
+		}
+		if len(call.Samples) == 0 {
+			return [2]int{}, fmt.Errorf("%w: VCF has no samples", ErrNoSampleData)
+		}
+		sample := call.Samples[0]
+		if len(sample.GT) != 2 || slices.Contains(sample.GT, -1) {
+			return [2]int{}, fmt.Errorf("%w: genotype at position %d is not called", ErrNoSampleData, variant.Position)
+		}
+		if !sample.Phased {
+			return [2]int{}, fmt.Errorf("genotype at position %d is not phased", variant.Position)
+		}
+
+		var haplotypes [2]int
+		for i, allele := range sample.GT {
+			if allele == altIndex {
+				haplotypes[i] = 1
+			}
+		}
+		return haplotypes, nil
+	}
+	return [2]int{}, fmt.Errorf("%w: %s>%s not found at position %d", ErrVariantNotFound, variant.Ref, variant.Alt, variant.Position)
+}
+
+func (p *PhaseProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, phase, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewPhaseCircuit(p.VariantA, p.VariantB), assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Phase proof successfully generated: variants at %d and %d are in %s",
+		p.VariantA.Position, p.VariantB.Position, phase)
+
+	return proofData, nil
+}
+
+func (p *PhaseProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "phase", verifyingKeyPath, proofPath)
+}
+
+func (p *PhaseProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "phase", proofData)
+}
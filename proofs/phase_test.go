@@ -0,0 +1,114 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestPhaseCircuit(t *testing.T) {
+	variantA := traits.TraitVariant{Chromosome: 13, Position: 32900000, Ref: "A", Alt: "G"}
+	variantB := traits.TraitVariant{Chromosome: 13, Position: 32900100, Ref: "C", Alt: "T"}
+	locusHashA, err := variantLocusHash(ecc.BN254, variantA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	locusHashB, err := variantLocusHash(ecc.BN254, variantB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		haplotypesA [2]int
+		haplotypesB [2]int
+		phase       HaplotypePhase
+		locusHashB  frontend.Variable
+		solved      bool
+	}{
+		{"cis", [2]int{0, 1}, [2]int{0, 1}, PhaseCis, locusHashB, true},
+		{"trans", [2]int{1, 0}, [2]int{0, 1}, PhaseTrans, locusHashB, true},
+		{"trans claimed cis", [2]int{1, 0}, [2]int{0, 1}, PhaseCis, locusHashB, false},
+		{"cis claimed trans", [2]int{1, 0}, [2]int{1, 0}, PhaseTrans, locusHashB, false},
+		{"homozygous", [2]int{1, 1}, [2]int{1, 0}, PhaseCis, locusHashB, false},
+		{"other locus", [2]int{0, 1}, [2]int{0, 1}, PhaseCis, locusHashA, false},
+	}
+
+	for _, tc := range tests {
+		assignment := &PhaseCircuit{
+			LocusHashA:   locusHashA,
+			LocusHashB:   tc.locusHashB,
+			ClaimedPhase: int(tc.phase),
+			HaplotypesA:  [2]frontend.Variable{tc.haplotypesA[0], tc.haplotypesA[1]},
+			HaplotypesB:  [2]frontend.Variable{tc.haplotypesB[0], tc.haplotypesB[1]},
+		}
+		err := test.IsSolved(NewPhaseCircuit(variantA, variantB), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("%s: expected circuit to be solved: %v", tc.name, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("%s: expected circuit not to be solved", tc.name)
+		}
+	}
+}
+
+func TestPhaseProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+13	32900000	.	A	G	60	PASS	.	GT	0|1
+13	32900100	.	C	T	60	PASS	.	GT	1|0
+13	32900200	.	G	A	60	PASS	.	GT	0|1
+13	32900300	.	T	C	60	PASS	.	GT	0/1
+13	32900400	.	A	C	60	PASS	.	GT	1|1
+`)
+	variant := func(position int, ref string, alt string) traits.TraitVariant {
+		return traits.TraitVariant{Chromosome: 13, Position: position, Ref: ref, Alt: alt}
+	}
+
+	tests := []struct {
+		name  string
+		b     traits.TraitVariant
+		phase HaplotypePhase
+	}{
+		{"trans", variant(32900100, "C", "T"), PhaseTrans},
+		{"cis", variant(32900200, "G", "A"), PhaseCis},
+	}
+	for _, tc := range tests {
+		proof := NewPhaseProof(variant(32900000, "A", "G"), tc.b)
+		_, phase, err := proof.assign(vcfPath)
+		if err != nil {
+			t.Fatalf("%s: assign should not return error: %v", tc.name, err)
+		}
+		if phase != tc.phase {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.phase, phase)
+		}
+	}
+
+	proof := NewPhaseProof(variant(32900000, "A", "G"), variant(32900100, "C", "T"))
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %v: %v", result, err)
+	}
+	if err := CheckPublicValues(proofData, []PublicValue{{Name: "ClaimedPhase", Value: "1"}}); err != nil {
+		t.Errorf("Expected a trans claim: %v", err)
+	}
+	result, err = proof.VerifyProofData(withPublicInput(t, proofData, 1, 1))
+	if err == nil && result.Result == ProofSuccess {
+		t.Error("Expected a proof with a changed LocusHashB to fail verification")
+	}
+
+	// Unphased and homozygous calls cannot be phased
+	for _, b := range []traits.TraitVariant{variant(32900300, "T", "C"), variant(32900400, "A", "C")} {
+		if _, _, err := NewPhaseProof(variant(32900000, "A", "G"), b).assign(vcfPath); err == nil {
+			t.Errorf("Expected the call at %d to be refused", b.Position)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package proofs
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// ProofLoci are the loci a proof reads genotypes at, so a genome can be
+// checked for them before proving
+type ProofLoci struct {
+	Variants []traits.TraitVariant
+	// AbsentIsReference is set for proofs that read a locus without a
+	// record as homozygous reference, as in variant-only VCFs
+	AbsentIsReference bool
+	// NoCallsTolerated is set for proofs that skip uncalled loci, up to a
+	// limit of their own
+	NoCallsTolerated bool
+}
+
+// TargetLoci returns the loci proof reads genotypes at. Proofs over regions
+// or whole genomes, such as ChromosomeProof and RegionCountProof, have none,
+// and rsID proofs have the locus of the bundled table if it lists the rsID.
+func TargetLoci(proof Proof) ProofLoci {
+	switch p := proof.(type) {
+	case *DynamicProof:
+		return ProofLoci{Variants: []traits.TraitVariant{{
+			Trait:      fmt.Sprintf("position %d", p.Position),
+			Chromosome: p.Chromosome,
+			Position:   int(p.Position),
+			Ref:        p.Reference,
+			Alt:        p.Alternate,
+			Build:      p.Build,
+		}}}
+	case *CustomTraitProof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Trait.TraitVariant}}
+	case *RsIDProof:
+		if variant, ok := traits.RsIDTable[p.RsID]; ok && !p.NoTable {
+			return ProofLoci{Variants: []traits.TraitVariant{variant}}
+		}
+	case *AggregateProof:
+		var loci ProofLoci
+		for _, claim := range p.Claims {
+			loci.Variants = append(loci.Variants, traits.TraitVariant{
+				Trait:      fmt.Sprintf("claim at position %d", claim.Position),
+				Chromosome: claim.Chromosome,
+				Position:   int(claim.Position),
+				Ref:        claim.Ref,
+				Alt:        claim.Alt,
+			})
+		}
+		return loci
+	case *NegativeProof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Variant}, AbsentIsReference: true}
+	case *CarrierProof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Variant}, AbsentIsReference: true}
+	case *CommittedVariantProof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Variant}}
+	case *PhaseProof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.VariantA, p.VariantB}}
+	case *KinshipProof:
+		return ProofLoci{Variants: p.Panel, AbsentIsReference: true, NoCallsTolerated: true}
+	case *BRCA2Proof:
+		return ProofLoci{Variants: p.Panel, AbsentIsReference: true}
+	case *BurdenProof:
+		return ProofLoci{Variants: p.Policy.Variants, AbsentIsReference: true}
+	case *EyeColorProof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Traits.Variant("herc2")}}
+	case *HERC2Proof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Traits.Variant("herc2")}}
+	case *ACTN3Proof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Traits.Variant("actn3")}}
+	case *ALDH2Proof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Traits.Variant("aldh2")}}
+	case *CCR5Proof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Traits.Variant("ccr5_delta32")}}
+	case *ABCC11Proof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Traits.Variant("abcc11")}}
+	case *MTHFRProof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Traits.Variant("mthfr_c677t"), p.Traits.Variant("mthfr_a1298c")}}
+	case *BloodTypeProof:
+		return ProofLoci{Variants: []traits.TraitVariant{p.Traits.Variant("abo_functional"), p.Traits.Variant("abo_b")}}
+	case *CYP2D6Proof:
+		var loci ProofLoci
+		for _, allele := range p.Traits.CYP2D6StarAlleles() {
+			loci.Variants = append(loci.Variants, allele.Variant)
+		}
+		return loci
+	}
+	return ProofLoci{}
+}
+
+// LocusStatus is how a genome covers a target locus
+type LocusStatus string
+
+const (
+	// LocusCalled is a record with the expected alleles and a called genotype
+	LocusCalled LocusStatus = "called"
+	// LocusNoCall is a record with the expected alleles but a missing genotype
+	LocusNoCall LocusStatus = "no-call"
+	// LocusAbsent is a position without a record
+	LocusAbsent LocusStatus = "absent"
+	// LocusAlleleMismatch is a record at the position with other alleles
+	LocusAlleleMismatch LocusStatus = "allele mismatch"
+)
+
+// LocusCoverage is how a genome covers one target locus
+type LocusCoverage struct {
+	Variant traits.TraitVariant
+	Status  LocusStatus
+	// Found describes the record at the position when its alleles differ,
+	// such as "C>T"
+	Found string
+}
+
+// CheckCoverage looks up every locus in source, reading the first sample's
+// genotype
+func CheckCoverage(source GenomeSource, loci []traits.TraitVariant) ([]LocusCoverage, error) {
+	if err := prefetchPanel(source, loci); err != nil {
+		return nil, err
+	}
+	coverage := make([]LocusCoverage, len(loci))
+	for i, variant := range loci {
+		coverage[i] = LocusCoverage{Variant: variant, Status: LocusAbsent}
+
+		chrom := ""
+		if variant.Chromosome > 0 {
+			chrom = strconv.Itoa(variant.Chromosome)
+		}
+		calls, err := lookupAlleles(source, chrom, uint64(variant.Position), variant.Ref, variant.Alt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", variant.Trait, err)
+		}
+		for _, call := range calls {
+			// Reference-only records, with no ALT allele, match any ALT
+			referenceOnly := len(call.Alternate) == 0 || call.Alternate[0] == "."
+			if !allelesMatch(variant.Ref, call.Reference) || (!referenceOnly && alternateIndex(call, variant.Alt) == 0) {
+				coverage[i].Status = LocusAlleleMismatch
+				coverage[i].Found = fmt.Sprintf("%s>%s", call.Reference, strings.Join(call.Alternate, ","))
+				continue
+			}
+			coverage[i].Status, coverage[i].Found = LocusCalled, ""
+			if len(call.Samples) == 0 || len(call.Samples[0].GT) == 0 || slices.Contains(call.Samples[0].GT, -1) {
+				coverage[i].Status = LocusNoCall
+			}
+			break
+		}
+	}
+	return coverage, nil
+}
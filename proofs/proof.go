@@ -1,5 +1,13 @@
 package proofs
 
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
 // ProofResult represents the possible outcomes of proof operations
 type ProofResult int
 
@@ -23,18 +31,85 @@ func (r ProofResult) String() string {
 	}
 }
 
+// FailureReason classifies why proof generation failed, for applications
+// that show their own messages
+type FailureReason string
+
+const (
+	FailureVariantNotFound FailureReason = "variant_not_found"
+	FailureNoSampleData    FailureReason = "no_sample_data"
+	FailureMalformedVCF    FailureReason = "malformed_vcf"
+	// FailureOther covers every other error, such as an unreadable file or
+	// a genotype that does not satisfy the claim
+	FailureOther FailureReason = "other"
+)
+
+// failureReasonOf classifies a generation error
+func failureReasonOf(err error) FailureReason {
+	switch {
+	case errors.Is(err, ErrVariantNotFound):
+		return FailureVariantNotFound
+	case errors.Is(err, ErrNoSampleData):
+		return FailureNoSampleData
+	case errors.Is(err, ErrMalformedVCF):
+		return FailureMalformedVCF
+	default:
+		return FailureOther
+	}
+}
+
 // ProofData contains all necessary data for verification
 type ProofData struct {
 	Proof         []byte      `json:"proof"`
 	VerifyingKey  []byte      `json:"verifying_key"`
 	PublicWitness []byte      `json:"public_witness"`
 	Result        ProofResult `json:"result"`
+	// Hints lists the audited solver hints the proof was solved with
+	Hints []string `json:"hints,omitempty"`
+	// ProofType names the kind of proof, so a verifier need not be told
+	ProofType string `json:"proof_type,omitempty"`
+	// CircuitID and CircuitVersion identify the circuit that produced the
+	// proof, so advisories can flag unsound versions
+	CircuitID      string `json:"circuit_id,omitempty"`
+	CircuitVersion int    `json:"circuit_version,omitempty"`
+	// Curve is the elliptic curve the proof is made over
+	Curve string `json:"curve,omitempty"`
+	// Backend names the proving system the proof is made with, such as
+	// "plonk". It is empty for Groth16 proofs.
+	Backend string `json:"backend,omitempty"`
+	// CreatedAt is when the proof was generated, in UTC
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	// CircuitHash is the SHA-256 of the serialized constraint system the
+	// verifying key was set up for
+	CircuitHash string `json:"circuit_hash,omitempty"`
+	// Binding, if set, is the presentation context the proof is bound to,
+	// such as its validity window
+	Binding *Binding `json:"binding,omitempty"`
+	// Signatures are issuer signatures over the proof envelope, as JWS with
+	// detached payload (see SignEnvelope)
+	Signatures []string `json:"signatures,omitempty"`
+	// Provenance, if set, is the sequencing lab's attestation of the genome
+	// the proof was generated from (see AttestGenome)
+	Provenance *GenomeAttestation `json:"provenance,omitempty"`
+	// Parameters, if set, are what a proof whose type does not fix its
+	// variant, such as a dynamic proof, was requested with (see
+	// CheckParameters)
+	Parameters *ProofParameters `json:"parameters,omitempty"`
+	// FailureReason is set when generation failed
+	FailureReason FailureReason `json:"failure_reason,omitempty"`
+	// Constraints is the size of the proven circuit. It is reported to the
+	// prover and not serialized.
+	Constraints int `json:"-"`
 }
 
 // VerificationResult contains the result of proof verification
 type VerificationResult struct {
 	Result ProofResult `json:"result"`
 	Error  error       `json:"error,omitempty"`
+	// ParsedPublicInputs maps each public input of a verified proof to its
+	// decimal field value, so a verifier can check what was proven. It is nil
+	// if verification failed or the proof does not record its circuit.
+	ParsedPublicInputs map[string]string `json:"parsed_public_inputs,omitempty"`
 }
 
 type Proof interface {
@@ -49,26 +124,232 @@ type DynamicProofGenerator interface {
 	GenerateDynamic(vcfPath string, provingKeyPath string, outputPath string, position uint64, ref string, alt string) (*ProofData, error)
 }
 
+// ProofOptions are the options every proof type embeds. A proof reads
+// those that apply to it: proofs over all samples or over a committed genome
+// ignore Sample, and only proofs of bundled traits read Traits.
+type ProofOptions struct {
+	Progress ProgressReporter
+	Logger   Logger
+	// Sample selects the sample of a multi-sample VCF to prove from, by name
+	// or index (see NewSampleSource); the first sample if empty
+	Sample string
+	// Source overrides reading the VCF path passed to Generate
+	Source GenomeSource
+	// Traits, if set, is the registry the trait's loci are read from; the
+	// bundled registry if nil
+	Traits *traits.Registry
+}
+
 type ChromosomeProof struct {
 	Proof
+	ProofOptions
+	// TargetChromosome is the code (see traits.ChromosomeCode) of the
+	// chromosome to prove present; zero uses DefaultTargetChromosome
+	TargetChromosome int
+	// Slots is the number of chromosome slots in the circuit; zero uses
+	// DefaultChromosomeSlots
+	Slots int
 }
 
+// EyeColorProof proves the eye color class predicted by HERC2 rs12913832
 type EyeColorProof struct {
 	Proof
+	ProofOptions
 }
 
 type BRCA1Proof struct {
 	Proof
+	ProofOptions
 }
 
+// HERC2Proof proves whether the ALT allele of HERC2 rs12913832 is carried
 type HERC2Proof struct {
 	Proof
+	ProofOptions
+}
+
+type BloodTypeProof struct {
+	Proof
+	ProofOptions
+}
+
+type CYP2D6Proof struct {
+	Proof
+	ProofOptions
+}
+
+type ACTN3Proof struct {
+	Proof
+	ProofOptions
+}
+
+type ALDH2Proof struct {
+	Proof
+	ProofOptions
+}
+
+type CCR5Proof struct {
+	Proof
+	ProofOptions
+}
+
+type MTHFRProof struct {
+	Proof
+	ProofOptions
+}
+
+type ABCC11Proof struct {
+	Proof
+	ProofOptions
+}
+
+// SexChromosomeProof proves the XX/XY configuration of the genome
+type SexChromosomeProof struct {
+	ProofOptions
+}
+
+// BRCA2Proof proves carrier status for any variant of a pathogenic panel
+type BRCA2Proof struct {
+	ProofOptions
+	Panel       []traits.TraitVariant
+	MaxVariants int
+}
+
+// CohortProof proves an aggregate carrier statement over all samples of a
+// multi-sample VCF without revealing any individual genotype
+type CohortProof struct {
+	ProofOptions
+	// Chromosome restricts the lookup to one chromosome and is part of the
+	// LocusHash the proof discloses; zero matches any
+	Chromosome        int
+	Position          uint64
+	Reference         string
+	Alternate         string
+	MinCarrierPercent int
+	// Salts are the salts of the members' commitments, in sample order
+	Salts []*big.Int
+	// Commitments, if set, are the commitments the members published, in
+	// sample order; every sample must open its commitment with its salt
+	Commitments []*big.Int
+}
+
+// BurdenProof proves a bound on how many variants of a defined list are carried
+type BurdenProof struct {
+	ProofOptions
+	Policy BurdenPolicy
+}
+
+// NegativeProof proves that a specific variant is not carried
+type NegativeProof struct {
+	ProofOptions
+	Variant traits.TraitVariant
+	// Coverage, if set, is where the genome was sequenced; a locus with no
+	// record is only taken as homozygous reference if it was
+	Coverage CoverageSource
+}
+
+// KinshipProof proves that the genome passed to Generate (the child) and a
+// second genome (the parent) are consistent with parentage over a panel of loci
+type KinshipProof struct {
+	ProofOptions
+	// ParentVCF is the parent's genome, read unless ParentSource is set
+	ParentVCF    string
+	ParentSource GenomeSource
+	// ParentSample selects the parent's sample, as Sample does the child's,
+	// so both can be read from one trio VCF
+	ParentSample string
+	Panel        []traits.TraitVariant
+	// MinLoci is how many panel loci must be called in both genomes; zero
+	// requires all of them
+	MinLoci int
+	// MaxMismatches is how many Mendelian inconsistencies are tolerated
+	MaxMismatches int
+}
+
+// RsIDProof proves the genotype at the variant named by an rsID, resolving
+// it to coordinates and proving with DynamicProof
+type RsIDProof struct {
+	ProofOptions
+	RsID string
+	// Mode selects the public statement, as for DynamicProof
+	Mode ClaimMode
+	// NoTable disables falling back to traits.RsIDTable for VCFs without rsIDs
+	NoTable bool
+}
+
+// CustomTraitProof proves the outcome of a user-defined trait with the
+// dynamic circuit, stating only as much of the genotype as the outcome needs
+type CustomTraitProof struct {
+	ProofOptions
+	Trait traits.CustomTrait
+	// Outcome is the label of the outcome to claim; empty claims the outcome
+	// of the genome
+	Outcome string
 }
 
 type DynamicProof struct {
-	Position uint64
-	Reference string
-	Alternate string
+	ProofOptions
+	// Chromosome restricts the lookup to one chromosome (see
+	// traits.ChromosomeCode); zero matches any
+	Chromosome int
+	Position   uint64
+	Reference  string
+	Alternate  string
+	// Build, if set, is the reference assembly of Position; genomes known
+	// to be in another build are refused with a BuildMismatchError
+	Build traits.GenomeBuild
+	// Mode selects the public statement; the zero value discloses the
+	// exact genotype
+	Mode ClaimMode
+	// MaxAlleleIndex bounds the VCF allele indices the circuit accepts at
+	// multi-allelic sites; zero uses DefaultMaxAlleleIndex
+	MaxAlleleIndex int
+}
+
+// HERC2Pos is the GRCh37 position of HERC2 rs12913832.
+//
+// Deprecated: use traits.HERC2Variant, which also names its chromosome and
+// alleles.
+var HERC2Pos = uint64(traits.HERC2Variant.Position)
+
+// AggregateProof proves several genotype claims in one proof
+type AggregateProof struct {
+	ProofOptions
+	Claims []AggregateClaim
 }
 
-const HERC2Pos uint64 = 28365618
+// CommittedVariantProof proves the genotype at a variant against the genome's
+// Merkle commitment
+type CommittedVariantProof struct {
+	ProofOptions
+	Variant traits.TraitVariant
+	// Commitment overrides loading the commitment stored next to the VCF
+	Commitment *GenomeCommitment
+}
+
+// RegionCountProof proves that at least Threshold variants within a gene
+// region are carried, without revealing which
+type RegionCountProof struct {
+	ProofOptions
+	Chromosome int
+	Region     traits.TraitRegion
+	Threshold  int
+	// Slots is the number of variant slots in the circuit; zero uses
+	// DefaultRegionCountSlots
+	Slots int
+}
+
+// PhaseProof proves whether two heterozygous variants on one chromosome are
+// in cis or in trans, read from the phased genotypes of the VCF. Both calls
+// must come from the same phase block.
+type PhaseProof struct {
+	ProofOptions
+	VariantA traits.TraitVariant
+	VariantB traits.TraitVariant
+}
+
+// CarrierProof proves that a specific variant is carried, hiding the zygosity
+type CarrierProof struct {
+	ProofOptions
+	Variant traits.TraitVariant
+}
@@ -0,0 +1,482 @@
+package proofs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// This file encodes ProofData and VerificationResult as the protobuf messages
+// of proto/zkgenomics/v1/proofs.proto, so services in other languages can
+// exchange proofs with generated code. The wire format is written by hand to
+// keep the protobuf runtime out of the library's dependencies.
+
+// Protobuf wire types
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+// protoResults maps ProofResult to the ProofResult enum of the schema, which
+// reserves zero for an unspecified result
+var protoResults = map[ProofResult]uint64{
+	ProofSuccess: 1,
+	ProofFail:    2,
+	ProofUnknown: 3,
+}
+
+func appendProtoKey(b []byte, field int, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wireType))
+}
+
+// appendProtoVarint appends a varint field, omitting it when zero as proto3
+// does
+func appendProtoVarint(b []byte, field int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = appendProtoKey(b, field, protoVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+// appendProtoBytes appends a length-delimited field, which may be empty
+func appendProtoBytes(b []byte, field int, v []byte) []byte {
+	b = appendProtoKey(b, field, protoBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// appendProtoString appends a string or bytes field, omitting it when empty
+func appendProtoString[T string | []byte](b []byte, field int, v T) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	return appendProtoBytes(b, field, []byte(v))
+}
+
+// appendProtoTimestamp appends a google.protobuf.Timestamp field, omitting it
+// for the zero time
+func appendProtoTimestamp(b []byte, field int, t time.Time) []byte {
+	if t.IsZero() {
+		return b
+	}
+	var ts []byte
+	ts = appendProtoVarint(ts, 1, uint64(t.Unix()))
+	ts = appendProtoVarint(ts, 2, uint64(t.Nanosecond()))
+	return appendProtoBytes(b, field, ts)
+}
+
+// protoField is one decoded field of a protobuf message
+type protoField struct {
+	num      int
+	wireType int
+	varint   uint64
+	bytes    []byte
+}
+
+// wantBytes returns the field's length-delimited value, or an error if the
+// field has another wire type
+func (f protoField) wantBytes() ([]byte, error) {
+	if f.wireType != protoBytes {
+		return nil, fmt.Errorf("field %d has wire type %d, expected length-delimited", f.num, f.wireType)
+	}
+	return f.bytes, nil
+}
+
+// wantVarint returns the field's varint value, or an error if the field has
+// another wire type
+func (f protoField) wantVarint() (uint64, error) {
+	if f.wireType != protoVarint {
+		return 0, fmt.Errorf("field %d has wire type %d, expected varint", f.num, f.wireType)
+	}
+	return f.varint, nil
+}
+
+// protoFields calls visit with each field of the protobuf message in data
+func protoFields(data []byte, visit func(protoField) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("malformed field key")
+		}
+		data = data[n:]
+		field := protoField{num: int(key >> 3), wireType: int(key & 7)}
+
+		switch field.wireType {
+		case protoVarint:
+			field.varint, n = binary.Uvarint(data)
+			if n <= 0 {
+				return fmt.Errorf("field %d: malformed varint", field.num)
+			}
+			data = data[n:]
+		case protoFixed64, protoFixed32:
+			size := 8
+			if field.wireType == protoFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return fmt.Errorf("field %d: truncated", field.num)
+			}
+			data = data[size:]
+		case protoBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return fmt.Errorf("field %d: truncated", field.num)
+			}
+			field.bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		default:
+			return fmt.Errorf("field %d: unsupported wire type %d", field.num, field.wireType)
+		}
+
+		if err := visit(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeProtoTimestamp decodes a google.protobuf.Timestamp as a UTC time
+func decodeProtoTimestamp(f protoField) (time.Time, error) {
+	data, err := f.wantBytes()
+	if err != nil {
+		return time.Time{}, err
+	}
+	var seconds, nanos uint64
+	err = protoFields(data, func(f protoField) error {
+		var err error
+		switch f.num {
+		case 1:
+			seconds, err = f.wantVarint()
+		case 2:
+			nanos, err = f.wantVarint()
+		}
+		return err
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(seconds), int64(int32(nanos))).UTC(), nil
+}
+
+// decodeProtoResult decodes the ProofResult enum, reading an unspecified or
+// unknown result as ProofUnknown
+func decodeProtoResult(f protoField) (ProofResult, error) {
+	v, err := f.wantVarint()
+	if err != nil {
+		return ProofUnknown, err
+	}
+	for result, encoded := range protoResults {
+		if encoded == v {
+			return result, nil
+		}
+	}
+	return ProofUnknown, nil
+}
+
+// MarshalProto encodes the proof as the ProofData message of the protobuf
+// schema
+func (p ProofData) MarshalProto() ([]byte, error) {
+	var b []byte
+	b = appendProtoString(b, 1, p.Proof)
+	b = appendProtoString(b, 2, p.VerifyingKey)
+	b = appendProtoString(b, 3, p.PublicWitness)
+	b = appendProtoVarint(b, 4, protoResults[p.Result])
+	for _, hint := range p.Hints {
+		b = appendProtoBytes(b, 5, []byte(hint))
+	}
+	b = appendProtoString(b, 6, p.ProofType)
+	b = appendProtoString(b, 7, p.CircuitID)
+	b = appendProtoVarint(b, 8, uint64(int64(p.CircuitVersion)))
+	b = appendProtoString(b, 9, p.Curve)
+	b = appendProtoTimestamp(b, 10, p.CreatedAt)
+	b = appendProtoString(b, 11, p.CircuitHash)
+	if p.Binding != nil {
+		var binding []byte
+		binding = appendProtoTimestamp(binding, 1, p.Binding.NotBefore)
+		binding = appendProtoTimestamp(binding, 2, p.Binding.NotAfter)
+		binding = appendProtoString(binding, 3, p.Binding.Nonce)
+		binding = appendProtoString(binding, 4, p.Binding.HolderKeyHash)
+		b = appendProtoBytes(b, 12, binding)
+	}
+	b = appendProtoString(b, 13, string(p.FailureReason))
+	for _, signature := range p.Signatures {
+		b = appendProtoBytes(b, 14, []byte(signature))
+	}
+	b = appendProtoVarint(b, 15, FormatVersion)
+	b = appendProtoString(b, 16, p.Backend)
+	if p.Provenance != nil {
+		var provenance []byte
+		provenance = appendProtoString(provenance, 1, p.Provenance.Digest)
+		provenance = appendProtoString(provenance, 2, p.Provenance.MerkleRoot)
+		provenance = appendProtoTimestamp(provenance, 3, p.Provenance.IssuedAt)
+		provenance = appendProtoString(provenance, 4, p.Provenance.Signature)
+		b = appendProtoBytes(b, 17, provenance)
+	}
+	if p.Parameters != nil {
+		var params []byte
+		params = appendProtoVarint(params, 1, uint64(int64(p.Parameters.Chromosome)))
+		params = appendProtoVarint(params, 2, p.Parameters.Position)
+		params = appendProtoString(params, 3, p.Parameters.Ref)
+		params = appendProtoString(params, 4, p.Parameters.Alt)
+		params = appendProtoString(params, 5, p.Parameters.RsID)
+		params = appendProtoString(params, 6, p.Parameters.Mode)
+		b = appendProtoBytes(b, 18, params)
+	}
+	return b, nil
+}
+
+// UnmarshalProto decodes a proof encoded as the ProofData message of the
+// protobuf schema, of any supported format version. Fields the schema adds
+// later are ignored.
+func (p *ProofData) UnmarshalProto(data []byte) error {
+	var decoded ProofData
+	var version uint64
+	err := protoFields(data, func(f protoField) error {
+		var err error
+		var v []byte
+		switch f.num {
+		case 1:
+			decoded.Proof, err = f.wantBytes()
+		case 2:
+			decoded.VerifyingKey, err = f.wantBytes()
+		case 3:
+			decoded.PublicWitness, err = f.wantBytes()
+		case 4:
+			decoded.Result, err = decodeProtoResult(f)
+		case 5:
+			v, err = f.wantBytes()
+			decoded.Hints = append(decoded.Hints, string(v))
+		case 6:
+			v, err = f.wantBytes()
+			decoded.ProofType = string(v)
+		case 7:
+			v, err = f.wantBytes()
+			decoded.CircuitID = string(v)
+		case 8:
+			var version uint64
+			version, err = f.wantVarint()
+			decoded.CircuitVersion = int(int32(version))
+		case 9:
+			v, err = f.wantBytes()
+			decoded.Curve = string(v)
+		case 10:
+			decoded.CreatedAt, err = decodeProtoTimestamp(f)
+		case 11:
+			v, err = f.wantBytes()
+			decoded.CircuitHash = string(v)
+		case 12:
+			decoded.Binding, err = decodeProtoBinding(f)
+		case 13:
+			v, err = f.wantBytes()
+			decoded.FailureReason = FailureReason(v)
+		case 14:
+			v, err = f.wantBytes()
+			decoded.Signatures = append(decoded.Signatures, string(v))
+		case 15:
+			version, err = f.wantVarint()
+		case 16:
+			v, err = f.wantBytes()
+			decoded.Backend = string(v)
+		case 17:
+			decoded.Provenance, err = decodeProtoAttestation(f)
+		case 18:
+			decoded.Parameters, err = decodeProtoParameters(f)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("decoding ProofData message: %w", err)
+	}
+	if err := checkFormatVersion("proof", int(version), FormatVersion); err != nil {
+		return err
+	}
+	migrateProofData(int(version), &decoded)
+
+	// Byte fields alias data; copy them so the proof does not pin or share it
+	for _, field := range []*[]byte{&decoded.Proof, &decoded.VerifyingKey, &decoded.PublicWitness} {
+		*field = cloneProtoBytes(*field)
+	}
+	if decoded.Binding != nil {
+		decoded.Binding.Nonce = cloneProtoBytes(decoded.Binding.Nonce)
+	}
+	*p = decoded
+	return nil
+}
+
+// decodeProtoBinding decodes a Binding message
+func decodeProtoBinding(f protoField) (*Binding, error) {
+	data, err := f.wantBytes()
+	if err != nil {
+		return nil, err
+	}
+	binding := &Binding{}
+	err = protoFields(data, func(f protoField) error {
+		var err error
+		switch f.num {
+		case 1:
+			binding.NotBefore, err = decodeProtoTimestamp(f)
+		case 2:
+			binding.NotAfter, err = decodeProtoTimestamp(f)
+		case 3:
+			binding.Nonce, err = f.wantBytes()
+		case 4:
+			var v []byte
+			v, err = f.wantBytes()
+			binding.HolderKeyHash = string(v)
+		}
+		return err
+	})
+	return binding, err
+}
+
+// decodeProtoAttestation decodes a GenomeAttestation message
+func decodeProtoAttestation(f protoField) (*GenomeAttestation, error) {
+	data, err := f.wantBytes()
+	if err != nil {
+		return nil, err
+	}
+	attestation := &GenomeAttestation{}
+	err = protoFields(data, func(f protoField) error {
+		var err error
+		var v []byte
+		switch f.num {
+		case 1:
+			v, err = f.wantBytes()
+			attestation.Digest = string(v)
+		case 2:
+			v, err = f.wantBytes()
+			attestation.MerkleRoot = string(v)
+		case 3:
+			attestation.IssuedAt, err = decodeProtoTimestamp(f)
+		case 4:
+			v, err = f.wantBytes()
+			attestation.Signature = string(v)
+		}
+		return err
+	})
+	return attestation, err
+}
+
+// decodeProtoParameters decodes a ProofParameters message
+func decodeProtoParameters(f protoField) (*ProofParameters, error) {
+	data, err := f.wantBytes()
+	if err != nil {
+		return nil, err
+	}
+	params := &ProofParameters{}
+	err = protoFields(data, func(f protoField) error {
+		var err error
+		var v []byte
+		var n uint64
+		switch f.num {
+		case 1:
+			n, err = f.wantVarint()
+			params.Chromosome = int(int32(n))
+		case 2:
+			params.Position, err = f.wantVarint()
+		case 3:
+			v, err = f.wantBytes()
+			params.Ref = string(v)
+		case 4:
+			v, err = f.wantBytes()
+			params.Alt = string(v)
+		case 5:
+			v, err = f.wantBytes()
+			params.RsID = string(v)
+		case 6:
+			v, err = f.wantBytes()
+			params.Mode = string(v)
+		}
+		return err
+	})
+	return params, err
+}
+
+// cloneProtoBytes copies a decoded byte field, leaving absent fields nil
+func cloneProtoBytes(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
+
+// MarshalProto encodes the result as the VerificationResult message of the
+// protobuf schema. The error is carried as its message.
+func (r VerificationResult) MarshalProto() ([]byte, error) {
+	var b []byte
+	b = appendProtoVarint(b, 1, protoResults[r.Result])
+	if r.Error != nil {
+		b = appendProtoString(b, 2, r.Error.Error())
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.ParsedPublicInputs)) {
+		var entry []byte
+		entry = appendProtoString(entry, 1, name)
+		entry = appendProtoString(entry, 2, r.ParsedPublicInputs[name])
+		b = appendProtoBytes(b, 3, entry)
+	}
+	b = appendProtoVarint(b, 4, FormatVersion)
+	return b, nil
+}
+
+// UnmarshalProto decodes a result encoded as the VerificationResult message
+// of the protobuf schema, of any supported format version
+func (r *VerificationResult) UnmarshalProto(data []byte) error {
+	decoded := VerificationResult{Result: ProofUnknown}
+	var version uint64
+	err := protoFields(data, func(f protoField) error {
+		switch f.num {
+		case 4:
+			var err error
+			version, err = f.wantVarint()
+			return err
+		case 1:
+			var err error
+			decoded.Result, err = decodeProtoResult(f)
+			return err
+		case 2:
+			message, err := f.wantBytes()
+			if err == nil && len(message) > 0 {
+				decoded.Error = errors.New(string(message))
+			}
+			return err
+		case 3:
+			entry, err := f.wantBytes()
+			if err != nil {
+				return err
+			}
+			var name, value []byte
+			err = protoFields(entry, func(f protoField) error {
+				var err error
+				switch f.num {
+				case 1:
+					name, err = f.wantBytes()
+				case 2:
+					value, err = f.wantBytes()
+				}
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if decoded.ParsedPublicInputs == nil {
+				decoded.ParsedPublicInputs = make(map[string]string)
+			}
+			decoded.ParsedPublicInputs[string(name)] = string(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("decoding VerificationResult message: %w", err)
+	}
+	if err := checkFormatVersion("verification result", int(version), FormatVersion); err != nil {
+		return err
+	}
+	*r = decoded
+	return nil
+}
@@ -0,0 +1,86 @@
+package proofs
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProofData_Proto(t *testing.T) {
+	// Field 1 (proof) = 0x01, field 4 (result) = PROOF_RESULT_FAIL, field 15
+	// (format_version) = 1
+	encoded, err := (&ProofData{Proof: []byte{1}, Result: ProofFail}).MarshalProto()
+	if err != nil || !bytes.Equal(encoded, []byte{0x0a, 0x01, 0x01, 0x20, 0x02, 0x78, 0x01}) {
+		t.Errorf("Expected the schema's wire format, got %x: %v", encoded, err)
+	}
+
+	proofData := &ProofData{
+		Proof:          []byte("proof"),
+		VerifyingKey:   []byte("vk"),
+		PublicWitness:  []byte("witness"),
+		Result:         ProofSuccess,
+		Hints:          []string{"hint_a", "hint_b"},
+		ProofType:      "actn3",
+		CircuitID:      "genotype_claim",
+		CircuitVersion: 2,
+		Curve:          proofCurve,
+		CreatedAt:      time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC),
+		CircuitHash:    "abc",
+		Binding: &Binding{
+			NotBefore:     time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+			NotAfter:      time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+			Nonce:         []byte("nonce"),
+			HolderKeyHash: "def",
+		},
+		FailureReason: FailureOther,
+		Provenance: &GenomeAttestation{
+			Digest:     "0123",
+			MerkleRoot: "42",
+			IssuedAt:   time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			Signature:  "jws",
+		},
+		Parameters: &ProofParameters{Chromosome: 15, Position: 28365618, Ref: "A", Alt: "G", Mode: "carrier"},
+	}
+	encoded, err = proofData.MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto should not return error: %v", err)
+	}
+	var decoded ProofData
+	if err := decoded.UnmarshalProto(encoded); err != nil {
+		t.Fatalf("UnmarshalProto should not return error: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, proofData) {
+		t.Errorf("Expected ProofData to round-trip, got %+v", decoded)
+	}
+
+	if err := decoded.UnmarshalProto(encoded[:len(encoded)-1]); err == nil {
+		t.Error("Expected a truncated message to be rejected")
+	}
+}
+
+func TestVerificationResult_Proto(t *testing.T) {
+	result := &VerificationResult{
+		Result:             ProofFail,
+		Error:              errors.New("proof verification failed"),
+		ParsedPublicInputs: map[string]string{"ClaimedValue": "3", "LocusHash": "42"},
+	}
+	encoded, err := result.MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto should not return error: %v", err)
+	}
+	var decoded VerificationResult
+	if err := decoded.UnmarshalProto(encoded); err != nil {
+		t.Fatalf("UnmarshalProto should not return error: %v", err)
+	}
+	if decoded.Result != ProofFail || decoded.Error.Error() != result.Error.Error() ||
+		!reflect.DeepEqual(decoded.ParsedPublicInputs, result.ParsedPublicInputs) {
+		t.Errorf("Expected VerificationResult to round-trip, got %+v", decoded)
+	}
+
+	// An empty message has an unspecified result, which is not a success
+	if err := decoded.UnmarshalProto(nil); err != nil || decoded.Result != ProofUnknown {
+		t.Errorf("Expected an empty message to decode as unknown, got %+v: %v", decoded, err)
+	}
+}
@@ -0,0 +1,190 @@
+package proofs
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// attestationSignatureType is the JWS typ of genome attestation signatures
+const attestationSignatureType = "zkgenomics-attestation+jws"
+
+// ErrUnattestedGenome is reported when a proof carries no genome attestation
+// signed by a trusted lab
+var ErrUnattestedGenome = errors.New("proof does not carry a genome attestation by a trusted lab")
+
+// ErrUnboundAttestation is reported when a proof carries a trusted genome
+// attestation but discloses no Merkle root binding it to the attested genome
+var ErrUnboundAttestation = errors.New("proof does not disclose the attested genome's Merkle root")
+
+// GenomeAttestation is a sequencing lab's signed statement that it produced
+// the VCF with Digest, the SHA-256 of the file as stored. MerkleRoot, if
+// set, is the Merkle root of the genome's commitment; proofs that disclose
+// it as their Root are bound to the attested genome through it. Any proof
+// can carry a copy of an attestation, so CheckProvenance accepts only
+// proofs bound to it.
+type GenomeAttestation struct {
+	Digest     string    `json:"digest"`
+	MerkleRoot string    `json:"merkle_root,omitempty"`
+	IssuedAt   time.Time `json:"issued_at"`
+	// Signature is the lab's JWS with detached payload over the other fields
+	Signature string `json:"signature"`
+}
+
+// AttestationMismatchError is returned when a VCF is not the one its
+// attestation is for
+type AttestationMismatchError struct {
+	VCFPath        string
+	AttestedDigest string
+	ActualDigest   string
+}
+
+func (e *AttestationMismatchError) Error() string {
+	return fmt.Sprintf("VCF %s is not the genome the lab attested (attested digest %s, current digest %s)",
+		e.VCFPath, e.AttestedDigest, e.ActualDigest)
+}
+
+// AttestationPath returns the sidecar file holding the attestation of vcfPath
+func AttestationPath(vcfPath string) string {
+	return vcfPath + ".attestation.json"
+}
+
+// AttestGenome signs an attestation of the VCF at vcfPath with signer, as
+// the lab that sequenced it. The Merkle root of its commitment is attested
+// too, if it has been committed with one.
+func AttestGenome(vcfPath string, signer EnvelopeSigner) (*GenomeAttestation, error) {
+	digest, err := DigestFile(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	attestation := &GenomeAttestation{Digest: digest, IssuedAt: time.Now().UTC().Truncate(time.Second)}
+
+	commitment, err := LoadGenomeCommitment(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	if commitment != nil && commitment.MerkleRoot != "" {
+		if commitment.Digest != digest {
+			return nil, &StaleCommitmentError{VCFPath: vcfPath, ExpectedDigest: commitment.Digest, ActualDigest: digest}
+		}
+		attestation.MerkleRoot = commitment.MerkleRoot
+	}
+
+	payload, err := attestation.payload()
+	if err != nil {
+		return nil, err
+	}
+	attestation.Signature, err = signDetached(payload, signer, attestationSignatureType)
+	if err != nil {
+		return nil, fmt.Errorf("signing attestation: %w", err)
+	}
+	return attestation, nil
+}
+
+// payload returns the JWS payload of the attestation: its JSON encoding
+// without the signature
+func (a *GenomeAttestation) payload() ([]byte, error) {
+	unsigned := *a
+	unsigned.Signature = ""
+	return json.Marshal(unsigned)
+}
+
+// Check returns an AttestationMismatchError if vcfPath is not the attested VCF
+func (a *GenomeAttestation) Check(vcfPath string) error {
+	digest, err := DigestFile(vcfPath)
+	if err != nil {
+		return err
+	}
+	if digest != a.Digest {
+		return &AttestationMismatchError{VCFPath: vcfPath, AttestedDigest: a.Digest, ActualDigest: digest}
+	}
+	return nil
+}
+
+// Verify returns the ID of the trusted lab key that signed the attestation,
+// or ErrUnattestedGenome if none did. A trusted key's invalid signature is
+// an error.
+func (a *GenomeAttestation) Verify(trusted map[string]crypto.PublicKey) (string, error) {
+	payload, err := a.payload()
+	if err != nil {
+		return "", err
+	}
+	keyID, err := checkDetached([]string{a.Signature}, payload, trusted)
+	if err == nil && keyID == "" {
+		err = ErrUnattestedGenome
+	}
+	return keyID, err
+}
+
+// Save writes the attestation next to the attested VCF
+func (a *GenomeAttestation) Save(vcfPath string) error {
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(AttestationPath(vcfPath), data, 0644)
+}
+
+// ReadGenomeAttestation reads an attestation from path
+func ReadGenomeAttestation(path string) (*GenomeAttestation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var attestation GenomeAttestation
+	if err := json.Unmarshal(data, &attestation); err != nil {
+		return nil, fmt.Errorf("decoding genome attestation: %w", err)
+	}
+	return &attestation, nil
+}
+
+// LoadGenomeAttestation reads the attestation stored next to vcfPath. It
+// returns nil without error when the genome has not been attested.
+func LoadGenomeAttestation(vcfPath string) (*GenomeAttestation, error) {
+	if IsRemote(vcfPath) {
+		return nil, nil
+	}
+	attestation, err := ReadGenomeAttestation(AttestationPath(vcfPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return attestation, err
+}
+
+// CheckProvenance returns the ID of the trusted lab key that attested the
+// genome proofData was proven from, or ErrUnattestedGenome if it carries no
+// attestation by one. The attestation must attest a Merkle root, and the
+// proof must disclose it as its Root; otherwise the attestation could have
+// been copied from any other proof, and CheckProvenance fails with
+// ErrUnboundAttestation.
+func CheckProvenance(proofData *ProofData, trusted map[string]crypto.PublicKey) (string, error) {
+	attestation := proofData.Provenance
+	if attestation == nil {
+		return "", ErrUnattestedGenome
+	}
+	keyID, err := attestation.Verify(trusted)
+	if err != nil {
+		return "", err
+	}
+	if attestation.MerkleRoot == "" {
+		return "", fmt.Errorf("%w: the attestation attests no Merkle root", ErrUnboundAttestation)
+	}
+
+	values, err := PublicValues(proofData)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnboundAttestation, err)
+	}
+	for _, value := range values {
+		if value.Name != "Root" {
+			continue
+		}
+		if value.Value != attestation.MerkleRoot {
+			return "", fmt.Errorf("proof is bound to Merkle root %s, not the attested genome's", value.Value)
+		}
+		return keyID, nil
+	}
+	return "", ErrUnboundAttestation
+}
@@ -0,0 +1,103 @@
+package proofs
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestAttestGenome(t *testing.T) {
+	vcfPath := writeTestVCF(t, merkleTestVCF)
+	t.Cleanup(func() { os.Remove(CommitmentPath(vcfPath)) })
+	if _, err := CommitGenomeMerkle(vcfPath, nil); err != nil {
+		t.Fatalf("CommitGenomeMerkle should not return error: %v", err)
+	}
+
+	labPublic, labKey, _ := ed25519.GenerateKey(nil)
+	otherPublic, _, _ := ed25519.GenerateKey(nil)
+	signer, err := NewEnvelopeSigner("lab", labKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestation, err := AttestGenome(vcfPath, signer)
+	if err != nil {
+		t.Fatalf("AttestGenome should not return error: %v", err)
+	}
+	if err := attestation.Check(vcfPath); err != nil {
+		t.Errorf("Expected the attested VCF to match its attestation: %v", err)
+	}
+
+	proofData, err := NewCommittedVariantProof(merkleTestVariant).Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	trusted := map[string]crypto.PublicKey{"lab": labPublic}
+	if _, err := CheckProvenance(proofData, trusted); !errors.Is(err, ErrUnattestedGenome) {
+		t.Errorf("Expected a proof without provenance to fail with ErrUnattestedGenome, got %v", err)
+	}
+	proofData.Provenance = attestation
+	if keyID, err := CheckProvenance(proofData, trusted); err != nil || keyID != "lab" {
+		t.Errorf("Expected the proof to be attested by lab, got %q: %v", keyID, err)
+	}
+	if _, err := CheckProvenance(proofData, map[string]crypto.PublicKey{"other": otherPublic}); !errors.Is(err, ErrUnattestedGenome) {
+		t.Errorf("Expected an untrusted lab to fail with ErrUnattestedGenome, got %v", err)
+	}
+
+	// An attestation of another genome does not match the committed root
+	// the proof discloses
+	other := *attestation
+	other.MerkleRoot = "1"
+	other.Signature = ""
+	payload, _ := other.payload()
+	other.Signature, _ = signDetached(payload, signer, attestationSignatureType)
+	proofData.Provenance = &other
+	if _, err := CheckProvenance(proofData, trusted); err == nil {
+		t.Error("Expected an attestation of another Merkle root to be refused")
+	}
+
+	// Copied onto a proof that discloses no root, the attestation binds
+	// nothing
+	variantPath := writeTestVCF(t, "##fileformat=VCFv4.2\n"+
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n"+
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n"+
+		"13\t32914437\t.\tGT\tG\t60\tPASS\t.\tGT\t0/1\n")
+	carrier, err := NewCarrierProof(traits.TraitVariant{Chromosome: 13, Position: 32914437, Ref: "GT", Alt: "G"}).Generate(variantPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	carrier.Provenance = attestation
+	if _, err := CheckProvenance(carrier, trusted); !errors.Is(err, ErrUnboundAttestation) {
+		t.Errorf("Expected a proof without a root to fail with ErrUnboundAttestation, got %v", err)
+	}
+
+	// An attestation of no root binds no proof
+	unrooted := *attestation
+	unrooted.MerkleRoot = ""
+	unrooted.Signature = ""
+	payload, _ = unrooted.payload()
+	unrooted.Signature, _ = signDetached(payload, signer, attestationSignatureType)
+	proofData.Provenance = &unrooted
+	if _, err := CheckProvenance(proofData, trusted); !errors.Is(err, ErrUnboundAttestation) {
+		t.Errorf("Expected an attestation without a root to fail with ErrUnboundAttestation, got %v", err)
+	}
+
+	// Editing the attestation breaks the lab's signature
+	tampered := *attestation
+	tampered.Digest = "00"
+	proofData.Provenance = &tampered
+	if _, err := CheckProvenance(proofData, trusted); err == nil {
+		t.Error("Expected a tampered attestation to be refused")
+	}
+
+	if err := os.WriteFile(vcfPath, []byte(merkleTestVCF+"22\t100\trs1\tA\tG\t60\tPASS\t.\tGT\t0/1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var mismatch *AttestationMismatchError
+	if err := attestation.Check(vcfPath); !errors.As(err, &mismatch) {
+		t.Errorf("Expected an edited VCF to fail with AttestationMismatchError, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	gnarkbackend "github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+)
+
+// Prover proves solved circuits in place of the backend, such as a GPU
+// accelerated prover or a remote proving service. Compiling, creating the
+// witness and verification stay local: every proof a Prover returns is
+// verified against the circuit's verifying key before it is accepted.
+type Prover interface {
+	// Prove proves the full witness of the circuit compiled as cs, whose keys
+	// are stored under circuit, returning the proof serialized as
+	// Backend.Prove does. pk may be read lazily; see LoadedProvingKey.
+	Prove(circuit CircuitKey, cs constraint.ConstraintSystem, pk ProvingKey, fullWitness witness.Witness, opts ...gnarkbackend.ProverOption) ([]byte, error)
+}
+
+// RemoteProveRequest is the body RemoteProver posts to a proving service
+type RemoteProveRequest struct {
+	CircuitID      string `json:"circuit_id"`
+	CircuitVersion int    `json:"circuit_version"`
+	// CircuitHash and Backend identify the keys to prove with, as in
+	// CircuitKey
+	CircuitHash string `json:"circuit_hash"`
+	Backend     string `json:"backend"`
+	Curve       string `json:"curve"`
+	// Witness is the full witness, serialized with MarshalBinary
+	Witness []byte `json:"witness"`
+}
+
+// RemoteProveResponse is the body a proving service answers with
+type RemoteProveResponse struct {
+	// Proof is the proof serialized as Backend.Prove does
+	Proof []byte `json:"proof,omitempty"`
+	// Error, if set, is why the service could not prove
+	Error string `json:"error,omitempty"`
+}
+
+// RemoteProver delegates proving to an HTTP proving service. It posts a
+// RemoteProveRequest as JSON to URL and reads a RemoteProveResponse; the
+// service proves with the keys it holds for the circuit, which must be the
+// keys the proof is verified with locally. The full witness sent holds the
+// private genotypes, so use only a service trusted with the genome, such as
+// one of your own GPU machines, and reach it over HTTPS.
+type RemoteProver struct {
+	URL string
+	// Client, if set, sends the requests; http.DefaultClient otherwise
+	Client *http.Client
+	// Header, if set, is added to every request, such as credentials for
+	// the service
+	Header http.Header
+}
+
+// Prove sends the witness of circuit to the service. Solver options such as
+// hints and threads are the service's concern and are not sent.
+func (p *RemoteProver) Prove(circuit CircuitKey, cs constraint.ConstraintSystem, pk ProvingKey, fullWitness witness.Witness, opts ...gnarkbackend.ProverOption) ([]byte, error) {
+	witnessBytes, err := fullWitness.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("serializing witness: %w", err)
+	}
+	body, err := json.Marshal(RemoteProveRequest{
+		CircuitID:      circuit.ID,
+		CircuitVersion: circuit.Version,
+		CircuitHash:    circuit.Hash,
+		Backend:        circuit.Backend,
+		Curve:          circuit.Curve,
+		Witness:        witnessBytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range p.Header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote prover: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("remote prover: %w", err)
+	}
+	var response RemoteProveResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("remote prover: %s", resp.Status)
+		}
+		return nil, fmt.Errorf("remote prover: decoding response: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("remote prover: %s", response.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote prover: %s", resp.Status)
+	}
+	if len(response.Proof) == 0 {
+		return nil, fmt.Errorf("remote prover returned no proof")
+	}
+	return response.Proof, nil
+}
@@ -0,0 +1,82 @@
+package proofs
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+
+	gnarkbackend "github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/constraint/solver"
+)
+
+// ProverOptions tune how proofs are proven without changing what they prove
+type ProverOptions struct {
+	// Threads, if set, caps the CPU threads compiling, setup and proving use,
+	// so proofs on small machines leave room for other work; every CPU is
+	// used otherwise. Go schedules threads process-wide, so while proofs
+	// with different caps run concurrently the lowest cap applies to all.
+	Threads int
+	// Hints are solver hints given to the solver beside those the circuit
+	// declares, such as hints of gadgets from other packages. Each must be
+	// in the audited registry.
+	Hints []solver.Hint
+	// Prover, if set, proves in place of the backend, and its proofs are
+	// verified before they are accepted
+	Prover Prover
+}
+
+// validate checks the options before any proving starts
+func (o ProverOptions) validate() error {
+	if o.Threads < 0 {
+		return fmt.Errorf("threads must not be negative, got %d", o.Threads)
+	}
+	_, err := auditedHintNames(o.Hints)
+	return err
+}
+
+// proverOptions returns the gnark prover options proving a circuit with
+// hints under o takes
+func (o ProverOptions) proverOptions(hints []solver.Hint) []gnarkbackend.ProverOption {
+	solverOpts := []solver.Option{solver.WithHints(hints...)}
+	if o.Threads > 0 {
+		solverOpts = append(solverOpts, solver.WithNbTasks(o.Threads))
+	}
+	return []gnarkbackend.ProverOption{gnarkbackend.WithSolverOptions(solverOpts...)}
+}
+
+// threadCaps holds the thread caps of the proofs being proven, and the
+// GOMAXPROCS in force before the first of them, restored after the last
+var threadCaps struct {
+	sync.Mutex
+	caps    []int
+	restore int
+}
+
+// CapThreads caps the CPU threads of the process at threads, if set, until
+// the returned function is called, as proving with ProverOptions.Threads
+// does. It lets work outside proving, such as key setup, be capped too.
+func CapThreads(threads int) (release func()) {
+	if threads <= 0 {
+		return func() {}
+	}
+	threadCaps.Lock()
+	defer threadCaps.Unlock()
+	if len(threadCaps.caps) == 0 {
+		threadCaps.restore = runtime.GOMAXPROCS(0)
+	}
+	threadCaps.caps = append(threadCaps.caps, threads)
+	runtime.GOMAXPROCS(slices.Min(threadCaps.caps))
+
+	return func() {
+		threadCaps.Lock()
+		defer threadCaps.Unlock()
+		i := slices.Index(threadCaps.caps, threads)
+		threadCaps.caps = slices.Delete(threadCaps.caps, i, i+1)
+		if len(threadCaps.caps) == 0 {
+			runtime.GOMAXPROCS(threadCaps.restore)
+			return
+		}
+		runtime.GOMAXPROCS(slices.Min(threadCaps.caps))
+	}
+}
@@ -0,0 +1,59 @@
+package proofs
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/consensys/gnark/constraint/solver"
+)
+
+func TestProverOptions_ThreadsAndHints(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	procs := runtime.GOMAXPROCS(0)
+	proof := &ALDH2Proof{}
+	opts := GenerateOptions{ProverOptions: ProverOptions{Threads: 1, Hints: []solver.Hint{divModHint}}}
+	proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, opts)
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+	if result, err := proof.VerifyProofData(proofData); err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Proof generated on one thread should verify, got %v, %v", result, err)
+	}
+	if len(proofData.Hints) != 1 || proofData.Hints[0] != "DivMod" {
+		t.Errorf("Expected the extra hint to be recorded, got %v", proofData.Hints)
+	}
+	if got := runtime.GOMAXPROCS(0); got != procs {
+		t.Errorf("Expected GOMAXPROCS restored to %d after proving, got %d", procs, got)
+	}
+
+	for name, prover := range map[string]ProverOptions{
+		"unaudited hint":   {Hints: []solver.Hint{solver.InvZeroHint}},
+		"negative threads": {Threads: -1},
+	} {
+		if _, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, GenerateOptions{ProverOptions: prover}); err == nil {
+			t.Errorf("Expected generation with %s to fail", name)
+		}
+	}
+}
+
+func TestCapThreads_LowestCapApplies(t *testing.T) {
+	procs := runtime.GOMAXPROCS(0)
+	releaseTwo := CapThreads(2)
+	releaseOne := CapThreads(1)
+	if got := runtime.GOMAXPROCS(0); got != 1 {
+		t.Errorf("Expected the lowest cap 1 to apply, got %d", got)
+	}
+	releaseOne()
+	if got := runtime.GOMAXPROCS(0); got != 2 {
+		t.Errorf("Expected the remaining cap 2 to apply, got %d", got)
+	}
+	releaseTwo()
+	if got := runtime.GOMAXPROCS(0); got != procs {
+		t.Errorf("Expected GOMAXPROCS restored to %d, got %d", procs, got)
+	}
+}
@@ -0,0 +1,97 @@
+package proofs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+)
+
+func TestRemoteProver(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	store := &FileKeyStore{Dir: t.TempDir()}
+	proof := &ALDH2Proof{}
+	circuit, err := proof.Circuit()
+	if err != nil {
+		t.Fatalf("Circuit should not return error: %v", err)
+	}
+	key, err := SetupKeys(circuit, nil, store, nil)
+	if err != nil {
+		t.Fatalf("SetupKeys should not return error: %v", err)
+	}
+	cs, err := Groth16.Compile(circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, _, err := store.LoadKeys(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The service proves with the keys it holds, answering with respond
+	respond := func(req RemoteProveRequest) RemoteProveResponse {
+		if req.CircuitHash != key.Hash {
+			return RemoteProveResponse{Error: "unknown circuit " + req.CircuitID}
+		}
+		w, err := witness.New(ecc.BN254.ScalarField())
+		if err == nil {
+			err = w.UnmarshalBinary(req.Witness)
+		}
+		if err != nil {
+			return RemoteProveResponse{Error: err.Error()}
+		}
+		proofBytes, err := Groth16.Prove(cs, pk, w)
+		if err != nil {
+			return RemoteProveResponse{Error: err.Error()}
+		}
+		return RemoteProveResponse{Proof: proofBytes}
+	}
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		var req RemoteProveRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(respond(req))
+	}))
+	defer server.Close()
+
+	remote := &RemoteProver{URL: server.URL, Header: http.Header{"Authorization": {"Bearer token"}}}
+	opts := GenerateOptions{Keys: store, ProverOptions: ProverOptions{Prover: remote}}
+	proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, opts)
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+	if result, err := proof.VerifyProofData(proofData); err != nil || result.Result != ProofSuccess {
+		t.Errorf("Remotely proven proof should verify, got %v, %v", result, err)
+	}
+	if authorization != "Bearer token" {
+		t.Errorf("Expected the request to carry the configured header, got %q", authorization)
+	}
+
+	// Proofs that do not verify locally are refused, and service errors
+	// reported
+	for name, response := range map[string]RemoteProveResponse{
+		"invalid proof": {Proof: []byte{1, 2, 3}},
+		"error":         {Error: "out of GPU memory"},
+	} {
+		respond = func(RemoteProveRequest) RemoteProveResponse { return response }
+		_, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, opts)
+		if err == nil {
+			t.Errorf("Expected a remote %s to fail generation", name)
+		} else if name == "error" && !strings.Contains(err.Error(), "out of GPU memory") {
+			t.Errorf("Expected the service's error to be reported, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package proofs
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// ClaimMismatchError is reported when a proof's public values differ from
+// the values a verifier expected
+type ClaimMismatchError struct {
+	Name     string
+	Expected string
+	Actual   string
+}
+
+func (e *ClaimMismatchError) Error() string {
+	if e.Actual == "" {
+		return fmt.Sprintf("proof has no public input %s", e.Name)
+	}
+	return fmt.Sprintf("public input %s is %s, expected %s", e.Name, e.Actual, e.Expected)
+}
+
+// PublicValues decodes the public witness of proofData and names each value
+// after the public input layout of the circuit that produced it
+func PublicValues(proofData *ProofData) ([]PublicValue, error) {
+	curve, err := CurveNamed(proofData.Curve)
+	if err != nil {
+		return nil, err
+	}
+	publicWitness, err := decodePublicWitness(curve, proofData.PublicWitness)
+	if err != nil {
+		return nil, err
+	}
+	values, err := witnessValues(publicWitness)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, bound, err := proofLayout(proofData, len(values))
+	if err != nil {
+		return nil, err
+	}
+	if bound {
+		layout.Inputs = append(slices.Clone(layout.Inputs), "Binding")
+	}
+
+	named := make([]PublicValue, len(values))
+	for i, name := range layout.Inputs {
+		named[i] = PublicValue{Name: name, Value: values[i].String()}
+	}
+	return named, nil
+}
+
+// verified returns a successful VerificationResult carrying the public values
+// of proofData. Values that cannot be named are left out; the proof itself has
+// already been verified.
+func verified(proofData *ProofData) *VerificationResult {
+	result := &VerificationResult{Result: ProofSuccess}
+	values, err := PublicValues(proofData)
+	if err != nil {
+		return result
+	}
+	result.ParsedPublicInputs = make(map[string]string, len(values))
+	for _, value := range values {
+		result.ParsedPublicInputs[value.Name] = value.Value
+	}
+	return result
+}
+
+// CheckPublicValues returns a ClaimMismatchError for the first expected value
+// the proof does not disclose. Public inputs not listed in expected are not
+// checked.
+func CheckPublicValues(proofData *ProofData, expected []PublicValue) error {
+	actual, err := PublicValues(proofData)
+	if err != nil {
+		return err
+	}
+	curve, err := CurveNamed(proofData.Curve)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]string, len(actual))
+	for _, value := range actual {
+		values[value.Name] = value.Value
+	}
+	for _, want := range expected {
+		got, ok := values[want.Name]
+		if !ok {
+			return &ClaimMismatchError{Name: want.Name, Expected: want.Value}
+		}
+		if !sameFieldValue(curve, got, want.Value) {
+			return &ClaimMismatchError{Name: want.Name, Expected: want.Value, Actual: got}
+		}
+	}
+	return nil
+}
+
+// DecodeClaim returns the label of the trait claim proofData discloses, as
+// the vocabulary of its proof type names it
+func DecodeClaim(proofData *ProofData) (string, error) {
+	vocabulary, ok := traits.LookupClaimVocabulary(proofData.ProofType)
+	if !ok {
+		return "", fmt.Errorf("proof type %q has no claim vocabulary", proofData.ProofType)
+	}
+	values, err := PublicValues(proofData)
+	if err != nil {
+		return "", err
+	}
+	for _, value := range values {
+		if value.Name != vocabulary.Input {
+			continue
+		}
+		code, err := strconv.Atoi(value.Value)
+		if err != nil {
+			return "", fmt.Errorf("public input %s is %s, not a claim value", value.Name, value.Value)
+		}
+		return vocabulary.Decode(code)
+	}
+	return "", &ClaimMismatchError{Name: vocabulary.Input}
+}
+
+// sameFieldValue compares two integer values as elements of the scalar field
+// of curve, so expected values need not be reduced
+func sameFieldValue(curve ecc.ID, a string, b string) bool {
+	x, ok := new(big.Int).SetString(a, 0)
+	if !ok {
+		return false
+	}
+	y, ok := new(big.Int).SetString(b, 0)
+	if !ok {
+		return false
+	}
+	modulus := curve.ScalarField()
+	return x.Mod(x, modulus).Cmp(y.Mod(y, modulus)) == 0
+}
+
+// proofLayout returns the layout of the circuit that produced proofData, a
+// proof with n public inputs, and whether the proof is bound. Whether it is
+// bound is decided by n, which its verifying key fixes, and never by whether
+// the proof records a binding, which a prover can strip or add.
+func proofLayout(proofData *ProofData, n int) (PublicInputLayout, bool, error) {
+	if proofData.CircuitID == "" {
+		return PublicInputLayout{}, false, fmt.Errorf("proof does not record the circuit that produced it")
+	}
+
+	layout, err := circuitLayout(proofData.CircuitID, proofData.CircuitVersion, n)
+	boundLayout, boundErr := circuitLayout(proofData.CircuitID, proofData.CircuitVersion, n-1)
+	switch {
+	case err == nil && boundErr == nil:
+		return PublicInputLayout{}, false, fmt.Errorf("%s v%d proof with %d public inputs may or may not be bound",
+			proofData.CircuitID, proofData.CircuitVersion, n)
+	case err == nil:
+		if proofData.Binding != nil {
+			return PublicInputLayout{}, false, fmt.Errorf("proof records a binding but was not proven with one")
+		}
+		return layout, false, nil
+	case boundErr == nil:
+		if proofData.Binding == nil {
+			return PublicInputLayout{}, false, fmt.Errorf("proof is bound but does not record its binding")
+		}
+		return boundLayout, true, nil
+	}
+	return PublicInputLayout{}, false, err
+}
+
+// circuitLayout returns the layout of a released circuit version with n
+// public inputs
+func circuitLayout(circuitID string, version int, n int) (PublicInputLayout, error) {
+	var circuit LayoutCircuit
+	switch circuitID {
+	case "blood_type":
+		circuit = &BloodTypeCircuit{}
+	case "mthfr":
+		circuit = &MTHFRCircuit{}
+	case "brca2_panel":
+		circuit = NewBRCA2PanelCircuit(nil, 0)
+	case "burden":
+		circuit = NewBurdenCircuit(0)
+	case "cohort":
+		if version == 1 {
+			// v1 disclosed every commitment, and v2 replaced them with
+			// LocusHash and CommitmentsHash
+			if n < 1 {
+				return PublicInputLayout{}, fmt.Errorf("cohort proof has no public inputs")
+			}
+			inputs := append([]string{"MinCarrierPercent"}, indexedInputs("Commitments", n-1)...)
+			return PublicInputLayout{CircuitID: "cohort", Version: 1, Inputs: inputs}, nil
+		}
+		circuit = NewCohortCircuit(0)
+	case "cyp2d6":
+		circuit = NewCYP2D6Circuit()
+	case "genotype_claim":
+		circuit = &GenotypeClaimCircuit{}
+	case "dynamic":
+		circuit = &DynamicCircuit{}
+	case "chromosome":
+		circuit = NewChromosomeCircuit(0)
+	case "sex_chromosome":
+		circuit = NewSexChromosomeCircuit(0)
+	case "negative":
+		circuit = &NegativeCircuit{}
+	case "kinship":
+		circuit = NewKinshipCircuit(0)
+	case "aggregate":
+		if version == 1 {
+			// v1 claims contributed five public inputs, and v2 added
+			// LocusHashes
+			if n < 5 || n%5 != 0 {
+				return PublicInputLayout{}, fmt.Errorf("aggregate proof has %d public inputs, expected a multiple of 5", n)
+			}
+			var inputs []string
+			for _, name := range []string{"Chromosomes", "Positions", "RefHashes", "AltHashes", "ClaimedGenotypes"} {
+				inputs = append(inputs, indexedInputs(name, n/5)...)
+			}
+			return PublicInputLayout{CircuitID: "aggregate", Version: 1, Inputs: inputs}, nil
+		}
+		// Every claim contributes six public inputs
+		if n < 6 || n%6 != 0 {
+			return PublicInputLayout{}, fmt.Errorf("aggregate proof has %d public inputs, expected a multiple of 6", n)
+		}
+		circuit = NewAggregateCircuit(n / 6)
+	case "carrier":
+		circuit = &CarrierCircuit{}
+	case "committed_variant":
+		circuit = NewCommittedVariantCircuit(0)
+	case "region_count":
+		circuit = NewRegionCountCircuit(0)
+	case "phase":
+		circuit = &PhaseCircuit{}
+	case "recursion":
+		// KeysHash, then every inner public input as emulated limbs
+		if n < 1 || (n-1)%recursionLimbs != 0 {
+			return PublicInputLayout{}, fmt.Errorf("recursion proof has %d public inputs, expected KeysHash and whole inner inputs", n)
+		}
+		circuit = &RecursionCircuit{Public: make([]innerScalar, (n-1)/recursionLimbs)}
+	default:
+		return PublicInputLayout{}, fmt.Errorf("unknown circuit %q", circuitID)
+	}
+
+	layout := circuit.PublicInputLayout()
+	if layout.Version != version {
+		i := slices.IndexFunc(retiredLayouts, func(l PublicInputLayout) bool {
+			return l.CircuitID == circuitID && l.Version == version
+		})
+		if i < 0 {
+			return PublicInputLayout{}, &CircuitVersionError{
+				CircuitID: circuitID,
+				Version:   version,
+				Supported: supportedVersions(circuitID, layout.Version),
+			}
+		}
+		layout = retiredLayouts[i]
+	}
+	if len(layout.Inputs) != n {
+		return PublicInputLayout{}, fmt.Errorf("%s v%d proof has %d public inputs, expected %d",
+			circuitID, version, n, len(layout.Inputs))
+	}
+	return layout, nil
+}
@@ -0,0 +1,86 @@
+package proofs
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestPublicValues(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	11854476	rs1801131	T	G	60	PASS	.	GT	0/0
+1	11856378	rs1801133	G	A	60	PASS	.	GT	1/1
+`)
+
+	proofData, err := (&MTHFRProof{}).Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	values, err := PublicValues(proofData)
+	if err != nil {
+		t.Fatalf("PublicValues should not return error: %v", err)
+	}
+	status := strconv.Itoa(int(traits.MTHFRC677THomozygous))
+	if len(values) != 1 || values[0].Name != "ClaimedStatus" || values[0].Value != status {
+		t.Errorf("Expected ClaimedStatus = %s, got %+v", status, values)
+	}
+
+	result, err := (&MTHFRProof{}).VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected the proof to verify, got %v: %v", result, err)
+	}
+	if result.ParsedPublicInputs["ClaimedStatus"] != status {
+		t.Errorf("Expected verification to disclose ClaimedStatus = %s, got %v", status, result.ParsedPublicInputs)
+	}
+
+	if err := CheckPublicValues(proofData, []PublicValue{{Name: "ClaimedStatus", Value: status}}); err != nil {
+		t.Errorf("CheckPublicValues should accept the disclosed status: %v", err)
+	}
+
+	var mismatch *ClaimMismatchError
+	err = CheckPublicValues(proofData, []PublicValue{{Name: "ClaimedStatus", Value: "1"}})
+	if !errors.As(err, &mismatch) {
+		t.Errorf("Expected ClaimMismatchError for a different status, got %v", err)
+	}
+	err = CheckPublicValues(proofData, []PublicValue{{Name: "ClaimedBloodType", Value: "1"}})
+	if !errors.As(err, &mismatch) {
+		t.Errorf("Expected ClaimMismatchError for an absent input, got %v", err)
+	}
+}
+
+func TestDecodeClaim(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+11	66328095	rs1815739	C	T	60	PASS	.	GT	0/1
+`)
+
+	proofData, err := (&ACTN3Proof{}).Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if _, err := DecodeClaim(proofData); err == nil {
+		t.Error("Expected a proof without a proof type not to be decoded")
+	}
+	proofData.ProofType = "actn3"
+	label, err := DecodeClaim(proofData)
+	if err != nil || label != traits.ACTN3RX.String() {
+		t.Errorf("Expected the claim to decode as RX, got %q: %v", label, err)
+	}
+
+	vocabulary, ok := traits.LookupClaimVocabulary("actn3")
+	if !ok {
+		t.Fatal("Expected actn3 to have a claim vocabulary")
+	}
+	if code, err := vocabulary.Encode("xx"); err != nil || code != int(traits.ACTN3XX) {
+		t.Errorf("Expected XX to encode as %d, got %d: %v", traits.ACTN3XX, code, err)
+	}
+	if _, err := vocabulary.Decode(int(traits.ACTN3Unknown)); err == nil {
+		t.Error("Expected the unknown value not to be a claim")
+	}
+}
@@ -0,0 +1,162 @@
+package proofs
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+)
+
+// qrChunkPrefix starts every QR chunk
+const qrChunkPrefix = "ZKGQR"
+
+// DefaultQRChunkSize is the number of encoded proof bytes per QR chunk. Its
+// chunks fit a version 25 QR code at error correction level M, which phone
+// cameras scan reliably.
+const DefaultQRChunkSize = 512
+
+// EncodeCompact encodes proofData for offline exchange, as a format version
+// byte followed by the CBOR encoding of the proof without its verifying key.
+// Verifiers of compact proofs pin the verifying key of each circuit instead,
+// such as with Verifier.VerifyingKeys.
+func EncodeCompact(proofData *ProofData) ([]byte, error) {
+	compact := *proofData
+	compact.VerifyingKey = nil
+	encoded, err := compact.MarshalCBOR()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{FormatVersion}, encoded...), nil
+}
+
+// DecodeCompact decodes a proof encoded by EncodeCompact. Unversioned compact
+// proofs, plain CBOR maps, are read as version 0.
+func DecodeCompact(data []byte) (*ProofData, error) {
+	if len(data) > 0 && !isCBORMap(data[0]) {
+		if err := checkFormatVersion("compact proof", int(data[0]), FormatVersion); err != nil {
+			return nil, err
+		}
+		data = data[1:]
+	}
+
+	var proofData ProofData
+	if err := decodeCBOR(data, &proofData, "compact proof"); err != nil {
+		return nil, err
+	}
+	return &proofData, nil
+}
+
+// isCBORMap reports whether b starts a CBOR map, of major type 5
+func isCBORMap(b byte) bool {
+	return b>>5 == 5
+}
+
+// SplitQRChunks encodes proofData compactly and splits it into text chunks of
+// at most chunkSize encoded bytes each, one per QR code. A chunkSize of zero
+// uses DefaultQRChunkSize. Each chunk reads
+//
+//	ZKGQR:<proof id>:<index>/<total>:<crc32>:<base64url data>
+//
+// where the proof ID, the first 8 bytes of the SHA-256 of the whole encoding,
+// keeps chunks of different proofs apart, and the CRC-32 of the chunk's data
+// catches misreads.
+func SplitQRChunks(proofData *ProofData, chunkSize int) ([]string, error) {
+	if chunkSize < 0 {
+		return nil, fmt.Errorf("invalid chunk size %d", chunkSize)
+	}
+	if chunkSize == 0 {
+		chunkSize = DefaultQRChunkSize
+	}
+	encoded, err := EncodeCompact(proofData)
+	if err != nil {
+		return nil, fmt.Errorf("encoding proof: %w", err)
+	}
+
+	id := qrProofID(encoded)
+	total := (len(encoded) + chunkSize - 1) / chunkSize
+	chunks := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		data := encoded[i*chunkSize : min((i+1)*chunkSize, len(encoded))]
+		chunks = append(chunks, fmt.Sprintf("%s:%s:%d/%d:%08x:%s",
+			qrChunkPrefix, id, i+1, total, crc32.ChecksumIEEE(data), base64.RawURLEncoding.EncodeToString(data)))
+	}
+	return chunks, nil
+}
+
+// JoinQRChunks reassembles a proof from the chunks SplitQRChunks made, which
+// may arrive in any order and more than once, as when scanned repeatedly
+func JoinQRChunks(chunks []string) (*ProofData, error) {
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("no QR chunks")
+	}
+
+	var id string
+	var parts [][]byte
+	for _, chunk := range chunks {
+		chunkID, index, total, data, err := parseQRChunk(chunk)
+		if err != nil {
+			return nil, err
+		}
+		if parts == nil {
+			id = chunkID
+			parts = make([][]byte, total)
+		}
+		if chunkID != id {
+			return nil, fmt.Errorf("QR chunks belong to different proofs: %s and %s", id, chunkID)
+		}
+		if total != len(parts) {
+			return nil, fmt.Errorf("QR chunks of proof %s disagree on the chunk count", id)
+		}
+		parts[index-1] = data
+	}
+
+	var encoded []byte
+	var missing []string
+	for i, part := range parts {
+		if part == nil {
+			missing = append(missing, strconv.Itoa(i+1))
+		}
+		encoded = append(encoded, part...)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing QR chunks %s of %d", strings.Join(missing, ", "), len(parts))
+	}
+	if qrProofID(encoded) != id {
+		return nil, fmt.Errorf("reassembled proof does not match proof ID %s", id)
+	}
+	return DecodeCompact(encoded)
+}
+
+// parseQRChunk parses and checksums one chunk
+func parseQRChunk(chunk string) (id string, index, total int, data []byte, err error) {
+	fields := strings.Split(strings.TrimSpace(chunk), ":")
+	if len(fields) != 5 || fields[0] != qrChunkPrefix {
+		return "", 0, 0, nil, fmt.Errorf("not a proof QR chunk")
+	}
+	position, count, ok := strings.Cut(fields[2], "/")
+	if !ok {
+		return "", 0, 0, nil, fmt.Errorf("malformed QR chunk position %q", fields[2])
+	}
+	if index, err = strconv.Atoi(position); err == nil {
+		total, err = strconv.Atoi(count)
+	}
+	if err != nil || total < 1 || index < 1 || index > total {
+		return "", 0, 0, nil, fmt.Errorf("malformed QR chunk position %q", fields[2])
+	}
+	if data, err = base64.RawURLEncoding.DecodeString(fields[4]); err != nil {
+		return "", 0, 0, nil, fmt.Errorf("QR chunk %d: malformed data: %w", index, err)
+	}
+	if fmt.Sprintf("%08x", crc32.ChecksumIEEE(data)) != fields[3] {
+		return "", 0, 0, nil, fmt.Errorf("QR chunk %d: checksum mismatch", index)
+	}
+	return fields[1], index, total, data, nil
+}
+
+// qrProofID identifies an encoded proof across its chunks
+func qrProofID(encoded []byte) string {
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:8])
+}
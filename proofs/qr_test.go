@@ -0,0 +1,70 @@
+package proofs
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitQRChunks(t *testing.T) {
+	proofData := &ProofData{
+		Proof:          bytes.Repeat([]byte{0xab}, 256),
+		VerifyingKey:   bytes.Repeat([]byte{0xcd}, 512),
+		PublicWitness:  bytes.Repeat([]byte{0xef}, 64),
+		ProofType:      "actn3",
+		CircuitID:      "genotype_claim",
+		CircuitVersion: 2,
+		CreatedAt:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	chunks, err := SplitQRChunks(proofData, 100)
+	if err != nil {
+		t.Fatalf("SplitQRChunks should not return error: %v", err)
+	}
+	if len(chunks) < 4 {
+		t.Fatalf("Expected the proof to span several chunks, got %d", len(chunks))
+	}
+	for _, chunk := range chunks {
+		if !strings.HasPrefix(chunk, "ZKGQR:") {
+			t.Errorf("Expected chunk to start with ZKGQR:, got %q", chunk)
+		}
+	}
+
+	// Chunks scan in any order and repeat
+	scanned := append(slices.Clone(chunks), chunks[0])
+	slices.Reverse(scanned)
+	joined, err := JoinQRChunks(scanned)
+	if err != nil {
+		t.Fatalf("JoinQRChunks should not return error: %v", err)
+	}
+	want := *proofData
+	want.VerifyingKey = nil
+	if !reflect.DeepEqual(joined, &want) {
+		t.Errorf("Expected the proof without its verifying key, got %+v", joined)
+	}
+
+	if _, err := JoinQRChunks(chunks[1:]); err == nil || !strings.Contains(err.Error(), "missing QR chunks 1 ") {
+		t.Errorf("Expected a missing chunk to be reported, got %v", err)
+	}
+
+	corrupted := slices.Clone(chunks)
+	misread := []byte(corrupted[1])
+	misread[len(misread)-2] ^= 0x01
+	corrupted[1] = string(misread)
+	if _, err := JoinQRChunks(corrupted); err == nil {
+		t.Error("Expected a misread chunk to fail its checksum")
+	}
+
+	other := *proofData
+	other.ProofType = "aldh2"
+	otherChunks, err := SplitQRChunks(&other, 100)
+	if err != nil {
+		t.Fatalf("SplitQRChunks should not return error: %v", err)
+	}
+	if _, err := JoinQRChunks(append(chunks[:1:1], otherChunks[1:]...)); err == nil {
+		t.Error("Expected chunks of different proofs to be refused")
+	}
+}
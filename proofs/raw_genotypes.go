@@ -0,0 +1,317 @@
+package proofs
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// InputFormat names a genome file format
+type InputFormat string
+
+// Genome file formats. Consumer genotyping services export raw data as
+// tab-separated rsID, chromosome, position and genotype lines: 23andMe with
+// the genotype as one field ("AG"), AncestryDNA as two allele fields.
+const (
+	InputVCF         InputFormat = "vcf"
+	Input23andMe     InputFormat = "23andme"
+	InputAncestryDNA InputFormat = "ancestrydna"
+)
+
+// ParseInputFormat returns the input format named name
+func ParseInputFormat(name string) (InputFormat, error) {
+	switch format := InputFormat(strings.ToLower(name)); format {
+	case InputVCF, Input23andMe, InputAncestryDNA:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown input format %q (supported: vcf, 23andme, ancestrydna)", name)
+}
+
+// DetectInputFormat reads the start of the genome file at path, which may be
+// compressed, and returns its format
+func DetectInputFormat(path string) (InputFormat, error) {
+	f, err := openRawGenotypes(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return detectInputFormat(bufio.NewReader(f))
+}
+
+// detectInputFormat tells formats apart by their first lines: the VCF
+// ##fileformat line, the comments and column headers DTC exports begin
+// with, or failing those the number of columns of the first record
+func detectInputFormat(r *bufio.Reader) (InputFormat, error) {
+	for {
+		line, err := r.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		lower := strings.ToLower(line)
+		switch {
+		case strings.HasPrefix(line, "##fileformat=VCF"), strings.HasPrefix(line, "#CHROM\t"):
+			return InputVCF, nil
+		case strings.Contains(lower, "23andme"):
+			return Input23andMe, nil
+		case strings.Contains(lower, "ancestrydna"), strings.HasPrefix(lower, "rsid\tchromosome\tposition\tallele1"):
+			return InputAncestryDNA, nil
+		case line != "" && !strings.HasPrefix(line, "#"):
+			switch len(strings.Split(line, "\t")) {
+			case 4:
+				return Input23andMe, nil
+			case 5:
+				return InputAncestryDNA, nil
+			}
+			return "", fmt.Errorf("%w: unrecognized genome file format", ErrMalformedVCF)
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: genome file has no records", ErrMalformedVCF)
+		}
+	}
+}
+
+// OpenGenomeSource opens the genome file at path as a GenomeSource,
+// detecting whether it is a VCF or DTC raw data. Scans of VCFs report to
+// progress if non-nil.
+func OpenGenomeSource(path string, progress ProgressReporter) (GenomeSource, error) {
+	format, err := DetectInputFormat(path)
+	if err != nil {
+		return nil, err
+	}
+	if format == InputVCF {
+		return NewVCFSource(path, progress)
+	}
+	return NewRawGenotypeSource(path, format)
+}
+
+// RawGenotypeSource reads variants from the raw data export of a consumer
+// genotyping service. Such exports name the bases called at each site but
+// not the reference allele, so their calls carry the reference "N" and the
+// called bases as ALT alleles, and are marked ArrayCall. The export is read
+// into memory on opening; the files hold well under a million sites.
+type RawGenotypeSource struct {
+	format  InputFormat
+	build   traits.GenomeBuild
+	calls   []*VariantCall
+	byPos   map[uint64][]int
+	samples []string
+}
+
+// NewRawGenotypeSource reads the raw data export at path in format, which
+// may be zipped as downloaded or gzip-compressed. Coordinates are taken to be
+// GRCh37, which both services use, unless the comments name GRCh38.
+func NewRawGenotypeSource(path string, format InputFormat) (*RawGenotypeSource, error) {
+	if format != Input23andMe && format != InputAncestryDNA {
+		return nil, fmt.Errorf("%s is not a raw genotype format", format)
+	}
+	f, err := openRawGenotypes(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	source := &RawGenotypeSource{
+		format:  format,
+		build:   traits.BuildGRCh37,
+		byPos:   make(map[uint64][]int),
+		samples: []string{string(format)},
+	}
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			lower := strings.ToLower(line)
+			if strings.Contains(lower, "build 38") || strings.Contains(lower, "grch38") {
+				source.build = traits.BuildGRCh38
+			}
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(line), "rsid\t") {
+			continue
+		}
+		call, err := source.parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrMalformedVCF, lineNum, err)
+		}
+		source.byPos[call.Position] = append(source.byPos[call.Position], len(source.calls))
+		source.calls = append(source.calls, call)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return source, nil
+}
+
+// parseLine parses one record of the export
+func (s *RawGenotypeSource) parseLine(line string) (*VariantCall, error) {
+	fields := strings.Split(line, "\t")
+	columns := 4
+	if s.format == InputAncestryDNA {
+		columns = 5
+	}
+	if len(fields) != columns {
+		return nil, fmt.Errorf("expected %d columns, found %d", columns, len(fields))
+	}
+	// 23andMe calls both bases in one field, "AG"
+	bases := fields[3:]
+	if s.format == Input23andMe {
+		bases = strings.Split(fields[3], "")
+	}
+	pos, err := strconv.ParseUint(fields[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid position %q", fields[2])
+	}
+
+	call := &VariantCall{
+		Chromosome: rawChromosome(fields[1]),
+		Position:   pos,
+		ID:         fields[0],
+		Reference:  "N",
+		Filter:     ".",
+		ArrayCall:  true,
+	}
+	gt := make([]int, len(bases))
+	for i, base := range bases {
+		base = strings.ToUpper(base)
+		// 23andMe marks no-calls "--", AncestryDNA "0"
+		if base == "-" || base == "0" {
+			gt[i] = -1
+			continue
+		}
+		index := slices.Index(call.Alternate, base)
+		if index < 0 {
+			call.Alternate = append(call.Alternate, base)
+			index = len(call.Alternate) - 1
+		}
+		gt[i] = index + 1
+	}
+	call.Samples = []SampleCall{{GT: gt}}
+	return call, nil
+}
+
+// rawChromosome returns the VCF name of a chromosome of a raw data export.
+// AncestryDNA numbers X 23, Y 24, the pseudoautosomal region 25 (on X) and
+// the mitochondrion 26.
+func rawChromosome(chrom string) string {
+	switch chrom {
+	case "23", "25", "XY":
+		return "X"
+	case "24":
+		return "Y"
+	case "26":
+		return "MT"
+	}
+	return normalizeChromosome(chrom)
+}
+
+func (s *RawGenotypeSource) LookupVariant(chrom string, pos uint64) ([]*VariantCall, error) {
+	var calls []*VariantCall
+	for _, i := range s.byPos[pos] {
+		if sameChromosome(chrom, s.calls[i].Chromosome) {
+			calls = append(calls, s.calls[i])
+		}
+	}
+	return calls, nil
+}
+
+func (s *RawGenotypeSource) IterateRegion(chrom string, start uint64, end uint64, fn func(*VariantCall) bool) error {
+	for _, call := range s.calls {
+		if call.Position < start || call.Position > end || !sameChromosome(chrom, call.Chromosome) {
+			continue
+		}
+		if !fn(call) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *RawGenotypeSource) SampleNames() []string {
+	return s.samples
+}
+
+func (s *RawGenotypeSource) Build() traits.GenomeBuild {
+	return s.build
+}
+
+// openRawGenotypes opens a genome file for reading its text. Besides the
+// compression OpenVCFFile undoes, it reads the first text file of zip
+// archives, the form raw data exports are downloaded in.
+func openRawGenotypes(filePath string) (io.ReadCloser, error) {
+	f, err := openGenomeFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	magic := make([]byte, 4)
+	n, _ := io.ReadFull(f, magic)
+	if !bytes.Equal(magic[:n], []byte("PK\x03\x04")) {
+		f.Close()
+		return OpenVCFFile(filePath)
+	}
+
+	var archiveData io.ReaderAt = f
+	size := f.Size()
+	if _, encrypted := f.(*decryptedFile); encrypted {
+		// The size of the plaintext is not known until it is read, so the
+		// archive is held in memory, which DTC exports fit in
+		_, err := f.Seek(0, io.SeekStart)
+		var data []byte
+		if err == nil {
+			data, err = io.ReadAll(f)
+		}
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		archiveData, size = bytes.NewReader(data), int64(len(data))
+	}
+	reader, err := zip.NewReader(archiveData, size)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%w: %v", ErrMalformedVCF, err)
+	}
+	archive := &zipArchive{Reader: reader, file: f}
+	for _, entry := range archive.File {
+		if entry.FileInfo().IsDir() || strings.HasPrefix(path.Base(entry.Name), ".") {
+			continue
+		}
+		r, err := entry.Open()
+		if err != nil {
+			archive.Close()
+			return nil, fmt.Errorf("%w: %v", ErrMalformedVCF, err)
+		}
+		return &zipEntry{ReadCloser: r, archive: archive}, nil
+	}
+	archive.Close()
+	return nil, fmt.Errorf("%w: zip archive holds no genome file", ErrMalformedVCF)
+}
+
+// zipArchive is an open zip archive, closing its file with it
+type zipArchive struct {
+	*zip.Reader
+	file genomeFile
+}
+
+func (a *zipArchive) Close() error {
+	return a.file.Close()
+}
+
+// zipEntry is an open file of a zip archive, closing the archive with it
+type zipEntry struct {
+	io.ReadCloser
+	archive *zipArchive
+}
+
+func (e *zipEntry) Close() error {
+	e.ReadCloser.Close()
+	return e.archive.Close()
+}
@@ -0,0 +1,117 @@
+package proofs
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+const rawGenotypes23andMe = `# This data file generated by 23andMe at: Thu Jan 01 00:00:00 2026
+#
+# More information on reference human assembly build 37 (a.k.a. GRCh37):
+# rsid	chromosome	position	genotype
+rs671	12	112241766	GA
+rs12913832	15	28365618	GG
+rs1815739	11	66328095	--
+i3003626	3	46414943	DI
+rs2032652	Y	14850327	C
+`
+
+// writeZippedGenotypes writes content into a zip archive, as raw data exports
+// are downloaded
+func writeZippedGenotypes(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "genome.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	archive := zip.NewWriter(f)
+	w, err := archive.Create("genome_Full_20260101.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(content))
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRawGenotypeSource(t *testing.T) {
+	ancestryPath := writeTestVCF(t, "#AncestryDNA raw data download\n"+
+		"rsid\tchromosome\tposition\tallele1\tallele2\n"+
+		"rs671\t12\t112241766\tA\tA\n"+
+		"rs5743618\t23\t100\t0\t0\n")
+	zippedPath := writeZippedGenotypes(t, rawGenotypes23andMe)
+	vcfPath := writeTestVCF(t, genomeSourceTestVCF)
+
+	for path, want := range map[string]InputFormat{zippedPath: Input23andMe, ancestryPath: InputAncestryDNA, vcfPath: InputVCF} {
+		if format, err := DetectInputFormat(path); err != nil || format != want {
+			t.Errorf("Expected %s to be detected, got %s, %v", want, format, err)
+		}
+	}
+
+	source, err := OpenGenomeSource(zippedPath, nil)
+	if err != nil {
+		t.Fatalf("OpenGenomeSource failed: %v", err)
+	}
+	if source.Build() != traits.BuildGRCh37 {
+		t.Errorf("Expected raw data to be GRCh37, got %q", source.Build())
+	}
+
+	// Calls name the bases; matching resolves them against the alleles
+	for _, tt := range []struct {
+		variant traits.TraitVariant
+		gt      []int
+	}{
+		{traits.ALDH2Variant, []int{0, 1}},
+		{traits.RsIDTable["rs12913832"], []int{1, 1}},
+		{traits.ACTN3Variant, []int{-1, -1}},
+		{traits.CCR5Delta32Variant, []int{1, 0}},
+	} {
+		calls, err := lookupAlleles(source, "", uint64(tt.variant.Position), tt.variant.Ref, tt.variant.Alt)
+		if err != nil || len(calls) != 1 {
+			t.Fatalf("%s: expected one call, got %v, %v", tt.variant.Trait, calls, err)
+		}
+		call := calls[0]
+		if call.Reference != tt.variant.Ref || call.Alternate[0] != tt.variant.Alt {
+			t.Errorf("%s: expected the call in terms of its alleles, got %s/%v", tt.variant.Trait, call.Reference, call.Alternate)
+		}
+		if gt := call.Samples[0].GT; len(gt) != 2 || gt[0] != tt.gt[0] || gt[1] != tt.gt[1] {
+			t.Errorf("%s: expected GT %v, got %v", tt.variant.Trait, tt.gt, gt)
+		}
+	}
+
+	ancestry, err := OpenGenomeSource(ancestryPath, nil)
+	if err != nil {
+		t.Fatalf("OpenGenomeSource failed: %v", err)
+	}
+	if calls, _ := ancestry.LookupVariant("X", 100); len(calls) != 1 || calls[0].Samples[0].GT[0] != -1 {
+		t.Errorf("Expected AncestryDNA chromosome 23 to be X, with a no-call, got %+v", calls)
+	}
+
+	// rsIDs of raw data resolve to their position with the table's alleles
+	locus, err := ResolveRsID(source, "rs12913832", true)
+	if err != nil || locus.FromTable || locus.Reference != "A" || locus.Alternate != "G" {
+		t.Errorf("Expected rs12913832 resolved from the raw data with table alleles, got %+v, %v", locus, err)
+	}
+	if _, err := ResolveRsID(source, "rs2032652", true); err == nil {
+		t.Error("Expected an rsID without table alleles to be refused")
+	}
+
+	// Trait proofs read raw data like a VCF
+	proof := &ALDH2Proof{}
+	proofData, err := proof.Generate(zippedPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate from raw data should not return error: %v", err)
+	}
+	if result, err := proof.VerifyProofData(proofData); err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected the proof from raw data to verify, got %v, %v", result, err)
+	}
+}
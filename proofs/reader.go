@@ -0,0 +1,110 @@
+package proofs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SpoolVCF copies vcf to a temporary file readable only by the current user,
+// since proof types scan their VCF more than once. The returned cleanup
+// removes the file.
+func SpoolVCF(vcf io.Reader) (string, func(), error) {
+	f, err := os.CreateTemp("", "zkgenomics-*.vcf")
+	if err != nil {
+		return "", nil, fmt.Errorf("spooling VCF: %w", err)
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+
+	if _, err := io.Copy(f, vcf); err != nil {
+		f.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("spooling VCF: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("spooling VCF: %w", err)
+	}
+	return f.Name(), cleanup, nil
+}
+
+// GenerateFromReader generates proof from a VCF read from vcf, such as an
+// in-memory genome or a network stream. The VCF is spooled to a private
+// temporary file for the duration of the call.
+func GenerateFromReader(proof Proof, vcf io.Reader) (*ProofData, error) {
+	return GenerateFromReaderContext(context.Background(), proof, vcf)
+}
+
+// GenerateFromReaderContext is GenerateFromReader with cancellation, as for
+// GenerateContext. The spooled file is removed once generation finishes, even
+// after ctx is done.
+func GenerateFromReaderContext(ctx context.Context, proof Proof, vcf io.Reader) (*ProofData, error) {
+	proofData, err := runContext(ctx, func() (*ProofData, error) {
+		vcfPath, cleanup, err := SpoolVCF(vcf)
+		if err != nil {
+			return failedProofData(), err
+		}
+		defer cleanup()
+
+		return generateWithRandomness(nil, func() (*ProofData, error) {
+			return proof.Generate(vcfPath, "", "")
+		})
+	})
+	return recordFailure(proofData, err), err
+}
+
+// SimulateFromReader simulates proof like Simulate, reading the VCF from vcf
+func SimulateFromReader(proof Proof, vcf io.Reader) (*Simulation, error) {
+	vcfPath, cleanup, err := SpoolVCF(vcf)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	return Simulate(proof, vcfPath)
+}
+
+// VerifyFromReaders verifies a JSON-encoded ProofData read from proof. A
+// non-nil verifyingKey replaces the verifying key bundled in the proof.
+func VerifyFromReaders(proof Proof, verifyingKey io.Reader, proofData io.Reader) (*VerificationResult, error) {
+	data, err := DecodeProofData(proofData)
+	if err != nil {
+		return nil, err
+	}
+
+	if verifyingKey != nil {
+		vkBytes, err := io.ReadAll(verifyingKey)
+		if err != nil {
+			return nil, fmt.Errorf("reading verifying key: %w", err)
+		}
+		data.VerifyingKey = vkBytes
+	}
+
+	return proof.VerifyProofData(data)
+}
+
+// DecodeProofData reads a JSON-, CBOR- or armor-encoded ProofData from r
+func DecodeProofData(r io.Reader) (*ProofData, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading proof file: %w", err)
+	}
+
+	if isArmored(data) {
+		return DecodeArmor(data)
+	}
+
+	var proofData ProofData
+	if !isJSON(data) {
+		if err := decodeCBOR(data, &proofData, "proof file"); err != nil {
+			return nil, err
+		}
+		return &proofData, nil
+	}
+	if err := json.Unmarshal(data, &proofData); err != nil {
+		return nil, fmt.Errorf("decoding proof file: %w", err)
+	}
+	return &proofData, nil
+}
@@ -0,0 +1,47 @@
+package proofs
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerateFromReader(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+
+	vcf := strings.NewReader(`##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+11	66328095	rs1815739	C	T	60	PASS	.	GT	1/1
+`)
+
+	proofData, err := GenerateFromReader(&ACTN3Proof{}, vcf)
+	if err != nil {
+		t.Fatalf("GenerateFromReader should not return error: %v", err)
+	}
+	if entries, _ := os.ReadDir(tempDir); len(entries) != 0 {
+		t.Errorf("Expected the spooled VCF to be removed, found %d files", len(entries))
+	}
+
+	encoded, err := json.Marshal(proofData)
+	if err != nil {
+		t.Fatalf("Failed to encode proof data: %v", err)
+	}
+	result, err := VerifyFromReaders(&ACTN3Proof{}, nil, bytes.NewReader(encoded))
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %v: %v", result, err)
+	}
+
+	// A supplied verifying key replaces the bundled one
+	result, err = VerifyFromReaders(&ACTN3Proof{}, strings.NewReader("not a key"), bytes.NewReader(encoded))
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected ProofFail with a bad verifying key, got %v: %v", result, err)
+	}
+
+	if _, err := VerifyFromReaders(&ACTN3Proof{}, nil, strings.NewReader("{")); err == nil {
+		t.Error("Expected malformed proof JSON to be refused")
+	}
+}
@@ -0,0 +1,323 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bls12377 "github.com/consensys/gnark/backend/groth16/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/math/emulated"
+	stdgroth16 "github.com/consensys/gnark/std/recursion/groth16"
+)
+
+// AggregableBackend is the backend proofs are made with to be aggregated
+// recursively: Groth16 over BLS12-377, whose proofs BW6-761 circuits verify
+// with native arithmetic
+var AggregableBackend = NewGroth16(ecc.BLS12_377)
+
+// recursionBackend proves the outer circuit of a recursive proof
+var recursionBackend = NewGroth16(ecc.BW6_761)
+
+// recursionLimbs is the number of public inputs of the outer circuit each
+// public input of an inner proof takes: BLS12-377 scalars are emulated
+var recursionLimbs = int(sw_bls12377.ScalarField{}.NbLimbs())
+
+type (
+	innerProof  = stdgroth16.Proof[sw_bls12377.G1Affine, sw_bls12377.G2Affine]
+	innerScalar = emulated.Element[sw_bls12377.ScalarField]
+)
+
+// RecursionCircuit verifies Groth16 proofs over BLS12-377 inside a circuit
+// over BW6-761, so a single proof attests to all of them. The verifying keys
+// of the inner proofs are constants of the circuit, so its keys are set up
+// for one panel of circuits; KeysHash discloses which. The public inputs of
+// the inner proofs, concatenated, are disclosed as Public.
+type RecursionCircuit struct {
+	KeysHash frontend.Variable `gnark:",public"`
+	Public   []innerScalar     `gnark:",public"`
+
+	Proofs []innerProof
+
+	// verifyingKeys are the serialized verifying keys of the inner proofs
+	verifyingKeys [][]byte
+}
+
+// NewRecursionCircuit allocates the circuit verifying one proof under each
+// of the serialized BLS12-377 Groth16 verifying keys
+func NewRecursionCircuit(verifyingKeys [][]byte) (*RecursionCircuit, error) {
+	nbPublic := 0
+	for i, vk := range verifyingKeys {
+		key, err := decodeInnerVerifyingKey(vk)
+		if err != nil {
+			return nil, fmt.Errorf("proof %d: %w", i, err)
+		}
+		nbPublic += key.NbPublicWitness()
+	}
+	return &RecursionCircuit{
+		Public:        make([]innerScalar, nbPublic),
+		Proofs:        make([]innerProof, len(verifyingKeys)),
+		verifyingKeys: verifyingKeys,
+	}, nil
+}
+
+func (c *RecursionCircuit) Define(api frontend.API) error {
+	if len(c.Proofs) != len(c.verifyingKeys) {
+		return fmt.Errorf("recursion circuit has %d proofs for %d verifying keys", len(c.Proofs), len(c.verifyingKeys))
+	}
+	keysHash, err := recursionKeysHash(c.verifyingKeys)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(c.KeysHash, keysHash)
+
+	verifier, err := stdgroth16.NewVerifier[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT](api)
+	if err != nil {
+		return fmt.Errorf("creating verifier: %w", err)
+	}
+	offset := 0
+	for i, vk := range c.verifyingKeys {
+		key, err := decodeInnerVerifyingKey(vk)
+		if err != nil {
+			return fmt.Errorf("proof %d: %w", i, err)
+		}
+		fixed, err := stdgroth16.ValueOfVerifyingKeyFixed[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT](key)
+		if err != nil {
+			return fmt.Errorf("proof %d: %w", i, err)
+		}
+
+		n := key.NbPublicWitness()
+		if offset+n > len(c.Public) {
+			return fmt.Errorf("recursion circuit has %d public inputs, too few for proof %d", len(c.Public), i)
+		}
+		witness := stdgroth16.Witness[sw_bls12377.ScalarField]{Public: c.Public[offset : offset+n]}
+		offset += n
+
+		if err := verifier.AssertProof(fixed, c.Proofs[i], witness); err != nil {
+			return fmt.Errorf("proof %d: %w", i, err)
+		}
+	}
+	if offset != len(c.Public) {
+		return fmt.Errorf("recursion circuit has %d public inputs, expected %d", len(c.Public), offset)
+	}
+	return nil
+}
+
+func (c *RecursionCircuit) PublicInputLayout() PublicInputLayout {
+	inputs := []string{"KeysHash"}
+	for i := range c.Public {
+		inputs = append(inputs, indexedInputs(fmt.Sprintf("Public_%d_Limbs", i), recursionLimbs)...)
+	}
+	return PublicInputLayout{CircuitID: "recursion", Version: 1, Inputs: inputs}
+}
+
+// decodeInnerVerifyingKey decodes the verifying key of a proof to be
+// aggregated. gnark verifies a single commitment in circuit, and only with
+// prover options these proofs are not made with, so keys of circuits with
+// commitments are refused.
+func decodeInnerVerifyingKey(vk []byte) (groth16.VerifyingKey, error) {
+	key := groth16.NewVerifyingKey(ecc.BLS12_377)
+	if _, err := key.ReadFrom(bytes.NewReader(vk)); err != nil {
+		return nil, fmt.Errorf("verifying key is not a Groth16 key over %s: %w", ecc.BLS12_377, err)
+	}
+	if len(key.(*groth16_bls12377.VerifyingKey).CommitmentKeys) > 0 {
+		return nil, fmt.Errorf("proofs of circuits with commitments cannot be aggregated")
+	}
+	return key, nil
+}
+
+// recursionKeysHash returns the SHA-256 of the fingerprints of the inner
+// verifying keys, in order
+func recursionKeysHash(verifyingKeys [][]byte) (*big.Int, error) {
+	h := sha256.New()
+	for i, vk := range verifyingKeys {
+		fingerprint, err := VKFingerprint(vk)
+		if err != nil {
+			return nil, fmt.Errorf("proof %d: %w", i, err)
+		}
+		raw, err := hex.DecodeString(fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(raw)
+	}
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
+
+// RecursiveProof is a single proof attesting that each of a panel of proofs
+// verifies. Members are the aggregated proofs without their own SNARKs, so
+// their public values can still be named and checked.
+type RecursiveProof struct {
+	// Proof is the outer proof, over BW6-761
+	Proof *ProofData `json:"proof"`
+	// Members are the aggregated proofs, in the order the outer circuit
+	// verifies them
+	Members []*ProofData `json:"members"`
+}
+
+// AggregateProofsContext proves in one recursive proof that each of members
+// verifies. Members must be made with AggregableBackend; each is verified
+// before aggregation. Of opts, only Keys, Cache, Logger and Progress are
+// used: the outer proof is always Groth16 over BW6-761. It returns ctx.Err()
+// as soon as ctx is done.
+func AggregateProofsContext(ctx context.Context, members []*ProofData, opts GenerateOptions) (*RecursiveProof, error) {
+	return runContext(ctx, func() (*RecursiveProof, error) {
+		return aggregateProofs(members, opts)
+	})
+}
+
+func aggregateProofs(members []*ProofData, opts GenerateOptions) (*RecursiveProof, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no proofs to aggregate")
+	}
+
+	verifyingKeys := make([][]byte, len(members))
+	assignment := &RecursionCircuit{Proofs: make([]innerProof, len(members))}
+	for i, member := range members {
+		if err := checkAggregable(member); err != nil {
+			return nil, fmt.Errorf("proof %d: %w", i, err)
+		}
+		if err := AggregableBackend.Verify(member.Proof, member.VerifyingKey, member.PublicWitness); err != nil {
+			return nil, fmt.Errorf("proof %d: %w", i, err)
+		}
+		verifyingKeys[i] = member.VerifyingKey
+
+		proof := groth16.NewProof(ecc.BLS12_377)
+		if _, err := proof.ReadFrom(bytes.NewReader(member.Proof)); err != nil {
+			return nil, fmt.Errorf("proof %d: failed to deserialize proof: %w", i, err)
+		}
+		value, err := stdgroth16.ValueOfProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](proof)
+		if err != nil {
+			return nil, fmt.Errorf("proof %d: %w", i, err)
+		}
+		assignment.Proofs[i] = value
+	}
+
+	circuit, err := NewRecursionCircuit(verifyingKeys)
+	if err != nil {
+		return nil, err
+	}
+	if err := assignRecursionPublic(assignment, members); err != nil {
+		return nil, err
+	}
+
+	proofData, err := proveCircuitWithKeys(opts.Logger, opts.Progress, recursionBackend, opts.Keys, opts.Cache, opts.ProverOptions, circuit, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	stripped := make([]*ProofData, len(members))
+	for i, member := range members {
+		m := *member
+		m.Proof = nil
+		m.Signatures = nil
+		stripped[i] = &m
+	}
+	return &RecursiveProof{Proof: proofData, Members: stripped}, nil
+}
+
+// checkAggregable checks that member is a proof the recursion circuit can
+// verify, and that its public inputs are those of its circuit
+func checkAggregable(member *ProofData) error {
+	if member.Backend != "" && member.Backend != BackendGroth16 {
+		return fmt.Errorf("%s proofs cannot be aggregated; make them with Groth16 over %s", member.Backend, ecc.BLS12_377)
+	}
+	if curve, err := CurveNamed(member.Curve); err != nil || curve != ecc.BLS12_377 {
+		return fmt.Errorf("proof is over %s; aggregated proofs must be over %s", curveOrDefault(curve), ecc.BLS12_377)
+	}
+	if err := checkCompatibility(member); err != nil {
+		return err
+	}
+	publicWitness, err := decodePublicWitness(ecc.BLS12_377, member.PublicWitness)
+	if err != nil {
+		return err
+	}
+	return checkBinding(member, ecc.BLS12_377, publicWitness)
+}
+
+// assignRecursionPublic assigns the public inputs of the recursion circuit
+// attesting to members
+func assignRecursionPublic(assignment *RecursionCircuit, members []*ProofData) error {
+	verifyingKeys := make([][]byte, len(members))
+	assignment.Public = nil
+	for i, member := range members {
+		verifyingKeys[i] = member.VerifyingKey
+		publicWitness, err := decodePublicWitness(ecc.BLS12_377, member.PublicWitness)
+		if err != nil {
+			return fmt.Errorf("proof %d: %w", i, err)
+		}
+		witness, err := stdgroth16.ValueOfWitness[sw_bls12377.ScalarField](publicWitness)
+		if err != nil {
+			return fmt.Errorf("proof %d: %w", i, err)
+		}
+		assignment.Public = append(assignment.Public, witness.Public...)
+	}
+
+	keysHash, err := recursionKeysHash(verifyingKeys)
+	if err != nil {
+		return err
+	}
+	assignment.KeysHash = keysHash
+	return nil
+}
+
+// VerifyRecursiveProof checks the outer proof of rp, and that it attests to
+// the verifying keys and public values of its members. The outer verifying
+// key fixes the keys of the members, so trusting it trusts them. Public
+// values of the members are reported as Members_<i>_<name>. Messages go to
+// logger, which may be nil.
+func VerifyRecursiveProof(logger Logger, rp *RecursiveProof) (*VerificationResult, error) {
+	if rp == nil || rp.Proof == nil || len(rp.Members) == 0 {
+		return &VerificationResult{Result: ProofFail, Error: fmt.Errorf("invalid recursive proof: missing proof or members")}, nil
+	}
+	if rp.Proof.CircuitID != "recursion" {
+		return &VerificationResult{Result: ProofFail, Error: fmt.Errorf("proof was produced by circuit %q, not a recursion circuit", rp.Proof.CircuitID)}, nil
+	}
+	if curve, err := CurveNamed(rp.Proof.Curve); err != nil || curve != ecc.BW6_761 || (rp.Proof.Backend != "" && rp.Proof.Backend != BackendGroth16) {
+		return &VerificationResult{Result: ProofFail, Error: fmt.Errorf("recursive proofs are made with Groth16 over %s", ecc.BW6_761)}, nil
+	}
+
+	for i, member := range rp.Members {
+		if err := checkAggregable(member); err != nil {
+			return &VerificationResult{Result: ProofFail, Error: fmt.Errorf("proof %d: %w", i, err)}, nil
+		}
+	}
+	assignment := &RecursionCircuit{}
+	if err := assignRecursionPublic(assignment, rp.Members); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	expected, err := frontend.NewWitness(assignment, ecc.BW6_761.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		return &VerificationResult{Result: ProofFail, Error: fmt.Errorf("public witness error: %w", err)}, nil
+	}
+	expectedBytes, err := expected.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("serializing public witness: %w", err)
+	}
+	if !bytes.Equal(expectedBytes, rp.Proof.PublicWitness) {
+		return &VerificationResult{Result: ProofFail, Error: fmt.Errorf("recursive proof does not attest to the keys and public values of its members")}, nil
+	}
+
+	result, err := verifySNARK(logger, "recursive", rp.Proof)
+	if err != nil || result.Result != ProofSuccess {
+		return result, err
+	}
+
+	result.ParsedPublicInputs = map[string]string{}
+	for i, member := range rp.Members {
+		values, err := PublicValues(member)
+		if err != nil {
+			continue
+		}
+		for _, value := range values {
+			result.ParsedPublicInputs[fmt.Sprintf("Members_%d_%s", i, value.Name)] = value.Value
+		}
+	}
+	return result, nil
+}
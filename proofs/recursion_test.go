@@ -0,0 +1,81 @@
+package proofs
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRecursion_AggregateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+11	66328095	rs1815739	C	T	60	PASS	.	GT	0/1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	opts := GenerateOptions{Backend: AggregableBackend}
+
+	var members []*ProofData
+	for _, proof := range []Proof{&ALDH2Proof{}, &ACTN3Proof{}} {
+		proofData, err := GenerateWithOptionsContext(context.Background(), proof, vcfPath, opts)
+		if err != nil {
+			t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+		}
+		members = append(members, proofData)
+	}
+
+	// Setting up the outer circuit is slow, so a single proof is aggregated
+	// and the other only used to tamper with it
+	rp, err := AggregateProofsContext(context.Background(), members[:1], GenerateOptions{})
+	if err != nil {
+		t.Fatalf("AggregateProofsContext should not return error: %v", err)
+	}
+	if rp.Proof.CircuitID != "recursion" || rp.Proof.Curve != "bw6_761" {
+		t.Errorf("Expected a recursion proof over bw6_761, got %s over %s", rp.Proof.CircuitID, rp.Proof.Curve)
+	}
+	if len(rp.Members) != 1 || rp.Members[0].Proof != nil {
+		t.Errorf("Expected one member carrying no proof of its own, got %d", len(rp.Members))
+	}
+
+	result, err := VerifyRecursiveProof(nil, rp)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Recursive proof should verify, got %v, %v", result, err)
+	}
+	locusHash, err := LocusHashOn(AggregableBackend.Curve(), 12, 112241766, "G", "A")
+	if err != nil {
+		t.Fatalf("LocusHashOn should not return error: %v", err)
+	}
+	if got := result.ParsedPublicInputs["Members_0_LocusHash"]; got != locusHash.String() {
+		t.Errorf("Expected the first member's LocusHash %s, got %q", locusHash, got)
+	}
+
+	// Members can have neither their public values nor their key changed
+	for name, tamper := range map[string]func(member *ProofData){
+		"public values": func(member *ProofData) { member.PublicWitness = members[1].PublicWitness },
+		"verifying key": func(member *ProofData) { member.VerifyingKey = members[1].VerifyingKey },
+	} {
+		member := *rp.Members[0]
+		tamper(&member)
+		tampered := &RecursiveProof{Proof: rp.Proof, Members: []*ProofData{&member}}
+		if result, _ := VerifyRecursiveProof(nil, tampered); result.Result == ProofSuccess {
+			t.Errorf("Expected a member with changed %s to fail verification", name)
+		}
+	}
+}
+
+func TestRecursion_RefusesOtherCurves(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+	proofData, err := GenerateWithOptionsContext(context.Background(), &ALDH2Proof{}, vcfPath, GenerateOptions{})
+	if err != nil {
+		t.Fatalf("GenerateWithOptionsContext should not return error: %v", err)
+	}
+	if _, err := AggregateProofsContext(context.Background(), []*ProofData{proofData}, GenerateOptions{}); err == nil {
+		t.Error("Expected a proof over bn254 to be refused for aggregation")
+	}
+	if _, err := AggregateProofsContext(context.Background(), nil, GenerateOptions{}); err == nil {
+		t.Error("Expected aggregating no proofs to fail")
+	}
+}
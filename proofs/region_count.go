@@ -0,0 +1,197 @@
+package proofs
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// DefaultRegionCountSlots is the number of variant slots in region-count
+// circuits unless configured otherwise
+const DefaultRegionCountSlots = 16
+
+// RegionCountCircuit proves that at least Threshold distinct variant
+// positions within the public region carry an ALT allele. The counted
+// positions are private; each used slot holds one position, used slots come
+// first and their positions strictly increase, so no variant is counted twice.
+// Chromosome is constrained to a traits.ChromosomeCode, which binds it to the
+// proof.
+type RegionCountCircuit struct {
+	Threshold   frontend.Variable `gnark:",public"`
+	Chromosome  frontend.Variable `gnark:",public"`
+	RegionStart frontend.Variable `gnark:",public"`
+	RegionEnd   frontend.Variable `gnark:",public"`
+
+	Positions []frontend.Variable
+	Used      []frontend.Variable
+}
+
+// NewRegionCountCircuit allocates a region-count circuit with n slots
+func NewRegionCountCircuit(n int) *RegionCountCircuit {
+	return &RegionCountCircuit{
+		Positions: make([]frontend.Variable, n),
+		Used:      make([]frontend.Variable, n),
+	}
+}
+
+func (c *RegionCountCircuit) Define(api frontend.API) error {
+	n := len(c.Positions)
+	if len(c.Used) != n {
+		return fmt.Errorf("region count circuit slices must have equal length")
+	}
+
+	api.AssertIsDifferent(c.Chromosome, 0)
+	api.AssertIsLessOrEqual(c.Chromosome, traits.ChromosomeMT)
+	api.ToBinary(c.RegionStart, burdenPositionBits)
+	api.ToBinary(c.RegionEnd, burdenPositionBits)
+
+	var count frontend.Variable = 0
+	for i := range c.Positions {
+		used := c.Used[i]
+		api.AssertIsBoolean(used)
+		api.ToBinary(c.Positions[i], burdenPositionBits)
+
+		// Used positions lie within the region; unused slots are not checked
+		api.AssertIsLessOrEqual(c.RegionStart, api.Select(used, c.Positions[i], c.RegionStart))
+		api.AssertIsLessOrEqual(api.Select(used, c.Positions[i], c.RegionEnd), c.RegionEnd)
+
+		if i > 0 {
+			// Used slots form a prefix with strictly increasing positions
+			api.AssertIsLessOrEqual(used, c.Used[i-1])
+			api.AssertIsLessOrEqual(api.Mul(used, api.Add(c.Positions[i-1], 1)), api.Mul(used, c.Positions[i]))
+		}
+
+		count = api.Add(count, used)
+	}
+
+	api.AssertIsLessOrEqual(c.Threshold, count)
+
+	return nil
+}
+
+// NewRegionCountProof creates a region-count proof that at least threshold
+// variants within region of chromosome are carried
+func NewRegionCountProof(chromosome int, region traits.TraitRegion, threshold int) *RegionCountProof {
+	return &RegionCountProof{
+		Chromosome: chromosome,
+		Region:     region,
+		Threshold:  threshold,
+	}
+}
+
+// slots returns the number of slots in the circuit
+func (p *RegionCountProof) slots() int {
+	if p.Slots > 0 {
+		return p.Slots
+	}
+	return DefaultRegionCountSlots
+}
+
+// Assign collects the carried variants in the region and builds the circuit and its assignment
+func (p *RegionCountProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewRegionCountCircuit(p.slots()), assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *RegionCountProof) Circuit() (frontend.Circuit, error) {
+	return NewRegionCountCircuit(p.slots()), nil
+}
+
+func (p *RegionCountProof) assign(vcfPath string) (*RegionCountCircuit, error) {
+	slots := p.slots()
+	if p.Chromosome <= 0 {
+		return nil, fmt.Errorf("no chromosome set")
+	}
+	if p.Region.Start <= 0 || p.Region.End < p.Region.Start {
+		return nil, fmt.Errorf("invalid region %d-%d", p.Region.Start, p.Region.End)
+	}
+	if p.Threshold < 1 || p.Threshold > slots {
+		return nil, fmt.Errorf("region count threshold %d is outside 1..%d", p.Threshold, slots)
+	}
+
+	loggerOrNop(p.Logger).Infof("counting carried variants in %d:%d-%d...", p.Chromosome, p.Region.Start, p.Region.End)
+	positions, err := p.carriedPositions(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(positions) < p.Threshold {
+		return nil, fmt.Errorf("%d carried variants in the region, below the threshold of %d", len(positions), p.Threshold)
+	}
+	// Extra variants do not change an at-least claim
+	if len(positions) > slots {
+		positions = positions[:slots]
+	}
+
+	assignment := NewRegionCountCircuit(slots)
+	assignment.Threshold = p.Threshold
+	assignment.Chromosome = p.Chromosome
+	assignment.RegionStart = p.Region.Start
+	assignment.RegionEnd = p.Region.End
+	for i := range assignment.Positions {
+		assignment.Positions[i] = 0
+		assignment.Used[i] = 0
+		if i < len(positions) {
+			assignment.Positions[i] = positions[i]
+			assignment.Used[i] = 1
+		}
+	}
+	return assignment, nil
+}
+
+// carriedPositions returns the distinct positions in the region at which the
+// first sample carries an ALT allele, in increasing order
+func (p *RegionCountProof) carriedPositions(vcfPath string) ([]uint64, error) {
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []uint64
+	err = source.IterateRegion(strconv.Itoa(p.Chromosome), uint64(p.Region.Start), uint64(p.Region.End), func(call *VariantCall) bool {
+		if len(call.Samples) == 0 {
+			return true
+		}
+		if slices.ContainsFunc(call.Samples[0].GT, func(allele int) bool { return allele > 0 }) {
+			positions = append(positions, call.Position)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(positions)
+	return slices.Compact(positions), nil
+}
+
+func (p *RegionCountProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewRegionCountCircuit(p.slots()), assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Region count proof successfully generated: at least %d variants carried in %d:%d-%d",
+		p.Threshold, p.Chromosome, p.Region.Start, p.Region.End)
+
+	return proofData, nil
+}
+
+func (p *RegionCountProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "Region count", verifyingKeyPath, proofPath)
+}
+
+func (p *RegionCountProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "Region count", proofData)
+}
@@ -0,0 +1,121 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// regionCountAssignment builds a witness for the region 1000-2000 of
+// chromosome 2 with the given positions in the used slots
+func regionCountAssignment(threshold int, slots int, positions []int) *RegionCountCircuit {
+	assignment := NewRegionCountCircuit(slots)
+	assignment.Threshold = threshold
+	assignment.Chromosome = 2
+	assignment.RegionStart = 1000
+	assignment.RegionEnd = 2000
+	for i := range assignment.Positions {
+		assignment.Positions[i] = 0
+		assignment.Used[i] = 0
+		if i < len(positions) {
+			assignment.Positions[i] = positions[i]
+			assignment.Used[i] = 1
+		}
+	}
+	return assignment
+}
+
+func TestRegionCountCircuit(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		positions []int
+		solved    bool
+	}{
+		{"threshold met", 2, []int{1100, 1200}, true},
+		{"threshold exceeded", 2, []int{1000, 1500, 2000}, true},
+		{"threshold not met", 3, []int{1100, 1200}, false},
+		{"position before region", 1, []int{999}, false},
+		{"position after region", 1, []int{2001}, false},
+		{"position counted twice", 2, []int{1100, 1100}, false},
+		{"positions out of order", 2, []int{1200, 1100}, false},
+	}
+
+	for _, tc := range tests {
+		assignment := regionCountAssignment(tc.threshold, 4, tc.positions)
+		err := test.IsSolved(NewRegionCountCircuit(4), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("%s: expected circuit to be solved: %v", tc.name, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("%s: expected circuit not to be solved", tc.name)
+		}
+	}
+
+	// Used slots must come first
+	assignment := regionCountAssignment(1, 4, []int{1100, 1200})
+	assignment.Used[0] = 0
+	if err := test.IsSolved(NewRegionCountCircuit(4), assignment, ecc.BN254.ScalarField()); err == nil {
+		t.Error("Expected a gap in the used slots not to be solved")
+	}
+
+	// The chromosome must be a chromosome code
+	for _, chromosome := range []int{0, traits.ChromosomeMT + 1} {
+		assignment := regionCountAssignment(1, 4, []int{1100})
+		assignment.Chromosome = chromosome
+		if err := test.IsSolved(NewRegionCountCircuit(4), assignment, ecc.BN254.ScalarField()); err == nil {
+			t.Errorf("Expected chromosome %d not to be solved", chromosome)
+		}
+	}
+}
+
+func TestRegionCountProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+2	900	.	A	G	60	PASS	.	GT	1/1
+2	1100	.	A	G	60	PASS	.	GT	0/1
+2	1200	.	C	T	60	PASS	.	GT	0/0
+2	1300	.	G	GA	60	PASS	.	GT	1/1
+2	1300	.	G	C	60	PASS	.	GT	0/1
+2	1400	.	T	C	60	PASS	.	GT	./.
+3	1500	.	A	G	60	PASS	.	GT	1/1
+`)
+	region := traits.TraitRegion{Start: 1000, End: 2000}
+
+	proof := NewRegionCountProof(2, region, 2)
+	positions, err := proof.carriedPositions(vcfPath)
+	if err != nil {
+		t.Fatalf("carriedPositions should not return error: %v", err)
+	}
+	if len(positions) != 2 || positions[0] != 1100 || positions[1] != 1300 {
+		t.Errorf("Expected carried positions [1100 1300], got %v", positions)
+	}
+
+	proof.Slots = 4
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %v: %v", result, err)
+	}
+	expected := []PublicValue{{Name: "Threshold", Value: "2"}, {Name: "RegionStart", Value: "1000"}}
+	if err := CheckPublicValues(proofData, expected); err != nil {
+		t.Errorf("Expected the threshold and region to be public: %v", err)
+	}
+
+	// Chromosome is the second public input
+	result, err = proof.VerifyProofData(withPublicInput(t, proofData, 1, 3))
+	if err == nil && result.Result == ProofSuccess {
+		t.Error("Expected a proof with a changed chromosome to fail verification")
+	}
+
+	proof.Threshold = 3
+	if _, err := proof.assign(vcfPath); err == nil {
+		t.Error("Expected a threshold above the carried count to be refused")
+	}
+}
@@ -0,0 +1,349 @@
+package proofs
+
+import (
+	"cmp"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// genomeFile is an open genome file, on disk or behind an https:// or s3://
+// URL
+type genomeFile interface {
+	io.ReadSeekCloser
+	io.ReaderAt
+	Size() int64
+	// ModTime is the zero time if a remote file does not report it
+	ModTime() time.Time
+}
+
+// RemoteClient is the HTTP client genomes at https:// and s3:// URLs are
+// read with
+var RemoteClient = http.DefaultClient
+
+// IsRemote reports whether path is an https:// or s3:// URL rather than a
+// file path
+func IsRemote(path string) bool {
+	return strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "s3://")
+}
+
+// openStoredFile opens path, a file path or an https:// or s3:// URL, for
+// reading its bytes as stored. Remote files are read with HTTP range
+// requests, so seeking through an index transfers only the blocks read.
+// Genomes are not read over plain HTTP.
+func openStoredFile(path string) (genomeFile, error) {
+	if strings.HasPrefix(path, "http://") {
+		return nil, fmt.Errorf("%s: genomes are only read over HTTPS", path)
+	}
+	if IsRemote(path) {
+		return openRemoteFile(path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &localFile{File: f, info: info}, nil
+}
+
+// localFile is a genome file on disk
+type localFile struct {
+	*os.File
+	info os.FileInfo
+}
+
+func (f *localFile) Size() int64 {
+	return f.info.Size()
+}
+
+func (f *localFile) ModTime() time.Time {
+	return f.info.ModTime()
+}
+
+// siblingPath returns the path of the file next to path with ext appended
+// to its name, such as its index, keeping the query of URLs last
+func siblingPath(path string, ext string) string {
+	if !IsRemote(path) {
+		return path + ext
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return path + ext
+	}
+	u.Path += ext
+	u.RawPath = ""
+	return u.String()
+}
+
+// remoteFile is a genome file read over HTTPS. Sequential reads stream one
+// response from the offset reached; ReadAt requests only the bytes read.
+type remoteFile struct {
+	path    string
+	url     string
+	sign    func(*http.Request)
+	size    int64
+	modTime time.Time
+
+	offset int64
+	body   io.ReadCloser
+}
+
+func openRemoteFile(path string) (*remoteFile, error) {
+	target, sign, err := resolveRemote(path)
+	if err != nil {
+		return nil, err
+	}
+	f := &remoteFile{path: path, url: target, sign: sign}
+
+	resp, err := f.request(http.MethodHead, "")
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if err := remoteStatus(path, resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	if resp.ContentLength < 0 {
+		return nil, fmt.Errorf("%s: server does not report the file size", path)
+	}
+	f.size = resp.ContentLength
+	f.modTime, _ = http.ParseTime(resp.Header.Get("Last-Modified"))
+	return f, nil
+}
+
+func (f *remoteFile) Size() int64 {
+	return f.size
+}
+
+func (f *remoteFile) ModTime() time.Time {
+	return f.modTime
+}
+
+func (f *remoteFile) Read(p []byte) (int, error) {
+	if f.offset >= f.size {
+		return 0, io.EOF
+	}
+	if f.body == nil {
+		var resp *http.Response
+		var err error
+		if f.offset == 0 {
+			resp, err = f.request(http.MethodGet, "")
+			if err == nil {
+				err = remoteStatus(f.path, resp, http.StatusOK)
+			}
+		} else {
+			resp, err = f.request(http.MethodGet, fmt.Sprintf("bytes=%d-", f.offset))
+			if err == nil {
+				err = remoteStatus(f.path, resp, http.StatusPartialContent)
+			}
+		}
+		if err != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return 0, err
+		}
+		f.body = resp.Body
+	}
+	n, err := f.body.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *remoteFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("%s: seek before the start of the file", f.path)
+	}
+	if offset != f.offset && f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *remoteFile) ReadAt(p []byte, off int64) (int, error) {
+	if off >= f.size {
+		return 0, io.EOF
+	}
+	end := min(off+int64(len(p)), f.size)
+	resp, err := f.request(http.MethodGet, fmt.Sprintf("bytes=%d-%d", off, end-1))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := remoteStatus(f.path, resp, http.StatusPartialContent); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(resp.Body, p[:end-off])
+	if err == nil && end < off+int64(len(p)) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (f *remoteFile) Close() error {
+	if f.body != nil {
+		return f.body.Close()
+	}
+	return nil
+}
+
+func (f *remoteFile) request(method string, byteRange string) (*http.Response, error) {
+	req, err := http.NewRequest(method, f.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+	if f.sign != nil {
+		f.sign(req)
+	}
+	resp, err := RemoteClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.path, err)
+	}
+	return resp, nil
+}
+
+// remoteStatus returns an error unless resp has the expected status. A
+// missing file wraps os.ErrNotExist, as for files on disk.
+func remoteStatus(path string, resp *http.Response, expected int) error {
+	switch resp.StatusCode {
+	case expected:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("%s: %w", path, os.ErrNotExist)
+	case http.StatusOK:
+		return fmt.Errorf("%s: server does not support range requests", path)
+	}
+	return fmt.Errorf("%s: %s", path, resp.Status)
+}
+
+// resolveRemote returns the HTTPS URL of path and, for s3:// URLs with
+// credentials in the environment, the signer of its requests. s3://bucket/key
+// is read from the bucket's virtual-hosted endpoint in AWS_REGION (or
+// AWS_DEFAULT_REGION, else us-east-1), or path-style from AWS_ENDPOINT_URL_S3
+// or AWS_ENDPOINT_URL for S3-compatible stores. Requests are signed with
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN if set, and
+// are anonymous otherwise, for public buckets.
+func resolveRemote(path string) (string, func(*http.Request), error) {
+	if !strings.HasPrefix(path, "s3://") {
+		return path, nil, nil
+	}
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(path, "s3://"), "/")
+	if !ok || bucket == "" || key == "" {
+		return "", nil, fmt.Errorf("%s: expected s3://bucket/key", path)
+	}
+
+	region := cmp.Or(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), "us-east-1")
+	target := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, region, escapeS3Key(key))
+	if endpoint := cmp.Or(os.Getenv("AWS_ENDPOINT_URL_S3"), os.Getenv("AWS_ENDPOINT_URL")); endpoint != "" {
+		target = fmt.Sprintf("%s/%s/%s", strings.TrimRight(endpoint, "/"), bucket, escapeS3Key(key))
+	}
+
+	creds := s3Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return target, nil, nil
+	}
+	return target, func(req *http.Request) { signS3(req, creds, region, time.Now()) }, nil
+}
+
+// escapeS3Key escapes an object key for a URL path, keeping its slashes
+func escapeS3Key(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3Credentials are AWS credentials for signing S3 requests
+type s3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// emptyPayloadHash is the SHA-256 digest of the empty body of GET and HEAD requests
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// signS3 signs req with AWS Signature Version 4 for the s3 service,
+// covering its host, range and x-amz-* headers
+func signS3(req *http.Request, creds s3Credentials, region string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "range" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, headers[name])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, region)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := []byte("AWS4" + creds.SecretAccessKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
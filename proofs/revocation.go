@@ -0,0 +1,184 @@
+package proofs
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// RevocationChecker reports whether an issuer has revoked proofs, such as
+// proofs generated from data later found to be erroneous. Proofs are keyed by
+// the IDs RevocationIDs returns.
+type RevocationChecker interface {
+	IsRevoked(id string) (bool, error)
+}
+
+// RevokedError is reported when a proof has been revoked
+type RevokedError struct {
+	// ID is the revoked ID the proof matched
+	ID string
+}
+
+func (e *RevokedError) Error() string {
+	return fmt.Sprintf("proof has been revoked (%s)", e.ID)
+}
+
+// proofIDDomain separates proof IDs from other digests of a proof's key and
+// public inputs
+var proofIDDomain = []byte("zkgenomics-proof-id-v1")
+
+// ProofID identifies a proof for revocation by what it proves: the
+// hex-encoded SHA-256 of its verifying key's fingerprint and its public
+// inputs. Re-encoding or re-randomizing a proof changes its bytes but not its
+// ID. A proof whose key or public witness cannot be decoded is identified by
+// the SHA-256 of its proof bytes.
+func ProofID(proofData *ProofData) string {
+	id, err := statementID(proofData)
+	if err != nil {
+		return digestBytes(proofData.Proof)
+	}
+	return id
+}
+
+// statementID hashes the fingerprint of proofData's verifying key and the
+// canonical values of its public inputs
+func statementID(proofData *ProofData) (string, error) {
+	fingerprint, err := VKFingerprint(proofData.VerifyingKey)
+	if err != nil {
+		return "", err
+	}
+	curve, err := CurveNamed(proofData.Curve)
+	if err != nil {
+		return "", err
+	}
+	publicWitness, err := decodePublicWitness(curve, proofData.PublicWitness)
+	if err != nil {
+		return "", err
+	}
+	values, err := witnessValues(publicWitness)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write(proofIDDomain)
+	h.Write([]byte(fingerprint))
+	binary.Write(h, binary.BigEndian, uint64(len(values)))
+	for _, value := range values {
+		field := value.Bytes()
+		binary.Write(h, binary.BigEndian, uint64(len(field)))
+		h.Write(field)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// RevocationIDs returns the IDs a proof can be revoked by: its ProofID, the
+// SHA-256 of its proof bytes, which earlier releases used as the proof ID,
+// and, for proofs bound to a committed genome, the genome's Merkle root,
+// which revokes every proof made from that genome
+func RevocationIDs(proofData *ProofData) []string {
+	ids := []string{ProofID(proofData)}
+	if legacy := digestBytes(proofData.Proof); legacy != ids[0] {
+		ids = append(ids, legacy)
+	}
+	values, err := PublicValues(proofData)
+	if err != nil {
+		return ids
+	}
+	for _, value := range values {
+		if value.Name == "Root" {
+			ids = append(ids, value.Value)
+		}
+	}
+	return ids
+}
+
+// CheckRevocation returns a RevokedError if checker has revoked any of the
+// IDs of proofData
+func CheckRevocation(checker RevocationChecker, proofData *ProofData) error {
+	for _, id := range RevocationIDs(proofData) {
+		revoked, err := checker.IsRevoked(id)
+		if err != nil {
+			return fmt.Errorf("checking revocation: %w", err)
+		}
+		if revoked {
+			return &RevokedError{ID: id}
+		}
+	}
+	return nil
+}
+
+// MemoryRevocationList is a RevocationChecker holding revoked IDs in memory.
+// It is safe for concurrent use.
+type MemoryRevocationList struct {
+	mu  sync.RWMutex
+	ids map[string]bool
+}
+
+// NewMemoryRevocationList creates a revocation list with ids revoked
+func NewMemoryRevocationList(ids ...string) *MemoryRevocationList {
+	l := &MemoryRevocationList{ids: make(map[string]bool)}
+	for _, id := range ids {
+		l.ids[id] = true
+	}
+	return l
+}
+
+// Revoke revokes id
+func (l *MemoryRevocationList) Revoke(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ids[id] = true
+}
+
+func (l *MemoryRevocationList) IsRevoked(id string) (bool, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.ids[id], nil
+}
+
+// FileRevocationList is a RevocationChecker backed by a file of revoked IDs,
+// one per line. Blank lines and lines starting with # are ignored. The file is
+// read on every check, so revocations take effect without a restart, and a
+// missing file fails the check rather than passing it.
+type FileRevocationList struct {
+	Path string
+}
+
+// Revoke appends id to the file, creating it if needed
+func (l *FileRevocationList) Revoke(id string) error {
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, id); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (l *FileRevocationList) IsRevoked(id string) (bool, error) {
+	f, err := os.Open(l.Path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if text == id {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
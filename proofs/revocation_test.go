@@ -0,0 +1,92 @@
+package proofs
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+func TestCheckRevocation(t *testing.T) {
+	proofData := &ProofData{Proof: []byte("proof")}
+	id := ProofID(proofData)
+
+	memory := NewMemoryRevocationList()
+	if err := CheckRevocation(memory, proofData); err != nil {
+		t.Errorf("Expected an unrevoked proof to pass, got %v", err)
+	}
+	memory.Revoke(id)
+	var revoked *RevokedError
+	if err := CheckRevocation(memory, proofData); !errors.As(err, &revoked) || revoked.ID != id {
+		t.Errorf("Expected RevokedError for %s, got %v", id, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "revoked.txt")
+	file := &FileRevocationList{Path: path}
+	if err := CheckRevocation(file, proofData); err == nil {
+		t.Error("Expected a missing revocation file to fail the check")
+	}
+	if err := os.WriteFile(path, []byte("# revoked proofs\n\nother\n"), 0644); err != nil {
+		t.Fatalf("writing revocation list: %v", err)
+	}
+	if err := CheckRevocation(file, proofData); err != nil {
+		t.Errorf("Expected an unlisted proof to pass, got %v", err)
+	}
+	if err := file.Revoke(id); err != nil {
+		t.Fatalf("Revoke should not return error: %v", err)
+	}
+	if err := CheckRevocation(file, proofData); !errors.As(err, &revoked) {
+		t.Errorf("Expected RevokedError once listed, got %v", err)
+	}
+}
+
+func TestCheckRevocation_ReencodedProof(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+17	41276044	.	ACT	A	60	PASS	.	GT	0/0
+`)
+
+	proof := NewNegativeProof(negativeTestVariant)
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	list := NewMemoryRevocationList(ProofID(proofData))
+
+	// Proofs are written with compressed points; the same proof with
+	// uncompressed points must still verify and still be revoked
+	decoded := groth16.NewProof(ecc.BN254)
+	if _, err := decoded.ReadFrom(bytes.NewReader(proofData.Proof)); err != nil {
+		t.Fatal(err)
+	}
+	var raw bytes.Buffer
+	if _, err := decoded.WriteRawTo(&raw); err != nil {
+		t.Fatal(err)
+	}
+	reencoded := *proofData
+	reencoded.Proof = raw.Bytes()
+	if bytes.Equal(reencoded.Proof, proofData.Proof) {
+		t.Fatal("Expected the uncompressed encoding to differ")
+	}
+	if result, err := proof.VerifyProofData(&reencoded); err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected the re-encoded proof to verify, got %v", err)
+	}
+
+	var revoked *RevokedError
+	if err := CheckRevocation(list, &reencoded); !errors.As(err, &revoked) {
+		t.Errorf("Expected the re-encoded proof to be revoked, got %v", err)
+	}
+	if err := CheckRevocation(list, withPublicInput(t, proofData, 1, 32340300)); err != nil {
+		t.Errorf("Expected a proof of another statement not to be revoked, got %v", err)
+	}
+
+	legacy := NewMemoryRevocationList(digestBytes(proofData.Proof))
+	if err := CheckRevocation(legacy, proofData); !errors.As(err, &revoked) {
+		t.Errorf("Expected a proof revoked by its proof bytes to stay revoked, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package proofs
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// RsIDLocus is the variant an rsID resolved to
+type RsIDLocus struct {
+	RsID       string
+	Chromosome string
+	Position   uint64
+	Reference  string
+	Alternate  string
+	// FromTable is set when the locus came from traits.RsIDTable rather than
+	// the VCF ID column
+	FromTable bool
+}
+
+// ResolveRsID finds the variant named by rsID. The VCF ID column is searched
+// first; if no record carries the rsID and useTable is set, the bundled
+// traits.RsIDTable is consulted, provided the genome uses its reference build.
+// Records of genotyping array raw data name no alleles, which are then taken
+// from the table.
+func ResolveRsID(source GenomeSource, rsID string, useTable bool) (*RsIDLocus, error) {
+	if !traits.IsRsID(rsID) {
+		return nil, fmt.Errorf("%q is not an rsID", rsID)
+	}
+	rsID = strings.ToLower(rsID)
+
+	var locus *RsIDLocus
+	arrayCall := false
+	err := source.IterateRegion("", 0, math.MaxUint64, func(call *VariantCall) bool {
+		for _, id := range strings.Split(call.ID, ";") {
+			if strings.ToLower(id) == rsID {
+				locus = &RsIDLocus{
+					RsID:       rsID,
+					Chromosome: call.Chromosome,
+					Position:   call.Position,
+					Reference:  call.Reference,
+					Alternate:  firstAlternate(call),
+				}
+				arrayCall = call.ArrayCall
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	variant, ok := traits.RsIDTable[rsID]
+	if arrayCall {
+		// Genotyping arrays call bases without naming the alleles, which
+		// are taken from the table
+		if !useTable || !ok {
+			return nil, fmt.Errorf("%w: rsID %s is genotyped, but its alleles are not in the bundled table", ErrVariantNotFound, rsID)
+		}
+		locus.Reference, locus.Alternate = variant.Ref, variant.Alt
+	}
+	if locus != nil {
+		return locus, nil
+	}
+
+	if !useTable || !ok {
+		return nil, fmt.Errorf("%w: rsID %s not found in VCF ID column", ErrVariantNotFound, rsID)
+	}
+	if build := source.Build(); build != traits.BuildUnknown && build != traits.RsIDTableBuild {
+		return nil, fmt.Errorf("%w: rsID %s not found in VCF ID column, and the bundled table uses %s coordinates but the genome is %s",
+			ErrVariantNotFound, rsID, traits.RsIDTableBuild, build)
+	}
+
+	return &RsIDLocus{
+		RsID:       rsID,
+		Chromosome: strconv.Itoa(variant.Chromosome),
+		Position:   uint64(variant.Position),
+		Reference:  variant.Ref,
+		Alternate:  variant.Alt,
+		FromTable:  true,
+	}, nil
+}
+
+// NewRsIDProof creates an RsIDProof for the given rsID
+func NewRsIDProof(rsID string) *RsIDProof {
+	return &RsIDProof{RsID: rsID}
+}
+
+// dynamicProof resolves p.RsID and returns the DynamicProof for its locus
+func (p *RsIDProof) dynamicProof(vcfPath string) (*DynamicProof, error) {
+	if p.RsID == "" {
+		return nil, fmt.Errorf("no rsID set")
+	}
+
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, err
+	}
+
+	locus, err := ResolveRsID(source, p.RsID, !p.NoTable)
+	if err != nil {
+		return nil, err
+	}
+	loggerOrNop(p.Logger).Infof("Resolved %s to %s:%d", locus.RsID, locus.Chromosome, locus.Position)
+
+	proof := NewDynamicProof(locus.Position, locus.Reference, locus.Alternate)
+	proof.Mode = p.Mode
+	proof.Progress = p.Progress
+	proof.Logger = p.Logger
+	proof.Source = source
+	return proof, nil
+}
+
+func (p *RsIDProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	proof, err := p.dynamicProof(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+	return proof.Generate(vcfPath, provingKeyPath, outputPath)
+}
+
+// Assign resolves the rsID and builds the dynamic circuit and its assignment
+func (p *RsIDProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	proof, err := p.dynamicProof(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof.Assign(vcfPath)
+}
+
+func (p *RsIDProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "rsID", verifyingKeyPath, proofPath)
+}
+
+func (p *RsIDProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "rsID", proofData)
+}
@@ -0,0 +1,80 @@
+package proofs
+
+import (
+	"testing"
+)
+
+func TestResolveRsID(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	1000	rs1000;rs2000	A	G	60	PASS	.	GT	0/1
+16	48258198	.	C	T	60	PASS	.	GT	1/1
+`)
+	source, err := NewVCFSource(vcfPath, nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource should not return error: %v", err)
+	}
+
+	locus, err := ResolveRsID(source, "RS2000", true)
+	if err != nil {
+		t.Fatalf("ResolveRsID should find a secondary ID: %v", err)
+	}
+	if locus.Position != 1000 || locus.Reference != "A" || locus.Alternate != "G" || locus.FromTable {
+		t.Errorf("Expected 1:1000 A>G from the VCF, got %+v", locus)
+	}
+
+	locus, err = ResolveRsID(source, "rs17822931", true)
+	if err != nil {
+		t.Fatalf("ResolveRsID should fall back to the bundled table: %v", err)
+	}
+	if locus.Position != 48258198 || !locus.FromTable {
+		t.Errorf("Expected 16:48258198 from the table, got %+v", locus)
+	}
+
+	if _, err := ResolveRsID(source, "rs17822931", false); err == nil {
+		t.Error("ResolveRsID should not use the table when disabled")
+	}
+	if _, err := ResolveRsID(source, "17822931", true); err == nil {
+		t.Error("ResolveRsID should reject a malformed rsID")
+	}
+}
+
+func TestResolveRsID_RejectsTableForOtherBuild(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##reference=GRCh38
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+16	48224287	.	C	T	60	PASS	.	GT	1/1
+`)
+	source, err := NewVCFSource(vcfPath, nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource should not return error: %v", err)
+	}
+
+	if _, err := ResolveRsID(source, "rs17822931", true); err == nil {
+		t.Error("ResolveRsID should not apply GRCh37 table coordinates to a GRCh38 genome")
+	}
+}
+
+func TestRsIDProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+15	28365618	rs12913832	A	G	60	PASS	.	GT	1/1
+`)
+
+	proof := NewRsIDProof("rs12913832")
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
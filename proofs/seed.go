@@ -0,0 +1,131 @@
+package proofs
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// randomnessMu keeps seeded generation apart from all other generation. gnark
+// draws setup and proving randomness from the process-wide crypto/rand.Reader,
+// which seeded generation replaces while it runs.
+var randomnessMu sync.RWMutex
+
+// seededReader is a deterministic byte stream: the SHA-256 of the seed and a
+// block counter, block after block
+type seededReader struct {
+	seed    []byte
+	counter uint64
+	block   []byte
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.block) == 0 {
+			h := sha256.New()
+			h.Write(r.seed)
+			binary.Write(h, binary.BigEndian, r.counter)
+			r.counter++
+			r.block = h.Sum(nil)
+		}
+		copied := copy(p[n:], r.block)
+		r.block = r.block[copied:]
+		n += copied
+	}
+	return n, nil
+}
+
+// generateWithRandomness runs generate, drawing setup and proving randomness
+// from seed if it is set and from crypto/rand otherwise
+func generateWithRandomness(seed []byte, generate func() (*ProofData, error)) (*ProofData, error) {
+	if seed == nil {
+		randomnessMu.RLock()
+		defer randomnessMu.RUnlock()
+		return generate()
+	}
+
+	randomnessMu.Lock()
+	defer randomnessMu.Unlock()
+	saved := rand.Reader
+	rand.Reader = &seededReader{seed: seed}
+	defer func() { rand.Reader = saved }()
+
+	proofData, err := generate()
+	if proofData != nil {
+		proofData.CreatedAt = time.Time{}
+	}
+	return proofData, err
+}
+
+// GenerateSeededContext generates proof like GenerateContext, but draws the
+// randomness of key setup and proving from seed, so the same seed, genome and
+// release reproduce byte-identical keys and proof. Seeded proofs leave
+// CreatedAt unset for the same reason.
+//
+// Seeded proofs are not secure: anyone who knows the seed can forge proofs
+// that verify under the seeded key, and can recover the private inputs. Use
+// them only in tests and audits. While a seeded proof is generated,
+// crypto/rand.Reader is replaced for the whole process, and other generation
+// through this package waits for it.
+func GenerateSeededContext(ctx context.Context, proof Proof, vcfPath string, seed []byte) (*ProofData, error) {
+	if len(seed) == 0 {
+		return failedProofData(), fmt.Errorf("seeded generation requires a seed")
+	}
+	return GenerateWithOptionsContext(ctx, proof, vcfPath, GenerateOptions{Seed: seed})
+}
+
+// ReproducibilityManifest records what a seeded proof was generated from, so
+// an auditor holding the seed and inputs can regenerate it and compare
+type ReproducibilityManifest struct {
+	ProofType      string `json:"proof_type"`
+	CircuitID      string `json:"circuit_id"`
+	CircuitVersion int    `json:"circuit_version"`
+	CircuitHash    string `json:"circuit_hash"`
+	// SeedHash is the SHA-256 of the seed; the seed itself is kept out of the
+	// manifest since it lets anyone forge proofs
+	SeedHash string `json:"seed_hash"`
+	// Inputs maps each input file's role, such as "vcf", to its SHA-256
+	Inputs map[string]string `json:"inputs"`
+	// Outputs maps "proof", "verifying_key" and "public_witness" to their SHA-256
+	Outputs  map[string]string `json:"outputs"`
+	Software map[string]string `json:"software"`
+}
+
+// NewReproducibilityManifest describes proofData, generated from seed and the
+// input files in inputs, keyed by role
+func NewReproducibilityManifest(proofType string, proofData *ProofData, seed []byte, inputs map[string]string) (*ReproducibilityManifest, error) {
+	manifest := &ReproducibilityManifest{
+		ProofType:      proofType,
+		CircuitID:      proofData.CircuitID,
+		CircuitVersion: proofData.CircuitVersion,
+		CircuitHash:    proofData.CircuitHash,
+		SeedHash:       digestBytes(seed),
+		Inputs:         make(map[string]string, len(inputs)),
+		Outputs: map[string]string{
+			"proof":          digestBytes(proofData.Proof),
+			"verifying_key":  digestBytes(proofData.VerifyingKey),
+			"public_witness": digestBytes(proofData.PublicWitness),
+		},
+		Software: softwareVersions(),
+	}
+	for role, path := range inputs {
+		digest, err := DigestFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("hashing %s input: %w", role, err)
+		}
+		manifest.Inputs[role] = digest
+	}
+	return manifest, nil
+}
+
+// digestBytes returns the hex-encoded SHA-256 of data
+func digestBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
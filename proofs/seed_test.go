@@ -0,0 +1,49 @@
+package proofs
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestGenerateSeededContext_IsReproducible(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+12	112241766	rs671	G	A	60	PASS	.	GT	0/1
+`)
+
+	generate := func(seed string) *ProofData {
+		t.Helper()
+		proofData, err := GenerateSeededContext(context.Background(), &ALDH2Proof{}, vcfPath, []byte(seed))
+		if err != nil {
+			t.Fatalf("GenerateSeededContext should not return error: %v", err)
+		}
+		return proofData
+	}
+
+	first, second := generate("audit"), generate("audit")
+	if !bytes.Equal(first.VerifyingKey, second.VerifyingKey) || !bytes.Equal(first.Proof, second.Proof) {
+		t.Error("Expected the same seed to reproduce the verifying key and proof")
+	}
+	if !first.CreatedAt.IsZero() {
+		t.Errorf("Expected seeded proofs to leave CreatedAt unset, got %v", first.CreatedAt)
+	}
+	if other := generate("other"); bytes.Equal(first.Proof, other.Proof) {
+		t.Error("Expected a different seed to produce a different proof")
+	}
+
+	result, err := (&ALDH2Proof{}).VerifyProofData(first)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected the seeded proof to verify, got %v: %v", result, err)
+	}
+
+	manifest, err := NewReproducibilityManifest("aldh2", first, []byte("audit"), map[string]string{"vcf": vcfPath})
+	if err != nil {
+		t.Fatalf("NewReproducibilityManifest should not return error: %v", err)
+	}
+	digest, _ := DigestFile(vcfPath)
+	if manifest.Inputs["vcf"] != digest || manifest.Outputs["proof"] != digestBytes(first.Proof) {
+		t.Errorf("Expected the manifest to hash the inputs and outputs, got %+v", manifest)
+	}
+}
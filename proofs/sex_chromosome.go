@@ -0,0 +1,164 @@
+package proofs
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// SexChromosomeSlots is the number of distinct chromosome codes a genome can
+// contain: the autosomes, X, Y and MT
+const SexChromosomeSlots = traits.ChromosomeMT
+
+// SexChromosomeCircuit proves the XX/XY configuration from the set of
+// chromosomes with called genotypes. Every chromosome code occupies at most one
+// slot and unused slots are zero, so the circuit reveals neither the variants
+// nor how many chromosomes the VCF covers.
+type SexChromosomeCircuit struct {
+	ClaimedKaryotype frontend.Variable `gnark:",public"`
+
+	Chromosomes []frontend.Variable
+}
+
+// NewSexChromosomeCircuit returns a circuit scanning n chromosome slots
+func NewSexChromosomeCircuit(n int) *SexChromosomeCircuit {
+	return &SexChromosomeCircuit{Chromosomes: make([]frontend.Variable, n)}
+}
+
+// Define requires an X chromosome to be present and derives XY from the
+// presence of a Y chromosome
+func (c *SexChromosomeCircuit) Define(api frontend.API) error {
+	var countX, countY frontend.Variable = 0, 0
+	for _, chrom := range c.Chromosomes {
+		countX = api.Add(countX, api.IsZero(api.Sub(chrom, traits.ChromosomeX)))
+		countY = api.Add(countY, api.IsZero(api.Sub(chrom, traits.ChromosomeY)))
+	}
+
+	// Without X calls the sex chromosomes were not genotyped at all
+	api.AssertIsDifferent(countX, 0)
+
+	hasY := api.Sub(1, api.IsZero(countY))
+	api.AssertIsEqual(c.ClaimedKaryotype, api.Add(int(traits.KaryotypeXX), hasY))
+
+	return nil
+}
+
+// Assign scans the genome and builds the circuit and its assignment
+func (p *SexChromosomeProof) Assign(vcfPath string) (frontend.Circuit, frontend.Circuit, error) {
+	assignment, _, err := p.assign(vcfPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewSexChromosomeCircuit(SexChromosomeSlots), assignment, nil
+}
+
+// Circuit returns the circuit of the proof, for setting up its keys
+func (p *SexChromosomeProof) Circuit() (frontend.Circuit, error) {
+	return NewSexChromosomeCircuit(SexChromosomeSlots), nil
+}
+
+func (p *SexChromosomeProof) assign(vcfPath string) (*SexChromosomeCircuit, traits.Karyotype, error) {
+	source, err := sourceOrVCF(p.Source, vcfPath, p.Sample, p.Progress)
+	if err != nil {
+		return nil, traits.KaryotypeUnknown, err
+	}
+
+	loggerOrNop(p.Logger).Infof("scanning chromosomes...")
+	chromosomes, err := calledChromosomes(source)
+	if err != nil {
+		return nil, traits.KaryotypeUnknown, err
+	}
+	if !slices.Contains(chromosomes, traits.ChromosomeX) {
+		return nil, traits.KaryotypeUnknown, fmt.Errorf("no called genotypes on chromosome X")
+	}
+
+	karyotype := traits.KaryotypeXX
+	if slices.Contains(chromosomes, traits.ChromosomeY) {
+		karyotype = traits.KaryotypeXY
+	}
+
+	assignment := NewSexChromosomeCircuit(SexChromosomeSlots)
+	for i := range assignment.Chromosomes {
+		assignment.Chromosomes[i] = 0
+		if i < len(chromosomes) {
+			assignment.Chromosomes[i] = chromosomes[i]
+		}
+	}
+	assignment.ClaimedKaryotype = int(karyotype)
+
+	return assignment, karyotype, nil
+}
+
+// calledChromosomes returns the distinct codes of the chromosomes on which the
+// first sample has a called genotype. Calls in the Y pseudoautosomal regions
+// are ignored.
+func calledChromosomes(source GenomeSource) ([]int, error) {
+	pars := traits.YPseudoautosomalRegions(source.Build())
+
+	var chromosomes []int
+	err := source.IterateRegion("", 0, math.MaxUint64, func(call *VariantCall) bool {
+		code := traits.ChromosomeCode(call.Chromosome)
+		if code == 0 || slices.Contains(chromosomes, code) || !hasCalledGenotype(call) {
+			return true
+		}
+		if code == traits.ChromosomeY && inRegions(pars, call.Position) {
+			return true
+		}
+		chromosomes = append(chromosomes, code)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chromosomes, nil
+}
+
+// hasCalledGenotype reports whether the first sample has a non-missing allele
+func hasCalledGenotype(call *VariantCall) bool {
+	if len(call.Samples) == 0 {
+		return false
+	}
+	for _, allele := range call.Samples[0].GT {
+		if allele >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// inRegions reports whether pos falls within any of the regions
+func inRegions(regions []traits.TraitRegion, pos uint64) bool {
+	for _, region := range regions {
+		if pos >= uint64(region.Start) && pos <= uint64(region.End) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *SexChromosomeProof) Generate(vcfPath string, provingKeyPath string, outputPath string) (*ProofData, error) {
+	assignment, karyotype, err := p.assign(vcfPath)
+	if err != nil {
+		return failedProofData(), err
+	}
+
+	proofData, err := proveCircuit(p.Logger, p.Progress, NewSexChromosomeCircuit(SexChromosomeSlots), assignment)
+	if err != nil {
+		return proofData, err
+	}
+
+	loggerOrNop(p.Logger).Infof("✅ Sex chromosome proof successfully generated for %s!", karyotype)
+
+	return proofData, nil
+}
+
+func (p *SexChromosomeProof) Verify(verifyingKeyPath string, proofPath string) (*VerificationResult, error) {
+	return verifyProofFile(p.Logger, "sex chromosome", verifyingKeyPath, proofPath)
+}
+
+func (p *SexChromosomeProof) VerifyProofData(proofData *ProofData) (*VerificationResult, error) {
+	return verifySNARK(p.Logger, "sex chromosome", proofData)
+}
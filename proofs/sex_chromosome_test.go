@@ -0,0 +1,85 @@
+package proofs
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestSexChromosomeCircuit(t *testing.T) {
+	tests := []struct {
+		name        string
+		chromosomes []int
+		claimed     traits.Karyotype
+		solved      bool
+	}{
+		{"XX", []int{1, traits.ChromosomeX, 0}, traits.KaryotypeXX, true},
+		{"XY", []int{traits.ChromosomeX, 2, traits.ChromosomeY}, traits.KaryotypeXY, true},
+		{"hides Y", []int{traits.ChromosomeX, traits.ChromosomeY, 0}, traits.KaryotypeXX, false},
+		{"claims absent Y", []int{traits.ChromosomeX, 1, 0}, traits.KaryotypeXY, false},
+		{"no X", []int{1, 2, traits.ChromosomeY}, traits.KaryotypeXY, false},
+	}
+
+	for _, tc := range tests {
+		assignment := NewSexChromosomeCircuit(len(tc.chromosomes))
+		for i, chrom := range tc.chromosomes {
+			assignment.Chromosomes[i] = chrom
+		}
+		assignment.ClaimedKaryotype = int(tc.claimed)
+
+		err := test.IsSolved(NewSexChromosomeCircuit(len(tc.chromosomes)), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("%s: expected circuit to be solved: %v", tc.name, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("%s: expected circuit not to be solved", tc.name)
+		}
+	}
+}
+
+func TestSexChromosomeProof_IgnoresPseudoautosomalY(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##reference=GRCh38
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+chr1	1000	.	A	G	60	PASS	.	GT	0/1
+chrX	5000000	.	C	T	60	PASS	.	GT	1/1
+chrY	20000	.	G	A	60	PASS	.	GT	0/1
+chrY	3000000	.	T	C	60	PASS	.	GT	./.
+`)
+
+	proof := &SexChromosomeProof{}
+	_, karyotype, err := proof.assign(vcfPath)
+	if err != nil {
+		t.Fatalf("assign should not return error: %v", err)
+	}
+	if karyotype != traits.KaryotypeXX {
+		t.Errorf("Expected XX, got %s", karyotype)
+	}
+}
+
+func TestSexChromosomeProof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	1000	.	A	G	60	PASS	.	GT	0/1
+X	5000000	.	C	T	60	PASS	.	GT	1
+Y	7000000	.	G	A	60	PASS	.	GT	1
+`)
+
+	proof := &SexChromosomeProof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
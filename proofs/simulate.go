@@ -0,0 +1,90 @@
+package proofs
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// CircuitAssigner is implemented by proofs that can extract their inputs and
+// build the circuit and witness assignment without proving
+type CircuitAssigner interface {
+	Assign(vcfPath string) (circuit frontend.Circuit, assignment frontend.Circuit, err error)
+}
+
+// PublicValue is one named public input as a verifier would see it
+type PublicValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Simulation is everything a verifier would learn from a proof, computed
+// without running setup or proving
+type Simulation struct {
+	CircuitID      string        `json:"circuit_id"`
+	CircuitVersion int           `json:"circuit_version"`
+	PublicValues   []PublicValue `json:"public_values"`
+	Hints          []string      `json:"hints,omitempty"`
+}
+
+// Simulate performs extraction and claim evaluation for proof and returns the
+// public values its proof would disclose. The assignment is checked against
+// the compiled circuit, so a claim that would fail to prove fails here too.
+func Simulate(proof Proof, vcfPath string) (*Simulation, error) {
+	assigner, ok := proof.(CircuitAssigner)
+	if !ok {
+		return nil, fmt.Errorf("%T does not support simulation", proof)
+	}
+
+	circuit, assignment, err := assigner.Assign(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePublicInputLayout(circuit); err != nil {
+		return nil, err
+	}
+	layout := circuit.(LayoutCircuit).PublicInputLayout()
+
+	hints, hintNames, err := circuitHints(circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		return nil, fmt.Errorf("circuit compilation error: %w", err)
+	}
+
+	w, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, fmt.Errorf("witness creation error: %w", err)
+	}
+	if err := cs.IsSolved(w, solver.WithHints(hints...)); err != nil {
+		return nil, fmt.Errorf("claim does not hold: %w", err)
+	}
+
+	publicWitness, err := w.Public()
+	if err != nil {
+		return nil, fmt.Errorf("public witness error: %w", err)
+	}
+	values, ok := publicWitness.Vector().(fr.Vector)
+	if !ok || len(values) != len(layout.Inputs) {
+		return nil, fmt.Errorf("public witness does not match the %s layout", layout.CircuitID)
+	}
+
+	simulation := &Simulation{
+		CircuitID:      layout.CircuitID,
+		CircuitVersion: layout.Version,
+		Hints:          hintNames,
+	}
+	for i, name := range layout.Inputs {
+		simulation.PublicValues = append(simulation.PublicValues, PublicValue{Name: name, Value: values[i].String()})
+	}
+
+	return simulation, nil
+}
@@ -0,0 +1,49 @@
+package proofs
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestSimulate_ReportsPublicValues(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+1	11854476	rs1801131	T	G	60	PASS	.	GT	0/1
+1	11856378	rs1801133	G	A	60	PASS	.	GT	0/1
+`)
+
+	simulation, err := Simulate(&MTHFRProof{}, vcfPath)
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if simulation.CircuitID != "mthfr" || simulation.CircuitVersion != 1 {
+		t.Errorf("Unexpected circuit %s v%d", simulation.CircuitID, simulation.CircuitVersion)
+	}
+
+	want := strconv.Itoa(int(traits.MTHFRCompoundHeterozygous))
+	if len(simulation.PublicValues) != 1 || simulation.PublicValues[0].Name != "ClaimedStatus" || simulation.PublicValues[0].Value != want {
+		t.Errorf("Expected only ClaimedStatus = %s, got %v", want, simulation.PublicValues)
+	}
+}
+
+func TestSimulate_FailingClaim(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+2	1100	.	A	G	60	PASS	.	GT	0/1
+2	1200	.	C	T	60	PASS	.	GT	0/1
+`)
+
+	if _, err := Simulate(NewBurdenProof(testBurdenPolicy(1, false)), vcfPath); err == nil {
+		t.Errorf("Expected simulation of an exceeded burden to fail")
+	}
+}
+
+func TestSimulate_UnsupportedProof(t *testing.T) {
+	if _, err := Simulate(&BRCA1Proof{}, "unused.vcf"); err == nil {
+		t.Errorf("Expected proofs without Assign to be rejected")
+	}
+}
@@ -0,0 +1,264 @@
+package proofs
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// vcfIndex is a tabix (.tbi) or CSI (.csi) index of a bgzip-compressed VCF,
+// locating the records of a region by the virtual offsets of their BGZF
+// blocks. A virtual offset holds the file offset of a block in its upper 48
+// bits and the offset within the decompressed block in its lower 16.
+type vcfIndex struct {
+	minShift int
+	depth    int
+	// names are the indexed chromosomes in file order, and refs their
+	// indexes by normalized name
+	names []string
+	refs  map[string]*indexedRef
+}
+
+// indexedRef is the index of one chromosome
+type indexedRef struct {
+	bins map[uint32]indexBin
+	// linear holds, for each window of 1<<minShift positions, the lowest
+	// offset of a record overlapping it. Only tabix indexes have one.
+	linear []uint64
+}
+
+// indexBin is a bin of the binning scheme and the chunks of records in it
+type indexBin struct {
+	// loffset is the lowest offset of a record overlapping the bin. Only
+	// CSI indexes record it.
+	loffset uint64
+	chunks  [][2]uint64
+}
+
+// loadVCFIndex reads the index of vcfPath from vcfPath.tbi or vcfPath.csi,
+// next to it on disk or at the same URL. It returns nil if the VCF has
+// neither, or only one older than the VCF, which no longer locates its
+// records.
+func loadVCFIndex(vcfPath string, modTime time.Time) (*vcfIndex, error) {
+	for _, ext := range []string{".tbi", ".csi"} {
+		f, err := openStoredFile(siblingPath(vcfPath, ext))
+		if err != nil {
+			continue
+		}
+		if f.ModTime().Before(modTime) {
+			f.Close()
+			continue
+		}
+		data, err := readBGZF(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s index: %w", ext, err)
+		}
+		index, err := parseVCFIndex(data)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s index: %w", ext, err)
+		}
+		return index, nil
+	}
+	return nil, nil
+}
+
+// readBGZF returns the decompressed contents of a BGZF file
+func readBGZF(f io.Reader) ([]byte, error) {
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(gz)
+}
+
+// parseVCFIndex decodes a tabix or CSI index. CSI indexes of VCFs carry the
+// chromosome names in their auxiliary data; those without cannot be used.
+func parseVCFIndex(data []byte) (*vcfIndex, error) {
+	r := &indexReader{data: data}
+	magic := string(r.bytes(4))
+	index := &vcfIndex{refs: make(map[string]*indexedRef)}
+
+	var names []string
+	var nRef int
+	switch magic {
+	case "TBI\x01":
+		index.minShift, index.depth = 14, 5
+		nRef = int(r.int32())
+		names = r.tabixHeader()
+	case "CSI\x01":
+		index.minShift, index.depth = int(r.int32()), int(r.int32())
+		aux := r.bytes(int(r.int32()))
+		if len(aux) == 0 {
+			return nil, fmt.Errorf("CSI index has no chromosome names")
+		}
+		names = (&indexReader{data: aux}).tabixHeader()
+		nRef = int(r.int32())
+	default:
+		return nil, fmt.Errorf("not a tabix or CSI index")
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(names) != nRef {
+		return nil, fmt.Errorf("index names %d chromosomes but indexes %d", len(names), nRef)
+	}
+
+	for _, name := range names {
+		ref := &indexedRef{bins: make(map[uint32]indexBin)}
+		nBin := int(r.int32())
+		for b := 0; b < nBin && r.err == nil; b++ {
+			id := r.uint32()
+			var bin indexBin
+			if magic == "CSI\x01" {
+				bin.loffset = r.uint64()
+			}
+			nChunk := int(r.int32())
+			for c := 0; c < nChunk && r.err == nil; c++ {
+				bin.chunks = append(bin.chunks, [2]uint64{r.uint64(), r.uint64()})
+			}
+			ref.bins[id] = bin
+		}
+		if magic == "TBI\x01" {
+			nIntv := int(r.int32())
+			for i := 0; i < nIntv && r.err == nil; i++ {
+				ref.linear = append(ref.linear, r.uint64())
+			}
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+		index.refs[normalizeChromosome(name)] = ref
+	}
+	index.names = names
+	return index, nil
+}
+
+// offset returns the virtual offset to read from for the records of chrom
+// overlapping the 1-based positions [start, end]. It reports false if no
+// record of chrom overlaps them.
+func (x *vcfIndex) offset(chrom string, start uint64, end uint64) (uint64, bool) {
+	ref, ok := x.refs[normalizeChromosome(chrom)]
+	if !ok {
+		return 0, false
+	}
+	// Positions past the binning scheme's range are clamped to it
+	limit := uint64(1) << (x.minShift + 3*x.depth)
+	beg := min(max(start, 1)-1, limit-1)
+	end = min(end, limit)
+
+	// Records overlapping the region start no earlier than minOffset
+	var minOffset uint64
+	if len(ref.linear) > 0 {
+		window := min(int(beg>>x.minShift), len(ref.linear)-1)
+		minOffset = ref.linear[window]
+	} else {
+		for _, id := range regionBins(beg, beg+1, x.minShift, x.depth) {
+			if bin, ok := ref.bins[id]; ok {
+				minOffset = max(minOffset, bin.loffset)
+			}
+		}
+	}
+
+	found := false
+	var offset uint64
+	for _, id := range regionBins(beg, end, x.minShift, x.depth) {
+		for _, chunk := range ref.bins[id].chunks {
+			if chunk[1] <= minOffset {
+				continue
+			}
+			if !found || chunk[0] < offset {
+				offset = chunk[0]
+			}
+			found = true
+		}
+	}
+	return max(offset, minOffset), found
+}
+
+// regionBins returns the bins of the binning scheme overlapping the 0-based
+// half-open interval [beg, end)
+func regionBins(beg uint64, end uint64, minShift int, depth int) []uint32 {
+	end = max(end, beg+1) - 1
+	var bins []uint32
+	shift := minShift + depth*3
+	first := uint64(0)
+	for level := 0; level <= depth; level++ {
+		for bin := first + beg>>shift; bin <= first+end>>shift; bin++ {
+			bins = append(bins, uint32(bin))
+		}
+		first += 1 << (level * 3)
+		shift -= 3
+	}
+	return bins
+}
+
+// indexReader decodes the little-endian fields of an index, holding the
+// first error
+type indexReader struct {
+	data []byte
+	err  error
+}
+
+func (r *indexReader) bytes(n int) []byte {
+	if r.err != nil || n < 0 || n > len(r.data) {
+		r.err = fmt.Errorf("index is truncated")
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *indexReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *indexReader) int32() int32 {
+	return int32(r.uint32())
+}
+
+func (r *indexReader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// tabixHeader reads the tabix configuration (format, columns, meta
+// character and skipped lines) and returns the chromosome names following it
+func (r *indexReader) tabixHeader() []string {
+	r.bytes(6 * 4)
+	names := r.bytes(int(r.int32()))
+	var split []string
+	for _, name := range bytes.Split(bytes.TrimRight(names, "\x00"), []byte{0}) {
+		if len(name) > 0 {
+			split = append(split, string(name))
+		}
+	}
+	return split
+}
+
+// seekBGZF returns a reader of the decompressed contents of the BGZF file f
+// from virtual offset offset
+func seekBGZF(f io.ReadSeeker, offset uint64) (io.Reader, error) {
+	if _, err := f.Seek(int64(offset>>16), io.SeekStart); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(f)
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedVCF, err)
+	}
+	if _, err := io.CopyN(io.Discard, gz, int64(offset&0xffff)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedVCF, err)
+	}
+	return gz, nil
+}
@@ -0,0 +1,219 @@
+package proofs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeIndexedVCF writes records as a bgzip-compressed VCF, one BGZF block
+// per line, and indexes it as tabix would, returning the VCF's path
+func writeIndexedVCF(t *testing.T, header string, records []string) string {
+	t.Helper()
+
+	// entry is a record's 0-based interval and the virtual offsets of its
+	// block and the next
+	type entry struct {
+		beg, end   uint64
+		from, upTo uint64
+	}
+	var data bytes.Buffer
+	block := func(text string) {
+		w := gzip.NewWriter(&data)
+		w.Write([]byte(text))
+		w.Close()
+	}
+	block(header)
+
+	var names []string
+	entries := make(map[string][]entry)
+	for _, record := range records {
+		fields := strings.Split(record, "\t")
+		pos, _ := strconv.ParseUint(fields[1], 10, 64)
+		beg, end := pos-1, pos-1+uint64(len(fields[3]))
+		if _, value, ok := strings.Cut(fields[7], "END="); ok {
+			end, _ = strconv.ParseUint(value, 10, 64)
+		}
+		if _, ok := entries[fields[0]]; !ok {
+			names = append(names, fields[0])
+		}
+		start := uint64(data.Len()) << 16
+		block(record + "\n")
+		entries[fields[0]] = append(entries[fields[0]], entry{beg, end, start, uint64(data.Len()) << 16})
+	}
+	block("")
+
+	var index bytes.Buffer
+	put := func(values ...any) {
+		for _, v := range values {
+			binary.Write(&index, binary.LittleEndian, v)
+		}
+	}
+	nameBytes := []byte(strings.Join(names, "\x00") + "\x00")
+	index.WriteString("TBI\x01")
+	put(int32(len(names)), int32(2), int32(1), int32(2), int32(0), int32('#'), int32(0), int32(len(nameBytes)))
+	index.Write(nameBytes)
+	for _, name := range names {
+		bins := make(map[uint32][][2]uint64)
+		var order []uint32
+		var linear []uint64
+		for _, e := range entries[name] {
+			// A record belongs to the smallest bin holding all of it
+			var id uint32
+			for _, b := range regionBins(e.beg, e.end, 14, 5) {
+				if binContains(b, e.beg, e.end) {
+					id = b
+				}
+			}
+			if _, ok := bins[id]; !ok {
+				order = append(order, id)
+			}
+			bins[id] = append(bins[id], [2]uint64{e.from, e.upTo})
+			for w := e.beg >> 14; w <= (e.end-1)>>14; w++ {
+				for uint64(len(linear)) <= w {
+					linear = append(linear, 0)
+				}
+				if linear[w] == 0 || e.from < linear[w] {
+					linear[w] = e.from
+				}
+			}
+		}
+		for w := 1; w < len(linear); w++ {
+			if linear[w] == 0 {
+				linear[w] = linear[w-1]
+			}
+		}
+		put(int32(len(order)))
+		for _, id := range order {
+			put(id, int32(len(bins[id])))
+			for _, chunk := range bins[id] {
+				put(chunk[0], chunk[1])
+			}
+		}
+		put(int32(len(linear)))
+		for _, offset := range linear {
+			put(offset)
+		}
+	}
+
+	dir := t.TempDir()
+	vcfPath := filepath.Join(dir, "sample.vcf.gz")
+	if err := os.WriteFile(vcfPath, data.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var compressedIndex bytes.Buffer
+	w := gzip.NewWriter(&compressedIndex)
+	w.Write(index.Bytes())
+	w.Close()
+	if err := os.WriteFile(vcfPath+".tbi", compressedIndex.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return vcfPath
+}
+
+// binLevel returns the level of a bin of the tabix binning scheme and the
+// first bin of that level
+func binLevel(bin uint32) (int, uint32) {
+	first := uint32(0)
+	for level := 0; ; level++ {
+		if bin < first+1<<(level*3) {
+			return level, first
+		}
+		first += 1 << (level * 3)
+	}
+}
+
+// binSpan returns the number of positions a bin covers
+func binSpan(bin uint32) uint64 {
+	level, _ := binLevel(bin)
+	return 1 << (14 + 3*(5-level))
+}
+
+// binContains reports whether a bin covers all of [beg, end)
+func binContains(bin uint32, beg uint64, end uint64) bool {
+	_, first := binLevel(bin)
+	lo := uint64(bin-first) * binSpan(bin)
+	return beg >= lo && end <= lo+binSpan(bin)
+}
+
+func TestVCFSource_SeeksThroughTabixIndex(t *testing.T) {
+	header := "##fileformat=VCFv4.2\n" +
+		"##INFO=<ID=END,Number=1,Type=Integer,Description=\"End position of the block\">\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n"
+	records := []string{
+		"1\t100\trs1\tA\tG\t60\tPASS\t.\tGT\t0/1",
+		"1\t20000\t.\tC\t<NON_REF>\t.\t.\tEND=70000\tGT\t0/0",
+		"1\t70001\trs2\tT\tC\t60\tPASS\t.\tGT\t1/1",
+		"1\t5000000\trs3\tG\tA\t60\tPASS\t.\tGT\t0/1",
+		"2\t100\trs4\tC\tT\t60\tPASS\t.\tGT\t0/1",
+		"2\t300\trs5\tA\tT\t60\tPASS\t.\tGT\t1/1",
+	}
+	vcfPath := writeIndexedVCF(t, header, records)
+
+	indexed, err := NewVCFSource(vcfPath, nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource failed: %v", err)
+	}
+	if indexed.index == nil {
+		t.Fatal("Expected the tabix index to be loaded")
+	}
+	if offset, ok := indexed.index.offset("1", 70001, 70001); !ok || offset>>16 <= indexed.index.refs["1"].linear[0]>>16 {
+		t.Errorf("Expected 1:70001 to be read from past the start of chromosome 1, got offset %d", offset)
+	}
+	// The same VCF without its index is scanned
+	plain := &VCFSource{path: vcfPath, samples: indexed.samples, header: indexed.header}
+
+	describe := func(calls []*VariantCall) string {
+		var parts []string
+		for _, call := range calls {
+			parts = append(parts, fmt.Sprintf("%s:%d %s %s %v %v", call.Chromosome, call.Position, call.ID, call.Reference, call.Alternate, call.Samples))
+		}
+		return strings.Join(parts, "; ")
+	}
+	for _, query := range []struct {
+		chrom string
+		pos   uint64
+	}{
+		{"1", 100}, {"1", 150}, {"1", 20000}, {"1", 50000}, {"1", 70001},
+		{"chr1", 5000000}, {"1", 6000000}, {"2", 100}, {"2", 200}, {"2", 300}, {"3", 100},
+		{"", 100}, {"", 50000},
+	} {
+		want, err := plain.LookupVariant(query.chrom, query.pos)
+		if err != nil {
+			t.Fatalf("LookupVariant without the index failed: %v", err)
+		}
+		got, err := indexed.LookupVariant(query.chrom, query.pos)
+		if err != nil {
+			t.Fatalf("LookupVariant through the index failed: %v", err)
+		}
+		if describe(got) != describe(want) {
+			t.Errorf("%s:%d: expected %q through the index, got %q", query.chrom, query.pos, describe(want), describe(got))
+		}
+	}
+
+	var ids []string
+	err = indexed.IterateRegion("1", 60000, 5000000, func(call *VariantCall) bool {
+		ids = append(ids, call.ID)
+		return true
+	})
+	if err != nil || strings.Join(ids, ",") != "rs2,rs3" {
+		t.Errorf("Expected rs2 and rs3 in the region, got %v, %v", ids, err)
+	}
+
+	// An index older than its VCF is stale and ignored
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(vcfPath+".tbi", past, past); err != nil {
+		t.Fatal(err)
+	}
+	if stale, err := NewVCFSource(vcfPath, nil); err != nil || stale.index != nil {
+		t.Errorf("Expected a stale index to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package proofs
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/zkgenomics/zkgenomics-proofs/proofs/gadgets"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+// extractTraitGenotype returns the genotype of sample, or the first sample if
+// it is empty, at the trait's position after checking that the VCF record carries the expected alleles
+func extractTraitGenotype(vcfPath string, sample string, variant traits.TraitVariant, progress ProgressReporter, logger Logger) (int, error) {
+	return traitGenotype(nil, vcfPath, sample, variant, progress, logger)
+}
+
+// extractTraitGenotypes returns the genotypes of sample at several trait
+// variants like extractTraitGenotype, reading the genome in a single pass
+func extractTraitGenotypes(vcfPath string, sample string, variants []traits.TraitVariant, progress ProgressReporter, logger Logger) ([]int, error) {
+	source, err := sourceOrVCF(nil, vcfPath, sample, progress)
+	if err != nil {
+		return nil, err
+	}
+	if err := prefetchPanel(source, variants); err != nil {
+		return nil, err
+	}
+
+	genotypes := make([]int, len(variants))
+	for i, variant := range variants {
+		genotypes[i], err = traitGenotype(source, vcfPath, "", variant, progress, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return genotypes, nil
+}
+
+// traitGenotype reads the genotype at variant from source, or from the
+// genome at vcfPath if source is nil
+func traitGenotype(source GenomeSource, vcfPath string, sample string, variant traits.TraitVariant, progress ProgressReporter, logger Logger) (int, error) {
+	dp := NewDynamicProof(uint64(variant.Position), variant.Ref, variant.Alt)
+	dp.Source = source
+	dp.Sample = sample
+	dp.Build = variant.Build
+	dp.Progress = progress
+	dp.Logger = logger
+
+	genotype, actualRef, actualAlt, err := dp.extractGenotypeAtPosition(vcfPath, dp.Position, dp.Reference, dp.Alternate)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", variant.Trait, err)
+	}
+	if !allelesMatch(variant.Ref, actualRef) {
+		return 0, fmt.Errorf("%s: reference mismatch: expected %s, found %s", variant.Trait, variant.Ref, actualRef)
+	}
+	if !allelesMatch(variant.Alt, actualAlt) {
+		return 0, fmt.Errorf("%s: alternate mismatch: expected %s, found %s", variant.Trait, variant.Alt, actualAlt)
+	}
+
+	return genotype, nil
+}
+
+// GenotypeClaimCircuit proves a public trait claim derived from a single
+// private genotype through a fixed genotype → claim mapping. The public
+// LocusHash is fixed to the LocusHash of the circuit's variant, so the
+// public witness names the locus the claim is about.
+type GenotypeClaimCircuit struct {
+	ClaimedValue frontend.Variable `gnark:",public"`
+	LocusHash    frontend.Variable `gnark:",public"`
+	Genotype     frontend.Variable
+
+	variant traits.TraitVariant
+	claims  [3]int
+}
+
+// NewGenotypeClaimCircuit creates a circuit for variant mapping genotypes 0,
+// 1 and 2 to claims
+func NewGenotypeClaimCircuit(variant traits.TraitVariant, claims [3]int) *GenotypeClaimCircuit {
+	return &GenotypeClaimCircuit{variant: variant, claims: claims}
+}
+
+func (c *GenotypeClaimCircuit) Define(api frontend.API) error {
+	curve, err := curveOfField(api.Compiler().Field())
+	if err != nil {
+		return err
+	}
+	locusHash, err := variantLocusHash(curve, c.variant)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(c.LocusHash, locusHash)
+
+	claim := gadgets.SelectByGenotype(api, c.Genotype, [3]frontend.Variable{c.claims[0], c.claims[1], c.claims[2]})
+	api.AssertIsEqual(c.ClaimedValue, claim)
+
+	return nil
+}
+
+func (c *GenotypeClaimCircuit) assignCurve(curve ecc.ID) error {
+	locusHash, err := variantLocusHash(curve, c.variant)
+	if err != nil {
+		return err
+	}
+	c.LocusHash = locusHash
+	return nil
+}
+
+// assignGenotypeClaim extracts sample's genotype at variant and
+// builds the claim circuit and its assignment, returning the claim value
+func assignGenotypeClaim(vcfPath string, sample string, variant traits.TraitVariant, claims [3]int, progress ProgressReporter, logger Logger) (*GenotypeClaimCircuit, int, error) {
+	loggerOrNop(logger).Infof("searching for %s...", variant.Trait)
+	genotype, err := extractTraitGenotype(vcfPath, sample, variant, progress, logger)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	locusHash, err := variantLocusHash(ecc.BN254, variant)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	assignment := NewGenotypeClaimCircuit(variant, claims)
+	assignment.ClaimedValue = claims[genotype]
+	assignment.LocusHash = locusHash
+	assignment.Genotype = genotype
+
+	return assignment, claims[genotype], nil
+}
+
+// generateGenotypeClaim proves the claim that claims assigns to sample's
+// genotype at variant, returning the proof data and the claim value
+func generateGenotypeClaim(vcfPath string, sample string, variant traits.TraitVariant, claims [3]int, progress ProgressReporter, logger Logger) (*ProofData, int, error) {
+	assignment, claim, err := assignGenotypeClaim(vcfPath, sample, variant, claims, progress, logger)
+	if err != nil {
+		return failedProofData(), 0, err
+	}
+
+	proofData, err := proveCircuit(logger, progress, NewGenotypeClaimCircuit(variant, claims), assignment)
+	if err != nil {
+		return proofData, 0, err
+	}
+
+	return proofData, claim, nil
+}
@@ -0,0 +1,222 @@
+package proofs
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkgenomics/zkgenomics-proofs/traits"
+)
+
+func TestGenotypeClaimCircuit(t *testing.T) {
+	variant, claims := traits.ACTN3Variant, traits.ACTN3Claims
+	locus, err := variantLocusHash(ecc.BN254, variant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherLocus, err := variantLocusHash(ecc.BN254, traits.ALDH2Variant)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		genotype int
+		claimed  int
+		locus    *big.Int
+		solved   bool
+	}{
+		{0, int(traits.ACTN3RR), locus, true},
+		{1, int(traits.ACTN3RX), locus, true},
+		{2, int(traits.ACTN3XX), locus, true},
+		{2, int(traits.ACTN3RR), locus, false},      // Wrong claim
+		{3, 0, locus, false},                        // Out of range genotype
+		{2, int(traits.ACTN3XX), otherLocus, false}, // Wrong locus
+	}
+
+	for _, tc := range tests {
+		assignment := NewGenotypeClaimCircuit(variant, claims)
+		assignment.ClaimedValue = tc.claimed
+		assignment.LocusHash = tc.locus
+		assignment.Genotype = tc.genotype
+
+		err := test.IsSolved(NewGenotypeClaimCircuit(variant, claims), assignment, ecc.BN254.ScalarField())
+		if tc.solved && err != nil {
+			t.Errorf("Expected genotype %d to prove claim %d: %v", tc.genotype, tc.claimed, err)
+		}
+		if !tc.solved && err == nil {
+			t.Errorf("Expected genotype %d not to prove claim %d", tc.genotype, tc.claimed)
+		}
+	}
+}
+
+func TestACTN3Proof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+11	66328095	rs1815739	C	T	60	PASS	.	GT	1/1
+`)
+
+	proof := &ACTN3Proof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
+
+func TestABCC11Proof_GenerateAndVerify(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+16	48258198	rs17822931	C	T	60	PASS	.	GT	0/1
+`)
+
+	proof := &ABCC11Proof{}
+	proofData, err := proof.Generate(vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := proof.VerifyProofData(proofData)
+	if err != nil {
+		t.Fatalf("VerifyProofData should not return error: %v", err)
+	}
+	if result.Result != ProofSuccess {
+		t.Errorf("Expected ProofSuccess, got %s: %v", result.Result.String(), result.Error)
+	}
+}
+
+func TestACTN3Proof_TraitRegistry(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+11	66560624	rs1815739	C	T	60	PASS	.	GT	0/1
+`)
+	definitions := filepath.Join(t.TempDir(), "traits.yaml")
+	err := os.WriteFile(definitions, []byte(`- name: ACTN3
+  rsid: rs1815739
+  trait: ACTN3 R577X (rs1815739)
+  gene: ACTN3
+  chromosome: 11
+  position: 66560624
+  ref: C
+  alt: T
+  build: GRCh38
+  claims: [1, 2, 3]
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := traits.LoadRegistry(definitions)
+	if err != nil {
+		t.Fatalf("LoadRegistry should not return error: %v", err)
+	}
+	if variant := registry.Variant("aldh2"); variant != traits.ALDH2Variant {
+		t.Errorf("Expected bundled traits to be kept, got %+v", variant)
+	}
+
+	if _, _, err := (&ACTN3Proof{}).Assign(vcfPath); err == nil {
+		t.Error("Expected the bundled GRCh37 locus not to be found")
+	}
+	_, assignment, err := (&ACTN3Proof{ProofOptions: ProofOptions{Traits: registry}}).Assign(vcfPath)
+	if err != nil {
+		t.Fatalf("Assign should read the registry's locus: %v", err)
+	}
+	if claimed := assignment.(*GenotypeClaimCircuit).ClaimedValue; claimed != int(traits.ACTN3RX) {
+		t.Errorf("Expected claim %d, got %v", traits.ACTN3RX, claimed)
+	}
+}
+
+func TestTraitRegistry_ReplacementWithoutClaims(t *testing.T) {
+	definitions := filepath.Join(t.TempDir(), "traits.yaml")
+	err := os.WriteFile(definitions, []byte(`- name: actn3
+  trait: ACTN3 R577X (rs1815739)
+  chromosome: 11
+  position: 66560624
+  ref: C
+  alt: T
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := traits.LoadRegistry(definitions); err == nil {
+		t.Error("Expected a replacement of ACTN3 without claims to be rejected")
+	}
+
+	registry := traits.BundledRegistry()
+	err = registry.Add(traits.TraitDefinition{
+		Name:         "cyp2d6_4",
+		TraitVariant: traits.TraitVariant{Chromosome: 22, Position: 42128945, Ref: "C", Alt: "T"},
+	})
+	if err != nil {
+		t.Errorf("Expected a trait without claims to be replaceable without them: %v", err)
+	}
+}
+
+func TestBRCA1Proof_TraitRegistry(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO
+17	12345678	.	C	G	60	PASS	.
+`)
+	registry := traits.BundledRegistry()
+	err := registry.Add(traits.TraitDefinition{
+		Name:         "brca1",
+		TraitVariant: traits.TraitVariant{Chromosome: 17, Position: 12345678, Ref: "C", Alt: "G"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (&BRCA1Proof{}).Generate(vcfPath, "", ""); err == nil {
+		t.Error("Expected the bundled BRCA1 position not to be found")
+	}
+	proofData, err := (&BRCA1Proof{ProofOptions: ProofOptions{Traits: registry}}).Generate(vcfPath, "", "")
+	if err != nil || proofData.Result != ProofSuccess {
+		t.Errorf("Expected the registry's BRCA1 position to be found, got %v", err)
+	}
+}
+
+func TestCYP2D6Proof_TraitRegistry(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+22	42128945	rs3892097	C	T	60	PASS	.	GT	1/1
+22	42523805	rs28371725	C	T	60	PASS	.	GT	0/0
+22	42526694	rs1065852	G	A	60	PASS	.	GT	1/1
+`)
+	registry := traits.BundledRegistry()
+	err := registry.Add(traits.TraitDefinition{
+		Name:         "cyp2d6_4",
+		RsID:         "rs3892097",
+		TraitVariant: traits.TraitVariant{Trait: "CYP2D6*4 (rs3892097)", Gene: "CYP2D6", Chromosome: 22, Position: 42128945, Ref: "C", Alt: "T"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := &CYP2D6Proof{ProofOptions: ProofOptions{Traits: registry}}
+	if loci := TargetLoci(proof).Variants; len(loci) == 0 || loci[0].Position != 42128945 {
+		t.Errorf("Expected the registry's *4 locus to be targeted, got %+v", loci)
+	}
+	// With its *4 SNP read from the registry, the genome is *4/*4
+	_, status, err := proof.assign(vcfPath)
+	if err != nil {
+		t.Fatalf("assign should read the registry's loci: %v", err)
+	}
+	if status != traits.PoorMetabolizer {
+		t.Errorf("Expected poor metabolizer status, got %s", status)
+	}
+	if _, status, err := (&CYP2D6Proof{}).assign(vcfPath); err == nil && status == traits.PoorMetabolizer {
+		t.Error("Expected the bundled *4 locus not to be read")
+	}
+}
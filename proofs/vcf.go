@@ -0,0 +1,176 @@
+package proofs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/brentp/vcfgo"
+)
+
+// Generation errors that callers may want to tell apart wrap one of these,
+// so they can be matched with errors.Is
+var (
+	// ErrVariantNotFound is returned when the genome has no record of a
+	// variant the proof is about
+	ErrVariantNotFound = errors.New("variant not found")
+	// ErrNoSampleData is returned when the VCF has no samples, or the sample
+	// has no genotype called at the variant
+	ErrNoSampleData = errors.New("no sample data")
+	// ErrMalformedVCF is returned when the VCF cannot be parsed
+	ErrMalformedVCF = errors.New("malformed VCF")
+)
+
+// ProgressReporter receives progress updates for long-running operations.
+// Stage names the phase, percent is in the range [0, 100]. VCF scans report
+// "scan" as they go; proving reports "compile", "setup", "witness" and
+// "prove", each at 0 when it starts and 100 when it finishes, since gnark
+// cannot report progress within a step.
+type ProgressReporter func(stage string, percent float64, message string)
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// decompressVCF returns a reader of the VCF text in r. Gzip and bgzip input,
+// told apart from plain text by its magic bytes, is decompressed; bgzip files
+// are gzip members one after another, which gzip.Reader reads through.
+func decompressVCF(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		return br, nil
+	}
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedVCF, err)
+	}
+	return gz, nil
+}
+
+// vcfFile is an open VCF whose reads return its text
+type vcfFile struct {
+	io.Reader
+	file       genomeFile
+	compressed bool
+}
+
+func (f *vcfFile) Close() error {
+	return f.file.Close()
+}
+
+// OpenVCFFile opens vcfPath, a file path or an https:// or s3:// URL, for
+// reading its text, decompressing .vcf.gz and other gzip or bgzip input
+// transparently
+func OpenVCFFile(vcfPath string) (io.ReadCloser, error) {
+	return openVCFFile(vcfPath)
+}
+
+func openVCFFile(vcfPath string) (*vcfFile, error) {
+	f, err := openGenomeFile(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+	r, err := decompressVCF(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	_, compressed := r.(*gzip.Reader)
+	return &vcfFile{Reader: r, file: f, compressed: compressed}, nil
+}
+
+// vcfScan is a VCF reader that reports scan progress. Progress is measured as
+// bytes consumed from the file against its size, so it stays accurate
+// when a decompressing reader sits between the file and the VCF parser.
+type vcfScan struct {
+	*vcfgo.Reader
+
+	file        genomeFile
+	counter     *countingReader
+	total       int64
+	start       time.Time
+	progress    ProgressReporter
+	lastPercent float64
+}
+
+// openVCF opens vcfPath for a sequential scan, reporting to progress if
+// non-nil. Compressed VCFs are decompressed as for OpenVCFFile.
+func openVCF(vcfPath string, progress ProgressReporter) (*vcfScan, error) {
+	f, err := openGenomeFile(vcfPath)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &countingReader{r: f}
+	text, err := decompressVCF(counter)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	rdr, err := vcfgo.NewReader(text, false)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%w: %v", ErrMalformedVCF, err)
+	}
+
+	return &vcfScan{
+		Reader:      rdr,
+		file:        f,
+		counter:     counter,
+		total:       f.Size(),
+		start:       time.Now(),
+		progress:    progress,
+		lastPercent: -1,
+	}, nil
+}
+
+// Read returns the next variant, or nil at the end of the file
+func (s *vcfScan) Read() *vcfgo.Variant {
+	variant := s.Reader.Read()
+	if variant == nil {
+		s.report(100)
+	} else if s.total > 0 {
+		// The parser reads ahead, so hold back 100% until the scan really ends
+		s.report(min(float64(s.counter.n)*100/float64(s.total), 99))
+	}
+	return variant
+}
+
+// Close closes the underlying file. A scan stopped before the end of the file
+// still reports completion so progress displays can finish their line.
+func (s *vcfScan) Close() error {
+	s.report(100)
+	return s.file.Close()
+}
+
+// report emits a progress update whenever the scan advances by a whole percent
+func (s *vcfScan) report(percent float64) {
+	if s.progress == nil || s.lastPercent >= 100 {
+		return
+	}
+	if percent < 100 && percent-s.lastPercent < 1 {
+		return
+	}
+	s.lastPercent = percent
+
+	elapsed := time.Since(s.start)
+	message := fmt.Sprintf("%d of %d bytes read", s.counter.n, s.total)
+	if percent > 0 && percent < 100 {
+		eta := time.Duration(float64(elapsed) * (100 - percent) / percent)
+		message += fmt.Sprintf(", ETA %s", eta.Round(time.Second))
+	}
+
+	s.progress("scan", percent, message)
+}
@@ -0,0 +1,165 @@
+package proofs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenVCF_ReportsProgress(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("##fileformat=VCFv4.2\n")
+	sb.WriteString("#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\n")
+	for i := 1; i <= 5000; i++ {
+		fmt.Fprintf(&sb, "1\t%d\t.\tA\tG\t60\tPASS\t.\n", i*100)
+	}
+	vcfPath := writeTestVCF(t, sb.String())
+
+	var percents []float64
+	rdr, err := openVCF(vcfPath, func(stage string, percent float64, message string) {
+		if stage != "scan" {
+			t.Errorf("Expected scan stage, got %s", stage)
+		}
+		percents = append(percents, percent)
+	})
+	if err != nil {
+		t.Fatalf("openVCF failed: %v", err)
+	}
+	defer rdr.Close()
+
+	for rdr.Read() != nil {
+	}
+
+	if len(percents) < 2 {
+		t.Fatalf("Expected several progress updates, got %d", len(percents))
+	}
+	for i := 1; i < len(percents); i++ {
+		if percents[i] <= percents[i-1] {
+			t.Errorf("Progress went backwards: %v", percents)
+			break
+		}
+	}
+	if last := percents[len(percents)-1]; last != 100 {
+		t.Errorf("Expected final progress of 100, got %f", last)
+	}
+}
+
+func TestVCFSource_ReadsBgzip(t *testing.T) {
+	// bgzip writes the file as a series of gzip members
+	var compressed bytes.Buffer
+	for _, block := range []string{
+		"##fileformat=VCFv4.2\n##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n",
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/1\n",
+	} {
+		w := gzip.NewWriter(&compressed)
+		w.Write([]byte(block))
+		w.Close()
+	}
+	vcfPath := filepath.Join(t.TempDir(), "sample.vcf.gz")
+	if err := os.WriteFile(vcfPath, compressed.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := NewVCFSource(vcfPath, nil)
+	if err != nil {
+		t.Fatalf("NewVCFSource failed: %v", err)
+	}
+	if names := source.SampleNames(); len(names) != 1 || names[0] != "SAMPLE1" {
+		t.Errorf("Expected the header of the compressed VCF, got samples %v", names)
+	}
+	calls, err := source.LookupVariant("12", 112241766)
+	if err != nil || len(calls) != 1 || calls[0].ID != "rs671" {
+		t.Errorf("Expected rs671 from the compressed VCF, got %v, %v", calls, err)
+	}
+
+	// A gzip header over a corrupt stream is malformed, not plain text
+	corrupt := filepath.Join(t.TempDir(), "corrupt.vcf.gz")
+	if err := os.WriteFile(corrupt, []byte{0x1f, 0x8b, 0}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewVCFSource(corrupt, nil); !errors.Is(err, ErrMalformedVCF) {
+		t.Errorf("Expected ErrMalformedVCF, got %v", err)
+	}
+}
+
+func TestGenerate_ReportsProvingStages(t *testing.T) {
+	vcfPath := writeTestVCF(t, `##fileformat=VCFv4.2
+##FORMAT=<ID=GT,Number=1,Type=String,Description="Genotype">
+#CHROM	POS	ID	REF	ALT	QUAL	FILTER	INFO	FORMAT	SAMPLE1
+11	66328095	rs1815739	C	T	60	PASS	.	GT	1/1
+`)
+
+	var updates []string
+	proof := &ACTN3Proof{
+		ProofOptions: ProofOptions{
+			Progress: func(stage string, percent float64, message string) {
+				updates = append(updates, fmt.Sprintf("%s %.0f", stage, percent))
+			},
+		},
+	}
+	if _, err := proof.Generate(vcfPath, "", ""); err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	got := strings.Join(updates, ",")
+	want := "compile 0,compile 100,setup 0,setup 100,witness 0,witness 100,prove 0,prove 100"
+	if !strings.HasSuffix(got, want) || !strings.HasPrefix(got, "scan ") {
+		t.Errorf("Expected a scan followed by %s, got %s", want, got)
+	}
+}
+
+func TestGenerateContext_ClassifiesFailures(t *testing.T) {
+	header := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n"
+	tests := []struct {
+		name   string
+		vcf    string
+		err    error
+		reason FailureReason
+	}{
+		{
+			name:   "missing variant",
+			vcf:    header + "#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n15\t100\t.\tA\tG\t60\tPASS\t.\tGT\t0/1\n",
+			err:    ErrVariantNotFound,
+			reason: FailureVariantNotFound,
+		},
+		{
+			name:   "no samples",
+			vcf:    header + "#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\n15\t28365618\t.\tA\tG\t60\tPASS\t.\n",
+			err:    ErrNoSampleData,
+			reason: FailureNoSampleData,
+		},
+		{
+			name:   "uncalled genotype",
+			vcf:    header + "#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n15\t28365618\t.\tA\tG\t60\tPASS\t.\tGT\t./.\n",
+			err:    ErrNoSampleData,
+			reason: FailureNoSampleData,
+		},
+		{
+			name:   "malformed",
+			vcf:    "not a VCF\n",
+			err:    ErrMalformedVCF,
+			reason: FailureMalformedVCF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vcfPath := writeTestVCF(t, tt.vcf)
+			proof := NewDynamicProof(28365618, "A", "G")
+			proofData, err := GenerateContext(context.Background(), proof, vcfPath, "", "")
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected %v, got %v", tt.err, err)
+			}
+			if proofData == nil || proofData.Result != ProofFail || proofData.FailureReason != tt.reason {
+				t.Errorf("Expected failed proof data with reason %s, got %+v", tt.reason, proofData)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package proofs
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FormatVersion is the serialization format of proofs and presentations
+// written by this release, recorded as format_version in JSON and CBOR and in
+// the protobuf messages. Files written before formats were versioned are read
+// as version 0 and migrated; newer versions fail with UnsupportedVersionError.
+const FormatVersion = 1
+
+// UnsupportedVersionError is returned when reading an artifact written in a
+// newer format than this release supports
+type UnsupportedVersionError struct {
+	// Artifact names what was being read, such as "proof"
+	Artifact string
+	Version  int
+	// Supported is the newest version this release reads
+	Supported int
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("%s format version %d is not supported (this release reads up to version %d); upgrade zkgenomics to read it",
+		e.Artifact, e.Version, e.Supported)
+}
+
+// checkFormatVersion refuses versions of artifact newer than supported
+func checkFormatVersion(artifact string, version, supported int) error {
+	if version < 0 || version > supported {
+		return &UnsupportedVersionError{Artifact: artifact, Version: version, Supported: supported}
+	}
+	return nil
+}
+
+// plainProofData and plainPresentation have the fields but not the methods of
+// the types they encode, so encoding them does not recurse
+type (
+	plainProofData    ProofData
+	plainPresentation Presentation
+)
+
+// versionedProofData and versionedPresentation are the encoded forms of
+// proofs and presentations: their fields, preceded by the format version
+type (
+	versionedProofData struct {
+		FormatVersion int `json:"format_version"`
+		*plainProofData
+	}
+	versionedPresentation struct {
+		FormatVersion int `json:"format_version"`
+		*plainPresentation
+	}
+)
+
+// migrateProofData brings a proof decoded from format version to the current
+// format
+func migrateProofData(version int, p *ProofData) {
+	if version == 0 {
+		// Unversioned proofs predate the curve field and were all made over
+		// BN254
+		if p.Curve == "" {
+			p.Curve = proofCurve
+		}
+	}
+}
+
+// MarshalJSON encodes the proof with the current format version
+func (p ProofData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(versionedProofData{FormatVersion, (*plainProofData)(&p)})
+}
+
+// UnmarshalJSON decodes a proof of any supported format version, migrating
+// it to the current format
+func (p *ProofData) UnmarshalJSON(data []byte) error {
+	var decoded ProofData
+	v := versionedProofData{plainProofData: (*plainProofData)(&decoded)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := checkFormatVersion("proof", v.FormatVersion, FormatVersion); err != nil {
+		return err
+	}
+	migrateProofData(v.FormatVersion, &decoded)
+	*p = decoded
+	return nil
+}
+
+// MarshalJSON encodes the presentation with the current format version
+func (p Presentation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(versionedPresentation{FormatVersion, (*plainPresentation)(&p)})
+}
+
+// UnmarshalJSON decodes a presentation of any supported format version
+func (p *Presentation) UnmarshalJSON(data []byte) error {
+	var decoded Presentation
+	v := versionedPresentation{plainPresentation: (*plainPresentation)(&decoded)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := checkFormatVersion("presentation", v.FormatVersion, FormatVersion); err != nil {
+		return err
+	}
+	*p = decoded
+	return nil
+}
@@ -0,0 +1,70 @@
+package proofs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeProofData_FormatVersion(t *testing.T) {
+	// Proofs written before formats were versioned
+	v0 := `{"proof":"AQ==","verifying_key":"Ag==","public_witness":"Aw==","result":0,"proof_type":"actn3"}`
+	proofData, err := DecodeProofData(strings.NewReader(v0))
+	if err != nil {
+		t.Fatalf("DecodeProofData should read version 0 proofs: %v", err)
+	}
+	if proofData.Curve != proofCurve || proofData.ProofType != "actn3" || proofData.Result != ProofSuccess {
+		t.Errorf("Expected the version 0 proof to be migrated, got %+v", proofData)
+	}
+
+	encoded, err := json.Marshal(proofData)
+	if err != nil {
+		t.Fatalf("json.Marshal should not return error: %v", err)
+	}
+	if !strings.HasPrefix(string(encoded), `{"format_version":1,`) {
+		t.Errorf("Expected the format version to lead the JSON encoding, got %s", encoded)
+	}
+
+	future := strings.Replace(string(encoded), `"format_version":1`, `"format_version":99`, 1)
+	var unsupported *UnsupportedVersionError
+	if _, err := DecodeProofData(strings.NewReader(future)); !errors.As(err, &unsupported) || unsupported.Version != 99 {
+		t.Errorf("Expected UnsupportedVersionError for a future JSON proof, got %v", err)
+	}
+
+	presentation := &Presentation{ProofData: proofData}
+	cborData, err := presentation.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR should not return error: %v", err)
+	}
+	if _, err := DecodePresentation(bytes.NewReader(cborData)); err != nil {
+		t.Errorf("DecodePresentation should read its own encoding: %v", err)
+	}
+	cborData = bytes.Replace(cborData, []byte("format_version\x01"), []byte("format_version\x18\x63"), 1)
+	if _, err := DecodePresentation(bytes.NewReader(cborData)); !errors.As(err, &unsupported) {
+		t.Errorf("Expected UnsupportedVersionError for a future CBOR presentation, got %v", err)
+	}
+
+	compact, err := EncodeCompact(proofData)
+	if err != nil {
+		t.Fatalf("EncodeCompact should not return error: %v", err)
+	}
+	if compact[0] != FormatVersion {
+		t.Errorf("Expected compact proofs to start with the format version, got %d", compact[0])
+	}
+	compact[0] = 2
+	if _, err := DecodeCompact(compact); !errors.As(err, &unsupported) {
+		t.Errorf("Expected UnsupportedVersionError for a future compact proof, got %v", err)
+	}
+
+	// The protobuf format_version field is 15
+	protoData, err := proofData.MarshalProto()
+	if err != nil {
+		t.Fatalf("MarshalProto should not return error: %v", err)
+	}
+	protoData = append(protoData, 0x78, 0x02)
+	if err := new(ProofData).UnmarshalProto(protoData); !errors.As(err, &unsupported) {
+		t.Errorf("Expected UnsupportedVersionError for a future protobuf proof, got %v", err)
+	}
+}
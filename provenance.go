@@ -0,0 +1,35 @@
+package zkgenomics
+
+import (
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// GenomeAttestation re-exports a sequencing lab's signed statement of the
+// genome a proof was generated from
+type GenomeAttestation = proofs.GenomeAttestation
+
+// AttestationMismatchError re-exports the error for a VCF that is not the one
+// its attestation is for
+type AttestationMismatchError = proofs.AttestationMismatchError
+
+// AttestGenome signs an attestation of the VCF at vcfPath with signer, as the
+// lab that sequenced it, and stores it next to the VCF. Proofs generated from
+// the VCF then carry it, and verifiers configured WithLabKeys require it.
+// Commit the genome with a Merkle root first to attest the root as well, so
+// committed proofs are bound to the attested genome.
+func AttestGenome(vcfPath string, signer EnvelopeSigner) (*GenomeAttestation, error) {
+	attestation, err := proofs.AttestGenome(vcfPath, signer)
+	if err != nil {
+		return nil, err
+	}
+	if err := attestation.Save(vcfPath); err != nil {
+		return nil, err
+	}
+	return attestation, nil
+}
+
+// ReadGenomeAttestation reads an attestation a lab delivered with a genome,
+// for ProofRequest.Attestation
+func ReadGenomeAttestation(path string) (*GenomeAttestation, error) {
+	return proofs.ReadGenomeAttestation(path)
+}
@@ -0,0 +1,86 @@
+package zkgenomics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// RecursiveProof re-exports the single proof attesting to a panel of proofs
+type RecursiveProof = proofs.RecursiveProof
+
+// RecursionProofType is the proof type the outer verifying keys of recursive
+// proofs are registered and pinned under
+const RecursionProofType ProofType = "recursion"
+
+// AggregableBackend is the backend proofs must be made with to be aggregated:
+// Groth16 over BLS12-377, as with WithCurve(BLS12_377)
+var AggregableBackend = proofs.AggregableBackend
+
+// AggregateProofs proves in a single recursive proof that each of members
+// verifies, so a panel of trait proofs can be presented as one artifact.
+// Members must be made with AggregableBackend. The outer circuit fixes the
+// verifying keys of the members, so it is set up once per panel of circuits;
+// its keys are taken from the generator's key store and circuit cache.
+func (pg *ProofGenerator) AggregateProofs(ctx context.Context, members []*ProofData) (*RecursiveProof, error) {
+	rp, err := proofs.AggregateProofsContext(ctx, members, proofs.GenerateOptions{
+		Keys:     pg.Keys,
+		Cache:    pg.Cache,
+		Logger:   pg.Logger,
+		Progress: pg.Progress,
+		ProverOptions: proofs.ProverOptions{
+			Threads: pg.Threads,
+			Hints:   pg.SolverHints,
+			Prover:  pg.ExternalProver,
+		},
+	})
+	if err != nil {
+		return nil, &ProofGenerationError{ProofType: string(RecursionProofType), Err: err}
+	}
+	rp.Proof.ProofType = string(RecursionProofType)
+	return rp, nil
+}
+
+// VerifyRecursiveProof verifies rp: its outer proof must verify and attest
+// to the keys and public values of its members, and be trusted as a
+// RecursionProofType proof, and every member must be trusted as the proof
+// type it records. Members carry no SNARK of their own, so issuer signatures
+// are required of the outer proof only; genome attestations are required of
+// the members, which are proven from genomes. Public values of the members are
+// reported as Members_<i>_<name>.
+func (pg *ProofGenerator) VerifyRecursiveProof(rp *RecursiveProof) (*VerificationResult, error) {
+	result, err := proofs.VerifyRecursiveProof(pg.Logger, rp)
+	if err != nil || result.Result != ProofSuccess {
+		return result, err
+	}
+
+	policy := pg.trustPolicy()
+	outer := policy
+	outer.LabKeys = nil
+	trust, err := pg.VerifyTrust(RecursionProofType, rp.Proof, outer)
+	if err != nil || trust.Result != ProofSuccess {
+		return trust, err
+	}
+	policy.IssuerKeys = nil
+	for i, member := range rp.Members {
+		if member.ProofType == "" {
+			return &VerificationResult{Result: ProofFail, Error: fmt.Errorf("member %d does not record its proof type", i)}, nil
+		}
+		trust, err := pg.VerifyTrust(ProofType(member.ProofType), member, policy)
+		if err != nil {
+			return nil, err
+		}
+		if trust.Result != ProofSuccess {
+			return &VerificationResult{Result: ProofFail, Error: fmt.Errorf("member %d: %w", i, trust.Error)}, nil
+		}
+	}
+	return result, nil
+}
+
+// VerifyRecursiveProof verifies rp like ProofGenerator.VerifyRecursiveProof.
+// Keys pinned in VerifyingKeys are not consulted: register the outer and
+// member keys in KeyRegistry instead.
+func (v *Verifier) VerifyRecursiveProof(rp *RecursiveProof) (*VerificationResult, error) {
+	return v.generator().VerifyRecursiveProof(rp)
+}
@@ -0,0 +1,185 @@
+package zkgenomics
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// VerifyingKeyRegistry holds the verifying keys a verifier trusts for each
+// proof type. Proofs are checked against a registered key rather than the
+// key they carry, which the prover chose. Keys may be registered with the
+// circuit they were set up for, which advisories are then checked against
+// instead of the circuit a proof claims. A VerifyingKeyRegistry is safe for
+// concurrent use.
+type VerifyingKeyRegistry struct {
+	mu sync.RWMutex
+	// keys holds the registered keys of each proof type by fingerprint
+	keys map[ProofType]map[string]registeredKey
+}
+
+// registeredKey is a trusted verifying key and, if known, its circuit
+type registeredKey struct {
+	vk      []byte
+	circuit *CircuitKey
+}
+
+// NewVerifyingKeyRegistry creates an empty registry
+func NewVerifyingKeyRegistry() *VerifyingKeyRegistry {
+	return &VerifyingKeyRegistry{keys: make(map[ProofType]map[string]registeredKey)}
+}
+
+// Register trusts the serialized verifying key vk for proofs of proofType.
+// A proof type may have several keys, such as keys of successive setups.
+func (r *VerifyingKeyRegistry) Register(proofType ProofType, vk []byte) error {
+	return r.register(proofType, vk, nil)
+}
+
+// RegisterCircuit trusts vk for proofs of proofType like Register, and
+// records that vk was set up for circuit. VerifyTrust checks advisories
+// against that circuit and fails proofs under vk claiming another one.
+func (r *VerifyingKeyRegistry) RegisterCircuit(proofType ProofType, vk []byte, circuit CircuitKey) error {
+	if circuit.ID == "" || circuit.Version <= 0 {
+		return fmt.Errorf("circuit %q v%d is not a released circuit version", circuit.ID, circuit.Version)
+	}
+	return r.register(proofType, vk, &circuit)
+}
+
+func (r *VerifyingKeyRegistry) register(proofType ProofType, vk []byte, circuit *CircuitKey) error {
+	fingerprint, err := proofs.VKFingerprint(vk)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.keys[proofType] == nil {
+		r.keys[proofType] = make(map[string]registeredKey)
+	}
+	r.keys[proofType][fingerprint] = registeredKey{vk: slices.Clone(vk), circuit: circuit}
+	return nil
+}
+
+// RegisterFrom trusts the verifying key supplied by provider for proofs of
+// proofType
+func (r *VerifyingKeyRegistry) RegisterFrom(ctx context.Context, proofType ProofType, provider KeyProvider) error {
+	vk, err := proofs.ReadKey(ctx, provider)
+	if err != nil {
+		return fmt.Errorf("reading verifying key: %w", err)
+	}
+	return r.Register(proofType, vk)
+}
+
+// Keys returns the keys registered for proofType, ordered by fingerprint
+func (r *VerifyingKeyRegistry) Keys(proofType ProofType) [][]byte {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([][]byte, 0, len(r.keys[proofType]))
+	for _, fingerprint := range slices.Sorted(maps.Keys(r.keys[proofType])) {
+		keys = append(keys, r.keys[proofType][fingerprint].vk)
+	}
+	return keys
+}
+
+// ProofTypes returns the proof types with registered keys, in order
+func (r *VerifyingKeyRegistry) ProofTypes() []ProofType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.keys))
+}
+
+// Trusts reports whether vk is registered for proofType
+func (r *VerifyingKeyRegistry) Trusts(proofType ProofType, vk []byte) bool {
+	fingerprint, err := proofs.VKFingerprint(vk)
+	if err != nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.keys[proofType][fingerprint]
+	return ok
+}
+
+// Circuit returns the circuit vk was registered with for proofType, if it
+// is registered with one
+func (r *VerifyingKeyRegistry) Circuit(proofType ProofType, vk []byte) (CircuitKey, bool) {
+	fingerprint, err := proofs.VKFingerprint(vk)
+	if err != nil {
+		return CircuitKey{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, ok := r.keys[proofType][fingerprint]
+	if !ok || key.circuit == nil {
+		return CircuitKey{}, false
+	}
+	return *key.circuit, true
+}
+
+// LoadVerifyingKeyRegistry reads a registry file, one "<proof-type> <base64
+// verifying key>" pair per line, optionally followed by the "<circuit-id>
+// <circuit-version>" the key was set up for. Blank lines and lines starting
+// with # are ignored.
+func LoadVerifyingKeyRegistry(path string) (*VerifyingKeyRegistry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	registry := NewVerifyingKeyRegistry()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 && len(fields) != 4 {
+			return nil, fmt.Errorf("%s:%d: expected proof type, verifying key and optionally circuit ID and version", path, line)
+		}
+		vk, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid verifying key", path, line)
+		}
+		if len(fields) == 2 {
+			err = registry.Register(ProofType(fields[0]), vk)
+		} else {
+			version, convErr := strconv.Atoi(fields[3])
+			if convErr != nil {
+				return nil, fmt.Errorf("%s:%d: invalid circuit version %q", path, line, fields[3])
+			}
+			err = registry.RegisterCircuit(ProofType(fields[0]), vk, CircuitKey{ID: fields[2], Version: version})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// RegistryLine returns the line of a registry file trusting vk for proofs of
+// proofType
+func RegistryLine(proofType ProofType, vk []byte) string {
+	return fmt.Sprintf("%s %s", proofType, base64.StdEncoding.EncodeToString(vk))
+}
+
+// CircuitRegistryLine returns the line of a registry file trusting vk for
+// proofs of proofType and recording the circuit it was set up for
+func CircuitRegistryLine(proofType ProofType, vk []byte, circuit CircuitKey) string {
+	return fmt.Sprintf("%s %s %d", RegistryLine(proofType, vk), circuit.ID, circuit.Version)
+}
@@ -0,0 +1,152 @@
+package zkgenomics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyingKeyRegistry(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/0\n"
+
+	prover := NewProver()
+	aldh2, err := prover.GenerateProofFromReader(ALDH2ProofType, strings.NewReader(vcf))
+	if err != nil {
+		t.Fatalf("GenerateProofFromReader should not return error: %v", err)
+	}
+	// A fresh setup bundles another key, as a prover choosing its own would
+	other, err := prover.GenerateProofFromReader(ALDH2ProofType, strings.NewReader(vcf))
+	if err != nil {
+		t.Fatalf("GenerateProofFromReader should not return error: %v", err)
+	}
+
+	// Bundled keys are refused by default
+	var untrusted *UntrustedKeyError
+	result, err := NewProofGenerator().VerifyProofData(ALDH2ProofType, aldh2)
+	if err != nil || result.Result != ProofFail || !errors.As(result.Error, &untrusted) {
+		t.Errorf("Expected UntrustedKeyError for a bundled key, got %v: %v", result, err)
+	}
+	result, err = NewProofGenerator(WithInsecureBundledKeys()).VerifyProofData(ALDH2ProofType, aldh2)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected WithInsecureBundledKeys to accept a bundled key, got %v: %v", result, err)
+	}
+
+	// Registries are read from files written with RegistryLine
+	path := filepath.Join(t.TempDir(), "trusted-keys")
+	contents := "# trusted verifying keys\n\n" + RegistryLine(ALDH2ProofType, aldh2.VerifyingKey) + "\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile should not return error: %v", err)
+	}
+	registry, err := LoadVerifyingKeyRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadVerifyingKeyRegistry should not return error: %v", err)
+	}
+	if types := registry.ProofTypes(); len(types) != 1 || types[0] != ALDH2ProofType {
+		t.Errorf("Expected only %s to have keys, got %v", ALDH2ProofType, types)
+	}
+	if !registry.Trusts(ALDH2ProofType, aldh2.VerifyingKey) || registry.Trusts(ALDH2ProofType, other.VerifyingKey) {
+		t.Error("Expected only the registered key to be trusted")
+	}
+
+	pg := NewProofGenerator(WithVerifyingKeyRegistry(registry))
+	result, err = pg.VerifyProofData(ALDH2ProofType, aldh2)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected a proof with a registered key to verify, got %v: %v", result, err)
+	}
+
+	// A proof carrying its own key is checked against the registered key
+	result, err = pg.VerifyProofData(ALDH2ProofType, other)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected a proof for an unregistered key to fail, got %v: %v", result, err)
+	}
+	forged := *aldh2
+	forged.VerifyingKey = other.VerifyingKey
+	result, err = pg.VerifyProofData(ALDH2ProofType, &forged)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected the registered key to replace the bundled one, got %v: %v", result, err)
+	}
+
+	result, err = pg.VerifyProofData(ACTN3ProofType, aldh2)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected a type without registered keys to be refused, got %v: %v", result, err)
+	}
+
+	if _, err := LoadVerifyingKeyRegistry(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected an error for a missing registry file")
+	}
+
+	// Lines written by CircuitRegistryLine record the key's circuit
+	circuit := CircuitKey{ID: aldh2.CircuitID, Version: aldh2.CircuitVersion}
+	contents = CircuitRegistryLine(ALDH2ProofType, aldh2.VerifyingKey, circuit) + "\n" + RegistryLine(ALDH2ProofType, other.VerifyingKey) + "\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile should not return error: %v", err)
+	}
+	registry, err = LoadVerifyingKeyRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadVerifyingKeyRegistry should not return error: %v", err)
+	}
+	if registered, ok := registry.Circuit(ALDH2ProofType, aldh2.VerifyingKey); !ok || registered != circuit {
+		t.Errorf("Expected the key to be registered for %v, got %v", circuit, registered)
+	}
+	if _, ok := registry.Circuit(ALDH2ProofType, other.VerifyingKey); ok {
+		t.Error("Expected a key registered without a circuit to have none")
+	}
+	if err := os.WriteFile(path, []byte(RegistryLine(ALDH2ProofType, aldh2.VerifyingKey)+" genotype_claim\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile should not return error: %v", err)
+	}
+	if _, err := LoadVerifyingKeyRegistry(path); err == nil {
+		t.Error("Expected an error for a circuit without a version")
+	}
+}
+
+func TestVerifyingKeyRegistry_StrippedBinding(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/0\n"
+
+	response, err := NewProofGenerator().Generate(context.Background(), ProofRequest{
+		ProofType: ALDH2ProofType,
+		VCF:       strings.NewReader(vcf),
+		NotAfter:  time.Now().Add(-time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	registry := NewVerifyingKeyRegistry()
+	if err := registry.Register(ALDH2ProofType, response.ProofData.VerifyingKey); err != nil {
+		t.Fatalf("Register should not return error: %v", err)
+	}
+	pg := NewProofGenerator(WithVerifyingKeyRegistry(registry))
+
+	var windowErr *ValidityWindowError
+	result, err := pg.VerifyProofData(ALDH2ProofType, response.ProofData)
+	if err != nil || result.Result != ProofFail || !errors.As(result.Error, &windowErr) {
+		t.Fatalf("Expected an expired proof to fail with ValidityWindowError, got %v: %v", result, err)
+	}
+
+	// Stripping the metadata that says the proof is bound does not unbind it
+	tests := []struct {
+		name  string
+		strip func(*ProofData)
+	}{
+		{"binding", func(p *ProofData) { p.Binding = nil }},
+		{"binding and circuit", func(p *ProofData) { p.Binding, p.CircuitID, p.CircuitVersion = nil, "", 0 }},
+		{"circuit", func(p *ProofData) { p.CircuitID, p.CircuitVersion = "", 0 }},
+	}
+	for _, tc := range tests {
+		stripped := *response.ProofData
+		tc.strip(&stripped)
+		result, err := pg.VerifyProofData(ALDH2ProofType, &stripped)
+		if err != nil || result.Result != ProofFail {
+			t.Errorf("Expected an expired proof stripped of its %s to fail, got %v: %v", tc.name, result, err)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package zkgenomics
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// ProofRequest describes one proof to generate. New parameters are added as
+// fields, so callers only set what they need.
+type ProofRequest struct {
+	// ProofType selects the proof; it is ignored when Claim is set
+	ProofType ProofType
+	// Claim, if set, configures the proof like a claim file, including its
+	// variant, mode, thresholds and parent VCF
+	Claim *ClaimSpec
+	// VCFPath is the genome to prove from. If it has been committed, it must
+	// still match its commitment.
+	VCFPath string
+	// VCF, if set, is read instead of VCFPath. A reader has no commitment
+	// sidecar, so no commitment check is made.
+	VCF io.Reader
+	// Sample, if set, selects the sample of a multi-sample VCF, such as a
+	// trio or cohort VCF, to prove from: its name in the VCF header, or its
+	// index counting from 0. A missing sample is reported as a
+	// SampleNotFoundError listing the samples there are.
+	Sample string
+	// ProvingKeyPath, if set, names a proving key written by Setup, to prove
+	// with instead of the generator's key store; its verifying key is read
+	// from the same path with .vk in place of .pk
+	ProvingKeyPath string
+	// ProvingKey and VerifyingKey, if set, supply the keys to prove with, as
+	// for ProvingKeyPath, from memory, a key server or a database
+	ProvingKey   KeyProvider
+	VerifyingKey KeyProvider
+	OutputPath   string
+	// NotBefore and NotAfter, if set, bound the time the proof is valid in.
+	// They are bound to the proof, and checked when it is verified.
+	NotBefore time.Time
+	NotAfter  time.Time
+	// Nonce, if set, is a challenge from the verifier the proof is for. It is
+	// bound to the proof, so the proof cannot be replayed to other verifiers.
+	Nonce []byte
+	// HolderKey, if set, is the public key of the holder the proof is issued
+	// to. Its hash is bound to the proof, and verifiers of a Presentation
+	// require the holder's signature.
+	HolderKey ed25519.PublicKey
+	// Attestation, if set, is the lab attestation of the genome, embedded in
+	// the proof so verifiers can check the genome came from the lab. An
+	// attestation stored next to VCFPath by AttestGenome is embedded
+	// otherwise. The genome must be the attested one, or generation fails
+	// with an AttestationMismatchError.
+	Attestation *GenomeAttestation
+	// DebugWitness, if set, is filled with the full and public witness before
+	// proving, even if proving then fails. It reveals the private inputs and
+	// is for debugging circuits only.
+	DebugWitness *DebugWitness
+	// Metadata is returned unchanged on the response, to correlate requests
+	// in batches and logs
+	Metadata map[string]string
+}
+
+// DebugWitness re-exports the witness recorded for debugging circuits
+type DebugWitness = proofs.DebugWitness
+
+// ProofTimings is the wall-clock time one proof spent in each stage reported
+// through ProgressReporter, and in total
+type ProofTimings struct {
+	Scan    time.Duration
+	Compile time.Duration
+	Setup   time.Duration
+	Witness time.Duration
+	Prove   time.Duration
+	Total   time.Duration
+}
+
+// ProofResponse is the outcome of a ProofRequest
+type ProofResponse struct {
+	ProofType ProofType
+	ProofData *ProofData
+	Timings   ProofTimings
+	// Constraints is the size of the proven circuit
+	Constraints int
+	// PublicInputs is how many public inputs the proof discloses
+	PublicInputs int
+	Metadata     map[string]string
+	// Manifest describes how to reproduce the proof; it is set only for
+	// proofs generated with a seed
+	Manifest *ReproducibilityManifest
+}
+
+// Binding re-exports the presentation context a proof is bound to
+type Binding = proofs.Binding
+
+// ValidityWindowError re-exports the error for a proof verified outside its
+// validity window
+type ValidityWindowError = proofs.ValidityWindowError
+
+// ReproducibilityManifest re-exports the record of what a seeded proof was generated from
+type ReproducibilityManifest = proofs.ReproducibilityManifest
+
+// Generate generates the proof described by req. If generation fails once it
+// has started, the response is returned along with the error
+// and carries failed proof data and the timings so far. As with
+// GenerateProofContext, it returns ctx.Err() as soon as ctx is done.
+func (pg *ProofGenerator) Generate(ctx context.Context, req ProofRequest) (*ProofResponse, error) {
+	timer := &stageTimer{progress: pg.Progress, starts: map[string]time.Time{}}
+	worker := *pg
+	worker.Progress = timer.report
+	keys, err := req.keys()
+	if err != nil {
+		return nil, err
+	}
+	if keys != nil {
+		worker.Keys = keys
+	}
+
+	proofType := req.ProofType
+	var proof proofs.Proof
+	if req.Claim != nil {
+		proofType = req.Claim.ProofType
+		proof, err = worker.newClaimProof(req.Claim)
+	} else {
+		proof, err = worker.newProof(proofType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.Sample != "" {
+		if err := proofs.SelectSample(proof, req.Sample); err != nil {
+			return nil, err
+		}
+	}
+
+	binding := req.binding()
+	if req.VCF != nil && (worker.generatesWithOptions(binding, req.DebugWitness) || worker.InputFormat != "" || len(worker.Liftover) > 0 || req.Attestation != nil) {
+		// Seeded, bound, witness-recording, stored-key and cached generation
+		// read the VCF from a file, as do checking its format, lifting it
+		// over and checking its attestation. A spooled
+		// VCF has no commitment sidecar, so no commitment check is made.
+		vcfPath, cleanup, err := proofs.SpoolVCF(req.VCF)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		req.VCF, req.VCFPath = nil, vcfPath
+	}
+
+	attestation, err := req.attestation()
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var proofData *ProofData
+	inputs := map[string]string{"vcf": req.VCFPath}
+	if req.Sample != "" {
+		inputs["sample"] = req.Sample
+	}
+	if req.VCF != nil {
+		proofData, err = proofs.GenerateFromReaderContext(ctx, proof, req.VCF)
+	} else {
+		vcfPaths := []string{req.VCFPath}
+		if kinship, ok := proof.(*proofs.KinshipProof); ok && kinship.ParentVCF != "" {
+			vcfPaths = append(vcfPaths, kinship.ParentVCF)
+			inputs["parent_vcf"] = kinship.ParentVCF
+		}
+		proofData, err = worker.generateCommitted(ctx, proof, vcfPaths, req.OutputPath, binding, req.DebugWitness)
+	}
+
+	response := &ProofResponse{
+		ProofType: proofType,
+		ProofData: proofData,
+		Timings:   timer.timings(time.Since(start)),
+		Metadata:  req.Metadata,
+	}
+	if proofData != nil {
+		proofData.ProofType = string(proofType)
+		if err == nil {
+			proofData.Provenance = attestation
+			if req.Claim != nil {
+				proofData.Parameters = req.Claim.parameters()
+			}
+		}
+		response.Constraints = proofData.Constraints
+		if values, err := proofs.PublicValues(proofData); err == nil {
+			response.PublicInputs = len(values)
+		}
+	}
+	if err == nil && pg.Seed != nil {
+		response.Manifest, err = proofs.NewReproducibilityManifest(string(proofType), proofData, pg.Seed, inputs)
+	}
+	return response, err
+}
+
+// binding returns the binding req asks for, or nil if it asks for none
+func (req *ProofRequest) binding() *Binding {
+	if req.NotBefore.IsZero() && req.NotAfter.IsZero() && len(req.Nonce) == 0 && req.HolderKey == nil {
+		return nil
+	}
+	binding := &Binding{NotBefore: req.NotBefore, NotAfter: req.NotAfter, Nonce: req.Nonce}
+	if req.HolderKey != nil {
+		binding.HolderKeyHash = proofs.HolderKeyHash(req.HolderKey)
+	}
+	return binding
+}
+
+// attestation returns the attestation to embed in the proof req asks for,
+// checked against its genome, or nil if the genome is not attested
+func (req *ProofRequest) attestation() (*GenomeAttestation, error) {
+	if req.VCF != nil {
+		return nil, nil
+	}
+	attestation := req.Attestation
+	if attestation == nil {
+		loaded, err := proofs.LoadGenomeAttestation(req.VCFPath)
+		if err != nil || loaded == nil {
+			return nil, err
+		}
+		attestation = loaded
+	}
+	if err := attestation.Check(req.VCFPath); err != nil {
+		return nil, err
+	}
+	return attestation, nil
+}
+
+// keys returns the key store holding the keys req names, or nil if it names
+// none
+func (req *ProofRequest) keys() (KeyStore, error) {
+	switch {
+	case req.ProvingKey != nil || req.VerifyingKey != nil:
+		if req.ProvingKey == nil || req.VerifyingKey == nil {
+			return nil, fmt.Errorf("proving with provided keys requires both a proving and a verifying key")
+		}
+		return &proofs.ProvidedKeys{ProvingKey: req.ProvingKey, VerifyingKey: req.VerifyingKey}, nil
+	case req.ProvingKeyPath != "":
+		return proofs.FileKeys(req.ProvingKeyPath), nil
+	}
+	return nil, nil
+}
+
+// stageTimer forwards progress updates while timing each stage from its
+// first update to its update at 100%
+type stageTimer struct {
+	progress ProgressReporter
+
+	mu        sync.Mutex
+	starts    map[string]time.Time
+	durations ProofTimings
+}
+
+func (t *stageTimer) report(stage string, percent float64, message string) {
+	t.mu.Lock()
+	now := time.Now()
+	start, started := t.starts[stage]
+	if !started && percent < 100 {
+		t.starts[stage] = now
+	} else if started && percent >= 100 {
+		delete(t.starts, stage)
+		if d := t.stage(stage); d != nil {
+			*d += now.Sub(start)
+		}
+	}
+	t.mu.Unlock()
+
+	if t.progress != nil {
+		t.progress(stage, percent, message)
+	}
+}
+
+// stage returns the duration a stage is accounted to, or nil if it is not timed
+func (t *stageTimer) stage(stage string) *time.Duration {
+	switch stage {
+	case "scan":
+		return &t.durations.Scan
+	case "compile":
+		return &t.durations.Compile
+	case "setup":
+		return &t.durations.Setup
+	case "witness":
+		return &t.durations.Witness
+	case "prove":
+		return &t.durations.Prove
+	default:
+		return nil
+	}
+}
+
+func (t *stageTimer) timings(total time.Duration) ProofTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := t.durations
+	timings.Total = total
+	return timings
+}
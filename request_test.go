@@ -0,0 +1,294 @@
+package zkgenomics
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProofGenerator_Generate(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"15\t28365618\trs12913832\tA\tG\t60\tPASS\t.\tGT\t0/1\n"
+
+	pg := NewProofGenerator(WithInsecureBundledKeys())
+	response, err := pg.Generate(context.Background(), ProofRequest{
+		Claim: &ClaimSpec{
+			ProofType:  DynamicProofType,
+			Chromosome: 15,
+			Position:   28365618,
+			Ref:        "A",
+			Alt:        "G",
+			Mode:       ClaimCarrier,
+		},
+		VCF:      strings.NewReader(vcf),
+		Metadata: map[string]string{"order": "42"},
+	})
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	if response.ProofType != DynamicProofType || response.ProofData.Result != ProofSuccess {
+		t.Errorf("Expected a successful dynamic proof, got %s %s", response.ProofType, response.ProofData.Result)
+	}
+	if response.Metadata["order"] != "42" {
+		t.Errorf("Expected request metadata on the response, got %v", response.Metadata)
+	}
+	if response.Constraints == 0 || response.PublicInputs != 5 {
+		t.Errorf("Expected circuit stats, got %d constraints and %d public inputs", response.Constraints, response.PublicInputs)
+	}
+	timings := response.Timings
+	if timings.Compile <= 0 || timings.Setup <= 0 || timings.Prove <= 0 || timings.Total < timings.Compile+timings.Setup+timings.Prove {
+		t.Errorf("Expected stage timings within the total, got %+v", timings)
+	}
+
+	if response.ProofData.ProofType != string(DynamicProofType) {
+		t.Errorf("Expected the proof to record its type, got %q", response.ProofData.ProofType)
+	}
+	mismatch, err := pg.VerifyProofData(MTHFRProofType, response.ProofData)
+	var typeErr *ProofTypeMismatchError
+	if err != nil || !errors.As(mismatch.Error, &typeErr) {
+		t.Errorf("Expected ProofTypeMismatchError verifying as mthfr, got %v: %v", mismatch, err)
+	}
+
+	verified, err := pg.VerifyProofData(DynamicProofType, response.ProofData)
+	if err != nil || verified.ParsedPublicInputs["ClaimMode"] != "3" {
+		t.Errorf("Expected verification to disclose ClaimMode = 3, got %v: %v", verified, err)
+	}
+
+	result, err := pg.VerifyClaims(response.ProofData, []PublicValue{{Name: "ClaimMode", Value: "3"}})
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected the carrier claim to be proven, got %v: %v", result, err)
+	}
+
+	params := response.ProofData.Parameters
+	if params == nil || params.Position != 28365618 || params.Mode != "carrier" {
+		t.Fatalf("Expected the proof to record its variant and mode, got %+v", params)
+	}
+	params.Position++
+	if tampered, err := pg.VerifyProofData(DynamicProofType, response.ProofData); err != nil || tampered.Result != ProofFail {
+		t.Errorf("Expected parameters disagreeing with the LocusHash to fail verification, got %v: %v", tampered, err)
+	}
+	params.Position--
+
+	if _, err := pg.Generate(context.Background(), ProofRequest{ProofType: "unknown"}); err == nil {
+		t.Error("Expected an unsupported proof type to be refused")
+	}
+}
+
+func TestProofGenerator_Generate_Liftover(t *testing.T) {
+	// rs671 at its GRCh38 position; the built-in ALDH2 trait is GRCh37
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##contig=<ID=1,length=248956422>\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"12\t111803962\trs671\tG\tA\t60\tPASS\t.\tGT\t0/1\n"
+	vcfPath := filepath.Join(t.TempDir(), "grch38.vcf")
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buildErr *BuildMismatchError
+	_, err := NewProofGenerator().GenerateProof(ALDH2ProofType, vcfPath, "", "")
+	if !errors.As(err, &buildErr) || buildErr.GenomeBuild != BuildGRCh38 || buildErr.LocusBuild != BuildGRCh37 {
+		t.Fatalf("Expected a BuildMismatchError for a GRCh38 genome, got %v", err)
+	}
+
+	blocks := []LiftoverBlock{{Chromosome: "12", Start: 112241000, End: 112242000, Offset: -437804}}
+	pg := NewProofGenerator(WithLiftover(BuildGRCh37, blocks))
+	proofData, err := pg.GenerateProof(ALDH2ProofType, vcfPath, "", "")
+	if err != nil || proofData.Result != ProofSuccess {
+		t.Fatalf("Expected the lifted genome to be proven, got %v", err)
+	}
+}
+
+func TestProofGenerator_Generate_Seeded(t *testing.T) {
+	vcfPath := filepath.Join(t.TempDir(), "test.vcf")
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t1/1\n"
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatalf("writing test VCF: %v", err)
+	}
+
+	pg := NewProofGenerator(WithSeed([]byte("audit")))
+	var responses []*ProofResponse
+	for range 2 {
+		response, err := pg.Generate(context.Background(), ProofRequest{ProofType: ACTN3ProofType, VCFPath: vcfPath})
+		if err != nil {
+			t.Fatalf("Generate should not return error: %v", err)
+		}
+		responses = append(responses, response)
+	}
+	if !bytes.Equal(responses[0].ProofData.Proof, responses[1].ProofData.Proof) {
+		t.Error("Expected seeded generation to reproduce the proof")
+	}
+	manifest := responses[0].Manifest
+	if manifest == nil || manifest.ProofType != string(ACTN3ProofType) || manifest.Inputs["vcf"] == "" {
+		t.Errorf("Expected a reproducibility manifest for the seeded proof, got %+v", manifest)
+	}
+
+	fromReader, err := pg.Generate(context.Background(), ProofRequest{ProofType: ACTN3ProofType, VCF: strings.NewReader(vcf)})
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if !bytes.Equal(responses[0].ProofData.Proof, fromReader.ProofData.Proof) {
+		t.Error("Expected seeded generation from a reader to reproduce the proof")
+	}
+}
+
+func TestProofGenerator_Generate_NotAfter(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t1/1\n"
+
+	pg := NewProofGenerator(WithInsecureBundledKeys())
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+	response, err := pg.Generate(context.Background(), ProofRequest{
+		ProofType: ACTN3ProofType,
+		VCF:       strings.NewReader(vcf),
+		NotAfter:  notAfter,
+	})
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if response.ProofData.Binding == nil || !response.ProofData.Binding.NotAfter.Equal(notAfter) {
+		t.Fatalf("Expected the proof to record its expiry, got %+v", response.ProofData.Binding)
+	}
+
+	result, err := pg.VerifyProofData(ACTN3ProofType, response.ProofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected an unexpired proof to verify, got %v: %v", result, err)
+	}
+}
+
+func TestProofGenerator_VerifyProofDataWithNonce(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t1/1\n"
+
+	pg := NewProofGenerator(WithInsecureBundledKeys())
+	response, err := pg.Generate(context.Background(), ProofRequest{
+		ProofType: ACTN3ProofType,
+		VCF:       strings.NewReader(vcf),
+		Nonce:     []byte("challenge"),
+	})
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+
+	result, err := pg.VerifyProofDataWithNonce(ACTN3ProofType, response.ProofData, []byte("challenge"))
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected the proof to verify with its nonce, got %v: %v", result, err)
+	}
+	result, err = pg.VerifyProofDataWithNonce(ACTN3ProofType, response.ProofData, []byte("other"))
+	if err != nil || result.Result != ProofFail || !errors.Is(result.Error, ErrNonceMismatch) {
+		t.Errorf("Expected another nonce to fail with ErrNonceMismatch, got %v: %v", result, err)
+	}
+}
+
+func TestProofGenerator_Generate_ProvingKeyPath(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/1\n"
+
+	dir := t.TempDir()
+	circuit, err := NewProofGenerator(WithKeyStore(&FileKeyStore{Dir: dir})).Setup(ALDH2ProofType, "")
+	if err != nil {
+		t.Fatalf("Setup should not return error: %v", err)
+	}
+	keyPath := filepath.Join(dir, circuit.String())
+	vkBytes, err := os.ReadFile(keyPath + ".vk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pg := NewProofGenerator()
+	response, err := pg.Generate(context.Background(), ProofRequest{
+		ProofType:      ALDH2ProofType,
+		VCF:            strings.NewReader(vcf),
+		ProvingKeyPath: keyPath + ".pk",
+	})
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if !bytes.Equal(response.ProofData.VerifyingKey, vkBytes) {
+		t.Error("Expected the proof to carry the verifying key stored by Setup")
+	}
+	if response.Timings.Setup != 0 {
+		t.Errorf("Expected no setup when proving with stored keys, took %s", response.Timings.Setup)
+	}
+
+	result, err := pg.VerifyProofWithKey(context.Background(), ALDH2ProofType, response.ProofData, FromBytes(vkBytes))
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected the proof to verify against the stored key, got %v: %v", result, err)
+	}
+
+	if _, err := pg.Generate(context.Background(), ProofRequest{
+		ProofType:  ALDH2ProofType,
+		VCF:        strings.NewReader(vcf),
+		ProvingKey: FromBytes(nil),
+	}); err == nil {
+		t.Error("Expected an error for a proving key without its verifying key")
+	}
+}
+
+func TestProofGenerator_Generate_Attested(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t1/1\n"
+	vcfPath := filepath.Join(t.TempDir(), "sample.vcf")
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatal(err)
+	}
+	labPublic, labKey, _ := ed25519.GenerateKey(nil)
+	signer, err := NewEnvelopeSigner("lab", labKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AttestGenome(vcfPath, signer); err != nil {
+		t.Fatalf("AttestGenome should not return error: %v", err)
+	}
+
+	pg := NewProofGenerator(WithInsecureBundledKeys(), WithLabKeys(map[string]crypto.PublicKey{"lab": labPublic}))
+	response, err := pg.Generate(context.Background(), ProofRequest{ProofType: ACTN3ProofType, VCFPath: vcfPath})
+	if err != nil {
+		t.Fatalf("Generate should not return error: %v", err)
+	}
+	if response.ProofData.Provenance == nil {
+		t.Fatal("Expected the proof to carry the lab attestation")
+	}
+	// ACTN3 proofs disclose no Merkle root, so the attestation beside them
+	// could have been copied from another proof
+	result, err := pg.VerifyProofData(ACTN3ProofType, response.ProofData)
+	if err != nil || !errors.Is(result.Error, ErrUnboundAttestation) {
+		t.Errorf("Expected an attested proof without a root to fail with ErrUnboundAttestation, got %v: %v", result, err)
+	}
+
+	response.ProofData.Provenance = nil
+	result, err = pg.VerifyProofData(ACTN3ProofType, response.ProofData)
+	if err != nil || !errors.Is(result.Error, ErrUnattestedGenome) {
+		t.Errorf("Expected a proof without provenance to fail with ErrUnattestedGenome, got %v: %v", result, err)
+	}
+
+	if err := os.WriteFile(vcfPath, []byte(strings.Replace(vcf, "1/1", "0/1", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var mismatch *AttestationMismatchError
+	if _, err := pg.Generate(context.Background(), ProofRequest{ProofType: ACTN3ProofType, VCFPath: vcfPath}); !errors.As(err, &mismatch) {
+		t.Errorf("Expected an edited VCF to fail with AttestationMismatchError, got %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package zkgenomics
+
+import (
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// RevocationChecker re-exports the interface consulted for revoked proofs
+type RevocationChecker = proofs.RevocationChecker
+
+// MemoryRevocationList re-exports the in-memory RevocationChecker
+type MemoryRevocationList = proofs.MemoryRevocationList
+
+// FileRevocationList re-exports the file-backed RevocationChecker
+type FileRevocationList = proofs.FileRevocationList
+
+// RevokedError re-exports the error reported for revoked proofs
+type RevokedError = proofs.RevokedError
+
+// NewMemoryRevocationList creates an in-memory revocation list with ids revoked
+func NewMemoryRevocationList(ids ...string) *MemoryRevocationList {
+	return proofs.NewMemoryRevocationList(ids...)
+}
+
+// ProofID identifies proofData for revocation by its verifying key and
+// public inputs, so re-encoding the proof does not change it
+func ProofID(proofData *ProofData) string {
+	return proofs.ProofID(proofData)
+}
+
+// RevocationIDs returns the IDs proofData can be revoked by: its proof ID, the
+// digest of its proof bytes that earlier releases used as the proof ID and,
+// for proofs bound to a committed genome, the genome's Merkle root
+func RevocationIDs(proofData *ProofData) []string {
+	return proofs.RevocationIDs(proofData)
+}
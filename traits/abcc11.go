@@ -0,0 +1,29 @@
+package traits
+
+// ABCC11Variant is rs17822931 (538G>A, Gly180Arg). The ALT allele is
+// recessive: only homozygous carriers have dry earwax and reduced body odor.
+var ABCC11Variant = bundledVariant("abcc11")
+
+// EarwaxType is the public encoding of ABCC11 earwax type
+type EarwaxType int
+
+const (
+	EarwaxUnknown EarwaxType = iota
+	EarwaxWet
+	EarwaxDry
+)
+
+// String returns string representation of EarwaxType
+func (e EarwaxType) String() string {
+	switch e {
+	case EarwaxWet:
+		return "wet"
+	case EarwaxDry:
+		return "dry"
+	default:
+		return "unknown"
+	}
+}
+
+// ABCC11Claims maps the rs17822931 genotype to its public earwax type
+var ABCC11Claims = bundled.Claims("abcc11")
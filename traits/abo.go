@@ -0,0 +1,52 @@
+package traits
+
+// BloodGroup is the public encoding of an ABO blood group used as a circuit output
+type BloodGroup int
+
+const (
+	BloodGroupUnknown BloodGroup = iota
+	BloodGroupA
+	BloodGroupB
+	BloodGroupAB
+	BloodGroupO
+)
+
+// String returns string representation of BloodGroup
+func (g BloodGroup) String() string {
+	switch g {
+	case BloodGroupA:
+		return "A"
+	case BloodGroupB:
+		return "B"
+	case BloodGroupAB:
+		return "AB"
+	case BloodGroupO:
+		return "O"
+	default:
+		return "unknown"
+	}
+}
+
+// ABOFunctionalVariant is rs8176719. GRCh37 carries the O allele (the 261delG
+// frameshift) as reference, so the ALT insertion marks a functional A or B allele.
+var ABOFunctionalVariant = bundledVariant("abo_functional")
+
+// ABOBVariant is rs8176746, whose ALT allele distinguishes B from A on a functional allele
+var ABOBVariant = bundledVariant("abo_b")
+
+// ABOTable maps [rs8176719 genotype][rs8176746 genotype] to a blood group.
+// Genotypes count ALT alleles (0, 1 or 2). Combinations with more B alleles than
+// functional alleles cannot occur and map to BloodGroupUnknown.
+var ABOTable = [3][3]BloodGroup{
+	{BloodGroupO, BloodGroupUnknown, BloodGroupUnknown},
+	{BloodGroupA, BloodGroupB, BloodGroupUnknown},
+	{BloodGroupA, BloodGroupAB, BloodGroupB},
+}
+
+// BloodGroupFromGenotypes looks up the blood group for the two ABO genotypes
+func BloodGroupFromGenotypes(functional, b int) BloodGroup {
+	if functional < 0 || functional > 2 || b < 0 || b > 2 {
+		return BloodGroupUnknown
+	}
+	return ABOTable[functional][b]
+}
@@ -0,0 +1,32 @@
+package traits
+
+// ACTN3Variant is rs1815739 (R577X). The ALT allele introduces a premature stop
+// codon (X), so genotype 0 is RR, 1 is RX and 2 is XX.
+var ACTN3Variant = bundledVariant("actn3")
+
+// ACTN3Genotype is the public encoding of ACTN3 R577X status
+type ACTN3Genotype int
+
+const (
+	ACTN3Unknown ACTN3Genotype = iota
+	ACTN3RR
+	ACTN3RX
+	ACTN3XX
+)
+
+// String returns string representation of ACTN3Genotype
+func (g ACTN3Genotype) String() string {
+	switch g {
+	case ACTN3RR:
+		return "RR"
+	case ACTN3RX:
+		return "RX"
+	case ACTN3XX:
+		return "XX"
+	default:
+		return "unknown"
+	}
+}
+
+// ACTN3Claims maps the rs1815739 genotype (0, 1, 2) to its public claim value
+var ACTN3Claims = bundled.Claims("actn3")
@@ -0,0 +1,9 @@
+package traits
+
+// ALDH2Variant is rs671 (ALDH2*2, E504K). A single ALT allele is enough to
+// impair acetaldehyde metabolism, causing the alcohol flush reaction.
+var ALDH2Variant = bundledVariant("aldh2")
+
+// ALDH2Claims maps the rs671 genotype to the public deficiency claim
+// (0 = not deficient, 1 = deficient)
+var ALDH2Claims = bundled.Claims("aldh2")
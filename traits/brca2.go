@@ -0,0 +1,11 @@
+package traits
+
+// BRCA2Region spans the BRCA2 gene on chromosome 13
+var BRCA2Region = bundledVariant("brca2_5946delt").Region
+
+// BRCA2PathogenicVariants is the default BRCA2 carrier panel. It holds the
+// c.5946delT (6174delT) founder variant; callers screening for more variants
+// supply their own curated panel.
+var BRCA2PathogenicVariants = []TraitVariant{
+	bundledVariant("brca2_5946delt"),
+}
@@ -0,0 +1,52 @@
+package traits
+
+import "strings"
+
+// GenomeBuild identifies the reference assembly coordinates refer to
+type GenomeBuild string
+
+const (
+	BuildUnknown GenomeBuild = ""
+	BuildGRCh37  GenomeBuild = "GRCh37"
+	BuildGRCh38  GenomeBuild = "GRCh38"
+)
+
+// contigLengths holds the lengths of chromosomes that differ between builds,
+// by chromosome name without a "chr" prefix
+var contigLengths = map[string]map[uint64]GenomeBuild{
+	"1": {249250621: BuildGRCh37, 248956422: BuildGRCh38},
+	"2": {243199373: BuildGRCh37, 242193529: BuildGRCh38},
+	"X": {155270560: BuildGRCh37, 156040895: BuildGRCh38},
+	"Y": {59373566: BuildGRCh37, 57227415: BuildGRCh38},
+}
+
+// BuildFromHeaderLine infers the reference assembly from a VCF ##reference or
+// ##assembly header line, returning BuildUnknown for any other line
+func BuildFromHeaderLine(line string) GenomeBuild {
+	if !strings.HasPrefix(line, "##reference=") && !strings.HasPrefix(line, "##assembly=") {
+		return BuildUnknown
+	}
+	return BuildFromName(line)
+}
+
+// BuildFromName infers the reference assembly from a name such as "GRCh38",
+// "hg19" or a reference FASTA path mentioning one, returning BuildUnknown if
+// it names neither build
+func BuildFromName(name string) GenomeBuild {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, "grch38") || strings.Contains(lower, "hg38"):
+		return BuildGRCh38
+	case strings.Contains(lower, "grch37") || strings.Contains(lower, "hg19") || strings.Contains(lower, "b37"):
+		return BuildGRCh37
+	}
+	return BuildUnknown
+}
+
+// BuildFromContig infers the reference assembly from a ##contig header line's
+// ID and length, which differ between builds for most chromosomes. It returns
+// BuildUnknown for chromosomes whose length it does not know.
+func BuildFromContig(id string, length uint64) GenomeBuild {
+	chrom := strings.ToUpper(strings.TrimPrefix(strings.TrimPrefix(id, "chr"), "CHR"))
+	return contigLengths[chrom][length]
+}
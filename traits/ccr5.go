@@ -0,0 +1,32 @@
+package traits
+
+// CCR5Delta32Variant is rs333, the 32 bp CCR5-Δ32 deletion. It is an indel,
+// so REF spans the anchor base plus the deleted sequence.
+var CCR5Delta32Variant = bundledVariant("ccr5_delta32")
+
+// DeletionStatus is the public encoding of how many copies of a deletion are carried
+type DeletionStatus int
+
+const (
+	DeletionUnknown DeletionStatus = iota
+	DeletionAbsent
+	DeletionHeterozygous
+	DeletionHomozygous
+)
+
+// String returns string representation of DeletionStatus
+func (s DeletionStatus) String() string {
+	switch s {
+	case DeletionAbsent:
+		return "absent"
+	case DeletionHeterozygous:
+		return "heterozygous"
+	case DeletionHomozygous:
+		return "homozygous"
+	default:
+		return "unknown"
+	}
+}
+
+// CCR5Delta32Claims maps the rs333 genotype to its public deletion status
+var CCR5Delta32Claims = bundled.Claims("ccr5_delta32")
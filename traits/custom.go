@@ -0,0 +1,102 @@
+package traits
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CustomTrait is a user-defined trait read at one locus, so novel traits can
+// be proven without code. Its outcomes map the genotypes of the locus to what
+// they mean; a proof claims the outcome of the genome.
+type CustomTrait struct {
+	Name         string `json:"name" yaml:"name"`
+	TraitVariant `yaml:",inline"`
+	Outcomes     []TraitOutcome `json:"outcomes" yaml:"outcomes"`
+}
+
+// TraitOutcome is an outcome of a custom trait and the genotypes, counted in
+// ALT alleles (0, 1 or 2), that have it
+type TraitOutcome struct {
+	Label     string `json:"label" yaml:"label"`
+	Genotypes []int  `json:"genotypes" yaml:"genotypes"`
+}
+
+// LoadCustomTrait reads a custom trait from the JSON or YAML file at path and
+// validates it
+func LoadCustomTrait(path string) (*CustomTrait, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var trait CustomTrait
+	if err := yaml.Unmarshal(data, &trait); err != nil {
+		return nil, fmt.Errorf("decoding custom trait: %w", err)
+	}
+	if err := trait.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &trait, nil
+}
+
+// Validate checks that the trait is named, names a locus and has at least one
+// outcome, that outcomes are labelled uniquely, and that each genotype of 0,
+// 1 or 2 belongs to at most one outcome
+func (t *CustomTrait) Validate() error {
+	switch {
+	case t.Name == "":
+		return errors.New("custom trait has no name")
+	case t.Position <= 0:
+		return fmt.Errorf("custom trait %s has no position", t.Name)
+	case t.Ref == "" || t.Alt == "":
+		return fmt.Errorf("custom trait %s has no ref and alt alleles", t.Name)
+	case len(t.Outcomes) == 0:
+		return fmt.Errorf("custom trait %s has no outcomes", t.Name)
+	}
+
+	labels := make(map[string]bool, len(t.Outcomes))
+	owner := make(map[int]string, 3)
+	for i, outcome := range t.Outcomes {
+		switch {
+		case outcome.Label == "":
+			return fmt.Errorf("custom trait %s: outcome %d has no label", t.Name, i+1)
+		case labels[outcome.Label]:
+			return fmt.Errorf("custom trait %s: outcome %s is listed twice", t.Name, outcome.Label)
+		case len(outcome.Genotypes) == 0:
+			return fmt.Errorf("custom trait %s: outcome %s has no genotypes", t.Name, outcome.Label)
+		}
+		labels[outcome.Label] = true
+		for _, genotype := range outcome.Genotypes {
+			if genotype < 0 || genotype > 2 {
+				return fmt.Errorf("custom trait %s: outcome %s has genotype %d, outside 0..2", t.Name, outcome.Label, genotype)
+			}
+			if other, ok := owner[genotype]; ok {
+				return fmt.Errorf("custom trait %s: genotype %d belongs to both %s and %s", t.Name, genotype, other, outcome.Label)
+			}
+			owner[genotype] = outcome.Label
+		}
+	}
+	return nil
+}
+
+// Outcome returns the outcome of genotype, or false if no outcome lists it
+func (t *CustomTrait) Outcome(genotype int) (TraitOutcome, bool) {
+	for _, outcome := range t.Outcomes {
+		if slices.Contains(outcome.Genotypes, genotype) {
+			return outcome, true
+		}
+	}
+	return TraitOutcome{}, false
+}
+
+// LookupOutcome returns the outcome labelled label, or false if there is none
+func (t *CustomTrait) LookupOutcome(label string) (TraitOutcome, bool) {
+	i := slices.IndexFunc(t.Outcomes, func(outcome TraitOutcome) bool { return outcome.Label == label })
+	if i < 0 {
+		return TraitOutcome{}, false
+	}
+	return t.Outcomes[i], true
+}
@@ -0,0 +1,174 @@
+package traits
+
+import (
+	"slices"
+	"strings"
+)
+
+// AlleleFunction is the CPIC functional classification of a star allele
+type AlleleFunction int
+
+const (
+	NormalFunction AlleleFunction = iota
+	DecreasedFunction
+	NoFunction
+)
+
+// NormalActivityUnits is the activity value of a normal function allele.
+// Activity values are counted in quarter units, so that activity scores stay
+// integral inside circuits (normal = 1.0 = 4 units, *10 = 0.25 = 1 unit).
+const NormalActivityUnits = 4
+
+// StarAllele ties a pharmacogene star allele to the SNP that defines it
+type StarAllele struct {
+	Name     string
+	Variant  TraitVariant
+	Function AlleleFunction
+	// ActivityUnits is the CPIC activity value of the allele in quarter units
+	ActivityUnits int
+	// Carries lists the alleles whose defining SNP this allele's haplotype
+	// also carries, so copies of this allele do not call them
+	Carries []string
+}
+
+// CYP2D6StarAlleles is the SNP panel used to call CYP2D6 star alleles (GRCh37).
+// Alleles carrying none of these variants are treated as *1 (normal function).
+// An allele is listed before the alleles its haplotype carries the SNPs of.
+var CYP2D6StarAlleles = []StarAllele{
+	{
+		Name:          "*4",
+		Variant:       bundledVariant("cyp2d6_4"),
+		Function:      NoFunction,
+		ActivityUnits: 0,
+		// *4 haplotypes carry 100C>T, the SNP that defines *10
+		Carries: []string{"*10"},
+	},
+	{
+		Name:          "*10",
+		Variant:       bundledVariant("cyp2d6_10"),
+		Function:      DecreasedFunction,
+		ActivityUnits: 1,
+	},
+	{
+		Name:          "*41",
+		Variant:       bundledVariant("cyp2d6_41"),
+		Function:      DecreasedFunction,
+		ActivityUnits: 2,
+	},
+}
+
+// CYP2D6StarAlleles returns the CYP2D6 star-allele panel with the defining
+// SNP of each allele read from r, under the trait name cyp2d6_ followed by the
+// allele number, such as cyp2d6_4 for *4
+func (r *Registry) CYP2D6StarAlleles() []StarAllele {
+	alleles := slices.Clone(CYP2D6StarAlleles)
+	for i := range alleles {
+		alleles[i].Variant = r.Variant("cyp2d6_" + strings.TrimPrefix(alleles[i].Name, "*"))
+	}
+	return alleles
+}
+
+// CYP2D6HaplotypeCarriers returns, for each allele of CYP2D6StarAlleles, the
+// indices of the earlier alleles whose haplotypes carry its defining SNP
+func CYP2D6HaplotypeCarriers() [][]int {
+	carriers := make([][]int, len(CYP2D6StarAlleles))
+	for i, allele := range CYP2D6StarAlleles {
+		for j := range i {
+			if slices.Contains(CYP2D6StarAlleles[j].Carries, allele.Name) {
+				carriers[i] = append(carriers[i], j)
+			}
+		}
+	}
+	return carriers
+}
+
+// CallCYP2D6StarAlleles returns the number of copies of each allele of
+// CYP2D6StarAlleles given the genotypes of their defining SNPs. A SNP counts
+// towards its own allele only on the copies not explained by alleles whose
+// haplotypes carry it, so *4/*4 is called from two copies each of 1846G>A and
+// 100C>T. ok is false when the genotypes fit no pair of haplotypes.
+func CallCYP2D6StarAlleles(genotypes []int) (copies []int, ok bool) {
+	copies = make([]int, len(genotypes))
+	total := 0
+	for i, carriers := range CYP2D6HaplotypeCarriers() {
+		copies[i] = genotypes[i]
+		for _, j := range carriers {
+			copies[i] -= copies[j]
+		}
+		if copies[i] < 0 {
+			return nil, false
+		}
+		total += copies[i]
+	}
+	if total > 2 {
+		return nil, false
+	}
+	return copies, true
+}
+
+// MetabolizerStatus is the public encoding of a CYP2D6 metabolizer phenotype
+type MetabolizerStatus int
+
+const (
+	MetabolizerUnknown MetabolizerStatus = iota
+	PoorMetabolizer
+	IntermediateMetabolizer
+	NormalMetabolizer
+	UltrarapidMetabolizer
+)
+
+// String returns string representation of MetabolizerStatus
+func (s MetabolizerStatus) String() string {
+	switch s {
+	case PoorMetabolizer:
+		return "poor"
+	case IntermediateMetabolizer:
+		return "intermediate"
+	case NormalMetabolizer:
+		return "normal"
+	case UltrarapidMetabolizer:
+		return "ultrarapid"
+	default:
+		return "unknown"
+	}
+}
+
+// MaxDiploidActivityUnits is the highest activity score, in quarter units,
+// that two gene copies can reach. Ultrarapid status requires a gene
+// duplication, which a SNP panel cannot detect.
+const MaxDiploidActivityUnits = 2 * NormalActivityUnits
+
+// MetabolizerFromActivity maps an activity score in quarter units to a
+// phenotype following the CPIC activity score thresholds: 0 is poor, up to
+// 1.0 intermediate, up to 2.25 normal and above that ultrarapid
+func MetabolizerFromActivity(units int) MetabolizerStatus {
+	switch {
+	case units < 0:
+		return MetabolizerUnknown
+	case units == 0:
+		return PoorMetabolizer
+	case units <= 4:
+		return IntermediateMetabolizer
+	case units <= 9:
+		return NormalMetabolizer
+	default:
+		return UltrarapidMetabolizer
+	}
+}
+
+// CYP2D6Activity returns the diploid activity score in quarter units for the
+// genotypes of the defining SNPs of CYP2D6StarAlleles, or -1 when they fit no
+// pair of haplotypes
+func CYP2D6Activity(genotypes []int) int {
+	copies, ok := CallCYP2D6StarAlleles(genotypes)
+	if !ok {
+		return -1
+	}
+	variantAlleles := 0
+	units := 0
+	for i, n := range copies {
+		variantAlleles += n
+		units += n * CYP2D6StarAlleles[i].ActivityUnits
+	}
+	return units + (2-variantAlleles)*NormalActivityUnits
+}
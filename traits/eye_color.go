@@ -0,0 +1,34 @@
+package traits
+
+// HERC2Variant is rs12913832 in HERC2, which regulates OCA2 expression. Its
+// ALT G allele reduces iris pigmentation, so genotype 0 (A/A) predicts brown
+// eyes and 2 (G/G) blue eyes. Eye color and HERC2 proofs both read it.
+var HERC2Variant = bundledVariant("herc2")
+
+// EyeColorClaims maps the rs12913832 genotype to its public eye color
+var EyeColorClaims = bundled.Claims("herc2")
+
+// EyeColor is the public encoding of the eye color predicted from HERC2
+// rs12913832
+type EyeColor int
+
+const (
+	EyeColorUnknown EyeColor = iota
+	EyeColorBrown
+	EyeColorHazel
+	EyeColorBlue
+)
+
+// String returns string representation of EyeColor
+func (c EyeColor) String() string {
+	switch c {
+	case EyeColorBrown:
+		return "brown"
+	case EyeColorHazel:
+		return "hazel"
+	case EyeColorBlue:
+		return "blue"
+	default:
+		return "unknown"
+	}
+}
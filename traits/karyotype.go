@@ -0,0 +1,70 @@
+package traits
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Chromosome codes for the sex and mitochondrial chromosomes, numbered after
+// the autosomes
+const (
+	ChromosomeX  = 23
+	ChromosomeY  = 24
+	ChromosomeMT = 25
+)
+
+// ChromosomeCode maps a VCF chromosome name to its numeric code, returning 0
+// for unplaced and alternate contigs
+func ChromosomeCode(name string) int {
+	name = strings.ToUpper(strings.TrimPrefix(strings.TrimPrefix(name, "chr"), "CHR"))
+	switch name {
+	case "X":
+		return ChromosomeX
+	case "Y":
+		return ChromosomeY
+	case "M", "MT":
+		return ChromosomeMT
+	}
+	n, err := strconv.Atoi(name)
+	if err != nil || n < 1 || n > 22 {
+		return 0
+	}
+	return n
+}
+
+// Karyotype is the public encoding of the sex chromosome configuration
+type Karyotype int
+
+const (
+	KaryotypeUnknown Karyotype = iota
+	KaryotypeXX
+	KaryotypeXY
+)
+
+// String returns string representation of Karyotype
+func (k Karyotype) String() string {
+	switch k {
+	case KaryotypeXX:
+		return "XX"
+	case KaryotypeXY:
+		return "XY"
+	default:
+		return "unknown"
+	}
+}
+
+// YPseudoautosomalRegions returns PAR1 and PAR2 on chromosome Y. Reads from
+// these regions also map to X, so calls there do not indicate a Y chromosome.
+// Regions for both builds are returned when the build is unknown.
+func YPseudoautosomalRegions(build GenomeBuild) []TraitRegion {
+	grch37 := []TraitRegion{{Start: 10001, End: 2649520}, {Start: 59034050, End: 59363566}}
+	grch38 := []TraitRegion{{Start: 10001, End: 2781479}, {Start: 56887903, End: 57217415}}
+	switch build {
+	case BuildGRCh37:
+		return grch37
+	case BuildGRCh38:
+		return grch38
+	default:
+		return append(grch37, grch38...)
+	}
+}
@@ -0,0 +1,60 @@
+package traits
+
+// MTHFRC677TVariant is rs1801133. MTHFR lies on the minus strand, so the
+// c.677C>T change appears as G>A in genomic coordinates.
+var MTHFRC677TVariant = bundledVariant("mthfr_c677t")
+
+// MTHFRA1298CVariant is rs1801131, the c.1298A>C change, which appears as
+// T>G in genomic coordinates
+var MTHFRA1298CVariant = bundledVariant("mthfr_a1298c")
+
+// MTHFRStatus is the public encoding of the joint C677T/A1298C interpretation
+type MTHFRStatus int
+
+const (
+	MTHFRUnknown MTHFRStatus = iota
+	MTHFRTypical
+	MTHFRC677THeterozygous
+	MTHFRC677THomozygous
+	MTHFRA1298CHeterozygous
+	MTHFRA1298CHomozygous
+	MTHFRCompoundHeterozygous
+)
+
+// String returns string representation of MTHFRStatus
+func (s MTHFRStatus) String() string {
+	switch s {
+	case MTHFRTypical:
+		return "typical"
+	case MTHFRC677THeterozygous:
+		return "C677T heterozygous"
+	case MTHFRC677THomozygous:
+		return "C677T homozygous"
+	case MTHFRA1298CHeterozygous:
+		return "A1298C heterozygous"
+	case MTHFRA1298CHomozygous:
+		return "A1298C homozygous"
+	case MTHFRCompoundHeterozygous:
+		return "compound heterozygous"
+	default:
+		return "unknown"
+	}
+}
+
+// MTHFRTable maps [rs1801133 genotype][rs1801131 genotype] to a status.
+// Genotypes count ALT alleles (0, 1 or 2). The two variants are almost never
+// found on the same haplotype, so combinations that require it (three or
+// more variant alleles) map to MTHFRUnknown.
+var MTHFRTable = [3][3]MTHFRStatus{
+	{MTHFRTypical, MTHFRA1298CHeterozygous, MTHFRA1298CHomozygous},
+	{MTHFRC677THeterozygous, MTHFRCompoundHeterozygous, MTHFRUnknown},
+	{MTHFRC677THomozygous, MTHFRUnknown, MTHFRUnknown},
+}
+
+// MTHFRStatusFromGenotypes looks up the status for the two MTHFR genotypes
+func MTHFRStatusFromGenotypes(c677t, a1298c int) MTHFRStatus {
+	if c677t < 0 || c677t > 2 || a1298c < 0 || a1298c > 2 {
+		return MTHFRUnknown
+	}
+	return MTHFRTable[c677t][a1298c]
+}
@@ -0,0 +1,88 @@
+package traits
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TraitPanel is a named collection of trait variants, each with the
+// genotype claimed for it, that are proven together
+type TraitPanel struct {
+	Name     string         `json:"name" yaml:"name"`
+	Variants []PanelVariant `json:"variants" yaml:"variants"`
+}
+
+// PanelVariant is a variant of a panel and its claimed genotype, the number
+// of ALT alleles carried (0, 1 or 2)
+type PanelVariant struct {
+	TraitVariant `yaml:",inline"`
+	Genotype     int `json:"genotype" yaml:"genotype"`
+}
+
+// LoadTraitPanel reads a panel from the JSON or YAML file at path and
+// validates it
+func LoadTraitPanel(path string) (*TraitPanel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var panel TraitPanel
+	if err := yaml.Unmarshal(data, &panel); err != nil {
+		return nil, fmt.Errorf("decoding trait panel: %w", err)
+	}
+	if err := panel.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &panel, nil
+}
+
+// Validate checks that the panel is named and lists at least one variant,
+// that each variant names a locus and claims a genotype of 0, 1 or 2, that
+// no variant is listed twice, and that the variants share one build
+func (p *TraitPanel) Validate() error {
+	if p.Name == "" {
+		return errors.New("trait panel has no name")
+	}
+	if len(p.Variants) == 0 {
+		return fmt.Errorf("trait panel %s lists no variants", p.Name)
+	}
+
+	type locus struct {
+		chromosome int
+		position   int
+		ref, alt   string
+	}
+	seen := make(map[locus]bool, len(p.Variants))
+	build := BuildUnknown
+	for i, variant := range p.Variants {
+		name := variant.Trait
+		if name == "" {
+			name = fmt.Sprintf("variant %d", i+1)
+		}
+		switch {
+		case variant.Position <= 0:
+			return fmt.Errorf("trait panel %s: %s has no position", p.Name, name)
+		case variant.Ref == "" || variant.Alt == "":
+			return fmt.Errorf("trait panel %s: %s has no ref and alt alleles", p.Name, name)
+		case variant.Genotype < 0 || variant.Genotype > 2:
+			return fmt.Errorf("trait panel %s: %s claims genotype %d, outside 0..2", p.Name, name, variant.Genotype)
+		}
+
+		key := locus{variant.Chromosome, variant.Position, variant.Ref, variant.Alt}
+		if seen[key] {
+			return fmt.Errorf("trait panel %s: %s is listed twice", p.Name, name)
+		}
+		seen[key] = true
+
+		if variant.Build != BuildUnknown {
+			if build != BuildUnknown && variant.Build != build {
+				return fmt.Errorf("trait panel %s mixes %s and %s coordinates", p.Name, build, variant.Build)
+			}
+			build = variant.Build
+		}
+	}
+	return nil
+}
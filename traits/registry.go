@@ -0,0 +1,206 @@
+package traits
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// bundledDefinitions holds the definitions of the built-in traits
+//
+//go:embed registry.json
+var bundledDefinitions []byte
+
+// TraitDefinition is a trait as a registry holds it: the locus it is read
+// at, under a name proof types look it up by, and for traits proven as a
+// claim over one genotype, the public claim of each ALT dosage (0, 1, 2)
+type TraitDefinition struct {
+	Name         string `json:"name" yaml:"name"`
+	RsID         string `json:"rsid,omitempty" yaml:"rsid,omitempty"`
+	TraitVariant `yaml:",inline"`
+	Claims       []int `json:"claims,omitempty" yaml:"claims,omitempty"`
+}
+
+// validate checks the definition names a usable locus
+func (d TraitDefinition) validate() error {
+	switch {
+	case d.Name == "":
+		return fmt.Errorf("trait definition has no name")
+	case d.Position <= 0:
+		return fmt.Errorf("trait %s has no position", d.Name)
+	case d.Ref == "" || d.Alt == "":
+		return fmt.Errorf("trait %s has no ref and alt alleles", d.Name)
+	case d.Claims != nil && len(d.Claims) != 3:
+		return fmt.Errorf("trait %s maps %d genotypes to claims, expected 3", d.Name, len(d.Claims))
+	case d.RsID != "" && !IsRsID(d.RsID):
+		return fmt.Errorf("trait %s has invalid rsID %q", d.Name, d.RsID)
+	}
+	return nil
+}
+
+// Registry holds trait definitions by name. Proof types of the built-in
+// traits resolve their loci from one, so their coordinates can be replaced,
+// such as by those of another build, without changing code. A nil
+// *Registry is the bundled registry.
+type Registry struct {
+	definitions map[string]TraitDefinition
+}
+
+// bundled is the registry of the built-in traits, which the exported trait
+// variables are read from
+var bundled = mustParseRegistry(bundledDefinitions)
+
+func mustParseRegistry(data []byte) *Registry {
+	registry := &Registry{definitions: make(map[string]TraitDefinition)}
+	if err := registry.parse(data); err != nil {
+		panic(fmt.Sprintf("bundled trait registry: %v", err))
+	}
+	return registry
+}
+
+// BundledRegistry returns a copy of the registry of the built-in traits, to
+// which user definitions can be added with Load
+func BundledRegistry() *Registry {
+	registry := &Registry{definitions: make(map[string]TraitDefinition, len(bundled.definitions))}
+	for name, definition := range bundled.definitions {
+		registry.definitions[name] = definition
+	}
+	return registry
+}
+
+// LoadRegistry returns the bundled registry with the definitions in the
+// JSON or YAML files at paths added, in order
+func LoadRegistry(paths ...string) (*Registry, error) {
+	registry := BundledRegistry()
+	for _, path := range paths {
+		if err := registry.Load(path); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// Load adds the trait definitions in the JSON or YAML file at path, a list
+// of definitions. A definition replaces the one of the same name.
+func (r *Registry) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := r.parse(data); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// parse adds the definitions in data; YAML is a superset of JSON, so both
+// are read as YAML
+func (r *Registry) parse(data []byte) error {
+	var definitions []TraitDefinition
+	if err := yaml.Unmarshal(data, &definitions); err != nil {
+		return fmt.Errorf("decoding trait definitions: %w", err)
+	}
+	for _, definition := range definitions {
+		if err := r.Add(definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add adds definition, replacing the definition of the same name. A
+// definition replacing a trait proven as a claim must define its claims too.
+func (r *Registry) Add(definition TraitDefinition) error {
+	if err := definition.validate(); err != nil {
+		return err
+	}
+	definition.Name = strings.ToLower(definition.Name)
+	definition.RsID = strings.ToLower(definition.RsID)
+	if replaced, ok := r.definitions[definition.Name]; ok && replaced.Claims != nil && definition.Claims == nil {
+		return fmt.Errorf("trait %s maps genotypes to claims, but its replacement defines none", definition.Name)
+	}
+	if r.definitions == nil {
+		r.definitions = make(map[string]TraitDefinition)
+	}
+	r.definitions[definition.Name] = definition
+	return nil
+}
+
+// Lookup returns the definition of the trait name
+func (r *Registry) Lookup(name string) (TraitDefinition, bool) {
+	if r == nil {
+		r = bundled
+	}
+	definition, ok := r.definitions[strings.ToLower(name)]
+	return definition, ok
+}
+
+// LookupRsID returns the definition of the trait read at rsID
+func (r *Registry) LookupRsID(rsID string) (TraitDefinition, bool) {
+	if r == nil {
+		r = bundled
+	}
+	rsID = strings.ToLower(rsID)
+	for _, name := range r.Names() {
+		if definition := r.definitions[name]; definition.RsID == rsID {
+			return definition, true
+		}
+	}
+	return TraitDefinition{}, false
+}
+
+// Names returns the names of the registered traits, sorted
+func (r *Registry) Names() []string {
+	if r == nil {
+		r = bundled
+	}
+	names := make([]string, 0, len(r.definitions))
+	for name := range r.definitions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Variant returns the locus of the trait name, or the zero TraitVariant if
+// it is not registered
+func (r *Registry) Variant(name string) TraitVariant {
+	definition, _ := r.Lookup(name)
+	return definition.TraitVariant
+}
+
+// Claims returns the public claim of each genotype of the trait name, or
+// all zeros if it defines none
+func (r *Registry) Claims(name string) [3]int {
+	var claims [3]int
+	definition, _ := r.Lookup(name)
+	copy(claims[:], definition.Claims)
+	return claims
+}
+
+// RsIDTable returns the loci of the registered traits by rsID
+func (r *Registry) RsIDTable() map[string]TraitVariant {
+	if r == nil {
+		r = bundled
+	}
+	table := make(map[string]TraitVariant)
+	for _, definition := range r.definitions {
+		if definition.RsID != "" {
+			table[definition.RsID] = definition.TraitVariant
+		}
+	}
+	return table
+}
+
+// bundledVariant returns the locus of the built-in trait name
+func bundledVariant(name string) TraitVariant {
+	definition, ok := bundled.Lookup(name)
+	if !ok {
+		panic(fmt.Sprintf("bundled trait registry has no trait %s", name))
+	}
+	return definition.TraitVariant
+}
@@ -0,0 +1,23 @@
+package traits
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RsIDTableBuild is the reference assembly of the coordinates in RsIDTable
+const RsIDTableBuild = BuildGRCh37
+
+// RsIDTable is the bundled rsID lookup table, covering the variants behind the
+// built-in traits. It is used when a VCF does not carry rsIDs in its ID column.
+var RsIDTable = bundled.RsIDTable()
+
+// IsRsID reports whether s is a dbSNP reference SNP identifier such as "rs12913832"
+func IsRsID(s string) bool {
+	digits, ok := strings.CutPrefix(strings.ToLower(s), "rs")
+	if !ok || digits == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(digits, 10, 64)
+	return err == nil
+}
@@ -13,6 +13,11 @@ type TraitVariant struct {
 	Region     TraitRegion `json:"region"`
 	Ref        string      `json:"ref"`
 	Alt        string      `json:"alt"`
+	// Build is the reference assembly of Position and Region. Proofs refuse
+	// genomes known to be in another build; BuildUnknown is not checked.
+	Build GenomeBuild `json:"build,omitempty"`
 }
 
-type TraitPanel struct{}
\ No newline at end of file
+// CarrierClaims maps a genotype to whether any ALT allele is carried
+// (0 = not carried, 1 = carried), the claim of variant presence
+var CarrierClaims = [3]int{0, 1, 1}
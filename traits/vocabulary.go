@@ -0,0 +1,107 @@
+package traits
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ClaimValue is one value of a public claim and its label
+type ClaimValue struct {
+	Code  int    `json:"code"`
+	Label string `json:"label"`
+}
+
+// ClaimEncoder turns a claim label into the value a proof discloses
+type ClaimEncoder interface {
+	Encode(label string) (int, error)
+}
+
+// ClaimDecoder turns the value a proof discloses into its claim label
+type ClaimDecoder interface {
+	Decode(code int) (string, error)
+}
+
+// ClaimVocabulary is the public encoding of the claim a proof type
+// discloses: the public input that carries it and the meaning of each value.
+// The codes are part of the proof format. They never change, and new values
+// are only ever added, so verifiers in any language can rely on them.
+type ClaimVocabulary struct {
+	ProofType string       `json:"proof_type"`
+	Input     string       `json:"input"`
+	Values    []ClaimValue `json:"values"`
+}
+
+// Encode returns the code of label, compared case-insensitively
+func (v *ClaimVocabulary) Encode(label string) (int, error) {
+	for _, value := range v.Values {
+		if strings.EqualFold(value.Label, label) {
+			return value.Code, nil
+		}
+	}
+	return 0, fmt.Errorf("%s claim has no value %q", v.ProofType, label)
+}
+
+// Decode returns the label of code
+func (v *ClaimVocabulary) Decode(code int) (string, error) {
+	for _, value := range v.Values {
+		if value.Code == code {
+			return value.Label, nil
+		}
+	}
+	return "", fmt.Errorf("%s claim has no value %d", v.ProofType, code)
+}
+
+// enumValues lists the claim values of an encoding's constants, labelled as
+// their String method names them
+func enumValues[T interface {
+	~int
+	String() string
+}](values ...T) []ClaimValue {
+	claims := make([]ClaimValue, len(values))
+	for i, value := range values {
+		claims[i] = ClaimValue{Code: int(value), Label: value.String()}
+	}
+	return claims
+}
+
+// claimVocabularies holds the vocabulary of every proof type whose public
+// claim is an encoded trait. Unknown values are left out: no proof claims
+// them.
+var claimVocabularies = []ClaimVocabulary{
+	{ProofType: "eye_color", Input: "ClaimedValue", Values: enumValues(EyeColorBrown, EyeColorHazel, EyeColorBlue)},
+	{ProofType: "herc2", Input: "ClaimedValue", Values: []ClaimValue{{Code: 0, Label: "not carried"}, {Code: 1, Label: "carried"}}},
+	{ProofType: "blood_type", Input: "ClaimedBloodType", Values: enumValues(BloodGroupA, BloodGroupB, BloodGroupAB, BloodGroupO)},
+	{ProofType: "actn3", Input: "ClaimedValue", Values: enumValues(ACTN3RR, ACTN3RX, ACTN3XX)},
+	{ProofType: "aldh2", Input: "ClaimedValue", Values: []ClaimValue{{Code: 0, Label: "not deficient"}, {Code: 1, Label: "deficient"}}},
+	{ProofType: "ccr5", Input: "ClaimedValue", Values: enumValues(DeletionAbsent, DeletionHeterozygous, DeletionHomozygous)},
+	{ProofType: "abcc11", Input: "ClaimedValue", Values: enumValues(EarwaxWet, EarwaxDry)},
+	{ProofType: "mthfr", Input: "ClaimedStatus", Values: enumValues(
+		MTHFRTypical, MTHFRC677THeterozygous, MTHFRC677THomozygous,
+		MTHFRA1298CHeterozygous, MTHFRA1298CHomozygous, MTHFRCompoundHeterozygous,
+	)},
+	{ProofType: "cyp2d6", Input: "ClaimedStatus", Values: enumValues(PoorMetabolizer, IntermediateMetabolizer, NormalMetabolizer, UltrarapidMetabolizer)},
+	{ProofType: "brca2", Input: "IsCarrier", Values: []ClaimValue{{Code: 0, Label: "non-carrier"}, {Code: 1, Label: "carrier"}}},
+	{ProofType: "sex_chromosome", Input: "ClaimedKaryotype", Values: enumValues(KaryotypeXX, KaryotypeXY)},
+}
+
+// ClaimVocabularies returns the vocabulary of every proof type with an
+// encoded trait claim
+func ClaimVocabularies() []ClaimVocabulary {
+	vocabularies := slices.Clone(claimVocabularies)
+	for i := range vocabularies {
+		vocabularies[i].Values = slices.Clone(vocabularies[i].Values)
+	}
+	return vocabularies
+}
+
+// LookupClaimVocabulary returns the vocabulary of a proof type's claim, or
+// false if the proof type does not disclose an encoded trait
+func LookupClaimVocabulary(proofType string) (*ClaimVocabulary, bool) {
+	for _, vocabulary := range ClaimVocabularies() {
+		if vocabulary.ProofType == proofType {
+			return &vocabulary, true
+		}
+	}
+	return nil, false
+}
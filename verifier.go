@@ -0,0 +1,154 @@
+package zkgenomics
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// Prover is the proving side of the library. It is the ProofGenerator, which
+// also verifies; services that only verify use a Verifier.
+type Prover = ProofGenerator
+
+// NewProver creates a Prover configured by opts
+func NewProver(opts ...Option) *Prover {
+	return NewProofGenerator(opts...)
+}
+
+// Verifier verifies proofs. It holds none of the proving configuration of a
+// ProofGenerator, only the verifying keys it trusts and the trust policy.
+type Verifier struct {
+	// VerifyingKeys, if set, pins the verifying key of each proof type:
+	// proofs are checked against the key registered for their type instead
+	// of the key they carry, and types without a key are refused
+	VerifyingKeys map[ProofType][]byte
+	// Logger, if set, receives messages from verification
+	Logger Logger
+	// Advisories, if set, replaces the advisories bundled with this release
+	Advisories *AdvisoryList
+	// IgnoreAdvisories lists advisory IDs whose findings are explicitly overridden
+	IgnoreAdvisories []string
+	// Revocations, if set, is consulted for proofs the issuer has revoked
+	Revocations RevocationChecker
+	// IssuerKeys, if set, are the trusted issuer keys by key ID; proofs must
+	// carry an envelope signature by one of them
+	IssuerKeys map[string]crypto.PublicKey
+	// LabKeys, if set, are the trusted sequencing lab keys by key ID; proofs
+	// must carry an attestation of their genome by one of them and disclose
+	// its Merkle root, so only committed proofs pass
+	LabKeys map[string]crypto.PublicKey
+	// PinnedVKFingerprints, if set, lists the verifying key fingerprints
+	// accepted for each proof type; proofs of other types are refused
+	PinnedVKFingerprints map[ProofType][]string
+	// KeyRegistry, if set, holds the verifying keys trusted for each proof
+	// type, for types VerifyingKeys does not pin
+	KeyRegistry *VerifyingKeyRegistry
+	// InsecureBundledKeys trusts the key a proof carries when none is
+	// registered or pinned for its type; for development only
+	InsecureBundledKeys bool
+}
+
+// NewVerifier creates a Verifier trusting verifyingKeys, which may be nil to
+// trust the keys registered with WithVerifyingKeyRegistry or pinned with
+// WithPinnedVKFingerprints. Of opts, only WithLogger, WithAdvisories,
+// WithIgnoredAdvisories, WithRevocations, WithIssuerKeys, WithLabKeys,
+// WithPinnedVKFingerprints, WithVerifyingKeyRegistry and
+// WithInsecureBundledKeys affect verification; the others are ignored.
+func NewVerifier(verifyingKeys map[ProofType][]byte, opts ...Option) *Verifier {
+	pg := NewProofGenerator(opts...)
+	return &Verifier{
+		VerifyingKeys:        verifyingKeys,
+		Logger:               pg.Logger,
+		Advisories:           pg.Advisories,
+		IgnoreAdvisories:     pg.IgnoreAdvisories,
+		Revocations:          pg.Revocations,
+		IssuerKeys:           pg.IssuerKeys,
+		LabKeys:              pg.LabKeys,
+		PinnedVKFingerprints: pg.PinnedVKFingerprints,
+		KeyRegistry:          pg.KeyRegistry,
+		InsecureBundledKeys:  pg.InsecureBundledKeys,
+	}
+}
+
+// Verifier returns a Verifier with the generator's logger and trust policy,
+// including its trusted verifying keys
+func (pg *ProofGenerator) Verifier() *Verifier {
+	return &Verifier{
+		Logger:               pg.Logger,
+		Advisories:           pg.Advisories,
+		IgnoreAdvisories:     pg.IgnoreAdvisories,
+		Revocations:          pg.Revocations,
+		IssuerKeys:           pg.IssuerKeys,
+		LabKeys:              pg.LabKeys,
+		PinnedVKFingerprints: pg.PinnedVKFingerprints,
+		KeyRegistry:          pg.KeyRegistry,
+		InsecureBundledKeys:  pg.InsecureBundledKeys,
+	}
+}
+
+// generator returns the ProofGenerator verification is delegated to
+func (v *Verifier) generator() *ProofGenerator {
+	return &ProofGenerator{
+		Logger:               v.Logger,
+		Advisories:           v.Advisories,
+		IgnoreAdvisories:     v.IgnoreAdvisories,
+		Revocations:          v.Revocations,
+		IssuerKeys:           v.IssuerKeys,
+		LabKeys:              v.LabKeys,
+		PinnedVKFingerprints: v.PinnedVKFingerprints,
+		KeyRegistry:          v.KeyRegistry,
+		InsecureBundledKeys:  v.InsecureBundledKeys,
+	}
+}
+
+// VerifyProofData verifies proofData as a proof of proofType, like
+// ProofGenerator.VerifyProofData, against the registered verifying key
+func (v *Verifier) VerifyProofData(proofType ProofType, proofData *ProofData) (*VerificationResult, error) {
+	return v.VerifyProofDataContext(context.Background(), proofType, proofData)
+}
+
+// VerifyProofDataContext is VerifyProofData with cancellation: it returns
+// ctx.Err() as soon as ctx is done
+func (v *Verifier) VerifyProofDataContext(ctx context.Context, proofType ProofType, proofData *ProofData) (*VerificationResult, error) {
+	if v.VerifyingKeys != nil {
+		vk, ok := v.VerifyingKeys[proofType]
+		if !ok {
+			return &VerificationResult{
+				Result: ProofFail,
+				Error:  fmt.Errorf("no verifying key registered for %s proofs", proofType),
+			}, nil
+		}
+		return v.generator().verifyWithKey(ctx, proofType, proofData, vk)
+	}
+	return v.generator().VerifyProofDataContext(ctx, proofType, proofData)
+}
+
+// VerifyProofDataWithNonce verifies proofData like VerifyProofData, and
+// fails it with ErrNonceMismatch unless it is bound to nonce
+func (v *Verifier) VerifyProofDataWithNonce(proofType ProofType, proofData *ProofData, nonce []byte) (*VerificationResult, error) {
+	return verifyWithNonce(v.VerifyProofData, proofType, proofData, nonce)
+}
+
+// VerifyAnyProofData verifies proofData as the type it records, like
+// ProofGenerator.VerifyAnyProofData. Proofs that record none are tried
+// against every type with a registered key, or every supported type if no
+// keys are registered.
+func (v *Verifier) VerifyAnyProofData(proofData *ProofData) (ProofType, *VerificationResult, error) {
+	candidates := v.generator().GetSupportedProofTypes()
+	if v.VerifyingKeys != nil {
+		candidates = slices.Sorted(maps.Keys(v.VerifyingKeys))
+	}
+	return verifyAny(proofData, candidates, v.VerifyProofData)
+}
+
+// VerifyBytes checks a serialized Groth16 proof against a serialized
+// verifying key and public witness, for services that store the parts of a
+// proof themselves. Only the SNARK is checked: the proof type, circuit and
+// advisories are unknown, so the caller must trust the verifying key.
+func (v *Verifier) VerifyBytes(proof []byte, verifyingKey []byte, publicWitness []byte) (*VerificationResult, error) {
+	return proofs.VerifyBytes(v.Logger, proof, verifyingKey, publicWitness)
+}
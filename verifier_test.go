@@ -0,0 +1,97 @@
+package zkgenomics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVerifier(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t0/1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/0\n"
+
+	prover := NewProver()
+	aldh2, err := prover.GenerateProofFromReader(ALDH2ProofType, strings.NewReader(vcf))
+	if err != nil {
+		t.Fatalf("GenerateProofFromReader should not return error: %v", err)
+	}
+	actn3, err := prover.GenerateProofFromReader(ACTN3ProofType, strings.NewReader(vcf))
+	if err != nil {
+		t.Fatalf("GenerateProofFromReader should not return error: %v", err)
+	}
+
+	verifier := NewVerifier(map[ProofType][]byte{ALDH2ProofType: aldh2.VerifyingKey})
+	result, err := verifier.VerifyProofData(ALDH2ProofType, aldh2)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected the proof to verify against its registered key, got %v: %v", result, err)
+	}
+
+	// A proof carrying a key other than the registered one does not verify
+	forged := *actn3
+	forged.ProofType = string(ALDH2ProofType)
+	result, err = verifier.VerifyProofData(ALDH2ProofType, &forged)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected a proof for another key to fail, got %v: %v", result, err)
+	}
+
+	result, err = verifier.VerifyProofData(ACTN3ProofType, actn3)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected a type without a registered key to be refused, got %v: %v", result, err)
+	}
+
+	result, err = verifier.VerifyBytes(aldh2.Proof, aldh2.VerifyingKey, aldh2.PublicWitness)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected VerifyBytes to accept the proof, got %v: %v", result, err)
+	}
+	result, err = verifier.VerifyBytes(aldh2.Proof, actn3.VerifyingKey, aldh2.PublicWitness)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected VerifyBytes to reject the wrong key, got %v: %v", result, err)
+	}
+}
+
+func TestVerifier_PinnedVKFingerprints(t *testing.T) {
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"11\t66328095\trs1815739\tC\tT\t60\tPASS\t.\tGT\t0/1\n" +
+		"12\t112241766\trs671\tG\tA\t60\tPASS\t.\tGT\t0/0\n"
+
+	prover := NewProver()
+	aldh2, err := prover.GenerateProofFromReader(ALDH2ProofType, strings.NewReader(vcf))
+	if err != nil {
+		t.Fatalf("GenerateProofFromReader should not return error: %v", err)
+	}
+	actn3, err := prover.GenerateProofFromReader(ACTN3ProofType, strings.NewReader(vcf))
+	if err != nil {
+		t.Fatalf("GenerateProofFromReader should not return error: %v", err)
+	}
+	fingerprint, err := aldh2.VKFingerprint()
+	if err != nil {
+		t.Fatalf("VKFingerprint should not return error: %v", err)
+	}
+
+	verifier := NewVerifier(nil, WithPinnedVKFingerprints(ALDH2ProofType, fingerprint))
+	result, err := verifier.VerifyProofData(ALDH2ProofType, aldh2)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected a proof with a pinned key to verify, got %v: %v", result, err)
+	}
+
+	// A fresh setup bundles another key, which is refused
+	other, err := prover.GenerateProofFromReader(ALDH2ProofType, strings.NewReader(vcf))
+	if err != nil {
+		t.Fatalf("GenerateProofFromReader should not return error: %v", err)
+	}
+	var unpinned *UnpinnedKeyError
+	result, err = verifier.VerifyProofData(ALDH2ProofType, other)
+	if err != nil || result.Result != ProofFail || !errors.As(result.Error, &unpinned) {
+		t.Errorf("Expected UnpinnedKeyError for another key, got %v: %v", result, err)
+	}
+
+	result, err = verifier.VerifyProofData(ACTN3ProofType, actn3)
+	if err != nil || result.Result != ProofFail {
+		t.Errorf("Expected a type without pins to be refused, got %v: %v", result, err)
+	}
+}
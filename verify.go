@@ -0,0 +1,178 @@
+package zkgenomics
+
+import (
+	"crypto"
+
+	"github.com/zkgenomics/zkgenomics-proofs/proofs"
+)
+
+// PublicValue re-exports the named public input structure for convenience
+type PublicValue = proofs.PublicValue
+
+// ClaimMismatchError re-exports the error reported when public values differ from expectations
+type ClaimMismatchError = proofs.ClaimMismatchError
+
+// TrustPolicy selects what VerifyTrust checks beyond the proof itself
+type TrustPolicy struct {
+	// Advisories, if set, replaces the advisories bundled with this release
+	Advisories *AdvisoryList
+	// IgnoreAdvisories lists advisory IDs whose findings are explicitly overridden
+	IgnoreAdvisories []string
+	// Revocations, if set, is consulted for proofs the issuer has revoked
+	Revocations RevocationChecker
+	// IssuerKeys, if set, are the trusted issuer keys by key ID; proofs must
+	// carry an envelope signature by one of them
+	IssuerKeys map[string]crypto.PublicKey
+	// LabKeys, if set, are the trusted sequencing lab keys by key ID; proofs
+	// must carry an attestation of their genome by one of them and disclose
+	// its Merkle root, so only committed proofs pass
+	LabKeys map[string]crypto.PublicKey
+	// PinnedVKFingerprints, if set, lists the verifying key fingerprints
+	// accepted for each proof type; proofs of other types are refused
+	PinnedVKFingerprints map[ProofType][]string
+	// KeyRegistry, if set, holds the verifying keys trusted for each proof
+	// type, and the circuits of keys registered with one
+	KeyRegistry *VerifyingKeyRegistry
+	// InsecureBundledKeys trusts the verifying key a proof carries even if it
+	// is neither registered nor pinned. The prover chose that key, so such
+	// proofs prove nothing to a verifier; use it for development only.
+	InsecureBundledKeys bool
+}
+
+// VKFingerprint returns the hex SHA-256 of the canonical encoding of the
+// serialized verifying key vk, for pinning with WithPinnedVKFingerprints
+func VKFingerprint(vk []byte) (string, error) {
+	return proofs.VKFingerprint(vk)
+}
+
+// trustPolicy returns the trust policy configured on the generator
+func (pg *ProofGenerator) trustPolicy() TrustPolicy {
+	return TrustPolicy{
+		Advisories:           pg.Advisories,
+		IgnoreAdvisories:     pg.IgnoreAdvisories,
+		Revocations:          pg.Revocations,
+		IssuerKeys:           pg.IssuerKeys,
+		LabKeys:              pg.LabKeys,
+		PinnedVKFingerprints: pg.PinnedVKFingerprints,
+		KeyRegistry:          pg.KeyRegistry,
+		InsecureBundledKeys:  pg.InsecureBundledKeys,
+	}
+}
+
+// trustsKey reports whether policy trusts vk for proofs of proofType. Pinned
+// keys are trusted here and checked against their pins by VerifyTrust.
+func (policy TrustPolicy) trustsKey(proofType ProofType, vk []byte) bool {
+	if policy.InsecureBundledKeys || policy.PinnedVKFingerprints[proofType] != nil {
+		return true
+	}
+	return policy.KeyRegistry != nil && policy.KeyRegistry.Trusts(proofType, vk)
+}
+
+// VerifyCryptographic checks only the SNARK: that the proof is valid for its
+// verifying key and public witness. It says nothing about whether the circuit
+// is trusted or what the proof claims.
+func (pg *ProofGenerator) VerifyCryptographic(proofType ProofType, proofData *ProofData) (*VerificationResult, error) {
+	proof, err := pg.newProof(proofType)
+	if err != nil {
+		return nil, err
+	}
+
+	return proof.VerifyProofData(proofData)
+}
+
+// VerifyTrust checks whether the circuit that produced proofData is trusted
+// under policy, without checking the proof itself. The verifying key of
+// proofData must be registered for proofType in policy.KeyRegistry or have its
+// fingerprint pinned, unless policy allows bundled keys; otherwise it fails
+// with an UntrustedKeyError. Proofs carrying no verifying key cannot verify,
+// so their key is not checked. A circuit or verifying key flagged by an
+// advisory that has not been overridden fails with an AdvisoryError. Proofs
+// without a recorded circuit are attributed to version 1 of the circuit named
+// after their proof type. The recorded circuit is written by the prover, so
+// when the verifying key is registered in policy.KeyRegistry with its
+// circuit, a proof recording another circuit fails with a
+// KeyCircuitMismatchError; register keys with RegisterCircuit or ImportKeys
+// so a proof cannot claim a version no advisory flags. A proof revoked
+// under policy fails with a RevokedError. A policy with issuer keys fails
+// proofs not signed by one of them with ErrUnsignedEnvelope, and one with lab
+// keys fails proofs not carrying a genome attestation by one of them with
+// ErrUnattestedGenome, and proofs not disclosing the attested Merkle root
+// with ErrUnboundAttestation. A policy pinning verifying key fingerprints
+// fails proofs whose key is not pinned for proofType with an
+// UnpinnedKeyError.
+func (pg *ProofGenerator) VerifyTrust(proofType ProofType, proofData *ProofData, policy TrustPolicy) (*VerificationResult, error) {
+	if len(proofData.VerifyingKey) > 0 && !policy.trustsKey(proofType, proofData.VerifyingKey) {
+		return &VerificationResult{Result: ProofFail, Error: &UntrustedKeyError{ProofType: string(proofType)}}, nil
+	}
+
+	list := policy.Advisories
+	if list == nil {
+		bundled, err := proofs.BundledAdvisories()
+		if err != nil {
+			return nil, err
+		}
+		list = bundled
+	}
+
+	circuitID, version := proofData.CircuitID, proofData.CircuitVersion
+	if circuitID == "" {
+		circuitID = string(proofType)
+	}
+	if version == 0 {
+		version = 1
+	}
+
+	if len(proofData.VerifyingKey) > 0 && policy.KeyRegistry != nil {
+		if circuit, ok := policy.KeyRegistry.Circuit(proofType, proofData.VerifyingKey); ok {
+			mismatched := circuit.ID != circuitID || circuit.Version != version ||
+				(circuit.Hash != "" && proofData.CircuitHash != "" && circuit.Hash != proofData.CircuitHash)
+			if mismatched {
+				claimed := CircuitKey{ID: circuitID, Version: version, Hash: proofData.CircuitHash}
+				return &VerificationResult{Result: ProofFail, Error: &KeyCircuitMismatchError{ProofType: string(proofType), Claimed: claimed, Registered: circuit}}, nil
+			}
+		}
+	}
+	if err := list.Check(circuitID, version, policy.IgnoreAdvisories); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	if len(proofData.VerifyingKey) > 0 {
+		fingerprint, err := proofs.VKFingerprint(proofData.VerifyingKey)
+		if err != nil {
+			return &VerificationResult{Result: ProofFail, Error: err}, nil
+		}
+		if err := list.CheckKey(fingerprint, policy.IgnoreAdvisories); err != nil {
+			return &VerificationResult{Result: ProofFail, Error: err}, nil
+		}
+	}
+	if policy.Revocations != nil {
+		if err := proofs.CheckRevocation(policy.Revocations, proofData); err != nil {
+			return &VerificationResult{Result: ProofFail, Error: err}, nil
+		}
+	}
+	if policy.PinnedVKFingerprints != nil {
+		if err := proofs.CheckVKFingerprint(proofData, string(proofType), policy.PinnedVKFingerprints[proofType]); err != nil {
+			return &VerificationResult{Result: ProofFail, Error: err}, nil
+		}
+	}
+	if policy.IssuerKeys != nil {
+		if _, err := proofs.CheckEnvelopeSignatures(proofData, policy.IssuerKeys); err != nil {
+			return &VerificationResult{Result: ProofFail, Error: err}, nil
+		}
+	}
+	if policy.LabKeys != nil {
+		if _, err := proofs.CheckProvenance(proofData, policy.LabKeys); err != nil {
+			return &VerificationResult{Result: ProofFail, Error: err}, nil
+		}
+	}
+	return &VerificationResult{Result: ProofSuccess}, nil
+}
+
+// VerifyClaims checks that proofData discloses the expected public values,
+// matched by public input name, without checking the proof itself. Public
+// inputs not listed in expected are not checked.
+func (pg *ProofGenerator) VerifyClaims(proofData *ProofData, expected []PublicValue) (*VerificationResult, error) {
+	if err := proofs.CheckPublicValues(proofData, expected); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	return &VerificationResult{Result: ProofSuccess}, nil
+}
@@ -0,0 +1,83 @@
+package zkgenomics
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProofGenerator_VerifyLayers(t *testing.T) {
+	vcfPath := filepath.Join(t.TempDir(), "test.vcf")
+	vcf := "##fileformat=VCFv4.2\n" +
+		"##FORMAT=<ID=GT,Number=1,Type=String,Description=\"Genotype\">\n" +
+		"#CHROM\tPOS\tID\tREF\tALT\tQUAL\tFILTER\tINFO\tFORMAT\tSAMPLE1\n" +
+		"16\t48258198\trs17822931\tC\tT\t60\tPASS\t.\tGT\t1/1\n"
+	if err := os.WriteFile(vcfPath, []byte(vcf), 0644); err != nil {
+		t.Fatalf("writing test VCF: %v", err)
+	}
+
+	pg := NewProofGenerator()
+	proofData, err := pg.GenerateProof(ABCC11ProofType, vcfPath, "", "")
+	if err != nil {
+		t.Fatalf("GenerateProof should not return error: %v", err)
+	}
+
+	result, err := pg.VerifyCryptographic(ABCC11ProofType, proofData)
+	if err != nil || result.Result != ProofSuccess {
+		t.Fatalf("Expected cryptographic check to succeed, got %v: %v", result, err)
+	}
+
+	// A policy flagging the circuit fails trust without touching the SNARK
+	policy := TrustPolicy{InsecureBundledKeys: true, Advisories: &AdvisoryList{Advisories: []Advisory{
+		{ID: "TEST-1", CircuitID: proofData.CircuitID, Summary: "test"},
+	}}}
+	result, err = pg.VerifyTrust(ABCC11ProofType, proofData, policy)
+	var advisoryErr *AdvisoryError
+	if err != nil || result.Result != ProofFail || !errors.As(result.Error, &advisoryErr) {
+		t.Errorf("Expected trust check to fail with AdvisoryError, got %v: %v", result, err)
+	}
+	policy.IgnoreAdvisories = []string{"TEST-1"}
+	result, err = pg.VerifyTrust(ABCC11ProofType, proofData, policy)
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected trust check to succeed with the advisory overridden, got %v: %v", result, err)
+	}
+
+	policy.Revocations = NewMemoryRevocationList(ProofID(proofData))
+	result, err = pg.VerifyTrust(ABCC11ProofType, proofData, policy)
+	var revokedErr *RevokedError
+	if err != nil || result.Result != ProofFail || !errors.As(result.Error, &revokedErr) {
+		t.Errorf("Expected trust check to fail for a revoked proof, got %v: %v", result, err)
+	}
+
+	dry := PublicValue{Name: "ClaimedValue", Value: "2"}
+	result, err = pg.VerifyClaims(proofData, []PublicValue{dry})
+	if err != nil || result.Result != ProofSuccess {
+		t.Errorf("Expected claim check to succeed for dry earwax, got %v: %v", result, err)
+	}
+	wet := PublicValue{Name: "ClaimedValue", Value: "1"}
+	result, err = pg.VerifyClaims(proofData, []PublicValue{wet})
+	var mismatch *ClaimMismatchError
+	if err != nil || result.Result != ProofFail || !errors.As(result.Error, &mismatch) {
+		t.Errorf("Expected claim check to fail for wet earwax, got %v: %v", result, err)
+	}
+}
+
+func TestProofGenerator_VerifyProof_NotAProof(t *testing.T) {
+	garbage := filepath.Join(t.TempDir(), "garbage.json")
+	if err := os.WriteFile(garbage, []byte("not a proof"), 0644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	pg := NewProofGenerator()
+	pg.InsecureBundledKeys = true
+	for _, proofType := range []ProofType{ChromosomeProofType, DynamicProofType, BRCA1ProofType} {
+		result, err := pg.VerifyProof(proofType, "", garbage)
+		if err != nil || result.Result != ProofFail {
+			t.Errorf("Expected %s verification of a file that is not a proof to fail, got %v: %v", proofType, result, err)
+		}
+		if _, err := pg.VerifyProof(proofType, "", filepath.Join(t.TempDir(), "missing.json")); err == nil {
+			t.Errorf("Expected %s verification of a missing file to return an error", proofType)
+		}
+	}
+}
@@ -1,7 +1,19 @@
 package zkgenomics
 
 import (
+	"bytes"
+	"context"
+	"crypto"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"math/big"
+	"os"
+
+	"filippo.io/age"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/zkgenomics/zkgenomics-proofs/genotools"
 	"github.com/zkgenomics/zkgenomics-proofs/proofs"
 	"github.com/zkgenomics/zkgenomics-proofs/traits"
 )
@@ -10,118 +22,790 @@ import (
 type ProofData = proofs.ProofData
 type VerificationResult = proofs.VerificationResult
 type ProofResult = proofs.ProofResult
+type FailureReason = proofs.FailureReason
 
 // Re-export constants
 const (
 	ProofSuccess ProofResult = proofs.ProofSuccess
 	ProofFail    ProofResult = proofs.ProofFail
 	ProofUnknown ProofResult = proofs.ProofUnknown
+
+	FailureVariantNotFound FailureReason = proofs.FailureVariantNotFound
+	FailureNoSampleData    FailureReason = proofs.FailureNoSampleData
+	FailureMalformedVCF    FailureReason = proofs.FailureMalformedVCF
+	FailureOther           FailureReason = proofs.FailureOther
 )
 
 // ProofType represents the type of genomic proof to generate
 type ProofType string
 
 const (
-	ChromosomeProofType ProofType = "chromosome"
-	EyeColorProofType   ProofType = "eye_color"
-	BRCA1ProofType      ProofType = "brca1"
-	HERC2ProofType      ProofType = "herc2"
-	DynamicProofType    ProofType = "dynamic"
+	ChromosomeProofType    ProofType = "chromosome"
+	EyeColorProofType      ProofType = "eye_color"
+	BRCA1ProofType         ProofType = "brca1"
+	HERC2ProofType         ProofType = "herc2"
+	DynamicProofType       ProofType = "dynamic"
+	BloodTypeProofType     ProofType = "blood_type"
+	CohortProofType        ProofType = "cohort"
+	CYP2D6ProofType        ProofType = "cyp2d6"
+	ACTN3ProofType         ProofType = "actn3"
+	ALDH2ProofType         ProofType = "aldh2"
+	CCR5ProofType          ProofType = "ccr5"
+	MTHFRProofType         ProofType = "mthfr"
+	BRCA2ProofType         ProofType = "brca2"
+	BurdenProofType        ProofType = "burden"
+	ABCC11ProofType        ProofType = "abcc11"
+	SexChromosomeProofType ProofType = "sex_chromosome"
+	RsIDProofType          ProofType = "rsid"
+	NegativeProofType      ProofType = "negative"
+	KinshipProofType       ProofType = "kinship"
+	AggregateProofType     ProofType = "aggregate"
+	CommittedProofType     ProofType = "committed"
+	CarrierProofType       ProofType = "carrier"
+	RegionCountProofType   ProofType = "region_count"
+	PhaseProofType         ProofType = "phase"
+	CustomProofType        ProofType = "custom"
+)
+
+// GenomeCommitment re-exports the genome commitment record for convenience
+type GenomeCommitment = proofs.GenomeCommitment
+
+// ProgressReporter re-exports the progress callback type for convenience
+type ProgressReporter = proofs.ProgressReporter
+
+// Logger re-exports the logging interface for convenience
+type Logger = proofs.Logger
+
+// LogLevel re-exports the log severity type for convenience
+type LogLevel = proofs.LogLevel
+
+// Log levels accepted by NewWriterLogger
+const (
+	LevelDebug LogLevel = proofs.LevelDebug
+	LevelInfo  LogLevel = proofs.LevelInfo
+	LevelWarn  LogLevel = proofs.LevelWarn
+)
+
+// NewWriterLogger returns a Logger writing messages at or above level to w
+func NewWriterLogger(w io.Writer, level LogLevel) Logger {
+	return proofs.NewWriterLogger(w, level)
+}
+
+// GenomeSource re-exports the genome input interface for convenience
+type GenomeSource = proofs.GenomeSource
+
+// VariantCall re-exports the variant record structure for convenience
+type VariantCall = proofs.VariantCall
+
+// CoverageSource re-exports the interface reporting where a genome was
+// sequenced, which absence proofs consult
+type CoverageSource = proofs.CoverageSource
+
+// OpenCoverage opens a gVCF or BED coverage file, such as mosdepth output,
+// for WithCoverage; BED intervals below minDepth are not covered
+func OpenCoverage(path string, minDepth float64) (CoverageSource, error) {
+	return proofs.OpenCoverage(path, minDepth)
+}
+
+// InputFormat re-exports the genome file format names for convenience
+type InputFormat = proofs.InputFormat
+
+// Genome file formats: VCFs and the raw data exports of consumer genotyping
+// services
+const (
+	InputVCF         InputFormat = proofs.InputVCF
+	Input23andMe     InputFormat = proofs.Input23andMe
+	InputAncestryDNA InputFormat = proofs.InputAncestryDNA
 )
 
+// BurdenPolicy re-exports the burden claim definition for convenience
+type BurdenPolicy = proofs.BurdenPolicy
+
+// ClaimMode re-exports the dynamic proof statement selector for convenience
+type ClaimMode = proofs.ClaimMode
+
+// Claim modes selecting the statement a dynamic or rsID proof makes
+const (
+	ClaimExactGenotype ClaimMode = proofs.ClaimExactGenotype
+	ClaimHeterozygous  ClaimMode = proofs.ClaimHeterozygous
+	ClaimHomozygousAlt ClaimMode = proofs.ClaimHomozygousAlt
+	ClaimCarrier       ClaimMode = proofs.ClaimCarrier
+)
+
+// ProofParameters re-exports what a dynamic or rsID proof was requested with
+type ProofParameters = proofs.ProofParameters
+
+// HaplotypePhase re-exports the cis/trans encoding of phase proofs for convenience
+type HaplotypePhase = proofs.HaplotypePhase
+
+// Phases disclosed by phase proofs
+const (
+	PhaseCis   HaplotypePhase = proofs.PhaseCis
+	PhaseTrans HaplotypePhase = proofs.PhaseTrans
+)
+
+// AggregateClaim re-exports the aggregate proof claim for convenience
+type AggregateClaim = proofs.AggregateClaim
+
 // ProofGenerator provides a unified interface for generating genomic proofs
-type ProofGenerator struct{}
+type ProofGenerator struct {
+	// Progress, if set, receives progress updates from VCF scans
+	Progress ProgressReporter
+	// Logger, if set, receives messages from proving and verification; the
+	// library is silent otherwise
+	Logger Logger
+	// BRCA2Panel, if set, replaces the default BRCA2 pathogenic variant panel
+	BRCA2Panel []TraitVariant
+	// MaxVariants, if set, bounds how many non-reference variants BRCA2
+	// proofs scan for
+	MaxVariants int
+	// BurdenPolicy, if set, replaces the default burden policy
+	BurdenPolicy *BurdenPolicy
+	// ChromosomeSlots, if set, sizes the chromosome circuit
+	ChromosomeSlots int
+	// TargetChromosome, if set, is the code of the chromosome that chromosome
+	// proofs show present, replacing chromosome 22
+	TargetChromosome int
+	// Advisories, if set, replaces the advisories bundled with this release
+	// when checking verified proofs
+	Advisories *AdvisoryList
+	// IgnoreAdvisories lists advisory IDs whose findings are explicitly overridden
+	IgnoreAdvisories []string
+	// Revocations, if set, is consulted for proofs the issuer has revoked
+	// when checking verified proofs
+	Revocations RevocationChecker
+	// IssuerKeys, if set, are the trusted issuer keys by key ID; verified
+	// proofs must carry an envelope signature by one of them
+	IssuerKeys map[string]crypto.PublicKey
+	// LabKeys, if set, are the trusted sequencing lab keys by key ID;
+	// verified proofs must carry an attestation of their genome by one of them
+	// and disclose its Merkle root, so only committed proofs pass
+	LabKeys map[string]crypto.PublicKey
+	// PinnedVKFingerprints, if set, lists the verifying key fingerprints
+	// accepted for each proof type when checking verified proofs; proofs of
+	// other types are refused
+	PinnedVKFingerprints map[ProofType][]string
+	// KeyRegistry, if set, holds the verifying keys trusted for each proof
+	// type; proofs are verified against a registered key rather than the key
+	// they carry
+	KeyRegistry *VerifyingKeyRegistry
+	// InsecureBundledKeys verifies proofs against the key they carry when no
+	// key is registered or pinned for their type. The prover chose that key,
+	// so this is for development only.
+	InsecureBundledKeys bool
+	// Seed, if set, makes key setup and proving deterministic for tests and
+	// audits; seeded proofs are not secure (see proofs.GenerateSeededContext)
+	Seed []byte
+	// Keys, if set, holds the proving and verifying keys proofs are generated
+	// with, so proofs of a circuit share one verifying key
+	Keys KeyStore
+	// Cache, if set, keeps compiled circuits and their keys in memory across
+	// proofs, so repeated proofs skip compilation and setup
+	Cache *CircuitCache
+	// Backend, if set, is the proving system proofs are generated with;
+	// Groth16 if nil. Verification follows the backend recorded in the proof.
+	Backend Backend
+	// Curve, if set, is the curve proofs are generated over, overriding the
+	// curve of Backend; BN254 if neither sets one. Verification follows the
+	// curve recorded in the proof.
+	Curve Curve
+	// Threads, if set, caps the CPU threads setup and proving use; every CPU
+	// is used otherwise
+	Threads int
+	// SolverHints, if set, are audited solver hints given to the solver
+	// beside those the circuit declares
+	SolverHints []solver.Hint
+	// ExternalProver, if set, proves in place of the backend, such as a GPU
+	// or remote prover; compiling, witnesses and verification stay local
+	ExternalProver ExternalProver
+	// InputFormat, if set, is the format genomes must be in; genomes in
+	// another format are refused. The format is detected otherwise, so VCFs
+	// and DTC raw data are both read.
+	InputFormat InputFormat
+	// Liftover, if set, lifts genomes over to LiftoverBuild through these
+	// blocks before proving, such as a GRCh38 genome to the GRCh37
+	// coordinates of the built-in traits. Genomes already in LiftoverBuild
+	// are proven as they are.
+	Liftover      []LiftoverBlock
+	LiftoverBuild GenomeBuild
+	// Identities decrypt age-encrypted genomes as they are read, so their
+	// plaintext is never written to disk
+	Identities []age.Identity
+	// Coverage, if set, is where the genome was sequenced; negative proofs
+	// only prove a variant absent at a locus it covers
+	Coverage CoverageSource
+	// Traits, if set, is the registry the built-in trait proofs read their
+	// loci from; the bundled registry if nil
+	Traits *TraitRegistry
+}
 
-// NewProofGenerator creates a new proof generator instance
-func NewProofGenerator() *ProofGenerator {
-	return &ProofGenerator{}
+// NewProofGenerator creates a new proof generator instance configured by opts
+func NewProofGenerator(opts ...Option) *ProofGenerator {
+	pg := &ProofGenerator{}
+	for _, opt := range opts {
+		opt(pg)
+	}
+	return pg
 }
 
-// GenerateProof generates a proof of the specified type and returns the proof data
-func (pg *ProofGenerator) GenerateProof(proofType ProofType, vcfPath, provingKeyPath, outputPath string) (*ProofData, error) {
-	var proof proofs.Proof
+// proofOptions returns the options the generator passes to every proof
+func (pg *ProofGenerator) proofOptions() proofs.ProofOptions {
+	return proofs.ProofOptions{Progress: pg.Progress, Logger: pg.Logger, Traits: pg.Traits}
+}
 
+// newProof returns the proof implementation for the given proof type
+func (pg *ProofGenerator) newProof(proofType ProofType) (proofs.Proof, error) {
 	switch proofType {
 	case ChromosomeProofType:
-		proof = &proofs.ChromosomeProof{}
+		return &proofs.ChromosomeProof{
+			ProofOptions:     pg.proofOptions(),
+			TargetChromosome: pg.TargetChromosome,
+			Slots:            pg.ChromosomeSlots,
+		}, nil
 	case EyeColorProofType:
-		proof = &proofs.EyeColorProof{}
+		return &proofs.EyeColorProof{ProofOptions: pg.proofOptions()}, nil
 	case BRCA1ProofType:
-		proof = &proofs.BRCA1Proof{}
+		return &proofs.BRCA1Proof{ProofOptions: pg.proofOptions()}, nil
 	case HERC2ProofType:
-		proof = &proofs.HERC2Proof{}
+		return &proofs.HERC2Proof{ProofOptions: pg.proofOptions()}, nil
 	case DynamicProofType:
-		proof = &proofs.DynamicProof{}
+		return &proofs.DynamicProof{ProofOptions: pg.proofOptions()}, nil
+	case BloodTypeProofType:
+		return &proofs.BloodTypeProof{ProofOptions: pg.proofOptions()}, nil
+	case CohortProofType:
+		return &proofs.CohortProof{ProofOptions: pg.proofOptions()}, nil
+	case CYP2D6ProofType:
+		return &proofs.CYP2D6Proof{ProofOptions: pg.proofOptions()}, nil
+	case ACTN3ProofType:
+		return &proofs.ACTN3Proof{ProofOptions: pg.proofOptions()}, nil
+	case ALDH2ProofType:
+		return &proofs.ALDH2Proof{ProofOptions: pg.proofOptions()}, nil
+	case CCR5ProofType:
+		return &proofs.CCR5Proof{ProofOptions: pg.proofOptions()}, nil
+	case MTHFRProofType:
+		return &proofs.MTHFRProof{ProofOptions: pg.proofOptions()}, nil
+	case BRCA2ProofType:
+		proof := proofs.NewBRCA2Proof()
+		proof.ProofOptions = pg.proofOptions()
+		if len(pg.BRCA2Panel) > 0 {
+			proof.Panel = pg.BRCA2Panel
+		}
+		if pg.MaxVariants > 0 {
+			proof.MaxVariants = pg.MaxVariants
+		}
+		return proof, nil
+	case BurdenProofType:
+		policy := proofs.DefaultBurdenPolicy()
+		if pg.BurdenPolicy != nil {
+			policy = *pg.BurdenPolicy
+		}
+		proof := proofs.NewBurdenProof(policy)
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case ABCC11ProofType:
+		return &proofs.ABCC11Proof{ProofOptions: pg.proofOptions()}, nil
+	case SexChromosomeProofType:
+		return &proofs.SexChromosomeProof{ProofOptions: pg.proofOptions()}, nil
+	case RsIDProofType:
+		return &proofs.RsIDProof{ProofOptions: pg.proofOptions()}, nil
+	case NegativeProofType:
+		return &proofs.NegativeProof{ProofOptions: pg.proofOptions(), Coverage: pg.Coverage}, nil
+	case KinshipProofType:
+		proof := proofs.NewKinshipProof("")
+		proof.ProofOptions = pg.proofOptions()
+		return proof, nil
+	case AggregateProofType:
+		return &proofs.AggregateProof{ProofOptions: pg.proofOptions()}, nil
+	case CommittedProofType:
+		return &proofs.CommittedVariantProof{ProofOptions: pg.proofOptions()}, nil
+	case CarrierProofType:
+		return &proofs.CarrierProof{ProofOptions: pg.proofOptions()}, nil
+	case RegionCountProofType:
+		return &proofs.RegionCountProof{ProofOptions: pg.proofOptions()}, nil
+	case PhaseProofType:
+		return &proofs.PhaseProof{ProofOptions: pg.proofOptions()}, nil
+	case CustomProofType:
+		return &proofs.CustomTraitProof{ProofOptions: pg.proofOptions()}, nil
 	default:
 		return nil, &UnsupportedProofTypeError{Type: string(proofType)}
 	}
+}
+
+// GenerateProof generates a proof of the specified type and returns the proof data.
+// If the VCF has been committed, proving is refused with a StaleCommitmentError
+// when its contents no longer match the commitment. A non-empty provingKeyPath
+// proves with the keys Setup wrote there, as for ProofRequest.ProvingKeyPath.
+func (pg *ProofGenerator) GenerateProof(proofType ProofType, vcfPath, provingKeyPath, outputPath string) (*ProofData, error) {
+	return pg.GenerateProofContext(context.Background(), proofType, vcfPath, provingKeyPath, outputPath)
+}
+
+// GenerateProofContext is GenerateProof with cancellation: it returns
+// ctx.Err() as soon as ctx is done. A compile, setup or proving step that is
+// already running finishes in the background and its result is discarded.
+func (pg *ProofGenerator) GenerateProofContext(ctx context.Context, proofType ProofType, vcfPath, provingKeyPath, outputPath string) (*ProofData, error) {
+	return pg.generateData(ctx, ProofRequest{
+		ProofType:      proofType,
+		VCFPath:        vcfPath,
+		ProvingKeyPath: provingKeyPath,
+		OutputPath:     outputPath,
+	})
+}
+
+// generateData runs Generate and returns only the proof data, as the
+// positional Generate* methods do
+func (pg *ProofGenerator) generateData(ctx context.Context, req ProofRequest) (*ProofData, error) {
+	response, err := pg.Generate(ctx, req)
+	if response == nil {
+		return nil, err
+	}
+	return response.ProofData, err
+}
+
+// generateCommitted checks every VCF against its commitment and generates
+// proof from the first, bound to binding if it is set and recording its
+// witness in debugWitness if that is set
+func (pg *ProofGenerator) generateCommitted(ctx context.Context, proof proofs.Proof, vcfPaths []string, outputPath string, binding *Binding, debugWitness *DebugWitness) (*ProofData, error) {
+	defer pg.registerIdentities(vcfPaths...)()
+	for _, vcfPath := range vcfPaths {
+		if err := pg.checkInputFormat(vcfPath); err != nil {
+			return nil, err
+		}
+		if err := proofs.CheckGenomeCommitmentContext(ctx, vcfPath); err != nil {
+			return nil, err
+		}
+	}
+	if len(pg.Liftover) > 0 {
+		lifted, cleanup, err := pg.liftOver(vcfPaths)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		if kinship, ok := proof.(*proofs.KinshipProof); ok && len(lifted) > 1 {
+			kinship.ParentVCF = lifted[1]
+		}
+		vcfPaths = lifted
+	}
+
+	if pg.generatesWithOptions(binding, debugWitness) {
+		backend, err := pg.backend()
+		if err != nil {
+			return nil, err
+		}
+		return proofs.GenerateWithOptionsContext(ctx, proof, vcfPaths[0], proofs.GenerateOptions{
+			Seed:         pg.Seed,
+			Binding:      binding,
+			DebugWitness: debugWitness,
+			Keys:         pg.Keys,
+			Cache:        pg.Cache,
+			Backend:      backend,
+			Logger:       pg.Logger,
+			Progress:     pg.Progress,
+			ProverOptions: proofs.ProverOptions{
+				Threads: pg.Threads,
+				Hints:   pg.SolverHints,
+				Prover:  pg.ExternalProver,
+			},
+		})
+	}
+	return proofs.GenerateContext(ctx, proof, vcfPaths[0], "", outputPath)
+}
+
+// registerIdentities lets pg.Identities decrypt the genomes at vcfPaths until
+// the returned function is called
+func (pg *ProofGenerator) registerIdentities(vcfPaths ...string) (release func()) {
+	var releases []func()
+	if len(pg.Identities) > 0 {
+		for _, vcfPath := range vcfPaths {
+			releases = append(releases, proofs.RegisterIdentities(vcfPath, pg.Identities...))
+		}
+	}
+	return func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+}
+
+// checkInputFormat refuses a genome not in pg.InputFormat, if that is set
+func (pg *ProofGenerator) checkInputFormat(vcfPath string) error {
+	if pg.InputFormat == "" {
+		return nil
+	}
+	format, err := proofs.DetectInputFormat(vcfPath)
+	if err != nil {
+		return err
+	}
+	if format != pg.InputFormat {
+		return fmt.Errorf("%w: %s is %s input, expected %s", proofs.ErrMalformedVCF, vcfPath, format, pg.InputFormat)
+	}
+	return nil
+}
+
+// liftOver writes the genomes at vcfPaths not already in pg.LiftoverBuild
+// lifted over to it through pg.Liftover, returning the paths to prove from.
+// cleanup removes the lifted genomes.
+func (pg *ProofGenerator) liftOver(vcfPaths []string) ([]string, func(), error) {
+	var spooled []func()
+	cleanup := func() {
+		for _, remove := range spooled {
+			remove()
+		}
+	}
+	lifted := make([]string, len(vcfPaths))
+	for i, vcfPath := range vcfPaths {
+		lifted[i] = vcfPath
+		if encrypted, err := proofs.IsEncrypted(vcfPath); err != nil || encrypted {
+			cleanup()
+			if err == nil {
+				err = fmt.Errorf("%w: %s cannot be lifted over without writing it decrypted", ErrEncryptedGenome, vcfPath)
+			}
+			return nil, nil, err
+		}
+		source, err := proofs.OpenGenomeSource(vcfPath, nil)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		if source.Build() == pg.LiftoverBuild {
+			continue
+		}
+
+		var buf bytes.Buffer
+		opts := genotools.Options{Liftover: pg.Liftover, TargetBuild: pg.LiftoverBuild}
+		if err := genotools.Liftover(&buf, vcfPath, opts); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("lifting %s over to %s: %w", vcfPath, pg.LiftoverBuild, err)
+		}
+		path, remove, err := proofs.SpoolVCF(&buf)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		spooled = append(spooled, remove)
+		lifted[i] = path
+	}
+	return lifted, cleanup, nil
+}
+
+// generatesWithOptions reports whether proofs are generated through
+// proofs.GenerateWithOptionsContext, which reads the VCF from a file
+func (pg *ProofGenerator) generatesWithOptions(binding *Binding, debugWitness *DebugWitness) bool {
+	return pg.Seed != nil || binding != nil || debugWitness != nil || pg.Keys != nil || pg.Cache != nil ||
+		(pg.Backend != nil && pg.Backend != Groth16) || (pg.Curve != 0 && pg.Curve != BN254) ||
+		pg.Threads != 0 || len(pg.SolverHints) > 0 || pg.ExternalProver != nil
+}
+
+// GenerateProofFromReader generates a proof of the specified type from a VCF
+// read from vcf, such as an in-memory genome or a network stream. A reader has
+// no commitment sidecar, so no commitment check is made.
+func (pg *ProofGenerator) GenerateProofFromReader(proofType ProofType, vcf io.Reader) (*ProofData, error) {
+	return pg.generateData(context.Background(), ProofRequest{ProofType: proofType, VCF: vcf})
+}
+
+// GenerateRsIDProof generates a proof of the genotype at the variant named by
+// rsID, such as "rs12913832". The rsID is resolved through the VCF ID column,
+// falling back to the bundled table of trait variants.
+func (pg *ProofGenerator) GenerateRsIDProof(rsID string, vcfPath, provingKeyPath, outputPath string) (*ProofData, error) {
+	return pg.GenerateRsIDProofContext(context.Background(), rsID, vcfPath, provingKeyPath, outputPath)
+}
+
+// GenerateRsIDProofContext is GenerateRsIDProof with cancellation, as for
+// GenerateProofContext
+func (pg *ProofGenerator) GenerateRsIDProofContext(ctx context.Context, rsID string, vcfPath, provingKeyPath, outputPath string) (*ProofData, error) {
+	return pg.generateData(ctx, ProofRequest{
+		Claim:          &ClaimSpec{ProofType: RsIDProofType, RsID: rsID},
+		VCFPath:        vcfPath,
+		ProvingKeyPath: provingKeyPath,
+		OutputPath:     outputPath,
+	})
+}
+
+// GenerateKinshipProof generates a proof that the genomes in childVCF and
+// parentVCF are consistent with a parent-child relationship over the default
+// kinship panel, revealing neither genome. Both VCFs must match their
+// commitments if they have been committed.
+func (pg *ProofGenerator) GenerateKinshipProof(childVCF, parentVCF, provingKeyPath, outputPath string) (*ProofData, error) {
+	return pg.GenerateKinshipProofContext(context.Background(), childVCF, parentVCF, provingKeyPath, outputPath)
+}
+
+// GenerateKinshipProofContext is GenerateKinshipProof with cancellation, as
+// for GenerateProofContext
+func (pg *ProofGenerator) GenerateKinshipProofContext(ctx context.Context, childVCF, parentVCF, provingKeyPath, outputPath string) (*ProofData, error) {
+	return pg.generateData(ctx, ProofRequest{
+		Claim:          &ClaimSpec{ProofType: KinshipProofType, ParentVCF: parentVCF},
+		VCFPath:        childVCF,
+		ProvingKeyPath: provingKeyPath,
+		OutputPath:     outputPath,
+	})
+}
+
+// GenerateAggregateProof generates a single proof covering every genotype
+// claim, such as eye color, lactase persistence and BRCA1 status together
+func (pg *ProofGenerator) GenerateAggregateProof(claims []AggregateClaim, vcfPath, provingKeyPath, outputPath string) (*ProofData, error) {
+	return pg.GenerateAggregateProofContext(context.Background(), claims, vcfPath, provingKeyPath, outputPath)
+}
+
+// GenerateAggregateProofContext is GenerateAggregateProof with cancellation,
+// as for GenerateProofContext
+func (pg *ProofGenerator) GenerateAggregateProofContext(ctx context.Context, claims []AggregateClaim, vcfPath, provingKeyPath, outputPath string) (*ProofData, error) {
+	return pg.generateData(ctx, ProofRequest{
+		Claim:          &ClaimSpec{ProofType: AggregateProofType, Claims: claims},
+		VCFPath:        vcfPath,
+		ProvingKeyPath: provingKeyPath,
+		OutputPath:     outputPath,
+	})
+}
+
+// ChromosomeCode maps a chromosome name such as "22", "chrX" or "MT" to the
+// code used by chromosome proofs, or 0 if it is not a placed chromosome
+func ChromosomeCode(name string) int {
+	return traits.ChromosomeCode(name)
+}
+
+// LocusHash returns the hash single-variant proofs disclose as their
+// LocusHash public input. Verifiers compare it with CheckPublicValues to
+// learn which locus a proof refers to. chromosome is a ChromosomeCode. The
+// hash is the one BN254 proofs disclose.
+func LocusHash(chromosome int, position uint64, ref, alt string) (*big.Int, error) {
+	return proofs.LocusHash(chromosome, position, ref, alt)
+}
+
+// LocusHashOn returns the LocusHash disclosed by proofs over curve
+func LocusHashOn(curve Curve, chromosome int, position uint64, ref, alt string) (*big.Int, error) {
+	return proofs.LocusHashOn(curve, chromosome, position, ref, alt)
+}
+
+// IsRsID reports whether s is a dbSNP reference SNP identifier such as "rs12913832"
+func IsRsID(s string) bool {
+	return traits.IsRsID(s)
+}
+
+// CommitGenome records a commitment to the current contents of the VCF. An
+// existing commitment is returned unchanged if it still matches; if the VCF has
+// changed a StaleCommitmentError is returned and Recommit must be used instead.
+func (pg *ProofGenerator) CommitGenome(vcfPath string) (*GenomeCommitment, error) {
+	return genotools.Commit(vcfPath, false)
+}
+
+// CommitGenomeMerkle commits the VCF along with a Merkle root over its
+// genotypes. The root is the public commitment that committed proofs are bound
+// to, so proofs sharing it are provably derived from the same genome. As with
+// CommitGenome, a changed VCF is refused with a StaleCommitmentError unless
+// recommit is set.
+func (pg *ProofGenerator) CommitGenomeMerkle(vcfPath string, recommit bool) (*GenomeCommitment, error) {
+	if !recommit {
+		if err := proofs.CheckGenomeCommitment(vcfPath); err != nil {
+			return nil, err
+		}
+	}
+	return proofs.CommitGenomeMerkle(vcfPath, pg.Progress)
+}
 
-	return proof.Generate(vcfPath, provingKeyPath, outputPath)
+// Recommit replaces the commitment for the VCF with one matching its current contents
+func (pg *ProofGenerator) Recommit(vcfPath string) (*GenomeCommitment, error) {
+	return proofs.Recommit(vcfPath)
 }
 
 // VerifyProof verifies a proof of the specified type and returns the verification result
 func (pg *ProofGenerator) VerifyProof(proofType ProofType, verifyingKeyPath, proofPath string) (*VerificationResult, error) {
-	var proof proofs.Proof
+	return pg.VerifyProofContext(context.Background(), proofType, verifyingKeyPath, proofPath)
+}
 
-	switch proofType {
-	case ChromosomeProofType:
-		proof = &proofs.ChromosomeProof{}
-	case EyeColorProofType:
-		proof = &proofs.EyeColorProof{}
-	case BRCA1ProofType:
-		proof = &proofs.BRCA1Proof{}
-	case HERC2ProofType:
-		proof = &proofs.HERC2Proof{}
-	case DynamicProofType:
-		proof = &proofs.DynamicProof{}
-	default:
-		return nil, &UnsupportedProofTypeError{Type: string(proofType)}
+// VerifyProofContext is VerifyProof with cancellation: it returns ctx.Err()
+// as soon as ctx is done
+func (pg *ProofGenerator) VerifyProofContext(ctx context.Context, proofType ProofType, verifyingKeyPath, proofPath string) (*VerificationResult, error) {
+	if _, err := pg.newProof(proofType); err != nil {
+		return nil, err
 	}
 
-	return proof.Verify(verifyingKeyPath, proofPath)
+	proofData, err := proofs.ReadProofData(proofPath)
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return nil, err
+	}
+	if err != nil {
+		// A file that is not a proof proves nothing
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	if verifyingKeyPath == "" {
+		return pg.VerifyProofDataContext(ctx, proofType, proofData)
+	}
+	vkBytes, err := os.ReadFile(verifyingKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading verifying key: %w", err)
+	}
+	return pg.verifyWithKey(ctx, proofType, proofData, vkBytes)
 }
 
-// VerifyProofData verifies a proof directly from ProofData without file operations
-func (pg *ProofGenerator) VerifyProofData(proofType ProofType, proofData *ProofData) (*VerificationResult, error) {
-	var proof proofs.Proof
+// VerifyProofFromReaders verifies a JSON-encoded proof read from proof like
+// VerifyProof. A non-nil verifyingKey replaces the verifying key bundled in
+// the proof.
+func (pg *ProofGenerator) VerifyProofFromReaders(proofType ProofType, verifyingKey io.Reader, proof io.Reader) (*VerificationResult, error) {
+	proofData, err := proofs.DecodeProofData(proof)
+	if err != nil {
+		return nil, err
+	}
 
-	switch proofType {
-	case ChromosomeProofType:
-		proof = &proofs.ChromosomeProof{}
-	case EyeColorProofType:
-		proof = &proofs.EyeColorProof{}
-	case BRCA1ProofType:
-		proof = &proofs.BRCA1Proof{}
-	case HERC2ProofType:
-		proof = &proofs.HERC2Proof{}
-	case DynamicProofType:
-		proof = &proofs.DynamicProof{}
-	default:
-		return nil, &UnsupportedProofTypeError{Type: string(proofType)}
+	if verifyingKey != nil {
+		vkBytes, err := io.ReadAll(verifyingKey)
+		if err != nil {
+			return nil, fmt.Errorf("reading verifying key: %w", err)
+		}
+		return pg.verifyWithKey(context.Background(), proofType, proofData, vkBytes)
 	}
 
-	return proof.VerifyProofData(proofData)
+	return pg.VerifyProofData(proofType, proofData)
 }
 
-// VerifyAnyProofData attempts to verify ProofData by trying all supported proof types
-// This is useful when the proof type is unknown or not stored with the proof
-func (pg *ProofGenerator) VerifyAnyProofData(proofData *ProofData) (ProofType, *VerificationResult, error) {
-	supportedTypes := pg.GetSupportedProofTypes()
-	
-	for _, proofType := range supportedTypes {
-		result, err := pg.VerifyProofData(proofType, proofData)
+// VerifyProofWithKey verifies proofData like VerifyProofData, against the
+// verifying key supplied by verifyingKey instead of the one bundled in it
+func (pg *ProofGenerator) VerifyProofWithKey(ctx context.Context, proofType ProofType, proofData *ProofData, verifyingKey KeyProvider) (*VerificationResult, error) {
+	vkBytes, err := proofs.ReadKey(ctx, verifyingKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading verifying key: %w", err)
+	}
+	return pg.verifyWithKey(ctx, proofType, proofData, vkBytes)
+}
+
+// verifyWithKey verifies proofData against vk, a key the caller supplied and
+// so trusts, instead of the keys registered with the generator
+func (pg *ProofGenerator) verifyWithKey(ctx context.Context, proofType ProofType, proofData *ProofData, vk []byte) (*VerificationResult, error) {
+	registry := NewVerifyingKeyRegistry()
+	if err := registry.Register(proofType, vk); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	keyed := *pg
+	keyed.KeyRegistry = registry
+	return keyed.VerifyProofDataContext(ctx, proofType, proofData)
+}
+
+// VerifyProofData verifies a proof directly from ProofData without file
+// operations. It runs VerifyCryptographic followed by VerifyTrust with the
+// generator's advisory settings.
+func (pg *ProofGenerator) VerifyProofData(proofType ProofType, proofData *ProofData) (*VerificationResult, error) {
+	return pg.VerifyProofDataContext(context.Background(), proofType, proofData)
+}
+
+// VerifyProofDataContext is VerifyProofData with cancellation: it returns
+// ctx.Err() as soon as ctx is done
+func (pg *ProofGenerator) VerifyProofDataContext(ctx context.Context, proofType ProofType, proofData *ProofData) (*VerificationResult, error) {
+	proof, err := pg.newProof(proofType)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProofType(proofType, proofData); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+
+	// A proof carrying a key that is not registered is checked against the
+	// registered keys instead, so the prover cannot choose the key
+	candidates := []*ProofData{proofData}
+	if pg.KeyRegistry != nil && !pg.KeyRegistry.Trusts(proofType, proofData.VerifyingKey) {
+		if keys := pg.KeyRegistry.Keys(proofType); len(keys) > 0 {
+			candidates = candidates[:0]
+			for _, vk := range keys {
+				keyed := *proofData
+				keyed.VerifyingKey = vk
+				candidates = append(candidates, &keyed)
+			}
+		}
+	}
+	var result *VerificationResult
+	for _, candidate := range candidates {
+		result, err = proofs.VerifyProofDataContext(ctx, proof, candidate)
 		if err != nil {
-			continue // Try next proof type
+			return result, err
 		}
-		
 		if result.Result == ProofSuccess {
+			proofData = candidate
+			break
+		}
+	}
+	if result.Result != ProofSuccess {
+		return result, nil
+	}
+	if err := proofs.CheckParameters(proofData); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	trust, err := pg.VerifyTrust(proofType, proofData, pg.trustPolicy())
+	if err != nil || trust.Result != ProofSuccess {
+		return trust, err
+	}
+	pg.logger().Infof("✅ %s proof successfully verified!", proofType)
+	return result, nil
+}
+
+// logger returns the generator's Logger, or one discarding every message
+func (pg *ProofGenerator) logger() Logger {
+	if pg.Logger == nil {
+		return proofs.NopLogger{}
+	}
+	return pg.Logger
+}
+
+// VerifyProofDataWithNonce verifies proofData like VerifyProofData, and
+// fails it with ErrNonceMismatch unless it is bound to nonce, the challenge
+// this verifier issued for it
+func (pg *ProofGenerator) VerifyProofDataWithNonce(proofType ProofType, proofData *ProofData, nonce []byte) (*VerificationResult, error) {
+	return verifyWithNonce(pg.VerifyProofData, proofType, proofData, nonce)
+}
+
+// verifyWithNonce verifies proofData with verify, then checks its nonce
+func verifyWithNonce(verify func(ProofType, *ProofData) (*VerificationResult, error), proofType ProofType, proofData *ProofData, nonce []byte) (*VerificationResult, error) {
+	result, err := verify(proofType, proofData)
+	if err != nil || result.Result != ProofSuccess {
+		return result, err
+	}
+	if err := proofs.CheckNonce(proofData, nonce); err != nil {
+		return &VerificationResult{Result: ProofFail, Error: err}, nil
+	}
+	return result, nil
+}
+
+// checkProofType returns a ProofTypeMismatchError if proofData records a
+// proof type other than proofType. Proofs that record none are not checked.
+func checkProofType(proofType ProofType, proofData *ProofData) error {
+	if proofData.ProofType != "" && proofData.ProofType != string(proofType) {
+		return &ProofTypeMismatchError{Expected: string(proofType), Actual: proofData.ProofType}
+	}
+	return nil
+}
+
+// VerifyAnyProofData verifies ProofData without being told its proof type.
+// Proofs that record their type are verified as that type; older proofs are
+// tried against every supported type. If none verifies, the result carries an
+// AmbiguousProofError listing why each type failed.
+func (pg *ProofGenerator) VerifyAnyProofData(proofData *ProofData) (ProofType, *VerificationResult, error) {
+	return verifyAny(proofData, pg.GetSupportedProofTypes(), pg.VerifyProofData)
+}
+
+// verifyAny verifies proofData with verify as the type it records, or else as
+// each of candidates in turn
+func verifyAny(proofData *ProofData, candidates []ProofType, verify func(ProofType, *ProofData) (*VerificationResult, error)) (ProofType, *VerificationResult, error) {
+	if proofData.ProofType != "" {
+		proofType := ProofType(proofData.ProofType)
+		result, err := verify(proofType, proofData)
+		if err != nil || result.Result != ProofSuccess {
+			return "", result, err
+		}
+		return proofType, result, nil
+	}
+
+	ambiguous := &AmbiguousProofError{}
+	for _, proofType := range candidates {
+		result, err := verify(proofType, proofData)
+		if err == nil && result.Result == ProofSuccess {
 			return proofType, result, nil
 		}
+		if err == nil {
+			err = result.Error
+		}
+		ambiguous.Failures = append(ambiguous.Failures, ProofTypeFailure{ProofType: proofType, Err: err})
 	}
-	
+
 	return "", &VerificationResult{
 		Result: ProofFail,
-		Error:  fmt.Errorf("proof verification failed for all supported types"),
+		Error:  ambiguous,
 	}, nil
 }
 
@@ -133,6 +817,26 @@ func (pg *ProofGenerator) GetSupportedProofTypes() []ProofType {
 		BRCA1ProofType,
 		HERC2ProofType,
 		DynamicProofType,
+		BloodTypeProofType,
+		CohortProofType,
+		CYP2D6ProofType,
+		ACTN3ProofType,
+		ALDH2ProofType,
+		CCR5ProofType,
+		MTHFRProofType,
+		BRCA2ProofType,
+		BurdenProofType,
+		ABCC11ProofType,
+		SexChromosomeProofType,
+		RsIDProofType,
+		NegativeProofType,
+		KinshipProofType,
+		AggregateProofType,
+		CommittedProofType,
+		CarrierProofType,
+		RegionCountProofType,
+		PhaseProofType,
+		CustomProofType,
 	}
 }
 
@@ -143,4 +847,73 @@ type TraitVariant = traits.TraitVariant
 type TraitRegion = traits.TraitRegion
 
 // TraitPanel re-exports the trait panel structure for convenience
-type TraitPanel = traits.TraitPanel
\ No newline at end of file
+type TraitPanel = traits.TraitPanel
+
+// CustomTrait re-exports a user-defined trait for convenience
+type CustomTrait = traits.CustomTrait
+
+// LoadCustomTrait reads and validates a JSON or YAML custom trait, proven as
+// a CustomProofType claim
+func LoadCustomTrait(path string) (*CustomTrait, error) {
+	return traits.LoadCustomTrait(path)
+}
+
+// TraitRegistry re-exports the registry trait proof types read their loci
+// from for convenience
+type TraitRegistry = traits.Registry
+
+// TraitDefinition re-exports a registry's trait definition for convenience
+type TraitDefinition = traits.TraitDefinition
+
+// LoadTraitRegistry returns the registry of the built-in traits with the
+// definitions in the JSON or YAML files at paths added, for WithTraitRegistry
+func LoadTraitRegistry(paths ...string) (*TraitRegistry, error) {
+	return traits.LoadRegistry(paths...)
+}
+
+// ClaimVocabulary re-exports the public encoding of a trait claim for
+// convenience
+type ClaimVocabulary = traits.ClaimVocabulary
+
+// ClaimVocabularies returns the public encoding of every trait claim, such as
+// the eye colors or metabolizer statuses a proof can disclose
+func ClaimVocabularies() []ClaimVocabulary {
+	return traits.ClaimVocabularies()
+}
+
+// DecodeClaim returns the label of the trait claim proofData discloses, such
+// as "blue" for an eye color proof
+func DecodeClaim(proofData *ProofData) (string, error) {
+	return proofs.DecodeClaim(proofData)
+}
+
+// GenomeBuild re-exports the reference assembly identifier for convenience
+type GenomeBuild = traits.GenomeBuild
+
+// Genome builds, re-exported for convenience
+const (
+	BuildUnknown = traits.BuildUnknown
+	BuildGRCh37  = traits.BuildGRCh37
+	BuildGRCh38  = traits.BuildGRCh38
+)
+
+// LiftoverBlock re-exports the block of coordinates lifted between builds
+type LiftoverBlock = proofs.LiftoverBlock
+
+// BloodGroup re-exports the ABO blood group encoding for convenience
+type BloodGroup = traits.BloodGroup
+
+// MetabolizerStatus re-exports the CYP2D6 metabolizer encoding for convenience
+type MetabolizerStatus = traits.MetabolizerStatus
+
+// ACTN3Genotype re-exports the ACTN3 R577X encoding for convenience
+type ACTN3Genotype = traits.ACTN3Genotype
+
+// EarwaxType re-exports the ABCC11 earwax type encoding for convenience
+type EarwaxType = traits.EarwaxType
+
+// Karyotype re-exports the sex chromosome configuration encoding for convenience
+type Karyotype = traits.Karyotype
+
+// MTHFRStatus re-exports the joint MTHFR status encoding for convenience
+type MTHFRStatus = traits.MTHFRStatus